@@ -0,0 +1,42 @@
+package serviceimpl
+
+import (
+	"context"
+
+	"gofiber-template/domain/ports"
+	"gofiber-template/pkg/logger"
+)
+
+// buildCDNPurge สร้าง purge request ตามความสามารถของ CDN (nil = ไม่ได้ตั้ง purger)
+// CDN ไม่รองรับ purge by prefix → list ไฟล์ใน folder จาก storage แทน (ต้องเรียกก่อนลบไฟล์)
+func buildCDNPurge(ctx context.Context, purger ports.CDNPurgerPort, storage ports.StoragePort, files, prefixes []string) *ports.CDNPurgeRequest {
+	if purger == nil || !purger.IsEnabled() {
+		return nil
+	}
+
+	req := &ports.CDNPurgeRequest{Files: files}
+	if purger.SupportsPrefix() {
+		req.Prefixes = prefixes
+		return req
+	}
+
+	for _, prefix := range prefixes {
+		listed, err := storage.ListFiles(prefix)
+		if err != nil {
+			logger.WarnContext(ctx, "Failed to list files for CDN purge", "prefix", prefix, "error", err)
+			continue
+		}
+		req.Files = append(req.Files, listed...)
+	}
+	return req
+}
+
+// purgeCDN ส่ง purge request - ล้มเหลว = warn เท่านั้น (edge cache หมดเองตาม TTL)
+func purgeCDN(ctx context.Context, purger ports.CDNPurgerPort, req *ports.CDNPurgeRequest, reason string) {
+	if purger == nil || req.IsEmpty() {
+		return
+	}
+	if err := purger.Purge(ctx, req); err != nil {
+		logger.WarnContext(ctx, "CDN purge failed", "reason", reason, "error", err)
+	}
+}
@@ -0,0 +1,223 @@
+package serviceimpl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/ports"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/logger"
+	"gofiber-template/pkg/scheduler"
+)
+
+// GalleryReconcileConfig การตั้งค่า gallery reconcile
+type GalleryReconcileConfig struct {
+	Cron      string // cron expression (default: "30 4 * * *" = 04:30 ทุกวัน, "" ตอนลงทะเบียน = ปิด)
+	BatchSize int    // จำนวนวิดีโอต่อหน้า (default: 100)
+}
+
+// GalleryReconcileServiceImpl นับไฟล์จริงใน storage แล้วแก้ gallery counts ใน DB
+type GalleryReconcileServiceImpl struct {
+	config    GalleryReconcileConfig
+	videoRepo repositories.VideoRepository
+	storage   ports.StoragePort
+	scheduler scheduler.EventScheduler
+	running   atomic.Bool // reconcile ทั้งระบบทำได้ทีละรอบ (cron + admin trigger)
+}
+
+var _ services.GalleryReconcileService = (*GalleryReconcileServiceImpl)(nil)
+
+// NewGalleryReconcileService สร้าง service ใหม่
+func NewGalleryReconcileService(
+	config GalleryReconcileConfig,
+	videoRepo repositories.VideoRepository,
+	storage ports.StoragePort,
+	eventScheduler scheduler.EventScheduler,
+) *GalleryReconcileServiceImpl {
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+
+	return &GalleryReconcileServiceImpl{
+		config:    config,
+		videoRepo: videoRepo,
+		storage:   storage,
+		scheduler: eventScheduler,
+	}
+}
+
+// RegisterReconcileJob ลงทะเบียน reconcile job กับ scheduler (Cron ว่าง = ไม่ลงทะเบียน)
+func (s *GalleryReconcileServiceImpl) RegisterReconcileJob() error {
+	if s.config.Cron == "" {
+		return nil
+	}
+	return s.scheduler.AddJob("gallery_reconcile", s.config.Cron, func() {
+		ctx := context.Background()
+		_, err := s.ReconcileAll(ctx)
+		switch {
+		case errors.Is(err, ErrGalleryReconcileRunning):
+			logger.InfoContext(ctx, "Gallery reconcile skipped - previous run still in progress")
+		case err != nil:
+			logger.ErrorContext(ctx, "Gallery reconcile failed", "error", err)
+		}
+	})
+}
+
+// ErrVideoHasNoGallery video ยังไม่มี gallery ให้ reconcile
+var ErrVideoHasNoGallery = errors.New("video has no gallery")
+
+// ErrGalleryReconcileRunning มี reconcile ทั้งระบบกำลังทำงานอยู่
+var ErrGalleryReconcileRunning = errors.New("gallery reconcile already running")
+
+// ReconcileVideo reconcile gallery counts ของวิดีโอเดียว
+func (s *GalleryReconcileServiceImpl) ReconcileVideo(ctx context.Context, videoID uuid.UUID) (*dto.GalleryReconcileResult, error) {
+	video, err := findVideo(ctx, s.videoRepo, videoID)
+	if err != nil {
+		return nil, err
+	}
+	if video.GalleryPath == "" {
+		return nil, ErrVideoHasNoGallery
+	}
+
+	return s.reconcile(ctx, video)
+}
+
+// StartReconcileAll เริ่ม ReconcileAll ใน background (list storage ทุกวิดีโอใช้เวลานานเกิน request timeout)
+// ผลลัพธ์ดูได้จาก log "Gallery reconcile completed"
+func (s *GalleryReconcileServiceImpl) StartReconcileAll(ctx context.Context) error {
+	if !s.running.CompareAndSwap(false, true) {
+		return ErrGalleryReconcileRunning
+	}
+
+	logger.InfoContext(ctx, "Gallery reconcile started in background")
+
+	go func() {
+		defer s.running.Store(false)
+
+		bgCtx := context.Background()
+		if _, err := s.reconcileAll(bgCtx); err != nil {
+			logger.ErrorContext(bgCtx, "Gallery reconcile failed", "error", err)
+		}
+	}()
+	return nil
+}
+
+// ReconcileAll reconcile ทุกวิดีโอที่มี gallery ทีละหน้า - วิดีโอที่ล้มเหลวนับใน Failed แล้วทำต่อ
+func (s *GalleryReconcileServiceImpl) ReconcileAll(ctx context.Context) (*dto.GalleryReconcileSummary, error) {
+	if !s.running.CompareAndSwap(false, true) {
+		return nil, ErrGalleryReconcileRunning
+	}
+	defer s.running.Store(false)
+
+	return s.reconcileAll(ctx)
+}
+
+func (s *GalleryReconcileServiceImpl) reconcileAll(ctx context.Context) (*dto.GalleryReconcileSummary, error) {
+	summary := &dto.GalleryReconcileSummary{Results: []*dto.GalleryReconcileResult{}}
+
+	for offset := 0; ; offset += s.config.BatchSize {
+		videos, _, err := s.videoRepo.ListWithGallery(ctx, offset, s.config.BatchSize)
+		if err != nil {
+			return summary, fmt.Errorf("failed to list videos with gallery: %w", err)
+		}
+
+		for _, video := range videos {
+			if ctx.Err() != nil {
+				return summary, ctx.Err()
+			}
+
+			summary.Scanned++
+			result, err := s.reconcile(ctx, video)
+			if err != nil {
+				summary.Failed++
+				logger.WarnContext(ctx, "Failed to reconcile gallery counts", "video_id", video.ID, "error", err)
+				continue
+			}
+			if result.Changed {
+				summary.Fixed++
+				summary.Results = append(summary.Results, result)
+			}
+		}
+
+		if len(videos) < s.config.BatchSize {
+			break
+		}
+	}
+
+	logger.InfoContext(ctx, "Gallery reconcile completed",
+		"scanned", summary.Scanned,
+		"fixed", summary.Fixed,
+		"failed", summary.Failed,
+	)
+	return summary, nil
+}
+
+// reconcile นับไฟล์แล้วเขียน DB เฉพาะเมื่อ counts ไม่ตรง
+// list folder ไหนไม่ได้ = ไม่แก้ (กันเขียน 0 ทับ counts จริงตอน storage มีปัญหา)
+func (s *GalleryReconcileServiceImpl) reconcile(ctx context.Context, video *models.Video) (*dto.GalleryReconcileResult, error) {
+	after, err := s.countGalleryFiles(video.GalleryPath)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.GalleryReconcileResult{
+		VideoID:   video.ID,
+		VideoCode: video.Code,
+		Before: dto.GalleryCounts{
+			Source:    video.GallerySourceCount,
+			SuperSafe: video.GallerySuperSafeCount,
+			Safe:      video.GallerySafeCount,
+			Nsfw:      video.GalleryNsfwCount,
+			Total:     video.GalleryCount,
+		},
+		After: after,
+	}
+	result.Changed = result.Before != result.After
+	if !result.Changed {
+		return result, nil
+	}
+
+	if err := s.videoRepo.UpdateGalleryCounts(ctx, video.ID, after); err != nil {
+		return nil, fmt.Errorf("failed to update gallery counts: %w", err)
+	}
+
+	logger.InfoContext(ctx, "Gallery counts reconciled",
+		"video_id", video.ID,
+		"video_code", video.Code,
+		"before", result.Before,
+		"after", result.After,
+	)
+	return result, nil
+}
+
+// countGalleryFiles นับไฟล์ใน gallery/<code>/{source,super_safe,safe,nsfw} แล้วคำนวณ Total แบบ admin updateGalleryCounts
+func (s *GalleryReconcileServiceImpl) countGalleryFiles(galleryPath string) (dto.GalleryCounts, error) {
+	basePath := strings.TrimSuffix(galleryPath, "/")
+
+	var source, superSafe, safe, nsfw int
+	folders := []struct {
+		name  string
+		count *int
+	}{
+		{"source", &source},
+		{models.GalleryTierSuperSafe, &superSafe},
+		{models.GalleryTierSafe, &safe},
+		{models.GalleryTierNsfw, &nsfw},
+	}
+	for _, folder := range folders {
+		files, err := s.storage.ListFiles(fmt.Sprintf("%s/%s", basePath, folder.name))
+		if err != nil {
+			return dto.GalleryCounts{}, fmt.Errorf("failed to list %s/%s: %w", basePath, folder.name, err)
+		}
+		*folder.count = len(files)
+	}
+	return dto.NewGalleryCounts(source, superSafe, safe, nsfw), nil
+}
@@ -0,0 +1,61 @@
+package serviceimpl
+
+import (
+	"context"
+
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/logger"
+)
+
+// Default ของ classification stats
+const (
+	defaultOutlierMinNsfwScore = 0.10 // super_safe ต้อง < 0.15 → >= 0.10 คือเฉียด threshold
+	defaultOutlierLimit        = 50
+)
+
+// GalleryStatsServiceImpl สถิติ classification จาก gallery_image_scores
+type GalleryStatsServiceImpl struct {
+	scoreRepo repositories.GalleryImageScoreRepository
+}
+
+var _ services.GalleryStatsService = (*GalleryStatsServiceImpl)(nil)
+
+func NewGalleryStatsService(scoreRepo repositories.GalleryImageScoreRepository) services.GalleryStatsService {
+	return &GalleryStatsServiceImpl{
+		scoreRepo: scoreRepo,
+	}
+}
+
+// GetClassificationStats สถิติ classification รวมทุก gallery (ใช้ปรับ threshold ของ classifier)
+func (s *GalleryStatsServiceImpl) GetClassificationStats(ctx context.Context, req *dto.ClassificationStatsRequest) (*dto.ClassificationStatsResponse, error) {
+	minNsfwScore := req.MinNsfwScore
+	if minNsfwScore <= 0 {
+		minNsfwScore = defaultOutlierMinNsfwScore
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultOutlierLimit
+	}
+
+	tiers, err := s.scoreRepo.GetTierStats(ctx)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to get classification tier stats", "error", err)
+		return nil, err
+	}
+
+	outliers, err := s.scoreRepo.GetSuperSafeOutliers(ctx, minNsfwScore, limit)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to get classification outliers", "error", err)
+		return nil, err
+	}
+
+	outlierCount, err := s.scoreRepo.CountSuperSafeOutliers(ctx, minNsfwScore)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to count classification outliers", "error", err)
+		return nil, err
+	}
+
+	return dto.ToClassificationStatsResponse(tiers, outliers, outlierCount, minNsfwScore), nil
+}
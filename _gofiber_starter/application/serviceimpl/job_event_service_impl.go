@@ -0,0 +1,57 @@
+package serviceimpl
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/logger"
+)
+
+type JobEventServiceImpl struct {
+	jobEventRepo repositories.JobEventRepository
+	dlqRepo      repositories.DLQEntryRepository
+	videoRepo    repositories.VideoRepository
+}
+
+func NewJobEventService(jobEventRepo repositories.JobEventRepository, dlqRepo repositories.DLQEntryRepository, videoRepo repositories.VideoRepository) services.JobEventService {
+	return &JobEventServiceImpl{
+		jobEventRepo: jobEventRepo,
+		dlqRepo:      dlqRepo,
+		videoRepo:    videoRepo,
+	}
+}
+
+// GetJobTimeline ดึง timeline ของทุก pipeline ของ video
+func (s *JobEventServiceImpl) GetJobTimeline(ctx context.Context, videoID uuid.UUID) (*dto.JobTimelineResponse, error) {
+	if _, err := findVideo(ctx, s.videoRepo, videoID); err != nil {
+		return nil, err
+	}
+
+	events, err := s.jobEventRepo.GetByVideoID(ctx, videoID)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to get job events", "video_id", videoID, "error", err)
+		return nil, err
+	}
+
+	return dto.JobEventsToTimelineResponse(videoID, events), nil
+}
+
+// GetDLQJobDetail ดึง video ใน DLQ พร้อม job payload ที่ทำให้ fail
+func (s *JobEventServiceImpl) GetDLQJobDetail(ctx context.Context, videoID uuid.UUID) (*dto.DLQJobDetailResponse, error) {
+	video, err := findVideo(ctx, s.videoRepo, videoID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.dlqRepo.GetByVideoID(ctx, videoID)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to get DLQ entries", "video_id", videoID, "error", err)
+		return nil, err
+	}
+
+	return dto.DLQEntriesToDetailResponse(video, entries), nil
+}
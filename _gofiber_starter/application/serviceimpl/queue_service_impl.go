@@ -2,17 +2,35 @@ package serviceimpl
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"gofiber-template/domain/dto"
 	"gofiber-template/domain/models"
+	"gofiber-template/domain/ports"
 	"gofiber-template/domain/repositories"
 	"gofiber-template/domain/services"
 	"gofiber-template/infrastructure/nats"
 	"gofiber-template/pkg/logger"
 )
 
+// Job cancel errors
+var (
+	ErrJobCancelUnavailable = errors.New("job cancellation not available")
+	ErrUnsupportedJobType   = errors.New("unsupported job type")
+	ErrNoActiveJob          = errors.New("no active job")
+	// ErrTranscodeInProgress transcode worker ไม่ได้ดู cancel flag - หยุดกลางทางไม่ได้
+	ErrTranscodeInProgress = errors.New("transcode already in progress and cannot be cancelled")
+)
+
+// Priority bump errors (job ที่ worker ดึงไปแล้ว = ports.ErrJobInFlight)
+var (
+	ErrPriorityQueuesDisabled = errors.New("priority queues are disabled")
+	ErrJobNotBumpable         = errors.New("job cannot be bumped")
+)
+
 // WarmCachePublisher interface สำหรับส่ง warm cache jobs
 type WarmCachePublisher interface {
 	PublishWarmCacheJob(ctx context.Context, job *nats.WarmCacheJob) error
@@ -23,11 +41,48 @@ type SubtitleStreamPurger interface {
 	PurgeSubtitleStream(ctx context.Context) (uint64, error)
 }
 
+// Gallery backfill limits
+const (
+	galleryBackfillDefaultJobs = 50                     // จำนวน jobs ต่อรอบ (default)
+	galleryBackfillMaxJobs     = 200                    // จำนวน jobs สูงสุดต่อรอบ
+	galleryBackfillInterval    = 100 * time.Millisecond // เว้นระยะระหว่าง publish (ไม่ให้ worker queue ล้น)
+)
+
+// SEO bulk enqueue limits
+const (
+	seoEnqueueDefaultJobs = 50                     // จำนวน jobs ต่อรอบ (default)
+	seoEnqueueMaxJobs     = 500                    // จำนวน jobs สูงสุดต่อรอบ
+	seoEnqueueInterval    = 200 * time.Millisecond // เว้นระยะระหว่าง publish (Gemini quota ของ SEO worker)
+	seoEnqueueDedupWindow = 24 * time.Hour         // มี SEO job event ในช่วงนี้ = queued/กำลังทำ/เพิ่งทำ → ข้าม
+)
+
+// SEOJobPublisher interface สำหรับส่ง SEO article jobs
+type SEOJobPublisher interface {
+	PublishSEOArticleJob(ctx context.Context, job *nats.SEOArticleJob) error
+}
+
 // GalleryJobPublisher interface สำหรับส่ง gallery jobs
 type GalleryJobPublisher interface {
 	PublishGalleryJob(ctx context.Context, job *nats.GalleryJob) error
 }
 
+// QueuedJobRemover interface สำหรับลบ job ที่ยังรออยู่ในคิวปกติ (ใช้ตอน bump priority)
+type QueuedJobRemover interface {
+	RemoveQueuedTranscodeJobs(ctx context.Context, videoID string) (int, error)
+	RemoveQueuedGalleryJobs(ctx context.Context, videoID string) (int, error)
+}
+
+// JobCanceller interface สำหรับยกเลิก job (ลบจากคิว + ตั้ง flag ให้ worker ที่กำลังทำอยู่หยุด)
+type JobCanceller interface {
+	QueuedJobRemover
+	RequestJobCancel(ctx context.Context, jobType, videoID string) error
+}
+
+// GalleryLockReleaser ปล่อย gallery lock ของวิดีโอ (optional - เรียกเมื่อ job ที่ถือ lock "queued" ถูกลบจากคิวตอน cancel/bump)
+type GalleryLockReleaser interface {
+	ReleaseGalleryLock(ctx context.Context, videoCode string) error
+}
+
 type QueueServiceImpl struct {
 	videoRepo            repositories.VideoRepository
 	subtitleRepo         repositories.SubtitleRepository
@@ -38,6 +93,11 @@ type QueueServiceImpl struct {
 	warmCachePublisher   WarmCachePublisher
 	galleryJobPublisher  GalleryJobPublisher
 	subtitleStreamPurger SubtitleStreamPurger
+	galleryQuality       string           // HLS variant ที่ต้องการสำหรับ gallery ("" = สูงสุด)
+	queuedJobRemover     QueuedJobRemover // nil = ไม่เปิด priority queue (bump ไม่ได้)
+	jobCanceller         JobCanceller     // nil = ยกเลิก job ไม่ได้ (ไม่มี NATS)
+	seoJobPublisher      SEOJobPublisher  // nil = bulk SEO enqueue ไม่ได้ (ไม่มี NATS)
+	jobEventRepo         repositories.JobEventRepository
 }
 
 func NewQueueService(
@@ -64,6 +124,27 @@ func NewQueueService(
 	}
 }
 
+// SetGalleryQuality ตั้ง HLS variant ที่ใช้สร้าง gallery (ไม่ตั้ง = quality สูงสุด)
+func (s *QueueServiceImpl) SetGalleryQuality(quality string) {
+	s.galleryQuality = quality
+}
+
+// SetQueuedJobRemover เปิดใช้ bump priority (ต้องเปิด priority subject ที่ publisher ด้วย)
+func (s *QueueServiceImpl) SetQueuedJobRemover(remover QueuedJobRemover) {
+	s.queuedJobRemover = remover
+}
+
+// SetJobCanceller เปิดใช้การยกเลิก job
+func (s *QueueServiceImpl) SetJobCanceller(canceller JobCanceller) {
+	s.jobCanceller = canceller
+}
+
+// SetSEOJobPublisher เปิดใช้ bulk SEO enqueue (jobEventRepo บันทึก event "queued" กัน enqueue ซ้ำ)
+func (s *QueueServiceImpl) SetSEOJobPublisher(publisher SEOJobPublisher, jobEventRepo repositories.JobEventRepository) {
+	s.seoJobPublisher = publisher
+	s.jobEventRepo = jobEventRepo
+}
+
 // === Stats ===
 
 func (s *QueueServiceImpl) GetQueueStats(ctx context.Context) (*dto.QueueStatsResponse, error) {
@@ -258,6 +339,178 @@ func (s *QueueServiceImpl) RetryTranscodeOne(ctx context.Context, videoID uuid.U
 	return s.transcodingService.QueueTranscoding(ctx, videoID)
 }
 
+// BumpTranscode ลบ transcode job เดิมจากคิวปกติแล้ว queue ใหม่แบบ urgent
+func (s *QueueServiceImpl) BumpTranscode(ctx context.Context, videoID uuid.UUID) (*dto.BumpPriorityResponse, error) {
+	if s.queuedJobRemover == nil {
+		return nil, ErrPriorityQueuesDisabled
+	}
+
+	video, err := findVideo(ctx, s.videoRepo, videoID)
+	if err != nil {
+		return nil, err
+	}
+	if video.Status != models.VideoStatusPending && video.Status != models.VideoStatusQueued {
+		return nil, fmt.Errorf("%w: video is not waiting for transcode (status: %s)", ErrJobNotBumpable, video.Status)
+	}
+
+	// worker ดึงไปแล้ว = ไม่ queue ซ้ำ (job ที่ค้างในคิวซ้ำถูกลบไปแล้ว)
+	removed, err := s.queuedJobRemover.RemoveQueuedTranscodeJobs(ctx, videoID.String())
+	if errors.Is(err, ports.ErrJobInFlight) {
+		return nil, err
+	}
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to remove queued transcode job", "video_id", videoID, "error", err)
+		return nil, fmt.Errorf("failed to remove queued job: %w", err)
+	}
+
+	if err := s.transcodingService.QueueTranscodingWithPriority(ctx, videoID, ports.JobPriorityUrgent); err != nil {
+		return nil, err
+	}
+
+	logger.InfoContext(ctx, "Transcode job bumped to priority queue",
+		"video_id", videoID,
+		"video_code", video.Code,
+		"removed_jobs", removed,
+	)
+
+	return &dto.BumpPriorityResponse{
+		VideoID:     video.ID,
+		VideoCode:   video.Code,
+		Queue:       "transcode",
+		Priority:    ports.JobPriorityUrgent,
+		RemovedJobs: removed,
+		Message:     "Transcode job moved to priority queue",
+	}, nil
+}
+
+// === Cancel ===
+
+// CancelJob ยกเลิก job ของ video
+// job ที่ยังรอคิว = ลบออกแล้ว cancelled ทันที, กำลังทำอยู่ = ตั้ง flag ให้ worker หยุดที่ stage ถัดไป
+// แล้วเปลี่ยน status เป็น cancelled เองหลัง cleanup
+func (s *QueueServiceImpl) CancelJob(ctx context.Context, videoID uuid.UUID, jobType string) (*dto.CancelJobResponse, error) {
+	if s.jobCanceller == nil {
+		return nil, ErrJobCancelUnavailable
+	}
+
+	video, err := findVideo(ctx, s.videoRepo, videoID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.CancelJobResponse{
+		VideoID:   video.ID,
+		VideoCode: video.Code,
+		JobType:   jobType,
+		Status:    "cancelling",
+	}
+
+	switch jobType {
+	case ports.ProgressJobTranscode:
+		err = s.cancelTranscode(ctx, video, response)
+	case ports.ProgressJobGallery:
+		err = s.cancelGallery(ctx, video, response)
+	case ports.ProgressJobSEO:
+		// SEO worker ไม่ได้อยู่ในคิวของเรา - ทำได้แค่ตั้ง flag
+		err = s.requestJobCancel(ctx, video, jobType)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedJobType, jobType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Status == "cancelled" {
+		response.Message = fmt.Sprintf("%s job cancelled", jobType)
+	} else {
+		response.Message = fmt.Sprintf("%s job will stop at the next stage", jobType)
+	}
+
+	logger.InfoContext(ctx, "Job cancel requested",
+		"video_id", videoID,
+		"video_code", video.Code,
+		"job_type", jobType,
+		"status", response.Status,
+		"removed_jobs", response.RemovedJobs,
+	)
+
+	return response, nil
+}
+
+// cancelTranscode ยกเลิก transcode - pending/queued = ลบจากคิว
+// processing = ErrTranscodeInProgress (transcode worker ไม่ได้ watch cancel flag จึงหยุดกลางทางไม่ได้)
+func (s *QueueServiceImpl) cancelTranscode(ctx context.Context, video *models.Video, response *dto.CancelJobResponse) error {
+	switch video.Status {
+	case models.VideoStatusPending, models.VideoStatusQueued:
+		removed, err := s.jobCanceller.RemoveQueuedTranscodeJobs(ctx, video.ID.String())
+		if errors.Is(err, ports.ErrJobInFlight) {
+			// worker ดึงไปแล้วแต่ยังไม่อัพเดท status - หยุดไม่ได้เหมือน processing
+			return ErrTranscodeInProgress
+		}
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to remove queued transcode job", "video_id", video.ID, "error", err)
+			return fmt.Errorf("failed to remove queued job: %w", err)
+		}
+		response.RemovedJobs = removed
+
+		if err := s.videoRepo.UpdateStatus(ctx, video.ID, models.VideoStatusCancelled); err != nil {
+			return fmt.Errorf("failed to update video status: %w", err)
+		}
+		response.Status = "cancelled"
+		return nil
+	case models.VideoStatusProcessing:
+		return ErrTranscodeInProgress
+	default:
+		return fmt.Errorf("%w: transcode status is %s", ErrNoActiveJob, video.Status)
+	}
+}
+
+// cancelGallery ยกเลิก gallery - gallery_status = processing ตั้งแต่ตอน queue จึงแยกจาก job ที่ลบจากคิวได้
+func (s *QueueServiceImpl) cancelGallery(ctx context.Context, video *models.Video, response *dto.CancelJobResponse) error {
+	if video.GalleryStatus != "processing" {
+		return fmt.Errorf("%w: gallery status is %s", ErrNoActiveJob, video.GalleryStatus)
+	}
+
+	// worker ถือ job อยู่ (ErrJobInFlight) = ไม่ลบ ให้ worker เจอ flag แล้วหยุดเอง
+	removed, err := s.jobCanceller.RemoveQueuedGalleryJobs(ctx, video.ID.String())
+	inFlight := errors.Is(err, ports.ErrJobInFlight)
+	if err != nil && !inFlight {
+		logger.ErrorContext(ctx, "Failed to remove queued gallery job", "video_id", video.ID, "error", err)
+		return fmt.Errorf("failed to remove queued job: %w", err)
+	}
+	response.RemovedJobs = removed
+
+	if err := s.requestJobCancel(ctx, video, ports.ProgressJobGallery); err != nil {
+		return err
+	}
+
+	// ลบจากคิวได้และไม่มี worker ถืออยู่ = ไม่มีใครทำ - เปลี่ยน status เลย
+	if removed > 0 && !inFlight {
+		video.GalleryStatus = "cancelled"
+		if err := s.videoRepo.Update(ctx, video); err != nil {
+			return fmt.Errorf("failed to update gallery status: %w", err)
+		}
+		response.Status = "cancelled"
+
+		// job ไม่ถึง worker แล้ว - ปล่อย lock ให้สร้าง gallery ใหม่ได้ทันที (ไม่ต้องรอ TTL)
+		if releaser, ok := s.jobCanceller.(GalleryLockReleaser); ok {
+			if err := releaser.ReleaseGalleryLock(ctx, video.Code); err != nil {
+				logger.WarnContext(ctx, "Failed to release gallery lock", "video_id", video.ID, "error", err)
+			}
+		}
+	}
+	return nil
+}
+
+// requestJobCancel ตั้ง flag ยกเลิกให้ worker
+func (s *QueueServiceImpl) requestJobCancel(ctx context.Context, video *models.Video, jobType string) error {
+	if err := s.jobCanceller.RequestJobCancel(ctx, jobType, video.ID.String()); err != nil {
+		logger.ErrorContext(ctx, "Failed to request job cancel", "video_id", video.ID, "job_type", jobType, "error", err)
+		return fmt.Errorf("failed to request cancel: %w", err)
+	}
+	return nil
+}
+
 // === Subtitle Queue ===
 
 func (s *QueueServiceImpl) GetSubtitleStuck(ctx context.Context, page, limit int) ([]dto.SubtitleQueueItem, int64, error) {
@@ -753,6 +1006,32 @@ func (s *QueueServiceImpl) GetGalleryProcessing(ctx context.Context, page, limit
 	return items, total, nil
 }
 
+func (s *QueueServiceImpl) GetGalleryByStatus(ctx context.Context, galleryStatus string, page, limit int) ([]dto.GalleryQueueItem, int64, error) {
+	offset := (page - 1) * limit
+	videos, total, err := s.videoRepo.ListByGalleryStatus(ctx, galleryStatus, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]dto.GalleryQueueItem, len(videos))
+	for i, v := range videos {
+		items[i] = dto.GalleryQueueItem{
+			ID:            v.ID,
+			Code:          v.Code,
+			Title:         v.Title,
+			GalleryStatus: v.GalleryStatus,
+			SourceCount:   v.GallerySourceCount,
+			SafeCount:     v.GallerySafeCount,
+			NsfwCount:     v.GalleryNsfwCount,
+			Error:         v.LastError,
+			CreatedAt:     v.CreatedAt,
+			UpdatedAt:     v.UpdatedAt,
+		}
+	}
+
+	return items, total, nil
+}
+
 func (s *QueueServiceImpl) GetGalleryFailed(ctx context.Context, page, limit int) ([]dto.GalleryQueueItem, int64, error) {
 	offset := (page - 1) * limit
 	videos, total, err := s.videoRepo.GetGalleryFailed(ctx, offset, limit)
@@ -803,14 +1082,14 @@ func (s *QueueServiceImpl) RetryGalleryAll(ctx context.Context) (*dto.RetryRespo
 	var errors []string
 	for _, v := range videos {
 		// Create gallery job
-		outputPath := fmt.Sprintf("gallery/%s", v.Code)
+		quality := v.GalleryQuality(s.galleryQuality)
 		job := nats.NewGalleryJob(
 			v.ID.String(),
 			v.Code,
-			fmt.Sprintf("hls/%s", v.Code),
-			"720p",
+			fmt.Sprintf("hls/%s/%s/playlist.m3u8", v.Code, quality),
+			quality,
 			v.Duration,
-			outputPath,
+			fmt.Sprintf("gallery/%s/", v.Code),
 			100,
 		)
 
@@ -839,6 +1118,295 @@ func (s *QueueServiceImpl) RetryGalleryAll(ctx context.Context) (*dto.RetryRespo
 	return response, nil
 }
 
+func (s *QueueServiceImpl) GetGalleryMissing(ctx context.Context, page, limit int) ([]dto.GalleryQueueItem, int64, error) {
+	offset := (page - 1) * limit
+	videos, total, err := s.videoRepo.ListReadyWithoutGallery(ctx, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]dto.GalleryQueueItem, len(videos))
+	for i, v := range videos {
+		items[i] = dto.GalleryQueueItem{
+			ID:            v.ID,
+			Code:          v.Code,
+			Title:         v.Title,
+			GalleryStatus: v.GalleryStatus,
+			SourceCount:   v.GallerySourceCount,
+			SafeCount:     v.GallerySafeCount,
+			NsfwCount:     v.GalleryNsfwCount,
+			Error:         v.LastError,
+			CreatedAt:     v.CreatedAt,
+			UpdatedAt:     v.UpdatedAt,
+		}
+	}
+
+	return items, total, nil
+}
+
+func (s *QueueServiceImpl) BackfillGalleries(ctx context.Context, maxJobs int) (*dto.BackfillGalleryResponse, error) {
+	if maxJobs <= 0 {
+		maxJobs = galleryBackfillDefaultJobs
+	}
+	if maxJobs > galleryBackfillMaxJobs {
+		maxJobs = galleryBackfillMaxJobs
+	}
+
+	logger.InfoContext(ctx, "Backfilling galleries", "max_jobs", maxJobs)
+
+	videos, total, err := s.videoRepo.ListReadyWithoutGallery(ctx, 0, maxJobs)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.BackfillGalleryResponse{
+		TotalMissing: int(total),
+	}
+
+	if len(videos) == 0 {
+		response.Message = "No videos missing gallery"
+		return response, nil
+	}
+
+	if s.galleryJobPublisher == nil {
+		return nil, fmt.Errorf("gallery job publisher not available")
+	}
+
+	var errors []string
+backfillLoop:
+	for i, v := range videos {
+		// Rate limit: เว้นระยะระหว่าง jobs (หยุดถ้า request ถูก cancel)
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				errors = append(errors, "backfill interrupted: "+ctx.Err().Error())
+				response.Skipped += len(videos) - i
+				break backfillLoop
+			case <-time.After(galleryBackfillInterval):
+			}
+		}
+
+		quality := v.GalleryQuality(s.galleryQuality)
+		job := nats.NewGalleryJob(
+			v.ID.String(),
+			v.Code,
+			fmt.Sprintf("hls/%s/%s/playlist.m3u8", v.Code, quality),
+			quality,
+			v.Duration,
+			fmt.Sprintf("gallery/%s/", v.Code),
+			100,
+		)
+
+		if err := s.galleryJobPublisher.PublishGalleryJob(ctx, job); err != nil {
+			errors = append(errors, fmt.Sprintf("%s: %v", v.Code, err))
+			response.Skipped++
+			continue
+		}
+
+		// Update gallery status to processing (ไม่ให้ถูก backfill ซ้ำ)
+		// job ถูก publish แล้ว จึงยังนับเป็น queued แต่รายงาน error ไว้ (รอบหน้าอาจ queue ซ้ำ)
+		v.GalleryStatus = "processing"
+		v.LastError = ""
+		if err := s.videoRepo.Update(ctx, v); err != nil {
+			logger.WarnContext(ctx, "Failed to mark backfilled gallery as processing", "video_id", v.ID, "error", err)
+			errors = append(errors, fmt.Sprintf("%s: queued but failed to update status: %v", v.Code, err))
+		}
+
+		response.TotalQueued++
+	}
+
+	response.Errors = errors
+	response.Remaining = total - int64(response.TotalQueued)
+	response.Message = fmt.Sprintf("Queued %d/%d videos for gallery backfill (%d remaining)",
+		response.TotalQueued, response.TotalMissing, response.Remaining)
+
+	logger.InfoContext(ctx, "Backfill galleries completed",
+		"total_missing", response.TotalMissing,
+		"total_queued", response.TotalQueued,
+		"skipped", response.Skipped,
+	)
+
+	return response, nil
+}
+
+// === SEO Queue ===
+
+// EnqueueSEOArticles queue SEO article jobs ให้วิดีโอที่ตรง filter (จำกัดจำนวนต่อรอบ + rate limit)
+// วิดีโอที่ queue แล้วถูกบันทึก event "queued" - เรียกซ้ำภายใน dedup window จะไม่ queue ซ้ำ
+func (s *QueueServiceImpl) EnqueueSEOArticles(ctx context.Context, req *dto.EnqueueSEORequest) (*dto.EnqueueSEOResponse, error) {
+	if s.seoJobPublisher == nil {
+		return nil, fmt.Errorf("seo job publisher not available")
+	}
+
+	if req.Status == "" {
+		req.Status = string(models.VideoStatusReady)
+	}
+	if req.Max <= 0 {
+		req.Max = seoEnqueueDefaultJobs
+	}
+	if req.Max > seoEnqueueMaxJobs {
+		req.Max = seoEnqueueMaxJobs
+	}
+	if req.Priority == 0 {
+		req.Priority = ports.JobPriorityBackfill
+	}
+
+	logger.InfoContext(ctx, "Enqueueing SEO articles",
+		"status", req.Status,
+		"has_gallery", req.HasGallery,
+		"missing_article", req.MissingArticle,
+		"created_from", req.CreatedFrom,
+		"created_to", req.CreatedTo,
+		"max_jobs", req.Max,
+		"priority", req.Priority,
+	)
+
+	videos, total, err := s.videoRepo.ListForSEOEnqueue(ctx, req, time.Now().Add(-seoEnqueueDedupWindow), req.Max)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.EnqueueSEOResponse{
+		TotalMatched: total,
+	}
+
+	if len(videos) == 0 {
+		response.Message = "No videos match the filter"
+		return response, nil
+	}
+
+	var errors []string
+enqueueLoop:
+	for i, v := range videos {
+		// Rate limit: เว้นระยะระหว่าง jobs (หยุดถ้า request ถูก cancel)
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				errors = append(errors, "enqueue interrupted: "+ctx.Err().Error())
+				response.Skipped += len(videos) - i
+				break enqueueLoop
+			case <-time.After(seoEnqueueInterval):
+			}
+		}
+
+		job := nats.NewSEOArticleJob(v.ID.String(), v.Code, req.Priority, req.GenerateTTS)
+		job.OutputLanguage = req.OutputLanguage
+
+		if err := s.seoJobPublisher.PublishSEOArticleJob(ctx, job); err != nil {
+			errors = append(errors, fmt.Sprintf("%s: %v", v.Code, err))
+			response.Skipped++
+			continue
+		}
+
+		// บันทึก event "queued" (ไม่ให้ถูก enqueue ซ้ำก่อน worker เริ่มทำ)
+		if s.jobEventRepo != nil {
+			event := &models.JobEvent{
+				VideoID: v.ID,
+				Source:  models.JobEventSourceSEO,
+				Stage:   "queued",
+				Message: "bulk enqueue",
+			}
+			if err := s.jobEventRepo.Create(ctx, event); err != nil {
+				logger.WarnContext(ctx, "Failed to record SEO queued event", "video_id", v.ID, "error", err)
+			}
+		}
+
+		response.TotalQueued++
+	}
+
+	response.Errors = errors
+	response.Remaining = total - int64(response.TotalQueued)
+	response.Message = fmt.Sprintf("Queued %d/%d videos for SEO articles (%d remaining)",
+		response.TotalQueued, response.TotalMatched, response.Remaining)
+
+	logger.InfoContext(ctx, "Enqueue SEO articles completed",
+		"total_matched", response.TotalMatched,
+		"total_queued", response.TotalQueued,
+		"skipped", response.Skipped,
+	)
+
+	return response, nil
+}
+
+// BumpGallery ลบ gallery job เดิมจากคิวปกติแล้ว queue ใหม่แบบ urgent
+func (s *QueueServiceImpl) BumpGallery(ctx context.Context, videoID uuid.UUID) (*dto.BumpPriorityResponse, error) {
+	if s.queuedJobRemover == nil {
+		return nil, ErrPriorityQueuesDisabled
+	}
+	if s.galleryJobPublisher == nil {
+		return nil, fmt.Errorf("gallery job publisher not available")
+	}
+
+	video, err := findVideo(ctx, s.videoRepo, videoID)
+	if err != nil {
+		return nil, err
+	}
+	if video.Status != models.VideoStatusReady || video.HLSPath == "" {
+		return nil, fmt.Errorf("%w: video must be ready with HLS content", ErrJobNotBumpable)
+	}
+	// gallery เสร็จแล้ว = ใช้ regenerate-gallery แทน (bump ไม่ลบภาพเดิม)
+	// processing = มี job อยู่แล้ว (worker อาจทำอยู่) - bump ซ้ำจะได้ 2 job ทำ video เดียวกัน
+	switch video.GalleryStatus {
+	case "ready", "pending_review", "processing":
+		return nil, fmt.Errorf("%w: gallery status is %s", ErrJobNotBumpable, video.GalleryStatus)
+	}
+
+	removed, err := s.queuedJobRemover.RemoveQueuedGalleryJobs(ctx, videoID.String())
+	if errors.Is(err, ports.ErrJobInFlight) {
+		return nil, err
+	}
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to remove queued gallery job", "video_id", videoID, "error", err)
+		return nil, fmt.Errorf("failed to remove queued job: %w", err)
+	}
+
+	quality := video.GalleryQuality(s.galleryQuality)
+	job := nats.NewGalleryJob(
+		video.ID.String(),
+		video.Code,
+		fmt.Sprintf("hls/%s/%s/playlist.m3u8", video.Code, quality),
+		quality,
+		video.Duration,
+		fmt.Sprintf("gallery/%s/", video.Code),
+		100,
+	)
+	job.Priority = ports.JobPriorityUrgent
+
+	if err := s.galleryJobPublisher.PublishGalleryJob(ctx, job); err != nil {
+		// job เดิมถูกลบไปแล้ว - lock "queued" ของมันไม่มีเจ้าของ ปล่อยให้สร้างใหม่ได้ทันที
+		if removed > 0 {
+			if releaser, ok := s.queuedJobRemover.(GalleryLockReleaser); ok {
+				if err := releaser.ReleaseGalleryLock(ctx, video.Code); err != nil {
+					logger.WarnContext(ctx, "Failed to release gallery lock", "video_id", video.ID, "error", err)
+				}
+			}
+		}
+		return nil, fmt.Errorf("failed to queue gallery job: %w", err)
+	}
+
+	// เหมือน backfill - processing ตั้งแต่ตอน queue (กัน bump/backfill ซ้ำ)
+	video.GalleryStatus = "processing"
+	video.LastError = ""
+	if err := s.videoRepo.Update(ctx, video); err != nil {
+		logger.WarnContext(ctx, "Failed to mark bumped gallery as processing", "video_id", videoID, "error", err)
+	}
+
+	logger.InfoContext(ctx, "Gallery job bumped to priority queue",
+		"video_id", videoID,
+		"video_code", video.Code,
+		"removed_jobs", removed,
+	)
+
+	return &dto.BumpPriorityResponse{
+		VideoID:     video.ID,
+		VideoCode:   video.Code,
+		Queue:       "gallery",
+		Priority:    ports.JobPriorityUrgent,
+		RemovedJobs: removed,
+		Message:     "Gallery job moved to priority queue",
+	}, nil
+}
+
 // === Reel Queue ===
 
 func (s *QueueServiceImpl) GetReelExporting(ctx context.Context, page, limit int) ([]dto.ReelQueueItem, int64, error) {
@@ -0,0 +1,101 @@
+package serviceimpl
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/ports"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/logger"
+)
+
+const (
+	relatedVideosDefaultLimit = 10
+	relatedVideosMaxLimit     = 50
+)
+
+type RelatedVideoServiceImpl struct {
+	relatedFinder ports.RelatedFinderPort
+	videoRepo     repositories.VideoRepository
+}
+
+func NewRelatedVideoService(relatedFinder ports.RelatedFinderPort, videoRepo repositories.VideoRepository) services.RelatedVideoService {
+	return &RelatedVideoServiceImpl{
+		relatedFinder: relatedFinder,
+		videoRepo:     videoRepo,
+	}
+}
+
+// GetRelatedByEmbedding หา videos ที่คล้ายกันด้วย vector similarity (filter cast/maker/tag ได้)
+// embeddings อยู่ DB ของ SEO worker → ถาม IDs จาก SEO worker แล้วเติม metadata จาก DB ของเรา
+func (s *RelatedVideoServiceImpl) GetRelatedByEmbedding(ctx context.Context, videoID uuid.UUID, limit int, filter *dto.RelatedVideoFilter) ([]dto.RelatedVideoResponse, error) {
+	if _, err := findVideo(ctx, s.videoRepo, videoID); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = relatedVideosDefaultLimit
+	}
+	if limit > relatedVideosMaxLimit {
+		limit = relatedVideosMaxLimit
+	}
+
+	if !s.relatedFinder.IsEnabled() {
+		return []dto.RelatedVideoResponse{}, nil
+	}
+
+	query := &ports.RelatedQuery{VideoID: videoID, Limit: limit}
+	if filter != nil {
+		query.CastID = filter.CastID
+		query.MakerID = filter.MakerID
+		query.TagID = filter.TagID
+		query.MinSimilarity = filter.MinSimilarity
+	}
+
+	matches, hasEmbedding, err := s.relatedFinder.FindRelated(ctx, query)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to find related videos", "video_id", videoID, "error", err)
+		return nil, err
+	}
+	// ยังไม่มี embedding (SEO worker ยังไม่ได้ประมวลผล) → คืน list ว่าง
+	if !hasEmbedding {
+		logger.InfoContext(ctx, "Video has no embedding yet", "video_id", videoID)
+		return []dto.RelatedVideoResponse{}, nil
+	}
+
+	ids := make([]uuid.UUID, len(matches))
+	for i, m := range matches {
+		ids[i] = m.VideoID
+	}
+	videos, err := s.videoRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to load related videos", "video_id", videoID, "error", err)
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]*models.Video, len(videos))
+	for _, v := range videos {
+		byID[v.ID] = v
+	}
+
+	// คงลำดับ similarity จาก SEO worker - ข้าม video ที่ถูกลบหรือยังไม่ ready
+	related := make([]*models.RelatedVideo, 0, len(matches))
+	for _, m := range matches {
+		v, ok := byID[m.VideoID]
+		if !ok || v.Status != models.VideoStatusReady {
+			continue
+		}
+		related = append(related, &models.RelatedVideo{
+			ID:           v.ID,
+			Code:         v.Code,
+			Title:        v.Title,
+			ThumbnailURL: v.ThumbnailURL,
+			Similarity:   m.Similarity,
+		})
+	}
+
+	return dto.RelatedVideosToResponses(related), nil
+}
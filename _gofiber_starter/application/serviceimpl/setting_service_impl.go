@@ -130,6 +130,14 @@ func (s *SettingServiceImpl) Update(ctx context.Context, category string, update
 		return nil
 	}
 
+	// ตรวจทุกค่าก่อนบันทึก (ค่าไหนไม่ผ่าน = ไม่บันทึกเลย)
+	for key, newValue := range updates {
+		if err := settings.Validate(category, key, newValue); err != nil {
+			logger.WarnContext(ctx, "Invalid setting value", "category", category, "key", key, "error", err)
+			return err
+		}
+	}
+
 	for key, newValue := range updates {
 		// ตรวจสอบว่า key มีอยู่จริง
 		def, ok := catDefaults[key]
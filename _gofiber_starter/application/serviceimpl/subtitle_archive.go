@@ -0,0 +1,131 @@
+package serviceimpl
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/logger"
+)
+
+// ErrNoReadySubtitles video ไม่มี subtitle ที่ ready ให้ดาวน์โหลด
+var ErrNoReadySubtitles = errors.New("no ready subtitles")
+
+// DownloadAllSubtitles เตรียม zip ของ SRT ทุกภาษาที่ ready (ชื่อไฟล์ <code>.<lang>.srt)
+// subtitle ที่ยังไม่ ready ถูกข้ามและบันทึกไว้ใน MANIFEST.txt
+func (s *SubtitleServiceImpl) DownloadAllSubtitles(ctx context.Context, videoID uuid.UUID) (*services.SubtitleArchive, error) {
+	video, err := findVideo(ctx, s.videoRepo, videoID)
+	if err != nil {
+		return nil, err
+	}
+
+	subtitles, err := s.subtitleRepo.GetByVideoID(ctx, videoID)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to get subtitles", "video_id", videoID, "error", err)
+		return nil, err
+	}
+
+	var ready, skipped []*models.Subtitle
+	for _, subtitle := range subtitles {
+		if subtitle.IsReady() {
+			ready = append(ready, subtitle)
+		} else {
+			skipped = append(skipped, subtitle)
+		}
+	}
+	if len(ready) == 0 {
+		return nil, ErrNoReadySubtitles
+	}
+
+	logger.InfoContext(ctx, "Preparing subtitle archive",
+		"video_id", videoID,
+		"video_code", video.Code,
+		"ready", len(ready),
+		"skipped", len(skipped),
+	)
+
+	return &services.SubtitleArchive{
+		Filename: fmt.Sprintf("%s-subtitles.zip", video.Code),
+		Count:    len(ready),
+		Write: func(w io.Writer) error {
+			return s.writeSubtitleZip(ctx, w, video.Code, ready, skipped)
+		},
+	}, nil
+}
+
+// writeSubtitleZip stream SRT จาก storage ลง zip ทีละไฟล์ (ไม่โหลดทุกภาษาเข้า memory พร้อมกัน)
+// อ่านไฟล์ไม่ได้ = ข้ามและบันทึกใน manifest (header ส่งไปแล้ว เปลี่ยน status ไม่ได้)
+func (s *SubtitleServiceImpl) writeSubtitleZip(ctx context.Context, w io.Writer, code string, ready, skipped []*models.Subtitle) error {
+	zw := zip.NewWriter(w)
+
+	var manifest strings.Builder
+	fmt.Fprintf(&manifest, "Video: %s\nGenerated: %s\n\nIncluded:\n", code, time.Now().UTC().Format(time.RFC3339))
+
+	for _, subtitle := range ready {
+		name := fmt.Sprintf("%s.%s.srt", code, subtitle.Language)
+		if err := s.addSubtitleToZip(zw, name, subtitle.SRTPath); err != nil {
+			logger.WarnContext(ctx, "Failed to add subtitle to archive",
+				"subtitle_id", subtitle.ID,
+				"srt_path", subtitle.SRTPath,
+				"error", err,
+			)
+			skipped = append(skipped, subtitle)
+			continue
+		}
+		fmt.Fprintf(&manifest, "  %s (%s)\n", name, subtitle.Type)
+	}
+
+	if len(skipped) > 0 {
+		manifest.WriteString("\nSkipped:\n")
+		for _, subtitle := range skipped {
+			reason := string(subtitle.Status)
+			if subtitle.IsReady() {
+				reason = "file unavailable"
+			}
+			fmt.Fprintf(&manifest, "  %s - %s\n", subtitle.Language, reason)
+		}
+	}
+
+	mw, err := zw.Create("MANIFEST.txt")
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(mw, manifest.String()); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+// addSubtitleToZip copy SRT 1 ไฟล์จาก storage เข้า zip
+// อ่านทั้งไฟล์ก่อน (SRT มีขนาดเล็ก) - อ่านล้มเหลวกลางทางจะไม่เหลือ entry ที่เสียใน zip
+func (s *SubtitleServiceImpl) addSubtitleToZip(zw *zip.Writer, name, srtPath string) error {
+	reader, _, err := s.storage.GetFileContent(srtPath)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	fw, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = fw.Write(content)
+	return err
+}
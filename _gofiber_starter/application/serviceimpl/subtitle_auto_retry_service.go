@@ -0,0 +1,104 @@
+package serviceimpl
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/logger"
+	"gofiber-template/pkg/scheduler"
+)
+
+// Auto-retry settings (category "subtitle") - อ่านทุกรอบ เปลี่ยนจาก Admin UI ได้ทันที
+const (
+	subtitleAutoRetryTick            = "@every 1m" // scheduler tick - interval จริงมาจาก settings
+	subtitleAutoRetryDefaultInterval = 15          // นาที
+)
+
+// SubtitleStreamCounter interface สำหรับนับ messages ที่ค้างใน subtitle stream
+type SubtitleStreamCounter interface {
+	SubtitleStreamMessageCount(ctx context.Context) (uint64, error)
+}
+
+// SubtitleAutoRetryService republish subtitles ที่ค้าง queued อัตโนมัติ (เหมือนกด retry-stuck เอง)
+// กรณี worker crash แล้ว NATS job หาย - subtitle จะค้าง queued ตลอดไปถ้าไม่มีใคร retry
+type SubtitleAutoRetryService struct {
+	subtitleService services.SubtitleService
+	settingService  services.SettingService
+	streamCounter   SubtitleStreamCounter // nil = ไม่ตรวจ stream ก่อน retry
+	scheduler       scheduler.EventScheduler
+
+	mu      sync.Mutex
+	lastRun time.Time
+}
+
+// NewSubtitleAutoRetryService สร้าง service ใหม่
+func NewSubtitleAutoRetryService(
+	subtitleService services.SubtitleService,
+	settingService services.SettingService,
+	streamCounter SubtitleStreamCounter,
+	eventScheduler scheduler.EventScheduler,
+) *SubtitleAutoRetryService {
+	return &SubtitleAutoRetryService{
+		subtitleService: subtitleService,
+		settingService:  settingService,
+		streamCounter:   streamCounter,
+		scheduler:       eventScheduler,
+	}
+}
+
+// RegisterAutoRetryJob ลงทะเบียน auto-retry job กับ scheduler
+func (s *SubtitleAutoRetryService) RegisterAutoRetryJob() error {
+	return s.scheduler.AddJob("subtitle_auto_retry", subtitleAutoRetryTick, func() {
+		ctx := context.Background()
+		s.RunAutoRetry(ctx)
+	})
+}
+
+// RunAutoRetry retry subtitles ที่ queued นานเกิน interval (ถ้าเปิดใช้งานและถึงรอบ)
+func (s *SubtitleAutoRetryService) RunAutoRetry(ctx context.Context) {
+	if !s.settingService.GetBool(ctx, "subtitle", "auto_retry_stuck", true) {
+		return
+	}
+
+	intervalMin := s.settingService.GetInt(ctx, "subtitle", "auto_retry_interval_minutes", subtitleAutoRetryDefaultInterval)
+	if intervalMin <= 0 {
+		intervalMin = subtitleAutoRetryDefaultInterval
+	}
+	interval := time.Duration(intervalMin) * time.Minute
+
+	s.mu.Lock()
+	if time.Since(s.lastRun) < interval {
+		s.mu.Unlock()
+		return
+	}
+	s.lastRun = time.Now()
+	s.mu.Unlock()
+
+	// ยังมี jobs ค้างใน stream = workers ยังทำไม่ถึง (ไม่ใช่ job หาย) → ไม่ส่งซ้ำ
+	if s.streamCounter != nil {
+		pending, err := s.streamCounter.SubtitleStreamMessageCount(ctx)
+		if err != nil {
+			logger.WarnContext(ctx, "Subtitle auto-retry skipped: cannot read subtitle stream", "error", err)
+			return
+		}
+		if pending > 0 {
+			return
+		}
+	}
+
+	result, err := s.subtitleService.RetryStaleQueuedSubtitles(ctx, interval)
+	if err != nil {
+		logger.ErrorContext(ctx, "Subtitle auto-retry failed", "error", err)
+		return
+	}
+
+	if result.TotalFound > 0 {
+		logger.InfoContext(ctx, "Subtitle auto-retry completed",
+			"total_found", result.TotalFound,
+			"total_retried", result.TotalRetried,
+			"skipped", result.Skipped,
+		)
+	}
+}
@@ -0,0 +1,72 @@
+package serviceimpl
+
+import (
+	"context"
+	"strings"
+
+	"gofiber-template/pkg/logger"
+)
+
+// defaultAutoTranslatePolicy พฤติกรรมเดิม: ไทย → อังกฤษ, ภาษาอื่น → ไทย
+const defaultAutoTranslatePolicy = "th:en;*:th"
+
+// parseAutoTranslatePolicy แยก policy "source:target1,target2;...;*:target"
+// - "*" = ภาษาต้นทางที่ไม่ได้ระบุ
+// - target ว่าง (เช่น "ko:") = ปิด auto-translate สำหรับภาษานั้น
+func parseAutoTranslatePolicy(value string) map[string][]string {
+	policy := make(map[string][]string)
+	for _, rule := range strings.Split(value, ";") {
+		source, targets, ok := strings.Cut(rule, ":")
+		source = strings.ToLower(strings.TrimSpace(source))
+		if !ok || source == "" {
+			continue
+		}
+
+		langs := []string{}
+		for _, target := range strings.Split(targets, ",") {
+			if target = strings.ToLower(strings.TrimSpace(target)); target != "" {
+				langs = append(langs, target)
+			}
+		}
+		policy[source] = langs
+	}
+	return policy
+}
+
+// autoTranslateTargets ภาษาที่จะแปลอัตโนมัติหลัง transcribe เสร็จ (ตาม settings subtitle.auto_translate_*)
+// ตัดภาษาซ้ำ/ภาษาเดียวกับต้นทาง และภาษาที่ translation matrix ไม่รองรับ (CanTranslate)
+func (s *SubtitleServiceImpl) autoTranslateTargets(ctx context.Context, sourceLanguage string) []string {
+	policyValue := defaultAutoTranslatePolicy
+	if s.settingService != nil {
+		if !s.settingService.GetBool(ctx, "subtitle", "auto_translate_enabled", true) {
+			return nil
+		}
+		if value, _ := s.settingService.Get(ctx, "subtitle", "auto_translate_policy"); strings.TrimSpace(value) != "" {
+			policyValue = value
+		}
+	}
+
+	policy := parseAutoTranslatePolicy(policyValue)
+	targets, ok := policy[sourceLanguage]
+	if !ok {
+		targets = policy["*"]
+	}
+
+	seen := map[string]bool{sourceLanguage: true}
+	var unique []string
+	for _, target := range targets {
+		if !seen[target] {
+			seen[target] = true
+			unique = append(unique, target)
+		}
+	}
+
+	valid, invalid := s.CanTranslate(sourceLanguage, unique)
+	if len(invalid) > 0 {
+		logger.WarnContext(ctx, "Auto-translate targets not supported by translation matrix, skipping",
+			"source_language", sourceLanguage,
+			"targets", invalid,
+		)
+	}
+	return valid
+}
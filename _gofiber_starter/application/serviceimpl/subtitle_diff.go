@@ -0,0 +1,216 @@
+package serviceimpl
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/pkg/logger"
+	"gofiber-template/pkg/utils"
+)
+
+// Cue change types
+const (
+	cueChangeAdded   = "added"
+	cueChangeRemoved = "removed"
+	cueChangeChanged = "changed"
+	cueChangeRetimed = "retimed"
+)
+
+// previousSRTPath path ของ version ก่อนหน้า: subtitles/{code}/{lang}.srt → subtitles/{code}/{lang}.prev.srt
+func previousSRTPath(srtPath string) string {
+	return strings.TrimSuffix(srtPath, ".srt") + ".prev.srt"
+}
+
+// snapshotPreviousSRT copy SRT ปัจจุบันไปเป็น version ก่อนหน้า ก่อนถูก overwrite
+// content ไม่เปลี่ยน = ไม่ snapshot (ไม่ให้ version N-1 ถูกทับด้วยของเดิม)
+// ล้มเหลว = warn เท่านั้น (การแก้ไขหลักยังต้องทำต่อ)
+func (s *SubtitleServiceImpl) snapshotPreviousSRT(ctx context.Context, subtitle *models.Subtitle, newContent string) {
+	current, err := s.readSRT(subtitle.SRTPath)
+	if err != nil {
+		logger.WarnContext(ctx, "Failed to read current SRT for snapshot", "subtitle_id", subtitle.ID, "error", err)
+		return
+	}
+	if current == newContent {
+		return
+	}
+
+	prevPath := previousSRTPath(subtitle.SRTPath)
+	if _, err := s.storage.UploadFile(bytes.NewReader([]byte(current)), prevPath, "text/plain; charset=utf-8"); err != nil {
+		logger.WarnContext(ctx, "Failed to save previous SRT version",
+			"subtitle_id", subtitle.ID,
+			"prev_path", prevPath,
+			"error", err,
+		)
+	}
+}
+
+// readSRT อ่าน SRT ทั้งไฟล์จาก storage
+func (s *SubtitleServiceImpl) readSRT(path string) (string, error) {
+	reader, _, err := s.storage.GetFileContent(path)
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// Diff errors
+var (
+	ErrSubtitleNotFound = errors.New("subtitle not found")
+	ErrSubtitleHasNoSRT = errors.New("subtitle has no SRT file")
+)
+
+// GetSubtitleDiff เทียบ SRT ปัจจุบันกับ version ก่อนหน้า (cue-by-cue)
+func (s *SubtitleServiceImpl) GetSubtitleDiff(ctx context.Context, subtitleID uuid.UUID) (*dto.SubtitleDiffResponse, error) {
+	subtitle, err := s.subtitleRepo.GetByID(ctx, subtitleID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrSubtitleNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subtitle: %w", err)
+	}
+	if subtitle.SRTPath == "" {
+		return nil, ErrSubtitleHasNoSRT
+	}
+
+	response := &dto.SubtitleDiffResponse{
+		SubtitleID: subtitle.ID,
+		Language:   subtitle.Language,
+		Changes:    []dto.SubtitleCueChange{},
+	}
+
+	previous, err := s.readSRT(previousSRTPath(subtitle.SRTPath))
+	if err != nil {
+		// ไม่มี version ก่อนหน้า = ยังไม่เคยแก้ไข
+		return response, nil
+	}
+	current, err := s.readSRT(subtitle.SRTPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SRT file: %w", err)
+	}
+
+	oldCues, err := utils.ParseSRT(previous)
+	if err != nil {
+		return nil, fmt.Errorf("previous version: %w", err)
+	}
+	newCues, err := utils.ParseSRT(current)
+	if err != nil {
+		return nil, fmt.Errorf("current version: %w", err)
+	}
+
+	response.HasPrevious = true
+	response.Changes = diffSRTCues(oldCues, newCues)
+	for _, change := range response.Changes {
+		switch change.Type {
+		case cueChangeAdded:
+			response.Summary.Added++
+		case cueChangeRemoved:
+			response.Summary.Removed++
+		case cueChangeChanged:
+			response.Summary.Changed++
+		case cueChangeRetimed:
+			response.Summary.Retimed++
+		}
+	}
+
+	return response, nil
+}
+
+// diffSRTCues จับคู่ cue ด้วย LCS ของข้อความ
+// - ข้อความตรงกันแต่เวลาต่าง = retimed
+// - ช่วงที่ไม่ตรงกันระหว่างคู่ที่จับได้: จับคู่ตามลำดับเป็น changed ที่เหลือเป็น removed/added
+func diffSRTCues(oldCues, newCues []utils.SRTCue) []dto.SubtitleCueChange {
+	n, m := len(oldCues), len(newCues)
+
+	// lcs[i][j] = ความยาว LCS ของ oldCues[i:] กับ newCues[j:]
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if oldCues[i].Text == newCues[j].Text {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var changes []dto.SubtitleCueChange
+	var removed, added []utils.SRTCue
+
+	// flush จับคู่ removed/added ที่ค้างอยู่เป็น changed
+	flush := func() {
+		pairs := min(len(removed), len(added))
+		for k := 0; k < pairs; k++ {
+			changes = append(changes, cueChange(cueChangeChanged, &removed[k], &added[k]))
+		}
+		for k := pairs; k < len(removed); k++ {
+			changes = append(changes, cueChange(cueChangeRemoved, &removed[k], nil))
+		}
+		for k := pairs; k < len(added); k++ {
+			changes = append(changes, cueChange(cueChangeAdded, nil, &added[k]))
+		}
+		removed, added = removed[:0], added[:0]
+	}
+
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && oldCues[i].Text == newCues[j].Text:
+			flush()
+			if oldCues[i].Start != newCues[j].Start || oldCues[i].End != newCues[j].End {
+				changes = append(changes, cueChange(cueChangeRetimed, &oldCues[i], &newCues[j]))
+			}
+			i++
+			j++
+		case j < m && (i == n || lcs[i][j+1] >= lcs[i+1][j]):
+			added = append(added, newCues[j])
+			j++
+		default:
+			removed = append(removed, oldCues[i])
+			i++
+		}
+	}
+	flush()
+
+	if changes == nil {
+		changes = []dto.SubtitleCueChange{}
+	}
+	return changes
+}
+
+// cueChange สร้าง change entry จาก cue เก่า/ใหม่ (nil = ไม่มีฝั่งนั้น)
+func cueChange(changeType string, oldCue, newCue *utils.SRTCue) dto.SubtitleCueChange {
+	change := dto.SubtitleCueChange{Type: changeType}
+	if oldCue != nil {
+		change.OldIndex = oldCue.Index
+		change.OldStart = utils.FormatSRTTimestamp(oldCue.Start)
+		change.OldEnd = utils.FormatSRTTimestamp(oldCue.End)
+		change.OldText = oldCue.Text
+	}
+	if newCue != nil {
+		change.NewIndex = newCue.Index
+		change.NewStart = utils.FormatSRTTimestamp(newCue.Start)
+		change.NewEnd = utils.FormatSRTTimestamp(newCue.End)
+		change.NewText = newCue.Text
+	}
+	return change
+}
@@ -0,0 +1,97 @@
+package serviceimpl
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"gofiber-template/domain/models"
+	"gofiber-template/pkg/logger"
+	"gofiber-template/pkg/utils"
+)
+
+// Import errors - handler map เป็น 400/409, error อื่นเป็น infrastructure failure (500)
+var (
+	ErrInvalidSubtitleContent = errors.New("invalid subtitle content")
+	ErrSubtitleInProgress     = errors.New("subtitle is being processed")
+	ErrOriginalSubtitleExists = errors.New("original subtitle already exists")
+)
+
+// ImportSubtitle นำเข้า subtitle ที่ทำจากภายนอก (srt/vtt/ass) โดยไม่ผ่าน ASR pipeline
+// แปลงเป็น SRT มาตรฐาน → upload subtitles/{code}/{lang}.srt → record ready (type = imported)
+// ภาษาที่มี record อยู่แล้ว: กำลัง process = error, original = error (แก้ผ่าน content endpoint แทน
+// เพราะ translation ใช้ original เป็นต้นทาง), อื่น ๆ = แทนที่ไฟล์เดิม (เก็บ version ก่อนหน้าไว้ diff)
+func (s *SubtitleServiceImpl) ImportSubtitle(ctx context.Context, videoID uuid.UUID, language, format, content string) (*models.Subtitle, error) {
+	logger.InfoContext(ctx, "Importing subtitle",
+		"video_id", videoID,
+		"language", language,
+		"format", format,
+		"content_length", len(content),
+	)
+
+	video, err := findVideo(ctx, s.videoRepo, videoID)
+	if err != nil {
+		return nil, err
+	}
+
+	srt, err := utils.ConvertToSRT(format, content)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidSubtitleContent, format, err)
+	}
+
+	existing, err := s.subtitleRepo.GetByVideoIDAndLanguage(ctx, videoID, language)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get existing subtitle: %w", err)
+	}
+	if existing != nil {
+		if existing.IsInProgress() {
+			return nil, fmt.Errorf("%w: language '%s'", ErrSubtitleInProgress, language)
+		}
+		if existing.IsOriginal() && existing.IsReady() {
+			return nil, fmt.Errorf("%w: language '%s', edit its content instead", ErrOriginalSubtitleExists, language)
+		}
+	}
+
+	srtPath := fmt.Sprintf("subtitles/%s/%s.srt", video.Code, language)
+	if existing != nil && existing.IsReady() && existing.SRTPath == srtPath {
+		s.snapshotPreviousSRT(ctx, existing, srt)
+	}
+
+	if _, err := s.storage.UploadFile(bytes.NewReader([]byte(srt)), srtPath, "text/plain; charset=utf-8"); err != nil {
+		logger.ErrorContext(ctx, "Failed to upload imported SRT", "video_id", videoID, "srt_path", srtPath, "error", err)
+		return nil, fmt.Errorf("failed to save SRT file: %w", err)
+	}
+
+	subtitle := existing
+	if subtitle == nil {
+		subtitle = &models.Subtitle{VideoID: videoID, Language: language}
+	}
+	subtitle.Type = models.SubtitleTypeImported
+	subtitle.SourceLanguage = ""
+	subtitle.SRTPath = srtPath
+	subtitle.Status = models.SubtitleStatusReady
+	subtitle.Error = ""
+
+	if existing == nil {
+		err = s.subtitleRepo.Create(ctx, subtitle)
+	} else {
+		err = s.subtitleRepo.Update(ctx, subtitle)
+	}
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to save imported subtitle", "video_id", videoID, "language", language, "error", err)
+		return nil, err
+	}
+
+	logger.InfoContext(ctx, "Subtitle imported",
+		"video_id", videoID,
+		"subtitle_id", subtitle.ID,
+		"language", language,
+		"format", format,
+		"replaced", existing != nil,
+	)
+	return subtitle, nil
+}
@@ -28,10 +28,13 @@ func getTranslationTargets(sourceLanguage string) []string {
 }
 
 type SubtitleServiceImpl struct {
-	videoRepo    repositories.VideoRepository
-	subtitleRepo repositories.SubtitleRepository
-	jobPublisher services.SubtitleJobPublisher
-	storage      ports.StoragePort
+	videoRepo      repositories.VideoRepository
+	subtitleRepo   repositories.SubtitleRepository
+	jobPublisher   services.SubtitleJobPublisher
+	storage        ports.StoragePort
+	settingService services.SettingService // auto-translate policy (nil = ค่า default)
+	notifier       ports.NotifierPort      // แจ้งเตือนเมื่อ subtitle ready (optional - SetNotifier)
+	cdnPurger      ports.CDNPurgerPort     // ล้าง edge cache ของ SRT ที่เปลี่ยน (optional - SetCDNPurger)
 }
 
 func NewSubtitleService(
@@ -39,15 +42,38 @@ func NewSubtitleService(
 	subtitleRepo repositories.SubtitleRepository,
 	jobPublisher services.SubtitleJobPublisher,
 	storage ports.StoragePort,
+	settingService services.SettingService,
 ) services.SubtitleService {
 	return &SubtitleServiceImpl{
-		videoRepo:    videoRepo,
-		subtitleRepo: subtitleRepo,
-		jobPublisher: jobPublisher,
-		storage:      storage,
+		videoRepo:      videoRepo,
+		subtitleRepo:   subtitleRepo,
+		jobPublisher:   jobPublisher,
+		storage:        storage,
+		settingService: settingService,
 	}
 }
 
+// SetNotifier ตั้งค่า notifier สำหรับแจ้งเตือนเมื่อ transcribe/translate เสร็จ
+func (s *SubtitleServiceImpl) SetNotifier(notifier ports.NotifierPort) {
+	s.notifier = notifier
+}
+
+// SetCDNPurger ตั้ง purger สำหรับล้าง cache ของไฟล์ SRT ที่ CDN เมื่อเนื้อหาเปลี่ยน
+func (s *SubtitleServiceImpl) SetCDNPurger(purger ports.CDNPurgerPort) {
+	s.cdnPurger = purger
+}
+
+// purgeSubtitleCDN ล้าง edge cache ของไฟล์ SRT แบบ async (callback/แก้ไขต้องตอบเร็ว)
+func (s *SubtitleServiceImpl) purgeSubtitleCDN(srtPath string) {
+	if s.cdnPurger == nil || srtPath == "" {
+		return
+	}
+	go func() {
+		ctx := context.Background()
+		purgeCDN(ctx, s.cdnPurger, buildCDNPurge(ctx, s.cdnPurger, s.storage, []string{srtPath}, nil), "subtitle updated")
+	}()
+}
+
 // === Query Operations ===
 
 // GetSubtitlesByVideoID ดึง subtitles ทั้งหมดของ video
@@ -156,6 +182,9 @@ func (s *SubtitleServiceImpl) SetLanguage(ctx context.Context, videoID uuid.UUID
 	}, nil
 }
 
+// ErrTranscriptionInProgress มี original subtitle ที่กำลัง transcribe อยู่แล้ว
+var ErrTranscriptionInProgress = errors.New("transcription already in progress")
+
 // TriggerTranscribe สร้าง original subtitle record และส่ง transcribe job
 // ถ้ายังไม่ได้ตรวจจับภาษา worker จะ auto-detect ให้
 func (s *SubtitleServiceImpl) TriggerTranscribe(ctx context.Context, videoID uuid.UUID) (*dto.TranscribeResponse, error) {
@@ -182,7 +211,7 @@ func (s *SubtitleServiceImpl) TriggerTranscribe(ctx context.Context, videoID uui
 			return nil, errors.New("original subtitle already exists")
 		}
 		if existingOriginal.IsInProgress() {
-			return nil, errors.New("transcription already in progress")
+			return nil, ErrTranscriptionInProgress
 		}
 		// ถ้า failed ก็ลองใหม่ได้ - ลบอันเก่าก่อน
 		if err := s.subtitleRepo.Delete(ctx, existingOriginal.ID); err != nil {
@@ -437,40 +466,44 @@ func (s *SubtitleServiceImpl) HandleTranscribeComplete(ctx context.Context, subt
 	}
 
 	logger.InfoContext(ctx, "Transcription completed", "subtitle_id", subtitleID, "language", subtitle.Language)
+	s.notifySubtitleReady(subtitle)
+	s.purgeSubtitleCDN(subtitle.SRTPath)
 
 	// === Auto-translate ===
-	// หลัง transcribe เสร็จ → trigger translate อัตโนมัติ
-	// ภาษาไทย → แปลเป็นอังกฤษ, ภาษาอื่น → แปลเป็นไทย
+	// หลัง transcribe เสร็จ → trigger translate อัตโนมัติตาม policy ใน settings
+	// (default: ภาษาไทย → อังกฤษ, ภาษาอื่น → ไทย - ดู subtitle_auto_translate.go)
 	go func() {
 		autoCtx := context.Background()
-		var targetLang string
-		if subtitle.Language == "th" {
-			targetLang = "en"
-		} else {
-			targetLang = "th"
+		targetLangs := s.autoTranslateTargets(autoCtx, subtitle.Language)
+		if len(targetLangs) == 0 {
+			logger.InfoContext(autoCtx, "Auto-translate disabled for source language",
+				"video_id", subtitle.VideoID,
+				"source_language", subtitle.Language,
+			)
+			return
 		}
 
 		logger.InfoContext(autoCtx, "Auto-triggering translation",
 			"video_id", subtitle.VideoID,
 			"source_language", subtitle.Language,
-			"target_language", targetLang,
+			"target_languages", targetLangs,
 		)
 
 		translateReq := &dto.TranslateRequest{
-			TargetLanguages: []string{targetLang},
+			TargetLanguages: targetLangs,
 		}
 
 		_, err := s.TriggerTranslation(autoCtx, subtitle.VideoID, translateReq)
 		if err != nil {
 			logger.WarnContext(autoCtx, "Auto-translate failed (non-critical)",
 				"video_id", subtitle.VideoID,
-				"target_language", targetLang,
+				"target_languages", targetLangs,
 				"error", err,
 			)
 		} else {
 			logger.InfoContext(autoCtx, "Auto-translate triggered successfully",
 				"video_id", subtitle.VideoID,
-				"target_language", targetLang,
+				"target_languages", targetLangs,
 			)
 		}
 	}()
@@ -505,9 +538,42 @@ func (s *SubtitleServiceImpl) HandleTranslationComplete(ctx context.Context, sub
 	}
 
 	logger.InfoContext(ctx, "Translation completed", "subtitle_id", subtitleID, "language", req.Language)
+	s.notifySubtitleReady(subtitle)
+	s.purgeSubtitleCDN(subtitle.SRTPath)
 	return nil
 }
 
+// notifySubtitleReady ส่งแจ้งเตือน subtitle ready แบบ async (ไม่ critical - callback ต้องตอบเร็ว)
+func (s *SubtitleServiceImpl) notifySubtitleReady(subtitle *models.Subtitle) {
+	if s.notifier == nil || !s.notifier.IsEnabled() {
+		return
+	}
+
+	go func() {
+		ctx := context.Background()
+		video, err := s.videoRepo.GetByID(ctx, subtitle.VideoID)
+		if err != nil || video == nil {
+			logger.WarnContext(ctx, "Failed to get video for subtitle notification", "video_id", subtitle.VideoID, "error", err)
+			return
+		}
+
+		notification := &ports.SubtitleReadyNotification{
+			VideoCode:      video.Code,
+			Title:          video.Title,
+			Language:       subtitle.Language,
+			Type:           string(subtitle.Type),
+			SourceLanguage: subtitle.SourceLanguage,
+		}
+		if err := s.notifier.SendSubtitleReadyAlert(ctx, notification); err != nil {
+			logger.WarnContext(ctx, "Failed to send subtitle ready notification",
+				"subtitle_id", subtitle.ID,
+				"language", subtitle.Language,
+				"error", err,
+			)
+		}
+	}()
+}
+
 // HandleSubtitleFailed callback จาก worker เมื่อ job ล้มเหลว
 func (s *SubtitleServiceImpl) HandleSubtitleFailed(ctx context.Context, subtitleID uuid.UUID, req *dto.SubtitleFailedRequest) error {
 	logger.WarnContext(ctx, "Handling subtitle failed callback",
@@ -567,6 +633,23 @@ func (s *SubtitleServiceImpl) MarkJobStarted(ctx context.Context, subtitleID uui
 	return nil
 }
 
+// ErrSubtitleNotProcessing subtitle ไม่ได้อยู่ระหว่าง processing (ถูก mark failed/เสร็จไปแล้ว)
+var ErrSubtitleNotProcessing = errors.New("subtitle is not processing")
+
+// MarkJobHeartbeat callback จาก worker ระหว่างทำ job
+// stuck detector ใช้ last_heartbeat_at ตัดสินว่า worker ยังทำงานอยู่
+func (s *SubtitleServiceImpl) MarkJobHeartbeat(ctx context.Context, subtitleID uuid.UUID) error {
+	updated, err := s.subtitleRepo.UpdateHeartbeat(ctx, subtitleID, time.Now())
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to update heartbeat", "subtitle_id", subtitleID, "error", err)
+		return err
+	}
+	if !updated {
+		return ErrSubtitleNotProcessing
+	}
+	return nil
+}
+
 // === Content Edit Operations ===
 
 // GetSubtitleContent ดึง content ของ subtitle (SRT file)
@@ -648,7 +731,10 @@ func (s *SubtitleServiceImpl) UpdateSubtitleContent(ctx context.Context, subtitl
 		return fmt.Errorf("cannot edit subtitle with status '%s'", subtitle.Status)
 	}
 
-	// 4. อัปโหลดไฟล์ใหม่ไปยัง storage (overwrite)
+	// 4. เก็บ version ก่อนหน้า (N-1) ไว้สำหรับ diff ตอน review
+	s.snapshotPreviousSRT(ctx, subtitle, content)
+
+	// 5. อัปโหลดไฟล์ใหม่ไปยัง storage (overwrite)
 	reader := bytes.NewReader([]byte(content))
 	_, err = s.storage.UploadFile(reader, subtitle.SRTPath, "text/plain; charset=utf-8")
 	if err != nil {
@@ -660,7 +746,7 @@ func (s *SubtitleServiceImpl) UpdateSubtitleContent(ctx context.Context, subtitl
 		return fmt.Errorf("failed to save SRT file: %w", err)
 	}
 
-	// 5. อัปเดต timestamp ของ subtitle record
+	// 6. อัปเดต timestamp ของ subtitle record
 	subtitle.UpdatedAt = time.Now()
 	if err := s.subtitleRepo.Update(ctx, subtitle); err != nil {
 		logger.WarnContext(ctx, "Failed to update subtitle timestamp", "subtitle_id", subtitleID, "error", err)
@@ -671,6 +757,7 @@ func (s *SubtitleServiceImpl) UpdateSubtitleContent(ctx context.Context, subtitl
 		"subtitle_id", subtitleID,
 		"srt_path", subtitle.SRTPath,
 	)
+	s.purgeSubtitleCDN(subtitle.SRTPath)
 
 	return nil
 }
@@ -722,6 +809,32 @@ func (s *SubtitleServiceImpl) RetryStuckSubtitles(ctx context.Context) (*dto.Ret
 		return nil, err
 	}
 
+	return s.retryQueuedSubtitles(ctx, stuckSubtitles)
+}
+
+// RetryStaleQueuedSubtitles retry เฉพาะ subtitles ที่ queued นานกว่า olderThan
+// subtitle ที่เพิ่ง queue (กำลัง publish / worker เพิ่งรับไป) จะไม่ถูกส่งซ้ำ
+func (s *SubtitleServiceImpl) RetryStaleQueuedSubtitles(ctx context.Context, olderThan time.Duration) (*dto.RetryStuckResponse, error) {
+	queued, err := s.subtitleRepo.GetByStatus(ctx, models.SubtitleStatusQueued)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to get queued subtitles", "error", err)
+		return nil, err
+	}
+
+	threshold := time.Now().Add(-olderThan)
+	var stale []*models.Subtitle
+	for _, subtitle := range queued {
+		if subtitle.UpdatedAt.Before(threshold) {
+			stale = append(stale, subtitle)
+		}
+	}
+
+	return s.retryQueuedSubtitles(ctx, stale)
+}
+
+// retryQueuedSubtitles republish jobs ของ subtitles ที่ queued
+// หลัง publish สำเร็จจะ touch updated_at - รอบถัดไปของ auto-retry จะนับเวลาใหม่
+func (s *SubtitleServiceImpl) retryQueuedSubtitles(ctx context.Context, stuckSubtitles []*models.Subtitle) (*dto.RetryStuckResponse, error) {
 	response := &dto.RetryStuckResponse{
 		TotalFound: len(stuckSubtitles),
 	}
@@ -846,6 +959,10 @@ func (s *SubtitleServiceImpl) RetryStuckSubtitles(ctx context.Context) (*dto.Ret
 			continue
 		}
 
+		if err := s.subtitleRepo.UpdateStatus(ctx, subtitle.ID, models.SubtitleStatusQueued, ""); err != nil {
+			logger.WarnContext(ctx, "Failed to touch retried subtitle", "subtitle_id", subtitle.ID, "error", err)
+		}
+
 		response.TotalRetried++
 	}
 
@@ -860,3 +977,107 @@ func (s *SubtitleServiceImpl) RetryStuckSubtitles(ctx context.Context) (*dto.Ret
 
 	return response, nil
 }
+
+// === Bulk Operations ===
+
+// Bulk transcribe limits (ไม่ให้ ASR workers ล้น)
+const (
+	bulkTranscribeDefaultJobs = 20
+	bulkTranscribeMaxJobs     = 100
+	bulkTranscribeInterval    = 200 * time.Millisecond // เว้นระยะระหว่าง jobs
+	bulkTranscribePageSize    = 100
+	bulkTranscribeMaxScan     = 1000 // จำนวน videos สูงสุดที่ตรวจสอบต่อรอบ
+)
+
+// ListReadyWithoutSubtitles ดึง videos ที่มี audio แต่ยังไม่มี original subtitle ที่ ready
+func (s *SubtitleServiceImpl) ListReadyWithoutSubtitles(ctx context.Context, page, limit int) ([]dto.SubtitleMissingItem, int64, error) {
+	offset := (page - 1) * limit
+	videos, total, err := s.videoRepo.ListReadyWithoutSubtitles(ctx, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]dto.SubtitleMissingItem, len(videos))
+	for i, v := range videos {
+		items[i] = dto.SubtitleMissingItem{
+			ID:               v.ID,
+			Code:             v.Code,
+			Title:            v.Title,
+			DetectedLanguage: v.DetectedLanguage,
+			CreatedAt:        v.CreatedAt,
+		}
+	}
+
+	return items, total, nil
+}
+
+// TriggerTranscribeBulk เรียก TriggerTranscribe ทีละ video (ใช้ dedup logic เดิม)
+// video ที่กำลังทำอยู่จะถูกนับเป็น InProgress และข้ามไป
+func (s *SubtitleServiceImpl) TriggerTranscribeBulk(ctx context.Context, maxJobs int) (*dto.BulkTranscribeResponse, error) {
+	if maxJobs <= 0 {
+		maxJobs = bulkTranscribeDefaultJobs
+	}
+	if maxJobs > bulkTranscribeMaxJobs {
+		maxJobs = bulkTranscribeMaxJobs
+	}
+
+	logger.InfoContext(ctx, "Starting bulk transcribe", "max_jobs", maxJobs)
+
+	response := &dto.BulkTranscribeResponse{}
+
+	offset := 0
+	for response.TotalQueued < maxJobs && response.TotalScanned < bulkTranscribeMaxScan {
+		videos, total, err := s.videoRepo.ListReadyWithoutSubtitles(ctx, offset, bulkTranscribePageSize)
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to list videos without subtitles", "error", err)
+			return nil, err
+		}
+		if offset == 0 {
+			response.TotalMissing = total
+		}
+		if len(videos) == 0 {
+			break
+		}
+		offset += len(videos)
+
+		for _, video := range videos {
+			if response.TotalQueued >= maxJobs {
+				break
+			}
+			response.TotalScanned++
+
+			_, err := s.TriggerTranscribe(ctx, video.ID)
+			if err != nil {
+				if errors.Is(err, ErrTranscriptionInProgress) {
+					response.InProgress++
+					continue
+				}
+				response.Errors = append(response.Errors, fmt.Sprintf("%s: %v", video.Code, err))
+				response.Skipped++
+				continue
+			}
+			response.TotalQueued++
+
+			// Rate limit: เว้นระยะหลัง queue สำเร็จ (หยุดถ้า request ถูก cancel)
+			select {
+			case <-ctx.Done():
+				response.Message = fmt.Sprintf("Interrupted after queueing %d videos", response.TotalQueued)
+				return response, nil
+			case <-time.After(bulkTranscribeInterval):
+			}
+		}
+	}
+
+	response.Message = fmt.Sprintf("Queued %d videos for transcription (%d in progress, %d skipped, %d missing total)",
+		response.TotalQueued, response.InProgress, response.Skipped, response.TotalMissing)
+
+	logger.InfoContext(ctx, "Bulk transcribe completed",
+		"total_missing", response.TotalMissing,
+		"total_scanned", response.TotalScanned,
+		"total_queued", response.TotalQueued,
+		"in_progress", response.InProgress,
+		"skipped", response.Skipped,
+	)
+
+	return response, nil
+}
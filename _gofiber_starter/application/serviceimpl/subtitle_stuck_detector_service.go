@@ -2,6 +2,7 @@ package serviceimpl
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"gofiber-template/domain/repositories"
@@ -12,7 +13,8 @@ import (
 // SubtitleStuckDetectorConfig การตั้งค่าสำหรับ subtitle stuck detector
 type SubtitleStuckDetectorConfig struct {
 	CheckInterval     time.Duration // ทุกกี่วินาทีจะตรวจสอบ (default: 30s)
-	ProcessingTimeout time.Duration // processing/translating นานกว่านี้ถือว่า stuck (default: 10m)
+	ProcessingTimeout time.Duration // worker ไม่ส่ง heartbeat: processing/translating นานกว่านี้ถือว่า stuck (default: 10m)
+	HeartbeatTimeout  time.Duration // worker ส่ง heartbeat: ขาด heartbeat นานกว่านี้ถือว่า stuck (default: 2m)
 	// ไม่มี QueuedTimeout - jobs รอใน queue ได้นานเท่าที่ต้องการ
 	// เหตุผล: ถ้ามี 100 subtitles และ transcribe ทำได้ตัวละ 5 นาที = 500 นาที (~8 ชั่วโมง)
 	// queued timeout จะทำให้ jobs หลังๆ fail โดยไม่จำเป็น
@@ -44,6 +46,9 @@ func NewSubtitleStuckDetectorService(
 	if service.config.ProcessingTimeout == 0 {
 		service.config.ProcessingTimeout = 10 * time.Minute // ถ้า worker ไม่ respond 10 นาที = crash
 	}
+	if service.config.HeartbeatTimeout == 0 {
+		service.config.HeartbeatTimeout = 2 * time.Minute // ขาด heartbeat หลายรอบ = worker ตาย
+	}
 	// ไม่มี queued timeout - รอใน queue ได้ไม่จำกัด
 
 	return service
@@ -71,11 +76,14 @@ func (s *SubtitleStuckDetectorService) RunDetection(ctx context.Context) {
 	}
 }
 
-// detectStuckProcessing ตรวจสอบ subtitles ที่ processing/translating/detecting นานเกินไป (worker crash)
+// detectStuckProcessing ตรวจสอบ subtitles ที่ processing/translating/detecting แล้ว worker เงียบไป (worker crash)
+// job ที่มี heartbeat ทำได้นานเท่าที่ต้องการ ตราบใดที่ heartbeat ยังมา
 func (s *SubtitleStuckDetectorService) detectStuckProcessing(ctx context.Context) int {
-	threshold := time.Now().Add(-s.config.ProcessingTimeout)
+	now := time.Now()
+	heartbeatThreshold := now.Add(-s.config.HeartbeatTimeout)
+	startedThreshold := now.Add(-s.config.ProcessingTimeout)
 
-	stuckSubtitles, err := s.subtitleRepo.GetStuckProcessing(ctx, threshold)
+	stuckSubtitles, err := s.subtitleRepo.GetStuckProcessing(ctx, heartbeatThreshold, startedThreshold)
 	if err != nil {
 		logger.ErrorContext(ctx, "Failed to get stuck processing subtitles", "error", err)
 		return 0
@@ -89,11 +97,14 @@ func (s *SubtitleStuckDetectorService) detectStuckProcessing(ctx context.Context
 			"language", subtitle.Language,
 			"status", subtitle.Status,
 			"processing_started_at", subtitle.ProcessingStartedAt,
-			"timeout", s.config.ProcessingTimeout,
+			"last_heartbeat_at", subtitle.LastHeartbeatAt,
 		)
 
 		// Mark as failed
-		errorMsg := "Processing timeout: worker not responding for more than 10 minutes"
+		errorMsg := fmt.Sprintf("Processing timeout: worker not responding for more than %s", s.config.ProcessingTimeout)
+		if subtitle.LastHeartbeatAt != nil {
+			errorMsg = fmt.Sprintf("Heartbeat timeout: no worker heartbeat for more than %s", s.config.HeartbeatTimeout)
+		}
 		if err := s.subtitleRepo.MarkSubtitleFailed(ctx, subtitle.ID, errorMsg); err != nil {
 			logger.ErrorContext(ctx, "Failed to mark subtitle as failed", "subtitle_id", subtitle.ID, "error", err)
 			continue
@@ -16,6 +16,7 @@ import (
 	"gofiber-template/domain/services"
 	"gofiber-template/pkg/logger"
 	"gofiber-template/pkg/progress"
+	"gofiber-template/pkg/settings"
 )
 
 type TranscodingConfig struct {
@@ -61,6 +62,12 @@ func NewTranscodingService(
 
 // QueueTranscoding ส่งวิดีโอเข้า transcoding queue (NATS Distributed Workers)
 func (s *TranscodingServiceImpl) QueueTranscoding(ctx context.Context, videoID uuid.UUID) error {
+	return s.QueueTranscodingWithPriority(ctx, videoID, ports.JobPriorityNormal)
+}
+
+// QueueTranscodingWithPriority ส่งวิดีโอเข้า transcoding queue ตาม priority
+// urgent = bump job ที่รออยู่แล้ว → ไม่ตรวจ queue overflow (แทนที่ job เดิม ไม่ได้เพิ่มงาน)
+func (s *TranscodingServiceImpl) QueueTranscodingWithPriority(ctx context.Context, videoID uuid.UUID, priority int) error {
 	// ตรวจสอบว่า video มีอยู่จริง
 	video, err := s.videoRepo.GetByID(ctx, videoID)
 	if err != nil {
@@ -68,14 +75,15 @@ func (s *TranscodingServiceImpl) QueueTranscoding(ctx context.Context, videoID u
 		return errors.New("video not found")
 	}
 
-	// ตรวจสอบว่า video อยู่ในสถานะที่สามารถ transcode ได้ (pending, queued หรือ failed สำหรับ retry)
-	if video.Status != models.VideoStatusPending && video.Status != models.VideoStatusQueued && video.Status != models.VideoStatusFailed {
+	// ตรวจสอบว่า video อยู่ในสถานะที่สามารถ transcode ได้ (pending, queued หรือ failed/cancelled สำหรับ retry)
+	retryable := video.Status == models.VideoStatusFailed || video.Status == models.VideoStatusCancelled
+	if video.Status != models.VideoStatusPending && video.Status != models.VideoStatusQueued && !retryable {
 		logger.WarnContext(ctx, "Video not in valid status for transcoding", "video_id", videoID, "status", video.Status)
-		return fmt.Errorf("video must be in pending, queued or failed status to queue (current: %s)", video.Status)
+		return fmt.Errorf("video must be in pending, queued, failed or cancelled status to queue (current: %s)", video.Status)
 	}
 
 	// Reset status เป็น pending ก่อน queue (สำหรับ retry)
-	if video.Status == models.VideoStatusFailed {
+	if retryable {
 		if err := s.videoRepo.UpdateStatus(ctx, videoID, models.VideoStatusPending); err != nil {
 			logger.ErrorContext(ctx, "Failed to reset video status", "video_id", videoID, "error", err)
 			return fmt.Errorf("failed to reset video status: %w", err)
@@ -90,9 +98,11 @@ func (s *TranscodingServiceImpl) QueueTranscoding(ctx context.Context, videoID u
 	}
 
 	// ตรวจสอบ queue overflow protection
-	if err := s.checkQueueOverflow(ctx); err != nil {
-		logger.WarnContext(ctx, "Queue overflow protection triggered", "video_id", videoID, "error", err)
-		return err
+	if priority != ports.JobPriorityUrgent {
+		if err := s.checkQueueOverflow(ctx); err != nil {
+			logger.WarnContext(ctx, "Queue overflow protection triggered", "video_id", videoID, "error", err)
+			return err
+		}
 	}
 
 	// กำหนด qualities จาก Settings (runtime config)
@@ -114,6 +124,7 @@ func (s *TranscodingServiceImpl) QueueTranscoding(ctx context.Context, videoID u
 		Codec:        "h264",
 		Qualities:    qualities,
 		UseByteRange: false,
+		Priority:     priority,
 	}
 
 	if err := s.jobQueue.PublishJob(ctx, jobData); err != nil {
@@ -125,6 +136,7 @@ func (s *TranscodingServiceImpl) QueueTranscoding(ctx context.Context, videoID u
 		"video_id", videoID,
 		"code", video.Code,
 		"qualities_sent", qualities,
+		"priority", priority,
 	)
 
 	return nil
@@ -465,18 +477,9 @@ func (s *TranscodingServiceImpl) getDefaultQualities(ctx context.Context) []stri
 		return defaultQualities
 	}
 
-	// แยก comma-separated string เป็น slice
-	parts := strings.Split(qualitiesStr, ",")
-	var qualities []string
-	for _, p := range parts {
-		q := strings.TrimSpace(p)
-		if q != "" {
-			qualities = append(qualities, q)
-		}
-	}
-
-	if len(qualities) == 0 {
-		logger.WarnContext(ctx, "Parsed qualities empty, using defaults", "qualities", defaultQualities)
+	qualities, err := settings.ParseQualities(qualitiesStr)
+	if err != nil {
+		logger.WarnContext(ctx, "Invalid qualities in settings, using defaults", "raw_value", qualitiesStr, "error", err, "qualities", defaultQualities)
 		return defaultQualities
 	}
 
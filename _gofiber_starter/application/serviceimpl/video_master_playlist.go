@@ -0,0 +1,97 @@
+package serviceimpl
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"gofiber-template/domain/ports"
+	"gofiber-template/domain/services"
+	"gofiber-template/infrastructure/transcoder"
+	"gofiber-template/pkg/logger"
+)
+
+// ErrNoVariantPlaylists ไม่มี variant playlist ให้ใส่ใน master (storage ไม่มีไฟล์ / quality ไม่รู้จัก)
+var ErrNoVariantPlaylists = errors.New("no variant playlists found")
+
+// RebuildMasterPlaylist สร้าง master.m3u8 ใหม่จาก variant playlists ที่มีอยู่จริงใน storage
+// ใช้ซ่อม master ที่หาย/เสีย หรืออ้าง quality ที่ไม่มีไฟล์
+// video ไม่มี QualitySizes (ข้อมูลเก่า) = ตรวจหาจาก DefaultQualityProfiles แทน
+func (s *VideoServiceImpl) RebuildMasterPlaylist(ctx context.Context, code string) (*services.MasterPlaylistResult, error) {
+	video, err := findVideoByCode(ctx, s.videoRepo, code)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := video.GetQualities()
+	if len(candidates) == 0 {
+		for _, p := range ports.DefaultQualityProfiles {
+			candidates = append(candidates, p.Name)
+		}
+	}
+
+	result := &services.MasterPlaylistResult{
+		Qualities: []string{},
+		Missing:   []string{},
+	}
+
+	var available []string
+	for _, quality := range candidates {
+		playlistPath := fmt.Sprintf("hls/%s/%s/playlist.m3u8", video.Code, quality)
+		if _, err := s.storage.StatObject(playlistPath); err != nil {
+			if !errors.Is(err, ports.ErrObjectNotFound) {
+				logger.WarnContext(ctx, "Failed to stat variant playlist", "path", playlistPath, "error", err)
+			}
+			result.Missing = append(result.Missing, quality)
+			continue
+		}
+		available = append(available, quality)
+	}
+
+	if len(available) == 0 {
+		return nil, ErrNoVariantPlaylists
+	}
+
+	path, qualities, err := s.buildMasterPlaylist(ctx, video.Code, available, video.QualitySizes, video.Duration)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Path = path
+	result.Qualities = qualities
+
+	logger.InfoContext(ctx, "Master playlist rebuilt",
+		"code", video.Code,
+		"qualities", qualities,
+		"missing", result.Missing,
+	)
+	return result, nil
+}
+
+// buildMasterPlaylist สร้างและ upload hls/{code}/master.m3u8 จาก qualities ที่ระบุ
+// bandwidth/resolution มาจาก quality profile + ขนาดไฟล์ใน QualitySizes (ถ้ามี)
+func (s *VideoServiceImpl) buildMasterPlaylist(ctx context.Context, code string, qualities []string, qualitySizes map[string]int64, durationSec int) (string, []string, error) {
+	var variants []transcoder.MasterPlaylistVariant
+	included := []string{}
+	for _, quality := range qualities {
+		variant, ok := transcoder.VariantFromQuality(quality, qualitySizes[quality], durationSec)
+		if !ok {
+			logger.WarnContext(ctx, "Skipping unknown quality for master playlist", "code", code, "quality", quality)
+			continue
+		}
+		variants = append(variants, variant)
+		included = append(included, quality)
+	}
+	if len(variants) == 0 {
+		return "", nil, ErrNoVariantPlaylists
+	}
+
+	masterPath := fmt.Sprintf("hls/%s/master.m3u8", code)
+	content := transcoder.BuildMasterPlaylist(variants)
+	if _, err := s.storage.UploadFile(bytes.NewReader([]byte(content)), masterPath, "application/vnd.apple.mpegurl"); err != nil {
+		return "", nil, fmt.Errorf("failed to upload master playlist: %w", err)
+	}
+
+	return masterPath, included, nil
+}
@@ -0,0 +1,25 @@
+package serviceimpl
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"gofiber-template/domain/dto"
+	"gofiber-template/pkg/logger"
+)
+
+// GetVideoCost ดึง processing cost ของทุก worker job ของ video
+func (s *VideoServiceImpl) GetVideoCost(ctx context.Context, videoID uuid.UUID) (*dto.VideoCostResponse, error) {
+	if _, err := findVideo(ctx, s.videoRepo, videoID); err != nil {
+		return nil, err
+	}
+
+	costs, err := s.costRepo.GetByVideoID(ctx, videoID)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to get processing costs", "video_id", videoID, "error", err)
+		return nil, err
+	}
+
+	return dto.ProcessingCostsToVideoCostResponse(videoID, costs), nil
+}
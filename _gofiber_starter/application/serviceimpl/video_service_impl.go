@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 
 	"gofiber-template/domain/dto"
 	"gofiber-template/domain/models"
@@ -22,16 +23,43 @@ import (
 	"gofiber-template/pkg/utils"
 )
 
-// Storage quota errors
+// Storage / lookup errors
 var (
 	ErrStorageQuotaExceeded = errors.New("storage quota exceeded")
+	ErrStorageUploadFailed  = errors.New("storage upload failed")
+	ErrVideoNotFound        = errors.New("video not found")
 )
 
+// findVideo ดึง video - ไม่มี record = ErrVideoNotFound, DB error อื่นส่งต่อ (handler ตอบ 500)
+func findVideo(ctx context.Context, videoRepo repositories.VideoRepository, id uuid.UUID) (*models.Video, error) {
+	video, err := videoRepo.GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrVideoNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get video: %w", err)
+	}
+	return video, nil
+}
+
+// findVideoByCode เหมือน findVideo แต่ค้นด้วย code
+func findVideoByCode(ctx context.Context, videoRepo repositories.VideoRepository, code string) (*models.Video, error) {
+	video, err := videoRepo.GetByCode(ctx, code)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrVideoNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get video: %w", err)
+	}
+	return video, nil
+}
+
 const (
 	// Cache keys และ TTLs สำหรับ Video
-	videoCachePrefix   = "video:"
-	videoCodeCacheKey  = "video:code:"
-	videoCacheTTL      = 1 * time.Minute // Cache video 1 นาที
+	videoCachePrefix  = "video:"
+	videoCodeCacheKey = "video:code:"
+	videoCacheTTL     = 1 * time.Minute // Cache video 1 นาที
+	videoCacheGrace   = 5 * time.Minute // สำเนาสำรองอยู่ต่ออีก 5 นาที - ใช้ตอน DB ล่มชั่วคราว
 )
 
 type VideoServiceImpl struct {
@@ -40,9 +68,16 @@ type VideoServiceImpl struct {
 	userRepo     repositories.UserRepository
 	subtitleRepo repositories.SubtitleRepository
 	reelRepo     repositories.ReelRepository // สำหรับนับ reel count
+	costRepo     repositories.ProcessingCostRepository
 	storage      ports.StoragePort
-	redisClient  *redis.Client  // optional - ถ้าไม่มีจะ query DB ตลอด
-	config       *config.Config // for storage quota
+	redisClient  *redis.Client       // optional - ถ้าไม่มีจะ query DB ตลอด
+	config       *config.Config      // for storage quota
+	cdnPurger    ports.CDNPurgerPort // ล้าง edge cache เมื่อแก้/ลบ (optional - SetCDNPurger)
+}
+
+// SetCDNPurger ตั้ง purger สำหรับล้าง cache ที่ CDN เมื่อ status/gallery เปลี่ยนหรือวิดีโอถูกลบ
+func (s *VideoServiceImpl) SetCDNPurger(purger ports.CDNPurgerPort) {
+	s.cdnPurger = purger
 }
 
 func NewVideoService(
@@ -51,6 +86,7 @@ func NewVideoService(
 	userRepo repositories.UserRepository,
 	subtitleRepo repositories.SubtitleRepository,
 	reelRepo repositories.ReelRepository,
+	costRepo repositories.ProcessingCostRepository,
 	storage ports.StoragePort,
 	cfg *config.Config,
 ) services.VideoService {
@@ -60,6 +96,7 @@ func NewVideoService(
 		userRepo:     userRepo,
 		subtitleRepo: subtitleRepo,
 		reelRepo:     reelRepo,
+		costRepo:     costRepo,
 		storage:      storage,
 		config:       cfg,
 		redisClient:  nil,
@@ -73,6 +110,7 @@ func NewVideoServiceWithCache(
 	userRepo repositories.UserRepository,
 	subtitleRepo repositories.SubtitleRepository,
 	reelRepo repositories.ReelRepository,
+	costRepo repositories.ProcessingCostRepository,
 	storage ports.StoragePort,
 	redisClient *redis.Client,
 	cfg *config.Config,
@@ -83,6 +121,7 @@ func NewVideoServiceWithCache(
 		userRepo:     userRepo,
 		subtitleRepo: subtitleRepo,
 		reelRepo:     reelRepo,
+		costRepo:     costRepo,
 		storage:      storage,
 		redisClient:  redisClient,
 		config:       cfg,
@@ -132,7 +171,7 @@ func (s *VideoServiceImpl) Upload(ctx context.Context, userID uuid.UUID, fileHea
 	_, err = s.storage.UploadFile(file, storagePath, mimeType)
 	if err != nil {
 		logger.ErrorContext(ctx, "Failed to upload video to storage", "path", storagePath, "error", err)
-		return nil, err
+		return nil, fmt.Errorf("%w: %v", ErrStorageUploadFailed, err)
 	}
 
 	// สร้าง video record
@@ -201,11 +240,12 @@ func (s *VideoServiceImpl) GetByID(ctx context.Context, id uuid.UUID) (*models.V
 
 func (s *VideoServiceImpl) GetByCode(ctx context.Context, code string) (*models.Video, error) {
 	// ถ้ามี Redis cache ใช้ GetOrSet pattern (Singleflight)
+	// DB error ระหว่าง cache miss → ใช้สำเนาสำรอง (grace) ถ้ายังอยู่ ให้ embed เล่นต่อได้
 	if s.redisClient != nil {
 		cacheKey := videoCodeCacheKey + code
 		var video models.Video
 
-		err := s.redisClient.GetOrSet(ctx, cacheKey, &video, videoCacheTTL, func() (interface{}, error) {
+		err := s.redisClient.GetOrSetWithGrace(ctx, cacheKey, &video, videoCacheTTL, videoCacheGrace, func() (interface{}, error) {
 			// Fetch from DB
 			v, err := s.videoRepo.GetByCode(ctx, code)
 			if err != nil {
@@ -279,8 +319,24 @@ func (s *VideoServiceImpl) ListVideos(ctx context.Context, page, limit int) ([]*
 	return videos, total, nil
 }
 
+func (s *VideoServiceImpl) ListWithFiltersCursor(ctx context.Context, params *dto.VideoFilterRequest) ([]*models.Video, string, error) {
+	videos, nextCursor, err := s.videoRepo.ListWithFiltersCursor(ctx, params)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to list videos with filters by cursor", "error", err)
+		return nil, "", err
+	}
+
+	return videos, nextCursor, nil
+}
+
 func (s *VideoServiceImpl) ListWithFilters(ctx context.Context, params *dto.VideoFilterRequest) ([]*models.Video, int64, error) {
-	videos, total, err := s.videoRepo.ListWithFilters(ctx, params)
+	// มีคำค้น = ranked search (เรียงตามความเกี่ยวข้อง)
+	list := s.videoRepo.ListWithFilters
+	if params.Search != "" {
+		list = s.videoRepo.SearchWithFilters
+	}
+
+	videos, total, err := list(ctx, params)
 	if err != nil {
 		logger.ErrorContext(ctx, "Failed to list videos with filters", "error", err)
 		return nil, 0, err
@@ -369,6 +425,8 @@ func (s *VideoServiceImpl) Update(ctx context.Context, id uuid.UUID, req *dto.Up
 	}
 
 	// Gallery fields - Manual Selection Flow
+	galleryChanged := req.GalleryPath != nil || req.GalleryStatus != nil || req.GalleryCount != nil ||
+		req.GallerySafeCount != nil || req.GalleryNsfwCount != nil || req.GalleryCoverOverride != nil
 	if req.GalleryPath != nil {
 		video.GalleryPath = *req.GalleryPath
 	}
@@ -390,6 +448,12 @@ func (s *VideoServiceImpl) Update(ctx context.Context, id uuid.UUID, req *dto.Up
 	if req.GallerySuperSafeCount != nil {
 		video.GallerySuperSafeCount = *req.GallerySuperSafeCount // Deprecated
 	}
+	if req.GalleryLimits != nil {
+		video.GalleryLimits = req.GalleryLimits
+	}
+	if req.GalleryCoverOverride != nil {
+		video.GalleryCoverOverride = *req.GalleryCoverOverride
+	}
 
 	video.UpdatedAt = time.Now()
 
@@ -398,6 +462,10 @@ func (s *VideoServiceImpl) Update(ctx context.Context, id uuid.UUID, req *dto.Up
 		return nil, err
 	}
 
+	if galleryChanged {
+		go s.purgeVideoCDN(video.Code, "gallery updated", nil, []string{fmt.Sprintf("gallery/%s/", video.Code)})
+	}
+
 	logger.InfoContext(ctx, "Video updated", "video_id", id)
 	return video, nil
 }
@@ -450,12 +518,24 @@ func (s *VideoServiceImpl) Delete(ctx context.Context, id uuid.UUID) error {
 		return err
 	}
 
+	s.invalidateVideoCache(ctx, videoCode)
+
 	logger.InfoContext(ctx, "Video record deleted, cleaning up files in background", "video_id", id, "video_code", videoCode)
 
 	// ลบไฟล์ใน background (ไม่ block response)
 	go func() {
 		bgCtx := context.Background()
 
+		// รวมรายการ purge ก่อนลบไฟล์ (CDN ที่ไม่รองรับ prefix ต้อง list ไฟล์จาก storage)
+		var cdnPurge *ports.CDNPurgeRequest
+		if videoCode != "" {
+			cdnPurge = buildCDNPurge(bgCtx, s.cdnPurger, s.storage, nil, []string{
+				fmt.Sprintf("hls/%s/", videoCode),
+				fmt.Sprintf("subtitles/%s/", videoCode),
+				fmt.Sprintf("gallery/%s/", videoCode),
+			})
+		}
+
 		// ลบไฟล์ original จาก storage
 		if originalPath != "" {
 			if err := s.storage.DeleteFile(originalPath); err != nil {
@@ -513,6 +593,9 @@ func (s *VideoServiceImpl) Delete(ctx context.Context, id uuid.UUID) error {
 			}
 		}
 
+		// purge หลังลบไฟล์ - edge ที่ดึงใหม่จะได้ 404 ไม่ใช่ไฟล์เดิม
+		purgeCDN(bgCtx, s.cdnPurger, cdnPurge, "video deleted")
+
 		logger.InfoContext(bgCtx, "Video files cleanup completed", "video_code", videoCode)
 	}()
 
@@ -628,24 +711,97 @@ func (s *VideoServiceImpl) UpdateVideoStatus(ctx context.Context, id uuid.UUID,
 
 	// Invalidate cache
 	s.invalidateVideoCache(ctx, video.Code)
+	go s.purgeVideoCDN(video.Code, "status updated", hlsPlaylistPaths(video), nil)
 
 	logger.InfoContext(ctx, "Video status updated", "video_id", id, "status", status)
 	return nil
 }
 
+// purgeVideoCDN ล้าง edge cache ของวิดีโอ (เรียกแบบ goroutine - ไม่ block request)
+func (s *VideoServiceImpl) purgeVideoCDN(code, reason string, files, prefixes []string) {
+	if s.cdnPurger == nil || code == "" {
+		return
+	}
+	ctx := context.Background()
+	purgeCDN(ctx, s.cdnPurger, buildCDNPurge(ctx, s.cdnPurger, s.storage, files, prefixes), reason)
+}
+
+// hlsPlaylistPaths path ของ playlists (master + ทุก quality) - segments ไม่เปลี่ยนตาม status
+func hlsPlaylistPaths(video *models.Video) []string {
+	paths := []string{fmt.Sprintf("hls/%s/master.m3u8", video.Code)}
+	for quality := range video.QualitySizes {
+		paths = append(paths, fmt.Sprintf("hls/%s/%s/playlist.m3u8", video.Code, quality))
+	}
+	return paths
+}
+
 // invalidateVideoCache ลบ cache ของ video
 func (s *VideoServiceImpl) invalidateVideoCache(ctx context.Context, code string) {
 	if s.redisClient == nil {
 		return
 	}
 	cacheKey := videoCodeCacheKey + code
-	if err := s.redisClient.Del(ctx, cacheKey); err != nil {
+	if err := s.redisClient.Del(ctx, cacheKey, redis.StaleKey(cacheKey)); err != nil {
 		logger.WarnContext(ctx, "Failed to invalidate video cache", "code", code, "error", err)
 	} else {
 		logger.InfoContext(ctx, "Video cache invalidated", "code", code)
 	}
 }
 
+// RefreshVideoCache ลบ cache แล้วโหลดจาก DB ใส่ cache ใหม่ทันที
+// ใช้หลังแก้ข้อมูลใน DB ตรงๆ (ไม่ผ่าน service) ไม่ต้องรอ TTL หมด
+func (s *VideoServiceImpl) RefreshVideoCache(ctx context.Context, code string) (*models.Video, error) {
+	video, err := findVideoByCode(ctx, s.videoRepo, code)
+	if errors.Is(err, ErrVideoNotFound) {
+		// ไม่มีใน DB แล้ว - ลบ cache ที่ค้างอยู่ทิ้ง
+		s.invalidateVideoCache(ctx, code)
+		logger.WarnContext(ctx, "Video not found for cache refresh", "code", code)
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if s.redisClient == nil {
+		return video, nil // No Redis, no cache to refresh
+	}
+
+	cacheKey := videoCodeCacheKey + code
+	if err := s.redisClient.Del(ctx, cacheKey); err != nil {
+		logger.WarnContext(ctx, "Failed to delete video cache", "code", code, "error", err)
+		return nil, err
+	}
+	if err := s.redisClient.SetJSON(ctx, cacheKey, video, videoCacheTTL); err != nil {
+		// ลบแล้วแต่ set ไม่ได้ - request ถัดไปจะโหลดจาก DB เอง
+		logger.WarnContext(ctx, "Failed to repopulate video cache", "code", code, "error", err)
+		return video, nil
+	}
+	// สำเนาสำรองต้องเป็นข้อมูลใหม่ด้วย ไม่งั้นตอน DB ล่มจะได้ค่าก่อนแก้
+	if err := s.redisClient.SetJSON(ctx, redis.StaleKey(cacheKey), video, videoCacheTTL+videoCacheGrace); err != nil {
+		logger.WarnContext(ctx, "Failed to repopulate stale video cache", "code", code, "error", err)
+	}
+
+	logger.InfoContext(ctx, "Video cache refreshed", "code", code)
+	return video, nil
+}
+
+// PurgeAllVideoCache ลบ cache ของ video ทั้งหมด (สำหรับ Admin)
+func (s *VideoServiceImpl) PurgeAllVideoCache(ctx context.Context) (int64, error) {
+	if s.redisClient == nil {
+		return 0, nil // No Redis, no cache to purge
+	}
+
+	pattern := videoCodeCacheKey + "*"
+	deleted, err := s.redisClient.ScanAndDelete(ctx, pattern)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to purge video cache", "pattern", pattern, "error", err)
+		return 0, err
+	}
+
+	logger.InfoContext(ctx, "All video cache purged", "deleted", deleted)
+	return deleted, nil
+}
+
 // ResetVideoForRetry reset video สำหรับ retry จาก DLQ (ล้าง retry_count และ last_error)
 func (s *VideoServiceImpl) ResetVideoForRetry(ctx context.Context, id uuid.UUID) error {
 	video, err := s.videoRepo.GetByID(ctx, id)
@@ -709,6 +865,31 @@ func (s *VideoServiceImpl) CheckStorageQuota(ctx context.Context) error {
 	return nil
 }
 
+// CheckStorageQuotaFor ตรวจสอบว่าเพิ่มไฟล์ขนาด size (bytes) แล้วยังไม่เกิน quota
+// ใช้เมื่อรู้ขนาดไฟล์แล้ว (หลัง direct upload / ก่อน batch upload)
+func (s *VideoServiceImpl) CheckStorageQuotaFor(ctx context.Context, size int64) error {
+	if s.config == nil || s.config.Storage.QuotaTotal <= 0 {
+		return nil
+	}
+
+	totalUsed, err := s.videoRepo.GetTotalStorageUsed(ctx)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to get total storage used", "error", err)
+		return err
+	}
+
+	if totalUsed+size > s.config.Storage.QuotaTotal {
+		logger.WarnContext(ctx, "Storage quota exceeded",
+			"current_used", totalUsed,
+			"size", size,
+			"quota", s.config.Storage.QuotaTotal,
+		)
+		return ErrStorageQuotaExceeded
+	}
+
+	return nil
+}
+
 // GetStorageUsage ดึงข้อมูล storage usage
 func (s *VideoServiceImpl) GetStorageUsage(ctx context.Context) (*services.StorageUsage, error) {
 	totalUsed, err := s.videoRepo.GetTotalStorageUsed(ctx)
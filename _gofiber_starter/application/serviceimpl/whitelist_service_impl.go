@@ -4,9 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 
 	"gofiber-template/domain/dto"
 	"gofiber-template/domain/models"
@@ -294,6 +297,107 @@ func (s *WhitelistServiceImpl) GetDomainsByProfile(ctx context.Context, profileI
 	return s.whitelistRepo.GetDomainsByProfileID(ctx, profileID)
 }
 
+// ImportDomains เพิ่ม domains หลายตัวให้ profile
+// normalize → validate → dedupe (ทั้งใน input และกับที่มีอยู่) → bulk insert
+func (s *WhitelistServiceImpl) ImportDomains(ctx context.Context, profileID uuid.UUID, domains []string) (*dto.ImportDomainsResponse, error) {
+	logger.InfoContext(ctx, "Importing domains to profile",
+		"profile_id", profileID,
+		"input_count", len(domains),
+	)
+
+	if _, err := s.whitelistRepo.GetByID(ctx, profileID); err != nil {
+		return nil, profileLookupError(err)
+	}
+
+	existing, err := s.whitelistRepo.GetDomainsByProfileID(ctx, profileID)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to get existing domains", "profile_id", profileID, "error", err)
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(existing)+len(domains))
+	for _, d := range existing {
+		seen[models.NormalizeDomain(d.Domain)] = true
+	}
+
+	result := &dto.ImportDomainsResponse{
+		Added:   []string{},
+		Skipped: []string{},
+		Invalid: []dto.InvalidDomainItem{},
+	}
+	toInsert := make([]*models.ProfileDomain, 0, len(domains))
+
+	for _, raw := range domains {
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+
+		normalized := models.NormalizeDomain(raw)
+		if err := models.ValidateDomainPattern(normalized); err != nil {
+			result.Invalid = append(result.Invalid, dto.InvalidDomainItem{Domain: raw, Reason: err.Error()})
+			continue
+		}
+
+		if seen[normalized] {
+			result.Skipped = append(result.Skipped, normalized)
+			continue
+		}
+		seen[normalized] = true
+
+		toInsert = append(toInsert, &models.ProfileDomain{
+			ProfileID: profileID,
+			Domain:    normalized,
+		})
+		result.Added = append(result.Added, normalized)
+	}
+
+	if err := s.whitelistRepo.AddDomains(ctx, toInsert); err != nil {
+		logger.ErrorContext(ctx, "Failed to import domains",
+			"profile_id", profileID,
+			"count", len(toInsert),
+			"error", err,
+		)
+		return nil, err
+	}
+
+	result.AddedCount = len(result.Added)
+	result.SkippedCount = len(result.Skipped)
+	result.InvalidCount = len(result.Invalid)
+
+	logger.InfoContext(ctx, "Domains imported to profile",
+		"profile_id", profileID,
+		"added", result.AddedCount,
+		"skipped", result.SkippedCount,
+		"invalid", result.InvalidCount,
+	)
+
+	// Invalidate cache for added domains (ล้าง negative cache ของ domain ใหม่)
+	for _, d := range result.Added {
+		s.InvalidateDomainCache(ctx, d)
+	}
+
+	return result, nil
+}
+
+// ExportDomains ดึงรายการ domain patterns ของ profile (เรียงตามตัวอักษร)
+func (s *WhitelistServiceImpl) ExportDomains(ctx context.Context, profileID uuid.UUID) ([]string, error) {
+	if _, err := s.whitelistRepo.GetByID(ctx, profileID); err != nil {
+		return nil, profileLookupError(err)
+	}
+
+	domains, err := s.whitelistRepo.GetDomainsByProfileID(ctx, profileID)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to export domains", "profile_id", profileID, "error", err)
+		return nil, err
+	}
+
+	result := make([]string, 0, len(domains))
+	for _, d := range domains {
+		result = append(result, d.Domain)
+	}
+	return result, nil
+}
+
 // ==================== Domain Lookup ====================
 
 func (s *WhitelistServiceImpl) FindProfileByDomain(ctx context.Context, domain string) (*models.WhitelistProfile, error) {
@@ -353,6 +457,94 @@ func (s *WhitelistServiceImpl) IsDomainAllowed(ctx context.Context, domain strin
 	return true, profile, nil
 }
 
+// ErrProfileNotFound ไม่มี whitelist profile ตาม id
+var ErrProfileNotFound = errors.New("profile not found")
+
+// profileLookupError แปลง error ตอนดึง profile - ไม่มี record = ErrProfileNotFound, DB error อื่นส่งต่อ (handler ตอบ 500)
+func profileLookupError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrProfileNotFound
+	}
+	return fmt.Errorf("failed to get profile: %w", err)
+}
+
+// TestDomainMatch ใช้ MatchDomain/ExtractDomainFromURL ตัวจริง แต่ข้าม cache
+// เพื่อให้ admin ตรวจ config ได้โดยไม่ต้อง deploy แล้วดู log
+func (s *WhitelistServiceImpl) TestDomainMatch(ctx context.Context, req *dto.DomainMatchTestRequest) (*dto.DomainMatchTestResponse, error) {
+	domain := models.ExtractDomainFromURL(strings.TrimSpace(req.Domain))
+	resp := &dto.DomainMatchTestResponse{
+		Input:           req.Domain,
+		ExtractedDomain: domain,
+	}
+
+	// Mode 1: pattern เดียว
+	if req.Pattern != "" {
+		resp.CheckedPatterns = 1
+		if rule := models.MatchDomainRule(req.Pattern, domain); rule != "" {
+			resp.Matched = true
+			resp.Rule = rule
+			resp.Pattern = req.Pattern
+		}
+		return resp, nil
+	}
+
+	// Mode 2: ทดสอบกับ domains ใน whitelist (เหมือน FindProfileByDomain)
+	var candidates []*models.ProfileDomain
+	if req.ProfileID != nil {
+		profile, err := s.whitelistRepo.GetByID(ctx, *req.ProfileID)
+		if err != nil {
+			return nil, profileLookupError(err)
+		}
+		domains, err := s.whitelistRepo.GetDomainsByProfileID(ctx, profile.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range domains {
+			d.Profile = profile
+			candidates = append(candidates, d)
+		}
+	} else {
+		domains, err := s.whitelistRepo.GetAllDomains(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range domains {
+			// middleware ใช้เฉพาะ active profiles
+			if d.Profile != nil && d.Profile.IsActive {
+				candidates = append(candidates, d)
+			}
+		}
+	}
+
+	resp.CheckedPatterns = len(candidates)
+	for _, d := range candidates {
+		rule := models.MatchDomainRule(d.Domain, domain)
+		if rule == "" {
+			continue
+		}
+		resp.Matched = true
+		resp.Rule = rule
+		resp.Pattern = d.Domain
+		if d.Profile != nil {
+			resp.Profile = &dto.DomainMatchProfileInfo{
+				ID:       d.Profile.ID,
+				Name:     d.Profile.Name,
+				IsActive: d.Profile.IsActive,
+			}
+		}
+		break
+	}
+
+	logger.InfoContext(ctx, "Domain match test",
+		"domain", domain,
+		"matched", resp.Matched,
+		"rule", resp.Rule,
+		"pattern", resp.Pattern,
+	)
+
+	return resp, nil
+}
+
 // ==================== Watermark ====================
 
 func (s *WhitelistServiceImpl) UpdateWatermark(ctx context.Context, profileID uuid.UUID, watermarkURL string) error {
@@ -417,6 +609,73 @@ func (s *WhitelistServiceImpl) GetAdStatsByProfile(ctx context.Context, profileI
 	return s.adStatsRepo.GetStatsByProfile(ctx, profileID, start, end)
 }
 
+// GetProfileStats สรุปการใช้งานของ profile: ยอดรวม + รายวัน (เติมวันที่ไม่มีข้อมูลเป็น 0)
+// + impressions แยกตาม domain ที่เล่นจริง พร้อม pattern ของ profile ที่ match
+func (s *WhitelistServiceImpl) GetProfileStats(ctx context.Context, profileID uuid.UUID, start, end time.Time) (*models.ProfileUsageStats, error) {
+	profile, err := s.whitelistRepo.GetByIDWithDomains(ctx, profileID)
+	if err != nil {
+		return nil, profileLookupError(err)
+	}
+
+	totals, err := s.adStatsRepo.GetStatsByProfile(ctx, profileID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	daily, err := s.adStatsRepo.GetDailyStatsByProfile(ctx, profileID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	domainCounts, err := s.adStatsRepo.GetDomainCountsByProfile(ctx, profileID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	byDomain := make([]*models.ProfileDomainUsage, len(domainCounts))
+	for i, dc := range domainCounts {
+		usage := &models.ProfileDomainUsage{
+			Domain:      dc.Domain,
+			Impressions: dc.Impressions,
+		}
+		for _, d := range profile.Domains {
+			if models.MatchDomain(d.Domain, dc.Domain) {
+				usage.MatchedPattern = d.Domain
+				break
+			}
+		}
+		byDomain[i] = usage
+	}
+
+	return &models.ProfileUsageStats{
+		Profile:  profile,
+		Totals:   totals,
+		Daily:    fillDailyGaps(daily, start, end),
+		ByDomain: byDomain,
+	}, nil
+}
+
+// fillDailyGaps เติมวันที่ไม่มี impression เป็น 0 (กราฟจะได้ไม่ขาดช่วง)
+func fillDailyGaps(daily []*models.ProfileDailyStats, start, end time.Time) []*models.ProfileDailyStats {
+	byDate := make(map[string]*models.ProfileDailyStats, len(daily))
+	for _, d := range daily {
+		byDate[d.Date.Format("2006-01-02")] = d
+	}
+
+	var filled []*models.ProfileDailyStats
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	for !day.After(end) {
+		key := day.Format("2006-01-02")
+		if d, ok := byDate[key]; ok {
+			filled = append(filled, d)
+		} else {
+			filled = append(filled, &models.ProfileDailyStats{Date: day})
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return filled
+}
+
 func (s *WhitelistServiceImpl) GetDeviceStats(ctx context.Context, start, end time.Time) (*models.DeviceStats, error) {
 	return s.adStatsRepo.GetDeviceStats(ctx, start, end)
 }
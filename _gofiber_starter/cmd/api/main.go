@@ -4,11 +4,13 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"gofiber-template/interfaces/api/handlers"
 	"gofiber-template/interfaces/api/middleware"
 	"gofiber-template/interfaces/api/routes"
+	"gofiber-template/pkg/config"
 	"gofiber-template/pkg/di"
 	"gofiber-template/pkg/logger"
 )
@@ -38,7 +40,11 @@ func main() {
 	// Setup middleware (order matters!)
 	app.Use(middleware.RequestIDMiddleware()) // ต้องมาก่อน logger
 	app.Use(middleware.LoggerMiddleware())
-	app.Use(middleware.CorsMiddleware())
+	app.Use(middleware.TimeoutMiddleware(middleware.DefaultTimeoutConfig(
+		time.Duration(container.GetConfig().App.RequestTimeoutSec)*time.Second,
+		time.Duration(container.GetConfig().App.UploadTimeoutSec)*time.Second,
+	)))
+	app.Use(corsMiddleware(container.GetConfig().CORS))
 
 	// Create handlers from services
 	services := container.GetHandlerServices()
@@ -82,3 +88,39 @@ func setupGracefulShutdown(container *di.Container) {
 		os.Exit(0)
 	}()
 }
+
+// corsMiddleware CORS หลัก + override ของ embed routes (origin กว้าง) และ admin routes (ล็อก origin)
+func corsMiddleware(cfg config.CORSConfig) fiber.Handler {
+	base := middleware.DefaultCorsConfig()
+	base = mergeCors(base, cfg.AllowOrigins, cfg.AllowMethods, cfg.AllowHeaders, cfg.AllowCredentials)
+
+	return middleware.CorsMiddleware(base,
+		middleware.CorsGroup{
+			Name:     "embed",
+			Prefixes: middleware.EmbedCorsPrefixes,
+			Config:   mergeCors(base, cfg.Embed.AllowOrigins, cfg.Embed.AllowMethods, cfg.Embed.AllowHeaders, cfg.Embed.AllowCredentials),
+		},
+		middleware.CorsGroup{
+			Name:     "admin",
+			Prefixes: middleware.AdminCorsPrefixes,
+			Config:   mergeCors(base, cfg.Admin.AllowOrigins, cfg.Admin.AllowMethods, cfg.Admin.AllowHeaders, cfg.Admin.AllowCredentials),
+		},
+	)
+}
+
+// mergeCors ทับค่าที่ตั้งไว้ลงบน base (ว่าง/nil = ใช้ค่าเดิม)
+func mergeCors(base middleware.CorsConfig, origins, methods, headers string, credentials *bool) middleware.CorsConfig {
+	if origins != "" {
+		base.AllowOrigins = origins
+	}
+	if methods != "" {
+		base.AllowMethods = methods
+	}
+	if headers != "" {
+		base.AllowHeaders = headers
+	}
+	if credentials != nil {
+		base.AllowCredentials = *credentials
+	}
+	return base
+}
@@ -36,6 +36,17 @@ type CompletedPart struct {
 	ETag       string `json:"etag" validate:"required"`
 }
 
+// NotifyDirectUploadCompleteRequest แจ้งว่า browser อัปโหลดผ่าน presigned URL เสร็จแล้ว
+// (ไฟล์อยู่ใน storage แล้ว - server ตรวจไฟล์จริงผ่าน StatObject ไม่เชื่อขนาดจาก client)
+type NotifyDirectUploadCompleteRequest struct {
+	VideoCode   string `json:"videoCode" validate:"required"`
+	Path        string `json:"path" validate:"required"`
+	Filename    string `json:"filename" validate:"required"`
+	Title       string `json:"title" validate:"omitempty,max=255"`
+	Description string `json:"description" validate:"omitempty,max=1000"`
+	Category    string `json:"category" validate:"omitempty,max=100"`
+}
+
 // AbortDirectUploadRequest ข้อมูลสำหรับยกเลิก upload
 type AbortDirectUploadRequest struct {
 	UploadID string `json:"uploadId" validate:"required"`
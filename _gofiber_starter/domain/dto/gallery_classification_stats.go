@@ -0,0 +1,105 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+)
+
+// === Requests ===
+
+// ClassificationStatsRequest query ของ classification stats
+type ClassificationStatsRequest struct {
+	MinNsfwScore float64 `query:"minNsfwScore" validate:"omitempty,min=0,max=1"` // threshold ของ outlier (super_safe ที่ nsfw_score >= ค่านี้)
+	Limit        int     `query:"limit" validate:"omitempty,min=1,max=200"`      // จำนวน outliers สูงสุด
+}
+
+// === Responses ===
+
+// ClassificationTierStats สถิติของ tier หนึ่ง (super_safe, safe, nsfw, error)
+type ClassificationTierStats struct {
+	Tier              string  `json:"tier"`
+	Count             int64   `json:"count"`
+	Percent           float64 `json:"percent"` // สัดส่วนจากภาพทั้งหมด (0-100)
+	AvgNsfwScore      float64 `json:"avgNsfwScore"`
+	AvgFaceScore      float64 `json:"avgFaceScore"`
+	AvgAestheticScore float64 `json:"avgAestheticScore"`
+	MosaicCount       int64   `json:"mosaicCount"`
+	POVCount          int64   `json:"povCount"`
+}
+
+// ClassificationOutlier ภาพ super_safe ที่ nsfw_score สูงผิดปกติ (อาจหลุดเข้า public)
+type ClassificationOutlier struct {
+	VideoID        uuid.UUID `json:"videoId"`
+	Filename       string    `json:"filename"`
+	NsfwScore      float64   `json:"nsfwScore"`
+	FaceScore      float64   `json:"faceScore"`
+	AestheticScore float64   `json:"aestheticScore"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// ClassificationStatsResponse สถิติ classification รวมทุก gallery
+type ClassificationStatsResponse struct {
+	TotalImages  int64                     `json:"totalImages"`
+	Tiers        []ClassificationTierStats `json:"tiers"`
+	MinNsfwScore float64                   `json:"minNsfwScore"` // threshold ที่ใช้หา outliers
+	OutlierCount int64                     `json:"outlierCount"` // outliers ทั้งหมด (ไม่จำกัดตาม limit)
+	OutlierRate  float64                   `json:"outlierRate"`  // outliers / super_safe ทั้งหมด (0-100)
+	Outliers     []ClassificationOutlier   `json:"outliers"`
+}
+
+// === Mappers ===
+
+// ToClassificationStatsResponse รวม tier stats กับ outliers เป็น response
+func ToClassificationStatsResponse(tiers []models.GalleryTierStats, outliers []*models.GalleryImageScore, outlierCount int64, minNsfwScore float64) *ClassificationStatsResponse {
+	response := &ClassificationStatsResponse{
+		Tiers:        make([]ClassificationTierStats, 0, len(tiers)),
+		MinNsfwScore: minNsfwScore,
+		OutlierCount: outlierCount,
+		Outliers:     make([]ClassificationOutlier, 0, len(outliers)),
+	}
+
+	var superSafeCount int64
+	for _, tier := range tiers {
+		response.TotalImages += tier.Count
+		if tier.Tier == models.GalleryTierSuperSafe {
+			superSafeCount = tier.Count
+		}
+	}
+
+	for _, tier := range tiers {
+		response.Tiers = append(response.Tiers, ClassificationTierStats{
+			Tier:              tier.Tier,
+			Count:             tier.Count,
+			Percent:           percentOf(tier.Count, response.TotalImages),
+			AvgNsfwScore:      tier.AvgNsfwScore,
+			AvgFaceScore:      tier.AvgFaceScore,
+			AvgAestheticScore: tier.AvgAestheticScore,
+			MosaicCount:       tier.MosaicCount,
+			POVCount:          tier.POVCount,
+		})
+	}
+	response.OutlierRate = percentOf(outlierCount, superSafeCount)
+
+	for _, score := range outliers {
+		response.Outliers = append(response.Outliers, ClassificationOutlier{
+			VideoID:        score.VideoID,
+			Filename:       score.Filename,
+			NsfwScore:      score.NsfwScore,
+			FaceScore:      score.FaceScore,
+			AestheticScore: score.AestheticScore,
+			CreatedAt:      score.CreatedAt,
+		})
+	}
+
+	return response
+}
+
+// percentOf part/total เป็น % (total = 0 → 0)
+func percentOf(part, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) * 100 / float64(total)
+}
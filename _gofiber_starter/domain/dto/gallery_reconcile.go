@@ -0,0 +1,41 @@
+package dto
+
+import "github.com/google/uuid"
+
+// GalleryCounts จำนวนภาพของ gallery (นิยามเดียวกับ admin updateGalleryCounts)
+type GalleryCounts struct {
+	Source    int `json:"source"` // ภาพใน source/ ที่รอ admin เลือก (Manual Selection)
+	SuperSafe int `json:"superSafe"`
+	Safe      int `json:"safe"`
+	Nsfw      int `json:"nsfw"`
+	Total     int `json:"total"` // gallery_count = safe + nsfw
+}
+
+// NewGalleryCounts คำนวณ Total จากจำนวนภาพแต่ละ folder แบบเดียวกับ admin updateGalleryCounts
+// (reconcile ต้องได้ค่าตรงกับที่ admin publish เขียน ไม่งั้นจะ "แก้" ทุก video ที่ถูกอยู่แล้ว)
+func NewGalleryCounts(source, superSafe, safe, nsfw int) GalleryCounts {
+	return GalleryCounts{
+		Source:    source,
+		SuperSafe: superSafe,
+		Safe:      safe,
+		Nsfw:      nsfw,
+		Total:     safe + nsfw,
+	}
+}
+
+// GalleryReconcileResult ผล reconcile ของวิดีโอหนึ่ง
+type GalleryReconcileResult struct {
+	VideoID   uuid.UUID     `json:"videoId"`
+	VideoCode string        `json:"videoCode"`
+	Before    GalleryCounts `json:"before"` // counts ใน DB ก่อน reconcile
+	After     GalleryCounts `json:"after"`  // counts จากไฟล์จริงใน storage
+	Changed   bool          `json:"changed"`
+}
+
+// GalleryReconcileSummary ผล reconcile แบบ bulk
+type GalleryReconcileSummary struct {
+	Scanned int                       `json:"scanned"`
+	Fixed   int                       `json:"fixed"`
+	Failed  int                       `json:"failed"`
+	Results []*GalleryReconcileResult `json:"results"` // เฉพาะวิดีโอที่ counts เปลี่ยน
+}
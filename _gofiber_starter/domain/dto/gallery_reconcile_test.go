@@ -0,0 +1,32 @@
+package dto
+
+import "testing"
+
+func TestNewGalleryCounts(t *testing.T) {
+	tests := []struct {
+		name                          string
+		source, superSafe, safe, nsfw int
+		wantTotal                     int
+	}{
+		{"empty", 0, 0, 0, 0, 0},
+		{"all tiers", 0, 4, 10, 6, 16},
+		{"nsfw only", 0, 0, 0, 7, 7},
+		{"super safe not counted in total", 0, 3, 5, 0, 5},
+		{"pending review source only", 25, 0, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewGalleryCounts(tt.source, tt.superSafe, tt.safe, tt.nsfw)
+			if got.Source != tt.source {
+				t.Errorf("Source = %d, want %d", got.Source, tt.source)
+			}
+			if got.Total != tt.wantTotal {
+				t.Errorf("Total = %d, want %d", got.Total, tt.wantTotal)
+			}
+			if got.SuperSafe != tt.superSafe || got.Safe != tt.safe || got.Nsfw != tt.nsfw {
+				t.Errorf("tiers = %d/%d/%d, want %d/%d/%d", got.SuperSafe, got.Safe, got.Nsfw, tt.superSafe, tt.safe, tt.nsfw)
+			}
+		})
+	}
+}
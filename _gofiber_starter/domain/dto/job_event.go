@@ -0,0 +1,95 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+)
+
+// === Responses ===
+
+// JobEventResponse event เดียวใน timeline
+type JobEventResponse struct {
+	Source           string    `json:"source"` // transcode, gallery, seo
+	Stage            string    `json:"stage"`
+	Progress         float64   `json:"progress"`
+	Message          string    `json:"message,omitempty"`
+	Timestamp        time.Time `json:"timestamp"`
+	SincePreviousSec float64   `json:"sincePreviousSec"` // เวลาที่ใช้นับจาก event ก่อนหน้า (source เดียวกัน)
+}
+
+// JobTimelineSummary สรุป timeline ของแต่ละ pipeline
+type JobTimelineSummary struct {
+	Source          string    `json:"source"`
+	StartedAt       time.Time `json:"startedAt"`
+	LastEventAt     time.Time `json:"lastEventAt"`
+	LastStage       string    `json:"lastStage"`
+	LastProgress    float64   `json:"lastProgress"`
+	DurationSec     float64   `json:"durationSec"`
+	SlowestStage    string    `json:"slowestStage,omitempty"` // stage ที่ใช้เวลานานที่สุดก่อนถึง event ถัดไป
+	SlowestStageSec float64   `json:"slowestStageSec,omitempty"`
+	EventCount      int       `json:"eventCount"`
+}
+
+// JobTimelineResponse timeline ทั้งหมดของ video
+type JobTimelineResponse struct {
+	VideoID   uuid.UUID            `json:"videoId"`
+	Events    []JobEventResponse   `json:"events"`
+	Summaries []JobTimelineSummary `json:"summaries"`
+}
+
+// === Mappers ===
+
+// JobEventsToTimelineResponse แปลง events (เรียงตามเวลา) เป็น timeline พร้อมสรุปแยกตาม source
+func JobEventsToTimelineResponse(videoID uuid.UUID, events []*models.JobEvent) *JobTimelineResponse {
+	response := &JobTimelineResponse{
+		VideoID:   videoID,
+		Events:    make([]JobEventResponse, 0, len(events)),
+		Summaries: []JobTimelineSummary{},
+	}
+
+	lastBySource := make(map[string]*models.JobEvent)
+	summaryIndex := make(map[string]int)
+
+	for _, event := range events {
+		item := JobEventResponse{
+			Source:    event.Source,
+			Stage:     event.Stage,
+			Progress:  event.Progress,
+			Message:   event.Message,
+			Timestamp: event.CreatedAt,
+		}
+
+		idx, exists := summaryIndex[event.Source]
+		if !exists {
+			response.Summaries = append(response.Summaries, JobTimelineSummary{
+				Source:    event.Source,
+				StartedAt: event.CreatedAt,
+			})
+			idx = len(response.Summaries) - 1
+			summaryIndex[event.Source] = idx
+		}
+		summary := &response.Summaries[idx]
+
+		// เวลาที่ stage ก่อนหน้าใช้ = เวลาจาก event ก่อนหน้าถึง event นี้
+		if prev, ok := lastBySource[event.Source]; ok {
+			item.SincePreviousSec = event.CreatedAt.Sub(prev.CreatedAt).Seconds()
+			if item.SincePreviousSec > summary.SlowestStageSec {
+				summary.SlowestStage = prev.Stage
+				summary.SlowestStageSec = item.SincePreviousSec
+			}
+		}
+
+		summary.LastEventAt = event.CreatedAt
+		summary.LastStage = event.Stage
+		summary.LastProgress = event.Progress
+		summary.DurationSec = event.CreatedAt.Sub(summary.StartedAt).Seconds()
+		summary.EventCount++
+
+		lastBySource[event.Source] = event
+		response.Events = append(response.Events, item)
+	}
+
+	return response
+}
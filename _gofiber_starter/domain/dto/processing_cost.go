@@ -0,0 +1,80 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+)
+
+// === Responses ===
+
+// ProcessingCostResponse cost ของ worker job หนึ่งครั้ง
+type ProcessingCostResponse struct {
+	Source              string    `json:"source"` // gallery, seo
+	GeminiPromptTokens  int64     `json:"geminiPromptTokens"`
+	GeminiOutputTokens  int64     `json:"geminiOutputTokens"`
+	TTSCharacters       int       `json:"ttsCharacters"`
+	EmbeddingChars      int       `json:"embeddingChars"`
+	FrameCount          int       `json:"frameCount"`
+	ClassifierRuntimeMs int64     `json:"classifierRuntimeMs"`
+	JobDurationMs       int64     `json:"jobDurationMs"`
+	CreatedAt           time.Time `json:"createdAt"`
+}
+
+// ProcessingCostTotals ผลรวม cost ทุก job ของ video
+type ProcessingCostTotals struct {
+	GeminiPromptTokens  int64 `json:"geminiPromptTokens"`
+	GeminiOutputTokens  int64 `json:"geminiOutputTokens"`
+	GeminiTotalTokens   int64 `json:"geminiTotalTokens"`
+	TTSCharacters       int   `json:"ttsCharacters"`
+	EmbeddingChars      int   `json:"embeddingChars"`
+	FrameCount          int   `json:"frameCount"`
+	ClassifierRuntimeMs int64 `json:"classifierRuntimeMs"`
+	JobDurationMs       int64 `json:"jobDurationMs"`
+	JobCount            int   `json:"jobCount"`
+}
+
+// VideoCostResponse cost ทั้งหมดของ video
+type VideoCostResponse struct {
+	VideoID uuid.UUID                `json:"videoId"`
+	Totals  ProcessingCostTotals     `json:"totals"`
+	Jobs    []ProcessingCostResponse `json:"jobs"`
+}
+
+// === Mappers ===
+
+// ProcessingCostsToVideoCostResponse แปลง costs เป็น response พร้อมผลรวม
+func ProcessingCostsToVideoCostResponse(videoID uuid.UUID, costs []*models.ProcessingCost) *VideoCostResponse {
+	response := &VideoCostResponse{
+		VideoID: videoID,
+		Jobs:    make([]ProcessingCostResponse, 0, len(costs)),
+	}
+
+	for _, cost := range costs {
+		response.Jobs = append(response.Jobs, ProcessingCostResponse{
+			Source:              cost.Source,
+			GeminiPromptTokens:  cost.GeminiPromptTokens,
+			GeminiOutputTokens:  cost.GeminiOutputTokens,
+			TTSCharacters:       cost.TTSCharacters,
+			EmbeddingChars:      cost.EmbeddingChars,
+			FrameCount:          cost.FrameCount,
+			ClassifierRuntimeMs: cost.ClassifierRuntimeMs,
+			JobDurationMs:       cost.JobDurationMs,
+			CreatedAt:           cost.CreatedAt,
+		})
+
+		totals := &response.Totals
+		totals.GeminiPromptTokens += cost.GeminiPromptTokens
+		totals.GeminiOutputTokens += cost.GeminiOutputTokens
+		totals.TTSCharacters += cost.TTSCharacters
+		totals.EmbeddingChars += cost.EmbeddingChars
+		totals.FrameCount += cost.FrameCount
+		totals.ClassifierRuntimeMs += cost.ClassifierRuntimeMs
+		totals.JobDurationMs += cost.JobDurationMs
+		totals.JobCount++
+	}
+	response.Totals.GeminiTotalTokens = response.Totals.GeminiPromptTokens + response.Totals.GeminiOutputTokens
+
+	return response
+}
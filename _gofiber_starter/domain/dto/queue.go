@@ -170,6 +170,26 @@ type RetryResponse struct {
 	Errors       []string `json:"errors,omitempty"`
 }
 
+// BumpPriorityResponse response หลัง bump job ไปคิว priority
+type BumpPriorityResponse struct {
+	VideoID     uuid.UUID `json:"videoId"`
+	VideoCode   string    `json:"videoCode"`
+	Queue       string    `json:"queue"`       // transcode, gallery
+	Priority    int       `json:"priority"`    // 1 = urgent
+	RemovedJobs int       `json:"removedJobs"` // job เดิมที่ถูกลบจากคิวปกติ
+	Message     string    `json:"message"`
+}
+
+// CancelJobResponse response หลังสั่งยกเลิก job
+type CancelJobResponse struct {
+	VideoID     uuid.UUID `json:"videoId"`
+	VideoCode   string    `json:"videoCode"`
+	JobType     string    `json:"jobType"`     // transcode, gallery, seo
+	Status      string    `json:"status"`      // cancelled = ยกเลิกแล้ว, cancelling = รอ worker หยุดที่ stage ถัดไป
+	RemovedJobs int       `json:"removedJobs"` // job ที่ถูกลบจากคิว
+	Message     string    `json:"message"`
+}
+
 // WarmCacheResponse response หลัง warm cache
 type WarmCacheResponse struct {
 	VideoID string `json:"videoId"`
@@ -193,6 +213,16 @@ type ClearResponse struct {
 	Message         string `json:"message"`
 }
 
+// BackfillGalleryResponse response หลัง backfill gallery
+type BackfillGalleryResponse struct {
+	TotalMissing int      `json:"totalMissing"` // จำนวน video ที่ยังไม่มี gallery ทั้งหมด
+	TotalQueued  int      `json:"totalQueued"`  // จำนวนที่ queue สำเร็จในรอบนี้
+	Skipped      int      `json:"skipped"`
+	Remaining    int64    `json:"remaining"` // จำนวนที่เหลือ (เกิน cap) ให้กดซ้ำ
+	Message      string   `json:"message"`
+	Errors       []string `json:"errors,omitempty"`
+}
+
 // QueueMissingResponse response หลัง queue missing subtitles
 type QueueMissingResponse struct {
 	TotalVideos    int    `json:"totalVideos"`    // จำนวน video ทั้งหมดที่ ready
@@ -201,3 +231,29 @@ type QueueMissingResponse struct {
 	Skipped        int    `json:"skipped"`        // จำนวนที่ skip (ไม่มี audio, etc.)
 	Message        string `json:"message"`
 }
+
+// === SEO Queue ===
+
+// EnqueueSEORequest filter สำหรับ queue SEO article jobs หลายวิดีโอในครั้งเดียว
+// วิดีโอที่มี SEO job event ภายใน dedup window (queued/กำลังทำ/เพิ่งทำ) ถูกข้ามเสมอ
+type EnqueueSEORequest struct {
+	Status         string     `json:"status"`         // video status (default: ready)
+	HasGallery     bool       `json:"hasGallery"`     // true = เฉพาะวิดีโอที่มี gallery แล้ว
+	MissingArticle bool       `json:"missingArticle"` // true = ข้ามวิดีโอที่มีบทความแล้ว (article_published_at)
+	CreatedFrom    *time.Time `json:"createdFrom"`    // created_at >= (RFC3339)
+	CreatedTo      *time.Time `json:"createdTo"`      // created_at < (RFC3339)
+	Max            int        `json:"max"`            // จำนวนสูงสุดต่อรอบ (default 50, cap 500)
+	Priority       int        `json:"priority"`       // 1=urgent, 2=normal, 3=backfill (default: backfill)
+	GenerateTTS    bool       `json:"generateTts"`
+	OutputLanguage string     `json:"outputLanguage"` // ISO 639-1 (ว่าง = ภาษาไทย)
+}
+
+// EnqueueSEOResponse response หลัง queue SEO article jobs
+type EnqueueSEOResponse struct {
+	TotalMatched int64    `json:"totalMatched"` // จำนวนวิดีโอที่ตรง filter (หลัง dedup)
+	TotalQueued  int      `json:"totalQueued"`  // จำนวนที่ queue สำเร็จในรอบนี้
+	Skipped      int      `json:"skipped"`
+	Remaining    int64    `json:"remaining"` // จำนวนที่เหลือ (เกิน cap) ให้กดซ้ำ
+	Message      string   `json:"message"`
+	Errors       []string `json:"errors,omitempty"`
+}
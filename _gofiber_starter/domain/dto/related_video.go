@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+)
+
+// RelatedVideoFilter filter สำหรับ related videos (optional ทุก field)
+type RelatedVideoFilter struct {
+	CastID        string  `query:"castId"`        // ต้องมี cast คนนี้
+	MakerID       string  `query:"makerId"`       // ต้องเป็น maker นี้
+	TagID         string  `query:"tagId"`         // ต้องมี tag นี้
+	MinSimilarity float64 `query:"minSimilarity"` // 0-1
+}
+
+// RelatedVideoResponse video ที่คล้ายกัน (จาก embedding)
+type RelatedVideoResponse struct {
+	ID           uuid.UUID `json:"id"`
+	Code         string    `json:"code"`
+	Title        string    `json:"title"`
+	ThumbnailURL string    `json:"thumbnailUrl"`
+	Similarity   float64   `json:"similarity"`
+}
+
+// RelatedVideosToResponses แปลง related videos เป็น response
+func RelatedVideosToResponses(videos []*models.RelatedVideo) []RelatedVideoResponse {
+	responses := make([]RelatedVideoResponse, len(videos))
+	for i, v := range videos {
+		responses[i] = RelatedVideoResponse{
+			ID:           v.ID,
+			Code:         v.Code,
+			Title:        v.Title,
+			ThumbnailURL: v.ThumbnailURL,
+			Similarity:   v.Similarity,
+		}
+	}
+	return responses
+}
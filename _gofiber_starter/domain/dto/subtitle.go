@@ -49,6 +49,13 @@ type JobStartedRequest struct {
 	WorkerID   string `json:"worker_id"`
 }
 
+// JobHeartbeatRequest heartbeat จาก worker ระหว่างทำ job (ส่งทุก ~30 วินาที)
+// ใช้ตรวจจับ worker ที่ตาย แทนการจำกัดเวลาทำงานรวม
+type JobHeartbeatRequest struct {
+	SubtitleID string `json:"subtitle_id" validate:"required,uuid"`
+	WorkerID   string `json:"worker_id"`
+}
+
 // SetLanguageRequest request สำหรับตั้งค่าภาษาด้วยตนเอง (override auto-detect)
 type SetLanguageRequest struct {
 	Language string `json:"language" validate:"required,oneof=ja en zh ko th ru"`
@@ -68,6 +75,13 @@ type SubtitleContentRequest struct {
 	Content string `json:"content" validate:"required"`
 }
 
+// ImportSubtitleRequest request สำหรับนำเข้า subtitle จากไฟล์ภายนอก (แปลงเป็น SRT ก่อนเก็บ)
+type ImportSubtitleRequest struct {
+	Language string `json:"language" validate:"required,oneof=ja en zh ko th ru"`
+	Format   string `json:"format" validate:"required,oneof=srt vtt ass ssa"`
+	Content  string `json:"content" validate:"required"`
+}
+
 // === Content Edit Responses ===
 
 // SubtitleContentResponse response ที่มี subtitle content
@@ -81,6 +95,36 @@ type SubtitleContentResponse struct {
 
 // === Responses ===
 
+// SubtitleDiffResponse ผลเทียบ SRT ปัจจุบันกับ version ก่อนหน้า
+type SubtitleDiffResponse struct {
+	SubtitleID  uuid.UUID           `json:"subtitleId"`
+	Language    string              `json:"language"`
+	HasPrevious bool                `json:"hasPrevious"` // false = ยังไม่เคยถูกแก้ไข (ไม่มี version ก่อนหน้า)
+	Summary     SubtitleDiffSummary `json:"summary"`
+	Changes     []SubtitleCueChange `json:"changes"`
+}
+
+// SubtitleDiffSummary จำนวน cue ที่เปลี่ยนแต่ละแบบ
+type SubtitleDiffSummary struct {
+	Added   int `json:"added"`
+	Removed int `json:"removed"`
+	Changed int `json:"changed"` // ข้อความเปลี่ยน (เวลาอาจเปลี่ยนด้วย)
+	Retimed int `json:"retimed"` // ข้อความเดิม เปลี่ยนแค่เวลา
+}
+
+// SubtitleCueChange การเปลี่ยนแปลงของ 1 cue (index เริ่มที่ 1, เวลาเป็น SRT timestamp)
+type SubtitleCueChange struct {
+	Type     string `json:"type"` // added | removed | changed | retimed
+	OldIndex int    `json:"oldIndex,omitempty"`
+	NewIndex int    `json:"newIndex,omitempty"`
+	OldStart string `json:"oldStart,omitempty"`
+	OldEnd   string `json:"oldEnd,omitempty"`
+	NewStart string `json:"newStart,omitempty"`
+	NewEnd   string `json:"newEnd,omitempty"`
+	OldText  string `json:"oldText,omitempty"`
+	NewText  string `json:"newText,omitempty"`
+}
+
 // SubtitleResponse ข้อมูล subtitle แต่ละ record
 type SubtitleResponse struct {
 	ID             uuid.UUID            `json:"id"`
@@ -144,6 +188,26 @@ type RetryStuckResponse struct {
 	Errors       []string `json:"errors,omitempty"` // error messages ถ้ามี
 }
 
+// SubtitleMissingItem video ที่ยังไม่มี original subtitle ที่ ready
+type SubtitleMissingItem struct {
+	ID               uuid.UUID `json:"id"`
+	Code             string    `json:"code"`
+	Title            string    `json:"title"`
+	DetectedLanguage string    `json:"detectedLanguage,omitempty"`
+	CreatedAt        time.Time `json:"createdAt"`
+}
+
+// BulkTranscribeResponse response หลัง bulk transcribe
+type BulkTranscribeResponse struct {
+	TotalMissing int64    `json:"totalMissing"` // จำนวน video ที่ยังไม่มี subtitle ทั้งหมด
+	TotalScanned int      `json:"totalScanned"` // จำนวนที่ตรวจสอบในรอบนี้
+	TotalQueued  int      `json:"totalQueued"`  // จำนวนที่ queue สำเร็จ
+	InProgress   int      `json:"inProgress"`   // กำลังทำอยู่แล้ว (dedup)
+	Skipped      int      `json:"skipped"`      // skip เพราะ error อื่นๆ
+	Message      string   `json:"message"`
+	Errors       []string `json:"errors,omitempty"`
+}
+
 // LanguageInfo ข้อมูลภาษา
 type LanguageInfo struct {
 	Code string `json:"code"`
@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -28,19 +29,26 @@ type UpdateVideoRequest struct {
 	GallerySafeCount      *int    `json:"gallery_safe_count"`       // Admin เลือก - Public
 	GalleryNsfwCount      *int    `json:"gallery_nsfw_count"`       // Admin เลือก - Members only
 	GallerySuperSafeCount *int    `json:"gallery_super_safe_count"` // Deprecated - backward compat
+	GalleryCoverOverride  *string `json:"gallery_cover_override"`   // SEO cover override ("" = auto)
+
+	GalleryLimits map[string]int `json:"gallery_limits"` // tier limits ที่ worker ใช้ (nil = ไม่เปลี่ยน)
 }
 
+// VideoSortRelevance เรียงตามความเกี่ยวข้องกับคำค้น (default เมื่อมี search)
+const VideoSortRelevance = "relevance"
+
 type VideoFilterRequest struct {
-	Search     string `query:"search"`                                                           // ค้นหา title/code
+	Search     string `query:"search" validate:"omitempty,max=100"`                                      // ค้นหา title/code/description (เรียงตามความเกี่ยวข้อง)
 	Status     string `query:"status" validate:"omitempty,oneof=pending queued processing ready failed"` // เพิ่ม queued
 	CategoryID string `query:"categoryId" validate:"omitempty,uuid"`
 	UserID     string `query:"userId" validate:"omitempty,uuid"`
-	DateFrom   string `query:"dateFrom"`                              // วันที่เริ่มต้น (YYYY-MM-DD)
-	DateTo     string `query:"dateTo"`                                // วันที่สิ้นสุด (YYYY-MM-DD)
-	SortBy     string `query:"sortBy" validate:"omitempty,oneof=created_at title views"` // เรียงตาม
-	SortOrder  string `query:"sortOrder" validate:"omitempty,oneof=asc desc"`            // asc หรือ desc
+	DateFrom   string `query:"dateFrom"`                                                           // วันที่เริ่มต้น (YYYY-MM-DD)
+	DateTo     string `query:"dateTo"`                                                             // วันที่สิ้นสุด (YYYY-MM-DD)
+	SortBy     string `query:"sortBy" validate:"omitempty,oneof=created_at title views relevance"` // เรียงตาม (relevance = เฉพาะตอนมี search)
+	SortOrder  string `query:"sortOrder" validate:"omitempty,oneof=asc desc"`                      // asc หรือ desc
 	Page       int    `query:"page" validate:"omitempty,min=1"`
 	Limit      int    `query:"limit" validate:"omitempty,min=1,max=100"`
+	Cursor     string `query:"cursor"` // cursor pagination (keyed on created_at,id)
 }
 
 // === Responses ===
@@ -79,6 +87,9 @@ type VideoResponse struct {
 	GallerySafeCount      int    `json:"gallerySafeCount,omitempty"`      // Admin เลือก - Public
 	GalleryNsfwCount      int    `json:"galleryNsfwCount,omitempty"`      // Admin เลือก - Members only
 	GallerySuperSafeCount int    `json:"gallerySuperSafeCount,omitempty"` // Deprecated - backward compat
+	GalleryCoverOverride  string `json:"galleryCoverOverride,omitempty"`  // SEO cover override (filename ใน safe/ หรือ URL)
+
+	GalleryLimits map[string]int `json:"galleryLimits,omitempty"` // tier limits ที่ worker ใช้ได้ counts ชุดนี้
 
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
@@ -97,6 +108,25 @@ type VideoUploadResponse struct {
 	AutoEnqueued bool      `json:"autoEnqueued"` // ถูกส่งเข้า queue โดยอัตโนมัติหรือไม่
 }
 
+// Batch upload error codes - ให้ UI แยกประเภทความผิดพลาดรายไฟล์ได้
+const (
+	BatchUploadErrEmptyFile     = "EMPTY_FILE"
+	BatchUploadErrInvalidFormat = "INVALID_FORMAT"
+	BatchUploadErrQuotaExceeded = "QUOTA_EXCEEDED"
+	BatchUploadErrDiskSpace     = "INSUFFICIENT_DISK_SPACE"
+	BatchUploadErrStorage       = "STORAGE_ERROR"
+	BatchUploadErrInternal      = "INTERNAL_ERROR"
+)
+
+// BatchUploadResult ผลลัพธ์ของแต่ละไฟล์ใน batch upload
+type BatchUploadResult struct {
+	Filename string               `json:"filename"`
+	Success  bool                 `json:"success"`
+	Video    *VideoUploadResponse `json:"video,omitempty"`
+	Code     string               `json:"code,omitempty"`  // error code (BatchUploadErr*)
+	Error    string               `json:"error,omitempty"` // ข้อความสำหรับแสดงผล
+}
+
 type EmbedVideoResponse struct {
 	Code         string `json:"code"`
 	Title        string `json:"title"`
@@ -127,6 +157,40 @@ type DLQVideoResponse struct {
 	UserID       uuid.UUID             `json:"userId"`
 }
 
+// DLQEntryResponse job ที่เข้า DLQ หนึ่งครั้ง พร้อม payload (redacted)
+type DLQEntryResponse struct {
+	ID       uuid.UUID       `json:"id"`
+	Source   string          `json:"source"` // transcode, gallery, subtitle
+	Error    string          `json:"error"`
+	Attempts int             `json:"attempts"`
+	WorkerID string          `json:"workerId"`
+	Stage    string          `json:"stage"`
+	Payload  json.RawMessage `json:"payload"`
+	FailedAt time.Time       `json:"failedAt"`
+}
+
+// DLQJobDetailResponse รายละเอียด video ใน DLQ พร้อม job payload ทุกครั้งที่เข้า DLQ (ใหม่ → เก่า)
+type DLQJobDetailResponse struct {
+	DLQVideoResponse
+	Status  string             `json:"status"`
+	Entries []DLQEntryResponse `json:"entries"`
+}
+
+// VideoQualityResponse rendition ที่ transcode บันทึกไว้ใน video.QualitySizes
+type VideoQualityResponse struct {
+	Quality     string `json:"quality"`
+	SizeBytes   int64  `json:"size_bytes,omitempty"` // เฉพาะ caller ที่ login (public ไม่เห็นขนาด storage)
+	PlaylistURL string `json:"playlist_url"`
+}
+
+// VideoQualitiesResponse qualities ทั้งหมดของ video (เรียงจาก resolution สูงไปต่ำ)
+type VideoQualitiesResponse struct {
+	VideoID        uuid.UUID              `json:"video_id"`
+	VideoCode      string                 `json:"video_code"`
+	DefaultQuality string                 `json:"default_quality,omitempty"` // quality สูงสุดที่มี
+	Qualities      []VideoQualityResponse `json:"qualities"`
+}
+
 // === Helper Types ===
 
 // SubtitleSummary สรุปข้อมูล subtitle สำหรับแสดงใน video list
@@ -177,6 +241,8 @@ func VideoToVideoResponse(video *models.Video) *VideoResponse {
 		GallerySafeCount:      video.GallerySafeCount,
 		GalleryNsfwCount:      video.GalleryNsfwCount,
 		GallerySuperSafeCount: video.GallerySuperSafeCount, // Deprecated
+		GalleryCoverOverride:  video.GalleryCoverOverride,
+		GalleryLimits:         video.GalleryLimits,
 		CreatedAt:             video.CreatedAt,
 		UpdatedAt:             video.UpdatedAt,
 	}
@@ -261,3 +327,55 @@ func VideoToEmbedResponse(video *models.Video) *EmbedVideoResponse {
 		Duration:     video.Duration,
 	}
 }
+
+// VideoToDLQVideoResponse แปลง video ใน DLQ พร้อม error history
+func VideoToDLQVideoResponse(v *models.Video) DLQVideoResponse {
+	errorHistory := make([]ErrorRecordResponse, 0, len(v.ErrorHistory))
+	for _, record := range v.ErrorHistory {
+		errorHistory = append(errorHistory, ErrorRecordResponse{
+			Attempt:   record.Attempt,
+			Error:     record.Error,
+			WorkerID:  record.WorkerID,
+			Stage:     record.Stage,
+			Timestamp: record.Timestamp,
+		})
+	}
+
+	return DLQVideoResponse{
+		ID:           v.ID,
+		Code:         v.Code,
+		Title:        v.Title,
+		RetryCount:   v.RetryCount,
+		LastError:    v.LastError,
+		ErrorHistory: errorHistory,
+		CreatedAt:    v.CreatedAt,
+		UpdatedAt:    v.UpdatedAt,
+		UserID:       v.UserID,
+	}
+}
+
+// DLQEntriesToDetailResponse แปลง video + DLQ entries เป็น detail response
+func DLQEntriesToDetailResponse(v *models.Video, entries []*models.DLQEntry) *DLQJobDetailResponse {
+	response := &DLQJobDetailResponse{
+		DLQVideoResponse: VideoToDLQVideoResponse(v),
+		Status:           string(v.Status),
+		Entries:          make([]DLQEntryResponse, 0, len(entries)),
+	}
+	for _, e := range entries {
+		payload := json.RawMessage(e.Payload)
+		if len(payload) == 0 {
+			payload = json.RawMessage("{}")
+		}
+		response.Entries = append(response.Entries, DLQEntryResponse{
+			ID:       e.ID,
+			Source:   e.Source,
+			Error:    e.Error,
+			Attempts: e.Attempts,
+			WorkerID: e.WorkerID,
+			Stage:    e.Stage,
+			Payload:  payload,
+			FailedAt: e.FailedAt,
+		})
+	}
+	return response
+}
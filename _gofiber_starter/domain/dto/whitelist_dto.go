@@ -63,6 +63,37 @@ type AddDomainRequest struct {
 	Domain string `json:"domain" validate:"required,min=1,max=255"`
 }
 
+// ImportDomainsRequest สำหรับ import domains หลายตัว (JSON body)
+// รองรับ upload ไฟล์ newline/CSV ผ่าน multipart field "file" ด้วย
+type ImportDomainsRequest struct {
+	Domains []string `json:"domains" validate:"required,min=1,max=5000"`
+}
+
+// ImportDomainsResponse ผลการ import
+type ImportDomainsResponse struct {
+	AddedCount   int                 `json:"addedCount"`
+	SkippedCount int                 `json:"skippedCount"` // มีอยู่แล้ว หรือซ้ำใน input
+	InvalidCount int                 `json:"invalidCount"`
+	Added        []string            `json:"added"`
+	Skipped      []string            `json:"skipped"`
+	Invalid      []InvalidDomainItem `json:"invalid"`
+}
+
+// InvalidDomainItem domain ที่ import ไม่ได้ + เหตุผล
+type InvalidDomainItem struct {
+	Domain string `json:"domain"`
+	Reason string `json:"reason"`
+}
+
+// DomainMatchTestRequest สำหรับทดสอบ domain matching (debug whitelist)
+// - มี pattern: ทดสอบ pattern เดียวกับ domain
+// - ไม่มี pattern: ทดสอบกับ domains ของ active profiles ทั้งหมด (หรือเฉพาะ profile_id)
+type DomainMatchTestRequest struct {
+	Pattern   string     `json:"pattern" validate:"omitempty,max=255"`
+	Domain    string     `json:"domain" validate:"required,max=2048"` // domain หรือ URL เต็ม (เช่น Referer)
+	ProfileID *uuid.UUID `json:"profile_id"`
+}
+
 // AddPrerollAdRequest สำหรับเพิ่ม preroll ad
 type AddPrerollAdRequest struct {
 	// Ad Type & Content
@@ -162,6 +193,24 @@ type ProfileDomainResponse struct {
 	CreatedAt time.Time `json:"createdAt"`
 }
 
+// DomainMatchTestResponse ผลการทดสอบ domain matching
+type DomainMatchTestResponse struct {
+	Input           string                  `json:"input"`           // domain/URL ที่ส่งมา
+	ExtractedDomain string                  `json:"extractedDomain"` // domain หลังผ่าน ExtractDomainFromURL
+	Matched         bool                    `json:"matched"`
+	Rule            string                  `json:"rule,omitempty"`    // กฎที่ match (exact, www_variant, wildcard_subdomain, wildcard_base)
+	Pattern         string                  `json:"pattern,omitempty"` // pattern ที่ match
+	Profile         *DomainMatchProfileInfo `json:"profile,omitempty"` // profile เจ้าของ pattern (lookup mode)
+	CheckedPatterns int                     `json:"checkedPatterns"`   // จำนวน patterns ที่ทดสอบ
+}
+
+// DomainMatchProfileInfo ข้อมูล profile ที่ match
+type DomainMatchProfileInfo struct {
+	ID       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	IsActive bool      `json:"isActive"`
+}
+
 // PrerollAdResponse response DTO สำหรับ preroll ad
 type PrerollAdResponse struct {
 	ID        uuid.UUID `json:"id"`
@@ -216,6 +265,37 @@ type ProfileRankingResponse struct {
 	CompletionRate float64   `json:"completionRate"`
 }
 
+// ProfileStatsResponse สถิติการใช้งานของ profile ในช่วงเวลา
+type ProfileStatsResponse struct {
+	ProfileID   uuid.UUID                    `json:"profileId"`
+	ProfileName string                       `json:"profileName"`
+	IsActive    bool                         `json:"isActive"`
+	Domains     []string                     `json:"domains"` // patterns ที่ตั้งไว้ใน profile
+	Start       string                       `json:"start"`
+	End         string                       `json:"end"`
+	Totals      *AdImpressionStatsResponse   `json:"totals"`
+	Daily       []ProfileDailyStatsResponse  `json:"daily"`
+	ByDomain    []ProfileDomainUsageResponse `json:"byDomain"`
+}
+
+// ProfileDailyStatsResponse สถิติรายวัน
+type ProfileDailyStatsResponse struct {
+	Date          string `json:"date"` // YYYY-MM-DD
+	Impressions   int64  `json:"impressions"`
+	Completed     int64  `json:"completed"`
+	Skipped       int64  `json:"skipped"`
+	Errors        int64  `json:"errors"`
+	UniqueVideos  int64  `json:"uniqueVideos"`
+	UniqueDomains int64  `json:"uniqueDomains"`
+}
+
+// ProfileDomainUsageResponse impressions แยกตาม domain ที่เล่นจริง
+type ProfileDomainUsageResponse struct {
+	Domain         string `json:"domain"`
+	MatchedPattern string `json:"matchedPattern,omitempty"`
+	Impressions    int64  `json:"impressions"`
+}
+
 // EmbedConfigResponse config สำหรับ embed player
 type EmbedConfigResponse struct {
 	ProfileID uuid.UUID `json:"profileId"`
@@ -445,6 +525,47 @@ func ProfileRankingsToResponses(rankings []*models.ProfileAdStats) []*ProfileRan
 	return responses
 }
 
+// ProfileStatsToResponse แปลง usage stats เป็น response DTO
+func ProfileStatsToResponse(s *models.ProfileUsageStats, start, end time.Time) *ProfileStatsResponse {
+	if s == nil || s.Profile == nil {
+		return nil
+	}
+
+	resp := &ProfileStatsResponse{
+		ProfileID:   s.Profile.ID,
+		ProfileName: s.Profile.Name,
+		IsActive:    s.Profile.IsActive,
+		Domains:     make([]string, len(s.Profile.Domains)),
+		Start:       start.Format("2006-01-02"),
+		End:         end.Format("2006-01-02"),
+		Totals:      AdStatsToResponse(s.Totals),
+		Daily:       make([]ProfileDailyStatsResponse, len(s.Daily)),
+		ByDomain:    make([]ProfileDomainUsageResponse, len(s.ByDomain)),
+	}
+	for i, d := range s.Profile.Domains {
+		resp.Domains[i] = d.Domain
+	}
+	for i, d := range s.Daily {
+		resp.Daily[i] = ProfileDailyStatsResponse{
+			Date:          d.Date.Format("2006-01-02"),
+			Impressions:   d.Impressions,
+			Completed:     d.Completed,
+			Skipped:       d.Skipped,
+			Errors:        d.Errors,
+			UniqueVideos:  d.UniqueVideos,
+			UniqueDomains: d.UniqueDomains,
+		}
+	}
+	for i, u := range s.ByDomain {
+		resp.ByDomain[i] = ProfileDomainUsageResponse{
+			Domain:         u.Domain,
+			MatchedPattern: u.MatchedPattern,
+			Impressions:    u.Impressions,
+		}
+	}
+	return resp
+}
+
 // PrerollAdToResponse แปลง preroll ad model เป็น response DTO
 func PrerollAdToResponse(ad *models.PrerollAd) *PrerollAdResponse {
 	if ad == nil {
@@ -105,3 +105,35 @@ type ProfileAdStats struct {
 	TotalViews     int64     `json:"totalViews"`
 	CompletionRate float64   `json:"completionRate"`
 }
+
+// ProfileDailyStats สถิติรายวันของ Profile
+type ProfileDailyStats struct {
+	Date          time.Time `json:"date"`
+	Impressions   int64     `json:"impressions"`
+	Completed     int64     `json:"completed"`
+	Skipped       int64     `json:"skipped"`
+	Errors        int64     `json:"errors"`
+	UniqueVideos  int64     `json:"uniqueVideos"`
+	UniqueDomains int64     `json:"uniqueDomains"`
+}
+
+// DomainImpressionCount จำนวน impressions แยกตาม domain ที่เล่นจริง
+type DomainImpressionCount struct {
+	Domain      string `json:"domain"`
+	Impressions int64  `json:"impressions"`
+}
+
+// ProfileUsageStats สรุปการใช้งานของ Profile ในช่วงเวลา (daily + domains)
+type ProfileUsageStats struct {
+	Profile  *WhitelistProfile
+	Totals   *AdImpressionStats
+	Daily    []*ProfileDailyStats
+	ByDomain []*ProfileDomainUsage
+}
+
+// ProfileDomainUsage impressions ของ domain ที่เล่นจริง + pattern ของ profile ที่ match
+type ProfileDomainUsage struct {
+	Domain         string
+	MatchedPattern string // "" = ไม่ตรงกับ pattern ไหนแล้ว (เช่น domain ถูกลบออกจาก profile)
+	Impressions    int64
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DLQEntry job ที่ถูกย้ายเข้า Dead Letter Queue พร้อม payload เต็ม (append-only)
+// DLQ subscriber เขียนลงตารางนี้ทุกครั้งที่ได้รับ message - payload ผ่านการ redact secrets แล้ว
+// ใช้ดูว่า input ของ job ที่ fail ซ้ำคืออะไร ก่อนตัดสินใจ retry หรือทิ้ง
+type DLQEntry struct {
+	ID       uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	VideoID  uuid.UUID `gorm:"type:uuid;not null;index:idx_dlq_entries_video_failed"`
+	Source   string    `gorm:"size:20;not null"` // transcode, gallery, subtitle
+	Error    string    `gorm:"type:text"`
+	Attempts int       `gorm:"default:0"`
+	WorkerID string    `gorm:"size:100"`
+	Stage    string    `gorm:"size:50"`
+	Payload  string    `gorm:"type:jsonb"` // original job (redacted)
+
+	FailedAt  time.Time `gorm:"index:idx_dlq_entries_video_failed"`
+	CreatedAt time.Time
+}
+
+func (DLQEntry) TableName() string {
+	return "dlq_entries"
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Gallery classification tiers (ตรงกับ classification ของ worker)
+const (
+	GalleryTierSuperSafe = "super_safe"
+	GalleryTierSafe      = "safe"
+	GalleryTierNsfw      = "nsfw"
+	GalleryTierError     = "error"
+)
+
+// GalleryImageScore ผล classify ของภาพ gallery หนึ่งภาพ
+// Worker เขียนลงตารางนี้ตอนจบ gallery job (ทุก frame ที่ classify รวมที่ถูกทิ้ง)
+// สร้าง gallery ใหม่ = แทนที่ scores ชุดเดิมของ video
+// ใช้ดู distribution ของ tier เพื่อปรับ threshold ของ classifier
+type GalleryImageScore struct {
+	ID       uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	VideoID  uuid.UUID `gorm:"type:uuid;not null;index"`
+	Filename string    `gorm:"size:255;not null"`
+	Tier     string    `gorm:"size:20;not null;index"` // super_safe, safe, nsfw, error
+
+	NsfwScore      float64 `gorm:"default:0"`
+	FaceScore      float64 `gorm:"default:0"`
+	AestheticScore float64 `gorm:"default:0"`
+	MosaicDetected bool    `gorm:"default:false"`
+	POVDetected    bool    `gorm:"column:pov_detected;default:false"`
+
+	CreatedAt time.Time
+}
+
+func (GalleryImageScore) TableName() string {
+	return "gallery_image_scores"
+}
+
+// GalleryTierStats สถิติรวมของ tier หนึ่ง (ไม่ใช่ table - ผลจาก GROUP BY tier)
+type GalleryTierStats struct {
+	Tier              string
+	Count             int64
+	AvgNsfwScore      float64
+	AvgFaceScore      float64
+	AvgAestheticScore float64
+	MosaicCount       int64
+	POVCount          int64 `gorm:"column:pov_count"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobEventSource แหล่งที่มาของ event (pipeline ที่ส่ง progress)
+const (
+	JobEventSourceTranscode = "transcode"
+	JobEventSourceGallery   = "gallery"
+	JobEventSourceSEO       = "seo"
+)
+
+// JobEventStageCompleted stage สุดท้ายเมื่อ job สำเร็จ (ทุก source ใช้ค่าเดียวกัน)
+const JobEventStageCompleted = "completed"
+
+// JobEvent บันทึก progress แต่ละครั้งของ pipeline (append-only)
+// Worker เขียนลงตารางนี้ทุกครั้งที่ publishProgress/sendProgress
+// ใช้ดู timeline ย้อนหลังว่า job ไปถึง stage ไหนและช้าตรงไหน
+type JobEvent struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	VideoID   uuid.UUID `gorm:"type:uuid;not null;index:idx_job_events_video_created"`
+	Source    string    `gorm:"size:20;not null"` // transcode, gallery, seo
+	Stage     string    `gorm:"size:50;not null"` // เช่น processing, ai_processing, completed, failed
+	Progress  float64   `gorm:"default:0"`        // 0-100
+	Message   string    `gorm:"type:text"`
+	CreatedAt time.Time `gorm:"index:idx_job_events_video_created"`
+}
+
+func (JobEvent) TableName() string {
+	return "job_events"
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProcessingCost ค่าใช้จ่าย/compute ของ worker job หนึ่งครั้ง (append-only)
+// Worker เขียนลงตารางนี้ตอนจบ job (source เดียวกับ JobEvent: gallery, seo)
+// ใช้ดูว่า video ไหนประมวลผลแพง - รัน job ซ้ำ = เพิ่ม record ใหม่
+type ProcessingCost struct {
+	ID      uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	VideoID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Source  string    `gorm:"size:20;not null"` // gallery, seo
+
+	// SEO worker
+	GeminiPromptTokens int64 `gorm:"default:0"`
+	GeminiOutputTokens int64 `gorm:"default:0"`
+	TTSCharacters      int   `gorm:"default:0"`
+	EmbeddingChars     int   `gorm:"default:0"`
+
+	// Gallery worker
+	FrameCount          int   `gorm:"default:0"`
+	ClassifierRuntimeMs int64 `gorm:"default:0"`
+
+	JobDurationMs int64 `gorm:"default:0"` // เวลาทั้ง job
+	CreatedAt     time.Time
+}
+
+func (ProcessingCost) TableName() string {
+	return "processing_costs"
+}
@@ -1,10 +1,13 @@
 package models
 
 import (
+	"errors"
+	"net/url"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"golang.org/x/net/idna"
 )
 
 // ProfileDomain เก็บ domain ที่ผูกกับ WhitelistProfile
@@ -31,13 +34,27 @@ func (d *ProfileDomain) MatchesDomain(domain string) bool {
 	return MatchDomain(d.Domain, domain)
 }
 
+// Domain match rules - บอกว่า MatchDomain match ด้วยกฎข้อไหน (ใช้ debug whitelist)
+const (
+	DomainRuleExact             = "exact"              // game1.com = game1.com
+	DomainRuleWWWVariant        = "www_variant"        // www.game1.com ↔ game1.com
+	DomainRuleWildcardSubdomain = "wildcard_subdomain" // *.game1.com ↔ sub.game1.com
+	DomainRuleWildcardBase      = "wildcard_base"      // *.game1.com ↔ game1.com
+)
+
 // MatchDomain ตรวจสอบว่า domain ตรงกับ pattern หรือไม่
 func MatchDomain(pattern, domain string) bool {
-	pattern = strings.ToLower(strings.TrimSpace(pattern))
-	domain = strings.ToLower(strings.TrimSpace(domain))
+	return MatchDomainRule(pattern, domain) != ""
+}
+
+// MatchDomainRule เหมือน MatchDomain แต่คืนชื่อกฎที่ match ("" = ไม่ match)
+func MatchDomainRule(pattern, domain string) string {
+	// เทียบในรูป punycode ทั้งคู่ (münchen.de = xn--mnchen-3ya.de)
+	pattern = toASCIIDomain(pattern)
+	domain = toASCIIDomain(domain)
 
 	if pattern == "" || domain == "" {
-		return false
+		return ""
 	}
 
 	// Wildcard match: "*.game1.com"
@@ -47,19 +64,23 @@ func MatchDomain(pattern, domain string) bool {
 
 		// Match: sub.game1.com, www.game1.com
 		if strings.HasSuffix(domain, suffix) {
-			return true
+			return DomainRuleWildcardSubdomain
 		}
 		// Match ตัว base domain ด้วย (game1.com เฉยๆ)
 		if domain == baseDomain {
-			return true
+			return DomainRuleWildcardBase
 		}
-		return false
+		return ""
 	}
 
 	// Exact match or with/without www
-	return domain == pattern ||
-		domain == "www."+pattern ||
-		"www."+domain == pattern
+	switch {
+	case domain == pattern:
+		return DomainRuleExact
+	case domain == "www."+pattern || "www."+domain == pattern:
+		return DomainRuleWWWVariant
+	}
+	return ""
 }
 
 // NormalizeDomain ทำให้ domain อยู่ในรูปแบบมาตรฐาน
@@ -74,31 +95,115 @@ func NormalizeDomain(domain string) string {
 	if idx := strings.Index(domain, "/"); idx != -1 {
 		domain = domain[:idx]
 	}
-	return domain
+	return toASCIIDomain(domain)
+}
+
+// ValidateDomainPattern ตรวจ syntax ของ domain pattern (ใช้หลัง NormalizeDomain)
+// รองรับ "game1.com", "sub.game1.com", "*.game1.com" - ห้าม wildcard กลาง domain
+func ValidateDomainPattern(pattern string) error {
+	if pattern == "" {
+		return errors.New("empty domain")
+	}
+	if len(pattern) > 255 {
+		return errors.New("domain too long (max 255)")
+	}
+
+	host := strings.TrimPrefix(pattern, "*.")
+	if strings.Contains(host, "*") {
+		return errors.New("wildcard only allowed as leading \"*.\"")
+	}
+
+	labels := strings.Split(host, ".")
+	if len(labels) < 2 {
+		return errors.New("domain must contain at least one dot")
+	}
+	for _, label := range labels {
+		if label == "" || len(label) > 63 {
+			return errors.New("invalid label length")
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return errors.New("label cannot start or end with '-'")
+		}
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+				return errors.New("invalid character in domain")
+			}
+		}
+	}
+	return nil
 }
 
 // ExtractDomainFromURL ดึง domain จาก URL (Referer หรือ Origin)
-func ExtractDomainFromURL(url string) string {
-	if url == "" {
+// ใช้ net/url (รองรับ IPv6 "[::1]:8080" และ userinfo "user:pass@host") - parse ไม่ได้ = ตัด string แบบเดิม
+func ExtractDomainFromURL(rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
 		return ""
 	}
 
+	// ไม่มี scheme (เช่น "game1.com/path") → parse เป็น network-path reference ให้ได้ Host
+	target := rawURL
+	if !strings.Contains(target, "://") {
+		target = "//" + target
+	}
+	if u, err := url.Parse(target); err == nil {
+		if host := u.Hostname(); host != "" {
+			return toASCIIDomain(host)
+		}
+	}
+
+	return toASCIIDomain(extractDomainHeuristic(rawURL))
+}
+
+// extractDomainHeuristic ตัด protocol/userinfo/path/port ด้วย string index (fallback ของ URL ที่ net/url parse ไม่ได้)
+func extractDomainHeuristic(rawURL string) string {
 	// ลบ protocol
-	url = strings.TrimPrefix(url, "http://")
-	url = strings.TrimPrefix(url, "https://")
+	if idx := strings.Index(rawURL, "://"); idx != -1 {
+		rawURL = rawURL[idx+3:]
+	}
 
 	// ลบ path และ query string
-	if idx := strings.Index(url, "/"); idx != -1 {
-		url = url[:idx]
+	if idx := strings.IndexAny(rawURL, "/?#"); idx != -1 {
+		rawURL = rawURL[:idx]
 	}
-	if idx := strings.Index(url, "?"); idx != -1 {
-		url = url[:idx]
+
+	// ลบ userinfo
+	if idx := strings.LastIndex(rawURL, "@"); idx != -1 {
+		rawURL = rawURL[idx+1:]
+	}
+
+	// IPv6 "[::1]:8080" → "::1"
+	if strings.HasPrefix(rawURL, "[") {
+		if idx := strings.Index(rawURL, "]"); idx != -1 {
+			return rawURL[1:idx]
+		}
 	}
 
 	// ลบ port ถ้ามี
-	if idx := strings.Index(url, ":"); idx != -1 {
-		url = url[:idx]
+	if idx := strings.Index(rawURL, ":"); idx != -1 {
+		rawURL = rawURL[:idx]
 	}
 
-	return strings.ToLower(strings.TrimSpace(url))
+	return rawURL
+}
+
+// toASCIIDomain แปลง internationalized domain name เป็น punycode (lowercase)
+// รองรับ wildcard pattern ("*.münchen.de") - แปลงไม่ได้ → คืนค่า lowercase เดิม
+func toASCIIDomain(domain string) string {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	if domain == "" {
+		return domain
+	}
+
+	prefix := ""
+	host := domain
+	if strings.HasPrefix(host, "*.") {
+		prefix, host = "*.", host[2:]
+	}
+
+	ascii, err := idna.Lookup.ToASCII(host)
+	if err != nil || ascii == "" {
+		return domain
+	}
+	return prefix + ascii
 }
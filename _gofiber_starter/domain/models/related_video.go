@@ -0,0 +1,15 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// RelatedVideo ผลลัพธ์ของ vector similarity search บน article_embeddings
+// (ตารางอยู่ DB ของ SEO worker - similarity มาจาก SEO worker, metadata จาก videos ของเรา)
+type RelatedVideo struct {
+	ID           uuid.UUID
+	Code         string
+	Title        string
+	ThumbnailURL string
+	Similarity   float64 // 0-1 (cosine similarity)
+}
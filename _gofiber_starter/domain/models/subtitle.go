@@ -26,6 +26,7 @@ type SubtitleType string
 const (
 	SubtitleTypeOriginal   SubtitleType = "original"   // ภาษาต้นฉบับ (from Whisper)
 	SubtitleTypeTranslated SubtitleType = "translated" // แปลจากภาษาอื่น
+	SubtitleTypeImported   SubtitleType = "imported"   // นำเข้าจากไฟล์ภายนอก (SRT/VTT/ASS)
 )
 
 // Subtitle แต่ละ record = 1 ภาษา ของ 1 video
@@ -51,6 +52,8 @@ type Subtitle struct {
 
 	// Stuck Detection: บันทึกเวลาที่ worker เริ่มทำจริง
 	ProcessingStartedAt *time.Time `gorm:"index"`
+	// Heartbeat ล่าสุดจาก worker (nil = worker ไม่ส่ง heartbeat → ใช้ ProcessingStartedAt แทน)
+	LastHeartbeatAt *time.Time
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
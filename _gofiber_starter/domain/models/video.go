@@ -3,6 +3,7 @@ package models
 import (
 	"database/sql/driver"
 	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -70,6 +71,66 @@ func (q QualitySizes) Value() (driver.Value, error) {
 	return json.Marshal(q)
 }
 
+// GalleryLimits เก็บ tier limits ที่ worker ใช้ตอน classify gallery
+// Example: {"min_super_safe_images": 10, "min_safe_images": 12, "max_safe_images": 10, "max_nsfw_images": 20}
+type GalleryLimits map[string]int
+
+// Scan implements sql.Scanner for GalleryLimits
+func (g *GalleryLimits) Scan(value interface{}) error {
+	if value == nil {
+		*g = GalleryLimits{}
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return nil
+	}
+
+	return json.Unmarshal(bytes, g)
+}
+
+// Value implements driver.Valuer for GalleryLimits
+func (g GalleryLimits) Value() (driver.Value, error) {
+	if g == nil {
+		return "{}", nil
+	}
+	return json.Marshal(g)
+}
+
+// ThumbnailVariants poster ในรูปแบบ modern format ที่ worker สร้างคู่กับ JPEG
+// Example: {"webp": "thumbnails/ABC123.webp", "avif": "thumbnails/ABC123.avif"}
+type ThumbnailVariants map[string]string
+
+// Thumbnail variant formats
+const (
+	ThumbnailFormatWebP = "webp"
+	ThumbnailFormatAVIF = "avif"
+)
+
+// Scan implements sql.Scanner for ThumbnailVariants
+func (t *ThumbnailVariants) Scan(value interface{}) error {
+	if value == nil {
+		*t = ThumbnailVariants{}
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return nil
+	}
+
+	return json.Unmarshal(bytes, t)
+}
+
+// Value implements driver.Valuer for ThumbnailVariants
+func (t ThumbnailVariants) Value() (driver.Value, error) {
+	if t == nil {
+		return "{}", nil
+	}
+	return json.Marshal(t)
+}
+
 // VideoStatus สถานะของ video
 type VideoStatus string
 
@@ -80,6 +141,7 @@ const (
 	VideoStatusReady      VideoStatus = "ready"
 	VideoStatusFailed     VideoStatus = "failed"
 	VideoStatusDeadLetter VideoStatus = "dead_letter" // Poison pill - ต้องตรวจสอบ manual
+	VideoStatusCancelled  VideoStatus = "cancelled"   // admin ยกเลิก - retry ได้เหมือน failed
 )
 
 // CacheStatus สถานะ CDN cache
@@ -134,16 +196,29 @@ type Video struct {
 
 	// Gallery fields - Manual Selection Flow
 	// Status: none → processing → pending_review → ready
-	GalleryStatus      string `gorm:"size:20;default:none"` // none, processing, pending_review, ready
+	GalleryStatus      string `gorm:"size:20;default:none"` // none, processing, pending_review, ready, cancelled
 	GalleryPath        string `gorm:"type:text"`            // S3 path prefix e.g., "gallery/ABC123"
 	GallerySourceCount int    `gorm:"default:0"`            // ภาพใน source/ (ผ่าน gender filter, รอ admin เลือก)
 	GalleryCount       int    `gorm:"default:0"`            // จำนวนภาพทั้งหมด (safe + nsfw)
 	GallerySafeCount   int    `gorm:"default:0"`            // ภาพ safe (admin เลือก) - Public
 	GalleryNsfwCount   int    `gorm:"default:0"`            // ภาพ nsfw (admin เลือก) - Members only
 
+	// Tier limits ที่ worker ใช้ได้ counts ชุดล่าสุด (audit เมื่อเปลี่ยน config)
+	GalleryLimits GalleryLimits `gorm:"type:jsonb;default:'{}'"`
+
+	// Cover override สำหรับ SEO article (ว่าง = ให้ SEO worker เลือกเอง)
+	// ชื่อไฟล์ใน safe/ (e.g. "012.jpg") หรือ external URL
+	GalleryCoverOverride string `gorm:"type:text"`
+
 	// Deprecated - kept for backward compatibility
 	GallerySuperSafeCount int `gorm:"default:0"` // ไม่ใช้แล้ว (backward compat)
 
+	// Poster variants (WebP/AVIF) - ว่าง = มีแค่ ThumbnailURL (JPEG)
+	ThumbnailVariants ThumbnailVariants `gorm:"type:jsonb;default:'{}'"`
+
+	// เวลาที่ SEO worker publish บทความสำเร็จล่าสุด (API set เมื่อได้ event seo completed - บทความอยู่ DB ของ subth)
+	ArticlePublishedAt *time.Time `gorm:"type:timestamptz"`
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 
@@ -232,6 +307,17 @@ func (v *Video) HasH264Fallback() bool {
 	return v.HLSPathH264 != ""
 }
 
+// ThumbnailForAccept เลือก poster ตาม Accept header ของ browser (AVIF > WebP > JPEG)
+func (v *Video) ThumbnailForAccept(accept string) string {
+	if strings.Contains(accept, "image/avif") && v.ThumbnailVariants[ThumbnailFormatAVIF] != "" {
+		return v.ThumbnailVariants[ThumbnailFormatAVIF]
+	}
+	if strings.Contains(accept, "image/webp") && v.ThumbnailVariants[ThumbnailFormatWebP] != "" {
+		return v.ThumbnailVariants[ThumbnailFormatWebP]
+	}
+	return v.ThumbnailURL
+}
+
 // GetQualityCount จำนวน quality ที่มี
 func (v *Video) GetQualityCount() int {
 	if v.QualitySizes == nil {
@@ -252,6 +338,29 @@ func (v *Video) GetQualities() []string {
 	return qualities
 }
 
+// galleryQualityOrder ลำดับ quality ที่ใช้สร้าง gallery เมื่อไม่มี quality ที่ต้องการ (สูง → ต่ำ)
+var galleryQualityOrder = []string{"1080p", "720p", "480p", "360p"}
+
+// GalleryQuality เลือก HLS variant สำหรับดึงภาพ gallery
+// preferred มีอยู่จริง = ใช้ตัวนั้น (worker ย่อเป็น 1280x720 อยู่แล้ว ไม่ต้องโหลด 1080p)
+// ไม่ระบุ/ไม่มี = quality สูงสุดที่มี → video.Quality → "720p"
+func (v *Video) GalleryQuality(preferred string) string {
+	if preferred != "" {
+		if _, exists := v.QualitySizes[preferred]; exists {
+			return preferred
+		}
+	}
+	for _, q := range galleryQualityOrder {
+		if _, exists := v.QualitySizes[q]; exists {
+			return q
+		}
+	}
+	if v.Quality != "" {
+		return v.Quality
+	}
+	return "720p"
+}
+
 // HasAudioExtracted ตรวจสอบว่ามี audio ที่ตัดไว้หรือไม่
 func (v *Video) HasAudioExtracted() bool {
 	return v.AudioPath != ""
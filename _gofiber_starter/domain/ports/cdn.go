@@ -0,0 +1,31 @@
+package ports
+
+import "context"
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// CDN Purger Port - สำหรับล้าง cache ที่ CDN edge (Cloudflare)
+// path ทั้งหมด relative กับ CDN base URL และตรงกับ storage path (e.g. "hls/ABC123/master.m3u8")
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// CDNPurgeRequest - รายการที่ต้องล้าง cache
+type CDNPurgeRequest struct {
+	Files    []string // ไฟล์เดี่ยว (e.g. "subtitles/ABC123/th.srt")
+	Prefixes []string // ทั้ง folder (e.g. "hls/ABC123/") - ใช้ได้เมื่อ SupportsPrefix() เท่านั้น
+}
+
+// IsEmpty ไม่มีอะไรต้อง purge
+func (r *CDNPurgeRequest) IsEmpty() bool {
+	return r == nil || (len(r.Files) == 0 && len(r.Prefixes) == 0)
+}
+
+// CDNPurgerPort - Interface สำหรับล้าง cache ที่ CDN
+type CDNPurgerPort interface {
+	// Purge ล้าง cache ของ files/prefixes
+	Purge(ctx context.Context, req *CDNPurgeRequest) error
+
+	// SupportsPrefix CDN purge ทั้ง folder ได้หรือไม่ (false = caller ต้องแตก prefix เป็นรายไฟล์)
+	SupportsPrefix() bool
+
+	// IsEnabled ตรวจสอบว่าตั้งค่า purge ไว้หรือไม่
+	IsEnabled() bool
+}
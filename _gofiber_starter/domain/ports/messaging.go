@@ -1,6 +1,9 @@
 package ports
 
-import "context"
+import (
+	"context"
+	"errors"
+)
 
 // ═══════════════════════════════════════════════════════════════════════════════
 // Job Queue Port - สำหรับส่ง/รับ Transcode Jobs
@@ -15,8 +18,20 @@ type TranscodeJobData struct {
 	Codec        string
 	Qualities    []string
 	UseByteRange bool
+	Priority     int // JobPriority* (0 = normal)
 }
 
+// Job priority (ตรงกับ SEOArticleJob.Priority ของ SEO worker)
+// urgent = publish ไป subject ".priority" ที่ worker ดึงก่อนคิวปกติ
+const (
+	JobPriorityUrgent   = 1
+	JobPriorityNormal   = 2
+	JobPriorityBackfill = 3
+)
+
+// ErrJobInFlight job ของวิดีโอถูก worker ดึงไปแล้ว (ยังไม่ ack) - ลบ/ย้ายคิวไม่ได้
+var ErrJobInFlight = errors.New("job already delivered to a worker")
+
 // QueueStatus - สถานะของ job queue
 type QueueStatus struct {
 	StreamName  string
@@ -38,8 +53,28 @@ type JobQueuePort interface {
 // Progress Publisher Port - สำหรับส่ง Progress Updates
 // ═══════════════════════════════════════════════════════════════════════════════
 
+// Progress job types - ProgressData.JobType (ว่าง = worker รุ่นเก่า, broadcaster เดาจาก field อื่น)
+const (
+	ProgressJobTranscode = "transcode"
+	ProgressJobSubtitle  = "subtitle"
+	ProgressJobGallery   = "gallery"
+	ProgressJobWarmCache = "warmcache"
+	ProgressJobReel      = "reel"
+	ProgressJobSEO       = "seo"
+)
+
+// Progress statuses ที่ใช้ร่วมกันทุก job type (ส่งไป frontend)
+const (
+	ProgressStatusStarted    = "started"
+	ProgressStatusProcessing = "processing"
+	ProgressStatusCompleted  = "completed"
+	ProgressStatusFailed     = "failed"
+	ProgressStatusCancelled  = "cancelled" // admin ยกเลิก job (JOB_CANCEL KV)
+)
+
 // ProgressData - Plain struct สำหรับ progress update
 type ProgressData struct {
+	JobType    string // ProgressJob* (ว่าง = เดาจาก ReelID/SubtitleID/Stage/Quality)
 	VideoID    string
 	VideoCode  string
 	Status     string  // "processing", "completed", "failed"
@@ -18,6 +18,15 @@ type DLQNotification struct {
 	FailedAt   string
 }
 
+// SubtitleReadyNotification - ข้อมูลสำหรับแจ้งเตือนเมื่อ subtitle พร้อม review
+type SubtitleReadyNotification struct {
+	VideoCode      string
+	Title          string
+	Language       string
+	Type           string // original, translated
+	SourceLanguage string // ภาษาต้นทาง (เฉพาะ translated)
+}
+
 // NotifierPort - Interface สำหรับส่งการแจ้งเตือน
 type NotifierPort interface {
 	// SendDLQAlert ส่งแจ้งเตือนเมื่อวิดีโอเข้า DLQ
@@ -29,6 +38,9 @@ type NotifierPort interface {
 	// SendTranscodeFailAlert ส่งแจ้งเตือนเมื่อ transcode ล้มเหลว
 	SendTranscodeFailAlert(ctx context.Context, videoCode, title, errorMsg string) error
 
+	// SendSubtitleReadyAlert ส่งแจ้งเตือนเมื่อ transcribe/translate เสร็จ (subtitle = ready)
+	SendSubtitleReadyAlert(ctx context.Context, notification *SubtitleReadyNotification) error
+
 	// SendWorkerOfflineAlert ส่งแจ้งเตือนเมื่อ worker offline
 	SendWorkerOfflineAlert(ctx context.Context, workerID, hostname string, lastSeen string) error
 
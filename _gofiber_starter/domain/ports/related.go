@@ -0,0 +1,37 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Related Finder Port - vector search บน article_embeddings ของ SEO worker
+// ตาราง embeddings อยู่ DB ของ SEO เท่านั้น → ได้แค่ video IDs กลับมา, metadata ดึงจาก DB ของ API เอง
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// RelatedQuery เงื่อนไขการค้นหา (ค่าว่าง = ไม่ filter)
+type RelatedQuery struct {
+	VideoID       uuid.UUID
+	Limit         int
+	CastID        string
+	MakerID       string
+	TagID         string
+	MinSimilarity float64
+}
+
+// RelatedMatch video ที่ embedding ใกล้กัน เรียงตาม similarity มากไปน้อย
+type RelatedMatch struct {
+	VideoID    uuid.UUID
+	Similarity float64 // 0-1 (cosine similarity)
+}
+
+// RelatedFinderPort - Interface สำหรับหา videos ที่คล้ายกัน
+type RelatedFinderPort interface {
+	// FindRelated หา videos ที่ใกล้ video ต้นทาง - hasEmbedding = false เมื่อต้นทางยังไม่มี embedding
+	FindRelated(ctx context.Context, query *RelatedQuery) (matches []RelatedMatch, hasEmbedding bool, err error)
+
+	// IsEnabled ตรวจสอบว่าตั้งค่า SEO worker ไว้หรือไม่
+	IsEnabled() bool
+}
@@ -1,10 +1,15 @@
 package ports
 
 import (
+	"context"
+	"errors"
 	"io"
 	"time"
 )
 
+// ErrObjectNotFound คืนจาก StatObject เมื่อไม่มีไฟล์ใน storage
+var ErrObjectNotFound = errors.New("object not found")
+
 // StoragePort คือ interface หลักสำหรับ storage
 // ทำให้เปลี่ยน storage provider ได้ง่าย (Local, Bunny, S3, etc.)
 type StoragePort interface {
@@ -21,6 +26,10 @@ type StoragePort interface {
 	// สำหรับลบ HLS folder ที่มีหลายไฟล์
 	DeleteFolder(prefix string) error
 
+	// DeleteObjects ลบหลายไฟล์ในครั้งเดียว (bulk delete)
+	// ใช้ batch API ของ provider ถ้ามี - ลดจำนวน API calls ตอนลบ HLS segments
+	DeleteObjects(ctx context.Context, paths []string) error
+
 	// GetFileURL รับ URL สำหรับเข้าถึงไฟล์
 	GetFileURL(path string) string
 
@@ -63,6 +72,19 @@ type StoragePort interface {
 	// ListFiles list ไฟล์ทั้งหมดใน prefix (folder)
 	// return: slice ของ file paths
 	ListFiles(prefix string) ([]string, error)
+
+	// StatObject ดึงข้อมูลไฟล์ (ขนาด, content type) โดยไม่อ่านเนื้อหา
+	// ไม่มีไฟล์ = ErrObjectNotFound
+	StatObject(path string) (*ObjectInfo, error)
+}
+
+// ObjectInfo ข้อมูลไฟล์ใน storage
+type ObjectInfo struct {
+	Path         string
+	Size         int64
+	ContentType  string
+	ETag         string
+	LastModified time.Time
 }
 
 // CompletedPart ข้อมูล part ที่ upload สำเร็จ
@@ -37,6 +37,10 @@ type AdStatsRepository interface {
 	// Skip time distribution
 	GetSkipTimeDistribution(ctx context.Context, start, end time.Time) (map[int]int64, error)
 
+	// Per-profile usage (grouped by day / domain)
+	GetDailyStatsByProfile(ctx context.Context, profileID uuid.UUID, start, end time.Time) ([]*models.ProfileDailyStats, error)
+	GetDomainCountsByProfile(ctx context.Context, profileID uuid.UUID, start, end time.Time) ([]*models.DomainImpressionCount, error)
+
 	// Profile performance ranking
 	GetProfileRanking(ctx context.Context, start, end time.Time, limit int) ([]*models.ProfileAdStats, error)
 
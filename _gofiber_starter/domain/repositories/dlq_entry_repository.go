@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+)
+
+// DLQEntryRepository interface สำหรับ job payloads ที่เข้า Dead Letter Queue
+type DLQEntryRepository interface {
+	// Create บันทึก DLQ entry
+	Create(ctx context.Context, entry *models.DLQEntry) error
+
+	// GetByVideoID ดึง entries ทั้งหมดของ video เรียงตามเวลา (ใหม่ → เก่า)
+	GetByVideoID(ctx context.Context, videoID uuid.UUID) ([]*models.DLQEntry, error)
+}
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+
+	"gofiber-template/domain/models"
+)
+
+// GalleryImageScoreRepository interface สำหรับผล classify รายภาพของ gallery
+type GalleryImageScoreRepository interface {
+	// GetTierStats นับจำนวนและค่าเฉลี่ย scores แยกตาม tier (ทุก gallery)
+	GetTierStats(ctx context.Context) ([]models.GalleryTierStats, error)
+
+	// GetSuperSafeOutliers ดึงภาพ super_safe ที่ nsfw_score >= minNsfwScore (มาก → น้อย)
+	GetSuperSafeOutliers(ctx context.Context, minNsfwScore float64, limit int) ([]*models.GalleryImageScore, error)
+
+	// CountSuperSafeOutliers นับภาพ super_safe ที่ nsfw_score >= minNsfwScore
+	CountSuperSafeOutliers(ctx context.Context, minNsfwScore float64) (int64, error)
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+)
+
+// JobEventRepository interface สำหรับ job event log (pipeline timeline)
+type JobEventRepository interface {
+	// Create บันทึก event ใหม่
+	Create(ctx context.Context, event *models.JobEvent) error
+
+	// GetByVideoID ดึง events ทั้งหมดของ video เรียงตามเวลา (เก่า → ใหม่)
+	GetByVideoID(ctx context.Context, videoID uuid.UUID) ([]*models.JobEvent, error)
+}
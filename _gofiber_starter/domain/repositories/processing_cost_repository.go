@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+)
+
+// ProcessingCostRepository interface สำหรับ processing cost ของ worker jobs
+type ProcessingCostRepository interface {
+	// Create บันทึก cost ของ job
+	Create(ctx context.Context, cost *models.ProcessingCost) error
+
+	// GetByVideoID ดึง costs ทั้งหมดของ video เรียงตามเวลา (เก่า → ใหม่)
+	GetByVideoID(ctx context.Context, videoID uuid.UUID) ([]*models.ProcessingCost, error)
+}
@@ -51,12 +51,16 @@ type SubtitleRepository interface {
 	// GetByStatus ดึง subtitles ตาม status
 	GetByStatus(ctx context.Context, status models.SubtitleStatus) ([]*models.Subtitle, error)
 
-	// GetStuckProcessing หา subtitles ที่ processing นานเกินไป (worker crash)
-	GetStuckProcessing(ctx context.Context, threshold time.Time) ([]*models.Subtitle, error)
+	// GetStuckProcessing หา subtitles ที่ processing แล้ว worker เงียบไป (worker crash)
+	// มี heartbeat = heartbeat ล่าสุดก่อน heartbeatThreshold, ไม่มี heartbeat = เริ่มก่อน startedThreshold
+	GetStuckProcessing(ctx context.Context, heartbeatThreshold, startedThreshold time.Time) ([]*models.Subtitle, error)
 
 	// MarkSubtitleFailed mark subtitle as failed พร้อม error message
 	MarkSubtitleFailed(ctx context.Context, id uuid.UUID, errorMsg string) error
 
-	// UpdateProcessingStartedAt บันทึกเวลาที่ worker เริ่มทำจริง
+	// UpdateProcessingStartedAt บันทึกเวลาที่ worker เริ่มทำจริง (ล้าง heartbeat ของรอบก่อน)
 	UpdateProcessingStartedAt(ctx context.Context, id uuid.UUID, startedAt time.Time) error
+
+	// UpdateHeartbeat บันทึก heartbeat จาก worker - false = subtitle ไม่ได้อยู่ระหว่าง processing แล้ว
+	UpdateHeartbeat(ctx context.Context, id uuid.UUID, at time.Time) (bool, error)
 }
@@ -13,6 +13,8 @@ type VideoRepository interface {
 	Create(ctx context.Context, video *models.Video) error
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Video, error)
 	GetByCode(ctx context.Context, code string) (*models.Video, error)
+	// GetByIDs ดึงหลาย videos ตาม IDs (ไม่รับประกันลำดับ, ID ที่ไม่มีจะไม่อยู่ในผลลัพธ์)
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.Video, error)
 	GetByUserID(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*models.Video, error)
 	GetByCategory(ctx context.Context, categoryID uuid.UUID, offset, limit int) ([]*models.Video, error)
 	GetByStatus(ctx context.Context, status models.VideoStatus, offset, limit int) ([]*models.Video, error)
@@ -26,6 +28,10 @@ type VideoRepository interface {
 	ListReady(ctx context.Context, offset, limit int) ([]*models.Video, error)
 	// ListWithFilters ดึง videos พร้อม filter, search, sort, pagination
 	ListWithFilters(ctx context.Context, params *dto.VideoFilterRequest) ([]*models.Video, int64, error)
+	// SearchWithFilters เหมือน ListWithFilters แต่เรียงตามความเกี่ยวข้องกับ params.Search (pg_trgm)
+	SearchWithFilters(ctx context.Context, params *dto.VideoFilterRequest) ([]*models.Video, int64, error)
+	// ListWithFiltersCursor เหมือน ListWithFilters แต่ใช้ cursor (params.Cursor) แทน offset และไม่นับ total
+	ListWithFiltersCursor(ctx context.Context, params *dto.VideoFilterRequest) ([]*models.Video, string, error)
 	Count(ctx context.Context) (int64, error)
 	CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
 	CountByStatus(ctx context.Context, status models.VideoStatus) (int64, error)
@@ -53,6 +59,21 @@ type VideoRepository interface {
 	GetByGalleryStatus(ctx context.Context, galleryStatus string, offset, limit int) ([]*models.Video, error)
 	// CountByGalleryStatus นับ videos ตาม gallery_status
 	CountByGalleryStatus(ctx context.Context, galleryStatus string) (int64, error)
+	// ListByGalleryStatus ดึง videos ตาม gallery_status พร้อม total (รอนานสุดก่อน - ใช้เป็นคิว review)
+	ListByGalleryStatus(ctx context.Context, galleryStatus string, offset, limit int) ([]*models.Video, int64, error)
 	// GetGalleryFailed ดึง videos ที่ gallery failed (status=ready, gallery_status=none, last_error not empty)
 	GetGalleryFailed(ctx context.Context, offset, limit int) ([]*models.Video, int64, error)
+	// ListReadyWithoutGallery ดึง videos ที่ ready แต่ยังไม่มี gallery (gallery_count = 0) สำหรับ backfill
+	ListReadyWithoutGallery(ctx context.Context, offset, limit int) ([]*models.Video, int64, error)
+	// ListReadyWithoutSubtitles ดึง videos ที่ ready + มี audio แต่ยังไม่มี original subtitle ที่ ready
+	ListReadyWithoutSubtitles(ctx context.Context, offset, limit int) ([]*models.Video, int64, error)
+	// ListWithGallery ดึง videos ที่มี gallery_path และไม่ได้กำลังสร้าง gallery (สำหรับ reconcile counts)
+	ListWithGallery(ctx context.Context, offset, limit int) ([]*models.Video, int64, error)
+	// ListForSEOEnqueue ดึง videos ตาม filter ของ bulk SEO enqueue (เก่าสุดก่อน)
+	// ข้ามวิดีโอที่มี SEO job event ตั้งแต่ recentSince (queued/กำลังทำ/เพิ่งทำ)
+	ListForSEOEnqueue(ctx context.Context, params *dto.EnqueueSEORequest, recentSince time.Time, limit int) ([]*models.Video, int64, error)
+	// MarkArticlePublished บันทึกว่า SEO worker publish บทความของ video แล้ว
+	MarkArticlePublished(ctx context.Context, id uuid.UUID, publishedAt time.Time) error
+	// UpdateGalleryCounts เขียน gallery counts ตามไฟล์จริงใน storage (ไม่แตะ field อื่น)
+	UpdateGalleryCounts(ctx context.Context, id uuid.UUID, counts dto.GalleryCounts) error
 }
@@ -22,6 +22,7 @@ type WhitelistRepository interface {
 
 	// Domain management
 	AddDomain(ctx context.Context, domain *models.ProfileDomain) error
+	AddDomains(ctx context.Context, domains []*models.ProfileDomain) error
 	GetDomainByID(ctx context.Context, domainID uuid.UUID) (*models.ProfileDomain, error)
 	RemoveDomain(ctx context.Context, domainID uuid.UUID) error
 	GetDomainsByProfileID(ctx context.Context, profileID uuid.UUID) ([]*models.ProfileDomain, error)
@@ -0,0 +1,24 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+)
+
+// GalleryReconcileService แก้ gallery counts ใน DB ให้ตรงกับไฟล์จริงใน storage
+// (counts เพี้ยนได้ถ้า update API ล้มหลัง upload สำเร็จ)
+type GalleryReconcileService interface {
+	// ReconcileVideo นับไฟล์ใน gallery/<code>/{source,super_safe,safe,nsfw} แล้วแก้ DB ถ้าไม่ตรง
+	ReconcileVideo(ctx context.Context, videoID uuid.UUID) (*dto.GalleryReconcileResult, error)
+
+	// ReconcileAll reconcile ทุกวิดีโอที่มี gallery (ข้ามที่กำลังสร้าง gallery)
+	ReconcileAll(ctx context.Context) (*dto.GalleryReconcileSummary, error)
+
+	// StartReconcileAll เริ่ม ReconcileAll ใน background (ErrGalleryReconcileRunning ถ้ามีรอบที่ทำอยู่)
+	StartReconcileAll(ctx context.Context) error
+
+	// RegisterReconcileJob ลงทะเบียน reconcile job กับ scheduler
+	RegisterReconcileJob() error
+}
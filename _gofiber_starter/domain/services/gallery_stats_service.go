@@ -0,0 +1,13 @@
+package services
+
+import (
+	"context"
+
+	"gofiber-template/domain/dto"
+)
+
+// GalleryStatsService สถิติของ gallery ทุก video (ใช้ปรับ threshold ของ classifier)
+type GalleryStatsService interface {
+	// GetClassificationStats สถิติ classification ของ gallery ทุก video (tier counts, ค่าเฉลี่ย, outliers)
+	GetClassificationStats(ctx context.Context, req *dto.ClassificationStatsRequest) (*dto.ClassificationStatsResponse, error)
+}
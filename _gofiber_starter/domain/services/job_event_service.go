@@ -0,0 +1,17 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+)
+
+// JobEventService interface สำหรับดู pipeline event log (audit trail)
+type JobEventService interface {
+	// GetJobTimeline ดึง timeline ของทุก pipeline (transcode/gallery/seo) ของ video
+	GetJobTimeline(ctx context.Context, videoID uuid.UUID) (*dto.JobTimelineResponse, error)
+
+	// GetDLQJobDetail ดึงรายละเอียด video ใน DLQ พร้อม job payload (redacted) ทุกครั้งที่เข้า DLQ
+	GetDLQJobDetail(ctx context.Context, videoID uuid.UUID) (*dto.DLQJobDetailResponse, error)
+}
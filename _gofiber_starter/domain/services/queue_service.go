@@ -25,6 +25,13 @@ type QueueService interface {
 	// RetryTranscodeOne retry video 1 ตัว
 	RetryTranscodeOne(ctx context.Context, videoID uuid.UUID) error
 
+	// BumpTranscode ย้าย transcode job ที่รออยู่ไปคิว priority (ทำก่อนคิวปกติ)
+	BumpTranscode(ctx context.Context, videoID uuid.UUID) (*dto.BumpPriorityResponse, error)
+
+	// CancelJob ยกเลิก job ของ video (transcode, gallery, seo) - ลบจากคิวและสั่ง worker ที่กำลังทำอยู่ให้หยุด
+	// (transcode ที่กำลังทำ = ErrTranscodeInProgress)
+	CancelJob(ctx context.Context, videoID uuid.UUID, jobType string) (*dto.CancelJobResponse, error)
+
 	// === Subtitle Queue ===
 
 	// GetSubtitleStuck ดึงรายการ subtitle ที่ค้าง (queued)
@@ -61,12 +68,29 @@ type QueueService interface {
 	// GetGalleryProcessing ดึงรายการ video ที่กำลังสร้าง gallery
 	GetGalleryProcessing(ctx context.Context, page, limit int) ([]dto.GalleryQueueItem, int64, error)
 
+	// GetGalleryByStatus ดึงรายการ video ตาม gallery_status (เช่น pending_review = คิวรอ admin เลือกภาพ)
+	GetGalleryByStatus(ctx context.Context, galleryStatus string, page, limit int) ([]dto.GalleryQueueItem, int64, error)
+
 	// GetGalleryFailed ดึงรายการ video ที่ gallery failed
 	GetGalleryFailed(ctx context.Context, page, limit int) ([]dto.GalleryQueueItem, int64, error)
 
 	// RetryGalleryAll retry gallery ที่ failed ทั้งหมด
 	RetryGalleryAll(ctx context.Context) (*dto.RetryResponse, error)
 
+	// GetGalleryMissing ดึงรายการ video ที่ ready แต่ยังไม่มี gallery
+	GetGalleryMissing(ctx context.Context, page, limit int) ([]dto.GalleryQueueItem, int64, error)
+
+	// BackfillGalleries queue gallery jobs ให้ video ที่ยังไม่มี gallery (จำกัดจำนวนต่อรอบ + rate limit)
+	BackfillGalleries(ctx context.Context, maxJobs int) (*dto.BackfillGalleryResponse, error)
+
+	// BumpGallery ย้าย gallery job ของ video ไปคิว priority (ยังไม่อยู่ในคิว = queue ใหม่แบบ urgent)
+	BumpGallery(ctx context.Context, videoID uuid.UUID) (*dto.BumpPriorityResponse, error)
+
+	// === SEO Queue ===
+
+	// EnqueueSEOArticles queue SEO article jobs ให้วิดีโอที่ตรง filter (จำกัดจำนวนต่อรอบ + rate limit + dedup)
+	EnqueueSEOArticles(ctx context.Context, req *dto.EnqueueSEORequest) (*dto.EnqueueSEOResponse, error)
+
 	// === Reel Queue ===
 
 	// GetReelExporting ดึงรายการ reel ที่กำลัง export
@@ -0,0 +1,14 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+)
+
+type RelatedVideoService interface {
+	// GetRelatedByEmbedding หา videos ที่คล้ายกันจาก embedding ที่ SEO worker สร้างไว้
+	// คืน list ว่างถ้า video ยังไม่มี embedding
+	GetRelatedByEmbedding(ctx context.Context, videoID uuid.UUID, limit int, filter *dto.RelatedVideoFilter) ([]dto.RelatedVideoResponse, error)
+}
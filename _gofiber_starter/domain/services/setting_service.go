@@ -71,6 +71,7 @@ func GetCategoryInfo() []CategoryInfo {
 		{Name: "general", Label: "ทั่วไป", Description: "ตั้งค่าทั่วไปของระบบ"},
 		{Name: "p2p", Label: "P2P", Description: "ตั้งค่า P2P Streaming"},
 		{Name: "transcoding", Label: "แปลงไฟล์", Description: "ตั้งค่าการแปลงวิดีโอ"},
+		{Name: "subtitle", Label: "ซับไตเติ้ล", Description: "ตั้งค่างานซับไตเติ้ล"},
 		{Name: "worker", Label: "Worker", Description: "ตั้งค่า Worker"},
 		{Name: "disk_monitor", Label: "Disk Monitor", Description: "ตั้งค่าการตรวจสอบพื้นที่ดิสก์"},
 		{Name: "storage", Label: "Storage", Description: "ตั้งค่าการเก็บไฟล์"},
@@ -2,6 +2,8 @@ package services
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/google/uuid"
 	"gofiber-template/domain/dto"
@@ -58,6 +60,9 @@ type SubtitleService interface {
 	// เปลี่ยน status จาก queued → processing/translating และบันทึก processing_started_at
 	MarkJobStarted(ctx context.Context, subtitleID uuid.UUID, jobType string) error
 
+	// MarkJobHeartbeat callback จาก worker ระหว่างทำ job (อัปเดต last_heartbeat_at)
+	MarkJobHeartbeat(ctx context.Context, subtitleID uuid.UUID) error
+
 	// === Content Edit Operations ===
 
 	// GetSubtitleContent ดึง content ของ subtitle (SRT file)
@@ -79,6 +84,32 @@ type SubtitleService interface {
 
 	// RetryStuckSubtitles retry subtitles ที่ค้างอยู่ใน queue (status = queued)
 	RetryStuckSubtitles(ctx context.Context) (*dto.RetryStuckResponse, error)
+
+	// RetryStaleQueuedSubtitles retry เฉพาะ subtitles ที่ queued นานกว่า olderThan (ใช้โดย auto-retry scheduler)
+	RetryStaleQueuedSubtitles(ctx context.Context, olderThan time.Duration) (*dto.RetryStuckResponse, error)
+
+	// ListReadyWithoutSubtitles ดึง videos ที่มี audio แต่ยังไม่มี original subtitle ที่ ready
+	ListReadyWithoutSubtitles(ctx context.Context, page, limit int) ([]dto.SubtitleMissingItem, int64, error)
+
+	// TriggerTranscribeBulk เรียก TriggerTranscribe ให้ videos ที่ยังไม่มี subtitle (จำกัดจำนวน + rate limit)
+	TriggerTranscribeBulk(ctx context.Context, maxJobs int) (*dto.BulkTranscribeResponse, error)
+
+	// GetSubtitleDiff เทียบ SRT ปัจจุบันกับ version ก่อนหน้า (cue-by-cue)
+	GetSubtitleDiff(ctx context.Context, subtitleID uuid.UUID) (*dto.SubtitleDiffResponse, error)
+
+	// DownloadAllSubtitles เตรียม zip ของ SRT ทุกภาษาที่ ready (เขียนจริงตอนเรียก archive.Write)
+	DownloadAllSubtitles(ctx context.Context, videoID uuid.UUID) (*SubtitleArchive, error)
+
+	// ImportSubtitle นำเข้า subtitle ภายนอก (srt/vtt/ass) → แปลงเป็น SRT แล้วสร้าง record ready (type = imported)
+	ImportSubtitle(ctx context.Context, videoID uuid.UUID, language, format, content string) (*models.Subtitle, error)
+}
+
+// SubtitleArchive zip ของ subtitles ทุกภาษาของ video
+// แยก Filename ออกจาก Write เพื่อให้ handler ตั้ง header ได้ก่อนเริ่ม stream
+type SubtitleArchive struct {
+	Filename string                  // <code>-subtitles.zip
+	Count    int                     // จำนวน SRT ใน zip
+	Write    func(w io.Writer) error // stream zip ลง w
 }
 
 // SubtitleJobPublisher interface สำหรับส่ง subtitle jobs ไปยัง NATS
@@ -97,7 +128,8 @@ type SubtitleJobPublisher interface {
 type DetectJob struct {
 	VideoID   string `json:"video_id"`
 	VideoCode string `json:"video_code"`
-	AudioPath string `json:"audio_path"` // S3 path to audio file
+	AudioPath string `json:"audio_path"`         // S3 path to audio file
+	TraceID   string `json:"trace_id,omitempty"` // request ID ต้นทาง (ว่าง = publisher ใส่จาก context)
 }
 
 // TranscribeJob job สำหรับ transcribe (สร้าง original SRT)
@@ -110,6 +142,7 @@ type TranscribeJob struct {
 	OutputPath    string `json:"output_path"`    // S3 path for SRT output
 	RefineWithLLM bool   `json:"refine_with_llm"`
 	Context       string `json:"context"`        // Video description for better translation
+	TraceID       string `json:"trace_id,omitempty"` // request ID ต้นทาง (ว่าง = publisher ใส่จาก context)
 }
 
 // TranslateJob job สำหรับ translate
@@ -122,4 +155,5 @@ type TranslateJob struct {
 	TargetLanguages []string `json:"target_languages"`
 	OutputPath      string   `json:"output_path"`       // S3 directory for translated SRTs
 	Context         string   `json:"context"`           // Video description for better translation
+	TraceID         string   `json:"trace_id,omitempty"` // request ID ต้นทาง (ว่าง = publisher ใส่จาก context)
 }
@@ -11,6 +11,9 @@ type TranscodingService interface {
 	// QueueTranscoding ส่งวิดีโอเข้า transcoding queue
 	QueueTranscoding(ctx context.Context, videoID uuid.UUID) error
 
+	// QueueTranscodingWithPriority ส่งวิดีโอเข้า queue ตาม priority (ports.JobPriority*)
+	QueueTranscodingWithPriority(ctx context.Context, videoID uuid.UUID, priority int) error
+
 	// ProcessTranscoding ทำ transcoding (เรียกจาก worker)
 	ProcessTranscoding(ctx context.Context, videoID uuid.UUID) error
 
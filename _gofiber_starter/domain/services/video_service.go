@@ -34,6 +34,9 @@ type VideoService interface {
 	// ListWithFilters ดึง videos พร้อม filter, search, sort, pagination
 	ListWithFilters(ctx context.Context, params *dto.VideoFilterRequest) ([]*models.Video, int64, error)
 
+	// ListWithFiltersCursor ดึง videos พร้อม filter แบบ cursor pagination (?cursor=)
+	ListWithFiltersCursor(ctx context.Context, params *dto.VideoFilterRequest) ([]*models.Video, string, error)
+
 	// GetReelCountsForVideos นับจำนวน reels สำหรับแต่ละ video
 	GetReelCountsForVideos(ctx context.Context, videos []*models.Video) (map[uuid.UUID]int64, error)
 
@@ -64,12 +67,26 @@ type VideoService interface {
 	// ResetVideoForRetry reset video สำหรับ retry จาก DLQ (ล้าง retry_count และ last_error)
 	ResetVideoForRetry(ctx context.Context, id uuid.UUID) error
 
+	// RefreshVideoCache ลบแล้วโหลด cache ของ video ใหม่จาก DB (หลังแก้ DB ตรงๆ)
+	RefreshVideoCache(ctx context.Context, code string) (*models.Video, error)
+
+	// PurgeAllVideoCache ลบ cache ของ video ทั้งหมด (หลังเปลี่ยน schema/serialization)
+	PurgeAllVideoCache(ctx context.Context) (int64, error)
+
+	// RebuildMasterPlaylist สร้าง master.m3u8 ใหม่จาก variant playlists ที่มีอยู่จริงใน storage (repair)
+	RebuildMasterPlaylist(ctx context.Context, code string) (*MasterPlaylistResult, error)
+
+	// GetVideoCost ดึง processing cost ของทุก worker job ของ video พร้อมผลรวม
+	GetVideoCost(ctx context.Context, videoID uuid.UUID) (*dto.VideoCostResponse, error)
+
 	// DeleteAll ลบ videos ทั้งหมด (สำหรับ testing)
 	DeleteAll(ctx context.Context) (int64, error)
 
 	// Storage Quota
 	// CheckStorageQuota ตรวจสอบว่ายังอัพโหลดได้หรือไม่ (current_used < quota)
 	CheckStorageQuota(ctx context.Context) error
+	// CheckStorageQuotaFor ตรวจสอบว่าเพิ่มไฟล์ขนาด size แล้วยังไม่เกิน quota (current_used + size <= quota)
+	CheckStorageQuotaFor(ctx context.Context, size int64) error
 	// GetStorageUsage ดึงข้อมูล storage usage
 	GetStorageUsage(ctx context.Context) (*StorageUsage, error)
 }
@@ -91,3 +108,10 @@ type StorageUsage struct {
 	TotalPercent float64 `json:"totalPercent"` // Usage percentage
 	Unlimited    bool    `json:"unlimited"`    // true ถ้า quota = 0
 }
+
+// MasterPlaylistResult ผลการสร้าง master playlist ใหม่
+type MasterPlaylistResult struct {
+	Path      string   `json:"path"`      // hls/{code}/master.m3u8
+	Qualities []string `json:"qualities"` // variants ที่อยู่ใน master
+	Missing   []string `json:"missing"`   // qualities ที่ไม่มี playlist ใน storage (ไม่ถูกใส่)
+}
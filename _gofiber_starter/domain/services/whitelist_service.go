@@ -45,6 +45,12 @@ type WhitelistService interface {
 	// GetDomainsByProfile ดึง domains ทั้งหมดของ profile
 	GetDomainsByProfile(ctx context.Context, profileID uuid.UUID) ([]*models.ProfileDomain, error)
 
+	// ImportDomains เพิ่ม domains หลายตัว (normalize + dedupe + validate) แล้ว bulk insert
+	ImportDomains(ctx context.Context, profileID uuid.UUID, domains []string) (*dto.ImportDomainsResponse, error)
+
+	// ExportDomains ดึงรายการ domain patterns ของ profile
+	ExportDomains(ctx context.Context, profileID uuid.UUID) ([]string, error)
+
 	// ==================== Domain Lookup (สำหรับ Middleware) ====================
 
 	// FindProfileByDomain ค้นหา profile จาก domain (รองรับ wildcard)
@@ -53,6 +59,9 @@ type WhitelistService interface {
 	// IsDomainAllowed ตรวจสอบว่า domain ได้รับอนุญาตหรือไม่
 	IsDomainAllowed(ctx context.Context, domain string) (bool, *models.WhitelistProfile, error)
 
+	// TestDomainMatch ทดสอบ domain matching (ไม่ใช้ cache) - บอกว่า match กับ pattern ไหนด้วยกฎอะไร
+	TestDomainMatch(ctx context.Context, req *dto.DomainMatchTestRequest) (*dto.DomainMatchTestResponse, error)
+
 	// ==================== Watermark ====================
 
 	// UpdateWatermark อัพเดท URL ของ watermark
@@ -69,6 +78,9 @@ type WhitelistService interface {
 	// GetAdStatsByProfile ดึงสถิติ ads ของ profile
 	GetAdStatsByProfile(ctx context.Context, profileID uuid.UUID, start, end time.Time) (*models.AdImpressionStats, error)
 
+	// GetProfileStats ดึงสถิติการใช้งานรายวันของ profile + แยกตาม domain
+	GetProfileStats(ctx context.Context, profileID uuid.UUID, start, end time.Time) (*models.ProfileUsageStats, error)
+
 	// GetDeviceStats ดึงสถิติแยกตามอุปกรณ์
 	GetDeviceStats(ctx context.Context, start, end time.Time) (*models.DeviceStats, error)
 
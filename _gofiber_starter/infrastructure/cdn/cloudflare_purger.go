@@ -0,0 +1,129 @@
+package cdn
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"gofiber-template/domain/ports"
+	"gofiber-template/pkg/config"
+	"gofiber-template/pkg/logger"
+)
+
+// cloudflarePurgeBatchSize Cloudflare รับ files/prefixes ได้สูงสุด 30 รายการต่อ request
+const cloudflarePurgeBatchSize = 30
+
+// CloudflarePurger - Cloudflare implementation of CDNPurgerPort
+type CloudflarePurger struct {
+	baseURL    string // CDNBaseURL (e.g. https://hls.yourdomain.com)
+	cfg        config.CDNPurgeConfig
+	apiURL     string
+	httpClient *http.Client
+}
+
+// NewCloudflarePurger สร้าง CloudflarePurger
+func NewCloudflarePurger(baseURL string, cfg config.CDNPurgeConfig) ports.CDNPurgerPort {
+	return &CloudflarePurger{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		cfg:     cfg,
+		apiURL:  fmt.Sprintf("https://api.cloudflare.com/client/v4/zones/%s/purge_cache", cfg.ZoneID),
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// IsEnabled ต้องมี CDN base URL, zone และ token
+func (p *CloudflarePurger) IsEnabled() bool {
+	return p.baseURL != "" && p.cfg.ZoneID != "" && p.cfg.APIToken != ""
+}
+
+// SupportsPrefix purge by prefix ใช้ได้เฉพาะ Enterprise plan (เปิดผ่าน config)
+func (p *CloudflarePurger) SupportsPrefix() bool {
+	return p.cfg.PurgeByPrefix
+}
+
+// Purge ล้าง cache แบ่งเป็น batch ละ 30 รายการ
+func (p *CloudflarePurger) Purge(ctx context.Context, req *ports.CDNPurgeRequest) error {
+	if !p.IsEnabled() || req.IsEmpty() {
+		return nil
+	}
+	if len(req.Prefixes) > 0 && !p.SupportsPrefix() {
+		return fmt.Errorf("purge by prefix not enabled (CDN_PURGE_BY_PREFIX)")
+	}
+
+	// files ต้องเป็น URL เต็ม
+	files := make([]string, 0, len(req.Files))
+	for _, path := range req.Files {
+		files = append(files, p.baseURL+"/"+strings.TrimPrefix(path, "/"))
+	}
+	for start := 0; start < len(files); start += cloudflarePurgeBatchSize {
+		end := min(start+cloudflarePurgeBatchSize, len(files))
+		if err := p.send(ctx, map[string][]string{"files": files[start:end]}); err != nil {
+			return err
+		}
+	}
+
+	// prefixes ต้องเป็น host/path ไม่มี scheme
+	host := p.baseURL
+	if u, err := url.Parse(p.baseURL); err == nil && u.Host != "" {
+		host = u.Host + u.Path
+	}
+	prefixes := make([]string, 0, len(req.Prefixes))
+	for _, prefix := range req.Prefixes {
+		prefixes = append(prefixes, host+"/"+strings.TrimPrefix(prefix, "/"))
+	}
+	for start := 0; start < len(prefixes); start += cloudflarePurgeBatchSize {
+		end := min(start+cloudflarePurgeBatchSize, len(prefixes))
+		if err := p.send(ctx, map[string][]string{"prefixes": prefixes[start:end]}); err != nil {
+			return err
+		}
+	}
+
+	logger.InfoContext(ctx, "CDN cache purged", "files", len(files), "prefixes", len(prefixes))
+	return nil
+}
+
+// cloudflareResponse response ของ purge_cache API
+type cloudflareResponse struct {
+	Success bool `json:"success"`
+	Errors  []struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// send เรียก purge_cache API หนึ่งครั้ง
+func (p *CloudflarePurger) send(ctx context.Context, payload map[string][]string) error {
+	body, _ := json.Marshal(payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.apiURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+p.cfg.APIToken)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("cloudflare purge request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result cloudflareResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("cloudflare purge returned status %d", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK || !result.Success {
+		if len(result.Errors) > 0 {
+			return fmt.Errorf("cloudflare purge failed: %d %s", result.Errors[0].Code, result.Errors[0].Message)
+		}
+		return fmt.Errorf("cloudflare purge returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -42,6 +42,7 @@ func (q *NATSJobQueue) PublishJob(ctx context.Context, job *ports.TranscodeJobDa
 		Codec:        job.Codec,
 		Qualities:    job.Qualities,
 		UseByteRange: job.UseByteRange,
+		Priority:     job.Priority,
 		CreatedAt:    time.Now().Unix(),
 	}
 
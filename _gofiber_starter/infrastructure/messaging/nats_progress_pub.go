@@ -34,6 +34,7 @@ func (p *NATSProgressPublisher) PublishProgress(ctx context.Context, progress *p
 
 	// Convert to NATS type
 	natsProgress := &natspkg.ProgressUpdate{
+		JobType:    progress.JobType,
 		VideoID:    progress.VideoID,
 		VideoCode:  progress.VideoCode,
 		Status:     progress.Status,
@@ -42,6 +42,7 @@ func (s *NATSProgressSubscriber) Subscribe(ctx context.Context, handler ports.Pr
 
 		// Convert to port type and call handler
 		handler(&ports.ProgressData{
+			JobType:         update.JobType,
 			VideoID:         update.VideoID,
 			VideoCode:       update.VideoCode,
 			Status:          update.Status,
@@ -0,0 +1,68 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"gofiber-template/pkg/logger"
+)
+
+// JobCancelBucket KV bucket คำขอยกเลิก job - worker watch key ของ job ที่กำลังทำ
+// key = "<job type>.<video id>" (เช่น gallery.<uuid>) - worker ลบ key หลังยกเลิกเสร็จ
+const JobCancelBucket = "JOB_CANCEL"
+
+// jobCancelTTL อายุคำขอยกเลิก (กัน key ค้างถ้าไม่มี worker มารับ)
+const jobCancelTTL = 24 * time.Hour
+
+// JobCancelKey key ของคำขอยกเลิก job ใน JOB_CANCEL
+func JobCancelKey(jobType, videoID string) string {
+	return jobType + "." + videoID
+}
+
+// setupJobCancelBucket สร้าง/อัปเดต bucket คำขอยกเลิก job
+func (c *Client) setupJobCancelBucket(ctx context.Context) error {
+	kv, err := c.js.CreateOrUpdateKeyValue(ctx, jetstream.KeyValueConfig{
+		Bucket:      JobCancelBucket,
+		Description: "Cancel requests for in-flight jobs",
+		TTL:         jobCancelTTL,
+		Storage:     jetstream.FileStorage,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create %s bucket: %w", JobCancelBucket, err)
+	}
+	c.cancelKV = kv
+	logger.Info("NATS KV bucket ready", "bucket", JobCancelBucket)
+	return nil
+}
+
+// RequestJobCancel ตั้ง flag ยกเลิก job ของวิดีโอ - worker ที่ทำ job นี้อยู่จะหยุดที่ stage ถัดไป
+func (c *Client) RequestJobCancel(ctx context.Context, jobType, videoID string) error {
+	if c.cancelKV == nil {
+		return fmt.Errorf("job cancel bucket not initialized")
+	}
+	if _, err := c.cancelKV.PutString(ctx, JobCancelKey(jobType, videoID), time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return fmt.Errorf("failed to request job cancel: %w", err)
+	}
+	return nil
+}
+
+// ClearJobCancel ลบ flag ยกเลิก (ใช้ก่อน queue job ใหม่ของวิดีโอเดิม)
+func (c *Client) ClearJobCancel(ctx context.Context, jobType, videoID string) error {
+	if c.cancelKV == nil {
+		return nil
+	}
+	if err := c.cancelKV.Purge(ctx, JobCancelKey(jobType, videoID)); err != nil && !errors.Is(err, jetstream.ErrKeyNotFound) {
+		return fmt.Errorf("failed to clear job cancel: %w", err)
+	}
+	return nil
+}
+
+// clearJobCancel ล้าง flag ยกเลิกก่อน publish job ใหม่ (ล้มเหลว = warn, publish ต่อ)
+func (p *Publisher) clearJobCancel(ctx context.Context, jobType, videoID string) {
+	if err := p.client.ClearJobCancel(ctx, jobType, videoID); err != nil {
+		logger.Warn("Failed to clear job cancel request", "job_type", jobType, "video_id", videoID, "error", err)
+	}
+}
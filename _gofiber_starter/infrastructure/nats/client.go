@@ -21,6 +21,8 @@ type Client struct {
 
 	// KV Buckets
 	workerKV jetstream.KeyValue // Worker status (from heartbeat)
+	cancelKV jetstream.KeyValue // คำขอยกเลิก job (JOB_CANCEL)
+	lockKV   jetstream.KeyValue // lock การสร้าง gallery ต่อวิดีโอ (GALLERY_LOCK)
 }
 
 // ClientConfig configuration สำหรับ NATS Client
@@ -80,7 +82,7 @@ func (c *Client) setupStream(ctx context.Context) error {
 	// Transcode jobs stream
 	transcodeCfg := jetstream.StreamConfig{
 		Name:        StreamName,
-		Subjects:    []string{SubjectJobs},
+		Subjects:    []string{SubjectJobs, SubjectJobsPriority},
 		Storage:     jetstream.FileStorage,      // Persistent storage
 		Retention:   jetstream.WorkQueuePolicy,  // ลบ message หลัง Ack
 		MaxAge:      24 * time.Hour,             // เก็บ message ไม่เกิน 24 ชม.
@@ -138,7 +140,7 @@ func (c *Client) setupStream(ctx context.Context) error {
 	// Gallery generate jobs stream
 	galleryCfg := jetstream.StreamConfig{
 		Name:        GalleryStreamName,
-		Subjects:    []string{SubjectGalleryGenerate},
+		Subjects:    []string{SubjectGalleryGenerate, SubjectGalleryGeneratePriority},
 		Storage:     jetstream.FileStorage,
 		Retention:   jetstream.WorkQueuePolicy,
 		MaxAge:      24 * time.Hour,
@@ -168,6 +170,16 @@ func (c *Client) setupKVBuckets(ctx context.Context) error {
 		logger.Info("NATS KV bucket ready", "bucket", "WORKER_STATUS")
 	}
 
+	// Job Cancel KV - API เป็นคนสร้าง (worker แค่ watch/ลบ key)
+	if err := c.setupJobCancelBucket(ctx); err != nil {
+		return err
+	}
+
+	// Gallery Lock KV - API สร้าง (worker รับ lock ต่อตอนเริ่ม job)
+	if err := c.setupGalleryLockBucket(ctx); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -293,3 +305,16 @@ func (c *Client) PurgeSubtitleStream(ctx context.Context) (uint64, error) {
 	logger.Info("Purged subtitle stream", "messages_deleted", beforeCount)
 	return beforeCount, nil
 }
+
+// SubtitleStreamMessageCount จำนวน messages ที่ค้างใน SUBTITLE_JOBS stream (WorkQueue - ลบหลัง Ack)
+func (c *Client) SubtitleStreamMessageCount(ctx context.Context) (uint64, error) {
+	if c.subtitleStream == nil {
+		return 0, fmt.Errorf("subtitle stream not initialized")
+	}
+
+	info, err := c.subtitleStream.Info(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get subtitle stream info: %w", err)
+	}
+	return info.State.Msgs, nil
+}
@@ -5,11 +5,15 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/nats-io/nats.go"
 	"github.com/nats-io/nats.go/jetstream"
 
+	"gofiber-template/domain/models"
 	"gofiber-template/domain/ports"
+	"gofiber-template/domain/repositories"
 	"gofiber-template/pkg/logger"
+	"gofiber-template/pkg/utils"
 )
 
 const (
@@ -46,6 +50,7 @@ type TranscodeJobData struct {
 type DLQSubscriber struct {
 	js         jetstream.JetStream
 	notifier   ports.NotifierPort
+	entryRepo  repositories.DLQEntryRepository // เก็บ payload เต็ม (optional - SetEntryRepository)
 	consumer   jetstream.Consumer
 	cancelFunc context.CancelFunc
 	running    bool
@@ -64,6 +69,11 @@ func NewDLQSubscriber(nc *nats.Conn, notifier ports.NotifierPort) (*DLQSubscribe
 	}, nil
 }
 
+// SetEntryRepository ตั้ง repository สำหรับเก็บ job payload ที่เข้า DLQ (nil = แจ้งเตือนอย่างเดียว)
+func (s *DLQSubscriber) SetEntryRepository(repo repositories.DLQEntryRepository) {
+	s.entryRepo = repo
+}
+
 // Start เริ่ม subscribe และส่ง notifications
 func (s *DLQSubscriber) Start(ctx context.Context) error {
 	if s.running {
@@ -148,6 +158,8 @@ func (s *DLQSubscriber) handleMessage(ctx context.Context, msg jetstream.Msg) {
 		"stage", dlqJob.Stage,
 	)
 
+	s.storeEntry(ctx, msg.Data(), &dlqJob)
+
 	// ส่ง Telegram notification
 	notification := &ports.DLQNotification{
 		VideoID:   dlqJob.OriginalJob.VideoID,
@@ -168,6 +180,49 @@ func (s *DLQSubscriber) handleMessage(ctx context.Context, msg jetstream.Msg) {
 	msg.Ack()
 }
 
+// storeEntry บันทึก DLQ entry พร้อม original job payload (redact secrets) - ล้มเหลว = warn เท่านั้น
+func (s *DLQSubscriber) storeEntry(ctx context.Context, data []byte, dlqJob *DLQJob) {
+	if s.entryRepo == nil {
+		return
+	}
+
+	videoID, err := uuid.Parse(dlqJob.OriginalJob.VideoID)
+	if err != nil {
+		logger.Warn("DLQ job has invalid video ID, entry not stored", "video_id", dlqJob.OriginalJob.VideoID)
+		return
+	}
+
+	// เก็บ original_job แบบ raw (field ที่ TranscodeJobData ไม่รู้จักก็ไม่หาย)
+	var raw struct {
+		OriginalJob json.RawMessage `json:"original_job"`
+	}
+	payload := "{}"
+	if err := json.Unmarshal(data, &raw); err == nil && len(raw.OriginalJob) > 0 {
+		if redacted := utils.RedactJSON(raw.OriginalJob); redacted != nil {
+			payload = string(redacted)
+		}
+	}
+
+	failedAt := time.Now()
+	if dlqJob.FailedAt > 0 {
+		failedAt = time.Unix(dlqJob.FailedAt, 0)
+	}
+
+	entry := &models.DLQEntry{
+		VideoID:  videoID,
+		Source:   models.JobEventSourceTranscode,
+		Error:    dlqJob.Error,
+		Attempts: dlqJob.Attempts,
+		WorkerID: dlqJob.WorkerID,
+		Stage:    dlqJob.Stage,
+		Payload:  payload,
+		FailedAt: failedAt,
+	}
+	if err := s.entryRepo.Create(ctx, entry); err != nil {
+		logger.Warn("Failed to store DLQ entry", "video_id", videoID, "error", err)
+	}
+}
+
 // Stop หยุด subscriber
 func (s *DLQSubscriber) Stop() {
 	if !s.running {
@@ -0,0 +1,132 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"gofiber-template/pkg/logger"
+)
+
+// GalleryLockBucket KV bucket lock การสร้าง gallery ต่อวิดีโอ (key = video code)
+// API ตั้ง "queued" ก่อนลบ gallery เก่า + publish job → worker เปลี่ยนเป็น "processing" ตอนเริ่ม job แล้วลบเมื่อจบ
+// กัน regenerate ซ้อนกันแล้ว upload สลับกันจน gallery ปนกันสองชุด
+const GalleryLockBucket = "GALLERY_LOCK"
+
+// Gallery lock states (ค่าใน GALLERY_LOCK - ต้องตรงกับ _worker)
+const (
+	GalleryLockQueued     = "queued"
+	GalleryLockProcessing = "processing"
+)
+
+// galleryLockTTL อายุ lock (กัน lock ค้างเมื่อ job หาย/worker ตาย - ยาวกว่า gallery job ที่นานที่สุด)
+const galleryLockTTL = 2 * time.Hour
+
+// ErrGalleryLocked มี job สร้าง gallery ของวิดีโอนี้อยู่แล้ว (queued หรือ processing)
+var ErrGalleryLocked = errors.New("gallery generation already in progress")
+
+// setupGalleryLockBucket สร้าง/อัปเดต bucket lock ของ gallery
+func (c *Client) setupGalleryLockBucket(ctx context.Context) error {
+	kv, err := c.js.CreateOrUpdateKeyValue(ctx, jetstream.KeyValueConfig{
+		Bucket:      GalleryLockBucket,
+		Description: "Per-video gallery generation locks",
+		TTL:         galleryLockTTL,
+		Storage:     jetstream.FileStorage,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create %s bucket: %w", GalleryLockBucket, err)
+	}
+	c.lockKV = kv
+	logger.Info("NATS KV bucket ready", "bucket", GalleryLockBucket)
+	return nil
+}
+
+// AcquireGalleryLock จอง lock ของวิดีโอก่อนลบ/สร้าง gallery ใหม่ (ถือไว้อยู่แล้ว = ErrGalleryLocked)
+func (c *Client) AcquireGalleryLock(ctx context.Context, videoCode string) error {
+	if c.lockKV == nil {
+		return nil
+	}
+	if _, err := c.lockKV.Create(ctx, videoCode, []byte(GalleryLockQueued)); err != nil {
+		if errors.Is(err, jetstream.ErrKeyExists) {
+			if c.retakeStaleGalleryLock(ctx, videoCode) {
+				return nil
+			}
+			return ErrGalleryLocked
+		}
+		return fmt.Errorf("failed to acquire gallery lock: %w", err)
+	}
+	return nil
+}
+
+// retakeStaleGalleryLock รับ lock "queued" ต่อเมื่อไม่มี gallery job ของวิดีโอเหลือในคิวแล้ว
+// (job ถูกลบออกจากคิวตอน bump/cancel) - ไม่งั้นต้องรอ TTL 2 ชั่วโมงกว่าจะสร้างใหม่ได้
+func (c *Client) retakeStaleGalleryLock(ctx context.Context, videoCode string) bool {
+	entry, err := c.lockKV.Get(ctx, videoCode)
+	if err != nil || string(entry.Value()) != GalleryLockQueued {
+		return false // processing = worker ทำอยู่จริง
+	}
+
+	queued, err := c.hasGalleryJob(ctx, videoCode)
+	if err != nil {
+		logger.Warn("Failed to check queued gallery jobs", "video_code", videoCode, "error", err)
+		return false
+	}
+	if queued {
+		return false
+	}
+
+	// revision เปลี่ยน = มีคนจองไปก่อน
+	if _, err := c.lockKV.Update(ctx, videoCode, []byte(GalleryLockQueued), entry.Revision()); err != nil {
+		return false
+	}
+	logger.Warn("Re-took stale gallery lock (no queued job left)", "video_code", videoCode)
+	return true
+}
+
+// hasGalleryJob มี gallery job ของวิดีโอในคิว (ปกติหรือ priority, รวมที่ worker ถืออยู่) หรือไม่
+func (c *Client) hasGalleryJob(ctx context.Context, videoCode string) (bool, error) {
+	if c.galleryStream == nil {
+		return false, fmt.Errorf("stream not initialized")
+	}
+
+	found := false
+	for _, subject := range []string{SubjectGalleryGenerate, SubjectGalleryGeneratePriority} {
+		err := scanQueuedJobs(ctx, c.galleryStream, subject, func(msg *jetstream.RawStreamMsg) (bool, error) {
+			var job struct {
+				VideoCode string `json:"video_code"`
+			}
+			found = json.Unmarshal(msg.Data, &job) == nil && job.VideoCode == videoCode
+			return found, nil
+		})
+		if err != nil || found {
+			return found, err
+		}
+	}
+	return false, nil
+}
+
+// ReleaseGalleryLock ปล่อย lock (ใช้เมื่อ publish job ไม่สำเร็จ หรือ job ถูกลบออกจากคิว)
+func (c *Client) ReleaseGalleryLock(ctx context.Context, videoCode string) error {
+	if c.lockKV == nil {
+		return nil
+	}
+	if err := c.lockKV.Delete(ctx, videoCode); err != nil && !errors.Is(err, jetstream.ErrKeyNotFound) {
+		return fmt.Errorf("failed to release gallery lock: %w", err)
+	}
+	return nil
+}
+
+// AcquireGalleryLock ดู Client.AcquireGalleryLock
+func (p *Publisher) AcquireGalleryLock(ctx context.Context, videoCode string) error {
+	return p.client.AcquireGalleryLock(ctx, videoCode)
+}
+
+// ReleaseGalleryLock ปล่อย lock (ล้มเหลว = warn - lock หมดอายุเองตาม TTL)
+func (p *Publisher) ReleaseGalleryLock(ctx context.Context, videoCode string) {
+	if err := p.client.ReleaseGalleryLock(ctx, videoCode); err != nil {
+		logger.Warn("Failed to release gallery lock", "video_code", videoCode, "error", err)
+	}
+}
@@ -0,0 +1,193 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/pkg/logger"
+)
+
+const (
+	// SubjectJobEvent events.job.{source}.{video_id} - workers ที่ต่อ DB อื่น (SEO) ส่ง job events มาทางนี้
+	SubjectJobEvent = "events.job"
+	// SubjectJobCost events.cost.{source}.{video_id} - processing cost ของ job (เหตุผลเดียวกัน)
+	SubjectJobCost = "events.cost"
+	// jobEventQueueGroup หลาย API instance รับ event เดียวแค่ตัวเดียว (ไม่เขียนซ้ำ)
+	jobEventQueueGroup = "api-job-events"
+)
+
+// JobEventMessage payload ของ events.job.* (ต้องตรงกับ SEO worker)
+type JobEventMessage struct {
+	VideoID   string    `json:"video_id"`
+	Source    string    `json:"source"`
+	Stage     string    `json:"stage"`
+	Progress  float64   `json:"progress"`
+	Message   string    `json:"message,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// JobCostMessage payload ของ events.cost.* (ต้องตรงกับ SEO worker)
+type JobCostMessage struct {
+	VideoID            string    `json:"video_id"`
+	Source             string    `json:"source"`
+	GeminiPromptTokens int64     `json:"gemini_prompt_tokens"`
+	GeminiOutputTokens int64     `json:"gemini_output_tokens"`
+	TTSCharacters      int       `json:"tts_characters"`
+	EmbeddingChars     int       `json:"embedding_chars"`
+	JobDurationMs      int64     `json:"job_duration_ms"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+// JobEventSubscriber รับ job events/costs จาก NATS แล้วเขียนลง job_events/processing_costs
+type JobEventSubscriber struct {
+	conn      *nats.Conn
+	eventRepo repositories.JobEventRepository
+	costRepo  repositories.ProcessingCostRepository
+	videoRepo repositories.VideoRepository
+	subs      []*nats.Subscription
+	mu        sync.Mutex
+}
+
+// NewJobEventSubscriber สร้าง JobEventSubscriber
+func NewJobEventSubscriber(conn *nats.Conn, eventRepo repositories.JobEventRepository, costRepo repositories.ProcessingCostRepository, videoRepo repositories.VideoRepository) *JobEventSubscriber {
+	return &JobEventSubscriber{
+		conn:      conn,
+		eventRepo: eventRepo,
+		costRepo:  costRepo,
+		videoRepo: videoRepo,
+	}
+}
+
+// Start เริ่ม subscribe (queue group)
+func (s *JobEventSubscriber) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.subs) > 0 {
+		return nil
+	}
+
+	handlers := map[string]nats.MsgHandler{
+		SubjectJobEvent + ".>": s.handleJobEvent,
+		SubjectJobCost + ".>":  s.handleJobCost,
+	}
+	for subject, handler := range handlers {
+		sub, err := s.conn.QueueSubscribe(subject, jobEventQueueGroup, handler)
+		if err != nil {
+			for _, existing := range s.subs {
+				_ = existing.Unsubscribe()
+			}
+			s.subs = nil
+			return err
+		}
+		s.subs = append(s.subs, sub)
+	}
+
+	logger.Info("Job event subscriber started", "subjects", []string{SubjectJobEvent + ".>", SubjectJobCost + ".>"})
+	return nil
+}
+
+func (s *JobEventSubscriber) handleJobEvent(msg *nats.Msg) {
+	var m JobEventMessage
+	if err := json.Unmarshal(msg.Data, &m); err != nil {
+		logger.Warn("Failed to parse job event", "subject", msg.Subject, "error", err)
+		return
+	}
+
+	videoID, err := uuid.Parse(m.VideoID)
+	if err != nil {
+		logger.Warn("Job event has invalid video ID", "subject", msg.Subject, "video_id", m.VideoID)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	event := &models.JobEvent{
+		VideoID:   videoID,
+		Source:    sourceFromSubject(m.Source, msg.Subject),
+		Stage:     m.Stage,
+		Progress:  m.Progress,
+		Message:   m.Message,
+		CreatedAt: timeOrNow(m.CreatedAt),
+	}
+	if err := s.eventRepo.Create(ctx, event); err != nil {
+		logger.Warn("Failed to record job event", "video_id", videoID, "stage", m.Stage, "error", err)
+	}
+
+	// SEO completed = publish บทความที่ subth สำเร็จ → เก็บไว้ที่ videos (bulk enqueue ใช้ข้ามวิดีโอที่มีบทความแล้ว)
+	if event.Source == models.JobEventSourceSEO && event.Stage == models.JobEventStageCompleted {
+		if err := s.videoRepo.MarkArticlePublished(ctx, videoID, event.CreatedAt); err != nil {
+			logger.Warn("Failed to mark article published", "video_id", videoID, "error", err)
+		}
+	}
+}
+
+func (s *JobEventSubscriber) handleJobCost(msg *nats.Msg) {
+	var m JobCostMessage
+	if err := json.Unmarshal(msg.Data, &m); err != nil {
+		logger.Warn("Failed to parse processing cost", "subject", msg.Subject, "error", err)
+		return
+	}
+
+	videoID, err := uuid.Parse(m.VideoID)
+	if err != nil {
+		logger.Warn("Processing cost has invalid video ID", "subject", msg.Subject, "video_id", m.VideoID)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cost := &models.ProcessingCost{
+		VideoID:            videoID,
+		Source:             sourceFromSubject(m.Source, msg.Subject),
+		GeminiPromptTokens: m.GeminiPromptTokens,
+		GeminiOutputTokens: m.GeminiOutputTokens,
+		TTSCharacters:      m.TTSCharacters,
+		EmbeddingChars:     m.EmbeddingChars,
+		JobDurationMs:      m.JobDurationMs,
+		CreatedAt:          timeOrNow(m.CreatedAt),
+	}
+	if err := s.costRepo.Create(ctx, cost); err != nil {
+		logger.Warn("Failed to record processing cost", "video_id", videoID, "error", err)
+	}
+}
+
+// sourceFromSubject ใช้ source จาก payload ก่อน ไม่มีก็ดึงจาก subject events.{kind}.{source}.{video_id}
+func sourceFromSubject(source, subject string) string {
+	if source != "" {
+		return source
+	}
+	if parts := strings.Split(subject, "."); len(parts) == 4 {
+		return parts[2]
+	}
+	return ""
+}
+
+func timeOrNow(t time.Time) time.Time {
+	if t.IsZero() {
+		return time.Now()
+	}
+	return t
+}
+
+// Stop หยุด subscribe
+func (s *JobEventSubscriber) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, sub := range s.subs {
+		if err := sub.Unsubscribe(); err != nil {
+			logger.Warn("Failed to unsubscribe job events", "subject", sub.Subject, "error", err)
+		}
+	}
+	s.subs = nil
+}
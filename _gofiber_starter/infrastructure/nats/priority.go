@@ -0,0 +1,169 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"gofiber-template/domain/ports"
+	"gofiber-template/pkg/logger"
+)
+
+// maxQueuedJobScan จำนวน message สูงสุดที่ไล่หาต่อครั้ง (กัน scan stream ใหญ่ไม่จบ)
+const maxQueuedJobScan = 10000
+
+// SetPriorityQueues เปิด/ปิดการส่ง job urgent (transcode, gallery) ไป subject ".priority"
+// เปิดเมื่อ worker ทุกตัว consume priority subject แล้วเท่านั้น (ไม่งั้น job urgent ค้างในคิว)
+func (p *Publisher) SetPriorityQueues(enabled bool) {
+	p.priorityQueues = enabled
+}
+
+// SetSEOPriorityQueue เปิด/ปิดการส่ง SEO job urgent ไป subject ".priority"
+// แยกจาก SetPriorityQueues - เปิดเมื่อ SEO worker ตั้ง SEO_PRIORITY_QUEUE=true แล้ว
+func (p *Publisher) SetSEOPriorityQueue(enabled bool) {
+	p.seoPriorityQueue = enabled
+}
+
+// PriorityQueuesEnabled true = job urgent ถูกส่งไปคิว priority
+func (p *Publisher) PriorityQueuesEnabled() bool {
+	return p.priorityQueues
+}
+
+// jobSubject subject ที่ใช้ publish job ตาม priority
+func (p *Publisher) jobSubject(subject string, priority int) string {
+	return prioritySubject(subject, priority, p.priorityQueues)
+}
+
+// seoJobSubject subject ของ SEO job (ใช้ flag ของ SEO worker)
+func (p *Publisher) seoJobSubject(priority int) string {
+	return prioritySubject(SubjectSEOArticleGenerate, priority, p.seoPriorityQueue)
+}
+
+// prioritySubject job urgent ไป subject ".priority" เมื่อเปิดใช้
+func prioritySubject(subject string, priority int, enabled bool) string {
+	if enabled && priority == ports.JobPriorityUrgent {
+		return subject + PrioritySubjectSuffix
+	}
+	return subject
+}
+
+// RemoveQueuedTranscodeJobs ลบ transcode job ของวิดีโอที่ยังรออยู่ในคิวปกติ (ใช้ก่อน bump priority)
+func (c *Client) RemoveQueuedTranscodeJobs(ctx context.Context, videoID string) (int, error) {
+	return removeQueuedJobs(ctx, c.stream, SubjectJobs, videoID)
+}
+
+// RemoveQueuedGalleryJobs ลบ gallery job ของวิดีโอที่ยังรออยู่ในคิวปกติ (ใช้ก่อน bump priority)
+func (c *Client) RemoveQueuedGalleryJobs(ctx context.Context, videoID string) (int, error) {
+	return removeQueuedJobs(ctx, c.galleryStream, SubjectGalleryGenerate, videoID)
+}
+
+// removeQueuedJobs ไล่ message ของ subject ใน stream แล้วลบตัวที่ video_id ตรง
+// message ที่ worker ดึงไปแล้ว (ยังไม่ ack) ไม่ถูกลบ - คืน ports.ErrJobInFlight พร้อมจำนวนที่ลบได้
+func removeQueuedJobs(ctx context.Context, stream jetstream.Stream, subject, videoID string) (int, error) {
+	if stream == nil {
+		return 0, fmt.Errorf("stream not initialized")
+	}
+
+	delivered, err := deliveredStreamSeq(ctx, stream, subject)
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	inFlight := false
+	err = scanQueuedJobs(ctx, stream, subject, func(msg *jetstream.RawStreamMsg) (bool, error) {
+		var job struct {
+			VideoID string `json:"video_id"`
+		}
+		if json.Unmarshal(msg.Data, &job) != nil || job.VideoID != videoID {
+			return false, nil
+		}
+
+		// WorkQueue ลบ message หลัง ack - ยังอยู่และ seq <= delivered = worker ถืออยู่ (หรือรอ redeliver)
+		if msg.Sequence <= delivered {
+			inFlight = true
+			return false, nil
+		}
+
+		if err := stream.DeleteMsg(ctx, msg.Sequence); err != nil {
+			return true, fmt.Errorf("failed to delete queued job: %w", err)
+		}
+		removed++
+		logger.Info("Removed queued job",
+			"subject", subject,
+			"video_id", videoID,
+			"sequence", msg.Sequence,
+		)
+		return false, nil
+	})
+	if err != nil {
+		return removed, err
+	}
+
+	if inFlight {
+		return removed, ports.ErrJobInFlight
+	}
+	return removed, nil
+}
+
+// scanQueuedJobs ไล่ message ของ subject ตั้งแต่ต้น stream (สูงสุด maxQueuedJobScan) - fn คืน true = หยุด
+func scanQueuedJobs(ctx context.Context, stream jetstream.Stream, subject string, fn func(msg *jetstream.RawStreamMsg) (bool, error)) error {
+	info, err := stream.Info(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get stream info: %w", err)
+	}
+
+	seq := info.State.FirstSeq
+	for scanned := 0; scanned < maxQueuedJobScan && seq <= info.State.LastSeq; scanned++ {
+		msg, err := stream.GetMsg(ctx, seq, jetstream.WithGetMsgSubject(subject))
+		if err != nil {
+			if errors.Is(err, jetstream.ErrMsgNotFound) {
+				return nil // ไม่มี message ของ subject นี้หลัง seq แล้ว
+			}
+			return fmt.Errorf("failed to read queued job: %w", err)
+		}
+		seq = msg.Sequence + 1
+
+		stop, err := fn(msg)
+		if err != nil || stop {
+			return err
+		}
+	}
+	return nil
+}
+
+// deliveredStreamSeq stream sequence สูงสุดที่ consumer ของ subject ส่งให้ worker ไปแล้ว
+func deliveredStreamSeq(ctx context.Context, stream jetstream.Stream, subject string) (uint64, error) {
+	var delivered uint64
+	lister := stream.ListConsumers(ctx)
+	for ci := range lister.Info() {
+		if !consumerFilters(ci.Config, subject) {
+			continue
+		}
+		if ci.Delivered.Stream > delivered {
+			delivered = ci.Delivered.Stream
+		}
+	}
+	if err := lister.Err(); err != nil {
+		return 0, fmt.Errorf("failed to list consumers: %w", err)
+	}
+	return delivered, nil
+}
+
+// consumerFilters true = consumer รับ message ของ subject (ไม่มี filter = รับทั้ง stream)
+func consumerFilters(cfg jetstream.ConsumerConfig, subject string) bool {
+	if cfg.FilterSubject == "" && len(cfg.FilterSubjects) == 0 {
+		return true
+	}
+	if cfg.FilterSubject == subject {
+		return true
+	}
+	for _, s := range cfg.FilterSubjects {
+		if s == subject {
+			return true
+		}
+	}
+	return false
+}
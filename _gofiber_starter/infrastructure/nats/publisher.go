@@ -6,13 +6,16 @@ import (
 	"fmt"
 
 	"github.com/nats-io/nats.go/jetstream"
+	"gofiber-template/domain/ports"
 	"gofiber-template/domain/services"
 	"gofiber-template/pkg/logger"
 )
 
 // Publisher publishes transcode jobs to JetStream
 type Publisher struct {
-	client *Client
+	client           *Client
+	priorityQueues   bool // publish job urgent ไป subject ".priority" (worker ต้อง consume subject นี้ด้วย)
+	seoPriorityQueue bool // publish SEO job urgent ไป ".priority" (SEO worker ต้องตั้ง SEO_PRIORITY_QUEUE)
 }
 
 // NewPublisher สร้าง Publisher ใหม่
@@ -28,13 +31,18 @@ func NewPublisher(client *Client) *Publisher {
 
 // PublishTranscodeJob ส่ง transcode job ไปยัง JetStream
 func (p *Publisher) PublishTranscodeJob(ctx context.Context, job *TranscodeJob) error {
+	job.TraceID = traceIDFromContext(ctx, job.TraceID)
+
 	data, err := json.Marshal(job)
 	if err != nil {
 		return fmt.Errorf("failed to marshal job: %w", err)
 	}
 
+	// job ใหม่ = ล้างคำขอยกเลิกเก่าที่อาจค้างอยู่ (ไม่งั้น worker ยกเลิกทันทีที่เริ่ม)
+	p.clearJobCancel(ctx, ports.ProgressJobTranscode, job.VideoID)
+
 	// Publish to JetStream
-	ack, err := p.client.js.Publish(ctx, SubjectJobs, data)
+	ack, err := p.client.js.Publish(ctx, p.jobSubject(SubjectJobs, job.Priority), data)
 	if err != nil {
 		logger.Error("Failed to publish transcode job",
 			"video_id", job.VideoID,
@@ -46,6 +54,8 @@ func (p *Publisher) PublishTranscodeJob(ctx context.Context, job *TranscodeJob)
 	logger.Info("Transcode job published to JetStream",
 		"video_id", job.VideoID,
 		"video_code", job.VideoCode,
+		"priority", job.Priority,
+		"trace_id", job.TraceID,
 		"stream", ack.Stream,
 		"sequence", ack.Sequence,
 	)
@@ -53,6 +63,15 @@ func (p *Publisher) PublishTranscodeJob(ctx context.Context, job *TranscodeJob)
 	return nil
 }
 
+// traceIDFromContext คืน trace ID ของ job - ถ้า job ยังไม่มี ใช้ request ID จาก context ที่ enqueue
+// worker ใช้ค่านี้ติดกับ log เพื่อ trace วิดีโอเดียวข้าม API → transcode → gallery → subtitle → SEO
+func traceIDFromContext(ctx context.Context, current string) string {
+	if current != "" {
+		return current
+	}
+	return logger.GetRequestID(ctx)
+}
+
 // EnqueueTranscode helper method ที่รับ parameters แยก (เหมือน Asynq เดิม)
 func (p *Publisher) EnqueueTranscode(ctx context.Context, videoID, videoCode, inputPath, outputPath, codec string, qualities []string, useByteRange bool) error {
 	job := NewTranscodeJob(videoID, videoCode, inputPath, outputPath, codec, qualities, useByteRange)
@@ -224,6 +243,8 @@ func (p *Publisher) GetWorkerCount(ctx context.Context) int {
 
 // PublishDetectJob ส่ง detect language job ไปยัง NATS
 func (p *Publisher) PublishDetectJob(ctx context.Context, job *services.DetectJob) error {
+	job.TraceID = traceIDFromContext(ctx, job.TraceID)
+
 	data, err := json.Marshal(job)
 	if err != nil {
 		return fmt.Errorf("failed to marshal detect job: %w", err)
@@ -242,6 +263,7 @@ func (p *Publisher) PublishDetectJob(ctx context.Context, job *services.DetectJo
 	logger.Info("Detect job published to JetStream",
 		"video_id", job.VideoID,
 		"video_code", job.VideoCode,
+		"trace_id", job.TraceID,
 		"stream", ack.Stream,
 		"sequence", ack.Sequence,
 	)
@@ -251,6 +273,8 @@ func (p *Publisher) PublishDetectJob(ctx context.Context, job *services.DetectJo
 
 // PublishTranscribeJob ส่ง transcribe job ไปยัง NATS
 func (p *Publisher) PublishTranscribeJob(ctx context.Context, job *services.TranscribeJob) error {
+	job.TraceID = traceIDFromContext(ctx, job.TraceID)
+
 	data, err := json.Marshal(job)
 	if err != nil {
 		return fmt.Errorf("failed to marshal transcribe job: %w", err)
@@ -271,6 +295,7 @@ func (p *Publisher) PublishTranscribeJob(ctx context.Context, job *services.Tran
 		"subtitle_id", job.SubtitleID,
 		"video_id", job.VideoID,
 		"language", job.Language,
+		"trace_id", job.TraceID,
 		"stream", ack.Stream,
 		"sequence", ack.Sequence,
 	)
@@ -280,6 +305,8 @@ func (p *Publisher) PublishTranscribeJob(ctx context.Context, job *services.Tran
 
 // PublishTranslateJob ส่ง translate job ไปยัง NATS
 func (p *Publisher) PublishTranslateJob(ctx context.Context, job *services.TranslateJob) error {
+	job.TraceID = traceIDFromContext(ctx, job.TraceID)
+
 	data, err := json.Marshal(job)
 	if err != nil {
 		return fmt.Errorf("failed to marshal translate job: %w", err)
@@ -300,6 +327,7 @@ func (p *Publisher) PublishTranslateJob(ctx context.Context, job *services.Trans
 		"video_id", job.VideoID,
 		"source_language", job.SourceLanguage,
 		"target_languages", job.TargetLanguages,
+		"trace_id", job.TraceID,
 		"stream", ack.Stream,
 		"sequence", ack.Sequence,
 	)
@@ -313,6 +341,8 @@ func (p *Publisher) PublishTranslateJob(ctx context.Context, job *services.Trans
 
 // PublishWarmCacheJob ส่ง warm cache job ไปยัง NATS
 func (p *Publisher) PublishWarmCacheJob(ctx context.Context, job *WarmCacheJob) error {
+	job.TraceID = traceIDFromContext(ctx, job.TraceID)
+
 	data, err := json.Marshal(job)
 	if err != nil {
 		return fmt.Errorf("failed to marshal warm cache job: %w", err)
@@ -334,6 +364,7 @@ func (p *Publisher) PublishWarmCacheJob(ctx context.Context, job *WarmCacheJob)
 		"video_code", job.VideoCode,
 		"hls_path", job.HLSPath,
 		"priority", job.Priority,
+		"trace_id", job.TraceID,
 		"stream", ack.Stream,
 		"sequence", ack.Sequence,
 	)
@@ -347,6 +378,8 @@ func (p *Publisher) PublishWarmCacheJob(ctx context.Context, job *WarmCacheJob)
 
 // PublishReelExportJob ส่ง reel export job ไปยัง NATS
 func (p *Publisher) PublishReelExportJob(ctx context.Context, job *ReelExportJob) error {
+	job.TraceID = traceIDFromContext(ctx, job.TraceID)
+
 	data, err := json.Marshal(job)
 	if err != nil {
 		return fmt.Errorf("failed to marshal reel export job: %w", err)
@@ -369,6 +402,7 @@ func (p *Publisher) PublishReelExportJob(ctx context.Context, job *ReelExportJob
 		"video_code", job.VideoCode,
 		"segment", fmt.Sprintf("%.2f-%.2f", job.SegmentStart, job.SegmentEnd),
 		"layers", len(job.Layers),
+		"trace_id", job.TraceID,
 		"stream", ack.Stream,
 		"sequence", ack.Sequence,
 	)
@@ -382,13 +416,17 @@ func (p *Publisher) PublishReelExportJob(ctx context.Context, job *ReelExportJob
 
 // PublishGalleryJob ส่ง gallery generate job ไปยัง NATS
 func (p *Publisher) PublishGalleryJob(ctx context.Context, job *GalleryJob) error {
+	job.TraceID = traceIDFromContext(ctx, job.TraceID)
+
 	data, err := json.Marshal(job)
 	if err != nil {
 		return fmt.Errorf("failed to marshal gallery job: %w", err)
 	}
 
+	p.clearJobCancel(ctx, ports.ProgressJobGallery, job.VideoID)
+
 	// Publish to JetStream
-	ack, err := p.client.js.Publish(ctx, SubjectGalleryGenerate, data)
+	ack, err := p.client.js.Publish(ctx, p.jobSubject(SubjectGalleryGenerate, job.Priority), data)
 	if err != nil {
 		logger.Error("Failed to publish gallery job",
 			"video_id", job.VideoID,
@@ -403,6 +441,45 @@ func (p *Publisher) PublishGalleryJob(ctx context.Context, job *GalleryJob) erro
 		"video_code", job.VideoCode,
 		"hls_path", job.HLSPath,
 		"image_count", job.ImageCount,
+		"priority", job.Priority,
+		"trace_id", job.TraceID,
+		"stream", ack.Stream,
+		"sequence", ack.Sequence,
+	)
+
+	return nil
+}
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// SEO Article Job Publishing
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// PublishSEOArticleJob ส่ง SEO article job ไปยัง SEO worker
+func (p *Publisher) PublishSEOArticleJob(ctx context.Context, job *SEOArticleJob) error {
+	job.TraceID = traceIDFromContext(ctx, job.TraceID)
+
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to marshal seo article job: %w", err)
+	}
+
+	p.clearJobCancel(ctx, ports.ProgressJobSEO, job.VideoID)
+
+	ack, err := p.client.js.Publish(ctx, p.seoJobSubject(job.Priority), data)
+	if err != nil {
+		logger.Error("Failed to publish seo article job",
+			"video_id", job.VideoID,
+			"video_code", job.VideoCode,
+			"error", err,
+		)
+		return fmt.Errorf("failed to publish seo article job: %w", err)
+	}
+
+	logger.Info("SEO article job published to JetStream",
+		"video_id", job.VideoID,
+		"video_code", job.VideoCode,
+		"priority", job.Priority,
+		"trace_id", job.TraceID,
 		"stream", ack.Stream,
 		"sequence", ack.Sequence,
 	)
@@ -35,6 +35,15 @@ const (
 	GalleryConsumerName    = "GALLERY_WORKER"
 	SubjectGalleryGenerate = "jobs.gallery.generate"
 	SubjectGalleryProgress = "progress.gallery"
+
+	// SEO Article Jobs (stream SEO_ARTICLES สร้างโดย seo-worker)
+	SEOStreamName             = "SEO_ARTICLES"
+	SubjectSEOArticleGenerate = "seo.article.generate"
+
+	// Priority subjects - job urgent ไปคิวแยก (worker ดึงก่อนคิวปกติ)
+	SubjectJobsPriority            = SubjectJobs + PrioritySubjectSuffix
+	SubjectGalleryGeneratePriority = SubjectGalleryGenerate + PrioritySubjectSuffix
+	PrioritySubjectSuffix          = ".priority"
 )
 
 // ═══════════════════════════════════════════════════════════════════════════════
@@ -49,7 +58,9 @@ type TranscodeJob struct {
 	Codec        string   `json:"codec"`          // h264 or h265
 	Qualities    []string `json:"qualities"`      // ["1080p", "720p", "480p"]
 	UseByteRange bool     `json:"use_byte_range"` // Single file HLS
+	Priority     int      `json:"priority,omitempty"` // 1=urgent, 2=normal, 3=backfill (0 = normal)
 	CreatedAt    int64    `json:"created_at"`
+	TraceID      string   `json:"trace_id,omitempty"` // request ID ต้นทาง (correlation ข้าม service)
 }
 
 // ═══════════════════════════════════════════════════════════════════════════════
@@ -57,6 +68,7 @@ type TranscodeJob struct {
 // ⚠️ โครงสร้างนี้ต้องตรงกับ Worker
 // ═══════════════════════════════════════════════════════════════════════════════
 type ProgressUpdate struct {
+	JobType    string  `json:"job_type,omitempty"` // transcode, subtitle, gallery, warmcache, reel, seo (ว่าง = worker รุ่นเก่า)
 	VideoID    string  `json:"video_id"`
 	VideoCode  string  `json:"video_code"`
 	Status     string  `json:"status"`     // processing, completed, failed
@@ -127,6 +139,7 @@ type WarmCacheJob struct {
 	SegmentCounts map[string]int `json:"segment_counts"`  // {"1080p": 150, "720p": 150, ...}
 	Priority      int            `json:"priority"`        // 1=new, 2=popular, 3=backfill
 	CreatedAt     int64          `json:"created_at"`
+	TraceID       string         `json:"trace_id,omitempty"` // request ID ต้นทาง (correlation ข้าม service)
 }
 
 // NewWarmCacheJob สร้าง WarmCacheJob ใหม่
@@ -191,6 +204,7 @@ type ReelExportJob struct {
 
 	OutputPath string `json:"output_path"` // S3 path: reels/{reel_id}/output.mp4
 	CreatedAt  int64  `json:"created_at"`
+	TraceID    string `json:"trace_id,omitempty"` // request ID ต้นทาง (correlation ข้าม service)
 }
 
 // ReelLayerJob layer ใน export job
@@ -238,7 +252,9 @@ type GalleryJob struct {
 	Duration     int    `json:"duration"`       // Video duration in seconds
 	OutputPath   string `json:"output_path"`    // gallery/{code}/
 	ImageCount   int    `json:"image_count"`    // Number of images to generate (default 100)
+	Priority     int    `json:"priority,omitempty"` // 1=urgent, 2=normal, 3=backfill (0 = normal)
 	CreatedAt    int64  `json:"created_at"`
+	TraceID      string `json:"trace_id,omitempty"` // request ID ต้นทาง (correlation ข้าม service)
 }
 
 // NewGalleryJob สร้าง GalleryJob ใหม่
@@ -257,3 +273,32 @@ func NewGalleryJob(videoID, videoCode, hlsPath, videoQuality string, duration in
 		CreatedAt:    time.Now().Unix(),
 	}
 }
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// SEOArticleJob - API → SEO Worker (via JetStream)
+// ⚠️ โครงสร้างนี้ต้องตรงกับ models.SEOArticleJob ของ _seo_worker
+// ═══════════════════════════════════════════════════════════════════════════════
+type SEOArticleJob struct {
+	VideoID        string `json:"video_id"`
+	VideoCode      string `json:"video_code"`
+	Priority       int    `json:"priority"`     // 1=urgent, 2=normal, 3=backfill
+	GenerateTTS    bool   `json:"generate_tts"` // ต้องการ TTS หรือไม่
+	CreatedAt      int64  `json:"created_at"`
+	OutputLanguage string `json:"output_language,omitempty"` // ว่าง = ภาษาไทย
+	TraceID        string `json:"trace_id,omitempty"`        // request ID ต้นทาง (correlation ข้าม service)
+	Force          bool   `json:"force,omitempty"`           // ทำใหม่แม้วัตถุดิบไม่เปลี่ยน
+}
+
+// NewSEOArticleJob สร้าง SEOArticleJob ใหม่ (priority 0 = normal)
+func NewSEOArticleJob(videoID, videoCode string, priority int, generateTTS bool) *SEOArticleJob {
+	if priority == 0 {
+		priority = 2 // normal
+	}
+	return &SEOArticleJob{
+		VideoID:     videoID,
+		VideoCode:   videoCode,
+		Priority:    priority,
+		GenerateTTS: generateTTS,
+		CreatedAt:   time.Now().Unix(),
+	}
+}
@@ -351,6 +351,44 @@ func (r *AdStatsRepositoryImpl) GetSkipTimeDistribution(ctx context.Context, sta
 	return distribution, nil
 }
 
+// ==================== Profile Usage ====================
+
+func (r *AdStatsRepositoryImpl) GetDailyStatsByProfile(ctx context.Context, profileID uuid.UUID, start, end time.Time) ([]*models.ProfileDailyStats, error) {
+	var results []*models.ProfileDailyStats
+
+	err := r.db.WithContext(ctx).
+		Model(&models.AdImpression{}).
+		Where("profile_id = ? AND created_at BETWEEN ? AND ?", profileID, start, end).
+		Select(`
+			DATE(created_at) as date,
+			COUNT(*) as impressions,
+			SUM(CASE WHEN completed = true THEN 1 ELSE 0 END) as completed,
+			SUM(CASE WHEN skipped = true THEN 1 ELSE 0 END) as skipped,
+			SUM(CASE WHEN error_occurred = true THEN 1 ELSE 0 END) as errors,
+			COUNT(DISTINCT video_code) as unique_videos,
+			COUNT(DISTINCT domain) as unique_domains
+		`).
+		Group("DATE(created_at)").
+		Order("date ASC").
+		Scan(&results).Error
+
+	return results, err
+}
+
+func (r *AdStatsRepositoryImpl) GetDomainCountsByProfile(ctx context.Context, profileID uuid.UUID, start, end time.Time) ([]*models.DomainImpressionCount, error) {
+	var results []*models.DomainImpressionCount
+
+	err := r.db.WithContext(ctx).
+		Model(&models.AdImpression{}).
+		Where("profile_id = ? AND created_at BETWEEN ? AND ?", profileID, start, end).
+		Select("domain, COUNT(*) as impressions").
+		Group("domain").
+		Order("impressions DESC").
+		Scan(&results).Error
+
+	return results, err
+}
+
 // ==================== Profile Ranking ====================
 
 func (r *AdStatsRepositoryImpl) GetProfileRanking(ctx context.Context, start, end time.Time, limit int) ([]*models.ProfileAdStats, error) {
@@ -8,6 +8,7 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 	"gofiber-template/domain/models"
+	applog "gofiber-template/pkg/logger"
 )
 
 type DatabaseConfig struct {
@@ -77,15 +78,47 @@ func Migrate(db *gorm.DB) error {
 		// Reel Generator
 		&models.Reel{},
 		&models.ReelTemplate{},
+		// Pipeline event log (job timeline)
+		&models.JobEvent{},
+		// Worker job costs (Gemini tokens, TTS chars, classifier runtime)
+		&models.ProcessingCost{},
+		// Dead Letter Queue job payloads (redacted)
+		&models.DLQEntry{},
+		// ผล classify รายภาพของ gallery (classification stats)
+		&models.GalleryImageScore{},
 	)
 	if err != nil {
 		return err
 	}
 
+	// pg_trgm + trigram indexes สร้างด้วย migrations/20261017_add_video_search_trgm.sql (CONCURRENTLY)
+	// ไม่สร้างตอน startup - GIN build บน videos ล็อกตารางนานและ CREATE EXTENSION ต้องใช้สิทธิ์ superuser
+	warnIfVideoSearchUnavailable(db)
+
 	// Seed default reel templates
 	return SeedReelTemplates(db)
 }
 
+// warnIfVideoSearchUnavailable เตือนถ้ายังไม่ได้รัน migration ของ pg_trgm (search ใช้ ILIKE แบบเดิมจนกว่าจะรัน)
+func warnIfVideoSearchUnavailable(db *gorm.DB) {
+	installed, err := videoSearchTrgmInstalled(db)
+	if err != nil {
+		applog.Warn("Failed to check pg_trgm extension", "error", err)
+		return
+	}
+	if !installed {
+		applog.Warn("pg_trgm extension/index not installed - video search falls back to ILIKE until migrations/20261017_add_video_search_trgm.sql is applied")
+	}
+}
+
+// videoSearchTrgmInstalled มี pg_trgm extension และ trigram index ของ videos.title แล้วหรือยัง
+func videoSearchTrgmInstalled(db *gorm.DB) (bool, error) {
+	var installed bool
+	err := db.Raw(`SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'pg_trgm')
+		AND EXISTS (SELECT 1 FROM pg_indexes WHERE tablename = 'videos' AND indexname = 'idx_videos_title_trgm')`).Scan(&installed).Error
+	return installed, err
+}
+
 // SeedReelTemplates เพิ่ม templates เริ่มต้น (ถ้ายังไม่มี)
 func SeedReelTemplates(db *gorm.DB) error {
 	// ตรวจสอบว่ามี templates อยู่แล้วหรือไม่
@@ -0,0 +1,32 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+)
+
+type DLQEntryRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewDLQEntryRepository(db *gorm.DB) repositories.DLQEntryRepository {
+	return &DLQEntryRepositoryImpl{db: db}
+}
+
+func (r *DLQEntryRepositoryImpl) Create(ctx context.Context, entry *models.DLQEntry) error {
+	return r.db.WithContext(ctx).Create(entry).Error
+}
+
+func (r *DLQEntryRepositoryImpl) GetByVideoID(ctx context.Context, videoID uuid.UUID) ([]*models.DLQEntry, error) {
+	var entries []*models.DLQEntry
+	err := r.db.WithContext(ctx).
+		Where("video_id = ?", videoID).
+		Order("failed_at DESC").
+		Find(&entries).Error
+	return entries, err
+}
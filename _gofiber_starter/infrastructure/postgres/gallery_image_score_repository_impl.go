@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+)
+
+type GalleryImageScoreRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewGalleryImageScoreRepository(db *gorm.DB) repositories.GalleryImageScoreRepository {
+	return &GalleryImageScoreRepositoryImpl{db: db}
+}
+
+func (r *GalleryImageScoreRepositoryImpl) GetTierStats(ctx context.Context) ([]models.GalleryTierStats, error) {
+	var stats []models.GalleryTierStats
+	err := r.db.WithContext(ctx).
+		Model(&models.GalleryImageScore{}).
+		Select(`tier,
+			COUNT(*) AS count,
+			COALESCE(AVG(nsfw_score), 0) AS avg_nsfw_score,
+			COALESCE(AVG(face_score), 0) AS avg_face_score,
+			COALESCE(AVG(aesthetic_score), 0) AS avg_aesthetic_score,
+			COUNT(*) FILTER (WHERE mosaic_detected) AS mosaic_count,
+			COUNT(*) FILTER (WHERE pov_detected) AS pov_count`).
+		Group("tier").
+		Order("tier").
+		Scan(&stats).Error
+	return stats, err
+}
+
+func (r *GalleryImageScoreRepositoryImpl) GetSuperSafeOutliers(ctx context.Context, minNsfwScore float64, limit int) ([]*models.GalleryImageScore, error) {
+	var scores []*models.GalleryImageScore
+	err := r.db.WithContext(ctx).
+		Where("tier = ? AND nsfw_score >= ?", models.GalleryTierSuperSafe, minNsfwScore).
+		Order("nsfw_score DESC").
+		Limit(limit).
+		Find(&scores).Error
+	return scores, err
+}
+
+func (r *GalleryImageScoreRepositoryImpl) CountSuperSafeOutliers(ctx context.Context, minNsfwScore float64) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.GalleryImageScore{}).
+		Where("tier = ? AND nsfw_score >= ?", models.GalleryTierSuperSafe, minNsfwScore).
+		Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,32 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+)
+
+type JobEventRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewJobEventRepository(db *gorm.DB) repositories.JobEventRepository {
+	return &JobEventRepositoryImpl{db: db}
+}
+
+func (r *JobEventRepositoryImpl) Create(ctx context.Context, event *models.JobEvent) error {
+	return r.db.WithContext(ctx).Create(event).Error
+}
+
+func (r *JobEventRepositoryImpl) GetByVideoID(ctx context.Context, videoID uuid.UUID) ([]*models.JobEvent, error) {
+	var events []*models.JobEvent
+	err := r.db.WithContext(ctx).
+		Where("video_id = ?", videoID).
+		Order("created_at ASC").
+		Find(&events).Error
+	return events, err
+}
@@ -0,0 +1,32 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+)
+
+type ProcessingCostRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewProcessingCostRepository(db *gorm.DB) repositories.ProcessingCostRepository {
+	return &ProcessingCostRepositoryImpl{db: db}
+}
+
+func (r *ProcessingCostRepositoryImpl) Create(ctx context.Context, cost *models.ProcessingCost) error {
+	return r.db.WithContext(ctx).Create(cost).Error
+}
+
+func (r *ProcessingCostRepositoryImpl) GetByVideoID(ctx context.Context, videoID uuid.UUID) ([]*models.ProcessingCost, error) {
+	var costs []*models.ProcessingCost
+	err := r.db.WithContext(ctx).
+		Where("video_id = ?", videoID).
+		Order("created_at ASC").
+		Find(&costs).Error
+	return costs, err
+}
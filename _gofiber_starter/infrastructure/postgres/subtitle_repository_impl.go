@@ -127,17 +127,22 @@ func (r *subtitleRepository) GetByStatus(ctx context.Context, status models.Subt
 	return subtitles, nil
 }
 
-// GetStuckProcessing หา subtitles ที่ processing/translating/detecting นานเกินไป (worker crash)
-func (r *subtitleRepository) GetStuckProcessing(ctx context.Context, threshold time.Time) ([]*models.Subtitle, error) {
+// subtitleInFlightStatuses statuses ที่ worker กำลังทำอยู่
+var subtitleInFlightStatuses = []models.SubtitleStatus{
+	models.SubtitleStatusProcessing,
+	models.SubtitleStatusTranslating,
+	models.SubtitleStatusDetecting,
+}
+
+// GetStuckProcessing หา subtitles ที่ processing/translating/detecting แล้ว worker เงียบไป (worker crash)
+// worker ที่ส่ง heartbeat ตัดสินจาก heartbeat ล่าสุด - worker เก่าที่ไม่ส่ง heartbeat ใช้ processing_started_at
+func (r *subtitleRepository) GetStuckProcessing(ctx context.Context, heartbeatThreshold, startedThreshold time.Time) ([]*models.Subtitle, error) {
 	var subtitles []*models.Subtitle
 	err := r.db.WithContext(ctx).
-		Where("status IN ?", []models.SubtitleStatus{
-			models.SubtitleStatusProcessing,
-			models.SubtitleStatusTranslating,
-			models.SubtitleStatusDetecting,
-		}).
+		Where("status IN ?", subtitleInFlightStatuses).
 		Where("processing_started_at IS NOT NULL").
-		Where("processing_started_at < ?", threshold).
+		Where("(last_heartbeat_at IS NOT NULL AND last_heartbeat_at < ?) OR (last_heartbeat_at IS NULL AND processing_started_at < ?)",
+			heartbeatThreshold, startedThreshold).
 		Find(&subtitles).Error
 	return subtitles, err
 }
@@ -154,9 +159,26 @@ func (r *subtitleRepository) MarkSubtitleFailed(ctx context.Context, id uuid.UUI
 }
 
 // UpdateProcessingStartedAt บันทึกเวลาที่ worker เริ่มทำจริง
+// ล้าง last_heartbeat_at ด้วย - heartbeat เก่าจากรอบก่อน (retry) จะทำให้ถูกมองว่า stuck ทันที
 func (r *subtitleRepository) UpdateProcessingStartedAt(ctx context.Context, id uuid.UUID, startedAt time.Time) error {
 	return r.db.WithContext(ctx).
 		Model(&models.Subtitle{}).
 		Where("id = ?", id).
-		Update("processing_started_at", startedAt).Error
+		Updates(map[string]interface{}{
+			"processing_started_at": startedAt,
+			"last_heartbeat_at":     nil,
+		}).Error
+}
+
+// UpdateHeartbeat บันทึก heartbeat เฉพาะ subtitle ที่ยัง processing อยู่
+// (ถูก mark failed ไปแล้ว = false - worker ควรหยุดทำ)
+func (r *subtitleRepository) UpdateHeartbeat(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.Subtitle{}).
+		Where("id = ? AND status IN ?", id, subtitleInFlightStatuses).
+		UpdateColumn("last_heartbeat_at", at)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
 }
@@ -3,18 +3,26 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"gofiber-template/domain/dto"
 	"gofiber-template/domain/models"
 	"gofiber-template/domain/repositories"
+	applog "gofiber-template/pkg/logger"
+	"gofiber-template/pkg/utils"
 )
 
 type VideoRepositoryImpl struct {
 	db *gorm.DB
+
+	// pg_trgm ตรวจครั้งแรกที่มีการค้นหา - ไม่มี = ใช้ ILIKE แบบเดิม (ไม่ error 500)
+	trgmOnce      sync.Once
+	trgmAvailable bool
 }
 
 func NewVideoRepository(db *gorm.DB) repositories.VideoRepository {
@@ -38,6 +46,17 @@ func (r *VideoRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*model
 	return &video, nil
 }
 
+func (r *VideoRepositoryImpl) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.Video, error) {
+	var videos []*models.Video
+	if len(ids) == 0 {
+		return videos, nil
+	}
+	err := r.db.WithContext(ctx).
+		Where("id IN ?", ids).
+		Find(&videos).Error
+	return videos, err
+}
+
 func (r *VideoRepositoryImpl) GetByCode(ctx context.Context, code string) (*models.Video, error) {
 	var video models.Video
 	err := r.db.WithContext(ctx).
@@ -153,34 +172,7 @@ func (r *VideoRepositoryImpl) ListWithFilters(ctx context.Context, params *dto.V
 		Preload("Category").
 		Preload("Subtitles")
 
-	// Search (title หรือ code)
-	if params.Search != "" {
-		searchTerm := "%" + params.Search + "%"
-		query = query.Where("title ILIKE ? OR code ILIKE ?", searchTerm, searchTerm)
-	}
-
-	// Filter by status
-	if params.Status != "" {
-		query = query.Where("status = ?", params.Status)
-	}
-
-	// Filter by category
-	if params.CategoryID != "" {
-		query = query.Where("category_id = ?", params.CategoryID)
-	}
-
-	// Filter by user
-	if params.UserID != "" {
-		query = query.Where("user_id = ?", params.UserID)
-	}
-
-	// Filter by date range
-	if params.DateFrom != "" {
-		query = query.Where("created_at >= ?", params.DateFrom)
-	}
-	if params.DateTo != "" {
-		query = query.Where("created_at <= ?", params.DateTo+" 23:59:59")
-	}
+	query = applyVideoFilters(query, params, r.videoSearchTrgm(ctx))
 
 	// Count total (before pagination)
 	var total int64
@@ -191,7 +183,7 @@ func (r *VideoRepositoryImpl) ListWithFilters(ctx context.Context, params *dto.V
 	// Sort
 	sortBy := "created_at"
 	sortOrder := "DESC"
-	if params.SortBy != "" {
+	if params.SortBy != "" && params.SortBy != dto.VideoSortRelevance {
 		sortBy = params.SortBy
 	}
 	if params.SortOrder == "asc" {
@@ -219,6 +211,184 @@ func (r *VideoRepositoryImpl) ListWithFilters(ctx context.Context, params *dto.V
 	return videos, total, nil
 }
 
+// SearchWithFilters ค้นหา videos (params.Search) พร้อม filter อื่น เรียงตามความเกี่ยวข้อง
+// sortBy อื่นที่ไม่ใช่ relevance = เรียงตาม field นั้นเหมือน ListWithFilters (relevance เป็นตัวรอง)
+func (r *VideoRepositoryImpl) SearchWithFilters(ctx context.Context, params *dto.VideoFilterRequest) ([]*models.Video, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.Video{}).
+		Preload("User").
+		Preload("Category").
+		Preload("Subtitles")
+
+	trgm := r.videoSearchTrgm(ctx)
+	query = applyVideoFilters(query, params, trgm)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if params.SortBy != "" && params.SortBy != dto.VideoSortRelevance {
+		sortOrder := "DESC"
+		if params.SortOrder == "asc" {
+			sortOrder = "ASC"
+		}
+		query = query.Order(fmt.Sprintf("%s %s", params.SortBy, sortOrder))
+	}
+	if trgm {
+		query = query.Order(videoSearchRank(params.Search))
+	}
+	query = query.Order("created_at DESC")
+
+	page := params.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := params.Limit
+	if limit < 1 {
+		limit = 20
+	}
+	query = query.Offset((page - 1) * limit).Limit(limit)
+
+	var videos []*models.Video
+	if err := query.Find(&videos).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return videos, total, nil
+}
+
+// ListWithFiltersCursor ดึง videos พร้อม filter แบบ cursor pagination
+// cursor pagination รองรับเฉพาะ sort ตาม created_at (keyset บน created_at,id)
+func (r *VideoRepositoryImpl) ListWithFiltersCursor(ctx context.Context, params *dto.VideoFilterRequest) ([]*models.Video, string, error) {
+	query := r.db.WithContext(ctx).Model(&models.Video{}).
+		Preload("User").
+		Preload("Category").
+		Preload("Subtitles")
+
+	query = applyVideoFilters(query, params, r.videoSearchTrgm(ctx))
+
+	limit := params.Limit
+	if limit < 1 {
+		limit = 20
+	}
+
+	return findVideosByCursor(query, params.Cursor, limit, params.SortOrder != "asc")
+}
+
+// videoSearchTrgm pg_trgm + trigram indexes พร้อมใช้หรือไม่ (ตรวจครั้งเดียวต่อ process - รัน migration แล้วต้อง restart)
+// ยังไม่ได้รัน migration = ค้นหาด้วย ILIKE แบบเดิมแทน error จาก <% / word_similarity
+func (r *VideoRepositoryImpl) videoSearchTrgm(ctx context.Context) bool {
+	r.trgmOnce.Do(func() {
+		// ไม่ใช้ ctx ของ request - request ที่ถูกยกเลิกไม่ควรทำให้ตัดสินว่าไม่มี pg_trgm ไปตลอด
+		installed, err := videoSearchTrgmInstalled(r.db)
+		if err != nil {
+			applog.WarnContext(ctx, "Failed to check pg_trgm - video search uses ILIKE", "error", err)
+			return
+		}
+		if !installed {
+			applog.WarnContext(ctx, "pg_trgm not installed - video search uses ILIKE until migrations/20261017_add_video_search_trgm.sql is applied")
+		}
+		r.trgmAvailable = installed
+	})
+	return r.trgmAvailable
+}
+
+// applyVideoFilters ใส่ search/status/category/user/date filters (ใช้ร่วมกันระหว่าง offset และ cursor mode)
+// trgm = false → search แบบ ILIKE ของ title/code (ก่อนมี pg_trgm)
+func applyVideoFilters(query *gorm.DB, params *dto.VideoFilterRequest, trgm bool) *gorm.DB {
+	// Search (title/code/description - substring หรือใกล้เคียงแบบ trigram)
+	if params.Search != "" {
+		if trgm {
+			query = applyVideoSearch(query, params.Search)
+		} else {
+			searchTerm := "%" + params.Search + "%"
+			query = query.Where("title ILIKE ? OR code ILIKE ?", searchTerm, searchTerm)
+		}
+	}
+
+	// Filter by status
+	if params.Status != "" {
+		query = query.Where("status = ?", params.Status)
+	}
+
+	// Filter by category
+	if params.CategoryID != "" {
+		query = query.Where("category_id = ?", params.CategoryID)
+	}
+
+	// Filter by user
+	if params.UserID != "" {
+		query = query.Where("user_id = ?", params.UserID)
+	}
+
+	// Filter by date range
+	if params.DateFrom != "" {
+		query = query.Where("created_at >= ?", params.DateFrom)
+	}
+	if params.DateTo != "" {
+		query = query.Where("created_at <= ?", params.DateTo+" 23:59:59")
+	}
+
+	return query
+}
+
+// applyVideoSearch กรอง videos ที่ title/code/description มีคำค้น หรือสะกดใกล้เคียง (pg_trgm word_similarity)
+// ใช้ GIN trigram indexes ได้ทั้ง ILIKE และ <% (ดู migrations/20261017_add_video_search_trgm.sql)
+func applyVideoSearch(query *gorm.DB, search string) *gorm.DB {
+	searchTerm := "%" + search + "%"
+	return query.Where(
+		"(title ILIKE ? OR code ILIKE ? OR description ILIKE ? OR ? <% title OR ? <% code)",
+		searchTerm, searchTerm, searchTerm, search, search,
+	)
+}
+
+// videoSearchRank คะแนนความเกี่ยวข้องของผลค้นหา: code ตรงเป๊ะมาก่อน แล้วตาม similarity ของ title/code
+// description มีน้ำหนักครึ่งเดียว (ยาวและมักมีคำทั่วไป)
+func videoSearchRank(search string) clause.Expr {
+	return clause.Expr{
+		SQL: "(CASE WHEN LOWER(code) = LOWER(?) THEN 1 ELSE 0 END + " +
+			"GREATEST(word_similarity(?, title), similarity(?, code), word_similarity(?, COALESCE(description, '')) * 0.5)) DESC",
+		Vars:               []interface{}{search, search, search, search},
+		WithoutParentheses: true,
+	}
+}
+
+// findVideosByCursor ดึง videos ถัดจาก cursor (keyset บน created_at,id)
+// ดึงเกิน 1 แถวเพื่อรู้ว่ามีหน้าถัดไปไหม โดยไม่ต้อง COUNT ทั้งตาราง
+func findVideosByCursor(query *gorm.DB, cursor string, limit int, desc bool) ([]*models.Video, string, error) {
+	if cursor != "" {
+		createdAt, id, err := utils.DecodeCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		if desc {
+			query = query.Where("(created_at, id) < (?, ?)", createdAt, id)
+		} else {
+			query = query.Where("(created_at, id) > (?, ?)", createdAt, id)
+		}
+	}
+
+	if desc {
+		query = query.Order("created_at DESC").Order("id DESC")
+	} else {
+		query = query.Order("created_at ASC").Order("id ASC")
+	}
+
+	var videos []*models.Video
+	if err := query.Limit(limit + 1).Find(&videos).Error; err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(videos) > limit {
+		videos = videos[:limit]
+		last := videos[len(videos)-1]
+		nextCursor = utils.EncodeCursor(last.CreatedAt, last.ID)
+	}
+
+	return videos, nextCursor, nil
+}
+
 func (r *VideoRepositoryImpl) Count(ctx context.Context) (int64, error) {
 	var count int64
 	err := r.db.WithContext(ctx).Model(&models.Video{}).Count(&count).Error
@@ -357,6 +527,164 @@ func (r *VideoRepositoryImpl) CountByGalleryStatus(ctx context.Context, galleryS
 	return count, err
 }
 
+// ListByGalleryStatus ดึง videos ตาม gallery_status พร้อม total
+// เรียง updated_at เก่าสุดก่อน (video ที่รอ review นานสุดขึ้นก่อน)
+func (r *VideoRepositoryImpl) ListByGalleryStatus(ctx context.Context, galleryStatus string, offset, limit int) ([]*models.Video, int64, error) {
+	var videos []*models.Video
+	var total int64
+
+	query := r.db.WithContext(ctx).
+		Model(&models.Video{}).
+		Where("status = ? AND gallery_status = ?", models.VideoStatusReady, galleryStatus)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Order("updated_at ASC").
+		Offset(offset).Limit(limit).
+		Find(&videos).Error
+
+	return videos, total, err
+}
+
+// ListReadyWithoutGallery ดึง videos ที่ ready แต่ยังไม่มี gallery
+// ไม่รวม video ที่กำลังสร้าง/รอ review อยู่ และต้องมี HLS แล้ว
+func (r *VideoRepositoryImpl) ListReadyWithoutGallery(ctx context.Context, offset, limit int) ([]*models.Video, int64, error) {
+	var videos []*models.Video
+	var total int64
+
+	query := r.db.WithContext(ctx).
+		Model(&models.Video{}).
+		Where("status = ?", models.VideoStatusReady).
+		Where("gallery_count = 0").
+		Where("gallery_status NOT IN ?", []string{"processing", "pending_review"}).
+		Where("hls_path <> ''")
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results (เก่าสุดก่อน)
+	err := query.
+		Order("created_at ASC").
+		Offset(offset).Limit(limit).
+		Find(&videos).Error
+
+	return videos, total, err
+}
+
+// ListWithGallery ดึง videos ที่มี gallery_path (ข้าม processing - worker กำลังเขียน counts เอง)
+func (r *VideoRepositoryImpl) ListWithGallery(ctx context.Context, offset, limit int) ([]*models.Video, int64, error) {
+	var videos []*models.Video
+	var total int64
+
+	query := r.db.WithContext(ctx).
+		Model(&models.Video{}).
+		Where("gallery_path <> ''").
+		Where("gallery_status <> ?", "processing")
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Order("created_at ASC").
+		Offset(offset).Limit(limit).
+		Find(&videos).Error
+
+	return videos, total, err
+}
+
+// ListForSEOEnqueue ดึง videos ตาม filter ของ bulk SEO enqueue
+// "มีบทความแล้ว" = article_published_at ถูก set (MarkArticlePublished เมื่อ SEO worker publish สำเร็จ)
+// หรือมี job event seo/completed (วิดีโอที่ publish ก่อนมี column และยังไม่ได้รัน backfill migration)
+func (r *VideoRepositoryImpl) ListForSEOEnqueue(ctx context.Context, params *dto.EnqueueSEORequest, recentSince time.Time, limit int) ([]*models.Video, int64, error) {
+	var videos []*models.Video
+	var total int64
+
+	query := r.db.WithContext(ctx).
+		Model(&models.Video{}).
+		Where("status = ?", params.Status).
+		Where("NOT EXISTS (SELECT 1 FROM job_events e WHERE e.video_id = videos.id AND e.source = ? AND e.created_at >= ?)",
+			models.JobEventSourceSEO, recentSince)
+
+	if params.HasGallery {
+		query = query.Where("gallery_count > 0")
+	}
+	if params.MissingArticle {
+		query = query.Where("article_published_at IS NULL").
+			Where("NOT EXISTS (SELECT 1 FROM job_events e WHERE e.video_id = videos.id AND e.source = ? AND e.stage = ?)",
+				models.JobEventSourceSEO, models.JobEventStageCompleted)
+	}
+	if params.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *params.CreatedFrom)
+	}
+	if params.CreatedTo != nil {
+		query = query.Where("created_at < ?", *params.CreatedTo)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&videos).Error
+
+	return videos, total, err
+}
+
+func (r *VideoRepositoryImpl) MarkArticlePublished(ctx context.Context, id uuid.UUID, publishedAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&models.Video{}).
+		Where("id = ?", id).
+		Update("article_published_at", publishedAt).Error
+}
+
+// UpdateGalleryCounts อัพเดทเฉพาะ gallery counts
+func (r *VideoRepositoryImpl) UpdateGalleryCounts(ctx context.Context, id uuid.UUID, counts dto.GalleryCounts) error {
+	return r.db.WithContext(ctx).
+		Model(&models.Video{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"gallery_source_count":     counts.Source,
+			"gallery_safe_count":       counts.Safe,
+			"gallery_nsfw_count":       counts.Nsfw,
+			"gallery_super_safe_count": counts.SuperSafe,
+			"gallery_count":            counts.Total,
+		}).Error
+}
+
+// ListReadyWithoutSubtitles ดึง videos ที่ ready + มี audio แต่ยังไม่มี original subtitle ที่ ready
+func (r *VideoRepositoryImpl) ListReadyWithoutSubtitles(ctx context.Context, offset, limit int) ([]*models.Video, int64, error) {
+	var videos []*models.Video
+	var total int64
+
+	query := r.db.WithContext(ctx).
+		Model(&models.Video{}).
+		Where("status = ?", models.VideoStatusReady).
+		Where("audio_path <> ''").
+		Where("NOT EXISTS (SELECT 1 FROM subtitles s WHERE s.video_id = videos.id AND s.type = ? AND s.status = ?)",
+			models.SubtitleTypeOriginal, models.SubtitleStatusReady)
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results (เก่าสุดก่อน)
+	err := query.
+		Order("created_at ASC").
+		Offset(offset).Limit(limit).
+		Find(&videos).Error
+
+	return videos, total, err
+}
+
 // GetGalleryFailed ดึง videos ที่ gallery failed
 // เงื่อนไข: video status = ready, gallery_status = none, มี last_error ที่เกี่ยวกับ gallery
 func (r *VideoRepositoryImpl) GetGalleryFailed(ctx context.Context, offset, limit int) ([]*models.Video, int64, error) {
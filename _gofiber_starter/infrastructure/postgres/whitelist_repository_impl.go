@@ -105,6 +105,14 @@ func (r *WhitelistRepositoryImpl) AddDomain(ctx context.Context, domain *models.
 	return r.db.WithContext(ctx).Create(domain).Error
 }
 
+// AddDomains bulk insert (ใช้ตอน import)
+func (r *WhitelistRepositoryImpl) AddDomains(ctx context.Context, domains []*models.ProfileDomain) error {
+	if len(domains) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).CreateInBatches(domains, 100).Error
+}
+
 func (r *WhitelistRepositoryImpl) GetDomainByID(ctx context.Context, domainID uuid.UUID) (*models.ProfileDomain, error) {
 	var domain models.ProfileDomain
 	err := r.db.WithContext(ctx).First(&domain, "id = ?", domainID).Error
@@ -200,3 +200,38 @@ func (c *Client) GetOrSet(ctx context.Context, key string, target interface{}, t
 	data, _ := json.Marshal(result)
 	return json.Unmarshal(data, target)
 }
+
+// staleKeySuffix suffix ของสำเนาสำรองที่อยู่นานกว่า TTL ปกติ (ใช้ตอน source ล่มชั่วคราว)
+const staleKeySuffix = ":stale"
+
+// StaleKey key ของสำเนาสำรองของ key (ขึ้นต้นเหมือน key - pattern purge ลบไปด้วย)
+func StaleKey(key string) string {
+	return key + staleKeySuffix
+}
+
+// GetOrSetWithGrace เหมือน GetOrSet แต่เก็บสำเนาสำรองไว้นานกว่า ttl อีก grace
+// ถ้า getter error (เช่น DB ล่มชั่วคราว) และสำเนาสำรองยังอยู่ → คืนค่าเก่าพร้อม warn แทน error
+func (c *Client) GetOrSetWithGrace(ctx context.Context, key string, target interface{}, ttl, grace time.Duration, getter func() (interface{}, error)) error {
+	var fetchErr error
+	err := c.GetOrSet(ctx, key, target, ttl, func() (interface{}, error) {
+		result, err := getter()
+		if err != nil {
+			fetchErr = err
+			return nil, err
+		}
+		if err := c.SetJSON(ctx, StaleKey(key), result, ttl+grace); err != nil {
+			logger.Warn("Failed to cache stale copy", "key", key, "error", err)
+		}
+		return result, nil
+	})
+	if err == nil || fetchErr == nil {
+		return err
+	}
+
+	// source error - ใช้สำเนาสำรองถ้ายังไม่หมด grace
+	if staleErr := c.GetJSON(ctx, StaleKey(key), target); staleErr == nil {
+		logger.Warn("Serving stale cache after fetch error", "key", key, "error", fetchErr)
+		return nil
+	}
+	return err
+}
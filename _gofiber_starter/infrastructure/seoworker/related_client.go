@@ -0,0 +1,102 @@
+package seoworker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"gofiber-template/domain/ports"
+	"gofiber-template/pkg/config"
+)
+
+// RelatedClient เรียก GET /related/{videoId} ของ SEO worker (schema server)
+type RelatedClient struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// NewRelatedClient สร้าง RelatedClient (ไม่ได้ตั้ง URL/token = disabled)
+func NewRelatedClient(cfg config.SEOWorkerConfig) ports.RelatedFinderPort {
+	return &RelatedClient{
+		baseURL: strings.TrimSuffix(cfg.URL, "/"),
+		token:   cfg.Token,
+		httpClient: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+	}
+}
+
+// IsEnabled ต้องมีทั้ง URL และ token
+func (c *RelatedClient) IsEnabled() bool {
+	return c.baseURL != "" && c.token != ""
+}
+
+type relatedResponse struct {
+	HasEmbedding bool `json:"hasEmbedding"`
+	Matches      []struct {
+		VideoID    string  `json:"videoId"`
+		Similarity float64 `json:"similarity"`
+	} `json:"matches"`
+}
+
+// FindRelated ส่ง query ไป SEO worker - ID ที่ parse ไม่ได้จะถูกข้าม
+func (c *RelatedClient) FindRelated(ctx context.Context, query *ports.RelatedQuery) ([]ports.RelatedMatch, bool, error) {
+	if !c.IsEnabled() {
+		return nil, false, nil
+	}
+
+	params := url.Values{}
+	params.Set("limit", strconv.Itoa(query.Limit))
+	if query.CastID != "" {
+		params.Set("castId", query.CastID)
+	}
+	if query.MakerID != "" {
+		params.Set("makerId", query.MakerID)
+	}
+	if query.TagID != "" {
+		params.Set("tagId", query.TagID)
+	}
+	if query.MinSimilarity > 0 {
+		params.Set("minSimilarity", strconv.FormatFloat(query.MinSimilarity, 'f', -1, 64))
+	}
+
+	endpoint := fmt.Sprintf("%s/related/%s?%s", c.baseURL, query.VideoID, params.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create related request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, false, fmt.Errorf("related request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("related request failed: status %d", resp.StatusCode)
+	}
+
+	var body relatedResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, false, fmt.Errorf("failed to decode related response: %w", err)
+	}
+
+	matches := make([]ports.RelatedMatch, 0, len(body.Matches))
+	for _, m := range body.Matches {
+		id, err := uuid.Parse(m.VideoID)
+		if err != nil {
+			continue
+		}
+		matches = append(matches, ports.RelatedMatch{VideoID: id, Similarity: m.Similarity})
+	}
+	return matches, body.HasEmbedding, nil
+}
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -114,6 +115,28 @@ func (l *LocalStorage) DeleteFolder(prefix string) error {
 	return nil
 }
 
+// DeleteObjects ลบหลายไฟล์จาก local filesystem (ลบทีละไฟล์ ไม่มี batch API)
+func (l *LocalStorage) DeleteObjects(ctx context.Context, paths []string) error {
+	failedCount := 0
+	var firstErr error
+	for _, path := range paths {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("bulk delete cancelled: %w", err)
+		}
+		if err := l.DeleteFile(path); err != nil {
+			failedCount++
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	if failedCount > 0 {
+		return fmt.Errorf("failed to delete %d/%d files: %w", failedCount, len(paths), firstErr)
+	}
+	return nil
+}
+
 // GetFileURL สร้าง URL สำหรับเข้าถึงไฟล์
 func (l *LocalStorage) GetFileURL(path string) string {
 	path = strings.ReplaceAll(path, "\\", "/")
@@ -295,3 +318,27 @@ func (l *LocalStorage) ListFiles(prefix string) ([]string, error) {
 
 	return files, nil
 }
+
+// StatObject ดึงข้อมูลไฟล์จาก local filesystem
+func (l *LocalStorage) StatObject(path string) (*ports.ObjectInfo, error) {
+	path = strings.ReplaceAll(path, "\\", "/")
+	path = strings.TrimPrefix(path, "/")
+	fullPath := filepath.Join(l.basePath, path)
+
+	info, err := os.Stat(fullPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ports.ErrObjectNotFound, path)
+		}
+		return nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("%w: %s is a directory", ports.ErrObjectNotFound, path)
+	}
+
+	return &ports.ObjectInfo{
+		Path:         path,
+		Size:         info.Size(),
+		LastModified: info.ModTime(),
+	}, nil
+}
@@ -148,41 +148,75 @@ func (s *S3Storage) DeleteFolder(prefix string) error {
 		Recursive: true,
 	})
 
-	// Collect objects to delete
-	var objectsToDelete []minio.ObjectInfo
+	// Collect keys to delete
+	var keysToDelete []string
 	for obj := range objectsCh {
 		if obj.Err != nil {
 			return fmt.Errorf("failed to list objects: %w", obj.Err)
 		}
-		objectsToDelete = append(objectsToDelete, obj)
+		keysToDelete = append(keysToDelete, obj.Key)
 	}
 
-	if len(objectsToDelete) == 0 {
+	if len(keysToDelete) == 0 {
 		logger.Debug("No objects found to delete", "prefix", prefix)
 		return nil
 	}
 
-	// Delete objects
-	deletedCount := 0
-	for _, obj := range objectsToDelete {
-		err := s.client.RemoveObject(ctx, s.bucket, obj.Key, minio.RemoveObjectOptions{})
-		if err != nil {
-			logger.Warn("Failed to delete object", "key", obj.Key, "error", err)
-			// Continue deleting other objects
-		} else {
-			deletedCount++
-		}
+	// Bulk delete (RemoveObjects ส่งทีละ 1000 keys ต่อ request)
+	if err := s.DeleteObjects(ctx, keysToDelete); err != nil {
+		return fmt.Errorf("failed to delete folder %s: %w", prefix, err)
 	}
 
 	logger.Info("Folder deleted from S3",
 		"prefix", prefix,
-		"total_objects", len(objectsToDelete),
-		"deleted", deletedCount,
+		"total_objects", len(keysToDelete),
 	)
 
 	return nil
 }
 
+// DeleteObjects ลบหลายไฟล์ด้วย RemoveObjects (S3 multi-object delete)
+// ลบต่อจนครบแม้บางไฟล์ลบไม่สำเร็จ แล้ว return error สรุปจำนวนที่ล้มเหลว
+func (s *S3Storage) DeleteObjects(ctx context.Context, paths []string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+
+	objectsCh := make(chan minio.ObjectInfo)
+	go func() {
+		defer close(objectsCh)
+		for _, path := range paths {
+			path = strings.TrimPrefix(path, "/")
+			path = strings.ReplaceAll(path, "\\", "/")
+			select {
+			case objectsCh <- minio.ObjectInfo{Key: path}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	failedCount := 0
+	var firstErr error
+	for result := range s.client.RemoveObjects(ctx, s.bucket, objectsCh, minio.RemoveObjectsOptions{}) {
+		logger.Warn("Failed to delete object", "key", result.ObjectName, "error", result.Err)
+		failedCount++
+		if firstErr == nil {
+			firstErr = result.Err
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("bulk delete cancelled: %w", err)
+	}
+	if failedCount > 0 {
+		return fmt.Errorf("failed to delete %d/%d objects: %w", failedCount, len(paths), firstErr)
+	}
+
+	logger.Debug("Objects deleted from S3", "count", len(paths))
+	return nil
+}
+
 // GetFileURL สร้าง URL สำหรับเข้าถึงไฟล์
 func (s *S3Storage) GetFileURL(path string) string {
 	path = strings.TrimPrefix(path, "/")
@@ -408,7 +442,24 @@ func (s *S3Storage) ListFiles(prefix string) ([]string, error) {
 	return files, nil
 }
 
+// StatObject ดึงข้อมูลไฟล์จาก S3 (HEAD request)
+func (s *S3Storage) StatObject(path string) (*ports.ObjectInfo, error) {
+	path = strings.TrimPrefix(path, "/")
+	path = strings.ReplaceAll(path, "\\", "/")
 
+	info, err := s.client.StatObject(context.Background(), s.bucket, path, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, fmt.Errorf("%w: %s", ports.ErrObjectNotFound, path)
+		}
+		return nil, fmt.Errorf("failed to stat object: %w", err)
+	}
 
-
-
+	return &ports.ObjectInfo{
+		Path:         path,
+		Size:         info.Size,
+		ContentType:  info.ContentType,
+		ETag:         info.ETag,
+		LastModified: info.LastModified,
+	}, nil
+}
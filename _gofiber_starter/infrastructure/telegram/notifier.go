@@ -164,6 +164,38 @@ func (n *TelegramNotifier) SendTranscodeFailAlert(ctx context.Context, videoCode
 	return n.sendMessage(ctx, message)
 }
 
+// SendSubtitleReadyAlert ส่งแจ้งเตือนเมื่อ subtitle พร้อม review
+// เปิดแยกกันได้: on_subtitle_ready (original) / on_translation_ready (translated)
+func (n *TelegramNotifier) SendSubtitleReadyAlert(ctx context.Context, notification *ports.SubtitleReadyNotification) error {
+	settingKey := "on_subtitle_ready"
+	heading := "ถอดซับไตเติ้ลเสร็จแล้ว"
+	languageLine := notification.Language
+	if notification.Type == "translated" {
+		settingKey = "on_translation_ready"
+		heading = "แปลซับไตเติ้ลเสร็จแล้ว"
+		if notification.SourceLanguage != "" {
+			languageLine = fmt.Sprintf("%s → %s", notification.SourceLanguage, notification.Language)
+		}
+	}
+
+	if !n.settingService.GetBool(ctx, "alert", settingKey, false) {
+		return nil
+	}
+
+	message := fmt.Sprintf(`💬 <b>%s</b>
+
+📹 <b>%s</b>
+📝 Code: <code>%s</code>
+🌐 Language: %s`,
+		heading,
+		escapeHTML(notification.Title),
+		notification.VideoCode,
+		languageLine,
+	)
+
+	return n.sendMessage(ctx, message)
+}
+
 // SendWorkerOfflineAlert ส่งแจ้งเตือนเมื่อ worker offline
 func (n *TelegramNotifier) SendWorkerOfflineAlert(ctx context.Context, workerID, hostname, lastSeen string) error {
 	if !n.settingService.GetBool(ctx, "alert", "on_worker_offline", true) {
@@ -445,10 +445,8 @@ func (t *FFmpegTranscoder) TranscodeAdaptive(ctx context.Context, opts *ports.Ad
 // transcodeMultiQuality ทำ multi-quality HLS transcoding
 // ใช้ VideoCodecConfig สำหรับ extensibility และ HLS optimization
 func (t *FFmpegTranscoder) transcodeMultiQuality(ctx context.Context, inputPath, outputDir string, qualities []ports.QualityProfile, codecConfig *ports.VideoCodecConfig, videoInfo *ports.VideoInfo, preset string, segmentTime int, onProgress ports.ProgressCallback) (string, error) {
-	// สร้าง master playlist content
-	var masterPlaylistContent strings.Builder
-	masterPlaylistContent.WriteString("#EXTM3U\n")
-	masterPlaylistContent.WriteString("#EXT-X-VERSION:3\n")
+	// variants สำหรับ master playlist (เพิ่มเมื่อ transcode quality นั้นสำเร็จ)
+	var variants []MasterPlaylistVariant
 
 	// คำนวณ GOP size สำหรับ HLS optimization
 	var gopSize int
@@ -566,15 +564,16 @@ func (t *FFmpegTranscoder) transcodeMultiQuality(ctx context.Context, inputPath,
 		}
 
 		// เพิ่มลงใน master playlist
-		bandwidth := q.VideoBPS + q.AudioBPS
-		resolution := fmt.Sprintf("%dx%d", calculateWidth(q.Height), q.Height)
-		masterPlaylistContent.WriteString(fmt.Sprintf("#EXT-X-STREAM-INF:BANDWIDTH=%d,RESOLUTION=%s,NAME=\"%s\"\n", bandwidth, resolution, q.Name))
-		masterPlaylistContent.WriteString(fmt.Sprintf("%s/playlist.m3u8\n", q.Name))
+		variants = append(variants, MasterPlaylistVariant{
+			Name:      q.Name,
+			Height:    q.Height,
+			Bandwidth: q.VideoBPS + q.AudioBPS,
+		})
 	}
 
 	// เขียน master playlist
 	masterPlaylistPath := filepath.Join(outputDir, "master.m3u8")
-	if err := os.WriteFile(masterPlaylistPath, []byte(masterPlaylistContent.String()), 0644); err != nil {
+	if err := os.WriteFile(masterPlaylistPath, []byte(BuildMasterPlaylist(variants)), 0644); err != nil {
 		return "", fmt.Errorf("failed to write master playlist: %w", err)
 	}
 
@@ -0,0 +1,69 @@
+package transcoder
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gofiber-template/domain/ports"
+)
+
+// MasterPlaylistVariant 1 variant ใน master playlist ({name}/playlist.m3u8)
+type MasterPlaylistVariant struct {
+	Name             string // 1080p, 720p, 480p
+	Height           int
+	Bandwidth        int // peak bps (BANDWIDTH)
+	AverageBandwidth int // bps เฉลี่ย (AVERAGE-BANDWIDTH) - 0 = ไม่ใส่
+}
+
+// VariantFromQuality สร้าง variant จากชื่อ quality + ขนาดไฟล์รวม (จาก Video.QualitySizes)
+// - quality ที่อยู่ใน DefaultQualityProfiles: BANDWIDTH = bitrate ของ profile
+// - quality อื่น (เช่น 360p): BANDWIDTH = ค่าเฉลี่ยจากขนาดไฟล์ / duration
+// ชื่อที่ไม่ใช่รูปแบบ "{height}p" หรือคำนวณ bandwidth ไม่ได้ = false
+func VariantFromQuality(name string, sizeBytes int64, durationSec int) (MasterPlaylistVariant, bool) {
+	height, err := strconv.Atoi(strings.TrimSuffix(name, "p"))
+	if err != nil || height <= 0 || !strings.HasSuffix(name, "p") {
+		return MasterPlaylistVariant{}, false
+	}
+
+	variant := MasterPlaylistVariant{Name: name, Height: height}
+	if sizeBytes > 0 && durationSec > 0 {
+		variant.AverageBandwidth = int(sizeBytes * 8 / int64(durationSec))
+	}
+
+	for _, p := range ports.DefaultQualityProfiles {
+		if p.Name == name {
+			variant.Bandwidth = p.VideoBPS + p.AudioBPS
+			return variant, true
+		}
+	}
+
+	if variant.AverageBandwidth == 0 {
+		return MasterPlaylistVariant{}, false
+	}
+	variant.Bandwidth = variant.AverageBandwidth
+	return variant, true
+}
+
+// BuildMasterPlaylist สร้างเนื้อหา master.m3u8 (เรียง quality สูง → ต่ำ เหมือนตอน transcode)
+func BuildMasterPlaylist(variants []MasterPlaylistVariant) string {
+	sorted := make([]MasterPlaylistVariant, len(variants))
+	copy(sorted, variants)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Height > sorted[j].Height
+	})
+
+	var b strings.Builder
+	b.WriteString("#EXTM3U\n")
+	b.WriteString("#EXT-X-VERSION:3\n")
+	for _, v := range sorted {
+		fmt.Fprintf(&b, "#EXT-X-STREAM-INF:BANDWIDTH=%d,", v.Bandwidth)
+		if v.AverageBandwidth > 0 {
+			fmt.Fprintf(&b, "AVERAGE-BANDWIDTH=%d,", v.AverageBandwidth)
+		}
+		fmt.Fprintf(&b, "RESOLUTION=%dx%d,NAME=\"%s\"\n", calculateWidth(v.Height), v.Height, v.Name)
+		fmt.Fprintf(&b, "%s/playlist.m3u8\n", v.Name)
+	}
+	return b.String()
+}
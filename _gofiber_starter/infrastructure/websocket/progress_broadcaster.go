@@ -3,26 +3,33 @@ package websocket
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 
+	"gofiber-template/domain/models"
 	"gofiber-template/domain/ports"
 	"gofiber-template/domain/repositories"
 	"gofiber-template/pkg/logger"
 )
 
+// jobEventWriteTimeout เวลาสูงสุดในการบันทึก job event (ไม่ให้ DB ช้าไปค้าง NATS handler)
+const jobEventWriteTimeout = 5 * time.Second
+
 // ProgressBroadcaster รับ progress จาก messaging และ broadcast ไปยัง WebSocket clients
 // ใช้ ports.ProgressSubscriberPort เพื่อ decouple จาก NATS implementation
 type ProgressBroadcaster struct {
-	progressSub ports.ProgressSubscriberPort
-	manager     *WebSocketManager
-	videoRepo   repositories.VideoRepository
-	notifier    ports.NotifierPort // สำหรับส่ง notification เมื่อ completed/failed
-	titleCache  map[string]string  // cache video title เพื่อไม่ต้อง query ทุกครั้ง
-	cacheMu     sync.RWMutex
-	running     bool
-	runningMu   sync.Mutex
-	cancelCtx   context.CancelFunc
+	progressSub  ports.ProgressSubscriberPort
+	manager      *WebSocketManager
+	videoRepo    repositories.VideoRepository
+	notifier     ports.NotifierPort              // สำหรับส่ง notification เมื่อ completed/failed
+	jobEventRepo repositories.JobEventRepository // สำหรับบันทึก pipeline event log (optional)
+	titleCache   map[string]string               // cache video title เพื่อไม่ต้อง query ทุกครั้ง
+	lastStep     map[string]string               // step ล่าสุดที่บันทึกลง job_events ต่อ video (กัน event ซ้ำทุก %)
+	cacheMu      sync.RWMutex
+	running      bool
+	runningMu    sync.Mutex
+	cancelCtx    context.CancelFunc
 }
 
 // NewProgressBroadcaster สร้าง ProgressBroadcaster ใหม่
@@ -32,6 +39,7 @@ func NewProgressBroadcaster(progressSub ports.ProgressSubscriberPort, videoRepo
 		manager:     Manager, // ใช้ global Manager
 		videoRepo:   videoRepo,
 		titleCache:  make(map[string]string),
+		lastStep:    make(map[string]string),
 	}
 }
 
@@ -40,6 +48,11 @@ func (pb *ProgressBroadcaster) SetNotifier(notifier ports.NotifierPort) {
 	pb.notifier = notifier
 }
 
+// SetJobEventRepository ตั้งค่า repository สำหรับบันทึก transcode events ลง job_events
+func (pb *ProgressBroadcaster) SetJobEventRepository(repo repositories.JobEventRepository) {
+	pb.jobEventRepo = repo
+}
+
 // Start เริ่ม broadcaster
 func (pb *ProgressBroadcaster) Start() error {
 	pb.runningMu.Lock()
@@ -72,31 +85,22 @@ func (pb *ProgressBroadcaster) handleProgressUpdate(update *ports.ProgressData)
 		return
 	}
 
-	// ตรวจสอบว่าเป็น reel progress
-	isReelProgress := update.ReelID != ""
-	if isReelProgress {
+	switch progressJobType(update) {
+	case ports.ProgressJobReel:
 		pb.handleReelProgress(update)
 		return
-	}
-
-	// ตรวจสอบว่าเป็น subtitle progress หรือ transcode progress หรือ gallery progress หรือ warmcache
-	isSubtitleProgress := update.SubtitleID != "" || update.Stage != ""
-	isGalleryProgress := update.Quality == "gallery"
-	isWarmCacheProgress := update.Quality == "warmcache"
-
-	if isSubtitleProgress {
+	case ports.ProgressJobSubtitle:
 		pb.handleSubtitleProgress(update)
 		return
-	}
-
-	if isGalleryProgress {
+	case ports.ProgressJobGallery:
 		pb.handleGalleryProgress(update)
 		return
-	}
-
-	if isWarmCacheProgress {
+	case ports.ProgressJobWarmCache:
 		pb.handleWarmCacheProgress(update)
 		return
+	case ports.ProgressJobSEO:
+		pb.handleSEOProgress(update)
+		return
 	}
 
 	// === Transcode Progress ===
@@ -131,6 +135,8 @@ func (pb *ProgressBroadcaster) handleProgressUpdate(update *ports.ProgressData)
 		currentStep = "เสร็จสิ้น"
 	} else if status == "failed" {
 		currentStep = "ล้มเหลว"
+	} else if status == ports.ProgressStatusCancelled {
+		currentStep = "ยกเลิกแล้ว"
 	}
 
 	// ดึง video title จาก cache หรือ database
@@ -141,7 +147,7 @@ func (pb *ProgressBroadcaster) handleProgressUpdate(update *ports.ProgressData)
 		VideoID:      update.VideoID,
 		VideoCode:    update.VideoCode,
 		VideoTitle:   videoTitle,
-		Type:         "transcode",
+		Type:         ports.ProgressJobTranscode,
 		Status:       status,
 		Progress:     update.Progress,
 		CurrentStep:  currentStep,
@@ -163,8 +169,11 @@ func (pb *ProgressBroadcaster) handleProgressUpdate(update *ports.ProgressData)
 		"clients_count", pb.manager.GetTotalClients(),
 	)
 
-	// อัพเดท Database เมื่อ status เปลี่ยน (processing, completed, failed)
-	if update.Status == "processing" || update.Status == "completed" || update.Status == "failed" {
+	// บันทึก event log สำหรับดู timeline ภายหลัง
+	pb.recordTranscodeEvent(update, currentStep)
+
+	// อัพเดท Database เมื่อ status เปลี่ยน (processing, completed, failed, cancelled)
+	if update.Status == "processing" || update.Status == "completed" || update.Status == "failed" || update.Status == ports.ProgressStatusCancelled {
 		pb.updateVideoStatus(update)
 	}
 
@@ -198,6 +207,81 @@ func (pb *ProgressBroadcaster) handleProgressUpdate(update *ports.ProgressData)
 	}
 }
 
+// isTerminalProgressStatus job จบแล้ว (ไม่มี progress ตามมาอีก)
+func isTerminalProgressStatus(status string) bool {
+	return status == ports.ProgressStatusCompleted || status == ports.ProgressStatusFailed || status == ports.ProgressStatusCancelled
+}
+
+// progressJobType ประเภท job ของ update - worker ที่ส่ง job_type มาใช้ค่านั้นเลย
+// worker รุ่นเก่า (ไม่มี job_type) เดาจาก field เฉพาะของแต่ละ job
+func progressJobType(update *ports.ProgressData) string {
+	if update.JobType != "" {
+		return update.JobType
+	}
+	switch {
+	case update.ReelID != "":
+		return ports.ProgressJobReel
+	case update.SubtitleID != "" || update.Stage != "":
+		return ports.ProgressJobSubtitle
+	case update.Quality == "gallery":
+		return ports.ProgressJobGallery
+	case update.Quality == "warmcache":
+		return ports.ProgressJobWarmCache
+	default:
+		return ports.ProgressJobTranscode
+	}
+}
+
+// recordTranscodeEvent บันทึก transcode progress ลง job_events (ไม่ critical - log warning ถ้า fail)
+func (pb *ProgressBroadcaster) recordTranscodeEvent(update *ports.ProgressData, currentStep string) {
+	// job จบแล้ว (completed/failed/cancelled) → ล้าง step ล่าสุดก่อนเสมอ ไม่ให้ map โตไปเรื่อยๆ
+	terminal := isTerminalProgressStatus(update.Status)
+	if terminal {
+		pb.cacheMu.Lock()
+		delete(pb.lastStep, update.VideoID)
+		pb.cacheMu.Unlock()
+	}
+
+	if pb.jobEventRepo == nil {
+		return
+	}
+
+	videoID, err := uuid.Parse(update.VideoID)
+	if err != nil {
+		return
+	}
+
+	// บันทึกเฉพาะเมื่อ status/step เปลี่ยน - progress ระหว่าง step เดียวกันไม่ต้องเก็บ
+	if !terminal {
+		stepKey := update.Status + "|" + currentStep
+		pb.cacheMu.Lock()
+		if pb.lastStep[update.VideoID] == stepKey {
+			pb.cacheMu.Unlock()
+			return
+		}
+		pb.lastStep[update.VideoID] = stepKey
+		pb.cacheMu.Unlock()
+	}
+
+	message := currentStep
+	if update.Error != "" {
+		message = update.Error
+	}
+
+	event := &models.JobEvent{
+		VideoID:  videoID,
+		Source:   models.JobEventSourceTranscode,
+		Stage:    update.Status,
+		Progress: update.Progress,
+		Message:  message,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), jobEventWriteTimeout)
+	defer cancel()
+	if err := pb.jobEventRepo.Create(ctx, event); err != nil {
+		logger.Warn("Failed to record transcode event", "video_id", update.VideoID, "error", err)
+	}
+}
+
 // updateVideoStatus อัพเดท video status ใน Database
 func (pb *ProgressBroadcaster) updateVideoStatus(update *ports.ProgressData) {
 	if pb.videoRepo == nil {
@@ -260,6 +344,12 @@ func (pb *ProgressBroadcaster) updateVideoStatus(update *ports.ProgressData) {
 			"video_id", update.VideoID,
 			"worker_id", update.WorkerID,
 		)
+	} else if update.Status == ports.ProgressStatusCancelled {
+		video.Status = models.VideoStatusCancelled
+		logger.Info("Updating video status to cancelled",
+			"video_id", update.VideoID,
+			"worker_id", update.WorkerID,
+		)
 	}
 
 	// บันทึกลง database
@@ -359,8 +449,9 @@ func (pb *ProgressBroadcaster) handleSubtitleProgress(update *ports.ProgressData
 		VideoID:      update.VideoID,
 		VideoCode:    update.VideoCode,
 		VideoTitle:   videoTitle,
-		Type:         "subtitle",
+		Type:         ports.ProgressJobSubtitle,
 		Status:       status,
+		Stage:        update.Stage,
 		Progress:     update.Progress,
 		CurrentStep:  currentStep,
 		Message:      update.Message,
@@ -398,8 +489,9 @@ func (pb *ProgressBroadcaster) handleGalleryProgress(update *ports.ProgressData)
 		VideoID:      update.VideoID,
 		VideoCode:    update.VideoCode,
 		VideoTitle:   videoTitle,
-		Type:         "gallery",
+		Type:         ports.ProgressJobGallery,
 		Status:       status,
+		Stage:        galleryStageForProgress(update),
 		Progress:     update.Progress,
 		CurrentStep:  update.Message,
 		Message:      update.Message,
@@ -418,6 +510,29 @@ func (pb *ProgressBroadcaster) handleGalleryProgress(update *ports.ProgressData)
 	)
 }
 
+// galleryStageForProgress stage ของ gallery job - worker ส่ง stage มาใช้เลย
+// ไม่ส่ง = เดาจากช่วง % ที่ gallery worker ใช้ (5 วิเคราะห์, 10-84 ดึงภาพ/classify, 85 อัพโหลด, 95 บันทึก)
+func galleryStageForProgress(update *ports.ProgressData) string {
+	if update.Stage != "" {
+		return update.Stage
+	}
+	switch {
+	case update.Status == ports.ProgressStatusCompleted || update.Status == ports.ProgressStatusFailed ||
+		update.Status == ports.ProgressStatusCancelled:
+		return update.Status
+	case update.Progress < 5:
+		return "starting"
+	case update.Progress < 10:
+		return "analyzing"
+	case update.Progress < 85:
+		return "extracting"
+	case update.Progress < 95:
+		return "uploading"
+	default:
+		return "saving"
+	}
+}
+
 // handleWarmCacheProgress จัดการ warm cache progress update
 func (pb *ProgressBroadcaster) handleWarmCacheProgress(update *ports.ProgressData) {
 	// Map status
@@ -434,7 +549,7 @@ func (pb *ProgressBroadcaster) handleWarmCacheProgress(update *ports.ProgressDat
 		VideoID:      update.VideoID,
 		VideoCode:    update.VideoCode,
 		VideoTitle:   videoTitle,
-		Type:         "warmcache",
+		Type:         ports.ProgressJobWarmCache,
 		Status:       status,
 		Progress:     update.Progress,
 		CurrentStep:  update.Message,
@@ -454,6 +569,59 @@ func (pb *ProgressBroadcaster) handleWarmCacheProgress(update *ports.ProgressDat
 	)
 }
 
+// handleSEOProgress จัดการ SEO article progress update (จาก seo-worker)
+// stage ละเอียด (fetching_data, ai_processing, ...) ส่งต่อให้ frontend ใน Stage
+func (pb *ProgressBroadcaster) handleSEOProgress(update *ports.ProgressData) {
+	status := update.Status
+	if status == "" {
+		status = ports.ProgressStatusProcessing
+	}
+
+	currentStep := update.Message
+	if currentStep == "" {
+		switch update.Stage {
+		case "fetching_data", "data_fetched":
+			currentStep = "กำลังดึงข้อมูล"
+		case "ai_processing", "ai_completed":
+			currentStep = "กำลังเขียนบทความด้วย AI"
+		case "tts_embedding", "tts_embedding_completed":
+			currentStep = "กำลังสร้างเสียงและ embedding"
+		case "publishing":
+			currentStep = "กำลัง publish"
+		case "completed":
+			currentStep = "เสร็จสิ้น"
+		case "failed":
+			currentStep = "ล้มเหลว"
+		case ports.ProgressStatusCancelled:
+			currentStep = "ยกเลิกแล้ว"
+		default:
+			currentStep = update.Stage
+		}
+	}
+
+	wsMessage := ProgressMessage{
+		VideoID:      update.VideoID,
+		VideoCode:    update.VideoCode,
+		VideoTitle:   pb.getVideoTitle(update.VideoID, update.VideoCode),
+		Type:         ports.ProgressJobSEO,
+		Status:       status,
+		Stage:        update.Stage,
+		Progress:     update.Progress,
+		CurrentStep:  currentStep,
+		Message:      update.Message,
+		ErrorMessage: update.Error,
+	}
+
+	pb.manager.BroadcastToAll("video_progress", wsMessage)
+
+	logger.Info("SEO progress broadcasted to WebSocket",
+		"video_id", update.VideoID,
+		"stage", update.Stage,
+		"progress", update.Progress,
+		"clients_count", pb.manager.GetTotalClients(),
+	)
+}
+
 // handleReelProgress จัดการ reel progress update
 func (pb *ProgressBroadcaster) handleReelProgress(update *ports.ProgressData) {
 	// Map status
@@ -466,7 +634,7 @@ func (pb *ProgressBroadcaster) handleReelProgress(update *ports.ProgressData) {
 	wsMessage := ReelProgressMessage{
 		ReelID:       update.ReelID,
 		VideoCode:    update.VideoCode,
-		Type:         "reel",
+		Type:         ports.ProgressJobReel,
 		Status:       status,
 		Progress:     update.Progress,
 		CurrentStep:  update.Message,
@@ -528,10 +696,11 @@ type ProgressMessage struct {
 	VideoID      string  `json:"videoId"`
 	VideoCode    string  `json:"videoCode"`
 	VideoTitle   string  `json:"videoTitle"`
-	Type         string  `json:"type"`         // "upload", "transcode", or "subtitle"
-	Status       string  `json:"status"`       // "started", "processing", "completed", "failed"
-	Progress     float64 `json:"progress"`     // 0-100
-	CurrentStep  string  `json:"currentStep"`  // เช่น "uploading", "transcoding", "generating_thumbnail"
+	Type         string  `json:"type"`            // "upload", "transcode", "subtitle", "gallery", "warmcache", "seo"
+	Status       string  `json:"status"`          // "started", "processing", "completed", "failed"
+	Stage        string  `json:"stage,omitempty"` // ขั้นตอนละเอียดของ job (subtitle/seo stage)
+	Progress     float64 `json:"progress"`        // 0-100
+	CurrentStep  string  `json:"currentStep"`     // เช่น "uploading", "transcoding", "generating_thumbnail"
 	Message      string  `json:"message"`
 	ErrorMessage string  `json:"errorMessage,omitempty"`
 	Quality      string  `json:"quality,omitempty"`
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"gofiber-template/domain/services"
 	natspkg "gofiber-template/infrastructure/nats"
 	"gofiber-template/pkg/logger"
+	"gofiber-template/pkg/settings"
 	"gofiber-template/pkg/utils"
 )
 
@@ -151,6 +153,13 @@ func (h *DirectUploadHandler) InitUpload(c *fiber.Ctx) error {
 		}
 	}
 
+	// บันทึกว่า user ไหนขอ video code นี้ - notify ตรวจก่อนผูกไฟล์กับ video record
+	if _, err := h.storage.UploadFile(strings.NewReader(user.ID.String()), uploadOwnerPath(videoCode), "text/plain"); err != nil {
+		logger.ErrorContext(ctx, "Failed to record upload owner", "video_code", videoCode, "error", err)
+		h.storage.AbortMultipartUpload(path, uploadID)
+		return utils.InternalServerErrorResponse(c)
+	}
+
 	// NOTE: ไม่สร้าง video record ตรงนี้แล้ว
 	// จะสร้างตอน CompleteUpload เพื่อหลีกเลี่ยง StuckDetector timeout
 	// Frontend จะเก็บ videoCode, path, title ไว้ส่งมาตอน complete
@@ -249,6 +258,8 @@ func (h *DirectUploadHandler) CompleteUpload(c *fiber.Ctx) error {
 		return utils.InternalServerErrorResponse(c)
 	}
 
+	h.deleteUploadOwner(ctx, video.Code)
+
 	logger.InfoContext(ctx, "Direct upload completed",
 		"video_id", video.ID,
 		"video_code", video.Code,
@@ -256,41 +267,163 @@ func (h *DirectUploadHandler) CompleteUpload(c *fiber.Ctx) error {
 	)
 
 	// Auto-queue สำหรับ transcode
-	autoEnqueued := false
-	if h.isAutoQueueEnabled(ctx) && h.natsPublisher != nil {
-		inputPath := video.OriginalPath
-		outputPath := "hls/" + video.Code + "/"
-		qualities := h.getDefaultQualities(ctx)
+	autoEnqueued := h.autoQueueTranscode(ctx, video)
 
-		if err := h.natsPublisher.EnqueueTranscode(ctx, video.ID.String(), video.Code, inputPath, outputPath, "h264", qualities, false); err != nil {
-			logger.WarnContext(ctx, "Auto-queue failed, video remains pending",
-				"video_id", video.ID,
-				"video_code", video.Code,
-				"error", err,
-			)
+	return utils.SuccessResponse(c, dto.CompleteDirectUploadResponse{
+		VideoID:      video.ID,
+		VideoCode:    video.Code,
+		Title:        video.Title,
+		Status:       string(models.VideoStatusQueued),
+		AutoEnqueued: autoEnqueued,
+	})
+}
+
+// NotifyDirectUploadComplete รับแจ้งจาก browser ว่าอัปโหลดผ่าน presigned URL เสร็จแล้ว
+// ตรวจไฟล์จริงใน storage → สร้าง video record → auto-queue transcode
+// POST /api/v1/direct-upload/notify
+func (h *DirectUploadHandler) NotifyDirectUploadComplete(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		logger.WarnContext(ctx, "Unauthorized access attempt")
+		return utils.UnauthorizedResponse(c, "")
+	}
+
+	var req dto.NotifyDirectUploadCompleteRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.WarnContext(ctx, "Invalid request body", "error", err)
+		return utils.BadRequestResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		logger.WarnContext(ctx, "Validation failed", "errors", errors)
+		return utils.ValidationErrorResponse(c, errors)
+	}
+
+	// path ต้องอยู่ใต้ videos/{code}/ ตามที่ InitUpload สร้างให้ (กันผูก object อื่นใน bucket)
+	if !strings.HasPrefix(req.Path, fmt.Sprintf("videos/%s/", req.VideoCode)) || strings.Contains(req.Path, "..") {
+		logger.WarnContext(ctx, "Invalid upload path", "path", req.Path, "video_code", req.VideoCode)
+		return utils.BadRequestResponse(c, "Invalid upload path")
+	}
+
+	// video code ซ้ำ = แจ้งซ้ำ (retry จาก browser) ไม่สร้าง record ใหม่
+	if existing, err := h.videoService.GetByCode(ctx, req.VideoCode); err == nil && existing != nil {
+		logger.WarnContext(ctx, "Video already registered for upload", "video_code", req.VideoCode)
+		return utils.ConflictResponse(c, "Video already registered")
+	}
+
+	// video code ต้องเป็นของ user ที่เรียก InitUpload (กันผูกไฟล์ของ user อื่นเป็นของตัวเอง)
+	if !h.isUploadOwner(ctx, req.VideoCode, user.ID) {
+		logger.WarnContext(ctx, "Upload code not issued to user", "video_code", req.VideoCode, "user_id", user.ID)
+		return utils.ForbiddenResponse(c, "Upload was not initiated by this user")
+	}
+
+	// นามสกุลต้องอยู่ใน allowlist เดียวกับ upload ปกติ (ทั้งชื่อไฟล์และ path ที่ worker จะ transcode)
+	allowedExts := allowedVideoExtensions(ctx, h.settingService)
+	if !utils.IsVideoExtension(req.Filename, allowedExts) || !utils.IsVideoExtension(req.Path, allowedExts) {
+		logger.WarnContext(ctx, "Invalid video extension", "filename", req.Filename, "path", req.Path)
+		h.deleteOrphanedUpload(ctx, req.Path)
+		return utils.BadRequestResponse(c, fmt.Sprintf("%s: extension is not allowed", utils.ErrInvalidVideoFormat))
+	}
+
+	// ตรวจว่าไฟล์อยู่ใน storage จริง
+	info, err := h.storage.StatObject(req.Path)
+	if err != nil {
+		if errors.Is(err, ports.ErrObjectNotFound) {
+			logger.WarnContext(ctx, "Uploaded object not found", "path", req.Path)
+			return utils.NotFoundResponse(c, "Uploaded file not found in storage")
+		}
+		logger.ErrorContext(ctx, "Failed to stat uploaded object", "path", req.Path, "error", err)
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	if info.Size == 0 {
+		h.deleteOrphanedUpload(ctx, req.Path)
+		return utils.BadRequestResponse(c, "Empty file not allowed")
+	}
+
+	// ตรวจขนาดจริง (presigned URL ไม่จำกัดขนาดให้)
+	maxFileSize := h.getMaxUploadSize(ctx)
+	if info.Size > maxFileSize {
+		logger.WarnContext(ctx, "Uploaded file too large", "size", info.Size, "max", maxFileSize)
+		h.deleteOrphanedUpload(ctx, req.Path)
+		return utils.BadRequestResponse(c, fmt.Sprintf("File too large. Maximum size is %d GB", maxFileSize/(1024*1024*1024)))
+	}
+
+	// ตรวจ signature จาก bytes แรกของไฟล์จริงใน storage (ไม่เชื่อชื่อไฟล์/Content-Type จาก browser)
+	if err := h.validateUploadedContent(req.Path); err != nil {
+		if errors.Is(err, utils.ErrInvalidVideoFormat) {
+			logger.WarnContext(ctx, "Uploaded file is not video", "path", req.Path, "error", err)
+			h.deleteOrphanedUpload(ctx, req.Path)
+			return utils.BadRequestResponse(c, err.Error())
+		}
+		logger.ErrorContext(ctx, "Failed to read uploaded object", "path", req.Path, "error", err)
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	// ตรวจ quota หลังอัปโหลด (post-hoc) รวมขนาดไฟล์นี้ด้วย - เกินแล้วลบไฟล์ทิ้ง ไม่สร้าง record
+	if err := h.videoService.CheckStorageQuotaFor(ctx, info.Size); err != nil {
+		if errors.Is(err, serviceimpl.ErrStorageQuotaExceeded) {
+			logger.WarnContext(ctx, "Storage quota exceeded after upload", "user_id", user.ID, "size", info.Size)
+			h.deleteOrphanedUpload(ctx, req.Path)
+			return utils.ErrorResponse(c, fiber.StatusPaymentRequired, "STORAGE_QUOTA_EXCEEDED",
+				"พื้นที่เก็บข้อมูลเต็ม กรุณาลบวิดีโอเก่าหรือติดต่อทีมงาน", nil)
+		}
+		logger.ErrorContext(ctx, "Failed to check storage quota", "error", err)
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	title := req.Title
+	if title == "" {
+		title = strings.TrimSuffix(req.Filename, getFileExtension(req.Filename))
+	}
+
+	video := &models.Video{
+		ID:           uuid.New(),
+		Code:         req.VideoCode,
+		Title:        title,
+		Description:  req.Description,
+		UserID:       user.ID,
+		Status:       models.VideoStatusPending,
+		OriginalPath: req.Path,
+		OriginalSize: info.Size,
+	}
+
+	if req.Category != "" && h.categoryService != nil {
+		category, err := h.categoryService.GetOrCreateByName(ctx, req.Category)
+		if err != nil {
+			logger.WarnContext(ctx, "Failed to get/create category", "category", req.Category, "error", err)
 		} else {
-			// Update status to queued
-			if updateErr := h.videoService.UpdateVideoStatus(ctx, video.ID, models.VideoStatusQueued); updateErr != nil {
-				logger.WarnContext(ctx, "Failed to update video status to queued",
-					"video_id", video.ID,
-					"error", updateErr,
-				)
-			} else {
-				autoEnqueued = true
-				logger.InfoContext(ctx, "Video auto-queued for transcoding",
-					"video_id", video.ID,
-					"video_code", video.Code,
-					"qualities", qualities,
-				)
-			}
+			video.CategoryID = &category.ID
 		}
 	}
 
+	if err := h.videoService.CreateVideo(ctx, video); err != nil {
+		logger.ErrorContext(ctx, "Failed to create video record", "error", err)
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	h.deleteUploadOwner(ctx, video.Code)
+
+	logger.InfoContext(ctx, "Direct upload registered",
+		"video_id", video.ID,
+		"video_code", video.Code,
+		"size", info.Size,
+	)
+
+	autoEnqueued := h.autoQueueTranscode(ctx, video)
+	status := models.VideoStatusPending
+	if autoEnqueued {
+		status = models.VideoStatusQueued
+	}
+
 	return utils.SuccessResponse(c, dto.CompleteDirectUploadResponse{
 		VideoID:      video.ID,
 		VideoCode:    video.Code,
 		Title:        video.Title,
-		Status:       string(models.VideoStatusQueued),
+		Status:       string(status),
 		AutoEnqueued: autoEnqueued,
 	})
 }
@@ -341,6 +474,87 @@ func (h *DirectUploadHandler) AbortUpload(c *fiber.Ctx) error {
 
 // Helper functions
 
+// autoQueueTranscode ส่ง video เข้าคิว transcode (ถ้าเปิด auto-queue) - คืน true ถ้าเข้าคิวสำเร็จ
+func (h *DirectUploadHandler) autoQueueTranscode(ctx context.Context, video *models.Video) bool {
+	autoEnqueued := false
+	if h.isAutoQueueEnabled(ctx) && h.natsPublisher != nil {
+		inputPath := video.OriginalPath
+		outputPath := "hls/" + video.Code + "/"
+		qualities := h.getDefaultQualities(ctx)
+
+		if err := h.natsPublisher.EnqueueTranscode(ctx, video.ID.String(), video.Code, inputPath, outputPath, "h264", qualities, false); err != nil {
+			logger.WarnContext(ctx, "Auto-queue failed, video remains pending",
+				"video_id", video.ID,
+				"video_code", video.Code,
+				"error", err,
+			)
+		} else {
+			// Update status to queued
+			if updateErr := h.videoService.UpdateVideoStatus(ctx, video.ID, models.VideoStatusQueued); updateErr != nil {
+				logger.WarnContext(ctx, "Failed to update video status to queued",
+					"video_id", video.ID,
+					"error", updateErr,
+				)
+			} else {
+				autoEnqueued = true
+				logger.InfoContext(ctx, "Video auto-queued for transcoding",
+					"video_id", video.ID,
+					"video_code", video.Code,
+					"qualities", qualities,
+				)
+			}
+		}
+	}
+
+	return autoEnqueued
+}
+
+// uploadOwnerPath marker ที่ InitUpload เขียน user ID ที่ขอ video code ไว้
+func uploadOwnerPath(videoCode string) string {
+	return fmt.Sprintf("videos/%s/.upload-owner", videoCode)
+}
+
+// isUploadOwner video code ถูกออกให้ userID ตอน InitUpload หรือไม่ (ไม่มี marker = ไม่ใช่)
+func (h *DirectUploadHandler) isUploadOwner(ctx context.Context, videoCode string, userID uuid.UUID) bool {
+	reader, _, err := h.storage.GetFileContent(uploadOwnerPath(videoCode))
+	if err != nil {
+		logger.WarnContext(ctx, "Upload owner marker not readable", "video_code", videoCode, "error", err)
+		return false
+	}
+	defer reader.Close()
+
+	owner, err := io.ReadAll(io.LimitReader(reader, 64))
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(owner)) == userID.String()
+}
+
+// deleteUploadOwner ลบ marker หลังสร้าง video record แล้ว
+func (h *DirectUploadHandler) deleteUploadOwner(ctx context.Context, videoCode string) {
+	if err := h.storage.DeleteFile(uploadOwnerPath(videoCode)); err != nil {
+		logger.WarnContext(ctx, "Failed to delete upload owner marker", "video_code", videoCode, "error", err)
+	}
+}
+
+// validateUploadedContent อ่าน bytes แรกของ object ผ่าน storage แล้วตรวจ signature วิดีโอ
+func (h *DirectUploadHandler) validateUploadedContent(path string) error {
+	reader, _, err := h.storage.GetFileRange(path, 0, utils.VideoSniffLength-1)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	return utils.ValidateVideoContent(reader)
+}
+
+// deleteOrphanedUpload ลบไฟล์ที่อัปโหลดแล้วแต่ไม่ผ่านการตรวจ (ไม่ให้ค้างใน bucket)
+func (h *DirectUploadHandler) deleteOrphanedUpload(ctx context.Context, path string) {
+	if err := h.storage.DeleteFile(path); err != nil {
+		logger.WarnContext(ctx, "Failed to delete rejected upload", "path", path, "error", err)
+	}
+}
+
 func (h *DirectUploadHandler) getDefaultQualities(ctx context.Context) []string {
 	defaultQualities := []string{"1080p", "720p", "480p"}
 
@@ -353,16 +567,9 @@ func (h *DirectUploadHandler) getDefaultQualities(ctx context.Context) []string
 		return defaultQualities
 	}
 
-	parts := strings.Split(qualitiesStr, ",")
-	var qualities []string
-	for _, p := range parts {
-		q := strings.TrimSpace(p)
-		if q != "" {
-			qualities = append(qualities, q)
-		}
-	}
-
-	if len(qualities) == 0 {
+	qualities, err := settings.ParseQualities(qualitiesStr)
+	if err != nil {
+		logger.WarnContext(ctx, "Invalid qualities in settings, using defaults", "raw_value", qualitiesStr, "error", err, "qualities", defaultQualities)
 		return defaultQualities
 	}
 
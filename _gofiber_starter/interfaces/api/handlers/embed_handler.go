@@ -82,7 +82,9 @@ func (h *EmbedHandler) ServeEmbed(c *fiber.Ctx) error {
 		streamURLH264 = fmt.Sprintf("%s/stream/%s/h264/master.m3u8", baseURL, video.Code)
 	}
 
-	thumbnailURL := video.ThumbnailURL
+	// poster ตาม format ที่ browser รองรับ (Accept ของ navigation request มี image/avif, image/webp)
+	thumbnailURL := video.ThumbnailForAccept(c.Get(fiber.HeaderAccept))
+	c.Vary(fiber.HeaderAccept)
 	if thumbnailURL == "" {
 		thumbnailURL = fmt.Sprintf("%s/stream/%s/thumb", baseURL, video.Code)
 	}
@@ -144,6 +146,7 @@ func (h *EmbedHandler) GetEmbedInfo(c *fiber.Ctx) error {
 		"duration":     video.Duration,
 		"quality":      video.Quality,
 		"thumbnail":    video.ThumbnailURL,
+		"thumbnailVariants": video.ThumbnailVariants,
 		"streamUrl":    fmt.Sprintf("%s/stream/%s/master.m3u8", baseURL, video.Code),
 		"streamUrlH264": func() string {
 			if video.HasH264Fallback() {
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path/filepath"
 	"strings"
@@ -10,6 +11,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 
+	"gofiber-template/application/serviceimpl"
 	"gofiber-template/domain/dto"
 	"gofiber-template/domain/ports"
 	"gofiber-template/domain/services"
@@ -20,17 +22,25 @@ import (
 // GalleryAdminHandler จัดการ gallery สำหรับ Admin
 // ใช้ manual selection flow: source → safe/nsfw
 type GalleryAdminHandler struct {
-	videoService services.VideoService
-	storage      ports.StoragePort
+	videoService     services.VideoService
+	statsService     services.GalleryStatsService
+	storage          ports.StoragePort
+	reconcileService services.GalleryReconcileService // nil = ปิด endpoint reconcile
 }
 
-func NewGalleryAdminHandler(videoService services.VideoService, storage ports.StoragePort) *GalleryAdminHandler {
+func NewGalleryAdminHandler(videoService services.VideoService, statsService services.GalleryStatsService, storage ports.StoragePort) *GalleryAdminHandler {
 	return &GalleryAdminHandler{
 		videoService: videoService,
+		statsService: statsService,
 		storage:      storage,
 	}
 }
 
+// SetReconcileService เปิด endpoint reconcile gallery counts
+func (h *GalleryAdminHandler) SetReconcileService(reconcileService services.GalleryReconcileService) {
+	h.reconcileService = reconcileService
+}
+
 // === Request/Response DTOs ===
 
 // GalleryImage ข้อมูลภาพใน gallery
@@ -42,14 +52,15 @@ type GalleryImage struct {
 
 // GalleryImagesResponse รายการภาพทั้งหมดใน gallery
 type GalleryImagesResponse struct {
-	VideoCode   string         `json:"videoCode"`
-	Status      string         `json:"status"` // none, processing, pending_review, ready
-	Source      []GalleryImage `json:"source"`
-	Safe        []GalleryImage `json:"safe"`
-	Nsfw        []GalleryImage `json:"nsfw"`
-	SourceCount int            `json:"sourceCount"`
-	SafeCount   int            `json:"safeCount"`
-	NsfwCount   int            `json:"nsfwCount"`
+	VideoCode     string         `json:"videoCode"`
+	Status        string         `json:"status"` // none, processing, pending_review, ready
+	Source        []GalleryImage `json:"source"`
+	Safe          []GalleryImage `json:"safe"`
+	Nsfw          []GalleryImage `json:"nsfw"`
+	SourceCount   int            `json:"sourceCount"`
+	SafeCount     int            `json:"safeCount"`
+	NsfwCount     int            `json:"nsfwCount"`
+	CoverOverride string         `json:"coverOverride,omitempty"` // SEO cover override (filename ใน safe/ หรือ URL)
 }
 
 // MoveImageRequest ย้ายภาพเดี่ยว
@@ -66,6 +77,12 @@ type MoveBatchRequest struct {
 	To    string   `json:"to" validate:"required,oneof=source safe nsfw"`
 }
 
+// SetCoverRequest กำหนด cover override สำหรับ SEO article (ระบุอย่างใดอย่างหนึ่ง)
+type SetCoverRequest struct {
+	Filename string `json:"filename" validate:"omitempty,max=255"` // ชื่อไฟล์ใน safe/
+	URL      string `json:"url" validate:"omitempty,url,max=2000"` // external URL
+}
+
 // === Handlers ===
 
 // GetGalleryImages ดึงรายการภาพทั้งหมดใน gallery (พร้อม presigned URLs)
@@ -116,14 +133,15 @@ func (h *GalleryAdminHandler) GetGalleryImages(c *fiber.Ctx) error {
 	)
 
 	return utils.SuccessResponse(c, GalleryImagesResponse{
-		VideoCode:   video.Code,
-		Status:      video.GalleryStatus,
-		Source:      sourceImages,
-		Safe:        safeImages,
-		Nsfw:        nsfwImages,
-		SourceCount: len(sourceImages),
-		SafeCount:   len(safeImages),
-		NsfwCount:   len(nsfwImages),
+		VideoCode:     video.Code,
+		Status:        video.GalleryStatus,
+		Source:        sourceImages,
+		Safe:          safeImages,
+		Nsfw:          nsfwImages,
+		SourceCount:   len(sourceImages),
+		SafeCount:     len(safeImages),
+		NsfwCount:     len(nsfwImages),
+		CoverOverride: video.GalleryCoverOverride,
 	})
 }
 
@@ -323,8 +341,195 @@ func (h *GalleryAdminHandler) PublishGallery(c *fiber.Ctx) error {
 	})
 }
 
+// SetCover กำหนด cover image override สำหรับ SEO article
+// PUT /api/v1/admin/videos/:id/gallery/cover
+func (h *GalleryAdminHandler) SetCover(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	idParam := c.Params("id")
+
+	videoID, err := uuid.Parse(idParam)
+	if err != nil {
+		return utils.BadRequestResponse(c, "Invalid video ID")
+	}
+
+	var req SetCoverRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.BadRequestResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.ValidationErrorResponse(c, utils.GetValidationErrors(err))
+	}
+
+	// ต้องระบุอย่างใดอย่างหนึ่ง (filename หรือ url)
+	if (req.Filename == "") == (req.URL == "") {
+		return utils.BadRequestResponse(c, "Specify exactly one of filename or url")
+	}
+
+	video, err := h.videoService.GetByID(ctx, videoID)
+	if err != nil {
+		return utils.NotFoundResponse(c, "Video not found")
+	}
+
+	coverOverride := req.URL
+	if req.Filename != "" {
+		// ป้องกัน path traversal - รับเฉพาะชื่อไฟล์
+		if req.Filename != filepath.Base(req.Filename) || strings.Contains(req.Filename, "..") {
+			return utils.BadRequestResponse(c, "Invalid filename")
+		}
+
+		if video.GalleryPath == "" {
+			return utils.BadRequestResponse(c, "Video has no gallery")
+		}
+
+		// ตรวจสอบว่าไฟล์อยู่ใน safe/ จริง (cover ต้องเป็นภาพ public)
+		if !h.galleryFileExists(video.GalleryPath, "safe", req.Filename) {
+			return utils.BadRequestResponse(c, "Filename not found in safe gallery")
+		}
+		coverOverride = req.Filename
+	}
+
+	updateReq := &dto.UpdateVideoRequest{
+		GalleryCoverOverride: &coverOverride,
+	}
+	if _, err := h.videoService.Update(ctx, videoID, updateReq); err != nil {
+		logger.ErrorContext(ctx, "Failed to set gallery cover", "video_id", videoID, "error", err)
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	logger.InfoContext(ctx, "Gallery cover override set",
+		"video_id", videoID,
+		"cover", coverOverride,
+	)
+
+	return utils.SuccessResponse(c, fiber.Map{
+		"message":       "Cover override set successfully",
+		"coverOverride": coverOverride,
+	})
+}
+
+// ClearCover ลบ cover override (กลับไปให้ SEO worker เลือกเอง)
+// DELETE /api/v1/admin/videos/:id/gallery/cover
+func (h *GalleryAdminHandler) ClearCover(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	idParam := c.Params("id")
+
+	videoID, err := uuid.Parse(idParam)
+	if err != nil {
+		return utils.BadRequestResponse(c, "Invalid video ID")
+	}
+
+	if _, err := h.videoService.GetByID(ctx, videoID); err != nil {
+		return utils.NotFoundResponse(c, "Video not found")
+	}
+
+	empty := ""
+	updateReq := &dto.UpdateVideoRequest{
+		GalleryCoverOverride: &empty,
+	}
+	if _, err := h.videoService.Update(ctx, videoID, updateReq); err != nil {
+		logger.ErrorContext(ctx, "Failed to clear gallery cover", "video_id", videoID, "error", err)
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	logger.InfoContext(ctx, "Gallery cover override cleared", "video_id", videoID)
+
+	return utils.SuccessResponse(c, fiber.Map{
+		"message": "Cover override cleared",
+	})
+}
+
+// ReconcileCounts แก้ gallery counts ใน DB ให้ตรงกับไฟล์จริงใน storage
+// POST /api/v1/admin/videos/:id/gallery/reconcile
+func (h *GalleryAdminHandler) ReconcileCounts(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	if h.reconcileService == nil {
+		return utils.ErrorResponse(c, fiber.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "Gallery reconcile not available", nil)
+	}
+
+	videoID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.BadRequestResponse(c, "Invalid video ID")
+	}
+
+	result, err := h.reconcileService.ReconcileVideo(ctx, videoID)
+	if err != nil {
+		switch {
+		case errors.Is(err, serviceimpl.ErrVideoNotFound):
+			return utils.NotFoundResponse(c, "Video not found")
+		case errors.Is(err, serviceimpl.ErrVideoHasNoGallery):
+			return utils.BadRequestResponse(c, "Video has no gallery")
+		}
+		logger.ErrorContext(ctx, "Failed to reconcile gallery counts", "video_id", videoID, "error", err)
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	return utils.SuccessResponse(c, result)
+}
+
+// ReconcileAllCounts เริ่ม reconcile gallery counts ของทุกวิดีโอที่มี gallery ใน background
+// POST /api/v1/admin/gallery/reconcile → 202 (ผลลัพธ์อยู่ใน log)
+func (h *GalleryAdminHandler) ReconcileAllCounts(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	if h.reconcileService == nil {
+		return utils.ErrorResponse(c, fiber.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "Gallery reconcile not available", nil)
+	}
+
+	if err := h.reconcileService.StartReconcileAll(ctx); err != nil {
+		if errors.Is(err, serviceimpl.ErrGalleryReconcileRunning) {
+			return utils.ConflictResponse(c, "Gallery reconcile already running")
+		}
+		logger.ErrorContext(ctx, "Failed to start gallery reconcile", "error", err)
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	return utils.AcceptedResponse(c, fiber.Map{
+		"message": "Gallery reconcile started",
+	})
+}
+
+// GetClassificationStats สถิติ classification ของ gallery ทุก video
+// (จำนวน/ค่าเฉลี่ยแยกตาม tier + ภาพ super_safe ที่ nsfw_score สูงผิดปกติ)
+// GET /api/v1/admin/gallery/classification-stats?minNsfwScore=0.1&limit=50
+func (h *GalleryAdminHandler) GetClassificationStats(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	var req dto.ClassificationStatsRequest
+	if err := c.QueryParser(&req); err != nil {
+		return utils.BadRequestResponse(c, "Invalid query parameters")
+	}
+	if err := utils.ValidateStruct(&req); err != nil {
+		return utils.ValidationErrorResponse(c, utils.GetValidationErrors(err))
+	}
+
+	stats, err := h.statsService.GetClassificationStats(ctx, &req)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to get classification stats", "error", err)
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	return utils.SuccessResponse(c, stats)
+}
+
 // === Helper Functions ===
 
+// galleryFileExists ตรวจสอบว่ามีไฟล์อยู่ใน folder ของ gallery
+func (h *GalleryAdminHandler) galleryFileExists(basePath, folder, filename string) bool {
+	basePath = strings.TrimSuffix(basePath, "/")
+	files, err := h.storage.ListFiles(fmt.Sprintf("%s/%s", basePath, folder))
+	if err != nil {
+		return false
+	}
+	for _, filePath := range files {
+		if filepath.Base(filePath) == filename {
+			return true
+		}
+	}
+	return false
+}
+
 // listFolderImages list ภาพใน folder และสร้าง presigned URLs
 func (h *GalleryAdminHandler) listFolderImages(basePath, folder string, expiry time.Duration) []GalleryImage {
 	// Remove trailing slash from basePath to avoid double slash
@@ -25,12 +25,17 @@ type Services struct {
 	SubtitleService    services.SubtitleService  // Subtitle management
 	QueueService       services.QueueService     // Queue management (transcode/subtitle/warmcache)
 	ReelService        services.ReelService      // Reel Generator
+	JobEventService    services.JobEventService  // Pipeline event log (job timeline)
+	RelatedVideoService services.RelatedVideoService // Related videos (embedding similarity)
+	GalleryReconcileService services.GalleryReconcileService // แก้ gallery counts จากไฟล์จริง
+	GalleryStatsService     services.GalleryStatsService     // สถิติ classification ของ gallery
 	VideoRepository    repositories.VideoRepository // สำหรับ SubtitleHandler
 	StreamCookieService     *serviceimpl.StreamCookieService         // Signed cookie สำหรับ CDN access
 	NATSPublisher           *natspkg.Publisher                       // NATS JetStream publisher (แทน AsynqClient)
 	GoogleConfig       config.GoogleOAuthConfig
 	StorageBasePath    string // สำหรับ VideoHandler (legacy)
 	StorageType        string // "local" หรือ "s3"
+	GalleryQuality     string // HLS variant ที่ต้องการสำหรับ gallery ("" = สูงสุด)
 	BaseURL            string // Base URL สำหรับ embed URLs
 	CDNBaseURL         string // Cloudflare Worker URL สำหรับ HLS streaming
 	JWTSecret          string // JWT Secret สำหรับ stream access token
@@ -58,17 +63,25 @@ type Handlers struct {
 	DirectUploadHandler  *DirectUploadHandler             // Direct Upload via Presigned URL
 	ReelHandler          *ReelHandler                     // Reel Generator
 	GalleryAdminHandler  *GalleryAdminHandler             // Gallery Manual Selection (Admin)
+	JobEventHandler      *JobEventHandler                 // Pipeline event log (job timeline)
+	RelatedVideoHandler  *RelatedVideoHandler             // Related videos (embedding similarity)
 	StreamCookieService  *serviceimpl.StreamCookieService // Signed cookie สำหรับ CDN access
 }
 
 // NewHandlers creates a new instance of Handlers with all dependencies
 func NewHandlers(services *Services) *Handlers {
+	videoHandler := NewVideoHandler(services.VideoService, services.TranscodingService, services.SettingService, services.NATSPublisher, services.StoragePort, services.StorageBasePath, services.StorageType)
+	videoHandler.SetGalleryQuality(services.GalleryQuality)
+
+	galleryAdminHandler := NewGalleryAdminHandler(services.VideoService, services.GalleryStatsService, services.StoragePort)
+	galleryAdminHandler.SetReconcileService(services.GalleryReconcileService)
+
 	return &Handlers{
 		UserHandler:          NewUserHandler(services.UserService),
 		TaskHandler:          NewTaskHandler(services.TaskService),
 		FileHandler:          NewFileHandler(services.FileService),
 		JobHandler:           NewJobHandler(services.JobService),
-		VideoHandler:         NewVideoHandler(services.VideoService, services.TranscodingService, services.SettingService, services.NATSPublisher, services.StoragePort, services.StorageBasePath, services.StorageType),
+		VideoHandler:         videoHandler,
 		CategoryHandler:      NewCategoryHandler(services.CategoryService),
 		AuthHandler:          NewAuthHandler(services.UserService, services.GoogleConfig),
 		TranscodingHandler:   NewTranscodingHandler(services.VideoService, services.SettingService, services.NATSPublisher),
@@ -83,7 +96,9 @@ func NewHandlers(services *Services) *Handlers {
 		QueueHandler:         NewQueueHandler(services.QueueService),
 		DirectUploadHandler:  NewDirectUploadHandler(services.StoragePort, services.VideoService, services.SettingService, services.CategoryService, services.NATSPublisher),
 		ReelHandler:          NewReelHandler(services.ReelService),
-		GalleryAdminHandler:  NewGalleryAdminHandler(services.VideoService, services.StoragePort),
+		GalleryAdminHandler:  galleryAdminHandler,
+		JobEventHandler:      NewJobEventHandler(services.JobEventService),
+		RelatedVideoHandler:  NewRelatedVideoHandler(services.RelatedVideoService),
 		StreamCookieService:  services.StreamCookieService,
 	}
 }
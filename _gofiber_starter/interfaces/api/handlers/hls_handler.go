@@ -4,16 +4,21 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
 	"gofiber-template/domain/ports"
 	"gofiber-template/domain/services"
 	"gofiber-template/pkg/logger"
+	"gofiber-template/pkg/utils"
 )
 
 type HLSHandler struct {
@@ -116,6 +121,68 @@ func (h *HLSHandler) GetAccess(c *fiber.Ctx) error {
 	})
 }
 
+// GetQualities คืน qualities จาก video.QualitySizes (ที่ transcode บันทึกไว้ ไม่ได้เช็ค storage)
+// เรียง resolution สูง → ต่ำ พร้อม playlist URL ผ่าน CDN; default_quality = quality สูงสุด
+// Public สำหรับ embed player เลือก quality - size_bytes ส่งเฉพาะเมื่อ login (admin ตรวจ renditions)
+// GET /api/v1/videos/:id/qualities (:id = video ID หรือ code)
+func (h *HLSHandler) GetQualities(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	idParam := c.Params("id")
+
+	var video *models.Video
+	var err error
+	if id, parseErr := uuid.Parse(idParam); parseErr == nil {
+		video, err = h.videoService.GetByID(ctx, id)
+	} else {
+		video, err = h.videoService.GetByCode(ctx, idParam)
+	}
+	if err != nil {
+		logger.WarnContext(ctx, "Video not found", "id", idParam)
+		return utils.NotFoundResponse(c, "Video not found")
+	}
+
+	_, authErr := utils.GetUserFromContext(c)
+	showSizes := authErr == nil
+
+	qualities := make([]dto.VideoQualityResponse, 0, len(video.QualitySizes))
+	for quality, size := range video.QualitySizes {
+		// Sub-playlist ไม่ต้องใช้ token (เหมือน Chromecast โหลดผ่าน relative URL)
+		item := dto.VideoQualityResponse{
+			Quality:     quality,
+			PlaylistURL: fmt.Sprintf("%s/hls/%s/%s/playlist.m3u8", h.cdnBaseURL, video.Code, quality),
+		}
+		if showSizes {
+			item.SizeBytes = size
+		}
+		qualities = append(qualities, item)
+	}
+
+	// เรียงตาม resolution สูง → ต่ำ (1080p, 720p, 480p, ...)
+	sort.Slice(qualities, func(i, j int) bool {
+		return qualityHeight(qualities[i].Quality) > qualityHeight(qualities[j].Quality)
+	})
+
+	response := dto.VideoQualitiesResponse{
+		VideoID:   video.ID,
+		VideoCode: video.Code,
+		Qualities: qualities,
+	}
+	if len(qualities) > 0 {
+		response.DefaultQuality = qualities[0].Quality
+	}
+
+	return utils.SuccessResponse(c, response)
+}
+
+// qualityHeight แปลง "720p" → 720 (ไม่รู้จัก = 0)
+func qualityHeight(quality string) int {
+	height, err := strconv.Atoi(strings.TrimSuffix(strings.ToLower(quality), "p"))
+	if err != nil {
+		return 0
+	}
+	return height
+}
+
 // GetAccessByID สร้าง access token โดยใช้ video ID
 func (h *HLSHandler) GetAccessByID(c *fiber.Ctx) error {
 	ctx := c.UserContext()
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"gofiber-template/application/serviceimpl"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/logger"
+	"gofiber-template/pkg/utils"
+)
+
+// JobEventHandler แสดง pipeline event log (audit trail สำหรับ support)
+type JobEventHandler struct {
+	jobEventService services.JobEventService
+}
+
+func NewJobEventHandler(jobEventService services.JobEventService) *JobEventHandler {
+	return &JobEventHandler{
+		jobEventService: jobEventService,
+	}
+}
+
+// GetJobTimeline ดึง timeline ของทุก stage ที่ worker รายงานมา
+// GET /api/v1/videos/:id/timeline
+func (h *JobEventHandler) GetJobTimeline(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	videoID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.BadRequestResponse(c, "Invalid video ID")
+	}
+
+	timeline, err := h.jobEventService.GetJobTimeline(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, serviceimpl.ErrVideoNotFound) {
+			return utils.NotFoundResponse(c, "Video not found")
+		}
+		logger.ErrorContext(ctx, "Failed to get job timeline", "video_id", videoID, "error", err)
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	return utils.SuccessResponse(c, timeline)
+}
+
+// GetDLQJobDetail ดึงรายละเอียด video ใน DLQ พร้อม job payload (secrets ถูก redact แล้ว)
+// GET /api/v1/videos/dlq/:id
+func (h *JobEventHandler) GetDLQJobDetail(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	videoID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.BadRequestResponse(c, "Invalid video ID")
+	}
+
+	detail, err := h.jobEventService.GetDLQJobDetail(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, serviceimpl.ErrVideoNotFound) {
+			return utils.NotFoundResponse(c, "Video not found")
+		}
+		logger.ErrorContext(ctx, "Failed to get DLQ job detail", "video_id", videoID, "error", err)
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	return utils.SuccessResponse(c, detail)
+}
@@ -1,10 +1,14 @@
 package handlers
 
 import (
+	"errors"
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"gofiber-template/application/serviceimpl"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/ports"
 	"gofiber-template/domain/services"
 	"gofiber-template/pkg/logger"
 	"gofiber-template/pkg/utils"
@@ -92,6 +96,58 @@ func (h *QueueHandler) RetryTranscodeOne(c *fiber.Ctx) error {
 	})
 }
 
+// BumpTranscode ย้าย transcode job ที่รออยู่ไปคิว priority
+// POST /api/v1/admin/queues/transcode/:id/bump
+func (h *QueueHandler) BumpTranscode(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	videoID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.BadRequestResponse(c, "Invalid video ID")
+	}
+
+	logger.InfoContext(ctx, "Bump transcode priority request", "video_id", videoID)
+
+	result, err := h.queueService.BumpTranscode(ctx, videoID)
+	if err != nil {
+		return bumpErrorResponse(c, err, "Failed to bump transcode job", videoID)
+	}
+
+	return utils.SuccessResponse(c, result)
+}
+
+// CancelJob ยกเลิก job ของ video (ลบจากคิว หรือสั่ง worker ที่กำลังทำให้หยุด)
+// POST /api/v1/admin/queues/:jobType/:id/cancel (jobType = transcode, gallery, seo)
+func (h *QueueHandler) CancelJob(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	videoID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.BadRequestResponse(c, "Invalid video ID")
+	}
+	jobType := c.Params("jobType")
+
+	logger.InfoContext(ctx, "Cancel job request", "video_id", videoID, "job_type", jobType)
+
+	result, err := h.queueService.CancelJob(ctx, videoID, jobType)
+	if err != nil {
+		switch {
+		case errors.Is(err, serviceimpl.ErrVideoNotFound):
+			return utils.NotFoundResponse(c, "Video not found")
+		case errors.Is(err, serviceimpl.ErrUnsupportedJobType):
+			return utils.BadRequestResponse(c, "Unsupported job type")
+		case errors.Is(err, serviceimpl.ErrNoActiveJob):
+			return utils.ConflictResponse(c, err.Error())
+		case errors.Is(err, serviceimpl.ErrTranscodeInProgress):
+			return utils.ConflictResponse(c, "Transcode is already running and cannot be cancelled")
+		}
+		logger.ErrorContext(ctx, "Failed to cancel job", "video_id", videoID, "job_type", jobType, "error", err)
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	return utils.SuccessResponse(c, result)
+}
+
 // === Subtitle Queue ===
 
 // GetSubtitleStuck ดึงรายการ subtitle stuck
@@ -269,6 +325,30 @@ func (h *QueueHandler) GetGalleryProcessing(c *fiber.Ctx) error {
 	return utils.PaginatedSuccessResponse(c, items, total, page, limit)
 }
 
+// GetGalleryByStatus ดึงรายการ video ตาม gallery_status (none, processing, pending_review, ready)
+// GET /api/v1/admin/queues/gallery/status/:status
+func (h *QueueHandler) GetGalleryByStatus(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	status := c.Params("status")
+	switch status {
+	case "none", "processing", "pending_review", "ready":
+	default:
+		return utils.BadRequestResponse(c, "Invalid gallery status (none, processing, pending_review, ready)")
+	}
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	items, total, err := h.queueService.GetGalleryByStatus(ctx, status, page, limit)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to get gallery by status", "gallery_status", status, "error", err)
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	return utils.PaginatedSuccessResponse(c, items, total, page, limit)
+}
+
 // GetGalleryFailed ดึงรายการ gallery ที่ล้มเหลว
 // GET /api/v1/admin/queues/gallery/failed
 func (h *QueueHandler) GetGalleryFailed(c *fiber.Ctx) error {
@@ -286,6 +366,41 @@ func (h *QueueHandler) GetGalleryFailed(c *fiber.Ctx) error {
 	return utils.PaginatedSuccessResponse(c, items, total, page, limit)
 }
 
+// GetGalleryMissing ดึงรายการ video ที่ ready แต่ยังไม่มี gallery
+// GET /api/v1/admin/queues/gallery/missing
+func (h *QueueHandler) GetGalleryMissing(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	items, total, err := h.queueService.GetGalleryMissing(ctx, page, limit)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to get gallery missing", "error", err)
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	return utils.PaginatedSuccessResponse(c, items, total, page, limit)
+}
+
+// BackfillGalleries queue gallery jobs ให้ video ที่ยังไม่มี gallery
+// POST /api/v1/admin/queues/gallery/backfill?max=50
+func (h *QueueHandler) BackfillGalleries(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	maxJobs, _ := strconv.Atoi(c.Query("max", "0"))
+
+	logger.InfoContext(ctx, "Backfill galleries request", "max", maxJobs)
+
+	result, err := h.queueService.BackfillGalleries(ctx, maxJobs)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to backfill galleries", "error", err)
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	return utils.SuccessResponse(c, result)
+}
+
 // RetryGalleryAll retry gallery ที่ failed ทั้งหมด
 // POST /api/v1/admin/queues/gallery/retry-all
 func (h *QueueHandler) RetryGalleryAll(c *fiber.Ctx) error {
@@ -302,6 +417,57 @@ func (h *QueueHandler) RetryGalleryAll(c *fiber.Ctx) error {
 	return utils.SuccessResponse(c, result)
 }
 
+// BumpGallery ย้าย gallery job ของ video ไปคิว priority
+// POST /api/v1/admin/queues/gallery/:id/bump
+func (h *QueueHandler) BumpGallery(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	videoID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.BadRequestResponse(c, "Invalid video ID")
+	}
+
+	logger.InfoContext(ctx, "Bump gallery priority request", "video_id", videoID)
+
+	result, err := h.queueService.BumpGallery(ctx, videoID)
+	if err != nil {
+		return bumpErrorResponse(c, err, "Failed to bump gallery job", videoID)
+	}
+
+	return utils.SuccessResponse(c, result)
+}
+
+// === SEO Queue ===
+
+// EnqueueSEOArticles queue SEO article jobs ให้วิดีโอที่ตรง filter
+// POST /api/v1/admin/queues/seo/enqueue
+// Body: {"status":"ready","hasGallery":true,"missingArticle":true,"createdFrom":"2024-01-01T00:00:00Z","max":100}
+func (h *QueueHandler) EnqueueSEOArticles(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	var req dto.EnqueueSEORequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.WarnContext(ctx, "Invalid request body", "error", err)
+		return utils.BadRequestResponse(c, "Invalid request body")
+	}
+	if req.CreatedFrom != nil && req.CreatedTo != nil && !req.CreatedFrom.Before(*req.CreatedTo) {
+		return utils.BadRequestResponse(c, "createdFrom must be before createdTo")
+	}
+	if req.Priority < 0 || req.Priority > ports.JobPriorityBackfill {
+		return utils.BadRequestResponse(c, "priority must be 1 (urgent), 2 (normal) or 3 (backfill)")
+	}
+
+	logger.InfoContext(ctx, "Enqueue SEO articles request", "max", req.Max)
+
+	result, err := h.queueService.EnqueueSEOArticles(ctx, &req)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to enqueue SEO articles", "error", err)
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	return utils.SuccessResponse(c, result)
+}
+
 // === Reel Queue ===
 
 // GetReelExporting ดึงรายการ reel ที่กำลัง export
@@ -353,3 +519,21 @@ func (h *QueueHandler) RetryReelAll(c *fiber.Ctx) error {
 
 	return utils.SuccessResponse(c, result)
 }
+
+// bumpErrorResponse แปลง error จาก Bump* เป็น response (error ที่ไม่รู้จัก = log แล้วตอบ 500)
+func bumpErrorResponse(c *fiber.Ctx, err error, msg string, videoID uuid.UUID) error {
+	ctx := c.UserContext()
+	switch {
+	case errors.Is(err, serviceimpl.ErrVideoNotFound):
+		return utils.NotFoundResponse(c, "Video not found")
+	case errors.Is(err, serviceimpl.ErrPriorityQueuesDisabled):
+		return utils.BadRequestResponse(c, "Priority queues are disabled")
+	case errors.Is(err, serviceimpl.ErrJobNotBumpable):
+		logger.WarnContext(ctx, msg, "video_id", videoID, "error", err)
+		return utils.ConflictResponse(c, err.Error())
+	case errors.Is(err, ports.ErrJobInFlight):
+		return utils.ConflictResponse(c, "Job is already being processed by a worker")
+	}
+	logger.ErrorContext(ctx, msg, "video_id", videoID, "error", err)
+	return utils.InternalServerErrorResponse(c)
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"gofiber-template/application/serviceimpl"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/logger"
+	"gofiber-template/pkg/utils"
+)
+
+// RelatedVideoHandler แนะนำ videos ที่คล้ายกัน (จาก embedding ของ SEO worker)
+type RelatedVideoHandler struct {
+	relatedVideoService services.RelatedVideoService
+}
+
+func NewRelatedVideoHandler(relatedVideoService services.RelatedVideoService) *RelatedVideoHandler {
+	return &RelatedVideoHandler{
+		relatedVideoService: relatedVideoService,
+	}
+}
+
+// GetRelated ดึง videos ที่คล้ายกัน
+// GET /api/v1/videos/:id/related?limit=10&castId=&makerId=&tagId=&minSimilarity=
+func (h *RelatedVideoHandler) GetRelated(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	videoID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.BadRequestResponse(c, "Invalid video ID")
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+
+	var filter dto.RelatedVideoFilter
+	if err := c.QueryParser(&filter); err != nil {
+		return utils.BadRequestResponse(c, "Invalid query parameters")
+	}
+	if filter.MinSimilarity < 0 || filter.MinSimilarity > 1 {
+		return utils.BadRequestResponse(c, "minSimilarity must be between 0 and 1")
+	}
+
+	related, err := h.relatedVideoService.GetRelatedByEmbedding(ctx, videoID, limit, &filter)
+	if err != nil {
+		if errors.Is(err, serviceimpl.ErrVideoNotFound) {
+			return utils.NotFoundResponse(c, "Video not found")
+		}
+		logger.ErrorContext(ctx, "Failed to get related videos", "video_id", videoID, "error", err)
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	return utils.SuccessResponse(c, related)
+}
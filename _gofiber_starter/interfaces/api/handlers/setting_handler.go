@@ -1,13 +1,16 @@
 package handlers
 
 import (
+	"errors"
 	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 
 	"gofiber-template/domain/services"
 	"gofiber-template/pkg/logger"
+	"gofiber-template/pkg/settings"
 	"gofiber-template/pkg/utils"
 )
 
@@ -105,6 +108,10 @@ func (h *SettingHandler) Update(c *fiber.Ctx) error {
 	)
 
 	if err := h.settingService.Update(ctx, category, req.Settings, userID, req.Reason, ipAddress); err != nil {
+		var verr *settings.ValidationError
+		if errors.As(err, &verr) {
+			return utils.BadRequestResponse(c, verr.Error())
+		}
 		logger.ErrorContext(ctx, "Failed to update settings",
 			"category", category,
 			"error", err,
@@ -224,3 +231,79 @@ func (h *SettingHandler) ReloadCache(c *fiber.Ctx) error {
 	logger.InfoContext(ctx, "Settings cache reloaded successfully")
 	return utils.SuccessResponse(c, fiber.Map{"message": "Cache reloaded successfully"})
 }
+
+// UpdateQualitiesRequest request สำหรับตั้ง default transcode qualities
+type UpdateQualitiesRequest struct {
+	Qualities []string `json:"qualities"`
+	Reason    string   `json:"reason"` // เหตุผลที่แก้ไข (optional)
+}
+
+// GetDefaultQualities ดึง default transcode qualities ที่ parse แล้ว
+// ค่าที่บันทึกไว้ไม่ถูกต้อง (บันทึกก่อนมี validation) = valid=false + error ให้ admin แก้
+// GET /api/v1/settings/transcoding/qualities
+func (h *SettingHandler) GetDefaultQualities(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	raw, err := h.settingService.Get(ctx, "transcoding", "default_qualities")
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to get default qualities", "error", err)
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	response := fiber.Map{
+		"raw":     raw,
+		"allowed": settings.TranscodeQualities,
+		"valid":   true,
+	}
+	qualities, err := settings.ParseQualities(raw)
+	if err != nil {
+		response["valid"] = false
+		response["error"] = err.Error()
+		qualities, _ = settings.ParseQualities(settings.DefaultSettings["transcoding"]["default_qualities"].Value)
+	}
+	response["qualities"] = qualities
+
+	return utils.SuccessResponse(c, response)
+}
+
+// UpdateDefaultQualities ตั้ง default transcode qualities (quality ที่ไม่รู้จัก = 400)
+// PUT /api/v1/settings/transcoding/qualities
+func (h *SettingHandler) UpdateDefaultQualities(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	var req UpdateQualitiesRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.WarnContext(ctx, "Invalid request body", "error", err)
+		return utils.BadRequestResponse(c, "Invalid request body")
+	}
+
+	qualities, err := settings.ParseQualities(strings.Join(req.Qualities, ","))
+	if err != nil {
+		return utils.BadRequestResponse(c, err.Error())
+	}
+
+	var userID *uuid.UUID
+	if uid := c.Locals("user_id"); uid != nil {
+		if id, ok := uid.(uuid.UUID); ok {
+			userID = &id
+		}
+	}
+
+	value := strings.Join(qualities, ",")
+	updates := map[string]string{"default_qualities": value}
+	if err := h.settingService.Update(ctx, "transcoding", updates, userID, req.Reason, c.IP()); err != nil {
+		var verr *settings.ValidationError
+		if errors.As(err, &verr) {
+			return utils.BadRequestResponse(c, verr.Error())
+		}
+		logger.ErrorContext(ctx, "Failed to update default qualities", "qualities", qualities, "error", err)
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	logger.InfoContext(ctx, "Default qualities updated", "qualities", qualities, "user_id", userID)
+
+	return utils.SuccessResponse(c, fiber.Map{
+		"qualities": qualities,
+		"allowed":   settings.TranscodeQualities,
+	})
+}
@@ -1,8 +1,14 @@
 package handlers
 
 import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strconv"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"gofiber-template/application/serviceimpl"
 	"gofiber-template/domain/dto"
 	"gofiber-template/domain/repositories"
 	"gofiber-template/domain/services"
@@ -172,6 +178,50 @@ func (h *SubtitleHandler) SetLanguage(c *fiber.Ctx) error {
 	return utils.SuccessResponse(c, response)
 }
 
+// ImportSubtitle นำเข้า subtitle จากไฟล์ภายนอก (srt/vtt/ass) แปลงเป็น SRT
+// POST /api/v1/videos/:id/subtitle/import
+func (h *SubtitleHandler) ImportSubtitle(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	videoIDStr := c.Params("id")
+	videoID, err := uuid.Parse(videoIDStr)
+	if err != nil {
+		logger.WarnContext(ctx, "Invalid video ID", "video_id", videoIDStr)
+		return utils.BadRequestResponse(c, "Invalid video ID")
+	}
+
+	var req dto.ImportSubtitleRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.WarnContext(ctx, "Invalid request body", "error", err)
+		return utils.BadRequestResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		logger.WarnContext(ctx, "Validation failed", "errors", errors)
+		return utils.ValidationErrorResponse(c, errors)
+	}
+
+	subtitle, err := h.subtitleService.ImportSubtitle(ctx, videoID, req.Language, req.Format, req.Content)
+	if err != nil {
+		switch {
+		case errors.Is(err, serviceimpl.ErrVideoNotFound):
+			return utils.NotFoundResponse(c, "Video not found")
+		case errors.Is(err, serviceimpl.ErrInvalidSubtitleContent):
+			logger.WarnContext(ctx, "Invalid subtitle import", "video_id", videoID, "error", err)
+			return utils.BadRequestResponse(c, "Invalid "+req.Format+" subtitle content")
+		case errors.Is(err, serviceimpl.ErrSubtitleInProgress):
+			return utils.ConflictResponse(c, "Subtitle for this language is being processed")
+		case errors.Is(err, serviceimpl.ErrOriginalSubtitleExists):
+			return utils.ConflictResponse(c, "Original subtitle already exists, edit its content instead")
+		}
+		logger.ErrorContext(ctx, "Failed to import subtitle", "video_id", videoID, "error", err)
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	return utils.SuccessResponse(c, dto.SubtitleToResponse(subtitle))
+}
+
 // TriggerTranscribe trigger สร้าง original subtitle (manual)
 // POST /api/v1/videos/:id/subtitle/transcribe
 func (h *SubtitleHandler) TriggerTranscribe(c *fiber.Ctx) error {
@@ -441,6 +491,46 @@ func (h *SubtitleHandler) JobStarted(c *fiber.Ctx) error {
 	})
 }
 
+// JobHeartbeat callback จาก worker ระหว่างทำ job (ทุก ~30 วินาที)
+// 409 = subtitle ไม่ได้ processing แล้ว (เช่น ถูก mark failed) - worker ควรหยุดทำ job นี้
+// POST /api/v1/internal/subtitles/heartbeat
+func (h *SubtitleHandler) JobHeartbeat(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	var req dto.JobHeartbeatRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.WarnContext(ctx, "Invalid request body", "error", err)
+		return utils.BadRequestResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		logger.WarnContext(ctx, "Validation failed", "errors", errors)
+		return utils.ValidationErrorResponse(c, errors)
+	}
+
+	subtitleID, err := uuid.Parse(req.SubtitleID)
+	if err != nil {
+		logger.WarnContext(ctx, "Invalid subtitle ID", "subtitle_id", req.SubtitleID)
+		return utils.BadRequestResponse(c, "Invalid subtitle ID")
+	}
+
+	if err := h.subtitleService.MarkJobHeartbeat(ctx, subtitleID); err != nil {
+		if errors.Is(err, serviceimpl.ErrSubtitleNotProcessing) {
+			logger.WarnContext(ctx, "Heartbeat for subtitle that is not processing",
+				"subtitle_id", subtitleID,
+				"worker_id", req.WorkerID,
+			)
+			return utils.ConflictResponse(c, err.Error())
+		}
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	return utils.SuccessResponse(c, fiber.Map{
+		"message": "Heartbeat recorded",
+	})
+}
+
 // === User Actions ===
 
 // DeleteSubtitle ลบ subtitle
@@ -490,6 +580,41 @@ func (h *SubtitleHandler) RetryStuckSubtitles(c *fiber.Ctx) error {
 	return utils.SuccessResponse(c, response)
 }
 
+// ListMissingSubtitles ดึง videos ที่มี audio แต่ยังไม่มี original subtitle ที่ ready
+// GET /api/v1/admin/subtitles/missing
+func (h *SubtitleHandler) ListMissingSubtitles(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	items, total, err := h.subtitleService.ListReadyWithoutSubtitles(ctx, page, limit)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to list videos without subtitles", "error", err)
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	return utils.PaginatedSuccessResponse(c, items, total, page, limit)
+}
+
+// TranscribeMissingSubtitles queue transcribe ให้ videos ที่ยังไม่มี subtitle (rate limited)
+// POST /api/v1/admin/subtitles/transcribe-missing?max=20
+func (h *SubtitleHandler) TranscribeMissingSubtitles(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	maxJobs, _ := strconv.Atoi(c.Query("max", "0"))
+
+	logger.InfoContext(ctx, "Bulk transcribe request", "max", maxJobs)
+
+	response, err := h.subtitleService.TriggerTranscribeBulk(ctx, maxJobs)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to bulk transcribe", "error", err)
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	return utils.SuccessResponse(c, response)
+}
+
 // === Content Edit Operations ===
 
 // GetSubtitleContent ดึง content ของ subtitle (SRT file)
@@ -515,6 +640,69 @@ func (h *SubtitleHandler) GetSubtitleContent(c *fiber.Ctx) error {
 	return utils.SuccessResponse(c, response)
 }
 
+// DownloadAllSubtitles ดาวน์โหลด SRT ทุกภาษาที่ ready เป็น zip (<code>.<lang>.srt + MANIFEST.txt)
+// GET /api/v1/videos/:id/subtitles/download
+func (h *SubtitleHandler) DownloadAllSubtitles(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	videoIDStr := c.Params("id")
+	videoID, err := uuid.Parse(videoIDStr)
+	if err != nil {
+		logger.WarnContext(ctx, "Invalid video ID", "video_id", videoIDStr)
+		return utils.BadRequestResponse(c, "Invalid video ID")
+	}
+
+	archive, err := h.subtitleService.DownloadAllSubtitles(ctx, videoID)
+	if err != nil {
+		switch {
+		case errors.Is(err, serviceimpl.ErrVideoNotFound):
+			return utils.NotFoundResponse(c, "Video not found")
+		case errors.Is(err, serviceimpl.ErrNoReadySubtitles):
+			return utils.NotFoundResponse(c, "No ready subtitles")
+		}
+		logger.ErrorContext(ctx, "Failed to prepare subtitle archive", "video_id", videoID, "error", err)
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	c.Set(fiber.HeaderContentType, "application/zip")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, archive.Filename))
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := archive.Write(w); err != nil {
+			logger.ErrorContext(ctx, "Failed to stream subtitle archive", "video_id", videoID, "error", err)
+		}
+		w.Flush()
+	})
+
+	return nil
+}
+
+// GetSubtitleDiff เทียบ content ปัจจุบันกับ version ก่อนแก้ไขล่าสุด (สำหรับ review)
+// GET /api/v1/subtitles/:id/diff
+func (h *SubtitleHandler) GetSubtitleDiff(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	subtitleIDStr := c.Params("id")
+	subtitleID, err := uuid.Parse(subtitleIDStr)
+	if err != nil {
+		logger.WarnContext(ctx, "Invalid subtitle ID", "subtitle_id", subtitleIDStr)
+		return utils.BadRequestResponse(c, "Invalid subtitle ID")
+	}
+
+	response, err := h.subtitleService.GetSubtitleDiff(ctx, subtitleID)
+	if err != nil {
+		switch {
+		case errors.Is(err, serviceimpl.ErrSubtitleNotFound):
+			return utils.NotFoundResponse(c, "Subtitle not found")
+		case errors.Is(err, serviceimpl.ErrSubtitleHasNoSRT):
+			return utils.BadRequestResponse(c, "Subtitle has no SRT file")
+		}
+		logger.ErrorContext(ctx, "Failed to get subtitle diff", "subtitle_id", subtitleID, "error", err)
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	return utils.SuccessResponse(c, response)
+}
+
 // UpdateSubtitleContent อัปเดต content ของ subtitle (SRT file)
 // PUT /api/v1/subtitles/:id/content
 func (h *SubtitleHandler) UpdateSubtitleContent(c *fiber.Ctx) error {
@@ -2,7 +2,6 @@ package handlers
 
 import (
 	"context"
-	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -11,6 +10,7 @@ import (
 	"gofiber-template/domain/services"
 	natspkg "gofiber-template/infrastructure/nats"
 	"gofiber-template/pkg/logger"
+	"gofiber-template/pkg/settings"
 	"gofiber-template/pkg/utils"
 )
 
@@ -43,18 +43,9 @@ func (h *TranscodingHandler) getDefaultQualities(ctx context.Context) []string {
 		return defaultQualities
 	}
 
-	// แยก comma-separated string เป็น slice
-	parts := strings.Split(qualitiesStr, ",")
-	var qualities []string
-	for _, p := range parts {
-		q := strings.TrimSpace(p)
-		if q != "" {
-			qualities = append(qualities, q)
-		}
-	}
-
-	if len(qualities) == 0 {
-		logger.WarnContext(ctx, "Parsed qualities empty, using defaults", "qualities", defaultQualities)
+	qualities, err := settings.ParseQualities(qualitiesStr)
+	if err != nil {
+		logger.WarnContext(ctx, "Invalid qualities in settings, using defaults", "raw_value", qualitiesStr, "error", err, "qualities", defaultQualities)
 		return defaultQualities
 	}
 
@@ -2,13 +2,20 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 
+	"gofiber-template/application/serviceimpl"
 	"gofiber-template/domain/dto"
 	"gofiber-template/domain/models"
 	"gofiber-template/domain/ports"
@@ -16,9 +23,16 @@ import (
 	natspkg "gofiber-template/infrastructure/nats"
 	"gofiber-template/pkg/logger"
 	"gofiber-template/pkg/progress"
+	"gofiber-template/pkg/settings"
 	"gofiber-template/pkg/utils"
 )
 
+// defaultDiskMultiplier พื้นที่ที่ต้องเผื่อต่อขนาดไฟล์ต้นฉบับ เมื่อ transcode บนเครื่องนี้
+const defaultDiskMultiplier = 3.0
+
+// defaultBatchUploadConcurrency จำนวนไฟล์ที่ upload พร้อมกันใน BatchUpload (ไม่กระหน่ำ storage endpoint)
+const defaultBatchUploadConcurrency = 3
+
 type VideoHandler struct {
 	videoService       services.VideoService
 	transcodingService services.TranscodingService
@@ -27,6 +41,7 @@ type VideoHandler struct {
 	storage            ports.StoragePort  // Storage for deleting old gallery files
 	storagePath        string
 	storageType        string // "local" หรือ "s3"
+	galleryQuality     string // HLS variant ที่ต้องการสำหรับ gallery ("" = สูงสุด)
 }
 
 func NewVideoHandler(
@@ -49,6 +64,11 @@ func NewVideoHandler(
 	}
 }
 
+// SetGalleryQuality ตั้ง HLS variant ที่ใช้สร้าง gallery (ไม่ตั้ง = quality สูงสุด)
+func (h *VideoHandler) SetGalleryQuality(quality string) {
+	h.galleryQuality = quality
+}
+
 // getDefaultQualities ดึงค่า default qualities จาก Settings
 func (h *VideoHandler) getDefaultQualities(ctx context.Context) []string {
 	defaultQualities := []string{"1080p", "720p", "480p"}
@@ -63,21 +83,53 @@ func (h *VideoHandler) getDefaultQualities(ctx context.Context) []string {
 		return defaultQualities
 	}
 
-	// แยก comma-separated string เป็น slice
-	parts := strings.Split(qualitiesStr, ",")
-	var qualities []string
-	for _, p := range parts {
-		q := strings.TrimSpace(p)
-		if q != "" {
-			qualities = append(qualities, q)
+	qualities, err := settings.ParseQualities(qualitiesStr)
+	if err != nil {
+		logger.WarnContext(ctx, "Invalid qualities in settings, using defaults", "raw_value", qualitiesStr, "error", err, "qualities", defaultQualities)
+		return defaultQualities
+	}
+
+	return qualities
+}
+
+// getAllowedVideoExtensions ดึง allowlist นามสกุลวิดีโอจาก Settings
+func (h *VideoHandler) getAllowedVideoExtensions(ctx context.Context) map[string]bool {
+	return allowedVideoExtensions(ctx, h.settingService)
+}
+
+// allowedVideoExtensions allowlist นามสกุลวิดีโอจาก Settings (ใช้ร่วมกับ direct upload)
+func allowedVideoExtensions(ctx context.Context, settingService services.SettingService) map[string]bool {
+	if settingService != nil {
+		extStr, err := settingService.Get(ctx, "general", "allowed_video_extensions")
+		if err == nil {
+			if exts := utils.ParseVideoExtensions(extStr); len(exts) > 0 {
+				return exts
+			}
 		}
 	}
+	return utils.ParseVideoExtensions(utils.DefaultVideoExtensions)
+}
 
-	if len(qualities) == 0 {
-		return defaultQualities
+// requiredDiskSpace คำนวณพื้นที่ disk ที่ต้องมีก่อนรับไฟล์
+// local: transcode บนเครื่องนี้ → ต้องเผื่อ output HLS (fileSize × transcoding.disk_multiplier, default 3)
+// s3: ไฟล์แค่พักชั่วคราวก่อนส่งขึ้น storage, worker transcode ที่อื่น → ต้องการแค่ fileSize
+func (h *VideoHandler) requiredDiskSpace(ctx context.Context, fileSize int64) int64 {
+	if h.storageType == "s3" {
+		return fileSize
 	}
 
-	return qualities
+	multiplier := defaultDiskMultiplier
+	if h.settingService != nil {
+		if v, err := h.settingService.Get(ctx, "transcoding", "disk_multiplier"); err == nil && v != "" {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil && parsed >= 1 {
+				multiplier = parsed
+			} else {
+				logger.WarnContext(ctx, "Invalid disk_multiplier setting, using default", "value", v, "default", defaultDiskMultiplier)
+			}
+		}
+	}
+
+	return int64(float64(fileSize) * multiplier)
 }
 
 // isAutoQueueEnabled ตรวจสอบว่าเปิด auto-queue หรือไม่
@@ -116,8 +168,17 @@ func (h *VideoHandler) Upload(c *fiber.Ctx) error {
 		return utils.BadRequestResponse(c, "Empty file not allowed")
 	}
 
-	// ตรวจสอบ disk space ก่อน upload (ต้องการพื้นที่ประมาณ 3x ของไฟล์สำหรับ transcoding)
-	requiredSpace := file.Size * 3
+	// ตรวจสอบนามสกุล + Content-Type + signature ก่อน upload ไป storage
+	if err := utils.ValidateVideoFile(file, h.getAllowedVideoExtensions(ctx)); err != nil {
+		logger.WarnContext(ctx, "Invalid video file", "filename", file.Filename, "error", err)
+		if errors.Is(err, utils.ErrInvalidVideoFormat) {
+			return utils.BadRequestResponse(c, err.Error())
+		}
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	// ตรวจสอบ disk space ก่อน upload (local = ต้องเผื่อพื้นที่ transcoding ตาม disk_multiplier)
+	requiredSpace := h.requiredDiskSpace(ctx, file.Size)
 	hasSpace, diskInfo, err := utils.CheckDiskSpace(h.storagePath, requiredSpace, 10.0)
 	if err != nil {
 		logger.WarnContext(ctx, "Failed to check disk space", "error", err)
@@ -311,6 +372,27 @@ func (h *VideoHandler) List(c *fiber.Ctx) error {
 		}
 	}
 
+	// Cursor mode: ?cursor= (ค่าว่าง = หน้าแรก) - เร็วกว่า offset สำหรับหน้าลึกๆ
+	if c.Context().QueryArgs().Has("cursor") {
+		if params.SortBy != "created_at" {
+			return utils.BadRequestResponse(c, "Cursor pagination only supports sortBy=created_at")
+		}
+		params.Cursor = c.Query("cursor")
+
+		videos, nextCursor, err := h.videoService.ListWithFiltersCursor(ctx, params)
+		if err != nil {
+			if errors.Is(err, utils.ErrInvalidCursor) {
+				return utils.BadRequestResponse(c, "Invalid cursor")
+			}
+			logger.ErrorContext(ctx, "Failed to list videos by cursor", "error", err)
+			return utils.InternalServerErrorResponse(c)
+		}
+
+		reelCounts, _ := h.videoService.GetReelCountsForVideos(ctx, videos)
+
+		return utils.CursorPaginatedSuccessResponse(c, dto.VideosToVideoResponsesWithReelCounts(videos, reelCounts), limit, nextCursor)
+	}
+
 	videos, total, err := h.videoService.ListWithFilters(ctx, params)
 	if err != nil {
 		logger.ErrorContext(ctx, "Failed to list videos", "error", err)
@@ -490,29 +572,7 @@ func (h *VideoHandler) ListDLQ(c *fiber.Ctx) error {
 	// Convert to DLQ response with error details
 	dlqResponses := make([]dto.DLQVideoResponse, 0, len(videos))
 	for _, v := range videos {
-		// Convert error history
-		errorHistory := make([]dto.ErrorRecordResponse, 0, len(v.ErrorHistory))
-		for _, record := range v.ErrorHistory {
-			errorHistory = append(errorHistory, dto.ErrorRecordResponse{
-				Attempt:   record.Attempt,
-				Error:     record.Error,
-				WorkerID:  record.WorkerID,
-				Stage:     record.Stage,
-				Timestamp: record.Timestamp,
-			})
-		}
-
-		dlqResponses = append(dlqResponses, dto.DLQVideoResponse{
-			ID:           v.ID,
-			Code:         v.Code,
-			Title:        v.Title,
-			RetryCount:   v.RetryCount,
-			LastError:    v.LastError,
-			ErrorHistory: errorHistory,
-			CreatedAt:    v.CreatedAt,
-			UpdatedAt:    v.UpdatedAt,
-			UserID:       v.UserID,
-		})
+		dlqResponses = append(dlqResponses, dto.VideoToDLQVideoResponse(v))
 	}
 
 	return utils.PaginatedSuccessResponse(c, dlqResponses, total, page, limit)
@@ -609,6 +669,97 @@ func (h *VideoHandler) DeleteDLQ(c *fiber.Ctx) error {
 	})
 }
 
+// ==================== Cache Management ====================
+
+// RefreshCache ลบแล้วโหลด cache ของ video ใหม่จาก DB
+// POST /api/v1/videos/code/:code/refresh-cache
+func (h *VideoHandler) RefreshCache(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	code := c.Params("code")
+
+	if code == "" {
+		return utils.BadRequestResponse(c, "Video code is required")
+	}
+
+	video, err := h.videoService.RefreshVideoCache(ctx, code)
+	if err != nil {
+		if errors.Is(err, serviceimpl.ErrVideoNotFound) {
+			return utils.NotFoundResponse(c, "Video not found")
+		}
+		logger.ErrorContext(ctx, "Failed to refresh video cache", "code", code, "error", err)
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	return utils.SuccessResponse(c, fiber.Map{
+		"message": "Video cache refreshed",
+		"code":    video.Code,
+	})
+}
+
+// RebuildMasterPlaylist สร้าง master.m3u8 ใหม่จาก variant playlists ที่มีอยู่ใน storage
+// POST /api/v1/videos/code/:code/rebuild-master
+func (h *VideoHandler) RebuildMasterPlaylist(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	code := c.Params("code")
+
+	if code == "" {
+		return utils.BadRequestResponse(c, "Video code is required")
+	}
+
+	result, err := h.videoService.RebuildMasterPlaylist(ctx, code)
+	if err != nil {
+		switch {
+		case errors.Is(err, serviceimpl.ErrVideoNotFound):
+			return utils.NotFoundResponse(c, "Video not found")
+		case errors.Is(err, serviceimpl.ErrNoVariantPlaylists):
+			return utils.BadRequestResponse(c, "No variant playlists found in storage")
+		}
+		logger.ErrorContext(ctx, "Failed to rebuild master playlist", "code", code, "error", err)
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	return utils.SuccessResponse(c, result)
+}
+
+// GetVideoCost ดึง processing cost (Gemini tokens, TTS chars, classifier runtime) ของ video
+// GET /api/v1/videos/:id/cost
+func (h *VideoHandler) GetVideoCost(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	videoID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.BadRequestResponse(c, "Invalid video ID")
+	}
+
+	cost, err := h.videoService.GetVideoCost(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, serviceimpl.ErrVideoNotFound) {
+			return utils.NotFoundResponse(c, "Video not found")
+		}
+		logger.ErrorContext(ctx, "Failed to get video cost", "video_id", videoID, "error", err)
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	return utils.SuccessResponse(c, cost)
+}
+
+// PurgeAllCache ลบ cache ของ video ทั้งหมด
+// POST /api/v1/videos/cache/purge
+func (h *VideoHandler) PurgeAllCache(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	deleted, err := h.videoService.PurgeAllVideoCache(ctx)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to purge video cache", "error", err)
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	return utils.SuccessResponse(c, fiber.Map{
+		"message":     "Video cache purged",
+		"deletedKeys": deleted,
+	})
+}
+
 // BatchUpload อัปโหลดหลายไฟล์พร้อมกัน (สูงสุด 10 ไฟล์)
 func (h *VideoHandler) BatchUpload(c *fiber.Ctx) error {
 	ctx := c.UserContext()
@@ -637,104 +788,51 @@ func (h *VideoHandler) BatchUpload(c *fiber.Ctx) error {
 
 	logger.InfoContext(ctx, "Batch upload attempt", "user_id", user.ID, "file_count", len(files))
 
-	// ประมวลผลทุกไฟล์
-	type uploadResult struct {
-		Filename string      `json:"filename"`
-		Success  bool        `json:"success"`
-		Video    interface{} `json:"video,omitempty"`
-		Error    string      `json:"error,omitempty"`
-	}
+	allowedExts := h.getAllowedVideoExtensions(ctx)
+	concurrency := h.getBatchUploadConcurrency(ctx)
 
-	results := make([]uploadResult, 0, len(files))
-	successCount := 0
-	errorCount := 0
+	// ====== PHASE 1: Upload ทุกไฟล์ไป MinIO ก่อน (พร้อมกันไม่เกิน concurrency ไฟล์) ======
+	logger.InfoContext(ctx, "PHASE 1: Uploading all files to MinIO", "total_files", len(files), "concurrency", concurrency)
 
-	// เก็บ videos ที่ upload สำเร็จเพื่อ queue ทีหลัง
-	var uploadedVideos []*models.Video
+	// results/videos เก็บตาม index ของไฟล์ (ลำดับผลลัพธ์ตรงกับลำดับที่ส่งมา)
+	results := make([]dto.BatchUploadResult, len(files))
+	videos := make([]*models.Video, len(files))
 
-	// ====== PHASE 1: Upload ทุกไฟล์ไป MinIO ก่อน ======
-	logger.InfoContext(ctx, "PHASE 1: Uploading all files to MinIO", "total_files", len(files))
+	var mu sync.Mutex
+	var reservation batchReservation
+	successCount := 0
+	errorCount := 0
 
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
 	for i, file := range files {
-		result := uploadResult{Filename: file.Filename}
-		fileIndex := i + 1
-
-		// Log: เริ่มประมวลผลไฟล์
-		logger.InfoContext(ctx, "Processing file",
-			"index", fileIndex,
-			"total", len(files),
-			"filename", file.Filename,
-			"size_bytes", file.Size,
-			"size_mb", float64(file.Size)/(1024*1024),
-		)
-
-		// ตรวจสอบว่าไฟล์ว่างเปล่าหรือไม่
-		if file.Size == 0 {
-			logger.WarnContext(ctx, "Empty file skipped", "index", fileIndex, "filename", file.Filename)
-			result.Error = "Empty file"
-			errorCount++
-			results = append(results, result)
-			continue
-		}
-
-		// ใช้ชื่อไฟล์เป็น title (ตัด extension)
-		title := file.Filename
-		if dotIdx := len(title) - 1; dotIdx > 0 {
-			for i := len(title) - 1; i >= 0; i-- {
-				if title[i] == '.' {
-					title = title[:i]
-					break
-				}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, file *multipart.FileHeader) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result, video := h.uploadBatchFile(ctx, user.ID, file, i+1, len(files), allowedExts, &reservation)
+			results[i] = result
+			videos[i] = video
+
+			mu.Lock()
+			if result.Success {
+				successCount++
+			} else {
+				errorCount++
 			}
-		}
-
-		req := &dto.CreateVideoRequest{
-			Title: title,
-		}
-
-		// Log: เริ่ม upload ไป MinIO
-		logger.InfoContext(ctx, "Uploading to MinIO",
-			"index", fileIndex,
-			"filename", file.Filename,
-			"title", title,
-		)
-
-		video, err := h.videoService.Upload(ctx, user.ID, file, req)
-		if err != nil {
-			// Log: Upload ล้มเหลว
-			logger.ErrorContext(ctx, "Upload to MinIO FAILED",
-				"index", fileIndex,
-				"filename", file.Filename,
-				"error", err,
-			)
-			result.Error = err.Error()
-			errorCount++
-			results = append(results, result)
-			continue
-		}
-
-		// Log: Upload สำเร็จ - ไฟล์ไปถึง MinIO แล้ว
-		logger.InfoContext(ctx, "Upload to MinIO SUCCESS",
-			"index", fileIndex,
-			"filename", file.Filename,
-			"video_id", video.ID,
-			"video_code", video.Code,
-			"original_path", video.OriginalPath,
-		)
-
-		// เก็บ video ไว้ queue ทีหลัง
-		uploadedVideos = append(uploadedVideos, video)
+			mu.Unlock()
+		}(i, file)
+	}
+	wg.Wait()
 
-		result.Success = true
-		result.Video = dto.VideoUploadResponse{
-			ID:           video.ID,
-			Code:         video.Code,
-			Title:        video.Title,
-			Status:       string(video.Status),
-			AutoEnqueued: true,
+	// เก็บ videos ที่ upload สำเร็จเพื่อ queue ทีหลัง (ตามลำดับไฟล์)
+	var uploadedVideos []*models.Video
+	for _, video := range videos {
+		if video != nil {
+			uploadedVideos = append(uploadedVideos, video)
 		}
-		successCount++
-		results = append(results, result)
 	}
 
 	logger.InfoContext(ctx, "PHASE 1 COMPLETE: All files uploaded to MinIO",
@@ -801,6 +899,166 @@ func (h *VideoHandler) BatchUpload(c *fiber.Ctx) error {
 	})
 }
 
+// getBatchUploadConcurrency จำนวนไฟล์ที่ upload พร้อมกันใน BatchUpload (general.batch_upload_concurrency, default 3)
+func (h *VideoHandler) getBatchUploadConcurrency(ctx context.Context) int {
+	concurrency := defaultBatchUploadConcurrency
+	if h.settingService != nil {
+		concurrency = h.settingService.GetInt(ctx, "general", "batch_upload_concurrency", defaultBatchUploadConcurrency)
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return concurrency
+}
+
+// batchReservation quota/disk ที่ไฟล์ของ batch เดียวกันจองไว้ระหว่าง upload พร้อมกัน
+// ไฟล์ที่ยัง upload ไม่เสร็จยังไม่มี record ใน DB (quota) และยังเขียนไม่ครบบน disk
+// ถ้าไม่จอง ทุกไฟล์จะเห็น used ค่าเดิมแล้วผ่านการตรวจหมด
+type batchReservation struct {
+	mu    sync.Mutex
+	quota int64 // ขนาดรวมของไฟล์ที่กำลัง upload (bytes)
+	disk  int64 // พื้นที่ disk ที่ไฟล์ที่กำลัง upload ต้องใช้ (bytes)
+}
+
+// reserveBatchFile ตรวจ quota และ disk space ของไฟล์ โดยนับรวมไฟล์อื่นใน batch ที่กำลัง upload
+// ผ่าน = จองไว้จนกว่าจะเรียก release (หลัง upload เสร็จหรือล้มเหลว)
+func (h *VideoHandler) reserveBatchFile(ctx context.Context, res *batchReservation, size int64) (release func(), err error) {
+	res.mu.Lock()
+	defer res.mu.Unlock()
+
+	if err := h.videoService.CheckStorageQuotaFor(ctx, res.quota+size); err != nil {
+		return nil, err
+	}
+
+	// local = ต้องเผื่อพื้นที่ transcoding ตาม disk_multiplier (เหมือน Upload)
+	need := h.requiredDiskSpace(ctx, size)
+	hasSpace, diskInfo, err := utils.CheckDiskSpace(h.storagePath, res.disk+need, 10.0)
+	if err != nil {
+		logger.WarnContext(ctx, "Failed to check disk space", "error", err)
+		// ไม่ block upload ถ้าตรวจสอบไม่ได้
+	} else if !hasSpace {
+		return nil, utils.NewDiskSpaceError(res.disk+need, diskInfo.Free)
+	}
+
+	res.quota += size
+	res.disk += need
+
+	return func() {
+		res.mu.Lock()
+		res.quota -= size
+		res.disk -= need
+		res.mu.Unlock()
+	}, nil
+}
+
+// uploadBatchFile ตรวจสอบและ upload ไฟล์เดียวของ BatchUpload
+// video = nil เมื่อไฟล์ถูกข้ามหรือ upload ไม่สำเร็จ (รายละเอียดอยู่ใน result)
+func (h *VideoHandler) uploadBatchFile(ctx context.Context, userID uuid.UUID, file *multipart.FileHeader, fileIndex, total int, allowedExts map[string]bool, reservation *batchReservation) (dto.BatchUploadResult, *models.Video) {
+	result := dto.BatchUploadResult{Filename: file.Filename}
+
+	// Log: เริ่มประมวลผลไฟล์
+	logger.InfoContext(ctx, "Processing file",
+		"index", fileIndex,
+		"total", total,
+		"filename", file.Filename,
+		"size_bytes", file.Size,
+		"size_mb", float64(file.Size)/(1024*1024),
+	)
+
+	// ตรวจสอบว่าไฟล์ว่างเปล่าหรือไม่
+	if file.Size == 0 {
+		logger.WarnContext(ctx, "Empty file skipped", "index", fileIndex, "filename", file.Filename)
+		result.Code = dto.BatchUploadErrEmptyFile
+		result.Error = "Empty file"
+		return result, nil
+	}
+
+	// ตรวจสอบนามสกุล + Content-Type + signature ก่อน upload (ไม่เสียเวลา upload ไฟล์ที่ transcode ไม่ได้)
+	if err := utils.ValidateVideoFile(file, allowedExts); err != nil {
+		logger.WarnContext(ctx, "Invalid file format skipped", "index", fileIndex, "filename", file.Filename, "error", err)
+		result.Code = batchUploadErrorCode(err)
+		result.Error = err.Error()
+		return result, nil
+	}
+
+	// ตรวจ quota + disk space ทุกไฟล์ (ไฟล์ก่อนหน้าใน batch อาจทำให้เต็ม)
+	// จองไว้จน upload จบ - หลัง upload สำเร็จ record/ไฟล์จริงถูกนับแทนแล้ว
+	release, err := h.reserveBatchFile(ctx, reservation, file.Size)
+	if err != nil {
+		logger.WarnContext(ctx, "Batch file rejected by quota/disk check", "index", fileIndex, "filename", file.Filename, "error", err)
+		result.Code = batchUploadErrorCode(err)
+		result.Error = err.Error()
+		return result, nil
+	}
+	defer release()
+
+	// ใช้ชื่อไฟล์เป็น title (ตัด extension)
+	title := file.Filename
+	if dotIdx := strings.LastIndex(title, "."); dotIdx >= 0 {
+		title = title[:dotIdx]
+	}
+
+	req := &dto.CreateVideoRequest{
+		Title: title,
+	}
+
+	// Log: เริ่ม upload ไป MinIO
+	logger.InfoContext(ctx, "Uploading to MinIO",
+		"index", fileIndex,
+		"filename", file.Filename,
+		"title", title,
+	)
+
+	video, err := h.videoService.Upload(ctx, userID, file, req)
+	if err != nil {
+		// Log: Upload ล้มเหลว
+		logger.ErrorContext(ctx, "Upload to MinIO FAILED",
+			"index", fileIndex,
+			"filename", file.Filename,
+			"error", err,
+		)
+		result.Code = batchUploadErrorCode(err)
+		result.Error = err.Error()
+		return result, nil
+	}
+
+	// Log: Upload สำเร็จ - ไฟล์ไปถึง MinIO แล้ว
+	logger.InfoContext(ctx, "Upload to MinIO SUCCESS",
+		"index", fileIndex,
+		"filename", file.Filename,
+		"video_id", video.ID,
+		"video_code", video.Code,
+		"original_path", video.OriginalPath,
+	)
+
+	result.Success = true
+	result.Video = &dto.VideoUploadResponse{
+		ID:           video.ID,
+		Code:         video.Code,
+		Title:        video.Title,
+		Status:       string(video.Status),
+		AutoEnqueued: true,
+	}
+	return result, video
+}
+
+// batchUploadErrorCode แปลง error จาก service เป็น code สำหรับ BatchUploadResult
+func batchUploadErrorCode(err error) string {
+	var diskErr *utils.DiskSpaceError
+	switch {
+	case errors.Is(err, utils.ErrInvalidVideoFormat):
+		return dto.BatchUploadErrInvalidFormat
+	case errors.Is(err, serviceimpl.ErrStorageQuotaExceeded):
+		return dto.BatchUploadErrQuotaExceeded
+	case errors.As(err, &diskErr):
+		return dto.BatchUploadErrDiskSpace
+	case errors.Is(err, serviceimpl.ErrStorageUploadFailed):
+		return dto.BatchUploadErrStorage
+	default:
+		return dto.BatchUploadErrInternal
+	}
+}
+
 // ═══════════════════════════════════════════════════════════════════════════════
 // Gallery Generation
 // ═══════════════════════════════════════════════════════════════════════════════
@@ -836,25 +1094,27 @@ func (h *VideoHandler) GenerateGallery(c *fiber.Ctx) error {
 		return utils.BadRequestResponse(c, "Gallery already exists for this video")
 	}
 
-	// หา best quality ที่มี
-	bestQuality := h.getBestAvailableQuality(video)
-	if bestQuality == "" {
-		return utils.BadRequestResponse(c, "No quality available for gallery generation")
-	}
+	// เลือก HLS variant สำหรับ gallery (preferred → สูงสุดที่มี)
+	galleryQuality := video.GalleryQuality(h.galleryQuality)
 
 	// สร้าง gallery job
 	if h.natsPublisher == nil {
 		return utils.BadRequestResponse(c, "NATS publisher not available")
 	}
 
-	hlsPath := fmt.Sprintf("hls/%s/%s/playlist.m3u8", video.Code, bestQuality)
+	// กัน job gallery ซ้อนกันของวิดีโอเดียวกัน (upload สลับกันจน gallery ปนกันสองชุด)
+	if err := h.natsPublisher.AcquireGalleryLock(ctx, video.Code); err != nil {
+		return h.galleryLockedResponse(c, video, err)
+	}
+
+	hlsPath := fmt.Sprintf("hls/%s/%s/playlist.m3u8", video.Code, galleryQuality)
 	outputPath := fmt.Sprintf("gallery/%s/", video.Code)
 
 	job := natspkg.NewGalleryJob(
 		video.ID.String(),
 		video.Code,
 		hlsPath,
-		bestQuality,
+		galleryQuality,
 		video.Duration,
 		outputPath,
 		100, // default 100 images
@@ -866,13 +1126,14 @@ func (h *VideoHandler) GenerateGallery(c *fiber.Ctx) error {
 			"video_code", video.Code,
 			"error", err,
 		)
+		h.natsPublisher.ReleaseGalleryLock(ctx, video.Code)
 		return utils.BadRequestResponse(c, "Failed to queue gallery generation")
 	}
 
 	logger.InfoContext(ctx, "Gallery job published",
 		"video_id", id,
 		"video_code", video.Code,
-		"quality", bestQuality,
+		"quality", galleryQuality,
 		"duration", video.Duration,
 	)
 
@@ -880,7 +1141,7 @@ func (h *VideoHandler) GenerateGallery(c *fiber.Ctx) error {
 		"message":    "Gallery generation queued",
 		"video_id":   video.ID,
 		"video_code": video.Code,
-		"quality":    bestQuality,
+		"quality":    galleryQuality,
 	})
 }
 
@@ -910,38 +1171,120 @@ func (h *VideoHandler) RegenerateGallery(c *fiber.Ctx) error {
 		return utils.BadRequestResponse(c, "Video has no HLS content")
 	}
 
-	// หา best quality ที่มี
-	bestQuality := h.getBestAvailableQuality(video)
-	if bestQuality == "" {
-		return utils.BadRequestResponse(c, "No quality available for gallery generation")
-	}
+	// เลือก HLS variant สำหรับ gallery (preferred → สูงสุดที่มี)
+	galleryQuality := video.GalleryQuality(h.galleryQuality)
 
 	// สร้าง gallery job
 	if h.natsPublisher == nil {
 		return utils.BadRequestResponse(c, "NATS publisher not available")
 	}
 
-	// ลบ gallery เก่าใน E2/S3 ก่อน
+	// กัน job gallery ซ้อนกันของวิดีโอเดียวกัน (upload สลับกันจน gallery ปนกันสองชุด)
+	if err := h.natsPublisher.AcquireGalleryLock(ctx, video.Code); err != nil {
+		return h.galleryLockedResponse(c, video, err)
+	}
+
+	// ลบ gallery เก่าใน E2/S3 + reset counts ก่อน
+	h.resetGallery(ctx, video)
+
+	hlsPath := fmt.Sprintf("hls/%s/%s/playlist.m3u8", video.Code, galleryQuality)
+	outputPath := fmt.Sprintf("gallery/%s/", video.Code)
+
+	job := natspkg.NewGalleryJob(
+		video.ID.String(),
+		video.Code,
+		hlsPath,
+		galleryQuality,
+		video.Duration,
+		outputPath,
+		100, // default 100 images
+	)
+
+	if err := h.natsPublisher.PublishGalleryJob(ctx, job); err != nil {
+		logger.ErrorContext(ctx, "Failed to publish gallery regeneration job",
+			"video_id", id,
+			"video_code", video.Code,
+			"error", err,
+		)
+		h.natsPublisher.ReleaseGalleryLock(ctx, video.Code)
+		return utils.BadRequestResponse(c, "Failed to queue gallery regeneration")
+	}
+
+	logger.InfoContext(ctx, "Gallery regeneration job published",
+		"video_id", id,
+		"video_code", video.Code,
+		"quality", galleryQuality,
+		"duration", video.Duration,
+	)
+
+	return utils.SuccessResponse(c, fiber.Map{
+		"message":    "Gallery regeneration queued",
+		"video_id":   video.ID,
+		"video_code": video.Code,
+		"quality":    galleryQuality,
+	})
+}
+
+// resetGallery ลบไฟล์ gallery/<code>/ ใน E2/S3 แล้ว reset counts ใน DB (worker จะ update ใหม่เมื่อเสร็จ)
+// ล้มเหลว = log แล้วทำต่อ (ไฟล์ใหม่จะเขียนทับ / worker จะเขียน counts ทับ)
+func (h *VideoHandler) resetGallery(ctx context.Context, video *models.Video) {
 	galleryPrefix := fmt.Sprintf("gallery/%s/", video.Code)
 	if h.storage != nil {
 		if err := h.storage.DeleteFolder(galleryPrefix); err != nil {
 			logger.WarnContext(ctx, "Failed to delete old gallery files",
-				"video_id", id,
+				"video_id", video.ID,
 				"video_code", video.Code,
 				"prefix", galleryPrefix,
 				"error", err,
 			)
-			// Continue anyway - new files will overwrite
 		} else {
 			logger.InfoContext(ctx, "Deleted old gallery files",
-				"video_id", id,
+				"video_id", video.ID,
 				"video_code", video.Code,
 				"prefix", galleryPrefix,
 			)
 		}
 	}
 
-	// Reset gallery counts ใน DB (worker จะ update ใหม่เมื่อเสร็จ)
+	h.resetGalleryCounts(ctx, video)
+}
+
+// resetGalleryExcept เหมือน resetGallery แต่เก็บไฟล์ใน keep ไว้ (ภาพจากค่ายที่เพิ่ง upload)
+func (h *VideoHandler) resetGalleryExcept(ctx context.Context, video *models.Video, keep []string) {
+	galleryPrefix := fmt.Sprintf("gallery/%s/", video.Code)
+	files, err := h.storage.ListFiles(galleryPrefix)
+	if err != nil {
+		logger.WarnContext(ctx, "Failed to list old gallery files", "video_id", video.ID, "prefix", galleryPrefix, "error", err)
+	}
+
+	kept := make(map[string]bool, len(keep))
+	for _, path := range keep {
+		kept[path] = true
+	}
+	var stale []string
+	for _, path := range files {
+		if !kept[path] {
+			stale = append(stale, path)
+		}
+	}
+
+	if len(stale) > 0 {
+		if err := h.storage.DeleteObjects(ctx, stale); err != nil {
+			logger.WarnContext(ctx, "Failed to delete old gallery files", "video_id", video.ID, "prefix", galleryPrefix, "error", err)
+		} else {
+			logger.InfoContext(ctx, "Deleted old gallery files",
+				"video_id", video.ID,
+				"video_code", video.Code,
+				"files", len(stale),
+			)
+		}
+	}
+
+	h.resetGalleryCounts(ctx, video)
+}
+
+// resetGalleryCounts reset path/counts ของ gallery ใน DB
+func (h *VideoHandler) resetGalleryCounts(ctx context.Context, video *models.Video) {
 	zero := 0
 	emptyPath := ""
 	resetReq := &dto.UpdateVideoRequest{
@@ -950,73 +1293,239 @@ func (h *VideoHandler) RegenerateGallery(c *fiber.Ctx) error {
 		GallerySafeCount: &zero,
 		GalleryNsfwCount: &zero,
 	}
-	if _, err := h.videoService.Update(ctx, id, resetReq); err != nil {
-		logger.WarnContext(ctx, "Failed to reset gallery counts", "video_id", id, "error", err)
-		// Continue anyway - worker will overwrite
+	if _, err := h.videoService.Update(ctx, video.ID, resetReq); err != nil {
+		logger.WarnContext(ctx, "Failed to reset gallery counts", "video_id", video.ID, "error", err)
 	}
+}
 
-	hlsPath := fmt.Sprintf("hls/%s/%s/playlist.m3u8", video.Code, bestQuality)
-	outputPath := fmt.Sprintf("gallery/%s/", video.Code)
+// galleryLockedResponse response เมื่อจอง gallery lock ไม่ได้ (มี job ค้าง = 409 ให้รอ job เดิมเสร็จก่อน)
+func (h *VideoHandler) galleryLockedResponse(c *fiber.Ctx, video *models.Video, err error) error {
+	if errors.Is(err, natspkg.ErrGalleryLocked) {
+		logger.WarnContext(c.UserContext(), "Gallery generation already in progress",
+			"video_id", video.ID,
+			"video_code", video.Code,
+		)
+		return utils.ConflictResponse(c, "Gallery generation already in progress for this video")
+	}
+	logger.ErrorContext(c.UserContext(), "Failed to acquire gallery lock",
+		"video_id", video.ID,
+		"video_code", video.Code,
+		"error", err,
+	)
+	return utils.InternalServerErrorResponse(c)
+}
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// External Gallery - ภาพจากค่าย (official stills) แทน frames จาก HLS
+// admin upload → gallery/<code>/external/ → worker classify + แยก tier เหมือนเดิม (ข้ามการดึง frame)
+// ═══════════════════════════════════════════════════════════════════════════════
 
+const (
+	maxExternalGalleryImages    = 200
+	maxExternalGalleryImageSize = 20 * 1024 * 1024 // 20MB ต่อภาพ
+)
+
+// externalGalleryPrefix folder ที่เก็บภาพจากค่ายระหว่างรอ worker classify
+func externalGalleryPrefix(videoCode string) string {
+	return fmt.Sprintf("gallery/%s/external/", videoCode)
+}
+
+// UploadExternalGallery รับภาพ gallery จากค่าย (multipart field "images", JPEG เท่านั้น)
+// แทนที่ gallery เดิมทั้งหมด แล้วส่ง gallery job ให้ worker classify ภาพชุดนี้
+func (h *VideoHandler) UploadExternalGallery(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.BadRequestResponse(c, "Invalid video ID")
+	}
+
+	video, err := h.videoService.GetByID(ctx, id)
+	if err != nil {
+		logger.WarnContext(ctx, "Video not found for external gallery", "video_id", id)
+		return utils.NotFoundResponse(c, "Video not found")
+	}
+
+	if h.natsPublisher == nil {
+		return utils.BadRequestResponse(c, "NATS publisher not available")
+	}
+	if h.storage == nil {
+		return utils.BadRequestResponse(c, "Storage not available")
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		logger.WarnContext(ctx, "Failed to parse multipart form", "error", err)
+		return utils.BadRequestResponse(c, "Invalid form data")
+	}
+
+	files := form.File["images"]
+	if len(files) == 0 {
+		return utils.BadRequestResponse(c, "No images provided")
+	}
+	if len(files) > maxExternalGalleryImages {
+		return utils.BadRequestResponse(c, fmt.Sprintf("Maximum %d images allowed", maxExternalGalleryImages))
+	}
+
+	// ตรวจทุกไฟล์ก่อนลบ gallery เดิม (ไฟล์ไหนไม่ผ่าน = ไม่แตะของเดิม)
+	for _, file := range files {
+		if err := validateExternalGalleryImage(file); err != nil {
+			return utils.BadRequestResponse(c, err.Error())
+		}
+	}
+
+	// กัน job gallery ซ้อนกันของวิดีโอเดียวกัน (upload สลับกันจน gallery ปนกันสองชุด)
+	if err := h.natsPublisher.AcquireGalleryLock(ctx, video.Code); err != nil {
+		return h.galleryLockedResponse(c, video, err)
+	}
+
+	// upload ก่อน แล้วค่อยลบ gallery เดิม - upload ไม่ครบ = ลบเฉพาะภาพชุดใหม่ gallery เดิมยังอยู่
+	// ชื่อไฟล์มี batch prefix กันชนกับภาพจากค่ายชุดเก่าที่ยังไม่ถูก classify
+	prefix := externalGalleryPrefix(video.Code)
+	batch := time.Now().Unix()
+	uploaded := make([]string, 0, len(files))
+	for i, file := range files {
+		path := fmt.Sprintf("%s%d_%03d.jpg", prefix, batch, i+1)
+		if err := h.uploadExternalGalleryImage(file, path); err != nil {
+			logger.ErrorContext(ctx, "Failed to upload external gallery image",
+				"video_id", id,
+				"video_code", video.Code,
+				"filename", file.Filename,
+				"path", path,
+				"error", err,
+			)
+			if len(uploaded) > 0 {
+				if derr := h.storage.DeleteObjects(ctx, uploaded); derr != nil {
+					logger.WarnContext(ctx, "Failed to clean up partial external gallery upload", "video_id", id, "error", derr)
+				}
+			}
+			h.natsPublisher.ReleaseGalleryLock(ctx, video.Code)
+			return utils.InternalServerErrorResponse(c)
+		}
+		uploaded = append(uploaded, path)
+	}
+
+	h.resetGalleryExcept(ctx, video, uploaded)
+
+	// ไม่มี HLS path - worker เห็นภาพใน external/ แล้วข้ามการดึง frame
 	job := natspkg.NewGalleryJob(
 		video.ID.String(),
 		video.Code,
-		hlsPath,
-		bestQuality,
+		"",
+		"",
 		video.Duration,
-		outputPath,
-		100, // default 100 images
+		fmt.Sprintf("gallery/%s/", video.Code),
+		len(files),
 	)
 
 	if err := h.natsPublisher.PublishGalleryJob(ctx, job); err != nil {
-		logger.ErrorContext(ctx, "Failed to publish gallery regeneration job",
+		logger.ErrorContext(ctx, "Failed to publish external gallery job",
 			"video_id", id,
 			"video_code", video.Code,
 			"error", err,
 		)
-		return utils.BadRequestResponse(c, "Failed to queue gallery regeneration")
+		h.natsPublisher.ReleaseGalleryLock(ctx, video.Code)
+		return utils.BadRequestResponse(c, "Failed to queue gallery classification")
 	}
 
-	logger.InfoContext(ctx, "Gallery regeneration job published",
+	logger.InfoContext(ctx, "External gallery job published",
 		"video_id", id,
 		"video_code", video.Code,
-		"quality", bestQuality,
-		"duration", video.Duration,
+		"image_count", len(files),
 	)
 
 	return utils.SuccessResponse(c, fiber.Map{
-		"message":    "Gallery regeneration queued",
-		"video_id":   video.ID,
-		"video_code": video.Code,
-		"quality":    bestQuality,
+		"message":     "External gallery queued for classification",
+		"video_id":    video.ID,
+		"video_code":  video.Code,
+		"image_count": len(files),
 	})
 }
 
-// getBestAvailableQuality หา quality สูงสุดที่มี
-func (h *VideoHandler) getBestAvailableQuality(video *models.Video) string {
-	// ลำดับความสำคัญ: 1080p > 720p > 480p > 360p
-	qualityOrder := []string{"1080p", "720p", "480p", "360p"}
+// validateExternalGalleryImage ตรวจขนาด + เนื้อไฟล์ว่าเป็น JPEG จริง (worker upload เฉพาะ .jpg)
+func validateExternalGalleryImage(file *multipart.FileHeader) error {
+	if file.Size > maxExternalGalleryImageSize {
+		return fmt.Errorf("%s exceeds %dMB", file.Filename, maxExternalGalleryImageSize/(1024*1024))
+	}
 
-	if video.QualitySizes == nil {
-		// ถ้าไม่มี quality sizes ให้ใช้ค่าจาก video.Quality
-		if video.Quality != "" {
-			return video.Quality
-		}
-		return "720p" // default
+	f, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("cannot read %s", file.Filename)
+	}
+	defer f.Close()
+
+	head := make([]byte, 512)
+	n, _ := io.ReadFull(f, head)
+	if http.DetectContentType(head[:n]) != "image/jpeg" {
+		return fmt.Errorf("%s is not a JPEG image", file.Filename)
+	}
+	return nil
+}
+
+func (h *VideoHandler) uploadExternalGalleryImage(file *multipart.FileHeader, path string) error {
+	f, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = h.storage.UploadFile(f, path, "image/jpeg")
+	return err
+}
+
+// ListExternalGallery คืน storage keys ของภาพจากค่ายที่รอ classify (worker เรียกก่อนดึง frame)
+// ว่าง = gallery job ปกติจาก HLS
+func (h *VideoHandler) ListExternalGallery(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.BadRequestResponse(c, "Invalid video ID")
+	}
+
+	video, err := h.videoService.GetByID(ctx, id)
+	if err != nil {
+		return utils.NotFoundResponse(c, "Video not found")
 	}
 
-	for _, q := range qualityOrder {
-		if _, exists := video.QualitySizes[q]; exists {
-			return q
+	keys := []string{}
+	if h.storage != nil {
+		files, err := h.storage.ListFiles(externalGalleryPrefix(video.Code))
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to list external gallery", "video_id", id, "error", err)
+			return utils.InternalServerErrorResponse(c)
 		}
+		keys = append(keys, files...)
+	}
+
+	return utils.SuccessResponse(c, fiber.Map{
+		"video_id": video.ID,
+		"images":   keys,
+	})
+}
+
+// ClearExternalGallery ลบภาพจากค่ายหลัง worker classify + upload เข้า tier แล้ว
+func (h *VideoHandler) ClearExternalGallery(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.BadRequestResponse(c, "Invalid video ID")
 	}
 
-	// ถ้าไม่เจอ ให้ใช้ตัวแรกที่มี
-	for q := range video.QualitySizes {
-		return q
+	video, err := h.videoService.GetByID(ctx, id)
+	if err != nil {
+		return utils.NotFoundResponse(c, "Video not found")
 	}
 
-	return ""
+	if h.storage != nil {
+		if err := h.storage.DeleteFolder(externalGalleryPrefix(video.Code)); err != nil {
+			logger.ErrorContext(ctx, "Failed to clear external gallery", "video_id", id, "error", err)
+			return utils.InternalServerErrorResponse(c)
+		}
+	}
+
+	return utils.SuccessResponse(c, fiber.Map{"video_id": video.ID})
 }
 
 // ═══════════════════════════════════════════════════════════════════════════════
@@ -1032,6 +1541,8 @@ type UpdateGalleryRequest struct {
 	GallerySafeCount      int    `json:"gallery_safe_count"`       // Safe images count
 	GalleryNsfwCount      int    `json:"gallery_nsfw_count"`       // NSFW images count
 	GallerySuperSafeCount int    `json:"gallery_super_safe_count"` // Deprecated - backward compat
+
+	GalleryLimits map[string]int `json:"gallery_limits"` // tier limits ที่ worker ใช้ได้ counts ชุดนี้ (optional)
 }
 
 // UpdateGallery updates video gallery info (called by worker after gallery generation)
@@ -1065,6 +1576,7 @@ func (h *VideoHandler) UpdateGallery(c *fiber.Ctx) error {
 		GallerySafeCount:      &req.GallerySafeCount,
 		GalleryNsfwCount:      &req.GalleryNsfwCount,
 		GallerySuperSafeCount: &req.GallerySuperSafeCount,
+		GalleryLimits:         req.GalleryLimits,
 	}
 
 	video, err := h.videoService.Update(ctx, id, updateReq)
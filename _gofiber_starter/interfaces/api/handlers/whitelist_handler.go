@@ -1,12 +1,16 @@
 package handlers
 
 import (
+	"errors"
+	"io"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 
+	"gofiber-template/application/serviceimpl"
 	"gofiber-template/domain/dto"
 	"gofiber-template/domain/services"
 	"gofiber-template/pkg/logger"
@@ -235,6 +239,147 @@ func (h *WhitelistHandler) RemoveDomain(c *fiber.Ctx) error {
 	return utils.SuccessResponse(c, fiber.Map{"message": "Domain removed successfully"})
 }
 
+// ImportDomains เพิ่ม domains หลายตัวให้ profile
+// POST /api/v1/whitelist/profiles/:id/domains/import
+// รองรับ JSON {"domains": [...]}, text/plain หรือ multipart field "file" (newline/CSV)
+func (h *WhitelistHandler) ImportDomains(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	profileID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.BadRequestResponse(c, "Invalid profile ID")
+	}
+
+	var domains []string
+	if fileHeader, ferr := c.FormFile("file"); ferr == nil {
+		file, err := fileHeader.Open()
+		if err != nil {
+			logger.WarnContext(ctx, "Failed to open uploaded file", "error", err)
+			return utils.BadRequestResponse(c, "Failed to read uploaded file")
+		}
+		defer file.Close()
+
+		content, err := io.ReadAll(io.LimitReader(file, maxDomainImportBytes))
+		if err != nil {
+			logger.WarnContext(ctx, "Failed to read uploaded file", "error", err)
+			return utils.BadRequestResponse(c, "Failed to read uploaded file")
+		}
+		domains = parseDomainList(string(content))
+	} else if strings.HasPrefix(string(c.Request().Header.ContentType()), fiber.MIMETextPlain) {
+		domains = parseDomainList(string(c.Body()))
+	} else {
+		var req dto.ImportDomainsRequest
+		if err := c.BodyParser(&req); err != nil {
+			logger.WarnContext(ctx, "Invalid request body", "error", err)
+			return utils.BadRequestResponse(c, "Invalid request body")
+		}
+		domains = req.Domains
+	}
+
+	req := dto.ImportDomainsRequest{Domains: domains}
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		logger.WarnContext(ctx, "Validation failed", "errors", errors)
+		return utils.ValidationErrorResponse(c, errors)
+	}
+
+	result, err := h.whitelistService.ImportDomains(ctx, profileID, req.Domains)
+	if err != nil {
+		if errors.Is(err, serviceimpl.ErrProfileNotFound) {
+			return utils.NotFoundResponse(c, "Profile not found")
+		}
+		logger.ErrorContext(ctx, "Failed to import domains", "profile_id", profileID, "error", err)
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	return utils.SuccessResponse(c, result)
+}
+
+// ExportDomains ดึงรายการ domains ของ profile
+// GET /api/v1/whitelist/profiles/:id/domains/export?format=txt
+// format=txt → ไฟล์ text (1 domain ต่อบรรทัด), default → JSON
+func (h *WhitelistHandler) ExportDomains(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	profileID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.BadRequestResponse(c, "Invalid profile ID")
+	}
+
+	domains, err := h.whitelistService.ExportDomains(ctx, profileID)
+	if err != nil {
+		if errors.Is(err, serviceimpl.ErrProfileNotFound) {
+			return utils.NotFoundResponse(c, "Profile not found")
+		}
+		logger.ErrorContext(ctx, "Failed to export domains", "profile_id", profileID, "error", err)
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	if c.Query("format") == "txt" {
+		c.Set(fiber.HeaderContentType, fiber.MIMETextPlainCharsetUTF8)
+		c.Set(fiber.HeaderContentDisposition, `attachment; filename="domains-`+profileID.String()+`.txt"`)
+		if len(domains) == 0 {
+			return c.SendString("")
+		}
+		return c.SendString(strings.Join(domains, "\n") + "\n")
+	}
+
+	return utils.SuccessResponse(c, fiber.Map{
+		"profileId": profileID,
+		"domains":   domains,
+		"count":     len(domains),
+	})
+}
+
+// maxDomainImportBytes จำกัดขนาดไฟล์ import (1MB)
+const maxDomainImportBytes = 1 << 20
+
+// parseDomainList แยก domains จาก text (newline / CSV / ; / whitespace)
+// บรรทัดที่ขึ้นต้นด้วย # ถือเป็น comment
+func parseDomainList(content string) []string {
+	var domains []string
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.FieldsFunc(line, func(r rune) bool {
+			return r == ',' || r == ';' || r == '"' || r == ' ' || r == '\t' || r == '\r'
+		})
+		domains = append(domains, fields...)
+	}
+	return domains
+}
+
+// TestDomainMatch ทดสอบว่า domain/Referer ตรงกับ whitelist pattern ไหน (debug)
+// POST /api/v1/whitelist/match-test
+func (h *WhitelistHandler) TestDomainMatch(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	var req dto.DomainMatchTestRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.WarnContext(ctx, "Invalid request body", "error", err)
+		return utils.BadRequestResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		logger.WarnContext(ctx, "Validation failed", "errors", errors)
+		return utils.ValidationErrorResponse(c, errors)
+	}
+
+	result, err := h.whitelistService.TestDomainMatch(ctx, &req)
+	if err != nil {
+		if errors.Is(err, serviceimpl.ErrProfileNotFound) {
+			return utils.NotFoundResponse(c, "Profile not found")
+		}
+		logger.ErrorContext(ctx, "Domain match test failed", "domain", req.Domain, "error", err)
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	return utils.SuccessResponse(c, result)
+}
+
 // ==================== Preroll Ads Management ====================
 
 // AddPrerollAd เพิ่ม preroll ad ให้ profile
@@ -460,6 +605,31 @@ func (h *WhitelistHandler) GetAdStatsByProfile(c *fiber.Ctx) error {
 	return utils.SuccessResponse(c, dto.AdStatsToResponse(stats))
 }
 
+// GetProfileStats ดึงสถิติการใช้งานรายวันของ profile + แยกตาม domain
+// GET /api/v1/ads/stats/profile/:id/daily?start=YYYY-MM-DD&end=YYYY-MM-DD
+func (h *WhitelistHandler) GetProfileStats(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	profileIDStr := c.Params("id")
+	profileID, err := uuid.Parse(profileIDStr)
+	if err != nil {
+		return utils.BadRequestResponse(c, "Invalid profile ID")
+	}
+
+	start, end := h.parseDateRange(c)
+
+	stats, err := h.whitelistService.GetProfileStats(ctx, profileID, start, end)
+	if err != nil {
+		if errors.Is(err, serviceimpl.ErrProfileNotFound) {
+			return utils.NotFoundResponse(c, "Profile not found")
+		}
+		logger.ErrorContext(ctx, "Failed to get profile stats", "profile_id", profileID, "error", err)
+		return utils.InternalServerErrorResponse(c)
+	}
+
+	return utils.SuccessResponse(c, dto.ProfileStatsToResponse(stats, start, end))
+}
+
 // GetDeviceStats ดึงสถิติแยกตามอุปกรณ์
 // GET /api/v1/ads/stats/devices
 func (h *WhitelistHandler) GetDeviceStats(c *fiber.Ctx) error {
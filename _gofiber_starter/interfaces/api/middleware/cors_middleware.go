@@ -1,17 +1,92 @@
 package middleware
 
 import (
+	"strings"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
+	"gofiber-template/pkg/logger"
 )
 
-func CorsMiddleware() fiber.Handler {
-	return cors.New(cors.Config{
-		// Allow origins for development and production
+// CorsConfig กำหนด CORS (comma-separated เหมือน fiber cors.Config)
+type CorsConfig struct {
+	AllowOrigins     string
+	AllowMethods     string
+	AllowHeaders     string
+	ExposeHeaders    string
+	AllowCredentials bool // เปิด credentials สำหรับ cookies/auth (ใช้กับ origin "*" ไม่ได้)
+}
+
+// CorsGroup CORS เฉพาะ path prefix - เช่น embed (เปิด origin กว้าง) กับ admin (ล็อก origin)
+type CorsGroup struct {
+	Name     string
+	Prefixes []string
+	Config   CorsConfig
+}
+
+// DefaultCorsConfig ค่า CORS สำหรับ development และ production
+func DefaultCorsConfig() CorsConfig {
+	return CorsConfig{
 		AllowOrigins:     "http://localhost:5173,http://localhost:5174,http://localhost:3000,https://cdn.suekk.com,https://suekk.com,https://*.suekk.com",
 		AllowMethods:     "GET,POST,PUT,DELETE,OPTIONS,HEAD",
 		AllowHeaders:     "Origin,Content-Type,Accept,Authorization,Range,Cache-Control,X-Requested-With,X-Stream-Token",
 		ExposeHeaders:    "Content-Length,Content-Range,Accept-Ranges,Content-Type",
-		AllowCredentials: true, // เปิด credentials สำหรับ cookies/auth
+		AllowCredentials: true,
+	}
+}
+
+// EmbedCorsPrefixes routes ที่ player บนเว็บ partner เรียก (whitelist middleware ตรวจ domain ซ้ำอยู่แล้ว)
+var EmbedCorsPrefixes = []string{"/embed/", "/api/v1/embed/", "/api/v1/hls/"}
+
+// AdminCorsPrefixes routes สำหรับ admin panel เท่านั้น
+var AdminCorsPrefixes = []string{"/api/v1/admin/"}
+
+// CorsMiddleware ใส่ CORS headers - path ที่ตรงกับ group ใช้ config ของ group นั้น (group แรกที่ตรง) ไม่งั้นใช้ cfg
+func CorsMiddleware(cfg CorsConfig, groups ...CorsGroup) fiber.Handler {
+	defaultHandler := newCorsHandler("default", cfg)
+	if len(groups) == 0 {
+		return defaultHandler
+	}
+
+	handlers := make([]fiber.Handler, len(groups))
+	for i, g := range groups {
+		handlers[i] = newCorsHandler(g.Name, g.Config)
+	}
+
+	return func(c *fiber.Ctx) error {
+		path := c.Path()
+		for i, g := range groups {
+			for _, prefix := range g.Prefixes {
+				if strings.HasPrefix(path, prefix) {
+					return handlers[i](c)
+				}
+			}
+		}
+		return defaultHandler(c)
+	}
+}
+
+// newCorsHandler สร้าง fiber cors handler - origin "*" + credentials = ปิด credentials (fiber panic ถ้าตั้งคู่กัน)
+func newCorsHandler(name string, cfg CorsConfig) fiber.Handler {
+	if cfg.AllowCredentials && hasWildcardOrigin(cfg.AllowOrigins) {
+		logger.Warn("CORS credentials disabled for wildcard origin", "group", name)
+		cfg.AllowCredentials = false
+	}
+
+	return cors.New(cors.Config{
+		AllowOrigins:     cfg.AllowOrigins,
+		AllowMethods:     cfg.AllowMethods,
+		AllowHeaders:     cfg.AllowHeaders,
+		ExposeHeaders:    cfg.ExposeHeaders,
+		AllowCredentials: cfg.AllowCredentials,
 	})
-}
\ No newline at end of file
+}
+
+func hasWildcardOrigin(origins string) bool {
+	for _, origin := range strings.Split(origins, ",") {
+		if strings.TrimSpace(origin) == "*" {
+			return true
+		}
+	}
+	return false
+}
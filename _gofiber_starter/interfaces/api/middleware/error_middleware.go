@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"log"
 
 	"github.com/gofiber/fiber/v2"
@@ -13,6 +15,11 @@ func ErrorHandler() fiber.ErrorHandler {
 		errCode := utils.ErrCodeInternalError
 		message := "Internal server error"
 
+		// request context หมดเวลา (TimeoutMiddleware)
+		if errors.Is(err, context.DeadlineExceeded) {
+			return utils.GatewayTimeoutResponse(c)
+		}
+
 		if e, ok := err.(*fiber.Error); ok {
 			code = e.Code
 			message = e.Message
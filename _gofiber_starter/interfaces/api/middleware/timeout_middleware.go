@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gofiber-template/pkg/logger"
+	"gofiber-template/pkg/utils"
+)
+
+// TimeoutConfig กำหนด timeout ของ request context
+type TimeoutConfig struct {
+	Default time.Duration // timeout ปกติสำหรับทุก request
+	Upload  time.Duration // timeout สำหรับ upload routes (ไฟล์ใหญ่)
+
+	// UploadRoutes "METHOD /route" ที่ใช้ Upload timeout
+	// route เขียนแบบเดียวกับ c.Route().Path (เช่น /api/v1/videos/:id/subtitle/import)
+	UploadRoutes []string
+
+	// ExemptPrefixes path prefix ที่ไม่ใส่ timeout (streaming/websocket มี limit ของตัวเอง)
+	ExemptPrefixes []string
+}
+
+// DefaultTimeoutConfig สร้าง config พร้อม upload/streaming routes ของระบบ
+func DefaultTimeoutConfig(defaultTimeout, uploadTimeout time.Duration) TimeoutConfig {
+	return TimeoutConfig{
+		Default: defaultTimeout,
+		Upload:  uploadTimeout,
+		UploadRoutes: []string{
+			"POST /api/v1/videos",
+			"POST /api/v1/videos/upload",
+			"POST /api/v1/videos/batch",
+			"POST /api/v1/files/upload",
+			"POST /api/v1/direct-upload/complete",
+			"POST /api/v1/direct-upload/notify",
+			"POST /api/v1/videos/:id/gallery/external",
+			"POST /api/v1/videos/:id/subtitle/import",
+		},
+		ExemptPrefixes: []string{
+			"/ws",         // WebSocket (long-lived)
+			"/hls/",       // HLS streaming proxy
+			"/subtitles/", // Subtitle streaming proxy
+			"/stream/",    // Reel streaming proxy
+			"/gallery/",   // Gallery streaming proxy
+		},
+	}
+}
+
+// TimeoutMiddleware ใส่ deadline ให้ request context (c.UserContext())
+// services/repositories ที่ใช้ ctx จะถูก cancel เมื่อหมดเวลา และ response จะเป็น 504
+// ต้องวางหลัง RequestIDMiddleware เพื่อไม่ให้ context ถูกเขียนทับ
+func TimeoutMiddleware(cfg TimeoutConfig) fiber.Handler {
+	uploadRoutes := make([]routePattern, 0, len(cfg.UploadRoutes))
+	for _, r := range cfg.UploadRoutes {
+		if p, ok := parseRoutePattern(r); ok {
+			uploadRoutes = append(uploadRoutes, p)
+		}
+	}
+
+	return func(c *fiber.Ctx) error {
+		path := c.Path()
+		for _, prefix := range cfg.ExemptPrefixes {
+			if strings.HasPrefix(path, prefix) {
+				return c.Next()
+			}
+		}
+
+		timeout := cfg.Default
+		if isUploadRoute(uploadRoutes, c.Method(), c.Route().Path, path) {
+			timeout = cfg.Upload
+		}
+		if timeout <= 0 {
+			return c.Next()
+		}
+
+		ctx, cancel := context.WithTimeout(c.UserContext(), timeout)
+		defer cancel()
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+
+		// หมดเวลา → ตอบ 504 แทน error/response เดิมของ handler
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			logger.WarnContext(ctx, "Request timed out",
+				"method", c.Method(),
+				"path", path,
+				"timeout", timeout.String(),
+			)
+			return utils.GatewayTimeoutResponse(c)
+		}
+
+		return err
+	}
+}
+
+// routePattern route ที่ใช้ Upload timeout แยกเป็น method + segments
+type routePattern struct {
+	method   string
+	route    string
+	segments []string
+}
+
+func parseRoutePattern(r string) (routePattern, bool) {
+	method, route, ok := strings.Cut(strings.TrimSpace(r), " ")
+	if !ok {
+		return routePattern{}, false
+	}
+	route = strings.TrimSuffix(strings.TrimSpace(route), "/")
+	return routePattern{
+		method:   strings.ToUpper(method),
+		route:    route,
+		segments: strings.Split(route, "/"),
+	}, true
+}
+
+// isUploadRoute เทียบกับ route ที่ match (c.Route().Path) ก่อน
+// middleware ที่ลงทะเบียนด้วย app.Use จะได้ route ของ Use เอง (ไม่ใช่ route ปลายทาง)
+// จึงเทียบ path จริงกับ pattern ทีละ segment ด้วย (":param" = segment ใดก็ได้)
+func isUploadRoute(patterns []routePattern, method, routePath, path string) bool {
+	routePath = strings.TrimSuffix(routePath, "/")
+	segments := strings.Split(strings.TrimSuffix(path, "/"), "/")
+
+	for _, p := range patterns {
+		if p.method != method {
+			continue
+		}
+		if p.route == routePath || matchSegments(p.segments, segments) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) != len(segments) {
+		return false
+	}
+	for i, seg := range pattern {
+		if strings.HasPrefix(seg, ":") {
+			if segments[i] == "" {
+				return false
+			}
+			continue
+		}
+		if seg != segments[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -19,6 +19,9 @@ func SetupDirectUploadRoutes(api fiber.Router, h *handlers.Handlers) {
 	// POST /api/v1/direct-upload/complete - รวม parts และ auto-queue transcode
 	protected.Post("/complete", h.DirectUploadHandler.CompleteUpload)
 
+	// POST /api/v1/direct-upload/notify - แจ้งว่าอัปโหลดผ่าน presigned URL เสร็จ (ตรวจไฟล์ + สร้าง video + auto-queue)
+	protected.Post("/notify", h.DirectUploadHandler.NotifyDirectUploadComplete)
+
 	// DELETE /api/v1/direct-upload/abort - ยกเลิก upload ที่ค้าง
 	protected.Delete("/abort", h.DirectUploadHandler.AbortUpload)
 
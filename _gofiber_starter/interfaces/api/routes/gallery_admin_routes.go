@@ -23,4 +23,18 @@ func SetupGalleryAdminRoutes(api fiber.Router, h *handlers.Handlers) {
 
 	// Publish gallery (set status = ready)
 	adminGallery.Post("/:id/gallery/publish", h.GalleryAdminHandler.PublishGallery)
+
+	// Cover override สำหรับ SEO article
+	adminGallery.Put("/:id/gallery/cover", h.GalleryAdminHandler.SetCover)
+	adminGallery.Delete("/:id/gallery/cover", h.GalleryAdminHandler.ClearCover)
+
+	// แก้ gallery counts ใน DB ให้ตรงกับไฟล์จริง
+	adminGallery.Post("/:id/gallery/reconcile", h.GalleryAdminHandler.ReconcileCounts)
+
+	// สถิติ classification รวมทุก gallery (ใช้ปรับ threshold)
+	galleryStats := api.Group("/admin/gallery", middleware.Protected())
+	galleryStats.Get("/classification-stats", h.GalleryAdminHandler.GetClassificationStats)
+
+	// reconcile gallery counts ทุกวิดีโอใน background (ตัวเดียวกับ scheduled job) → 202
+	galleryStats.Post("/reconcile", h.GalleryAdminHandler.ReconcileAllCounts)
 }
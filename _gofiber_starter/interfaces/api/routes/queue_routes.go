@@ -19,6 +19,7 @@ func SetupQueueRoutes(api fiber.Router, h *handlers.Handlers) {
 	transcode.Get("/failed", h.QueueHandler.GetTranscodeFailed)
 	transcode.Post("/retry-all", h.QueueHandler.RetryTranscodeFailed)
 	transcode.Post("/:id/retry", h.QueueHandler.RetryTranscodeOne)
+	transcode.Post("/:id/bump", h.QueueHandler.BumpTranscode) // ย้ายไปคิว priority
 
 	// Subtitle queue
 	subtitle := admin.Group("/subtitle")
@@ -38,12 +39,23 @@ func SetupQueueRoutes(api fiber.Router, h *handlers.Handlers) {
 	// Gallery queue
 	gallery := admin.Group("/gallery")
 	gallery.Get("/processing", h.QueueHandler.GetGalleryProcessing)
+	gallery.Get("/status/:status", h.QueueHandler.GetGalleryByStatus) // pending_review = คิวรอ admin เลือกภาพ
 	gallery.Get("/failed", h.QueueHandler.GetGalleryFailed)
 	gallery.Post("/retry-all", h.QueueHandler.RetryGalleryAll)
+	gallery.Get("/missing", h.QueueHandler.GetGalleryMissing)
+	gallery.Post("/backfill", h.QueueHandler.BackfillGalleries)
+	gallery.Post("/:id/bump", h.QueueHandler.BumpGallery) // ย้ายไปคิว priority
+
+	// SEO queue (bulk enqueue ไป SEO worker)
+	seo := admin.Group("/seo")
+	seo.Post("/enqueue", h.QueueHandler.EnqueueSEOArticles)
 
 	// Reel queue
 	reel := admin.Group("/reel")
 	reel.Get("/exporting", h.QueueHandler.GetReelExporting)
 	reel.Get("/failed", h.QueueHandler.GetReelFailed)
 	reel.Post("/retry-all", h.QueueHandler.RetryReelAll)
+
+	// ยกเลิก job (transcode, gallery, seo) - ลงทะเบียนท้ายสุด ไม่ให้ :jobType ทับ route ข้างบน
+	admin.Post("/:jobType/:id/cancel", h.QueueHandler.CancelJob)
 }
@@ -28,6 +28,11 @@ func SetupSettingRoutes(api fiber.Router, h *handlers.Handlers) {
 	// POST /api/v1/settings/reload-cache
 	settings.Post("/reload-cache", h.SettingHandler.ReloadCache)
 
+	// Default transcode qualities (parse + validate กับ 1080p/720p/480p/360p)
+	// GET/PUT /api/v1/settings/transcoding/qualities
+	settings.Get("/transcoding/qualities", h.SettingHandler.GetDefaultQualities)
+	settings.Put("/transcoding/qualities", h.SettingHandler.UpdateDefaultQualities)
+
 	// Get settings by category
 	// GET /api/v1/settings/:category
 	settings.Get("/:category", h.SettingHandler.GetByCategory)
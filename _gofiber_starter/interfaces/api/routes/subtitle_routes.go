@@ -22,6 +22,7 @@ func SetupSubtitleRoutes(api fiber.Router, h *handlers.Handlers) {
 
 	// Job started callback (สำหรับ queue → processing transition)
 	internal.Post("/subtitles/job-started", h.SubtitleHandler.JobStarted) // callback เมื่อ worker เริ่มทำ job
+	internal.Post("/subtitles/heartbeat", h.SubtitleHandler.JobHeartbeat) // heartbeat ระหว่างทำ job (stuck detection)
 
 	// Callback สำหรับ detect language (ใช้ video_id)
 	internal.Post("/videos/:id/subtitle/callback/detect", h.SubtitleHandler.DetectComplete) // callback เมื่อ detect เสร็จ
@@ -40,13 +41,24 @@ func SetupSubtitleRoutes(api fiber.Router, h *handlers.Handlers) {
 	protected.Post("/:id/subtitle/transcribe", h.SubtitleHandler.TriggerTranscribe)  // trigger transcribe
 	protected.Post("/:id/subtitle/translate", h.SubtitleHandler.TriggerTranslation)  // trigger translation
 
+	// นำเข้า subtitle ภายนอก (srt/vtt/ass → SRT, type = imported)
+	protected.Post("/:id/subtitle/import", h.SubtitleHandler.ImportSubtitle)
+
+	// Download SRT ทุกภาษาเป็น zip (<code>.<lang>.srt + MANIFEST.txt)
+	protected.Get("/:id/subtitles/download", h.SubtitleHandler.DownloadAllSubtitles)
+
 	// === Subtitle Management Routes (Protected) ===
 	subtitlesProtected := subtitles.Group("", middleware.Protected())
 	subtitlesProtected.Delete("/:id", h.SubtitleHandler.DeleteSubtitle)              // ลบ subtitle
 	subtitlesProtected.Get("/:id/content", h.SubtitleHandler.GetSubtitleContent)     // ดึง content ของ subtitle (SRT)
 	subtitlesProtected.Put("/:id/content", h.SubtitleHandler.UpdateSubtitleContent)  // อัปเดต content ของ subtitle (SRT)
+	subtitlesProtected.Get("/:id/diff", h.SubtitleHandler.GetSubtitleDiff)           // เทียบกับ version ก่อนแก้ไข
 
 	// === Admin Routes (Protected) ===
 	admin := api.Group("/admin", middleware.Protected())
 	admin.Post("/subtitles/retry-stuck", h.SubtitleHandler.RetryStuckSubtitles) // retry stuck subtitles ทั้งหมด
+
+	// Bulk transcribe videos ที่ยังไม่มี subtitle
+	admin.Get("/subtitles/missing", h.SubtitleHandler.ListMissingSubtitles)                   // videos ที่ยังไม่มี subtitle
+	admin.Post("/subtitles/transcribe-missing", h.SubtitleHandler.TranscribeMissingSubtitles) // bulk transcribe (rate limited)
 }
@@ -13,11 +13,18 @@ func SetupVideoRoutes(api fiber.Router, h *handlers.Handlers) {
 	videos.Get("/ready", h.VideoHandler.ListReady)            // ดึงเฉพาะ videos ที่พร้อม stream
 	videos.Get("/code/:code", h.VideoHandler.GetByCode)       // ดึง video ตาม code (สำหรับ embed)
 	videos.Get("/embed/:code", h.VideoHandler.GetEmbed)       // ดึงข้อมูลสำหรับ embed player
+	videos.Get("/:id/qualities", middleware.Optional(), h.HLSHandler.GetQualities) // qualities + playlist URL สำหรับ player (public), size_bytes เฉพาะที่ login (:id หรือ code)
+	videos.Get("/:id/related", h.RelatedVideoHandler.GetRelated) // videos ที่คล้ายกัน (embedding similarity)
 
 	// Internal routes (for worker callbacks)
 	internal := api.Group("/internal/videos")
 	internal.Patch("/:id/gallery", h.VideoHandler.UpdateGallery) // Worker callback เมื่อ gallery เสร็จ
 
+	// Internal routes ที่ต้อง auth (worker login ผ่าน AuthClient แล้วส่ง Bearer token)
+	internalProtected := internal.Group("", middleware.Protected())
+	internalProtected.Get("/:id/gallery/external", h.VideoHandler.ListExternalGallery)     // ภาพจากค่ายที่รอ classify
+	internalProtected.Delete("/:id/gallery/external", h.VideoHandler.ClearExternalGallery) // ลบภาพจากค่ายหลัง classify เสร็จ
+
 	// Protected routes (ต้อง login)
 	protected := videos.Group("", middleware.Protected())
 	protected.Post("/", h.VideoHandler.Upload)                // อัปโหลดวิดีโอใหม่
@@ -31,13 +38,24 @@ func SetupVideoRoutes(api fiber.Router, h *handlers.Handlers) {
 	// ต้องอยู่ก่อน /:id routes เพื่อไม่ให้ "dlq" ถูกจับเป็น :id
 	dlq := protected.Group("/dlq")
 	dlq.Get("/", h.VideoHandler.ListDLQ)                      // ดึง videos ที่อยู่ใน DLQ
+	dlq.Get("/:id", h.JobEventHandler.GetDLQJobDetail)        // รายละเอียด + job payload (redacted)
 	dlq.Post("/:id/retry", h.VideoHandler.RetryDLQ)           // Retry video จาก DLQ
 	dlq.Delete("/:id", h.VideoHandler.DeleteDLQ)              // ลบ video จาก DLQ
 
+	// Cache Management - Admin only
+	protected.Post("/cache/purge", middleware.AdminOnly(), h.VideoHandler.PurgeAllCache)             // ลบ cache ของ video ทั้งหมด
+	protected.Post("/code/:code/refresh-cache", middleware.AdminOnly(), h.VideoHandler.RefreshCache) // ลบแล้วโหลด cache ใหม่จาก DB
+
+	// HLS Repair - Admin only
+	protected.Post("/code/:code/rebuild-master", h.VideoHandler.RebuildMasterPlaylist) // สร้าง master.m3u8 ใหม่จาก variants ที่มีอยู่
+
 	// Parameterized routes - ต้องอยู่หลัง specific routes
 	protected.Get("/:id", h.VideoHandler.GetByID)             // ดึง video ตาม ID
 	protected.Put("/:id", h.VideoHandler.Update)              // อัปเดต video
 	protected.Delete("/:id", h.VideoHandler.Delete)           // ลบ video
 	protected.Post("/:id/generate-gallery", h.VideoHandler.GenerateGallery)     // สร้าง gallery จาก HLS
 	protected.Post("/:id/regenerate-gallery", h.VideoHandler.RegenerateGallery) // สร้าง gallery ใหม่ (ลบเก่าแล้วสร้างใหม่)
+	protected.Post("/:id/gallery/external", h.VideoHandler.UploadExternalGallery) // แทน gallery ด้วยภาพจากค่าย (classify เหมือนเดิม)
+	protected.Get("/:id/timeline", h.JobEventHandler.GetJobTimeline)            // ดึง timeline ของทุก pipeline stage (audit trail)
+	protected.Get("/:id/cost", h.VideoHandler.GetVideoCost)                     // processing cost ของทุก worker job (tokens, TTS, classifier)
 }
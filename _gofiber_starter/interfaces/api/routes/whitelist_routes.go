@@ -28,7 +28,10 @@ func SetupWhitelistRoutes(api fiber.Router, h *handlers.Handlers) {
 
 	// Domain Management
 	profiles.Post("/:id/domains", h.WhitelistHandler.AddDomain)
+	profiles.Post("/:id/domains/import", h.WhitelistHandler.ImportDomains) // import หลาย domain (JSON/newline/CSV)
+	profiles.Get("/:id/domains/export", h.WhitelistHandler.ExportDomains)  // export domains (?format=txt)
 	whitelist.Delete("/domains/:id", h.WhitelistHandler.RemoveDomain)
+	whitelist.Post("/match-test", h.WhitelistHandler.TestDomainMatch) // ทดสอบ domain matching (debug)
 
 	// Preroll Ads Management
 	profiles.Post("/:id/prerolls", h.WhitelistHandler.AddPrerollAd)
@@ -46,6 +49,7 @@ func SetupWhitelistRoutes(api fiber.Router, h *handlers.Handlers) {
 	ads := api.Group("/ads", middleware.Protected())
 	ads.Get("/stats", h.WhitelistHandler.GetAdStats)
 	ads.Get("/stats/profile/:id", h.WhitelistHandler.GetAdStatsByProfile)
+	ads.Get("/stats/profile/:id/daily", h.WhitelistHandler.GetProfileStats)
 	ads.Get("/stats/devices", h.WhitelistHandler.GetDeviceStats)
 	ads.Get("/stats/ranking", h.WhitelistHandler.GetProfileRanking)
 	ads.Get("/stats/skip-distribution", h.WhitelistHandler.GetSkipTimeDistribution)
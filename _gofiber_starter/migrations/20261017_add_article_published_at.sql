@@ -0,0 +1,19 @@
+-- Migration: Add article_published_at to videos and backfill from SEO job events
+-- Date: 2026-10-17
+-- Purpose: bulk SEO enqueue (missingArticle) skips videos that already have an article
+
+-- Add column (AutoMigrate also adds it - IF NOT EXISTS keeps this safe to re-run)
+ALTER TABLE videos ADD COLUMN IF NOT EXISTS article_published_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN videos.article_published_at IS 'Last time the SEO worker published the article (set from job event seo/completed)';
+
+-- Backfill: videos published before the column existed only have job_events seo/completed
+UPDATE videos v
+SET article_published_at = e.published_at
+FROM (
+    SELECT video_id, MAX(created_at) AS published_at
+    FROM job_events
+    WHERE source = 'seo' AND stage = 'completed'
+    GROUP BY video_id
+) e
+WHERE v.id = e.video_id AND v.article_published_at IS NULL;
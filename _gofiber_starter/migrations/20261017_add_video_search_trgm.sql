@@ -0,0 +1,14 @@
+-- Migration: Add trigram indexes for video search
+-- Date: 2026-10-17
+-- Description: Ranked, typo-tolerant search on title/code/description (GET /videos?search=)
+-- Trigram ใช้กับภาษาไทยได้ (ไม่ต้องตัดคำเหมือน tsvector)
+-- ต้องรันนอก transaction (CREATE INDEX CONCURRENTLY) เช่น psql -f แบบไม่ใส่ --single-transaction
+-- API ไม่สร้างให้ตอน startup - search ใช้ไม่ได้จนกว่าจะรันไฟล์นี้
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- GIN trigram indexes (รองรับทั้ง ILIKE '%...%' และ word_similarity operator <%)
+-- CONCURRENTLY = ไม่ล็อก writes ของ videos ระหว่าง build
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_videos_title_trgm ON videos USING gin (title gin_trgm_ops);
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_videos_code_trgm ON videos USING gin (code gin_trgm_ops);
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_videos_description_trgm ON videos USING gin (description gin_trgm_ops);
@@ -18,6 +18,33 @@ type Config struct {
 	Google   GoogleOAuthConfig
 	Storage  StorageConfig
 	Stream   StreamConfig // Stream cookie และ R2 settings
+	CORS     CORSConfig
+	SEO      SEOWorkerConfig // related videos จาก SEO worker
+}
+
+// SEOWorkerConfig schema server ของ SEO worker (article_embeddings อยู่ DB ของ SEO)
+type SEOWorkerConfig struct {
+	URL   string // เช่น http://seo-worker:8090 (ว่าง = ปิด related videos)
+	Token string // ต้องตรงกับ SCHEMA_HTTP_RELATED_TOKEN ของ SEO worker
+}
+
+// CORSConfig CORS ของ API (comma-separated) - ว่าง = ค่า default ใน middleware.DefaultCorsConfig
+type CORSConfig struct {
+	AllowOrigins     string
+	AllowMethods     string
+	AllowHeaders     string
+	AllowCredentials *bool // nil = default (true)
+
+	Embed CORSOverride // /embed, /api/v1/embed, /api/v1/hls - player บนเว็บ partner (origin กว้างกว่า)
+	Admin CORSOverride // /api/v1/admin - admin panel (ล็อก origin)
+}
+
+// CORSOverride CORS เฉพาะ route group - field ว่าง/nil = ใช้ค่าจาก CORSConfig
+type CORSOverride struct {
+	AllowOrigins     string
+	AllowMethods     string
+	AllowHeaders     string
+	AllowCredentials *bool
 }
 
 // RedisConfig สำหรับ cache whitelist lookups
@@ -36,9 +63,11 @@ type StreamConfig struct {
 }
 
 type AppConfig struct {
-	Name string
-	Port string
-	Env  string
+	Name              string
+	Port              string
+	Env               string
+	RequestTimeoutSec int // timeout ของ request context ปกติ (0 = ไม่จำกัด)
+	UploadTimeoutSec  int // timeout สำหรับ upload routes
 }
 
 type DatabaseConfig struct {
@@ -52,7 +81,9 @@ type DatabaseConfig struct {
 
 // NATSConfig configuration สำหรับ NATS JetStream
 type NATSConfig struct {
-	URL string // nats://localhost:4222
+	URL            string // nats://localhost:4222
+	PriorityQueues bool   // ส่ง job urgent (transcode, gallery) ไป subject ".priority" (เปิดเมื่อ worker ทุกตัว consume priority subject แล้ว)
+	SEOPriority    bool   // ส่ง SEO job urgent ไป ".priority" (ตรงกับ SEO_PRIORITY_QUEUE ของ SEO worker)
 }
 
 type JWTConfig struct {
@@ -93,9 +124,18 @@ type StorageConfig struct {
 	// Transcoding Settings
 	TranscodeQualities []string // ความละเอียดที่ต้องการ ["1080p", "720p", "480p"]
 
+	// Gallery: HLS variant ที่ใช้ดึงภาพ (e.g. "720p") - ไม่ตั้ง/ไม่มี variant นี้ = ใช้ quality สูงสุด
+	GalleryQuality string
+
+	// Gallery reconcile: cron ที่แก้ gallery counts ให้ตรงกับไฟล์จริง ("" = ไม่รันอัตโนมัติ)
+	GalleryReconcileCron string
+
 	// CDN/Cloudflare Worker สำหรับ HLS streaming
 	CDNBaseURL string // URL ของ Cloudflare Worker (เช่น https://hls.yourdomain.com)
 
+	// ล้าง cache ที่ CDN เมื่อวิดีโอถูกแก้/ลบ (ไม่ตั้ง = ไม่ purge, รอ TTL หมดเอง)
+	CDNPurge CDNPurgeConfig
+
 	// S3-Compatible Storage (MinIO / Cloudflare R2)
 	S3 S3Config
 }
@@ -110,6 +150,13 @@ type S3Config struct {
 	PublicURL       string // URL สำหรับเข้าถึงไฟล์ public (optional)
 }
 
+// CDNPurgeConfig Cloudflare cache purge API
+type CDNPurgeConfig struct {
+	ZoneID        string // Cloudflare zone ของ CDNBaseURL
+	APIToken      string // API token ที่มีสิทธิ์ Zone.Cache Purge
+	PurgeByPrefix bool   // purge ทั้ง folder ได้ (Enterprise plan) - false = purge ทีละไฟล์
+}
+
 func LoadConfig() (*Config, error) {
 	err := godotenv.Load()
 	if err != nil {
@@ -130,14 +177,20 @@ func LoadConfig() (*Config, error) {
 	// Redis config
 	redisDB, _ := strconv.Atoi(getEnv("REDIS_DB", "0"))
 
+	// Request timeout config
+	requestTimeout, _ := strconv.Atoi(getEnv("APP_REQUEST_TIMEOUT", "30")) // 30 seconds default
+	uploadTimeout, _ := strconv.Atoi(getEnv("APP_UPLOAD_TIMEOUT", "3600")) // 1 hour default
+
 	// Stream cookie config
 	cookieMaxAge, _ := strconv.Atoi(getEnv("STREAM_COOKIE_MAX_AGE", "7200")) // 2 hours default
 
 	config := &Config{
 		App: AppConfig{
-			Name: getEnv("APP_NAME", "Suekk Stream"),
-			Port: getEnv("APP_PORT", "8080"),
-			Env:  getEnv("APP_ENV", "development"),
+			Name:              getEnv("APP_NAME", "Suekk Stream"),
+			Port:              getEnv("APP_PORT", "8080"),
+			Env:               getEnv("APP_ENV", "development"),
+			RequestTimeoutSec: requestTimeout,
+			UploadTimeoutSec:  uploadTimeout,
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -148,7 +201,9 @@ func LoadConfig() (*Config, error) {
 			SSLMode:  getEnv("DB_SSL_MODE", "disable"),
 		},
 		NATS: NATSConfig{
-			URL: getEnv("NATS_URL", "nats://localhost:4222"),
+			URL:            getEnv("NATS_URL", "nats://localhost:4222"),
+			PriorityQueues: getEnv("NATS_PRIORITY_QUEUES", "false") == "true",
+			SEOPriority:    getEnv("NATS_SEO_PRIORITY_QUEUE", "false") == "true",
 		},
 		Redis: RedisConfig{
 			URL:      getEnv("REDIS_URL", "redis://localhost:6379"),
@@ -161,6 +216,28 @@ func LoadConfig() (*Config, error) {
 			CookieDomain: getEnv("STREAM_COOKIE_DOMAIN", ".suekk.com"),
 			CookieMaxAge: cookieMaxAge,
 		},
+		CORS: CORSConfig{
+			AllowOrigins:     getEnv("CORS_ALLOW_ORIGINS", ""),
+			AllowMethods:     getEnv("CORS_ALLOW_METHODS", ""),
+			AllowHeaders:     getEnv("CORS_ALLOW_HEADERS", ""),
+			AllowCredentials: getEnvBoolPtr("CORS_ALLOW_CREDENTIALS"),
+			Embed: CORSOverride{
+				AllowOrigins:     getEnv("CORS_EMBED_ALLOW_ORIGINS", ""),
+				AllowMethods:     getEnv("CORS_EMBED_ALLOW_METHODS", ""),
+				AllowHeaders:     getEnv("CORS_EMBED_ALLOW_HEADERS", ""),
+				AllowCredentials: getEnvBoolPtr("CORS_EMBED_ALLOW_CREDENTIALS"),
+			},
+			Admin: CORSOverride{
+				AllowOrigins:     getEnv("CORS_ADMIN_ALLOW_ORIGINS", ""),
+				AllowMethods:     getEnv("CORS_ADMIN_ALLOW_METHODS", ""),
+				AllowHeaders:     getEnv("CORS_ADMIN_ALLOW_HEADERS", ""),
+				AllowCredentials: getEnvBoolPtr("CORS_ADMIN_ALLOW_CREDENTIALS"),
+			},
+		},
+		SEO: SEOWorkerConfig{
+			URL:   getEnv("SEO_WORKER_URL", ""),
+			Token: getEnv("SEO_WORKER_TOKEN", ""),
+		},
 		JWT: JWTConfig{
 			Secret: getEnv("JWT_SECRET", "your-secret-key"),
 		},
@@ -181,17 +258,24 @@ func LoadConfig() (*Config, error) {
 			FrontendURL:  getEnv("FRONTEND_URL", "http://localhost:5173"),
 		},
 		Storage: StorageConfig{
-			Type:               getEnv("STORAGE_TYPE", "local"),
-			BasePath:           getEnv("STORAGE_BASE_PATH", "./uploads"),
-			BaseURL:            getEnv("STORAGE_BASE_URL", "http://localhost:8080/files"),
-			VideoPath:          getEnv("STORAGE_VIDEO_PATH", "./videos"),
-			TempPath:           getEnv("STORAGE_TEMP_PATH", "./temp"),
-			FFmpegPath:         getEnv("FFMPEG_PATH", "ffmpeg"),
-			MaxUploadSize:      maxUploadSize,
-			CleanupOriginal:    cleanupOriginal,
-			QuotaTotal:         quotaTotal,
-			TranscodeQualities: transcodeQualities,
-			CDNBaseURL:         getEnv("CDN_BASE_URL", ""), // Cloudflare Worker URL
+			Type:                 getEnv("STORAGE_TYPE", "local"),
+			BasePath:             getEnv("STORAGE_BASE_PATH", "./uploads"),
+			BaseURL:              getEnv("STORAGE_BASE_URL", "http://localhost:8080/files"),
+			VideoPath:            getEnv("STORAGE_VIDEO_PATH", "./videos"),
+			TempPath:             getEnv("STORAGE_TEMP_PATH", "./temp"),
+			FFmpegPath:           getEnv("FFMPEG_PATH", "ffmpeg"),
+			MaxUploadSize:        maxUploadSize,
+			CleanupOriginal:      cleanupOriginal,
+			QuotaTotal:           quotaTotal,
+			TranscodeQualities:   transcodeQualities,
+			GalleryQuality:       getEnv("GALLERY_PREFERRED_QUALITY", ""),
+			GalleryReconcileCron: getEnv("GALLERY_RECONCILE_CRON", "30 4 * * *"),
+			CDNBaseURL:           getEnv("CDN_BASE_URL", ""), // Cloudflare Worker URL
+			CDNPurge: CDNPurgeConfig{
+				ZoneID:        getEnv("CDN_PURGE_ZONE_ID", ""),
+				APIToken:      getEnv("CDN_PURGE_API_TOKEN", ""),
+				PurgeByPrefix: getEnv("CDN_PURGE_BY_PREFIX", "false") == "true",
+			},
 			S3: S3Config{
 				Endpoint:  getEnv("S3_ENDPOINT", "localhost:9000"),
 				AccessKey: getEnv("S3_ACCESS_KEY", "minioadmin"),
@@ -215,6 +299,16 @@ func getEnv(key, defaultValue string) string {
 	return value
 }
 
+// getEnvBoolPtr อ่าน bool env ("true"/"false") - ไม่ได้ตั้ง = nil (ใช้ค่า default)
+func getEnvBoolPtr(key string) *bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil
+	}
+	b := value == "true"
+	return &b
+}
+
 // parseQualities แปลง comma-separated string เป็น slice
 // เช่น "1080p,720p,480p" -> ["1080p", "720p", "480p"]
 func parseQualities(s string) []string {
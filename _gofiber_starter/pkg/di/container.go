@@ -9,10 +9,12 @@ import (
 	"gofiber-template/domain/ports"
 	"gofiber-template/domain/repositories"
 	"gofiber-template/domain/services"
+	"gofiber-template/infrastructure/cdn"
 	"gofiber-template/infrastructure/messaging"
 	natspkg "gofiber-template/infrastructure/nats"
 	"gofiber-template/infrastructure/postgres"
 	redispkg "gofiber-template/infrastructure/redis"
+	"gofiber-template/infrastructure/seoworker"
 	"gofiber-template/infrastructure/storage"
 	"gofiber-template/infrastructure/telegram"
 	"gofiber-template/infrastructure/transcoder"
@@ -43,19 +45,23 @@ type Container struct {
 	StreamCookieService *serviceimpl.StreamCookieService // Signed cookie สำหรับ CDN access
 
 	// Repositories
-	UserRepository             repositories.UserRepository
-	TaskRepository             repositories.TaskRepository
-	FileRepository             repositories.FileRepository
-	JobRepository              repositories.JobRepository
-	VideoRepository            repositories.VideoRepository
-	CategoryRepository         repositories.CategoryRepository
-	AllowedDomainRepository    repositories.AllowedDomainRepository
-	WhitelistRepository        repositories.WhitelistRepository
-	AdStatsRepository          repositories.AdStatsRepository
-	SettingRepository          repositories.SettingRepository
-	SubtitleRepository         repositories.SubtitleRepository
-	ReelRepository             repositories.ReelRepository
-	ReelTemplateRepository     repositories.ReelTemplateRepository
+	UserRepository              repositories.UserRepository
+	TaskRepository              repositories.TaskRepository
+	FileRepository              repositories.FileRepository
+	JobRepository               repositories.JobRepository
+	VideoRepository             repositories.VideoRepository
+	CategoryRepository          repositories.CategoryRepository
+	AllowedDomainRepository     repositories.AllowedDomainRepository
+	WhitelistRepository         repositories.WhitelistRepository
+	AdStatsRepository           repositories.AdStatsRepository
+	SettingRepository           repositories.SettingRepository
+	SubtitleRepository          repositories.SubtitleRepository
+	ReelRepository              repositories.ReelRepository
+	ReelTemplateRepository      repositories.ReelTemplateRepository
+	JobEventRepository          repositories.JobEventRepository
+	ProcessingCostRepository    repositories.ProcessingCostRepository
+	DLQEntryRepository          repositories.DLQEntryRepository
+	GalleryImageScoreRepository repositories.GalleryImageScoreRepository
 
 	// Services
 	UserService            services.UserService
@@ -71,6 +77,10 @@ type Container struct {
 	SubtitleService        services.SubtitleService
 	QueueService           services.QueueService
 	ReelService            services.ReelService
+	JobEventService        services.JobEventService
+	RelatedVideoService    services.RelatedVideoService
+	GalleryReconcileService services.GalleryReconcileService
+	GalleryStatsService     services.GalleryStatsService
 
 	// Settings Cache
 	SettingsCache *settings.SettingsCache
@@ -78,6 +88,7 @@ type Container struct {
 	// WebSocket & Broadcasting
 	NATSSubscriber       *natspkg.Subscriber            // NATS Pub/Sub subscriber
 	ProgressBroadcaster  *websocket.ProgressBroadcaster // Progress → WebSocket
+	JobEventSubscriber   *natspkg.JobEventSubscriber    // events.job/cost.* จาก SEO worker → job_events, processing_costs
 
 	// Messaging Ports (Clean Architecture interfaces)
 	JobQueue           ports.JobQueuePort           // Job queue abstraction
@@ -87,6 +98,9 @@ type Container struct {
 	// Notifications
 	Notifier      ports.NotifierPort       // Telegram/Email notifications
 	DLQSubscriber *natspkg.DLQSubscriber   // DLQ notification subscriber
+
+	// CDN edge cache purge (Cloudflare) - ไม่ได้ตั้ง config = no-op
+	CDNPurger ports.CDNPurgerPort
 }
 
 func NewContainer() *Container {
@@ -134,12 +148,28 @@ func (c *Container) Initialize() error {
 		c.NATSPublisher,     // GalleryJobPublisher
 		c.NATSClient,        // SubtitleStreamPurger
 	)
-	logger.Info("Queue service initialized")
+	if svc, ok := c.QueueService.(*serviceimpl.QueueServiceImpl); ok {
+		svc.SetGalleryQuality(c.Config.Storage.GalleryQuality)
+		if c.Config.NATS.PriorityQueues && c.NATSClient != nil {
+			svc.SetQueuedJobRemover(c.NATSClient)
+		}
+		if c.NATSClient != nil {
+			svc.SetJobCanceller(c.NATSClient)
+		}
+		if c.NATSPublisher != nil {
+			svc.SetSEOJobPublisher(c.NATSPublisher, c.JobEventRepository)
+		}
+	}
+	logger.Info("Queue service initialized", "gallery_quality", c.Config.Storage.GalleryQuality)
 
 	if err := c.initStorageCleanup(); err != nil {
 		return err
 	}
 
+	if err := c.initGalleryReconcile(); err != nil {
+		return err
+	}
+
 	if err := c.initStuckDetector(); err != nil {
 		return err
 	}
@@ -154,6 +184,7 @@ func (c *Container) Initialize() error {
 
 	// Inject notifier หลังจาก initNotifications
 	c.injectNotifierToProgressBroadcaster()
+	c.injectNotifierToSubtitleService()
 
 	return nil
 }
@@ -249,7 +280,9 @@ func (c *Container) initInfrastructure() error {
 	} else {
 		c.NATSClient = natsClient
 		c.NATSPublisher = natspkg.NewPublisher(natsClient)
-		logger.Info("NATS client initialized", "url", c.Config.NATS.URL)
+		c.NATSPublisher.SetPriorityQueues(c.Config.NATS.PriorityQueues)
+		c.NATSPublisher.SetSEOPriorityQueue(c.Config.NATS.SEOPriority)
+		logger.Info("NATS client initialized", "url", c.Config.NATS.URL, "priority_queues", c.Config.NATS.PriorityQueues, "seo_priority_queue", c.Config.NATS.SEOPriority)
 
 		// Initialize Messaging Ports (Clean Architecture)
 		c.initMessagingPorts()
@@ -355,6 +388,11 @@ func (c *Container) initRepositories() error {
 	// Reel Generator
 	c.ReelRepository = postgres.NewReelRepository(c.DB)
 	c.ReelTemplateRepository = postgres.NewReelTemplateRepository(c.DB)
+	// Pipeline event log (job timeline)
+	c.JobEventRepository = postgres.NewJobEventRepository(c.DB)
+	c.ProcessingCostRepository = postgres.NewProcessingCostRepository(c.DB)
+	c.DLQEntryRepository = postgres.NewDLQEntryRepository(c.DB)
+	c.GalleryImageScoreRepository = postgres.NewGalleryImageScoreRepository(c.DB)
 	logger.Info("Repositories initialized")
 	return nil
 }
@@ -379,13 +417,14 @@ func (c *Container) initServices() error {
 			c.UserRepository,
 			c.SubtitleRepository,
 			c.ReelRepository,
+			c.ProcessingCostRepository,
 			c.Storage,
 			c.RedisClient,
 			c.Config,
 		)
 		logger.Info("Video service initialized with Redis cache")
 	} else {
-		c.VideoService = serviceimpl.NewVideoService(c.VideoRepository, c.CategoryRepository, c.UserRepository, c.SubtitleRepository, c.ReelRepository, c.Storage, c.Config)
+		c.VideoService = serviceimpl.NewVideoService(c.VideoRepository, c.CategoryRepository, c.UserRepository, c.SubtitleRepository, c.ReelRepository, c.ProcessingCostRepository, c.Storage, c.Config)
 		logger.Info("Video service initialized without cache")
 	}
 
@@ -416,7 +455,7 @@ func (c *Container) initServices() error {
 	}
 
 	// Subtitle Service with NATS job publisher and storage
-	c.SubtitleService = serviceimpl.NewSubtitleService(c.VideoRepository, c.SubtitleRepository, c.NATSPublisher, c.Storage)
+	c.SubtitleService = serviceimpl.NewSubtitleService(c.VideoRepository, c.SubtitleRepository, c.NATSPublisher, c.Storage, c.SettingService)
 	logger.Info("Subtitle service initialized", "has_publisher", c.NATSPublisher != nil)
 
 	// Reel Service with NATS job publisher and storage (for delete files)
@@ -427,6 +466,27 @@ func (c *Container) initServices() error {
 	c.ReelService = serviceimpl.NewReelService(c.ReelRepository, c.ReelTemplateRepository, c.VideoRepository, reelPublisher, c.Storage)
 	logger.Info("Reel service initialized", "has_publisher", reelPublisher != nil, "has_storage", c.Storage != nil)
 
+	// Job Event Service (pipeline timeline / audit trail)
+	c.JobEventService = serviceimpl.NewJobEventService(c.JobEventRepository, c.DLQEntryRepository, c.VideoRepository)
+
+	// Gallery Stats Service (สถิติ classification สำหรับปรับ threshold)
+	c.GalleryStatsService = serviceimpl.NewGalleryStatsService(c.GalleryImageScoreRepository)
+
+	// Related Video Service (embedding similarity - ถาม SEO worker, SEO_WORKER_URL ว่าง = list ว่าง)
+	c.RelatedVideoService = serviceimpl.NewRelatedVideoService(seoworker.NewRelatedClient(c.Config.SEO), c.VideoRepository)
+
+	// CDN purge - ล้าง edge cache เมื่อ status/gallery/subtitle เปลี่ยนหรือวิดีโอถูกลบ
+	c.CDNPurger = cdn.NewCloudflarePurger(c.Config.Storage.CDNBaseURL, c.Config.Storage.CDNPurge)
+	if c.CDNPurger.IsEnabled() {
+		if svc, ok := c.VideoService.(*serviceimpl.VideoServiceImpl); ok {
+			svc.SetCDNPurger(c.CDNPurger)
+		}
+		if svc, ok := c.SubtitleService.(*serviceimpl.SubtitleServiceImpl); ok {
+			svc.SetCDNPurger(c.CDNPurger)
+		}
+		logger.Info("CDN purger enabled", "by_prefix", c.CDNPurger.SupportsPrefix())
+	}
+
 	// Queue Service (unified queue management)
 	// Note: TranscodingService ต้องถูก init ก่อนใน initTranscoding()
 	// จึงย้ายไป init หลังจาก initTranscoding()
@@ -548,6 +608,30 @@ func (c *Container) initStorageCleanup() error {
 	return nil
 }
 
+func (c *Container) initGalleryReconcile() error {
+	// แก้ gallery counts ใน DB ให้ตรงกับไฟล์จริงใน storage (counts เพี้ยนเมื่อ update ล้มหลัง upload)
+	reconcileConfig := serviceimpl.GalleryReconcileConfig{
+		Cron: c.Config.Storage.GalleryReconcileCron,
+	}
+
+	c.GalleryReconcileService = serviceimpl.NewGalleryReconcileService(
+		reconcileConfig,
+		c.VideoRepository,
+		c.Storage,
+		c.EventScheduler,
+	)
+
+	if reconcileConfig.Cron == "" {
+		logger.Info("Gallery reconcile job disabled (GALLERY_RECONCILE_CRON empty)")
+	} else if err := c.GalleryReconcileService.RegisterReconcileJob(); err != nil {
+		logger.Warn("Failed to register gallery reconcile job", "error", err)
+	} else {
+		logger.Info("Gallery reconcile job registered", "cron", reconcileConfig.Cron)
+	}
+
+	return nil
+}
+
 func (c *Container) initStuckDetector() error {
 	// Initialize Stuck Detector Service
 	// ตรวจจับ jobs ที่ค้างและ mark เป็น failed อัตโนมัติ
@@ -578,7 +662,8 @@ func (c *Container) initStuckDetector() error {
 	// === Subtitle Stuck Detector ===
 	subtitleDetectorConfig := serviceimpl.SubtitleStuckDetectorConfig{
 		CheckInterval:     30 * time.Second, // ตรวจสอบทุก 30 วินาที
-		ProcessingTimeout: 10 * time.Minute, // ถ้า processing > 10 นาที = stuck (worker crash)
+		ProcessingTimeout: 10 * time.Minute, // worker ไม่มี heartbeat: processing > 10 นาที = stuck (worker crash)
+		HeartbeatTimeout:  2 * time.Minute,  // worker มี heartbeat: ขาด heartbeat > 2 นาที = stuck
 		// ไม่มี QueuedTimeout - jobs รอใน queue ได้นานเท่าที่ต้องการ
 	}
 
@@ -595,6 +680,28 @@ func (c *Container) initStuckDetector() error {
 		logger.Info("Subtitle stuck detector job registered",
 			"check_interval", "30s",
 			"processing_timeout", "10m",
+			"heartbeat_timeout", "2m",
+		)
+	}
+
+	// === Subtitle Auto-Retry ===
+	// republish subtitles ที่ค้าง queued (job หายหลัง worker crash) - เปิด/ปิดและ interval อยู่ใน settings
+	var streamCounter serviceimpl.SubtitleStreamCounter
+	if c.NATSClient != nil {
+		streamCounter = c.NATSClient
+	}
+	subtitleAutoRetry := serviceimpl.NewSubtitleAutoRetryService(
+		c.SubtitleService,
+		c.SettingService,
+		streamCounter,
+		c.EventScheduler,
+	)
+
+	if err := subtitleAutoRetry.RegisterAutoRetryJob(); err != nil {
+		logger.Warn("Failed to register subtitle auto-retry job", "error", err)
+	} else {
+		logger.Info("Subtitle auto-retry job registered",
+			"settings", "subtitle.auto_retry_stuck, subtitle.auto_retry_interval_minutes",
 		)
 	}
 
@@ -611,6 +718,7 @@ func (c *Container) initProgressBroadcaster() error {
 
 	// สร้าง Progress Broadcaster ใช้ interface (Clean Architecture)
 	c.ProgressBroadcaster = websocket.NewProgressBroadcaster(c.ProgressSubscriber, c.VideoRepository)
+	c.ProgressBroadcaster.SetJobEventRepository(c.JobEventRepository)
 
 	// เริ่ม broadcaster
 	if err := c.ProgressBroadcaster.Start(); err != nil {
@@ -619,6 +727,15 @@ func (c *Container) initProgressBroadcaster() error {
 	}
 
 	logger.Info("Progress broadcaster started (Messaging → WebSocket)")
+
+	// SEO worker เขียน job_events/processing_costs ของเราไม่ได้ (ต่อ DB ของ subth) → ส่งมาทาง NATS
+	if c.NATSClient != nil {
+		c.JobEventSubscriber = natspkg.NewJobEventSubscriber(c.NATSClient.Conn(), c.JobEventRepository, c.ProcessingCostRepository, c.VideoRepository)
+		if err := c.JobEventSubscriber.Start(); err != nil {
+			logger.Warn("Failed to start job event subscriber", "error", err)
+			c.JobEventSubscriber = nil
+		}
+	}
 	return nil
 }
 
@@ -630,6 +747,14 @@ func (c *Container) injectNotifierToProgressBroadcaster() {
 	}
 }
 
+// injectNotifierToSubtitleService inject notifier ให้ subtitle service (แจ้งเตือนเมื่อ subtitle ready)
+func (c *Container) injectNotifierToSubtitleService() {
+	if svc, ok := c.SubtitleService.(*serviceimpl.SubtitleServiceImpl); ok && c.Notifier != nil {
+		svc.SetNotifier(c.Notifier)
+		logger.Info("Notifier injected into subtitle service (subtitle ready notifications enabled)")
+	}
+}
+
 func (c *Container) initNotifications() error {
 	// Initialize Telegram Notifier
 	c.Notifier = telegram.NewTelegramNotifier(c.SettingService)
@@ -642,6 +767,7 @@ func (c *Container) initNotifications() error {
 			logger.Warn("Failed to create DLQ subscriber", "error", err)
 			return nil
 		}
+		dlqSubscriber.SetEntryRepository(c.DLQEntryRepository)
 		c.DLQSubscriber = dlqSubscriber
 
 		// Start DLQ subscriber
@@ -667,6 +793,12 @@ func (c *Container) Cleanup() error {
 		logger.Info("DLQ subscriber stopped")
 	}
 
+	// Stop job event subscriber
+	if c.JobEventSubscriber != nil {
+		c.JobEventSubscriber.Stop()
+		logger.Info("Job event subscriber stopped")
+	}
+
 	// Stop progress broadcaster
 	if c.ProgressBroadcaster != nil {
 		c.ProgressBroadcaster.Stop()
@@ -758,12 +890,17 @@ func (c *Container) GetHandlerServices() *handlers.Services {
 		SubtitleService:     c.SubtitleService,
 		QueueService:        c.QueueService,
 		ReelService:         c.ReelService,
+		JobEventService:     c.JobEventService,
+		RelatedVideoService: c.RelatedVideoService,
+		GalleryReconcileService: c.GalleryReconcileService,
+		GalleryStatsService:     c.GalleryStatsService,
 		VideoRepository:     c.VideoRepository, // สำหรับ SubtitleHandler
 		StreamCookieService: c.StreamCookieService, // Signed cookie สำหรับ CDN access
 		NATSPublisher:       c.NATSPublisher,
 		GoogleConfig:        c.Config.Google,
 		StorageBasePath:     c.Config.Storage.BasePath,
 		StorageType:         c.Config.Storage.Type,
+		GalleryQuality:      c.Config.Storage.GalleryQuality,
 		BaseURL:             baseURL,
 		CDNBaseURL:          cdnBaseURL,
 		JWTSecret:           c.Config.JWT.Secret,
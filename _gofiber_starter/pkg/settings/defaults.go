@@ -7,15 +7,25 @@ import "gofiber-template/domain/models"
 var DefaultSettings = map[string]map[string]SettingDefinition{
 	// ทั่วไป - Branding และ Limits
 	"general": {
-		"site_title":       {Value: "Suekk Stream", Type: models.SettingTypeString, Description: "ชื่อเว็บไซต์"},
-		"site_description": {Value: "ระบบจัดการวิดีโอสตรีมมิ่ง", Type: models.SettingTypeString, Description: "คำอธิบายเว็บไซต์"},
-		"max_upload_size":  {Value: "10", Type: models.SettingTypeNumber, Description: "ขนาดไฟล์สูงสุดที่อัปโหลดได้ (GB)"},
+		"site_title":               {Value: "Suekk Stream", Type: models.SettingTypeString, Description: "ชื่อเว็บไซต์"},
+		"site_description":         {Value: "ระบบจัดการวิดีโอสตรีมมิ่ง", Type: models.SettingTypeString, Description: "คำอธิบายเว็บไซต์"},
+		"max_upload_size":          {Value: "10", Type: models.SettingTypeNumber, Description: "ขนาดไฟล์สูงสุดที่อัปโหลดได้ (GB)"},
+		"allowed_video_extensions": {Value: "mp4,mkv,avi,mov,webm,ts,mts", Type: models.SettingTypeString, Description: "นามสกุลวิดีโอที่อนุญาตให้อัปโหลด (คั่นด้วย ,)"},
+		"batch_upload_concurrency": {Value: "3", Type: models.SettingTypeNumber, Description: "จำนวนไฟล์ที่อัปโหลดพร้อมกันในการอัปโหลดหลายไฟล์"},
 	},
 	// การแปลงวิดีโอ - Transcoding settings
 	"transcoding": {
-		"default_qualities": {Value: "1080p,720p,480p", Type: models.SettingTypeString, Description: "ความละเอียดที่ต้องการแปลง (คั่นด้วย ,)"},
+		"default_qualities": {Value: "1080p,720p,480p", Type: models.SettingTypeString, Description: "ความละเอียดที่ต้องการแปลง (คั่นด้วย ,) - 1080p, 720p, 480p, 360p", Validate: validateQualities},
 		"auto_queue":        {Value: "true", Type: models.SettingTypeBoolean, Description: "เข้าคิวอัตโนมัติหลังอัปโหลด"},
 		"max_queue_size":    {Value: "100", Type: models.SettingTypeNumber, Description: "จำนวน jobs สูงสุดในคิว (0 = ไม่จำกัด)"},
+		"disk_multiplier":   {Value: "3", Type: models.SettingTypeNumber, Description: "พื้นที่ disk ที่ต้องเผื่อต่อขนาดไฟล์ (เท่า) เมื่อ transcode บนเครื่อง API (ไม่ใช้กับ S3)"},
+	},
+	// ซับไตเติ้ล - Subtitle job settings
+	"subtitle": {
+		"auto_retry_stuck":            {Value: "true", Type: models.SettingTypeBoolean, Description: "ส่ง job ใหม่อัตโนมัติให้ซับไตเติ้ลที่ค้างสถานะ queued (job หายเพราะ worker crash)"},
+		"auto_retry_interval_minutes": {Value: "15", Type: models.SettingTypeNumber, Description: "ความถี่ในการ retry อัตโนมัติ และระยะเวลาที่ต้องค้าง queued ก่อนถูก retry (นาที)"},
+		"auto_translate_enabled":      {Value: "true", Type: models.SettingTypeBoolean, Description: "แปลซับไตเติ้ลอัตโนมัติหลังถอดเสียงเสร็จ"},
+		"auto_translate_policy":       {Value: "th:en;*:th", Type: models.SettingTypeString, Description: "ภาษาที่แปลอัตโนมัติต่อภาษาต้นทาง เช่น ja:th,en;th:en;*:th (* = ภาษาอื่น, เว้นว่างหลัง : = ไม่แปล) - ต้องอยู่ใน translation matrix"},
 	},
 	// การแจ้งเตือน - Notification settings
 	"alert": {
@@ -26,6 +36,8 @@ var DefaultSettings = map[string]map[string]SettingDefinition{
 		"on_transcode_fail":     {Value: "true", Type: models.SettingTypeBoolean, Description: "แจ้งเตือนเมื่อแปลงไฟล์ล้มเหลว"},
 		"on_worker_offline":     {Value: "true", Type: models.SettingTypeBoolean, Description: "แจ้งเตือนเมื่อ Worker ออฟไลน์"},
 		"on_dlq":                {Value: "true", Type: models.SettingTypeBoolean, Description: "แจ้งเตือนเมื่อวิดีโอเข้า Dead Letter Queue"},
+		"on_subtitle_ready":     {Value: "false", Type: models.SettingTypeBoolean, Description: "แจ้งเตือนเมื่อถอดซับไตเติ้ล (ภาษาต้นฉบับ) เสร็จ"},
+		"on_translation_ready":  {Value: "false", Type: models.SettingTypeBoolean, Description: "แจ้งเตือนเมื่อแปลซับไตเติ้ลเสร็จ"},
 	},
 }
 
@@ -41,6 +53,7 @@ type SettingDefinition struct {
 	Type        models.SettingValueType
 	Description string
 	IsSecret    bool
+	Validate    func(value string) error // nil = ไม่ตรวจ (ตรวจตอน Update ก่อนบันทึก)
 }
 
 // GetDefaultModels แปลง DefaultSettings เป็น models สำหรับ insert
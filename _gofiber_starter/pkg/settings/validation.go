@@ -0,0 +1,74 @@
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ValidationError ค่า setting ไม่ถูกต้อง (handler ตอบ 400 พร้อมข้อความนี้)
+type ValidationError struct {
+	Category string
+	Key      string
+	Message  string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s.%s: %s", e.Category, e.Key, e.Message)
+}
+
+// Validate ตรวจค่าของ setting ตาม SettingDefinition.Validate (key ที่ไม่มี validator = ผ่าน)
+func Validate(category, key, value string) error {
+	def, ok := DefaultSettings[category][key]
+	if !ok || def.Validate == nil {
+		return nil
+	}
+	if err := def.Validate(value); err != nil {
+		return &ValidationError{Category: category, Key: key, Message: err.Error()}
+	}
+	return nil
+}
+
+// TranscodeQualities ความละเอียดที่ worker transcode ได้ (สูง → ต่ำ)
+var TranscodeQualities = []string{"1080p", "720p", "480p", "360p"}
+
+// ParseQualities แยก comma-separated qualities เช่น "1080p,720p" → ["1080p", "720p"]
+// quality ที่ไม่รู้จัก (เช่น "1080") / ซ้ำ / ว่างทั้งหมด = error (ไม่ fallback เงียบๆ)
+func ParseQualities(value string) ([]string, error) {
+	known := make(map[string]bool, len(TranscodeQualities))
+	for _, q := range TranscodeQualities {
+		known[q] = true
+	}
+
+	var qualities, invalid []string
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ",") {
+		q := strings.ToLower(strings.TrimSpace(part))
+		if q == "" {
+			continue
+		}
+		if !known[q] {
+			invalid = append(invalid, strings.TrimSpace(part))
+			continue
+		}
+		if seen[q] {
+			return nil, fmt.Errorf("duplicate quality %q", q)
+		}
+		seen[q] = true
+		qualities = append(qualities, q)
+	}
+
+	if len(invalid) > 0 {
+		return nil, fmt.Errorf("unknown qualities %s (allowed: %s)",
+			strings.Join(invalid, ", "), strings.Join(TranscodeQualities, ", "))
+	}
+	if len(qualities) == 0 {
+		return nil, errors.New("at least one quality is required")
+	}
+	return qualities, nil
+}
+
+func validateQualities(value string) error {
+	_, err := ParseQualities(value)
+	return err
+}
@@ -0,0 +1,48 @@
+package settings
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseQualities(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{"default", "1080p,720p,480p", []string{"1080p", "720p", "480p"}, false},
+		{"single", "720p", []string{"720p"}, false},
+		{"spaces and case", " 1080P , 360p ", []string{"1080p", "360p"}, false},
+		{"empty parts skipped", "1080p,,720p,", []string{"1080p", "720p"}, false},
+		{"missing suffix", "1080,720p", nil, true},
+		{"unknown quality", "1440p", nil, true},
+		{"duplicate", "720p,720P", nil, true},
+		{"empty", "", nil, true},
+		{"only separators", " , ,", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseQualities(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseQualities(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseQualities(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateDefaultQualities(t *testing.T) {
+	if err := Validate("transcoding", "default_qualities", DefaultSettings["transcoding"]["default_qualities"].Value); err != nil {
+		t.Fatalf("default value rejected: %v", err)
+	}
+
+	err := Validate("transcoding", "default_qualities", "1080")
+	if _, ok := err.(*ValidationError); !ok {
+		t.Fatalf("Validate(1080) = %v, want *ValidationError", err)
+	}
+}
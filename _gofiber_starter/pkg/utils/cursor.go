@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidCursor cursor ที่ส่งมาไม่ถูกต้อง
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// EncodeCursor สร้าง opaque cursor จาก (created_at, id)
+// ใช้ id เป็น tie-breaker เมื่อ created_at ซ้ำกัน
+func EncodeCursor(createdAt time.Time, id uuid.UUID) string {
+	raw := strconv.FormatInt(createdAt.UnixNano(), 10) + "_" + id.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor แปลง cursor กลับเป็น (created_at, id)
+func DecodeCursor(cursor string) (time.Time, uuid.UUID, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, uuid.Nil, ErrInvalidCursor
+	}
+
+	parts := strings.SplitN(string(raw), "_", 2)
+	if len(parts) != 2 {
+		return time.Time{}, uuid.Nil, ErrInvalidCursor
+	}
+
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return time.Time{}, uuid.Nil, ErrInvalidCursor
+	}
+
+	id, err := uuid.Parse(parts[1])
+	if err != nil {
+		return time.Time{}, uuid.Nil, ErrInvalidCursor
+	}
+
+	return time.Unix(0, nanos), id, nil
+}
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+// RedactedValue ค่าที่ใช้แทน secret ใน payload
+const RedactedValue = "[REDACTED]"
+
+// key ที่มีคำเหล่านี้ (ไม่สนตัวพิมพ์, ไม่สน _/-) ถือเป็น secret
+var sensitiveKeyParts = []string{"password", "secret", "token", "apikey", "accesskey", "authorization", "credential", "signature", "privatekey"}
+
+// RedactJSON แทนค่า secret ใน JSON payload ก่อนเก็บ/แสดงผล
+// - field ที่ชื่อเข้าข่าย secret → [REDACTED]
+// - URL ที่มี presigned signature → ตัด query string ออก
+// JSON ไม่ถูกต้อง = คืน nil (ไม่เก็บ payload ที่ตรวจไม่ได้)
+func RedactJSON(raw []byte) []byte {
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil
+	}
+	redacted, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return nil
+	}
+	return redacted
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if isSensitiveKey(key) {
+				v[key] = RedactedValue
+				continue
+			}
+			v[key] = redactValue(item)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+		return v
+	case string:
+		return redactSignedURL(v)
+	default:
+		return v
+	}
+}
+
+func isSensitiveKey(key string) bool {
+	normalized := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(normalized, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactSignedURL ตัด query string ของ presigned URL (X-Amz-Signature, Signature=...)
+func redactSignedURL(s string) string {
+	if !strings.HasPrefix(s, "http://") && !strings.HasPrefix(s, "https://") {
+		return s
+	}
+	parsed, err := url.Parse(s)
+	if err != nil || parsed.RawQuery == "" {
+		return s
+	}
+	for key := range parsed.Query() {
+		if isSensitiveKey(key) {
+			parsed.RawQuery = ""
+			return parsed.String()
+		}
+	}
+	return s
+}
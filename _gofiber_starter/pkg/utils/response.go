@@ -19,6 +19,14 @@ type PaginatedResponse struct {
 	Error   *ErrorInfo `json:"error,omitempty"`
 }
 
+// CursorPaginatedResponse response สำหรับ cursor pagination (ไม่มี page/totalPages)
+type CursorPaginatedResponse struct {
+	Success bool       `json:"success"`
+	Data    any        `json:"data,omitempty"`
+	Meta    CursorMeta `json:"meta"`
+	Error   *ErrorInfo `json:"error,omitempty"`
+}
+
 type ErrorInfo struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
@@ -34,6 +42,12 @@ type Meta struct {
 	HasPrev    bool  `json:"hasPrev"`
 }
 
+type CursorMeta struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"nextCursor,omitempty"` // ว่าง = หน้าสุดท้าย
+	HasNext    bool   `json:"hasNext"`
+}
+
 // ========== Error Code Constants ==========
 
 const (
@@ -44,6 +58,7 @@ const (
 	ErrCodeConflict      = "CONFLICT"
 	ErrCodeInternalError = "INTERNAL_ERROR"
 	ErrCodeBadRequest    = "BAD_REQUEST"
+	ErrCodeTimeout       = "GATEWAY_TIMEOUT"
 )
 
 // ========== Success Responses ==========
@@ -62,6 +77,14 @@ func CreatedResponse(c *fiber.Ctx, data any) error {
 	})
 }
 
+// AcceptedResponse งานเริ่มใน background แล้ว (202)
+func AcceptedResponse(c *fiber.Ctx, data any) error {
+	return c.Status(fiber.StatusAccepted).JSON(Response{
+		Success: true,
+		Data:    data,
+	})
+}
+
 func NoContentResponse(c *fiber.Ctx) error {
 	return c.SendStatus(fiber.StatusNoContent)
 }
@@ -86,6 +109,19 @@ func PaginatedSuccessResponse(c *fiber.Ctx, data any, total int64, page, limit i
 	})
 }
 
+// CursorPaginatedSuccessResponse ส่ง response แบบ cursor pagination
+func CursorPaginatedSuccessResponse(c *fiber.Ctx, data any, limit int, nextCursor string) error {
+	return c.Status(fiber.StatusOK).JSON(CursorPaginatedResponse{
+		Success: true,
+		Data:    data,
+		Meta: CursorMeta{
+			Limit:      limit,
+			NextCursor: nextCursor,
+			HasNext:    nextCursor != "",
+		},
+	})
+}
+
 // ========== Error Responses ==========
 
 func ErrorResponse(c *fiber.Ctx, statusCode int, code, message string, details any) error {
@@ -177,3 +213,13 @@ func InternalServerErrorResponse(c *fiber.Ctx) error {
 		nil,
 	)
 }
+
+func GatewayTimeoutResponse(c *fiber.Ctx) error {
+	return ErrorResponse(
+		c,
+		fiber.StatusGatewayTimeout,
+		ErrCodeTimeout,
+		"Request timed out",
+		nil,
+	)
+}
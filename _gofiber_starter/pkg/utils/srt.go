@@ -0,0 +1,138 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidSRT ไฟล์ไม่ใช่ SRT ที่อ่านได้
+var ErrInvalidSRT = errors.New("invalid SRT content")
+
+// SRTCue 1 cue ของไฟล์ SRT
+type SRTCue struct {
+	Index int
+	Start time.Duration
+	End   time.Duration
+	Text  string // หลายบรรทัดคั่นด้วย \n
+}
+
+// ParseSRT แยก SRT เป็น cues (รองรับ BOM, CRLF, timestamp คั่นด้วย , หรือ .)
+// block ที่ไม่มีบรรทัดเวลาถูกข้าม - ไม่มี cue เลย = ErrInvalidSRT
+func ParseSRT(content string) ([]SRTCue, error) {
+	content = strings.TrimPrefix(content, "\ufeff")
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	content = strings.ReplaceAll(content, "\r", "\n")
+
+	var cues []SRTCue
+	for _, block := range strings.Split(content, "\n\n") {
+		lines := strings.Split(strings.TrimSpace(block), "\n")
+
+		// หาบรรทัดเวลา (บรรทัดแรกหรือบรรทัดที่สองถ้ามี index)
+		timingLine := -1
+		for i := 0; i < len(lines) && i < 2; i++ {
+			if strings.Contains(lines[i], "-->") {
+				timingLine = i
+				break
+			}
+		}
+		if timingLine < 0 {
+			continue
+		}
+
+		start, end, err := parseSRTTiming(lines[timingLine])
+		if err != nil {
+			return nil, fmt.Errorf("%w: cue %d: %v", ErrInvalidSRT, len(cues)+1, err)
+		}
+
+		cues = append(cues, SRTCue{
+			Index: len(cues) + 1,
+			Start: start,
+			End:   end,
+			Text:  strings.TrimSpace(strings.Join(lines[timingLine+1:], "\n")),
+		})
+	}
+
+	if len(cues) == 0 {
+		return nil, ErrInvalidSRT
+	}
+	return cues, nil
+}
+
+// FormatSRT สร้าง SRT จาก cues (เรียง index ใหม่ตั้งแต่ 1)
+func FormatSRT(cues []SRTCue) string {
+	var b strings.Builder
+	for i, cue := range cues {
+		fmt.Fprintf(&b, "%d\n%s --> %s\n%s\n\n", i+1, FormatSRTTimestamp(cue.Start), FormatSRTTimestamp(cue.End), cue.Text)
+	}
+	return b.String()
+}
+
+// FormatSRTTimestamp แปลง duration เป็น HH:MM:SS,mmm
+func FormatSRTTimestamp(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	ms := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d,%03d", ms/3600000, (ms/60000)%60, (ms/1000)%60, ms%1000)
+}
+
+// parseSRTTiming แยกบรรทัด "00:00:01,000 --> 00:00:02,500" (อาจมี position ต่อท้าย)
+func parseSRTTiming(line string) (time.Duration, time.Duration, error) {
+	parts := strings.SplitN(line, "-->", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid timing line %q", line)
+	}
+
+	start, err := ParseSRTTimestamp(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return 0, 0, err
+	}
+	endFields := strings.Fields(parts[1])
+	if len(endFields) == 0 {
+		return 0, 0, fmt.Errorf("invalid timing line %q", line)
+	}
+	end, err := ParseSRTTimestamp(endFields[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	return start, end, nil
+}
+
+// ParseSRTTimestamp แปลง "HH:MM:SS,mmm" (หรือ "MM:SS.mmm" แบบ VTT) เป็น duration
+func ParseSRTTimestamp(ts string) (time.Duration, error) {
+	ts = strings.Replace(ts, ",", ".", 1)
+
+	var ms int64
+	if dot := strings.IndexByte(ts, '.'); dot >= 0 {
+		frac := ts[dot+1:]
+		if frac == "" || len(frac) > 3 {
+			return 0, fmt.Errorf("invalid timestamp %q", ts)
+		}
+		frac += strings.Repeat("0", 3-len(frac))
+		v, err := strconv.ParseInt(frac, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid timestamp %q", ts)
+		}
+		ms = v
+		ts = ts[:dot]
+	}
+
+	parts := strings.Split(ts, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid timestamp %q", ts)
+	}
+
+	var total int64
+	for _, p := range parts {
+		v, err := strconv.ParseInt(p, 10, 64)
+		if err != nil || v < 0 {
+			return 0, fmt.Errorf("invalid timestamp %q", ts)
+		}
+		total = total*60 + v
+	}
+
+	return time.Duration(total)*time.Second + time.Duration(ms)*time.Millisecond, nil
+}
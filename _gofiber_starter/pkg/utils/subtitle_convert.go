@@ -0,0 +1,157 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Subtitle formats ที่ import ได้
+const (
+	SubtitleFormatSRT = "srt"
+	SubtitleFormatVTT = "vtt"
+	SubtitleFormatASS = "ass"
+	SubtitleFormatSSA = "ssa"
+)
+
+// ErrUnsupportedSubtitleFormat format ที่แปลงเป็น SRT ไม่ได้
+var ErrUnsupportedSubtitleFormat = errors.New("unsupported subtitle format")
+
+var (
+	// tag ของ VTT ที่ SRT ไม่รองรับ: <v Name>, <c.class>, <lang en>, <ruby>, <00:00:01.000>
+	vttTagPattern = regexp.MustCompile(`</?(?:v|c|lang|ruby|rt)(?:[ .][^>]*)?>|<\d{1,2}:\d{2}(?::\d{2})?\.\d{3}>`)
+	// override block ของ ASS เช่น {\an8}, {\i1}
+	assOverridePattern = regexp.MustCompile(`\{[^}]*\}`)
+)
+
+// ConvertToSRT แปลง subtitle (srt/vtt/ass/ssa) เป็น SRT มาตรฐาน (index เรียงใหม่, cue เรียงตามเวลา)
+func ConvertToSRT(format, content string) (string, error) {
+	var cues []SRTCue
+	var err error
+
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case SubtitleFormatSRT:
+		cues, err = ParseSRT(content)
+	case SubtitleFormatVTT:
+		cues, err = ParseVTT(content)
+	case SubtitleFormatASS, SubtitleFormatSSA:
+		cues, err = ParseASS(content)
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedSubtitleFormat, format)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return FormatSRT(cues), nil
+}
+
+// ParseVTT แยก WebVTT เป็น cues
+// NOTE/STYLE/REGION block ถูกข้าม (ไม่มีบรรทัดเวลา), tag เฉพาะ VTT ถูกตัดออก (<i>/<b>/<u> คงไว้)
+func ParseVTT(content string) ([]SRTCue, error) {
+	trimmed := strings.TrimSpace(strings.TrimPrefix(content, "\ufeff"))
+	if !strings.HasPrefix(trimmed, "WEBVTT") {
+		return nil, fmt.Errorf("%w: missing WEBVTT header", ErrInvalidSRT)
+	}
+
+	cues, err := ParseSRT(trimmed)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []SRTCue
+	for _, cue := range cues {
+		cue.Text = strings.TrimSpace(vttTagPattern.ReplaceAllString(cue.Text, ""))
+		if cue.Text == "" {
+			continue
+		}
+		result = append(result, cue)
+	}
+	if len(result) == 0 {
+		return nil, ErrInvalidSRT
+	}
+	return sortCues(result), nil
+}
+
+// ParseASS แยก Dialogue lines ของ ASS/SSA ([Events]) เป็น cues
+// ใช้ลำดับ field จากบรรทัด Format (ไม่มี = ลำดับมาตรฐาน), override {...} ถูกตัดออก, \N = ขึ้นบรรทัดใหม่
+func ParseASS(content string) ([]SRTCue, error) {
+	content = strings.TrimPrefix(content, "\ufeff")
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+
+	// ลำดับมาตรฐาน: Layer, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text
+	startIdx, endIdx, textIdx, fieldCount := 1, 2, 9, 10
+
+	inEvents := false
+	var cues []SRTCue
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") {
+			inEvents = strings.EqualFold(line, "[Events]")
+			continue
+		}
+		if !inEvents {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "Format":
+			fields := strings.Split(value, ",")
+			fieldCount = len(fields)
+			for i, f := range fields {
+				switch strings.ToLower(strings.TrimSpace(f)) {
+				case "start":
+					startIdx = i
+				case "end":
+					endIdx = i
+				case "text":
+					textIdx = i
+				}
+			}
+		case "Dialogue":
+			// Text เป็น field สุดท้ายและมี comma ได้
+			fields := strings.SplitN(value, ",", fieldCount)
+			if len(fields) <= textIdx || len(fields) <= startIdx || len(fields) <= endIdx {
+				return nil, fmt.Errorf("%w: malformed dialogue line %q", ErrInvalidSRT, line)
+			}
+			start, err := ParseSRTTimestamp(strings.TrimSpace(fields[startIdx]))
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidSRT, err)
+			}
+			end, err := ParseSRTTimestamp(strings.TrimSpace(fields[endIdx]))
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidSRT, err)
+			}
+
+			text := assOverridePattern.ReplaceAllString(fields[textIdx], "")
+			text = strings.NewReplacer(`\N`, "\n", `\n`, "\n", `\h`, " ").Replace(text)
+			text = strings.TrimSpace(text)
+			if text == "" {
+				continue
+			}
+			cues = append(cues, SRTCue{Start: start, End: end, Text: text})
+		}
+	}
+
+	if len(cues) == 0 {
+		return nil, ErrInvalidSRT
+	}
+	return sortCues(cues), nil
+}
+
+// sortCues เรียง cue ตามเวลาเริ่ม (ASS ไม่บังคับลำดับ) และเรียง index ใหม่
+func sortCues(cues []SRTCue) []SRTCue {
+	sort.SliceStable(cues, func(i, j int) bool {
+		return cues[i].Start < cues[j].Start
+	})
+	for i := range cues {
+		cues[i].Index = i + 1
+	}
+	return cues
+}
@@ -0,0 +1,124 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+)
+
+var ErrInvalidVideoFormat = errors.New("invalid video format")
+
+// DefaultVideoExtensions นามสกุลวิดีโอที่ transcode ได้ (ใช้เมื่อไม่ได้ตั้งค่าใน settings)
+const DefaultVideoExtensions = "mp4,mkv,avi,mov,webm,ts,mts"
+
+// VideoSniffLength จำนวน bytes ที่อ่านจากต้นไฟล์เพื่อตรวจ signature
+const VideoSniffLength = 512
+
+// ParseVideoExtensions แปลง "mp4, .MKV,mov" เป็น set ของนามสกุล (lowercase, ไม่มีจุด)
+func ParseVideoExtensions(list string) map[string]bool {
+	exts := make(map[string]bool)
+	for _, part := range strings.Split(list, ",") {
+		ext := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(part), "."))
+		if ext != "" {
+			exts[ext] = true
+		}
+	}
+	return exts
+}
+
+// IsVideoExtension นามสกุลของ filename อยู่ใน allowlist หรือไม่
+func IsVideoExtension(filename string, allowed map[string]bool) bool {
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(filename), "."))
+	return ext != "" && allowed[ext]
+}
+
+// ValidateVideoFile ตรวจไฟล์ที่อัปโหลดก่อนส่งขึ้น storage
+// 1. นามสกุลต้องอยู่ใน allowlist
+// 2. Content-Type ต้องเป็น video/* (ยอมรับ octet-stream/ว่าง เพราะ browser บางตัวไม่รู้จัก mkv/ts)
+// 3. signature ของ bytes แรกต้องเป็น container วิดีโอที่รู้จัก
+func ValidateVideoFile(fileHeader *multipart.FileHeader, allowed map[string]bool) error {
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(fileHeader.Filename), "."))
+	if !IsVideoExtension(fileHeader.Filename, allowed) {
+		return fmt.Errorf("%w: extension %q is not allowed", ErrInvalidVideoFormat, ext)
+	}
+
+	contentType := strings.ToLower(strings.TrimSpace(fileHeader.Header.Get("Content-Type")))
+	if !isVideoContentType(contentType) {
+		return fmt.Errorf("%w: content type %q is not video", ErrInvalidVideoFormat, contentType)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return ValidateVideoContent(file)
+}
+
+// ValidateVideoContent อ่าน bytes แรกของไฟล์แล้วตรวจ signature (ใช้ได้ทั้งไฟล์ที่อัปโหลดและ object ใน storage)
+func ValidateVideoContent(r io.Reader) error {
+	header := make([]byte, VideoSniffLength)
+	n, err := io.ReadFull(r, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return err
+	}
+	if !IsVideoSignature(header[:n]) {
+		return fmt.Errorf("%w: file content does not look like video", ErrInvalidVideoFormat)
+	}
+	return nil
+}
+
+func isVideoContentType(contentType string) bool {
+	if contentType == "" || contentType == "application/octet-stream" {
+		return true
+	}
+	if i := strings.IndexByte(contentType, ';'); i >= 0 {
+		contentType = strings.TrimSpace(contentType[:i])
+	}
+	return strings.HasPrefix(contentType, "video/")
+}
+
+// IsVideoSignature ตรวจ magic bytes ของ container วิดีโอที่รองรับ
+// MP4/MOV (ftyp, moov, mdat...), Matroska/WebM (EBML), AVI (RIFF....AVI), MPEG-TS/M2TS (sync byte 0x47)
+func IsVideoSignature(header []byte) bool {
+	switch {
+	case len(header) >= 8 && isISOBMFFBox(header[4:8]):
+		return true
+	case bytes.HasPrefix(header, []byte{0x1A, 0x45, 0xDF, 0xA3}):
+		return true
+	case len(header) >= 12 && bytes.Equal(header[:4], []byte("RIFF")) && bytes.Equal(header[8:12], []byte("AVI ")):
+		return true
+	case isTransportStream(header, 0, 188), isTransportStream(header, 4, 192):
+		return true
+	}
+	return false
+}
+
+// isISOBMFFBox box type แรกของไฟล์ MP4/MOV (QuickTime เก่าอาจเริ่มด้วย moov/mdat/wide แทน ftyp)
+func isISOBMFFBox(boxType []byte) bool {
+	switch string(boxType) {
+	case "ftyp", "moov", "mdat", "wide", "free", "skip":
+		return true
+	}
+	return false
+}
+
+// isTransportStream ตรวจ sync byte 0x47 ที่ต้นสอง packet ติดกัน (TS = 188 bytes, M2TS = 192 bytes + 4-byte prefix)
+func isTransportStream(header []byte, offset, packetSize int) bool {
+	if len(header) <= offset {
+		return false
+	}
+	if header[offset] != 0x47 {
+		return false
+	}
+	// ไฟล์สั้นกว่า 1 packet - เช็คได้แค่ byte แรก
+	if len(header) <= offset+packetSize {
+		return true
+	}
+	return header[offset+packetSize] == 0x47
+}
@@ -0,0 +1,152 @@
+// rebuild - เครื่องมือ editor สำหรับแก้ AI output แล้วสร้าง article ใหม่โดยไม่เรียก Gemini
+//
+//	go run ./cmd/rebuild -code=abc123 -ai=edited.json            # validate + sanitize + build + publish
+//	go run ./cmd/rebuild -code=abc123 -ai=edited.json -tts       # สร้าง TTS ใหม่จาก summaryShort ที่แก้
+//	go run ./cmd/rebuild -code=abc123 -ai=edited.json -dry-run   # validate อย่างเดียว ไม่ publish
+//
+// edited.json = AIOutput JSON (shape เดียวกับผลลัพธ์ของ pipeline เช่น {"title":"...","summary":"...",...})
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log/slog"
+	"os"
+
+	"seo-worker/config"
+	"seo-worker/domain/models"
+	"seo-worker/domain/ports"
+	"seo-worker/infrastructure/ai"
+	"seo-worker/infrastructure/auth"
+	"seo-worker/infrastructure/fetcher"
+	"seo-worker/infrastructure/imagecopier"
+	"seo-worker/infrastructure/publisher"
+	"seo-worker/infrastructure/storage"
+	"seo-worker/infrastructure/tts"
+	"seo-worker/use_cases"
+)
+
+func main() {
+	videoCode := flag.String("code", "", "Video code (embed code)")
+	aiFile := flag.String("ai", "", "JSON file with edited AI output")
+	outputLanguage := flag.String("lang", "", "Output language (ว่าง = ภาษาไทย)")
+	generateTTS := flag.Bool("tts", false, "Regenerate TTS from edited summaryShort")
+	dryRun := flag.Bool("dry-run", false, "Validate only, do not publish")
+	flag.Parse()
+
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	slog.SetDefault(logger)
+
+	if *videoCode == "" || *aiFile == "" {
+		logger.Error("-code and -ai are required")
+		os.Exit(2)
+	}
+
+	data, err := os.ReadFile(*aiFile)
+	if err != nil {
+		logger.Error("Failed to read AI output file", "file", *aiFile, "error", err)
+		os.Exit(1)
+	}
+	var edited ports.AIOutput
+	if err := json.Unmarshal(data, &edited); err != nil {
+		logger.Error("Invalid AI output file", "file", *aiFile, "error", err)
+		os.Exit(1)
+	}
+	if err := ai.ValidateAIOutput(&edited); err != nil {
+		logger.Error("Edited AI output failed validation", "file", *aiFile, "error", err)
+		os.Exit(1)
+	}
+	if *dryRun {
+		logger.Info("Edited AI output is valid (dry run, not published)", "video_code", *videoCode)
+		return
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		logger.Error("Failed to load config", "error", err)
+		os.Exit(1)
+	}
+
+	suekkAuth := auth.NewAuthClient(cfg.SuekkAPI.URL, cfg.SuekkAPI.Email, cfg.SuekkAPI.Password, nil)
+	subthAuth := auth.NewAuthClient(cfg.SubthAPI.URL, cfg.SubthAPI.Email, cfg.SubthAPI.Password, nil)
+
+	suekkStorage, err := storage.NewR2Client(storage.R2Config{
+		Endpoint:  cfg.SuekkStorage.Endpoint,
+		AccessKey: cfg.SuekkStorage.AccessKey,
+		SecretKey: cfg.SuekkStorage.SecretKey,
+		Bucket:    cfg.SuekkStorage.Bucket,
+		PublicURL: cfg.SuekkStorage.PublicURL,
+	})
+	if err != nil {
+		logger.Error("Failed to create suekk storage", "error", err)
+		os.Exit(1)
+	}
+	subthStorage, err := storage.NewR2Client(storage.R2Config{
+		Endpoint:  cfg.SubthStorage.Endpoint,
+		AccessKey: cfg.SubthStorage.AccessKey,
+		SecretKey: cfg.SubthStorage.SecretKey,
+		Bucket:    cfg.SubthStorage.Bucket,
+		PublicURL: cfg.SubthStorage.PublicURL,
+	})
+	if err != nil {
+		logger.Error("Failed to create subth storage", "error", err)
+		os.Exit(1)
+	}
+
+	suekkVideoFetcher := fetcher.NewSuekkVideoFetcher(cfg.SuekkAPI.URL, suekkAuth, suekkStorage, nil)
+	metadataFetcher := fetcher.NewMetadataFetcher(cfg.SubthAPI.URL, subthAuth, nil)
+	articlePublisher := publisher.NewArticlePublisher(cfg.SubthAPI.URL, subthAuth, nil)
+	imageCopier := imagecopier.NewImageCopier(suekkStorage, subthStorage)
+
+	var ttsService ports.TTSPort
+	if *generateTTS {
+		ttsService = tts.NewElevenLabsClient(tts.ElevenLabsConfig{
+			APIKey:  cfg.ElevenLabs.APIKey,
+			VoiceID: cfg.ElevenLabs.VoiceID,
+			Model:   cfg.ElevenLabs.Model,
+		})
+	}
+
+	// ไม่ใช้ SRT/AI/embedding/messenger - rebuild ข้าม generation ทั้งหมด
+	handler := use_cases.NewSEOHandler(nil, suekkVideoFetcher, metadataFetcher, nil, nil, ttsService, nil, articlePublisher, imageCopier, nil, subthStorage, nil)
+	handler.SetTTSFallback(use_cases.TTSFallbackConfig{
+		FallbackVoiceIDs: cfg.ElevenLabs.FallbackVoiceIDs,
+		MaxRetries:       cfg.ElevenLabs.MaxRetries,
+		RetryBackoff:     cfg.ElevenLabs.RetryBackoff,
+	})
+	handler.SetMetaLimits(use_cases.MetaLimitsConfig{
+		MaxTitleChars:       cfg.SEO.MetaTitleMaxChars,
+		MaxDescriptionChars: cfg.SEO.MetaDescriptionMaxChars,
+	})
+	handler.SetPreviousWorks(use_cases.PreviousWorksConfig{
+		PerCast:     cfg.SEO.PreviousWorksPerCast,
+		Concurrency: cfg.SEO.PreviousWorksConcurrency,
+	})
+	handler.SetSafeMoments(models.SafeMomentSettings{
+		Disabled:         cfg.SEO.SafeMomentsDisabled,
+		ThresholdSeconds: cfg.SEO.SafeThresholdSeconds,
+		MinMoments:       cfg.SEO.MinKeyMoments,
+		MaxPublic:        cfg.SEO.MaxKeyMomentsPublic,
+		MaxInternal:      cfg.SEO.MaxKeyMomentsInternal,
+	})
+	handler.SetArticleOutput(use_cases.ArticleOutputConfig{
+		Mode: cfg.SEO.ArticleOutput,
+	})
+
+	job := &models.SEOArticleJob{
+		VideoCode:      *videoCode,
+		GenerateTTS:    *generateTTS,
+		OutputLanguage: *outputLanguage,
+	}
+
+	article, err := handler.RebuildArticle(context.Background(), job, &edited)
+	if err != nil {
+		logger.Error("Rebuild failed", "video_code", *videoCode, "error", err)
+		os.Exit(1)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(article)
+}
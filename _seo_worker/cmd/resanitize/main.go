@@ -0,0 +1,138 @@
+// resanitize - รัน sanitize + buildArticle ใหม่บน AIOutput ดิบที่เก็บไว้ แล้ว publish (ไม่เรียก Gemini)
+// ใช้หลังแก้กฎ sanitize เพื่อให้มีผลย้อนหลังกับบทความที่ publish ไปแล้ว
+//
+//	go run ./cmd/resanitize -code=abc123               # วิดีโอเดียว
+//	go run ./cmd/resanitize -code=abc123,def456        # หลายวิดีโอ
+//	go run ./cmd/resanitize -all                       # ทุกวิดีโอที่มี ai-output/<code>.json
+//	go run ./cmd/resanitize -all -dry-run              # แสดงรายการอย่างเดียว ไม่ publish
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+	"path"
+	"strings"
+
+	"seo-worker/config"
+	"seo-worker/domain/models"
+	"seo-worker/infrastructure/auth"
+	"seo-worker/infrastructure/fetcher"
+	"seo-worker/infrastructure/imagecopier"
+	"seo-worker/infrastructure/publisher"
+	"seo-worker/infrastructure/storage"
+	"seo-worker/use_cases"
+)
+
+func main() {
+	codes := flag.String("code", "", "Video code(s), comma-separated")
+	all := flag.Bool("all", false, "Re-sanitize every video with stored AI output")
+	dryRun := flag.Bool("dry-run", false, "List videos only, do not publish")
+	flag.Parse()
+
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	slog.SetDefault(logger)
+
+	if *codes == "" && !*all {
+		logger.Error("-code or -all is required")
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		logger.Error("Failed to load config", "error", err)
+		os.Exit(1)
+	}
+
+	suekkAuth := auth.NewAuthClient(cfg.SuekkAPI.URL, cfg.SuekkAPI.Email, cfg.SuekkAPI.Password, nil)
+	subthAuth := auth.NewAuthClient(cfg.SubthAPI.URL, cfg.SubthAPI.Email, cfg.SubthAPI.Password, nil)
+
+	suekkStorage, err := storage.NewR2Client(storage.R2Config{
+		Endpoint:  cfg.SuekkStorage.Endpoint,
+		AccessKey: cfg.SuekkStorage.AccessKey,
+		SecretKey: cfg.SuekkStorage.SecretKey,
+		Bucket:    cfg.SuekkStorage.Bucket,
+		PublicURL: cfg.SuekkStorage.PublicURL,
+	})
+	if err != nil {
+		logger.Error("Failed to create suekk storage", "error", err)
+		os.Exit(1)
+	}
+	subthStorage, err := storage.NewR2Client(storage.R2Config{
+		Endpoint:  cfg.SubthStorage.Endpoint,
+		AccessKey: cfg.SubthStorage.AccessKey,
+		SecretKey: cfg.SubthStorage.SecretKey,
+		Bucket:    cfg.SubthStorage.Bucket,
+		PublicURL: cfg.SubthStorage.PublicURL,
+	})
+	if err != nil {
+		logger.Error("Failed to create subth storage", "error", err)
+		os.Exit(1)
+	}
+
+	var videoCodes []string
+	if *all {
+		files, err := subthStorage.ListFiles("ai-output/")
+		if err != nil {
+			logger.Error("Failed to list stored AI output", "error", err)
+			os.Exit(1)
+		}
+		for _, file := range files {
+			if strings.HasSuffix(file, ".json") {
+				videoCodes = append(videoCodes, strings.TrimSuffix(path.Base(file), ".json"))
+			}
+		}
+	} else {
+		for _, code := range strings.Split(*codes, ",") {
+			if code = strings.TrimSpace(code); code != "" {
+				videoCodes = append(videoCodes, code)
+			}
+		}
+	}
+
+	if *dryRun {
+		logger.Info("Videos to re-sanitize (dry run, not published)", "count", len(videoCodes), "codes", videoCodes)
+		return
+	}
+
+	suekkVideoFetcher := fetcher.NewSuekkVideoFetcher(cfg.SuekkAPI.URL, suekkAuth, suekkStorage, nil)
+	metadataFetcher := fetcher.NewMetadataFetcher(cfg.SubthAPI.URL, subthAuth, nil)
+	articlePublisher := publisher.NewArticlePublisher(cfg.SubthAPI.URL, subthAuth, nil)
+	imageCopier := imagecopier.NewImageCopier(suekkStorage, subthStorage)
+
+	// ไม่ใช้ SRT/AI/TTS/embedding/messenger - ใช้ AIOutput และเสียงเดิมที่เก็บไว้
+	handler := use_cases.NewSEOHandler(nil, suekkVideoFetcher, metadataFetcher, nil, nil, nil, nil, articlePublisher, imageCopier, nil, subthStorage, nil)
+	handler.SetMetaLimits(use_cases.MetaLimitsConfig{
+		MaxTitleChars:       cfg.SEO.MetaTitleMaxChars,
+		MaxDescriptionChars: cfg.SEO.MetaDescriptionMaxChars,
+	})
+	handler.SetPreviousWorks(use_cases.PreviousWorksConfig{
+		PerCast:     cfg.SEO.PreviousWorksPerCast,
+		Concurrency: cfg.SEO.PreviousWorksConcurrency,
+	})
+	handler.SetSafeMoments(models.SafeMomentSettings{
+		Disabled:         cfg.SEO.SafeMomentsDisabled,
+		ThresholdSeconds: cfg.SEO.SafeThresholdSeconds,
+		MinMoments:       cfg.SEO.MinKeyMoments,
+		MaxPublic:        cfg.SEO.MaxKeyMomentsPublic,
+		MaxInternal:      cfg.SEO.MaxKeyMomentsInternal,
+	})
+	handler.SetArticleOutput(use_cases.ArticleOutputConfig{
+		Mode: cfg.SEO.ArticleOutput,
+	})
+
+	ctx := context.Background()
+	failed := 0
+	for _, code := range videoCodes {
+		if _, err := handler.ResanitizeArticle(ctx, code); err != nil {
+			logger.Error("Re-sanitize failed", "video_code", code, "error", err)
+			failed++
+		}
+	}
+
+	logger.Info("Re-sanitize finished", "total", len(videoCodes), "failed", failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
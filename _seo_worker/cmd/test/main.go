@@ -47,8 +47,8 @@ func main() {
 	// === Create dependencies manually (no NATS needed) ===
 
 	// 1. Auth clients
-	suekkAuth := auth.NewAuthClient(cfg.SuekkAPI.URL, cfg.SuekkAPI.Email, cfg.SuekkAPI.Password)
-	subthAuth := auth.NewAuthClient(cfg.SubthAPI.URL, cfg.SubthAPI.Email, cfg.SubthAPI.Password)
+	suekkAuth := auth.NewAuthClient(cfg.SuekkAPI.URL, cfg.SuekkAPI.Email, cfg.SuekkAPI.Password, nil)
+	subthAuth := auth.NewAuthClient(cfg.SubthAPI.URL, cfg.SubthAPI.Email, cfg.SubthAPI.Password, nil)
 
 	// 2. Storage (IDrive for SRT)
 	suekkStorage, err := storage.NewR2Client(storage.R2Config{
@@ -67,10 +67,10 @@ func main() {
 	srtFetcher := fetcher.NewSRTFetcher(suekkStorage)
 
 	// 4. Suekk Video Fetcher (from api.suekk.com)
-	suekkVideoFetcher := fetcher.NewSuekkVideoFetcher(cfg.SuekkAPI.URL, suekkAuth, suekkStorage)
+	suekkVideoFetcher := fetcher.NewSuekkVideoFetcher(cfg.SuekkAPI.URL, suekkAuth, suekkStorage, nil)
 
 	// 5. Metadata Fetcher (from api.subth.com)
-	metadataFetcher := fetcher.NewMetadataFetcher(cfg.SubthAPI.URL, subthAuth)
+	metadataFetcher := fetcher.NewMetadataFetcher(cfg.SubthAPI.URL, subthAuth, nil)
 
 	// 6. Image Selector (Python - NSFW filter, face detection, aesthetic scoring)
 	imageSelector := imageselector.NewPythonImageSelector(imageselector.PythonImageSelectorConfig{
@@ -109,7 +109,7 @@ func main() {
 	embeddingClient := embedding.NewPgVectorClient(nil) // nil DB = will log warning but not fail
 
 	// 10. Article Publisher - stub
-	articlePublisher := publisher.NewArticlePublisher(cfg.SubthAPI.URL, subthAuth)
+	articlePublisher := publisher.NewArticlePublisher(cfg.SubthAPI.URL, subthAuth, nil)
 
 	// 11. Messenger - no-op for testing
 	noopMessenger := messenger.NewNoopMessenger()
@@ -144,6 +144,7 @@ func main() {
 		imageCopier,
 		noopMessenger,
 		subthStorage,
+		nil, // Event log - skip for testing
 	)
 
 	// === Create test job ===
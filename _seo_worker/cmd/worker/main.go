@@ -9,13 +9,14 @@ import (
 
 	"seo-worker/config"
 	"seo-worker/container"
+	"seo-worker/infrastructure/logging"
 )
 
 func main() {
-	// Setup logger
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+	// Setup logger (trace_id จาก job ถูกเติมให้ทุก log ที่ใช้ *Context)
+	logger := slog.New(logging.NewTraceHandler(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 		Level: slog.LevelInfo,
-	}))
+	})))
 	slog.SetDefault(logger)
 
 	logger.Info("Starting SEO Content Worker")
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -16,10 +17,16 @@ type Config struct {
 	SubthAPI      APIConfig
 	Gemini        GeminiConfig
 	ElevenLabs    ElevenLabsConfig
+	TTS           TTSConfig // เลือก provider + fallback provider
+	GoogleTTS     GoogleTTSConfig
+	Embedding     EmbeddingConfig
 	ImageSelector ImageSelectorConfig
 	SuekkStorage  StorageConfig // IDrive - for reading SRT files
 	SubthStorage  StorageConfig // R2 - for uploading audio files
 	Alert         AlertConfig
+	SEO           SEOConfig
+	Breaker       BreakerConfig // circuit breaker ของ suekk/subth API
+	SchemaServer  SchemaServerConfig
 }
 
 type WorkerConfig struct {
@@ -31,6 +38,7 @@ type NATSConfig struct {
 	URL             string
 	Stream          string
 	Subject         string
+	PrioritySubject string // job urgent (priority=1) - "" = ไม่เปิดคิว priority
 	Consumer        string
 	ShutdownTimeout time.Duration
 }
@@ -46,14 +54,64 @@ type APIConfig struct {
 }
 
 type GeminiConfig struct {
-	APIKey string
-	Model  string // gemini-1.5-flash or gemini-1.5-pro
+	APIKey      string
+	Model       string // gemini-1.5-flash or gemini-1.5-pro
+	MaxSRTChars int    // SRT ยาวกว่านี้จะถูกตัดก่อนส่ง Gemini (ตัดที่ SEO_SAFE_THRESHOLD_SECONDS)
+
+	// ChunkModels model ต่อ chunk เช่น {"chunk1v2": "gemini-1.5-pro"} (ไม่ระบุ = Model)
+	ChunkModels map[string]string
+	// ChunkTemperatures temperature ต่อ chunk (ไม่ระบุ = default ใน ai/chunk_config.go)
+	ChunkTemperatures map[string]float32
+	// Seed เปิด deterministic output (nil = ปิด)
+	Seed *int32
+	// ChunkTimeout timeout ต่อการเรียก Gemini 1 ครั้ง (หมดเวลา = retry chunk นั้น)
+	ChunkTimeout time.Duration
+
+	// Debug dump ของ chunk ที่ parse ไม่ผ่าน
+	DebugDumps          bool   // false = ไม่เขียน debug file (production)
+	DebugDir            string // ที่เก็บ chunkN_debug_<code>.json
+	DebugRetentionHours int    // ลบ debug file ที่เก่ากว่านี้ (0 = เก็บตลอด)
 }
 
 type ElevenLabsConfig struct {
-	APIKey  string
-	VoiceID string
-	Model   string // eleven_v3, eleven_multilingual_v2
+	APIKey           string
+	VoiceID          string
+	Model            string        // eleven_v3, eleven_multilingual_v2
+	FallbackVoiceIDs []string      // ลองทีละ voice เมื่อ voice หลักล้มเหลว
+	MaxRetries       int           // จำนวน retry ของ voice หลัก (ไม่รวมครั้งแรก)
+	RetryBackoff     time.Duration // backoff เริ่มต้น (เพิ่มเป็น 2 เท่าทุกครั้ง)
+	MaxChars         int           // ความยาวสูงสุดต่อ request (script ยาวกว่านี้จะแบ่ง chunk)
+}
+
+// TTSConfig เลือก TTS provider (elevenlabs | google)
+type TTSConfig struct {
+	Provider         string // provider หลัก (default elevenlabs)
+	FallbackProvider string // ใช้เมื่อ provider หลักล้มเหลว (ว่าง = ไม่มี)
+}
+
+type GoogleTTSConfig struct {
+	APIKey       string
+	VoiceName    string // e.g. th-TH-Neural2-C (ว่าง = Google เลือกตามภาษา)
+	LanguageCode string // e.g. th-TH
+
+	FallbackVoiceNames []string // ลองทีละ voice เมื่อ voice หลักล้มเหลว (Google เป็น provider หลัก)
+}
+
+// EmbeddingConfig provider/model ของ article embedding (placeholder | gemini | openai)
+type EmbeddingConfig struct {
+	Provider  string // placeholder = zero vector (default)
+	Model     string // ว่าง = default ของ provider
+	APIKey    string // ว่าง + gemini = ใช้ GEMINI_API_KEY
+	Dimension int    // ต้องตรงกับ article_embeddings.embedding vector(N) - ไม่ตั้ง = ขนาด default ของ provider
+}
+
+// defaultEmbeddingDimension ขนาด vector default ของ model ตั้งต้นแต่ละ provider
+// gemini text-embedding-004 = 768, openai text-embedding-3-small = 1536
+func defaultEmbeddingDimension(provider string) int {
+	if provider == "gemini" {
+		return 768
+	}
+	return 1536
 }
 
 type ImageSelectorConfig struct {
@@ -70,6 +128,48 @@ type StorageConfig struct {
 	PublicURL string
 }
 
+type SEOConfig struct {
+	MetaTitleMaxChars        int // ความยาวสูงสุด metaTitle (rune)
+	MetaDescriptionMaxChars  int // ความยาวสูงสุด metaDescription (rune)
+	PreviousWorksPerCast     int // จำนวนผลงานก่อนหน้าที่ดึงต่อ cast
+	PreviousWorksConcurrency int // ดึง previous works พร้อมกันกี่ cast
+
+	// Safe moments (key moments ที่แสดงใน Google Schema)
+	SafeMomentsDisabled   bool   // true = ไม่กรอง key moments ตามเวลา
+	SafeThresholdSeconds  int    // เก็บเฉพาะ moments ก่อนวินาทีนี้
+	MinKeyMoments         int    // ต่ำกว่านี้เติม seed moments
+	MaxKeyMomentsPublic   int    // สูงสุดใน Public Schema
+	MaxKeyMomentsInternal int    // สูงสุดสำหรับ Members
+	SeedMomentsFile       string // JSON seed moments ต่อภาษา (ว่าง = templates ที่มากับ binary)
+
+	ArticleOutput string // ที่เก็บ article JSON: local | storage | both
+
+	GalleryCopyNaming string // ชื่อไฟล์ gallery ใน R2: per_tier | global | source
+
+	// tier → ผู้ชมของ gallery (super_safe, safe, nsfw) - tier ที่ไม่อยู่ในทั้งสองรายการไม่ใช้
+	GalleryPublicTiers []string // public/ (SEO) - default: safe
+	GalleryMemberTiers []string // member/ (members only) - default: nsfw
+
+	// Gallery ขั้นต่ำก่อนสร้างบทความ
+	MinGalleryImages    int           // ภาพ public (safe) ขั้นต่ำ - 0 = ไม่ตรวจ
+	AutoGenerateGallery bool          // ยังไม่มี gallery = สั่ง suekk สร้างให้
+	GalleryDeferDelay   time.Duration // หน่วงก่อนลอง job ที่ gallery ยังไม่พร้อมใหม่
+}
+
+type BreakerConfig struct {
+	FailureThreshold    int           // fail ติดกันกี่ครั้งถึงเปิด breaker
+	OpenTimeout         time.Duration // เปิดค้างไว้นานเท่าไหร่ก่อนลอง half-open
+	HalfOpenMaxRequests int           // จำนวน request ทดลองตอน half-open
+}
+
+// SchemaServerConfig HTTP endpoint สำหรับ JSON Schema ของ ArticleContent
+type SchemaServerConfig struct {
+	Addr         string // เช่น ":8090" (ว่าง = ไม่เปิด)
+	PreviewToken string // Bearer token ของ /preview/safe-moments (ว่าง = ไม่เปิด preview)
+	RelatedToken string // Bearer token ของ /related ที่ API server เรียก (ว่าง = ไม่เปิด)
+	EditorToken  string // Bearer token ของ /articles/{code}/key-moments (ว่าง = ไม่เปิด)
+}
+
 type AlertConfig struct {
 	Enabled        bool
 	DiscordWebhook string
@@ -84,6 +184,40 @@ func Load() (*Config, error) {
 
 	workerID := getEnv("WORKER_ID", "seo-worker-1")
 
+	ttsMaxRetries, _ := strconv.Atoi(getEnv("ELEVENLABS_MAX_RETRIES", "2"))
+	ttsRetryBackoffSec, _ := strconv.Atoi(getEnv("ELEVENLABS_RETRY_BACKOFF_SEC", "2"))
+	ttsMaxChars, _ := strconv.Atoi(getEnv("ELEVENLABS_MAX_CHARS", "2500"))
+	embeddingProvider := strings.ToLower(getEnv("EMBEDDING_PROVIDER", "placeholder"))
+	embeddingDimension, _ := strconv.Atoi(getEnv("EMBEDDING_DIMENSION", ""))
+	if embeddingDimension <= 0 {
+		embeddingDimension = defaultEmbeddingDimension(embeddingProvider)
+	}
+	geminiMaxSRTChars, _ := strconv.Atoi(getEnv("GEMINI_MAX_SRT_CHARS", "120000"))
+	geminiChunkTemps := parseChunkTemperatures(getEnv("GEMINI_CHUNK_TEMPERATURES", ""))
+	geminiSeed := parseOptionalInt32(getEnv("GEMINI_SEED", ""))
+	geminiChunkTimeoutSec, _ := strconv.Atoi(getEnv("GEMINI_CHUNK_TIMEOUT_SEC", "180"))
+	geminiDebugDumps, _ := strconv.ParseBool(getEnv("GEMINI_DEBUG_DUMPS", "true"))
+	geminiDebugRetention, _ := strconv.Atoi(getEnv("GEMINI_DEBUG_RETENTION_HOURS", "72"))
+	metaTitleMaxChars, _ := strconv.Atoi(getEnv("SEO_META_TITLE_MAX_CHARS", "60"))
+	metaDescriptionMaxChars, _ := strconv.Atoi(getEnv("SEO_META_DESCRIPTION_MAX_CHARS", "160"))
+	previousWorksPerCast, _ := strconv.Atoi(getEnv("SEO_PREVIOUS_WORKS_PER_CAST", "5"))
+	previousWorksConcurrency, _ := strconv.Atoi(getEnv("SEO_PREVIOUS_WORKS_CONCURRENCY", "3"))
+	safeMomentsDisabled, _ := strconv.ParseBool(getEnv("SEO_SAFE_MOMENTS_DISABLED", "false"))
+	safeThresholdSeconds, _ := strconv.Atoi(getEnv("SEO_SAFE_THRESHOLD_SECONDS", "600"))
+	minKeyMoments, _ := strconv.Atoi(getEnv("SEO_MIN_KEY_MOMENTS", "3"))
+	maxKeyMomentsPublic, _ := strconv.Atoi(getEnv("SEO_MAX_KEY_MOMENTS_PUBLIC", "5"))
+	maxKeyMomentsInternal, _ := strconv.Atoi(getEnv("SEO_MAX_KEY_MOMENTS_INTERNAL", "20"))
+	minGalleryImages, _ := strconv.Atoi(getEnv("SEO_MIN_GALLERY_IMAGES", "0"))
+	autoGenerateGallery, _ := strconv.ParseBool(getEnv("SEO_AUTO_GENERATE_GALLERY", "false"))
+	galleryDeferDelaySec, _ := strconv.Atoi(getEnv("SEO_GALLERY_DEFER_DELAY_SEC", "300"))
+	prioritySubject := ""
+	if priorityQueue, _ := strconv.ParseBool(getEnv("SEO_PRIORITY_QUEUE", "false")); priorityQueue {
+		prioritySubject = "seo.article.generate.priority"
+	}
+	breakerFailureThreshold, _ := strconv.Atoi(getEnv("BREAKER_FAILURE_THRESHOLD", "5"))
+	breakerOpenTimeoutSec, _ := strconv.Atoi(getEnv("BREAKER_OPEN_TIMEOUT_SEC", "30"))
+	breakerHalfOpenMax, _ := strconv.Atoi(getEnv("BREAKER_HALF_OPEN_MAX_REQUESTS", "1"))
+
 	return &Config{
 		Worker: WorkerConfig{
 			ID:          workerID,
@@ -93,6 +227,7 @@ func Load() (*Config, error) {
 			URL:             getEnv("NATS_URL", "nats://localhost:4222"),
 			Stream:          getEnv("NATS_STREAM", "SEO_ARTICLES"),
 			Subject:         "seo.article.generate",
+			PrioritySubject: prioritySubject,
 			Consumer:        "seo-worker-" + workerID,
 			ShutdownTimeout: 60 * time.Second,
 		},
@@ -110,13 +245,45 @@ func Load() (*Config, error) {
 			Password: getEnv("SUBTH_API_PASSWORD", ""),
 		},
 		Gemini: GeminiConfig{
-			APIKey: getEnv("GEMINI_API_KEY", ""),
-			Model:  getEnv("GEMINI_MODEL", "gemini-1.5-flash"),
+			APIKey:      getEnv("GEMINI_API_KEY", ""),
+			Model:       getEnv("GEMINI_MODEL", "gemini-1.5-flash"),
+			MaxSRTChars: geminiMaxSRTChars,
+
+			ChunkModels:       splitKeyValues(getEnv("GEMINI_CHUNK_MODELS", "")),
+			ChunkTemperatures: geminiChunkTemps,
+			Seed:              geminiSeed,
+			ChunkTimeout:      time.Duration(geminiChunkTimeoutSec) * time.Second,
+
+			DebugDumps:          geminiDebugDumps,
+			DebugDir:            getEnv("GEMINI_DEBUG_DIR", "output"),
+			DebugRetentionHours: geminiDebugRetention,
 		},
 		ElevenLabs: ElevenLabsConfig{
 			APIKey:  getEnv("ELEVENLABS_API_KEY", ""),
 			VoiceID: getEnv("ELEVENLABS_VOICE_ID", "q0IMILNRPxOgtBTS4taI"),
 			Model:   getEnv("ELEVENLABS_MODEL", "eleven_v3"),
+			// comma-separated voice IDs
+			FallbackVoiceIDs: splitList(getEnv("ELEVENLABS_FALLBACK_VOICE_IDS", "")),
+			MaxRetries:       ttsMaxRetries,
+			RetryBackoff:     time.Duration(ttsRetryBackoffSec) * time.Second,
+			MaxChars:         ttsMaxChars,
+		},
+		TTS: TTSConfig{
+			Provider:         strings.ToLower(getEnv("TTS_PROVIDER", "elevenlabs")),
+			FallbackProvider: strings.ToLower(getEnv("TTS_FALLBACK_PROVIDER", "")),
+		},
+		GoogleTTS: GoogleTTSConfig{
+			APIKey:       getEnv("GOOGLE_TTS_API_KEY", ""),
+			VoiceName:    getEnv("GOOGLE_TTS_VOICE_NAME", ""),
+			LanguageCode: getEnv("GOOGLE_TTS_LANGUAGE_CODE", "th-TH"),
+			// comma-separated voice names
+			FallbackVoiceNames: splitList(getEnv("GOOGLE_TTS_FALLBACK_VOICE_NAMES", "")),
+		},
+		Embedding: EmbeddingConfig{
+			Provider:  embeddingProvider,
+			Model:     getEnv("EMBEDDING_MODEL", ""),
+			APIKey:    getEnv("EMBEDDING_API_KEY", ""),
+			Dimension: embeddingDimension,
 		},
 		// Image Selector (Python) - NSFW filter, face detection, aesthetic scoring
 		ImageSelector: ImageSelectorConfig{
@@ -144,9 +311,90 @@ func Load() (*Config, error) {
 			Enabled:        alertEnabled,
 			DiscordWebhook: getEnv("DISCORD_WEBHOOK_URL", ""),
 		},
+		SEO: SEOConfig{
+			MetaTitleMaxChars:        metaTitleMaxChars,
+			MetaDescriptionMaxChars:  metaDescriptionMaxChars,
+			PreviousWorksPerCast:     previousWorksPerCast,
+			PreviousWorksConcurrency: previousWorksConcurrency,
+			SafeMomentsDisabled:      safeMomentsDisabled,
+			SafeThresholdSeconds:     safeThresholdSeconds,
+			MinKeyMoments:            minKeyMoments,
+			MaxKeyMomentsPublic:      maxKeyMomentsPublic,
+			MaxKeyMomentsInternal:    maxKeyMomentsInternal,
+			SeedMomentsFile:          getEnv("SEO_SEED_MOMENTS_FILE", ""),
+			ArticleOutput:            getEnv("SEO_ARTICLE_OUTPUT", "local"),
+
+			GalleryCopyNaming: getEnv("SEO_GALLERY_COPY_NAMING", "per_tier"),
+
+			GalleryPublicTiers: splitList(getEnv("SEO_GALLERY_PUBLIC_TIERS", "safe")),
+			GalleryMemberTiers: splitList(getEnv("SEO_GALLERY_MEMBER_TIERS", "nsfw")),
+
+			MinGalleryImages:    minGalleryImages,
+			AutoGenerateGallery: autoGenerateGallery,
+			GalleryDeferDelay:   time.Duration(galleryDeferDelaySec) * time.Second,
+		},
+		Breaker: BreakerConfig{
+			FailureThreshold:    breakerFailureThreshold,
+			OpenTimeout:         time.Duration(breakerOpenTimeoutSec) * time.Second,
+			HalfOpenMaxRequests: breakerHalfOpenMax,
+		},
+		SchemaServer: SchemaServerConfig{
+			Addr:         getEnv("SCHEMA_HTTP_ADDR", ""),
+			PreviewToken: getEnv("SCHEMA_HTTP_PREVIEW_TOKEN", ""),
+			RelatedToken: getEnv("SCHEMA_HTTP_RELATED_TOKEN", ""),
+			EditorToken:  getEnv("SCHEMA_HTTP_EDITOR_TOKEN", ""),
+		},
 	}, nil
 }
 
+// splitList แยก comma-separated string (ตัดช่องว่าง/ค่าว่างทิ้ง)
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// splitKeyValues แยก "k1=v1,k2=v2" เป็น map (รายการที่ไม่มี = หรือค่าว่างถูกข้าม)
+func splitKeyValues(value string) map[string]string {
+	pairs := make(map[string]string)
+	for _, item := range splitList(value) {
+		key, val, ok := strings.Cut(item, "=")
+		key, val = strings.TrimSpace(key), strings.TrimSpace(val)
+		if !ok || key == "" || val == "" {
+			continue
+		}
+		pairs[key] = val
+	}
+	return pairs
+}
+
+// parseChunkTemperatures แยก "chunk=temp" (temp ที่ไม่ใช่ตัวเลข 0-2 ถูกข้าม)
+func parseChunkTemperatures(value string) map[string]float32 {
+	temps := make(map[string]float32)
+	for chunk, raw := range splitKeyValues(value) {
+		temp, err := strconv.ParseFloat(raw, 32)
+		if err != nil || temp < 0 || temp > 2 {
+			continue
+		}
+		temps[chunk] = float32(temp)
+	}
+	return temps
+}
+
+// parseOptionalInt32 ค่าว่าง/ไม่ใช่ตัวเลข = nil
+func parseOptionalInt32(value string) *int32 {
+	v, err := strconv.ParseInt(strings.TrimSpace(value), 10, 32)
+	if err != nil {
+		return nil
+	}
+	n := int32(v)
+	return &n
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -5,21 +5,27 @@ import (
 	"database/sql"
 	"fmt"
 	"log/slog"
+	"time"
 
 	_ "github.com/lib/pq"
 	"github.com/nats-io/nats.go"
 
 	"seo-worker/config"
+	"seo-worker/domain/models"
 	"seo-worker/domain/ports"
 	"seo-worker/infrastructure/ai"
 	"seo-worker/infrastructure/auth"
+	"seo-worker/infrastructure/circuitbreaker"
 	"seo-worker/infrastructure/consumer"
 	"seo-worker/infrastructure/embedding"
+	"seo-worker/infrastructure/eventlog"
 	"seo-worker/infrastructure/fetcher"
 	"seo-worker/infrastructure/imagecopier"
 	"seo-worker/infrastructure/imageselector"
+	"seo-worker/infrastructure/jobcancel"
 	"seo-worker/infrastructure/messenger"
 	"seo-worker/infrastructure/publisher"
+	"seo-worker/infrastructure/schemaserver"
 	"seo-worker/infrastructure/storage"
 	"seo-worker/infrastructure/tts"
 	"seo-worker/use_cases"
@@ -47,10 +53,18 @@ type Container struct {
 	Messenger          ports.MessengerPort
 	Storage            ports.StoragePort
 	SuekkStorage       ports.StoragePort  // e2 source for image copy
+	EventLog           ports.JobEventPort // pipeline event log (job_events)
+
+	// Circuit breakers (1 ตัวต่อ downstream API)
+	SuekkBreaker *circuitbreaker.Breaker
+	SubthBreaker *circuitbreaker.Breaker
 
 	// Use Cases
 	SEOHandler *use_cases.SEOHandler
 
+	// JSON Schema ของ ArticleContent (nil = ไม่เปิด)
+	SchemaServer *schemaserver.Server
+
 	// Internal
 	geminiClient *ai.GeminiClient
 	logger       *slog.Logger
@@ -92,9 +106,27 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 	// 2. Infrastructure Layer
 	// ─────────────────────────────────────────────────────────────────────────────
 
+	// Circuit Breakers - API ล่มแล้วตัด request ทันที ไม่ให้ทุก job ค้างรอ retry
+	c.SuekkBreaker = circuitbreaker.New(circuitbreaker.Config{
+		Name:                "suekk_api",
+		FailureThreshold:    cfg.Breaker.FailureThreshold,
+		OpenTimeout:         cfg.Breaker.OpenTimeout,
+		HalfOpenMaxRequests: cfg.Breaker.HalfOpenMaxRequests,
+	})
+	c.SubthBreaker = circuitbreaker.New(circuitbreaker.Config{
+		Name:                "subth_api",
+		FailureThreshold:    cfg.Breaker.FailureThreshold,
+		OpenTimeout:         cfg.Breaker.OpenTimeout,
+		HalfOpenMaxRequests: cfg.Breaker.HalfOpenMaxRequests,
+	})
+	c.logger.Info("Circuit breakers created",
+		"failure_threshold", cfg.Breaker.FailureThreshold,
+		"open_timeout", cfg.Breaker.OpenTimeout,
+	)
+
 	// Auth Clients (auto-login with email/password)
-	suekkAuth := auth.NewAuthClient(cfg.SuekkAPI.URL, cfg.SuekkAPI.Email, cfg.SuekkAPI.Password)
-	subthAuth := auth.NewAuthClient(cfg.SubthAPI.URL, cfg.SubthAPI.Email, cfg.SubthAPI.Password)
+	suekkAuth := auth.NewAuthClient(cfg.SuekkAPI.URL, cfg.SuekkAPI.Email, cfg.SuekkAPI.Password, c.SuekkBreaker)
+	subthAuth := auth.NewAuthClient(cfg.SubthAPI.URL, cfg.SubthAPI.Email, cfg.SubthAPI.Password, c.SubthBreaker)
 	c.logger.Info("Auth clients created")
 
 	// Suekk Storage (IDrive e2) - source for SRT files and image copy
@@ -118,11 +150,11 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 	c.logger.Info("SRT fetcher created")
 
 	// Suekk Video Fetcher (api.suekk.com) - ดึง duration, gallery
-	c.SuekkVideoFetcher = fetcher.NewSuekkVideoFetcher(cfg.SuekkAPI.URL, suekkAuth, c.SuekkStorage)
+	c.SuekkVideoFetcher = fetcher.NewSuekkVideoFetcher(cfg.SuekkAPI.URL, suekkAuth, c.SuekkStorage, c.SuekkBreaker)
 	c.logger.Info("Suekk video fetcher created", "url", cfg.SuekkAPI.URL)
 
 	// Metadata Fetcher (api.subth.com)
-	c.MetadataFetcher = fetcher.NewMetadataFetcher(cfg.SubthAPI.URL, subthAuth)
+	c.MetadataFetcher = fetcher.NewMetadataFetcher(cfg.SubthAPI.URL, subthAuth, c.SubthBreaker)
 	c.logger.Info("Metadata fetcher created", "url", cfg.SubthAPI.URL)
 
 	// Image Selector (Python - NSFW filter, face detection, aesthetic scoring)
@@ -142,30 +174,48 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Gemini client: %w", err)
 	}
-	c.AIService = c.geminiClient
-	c.logger.Info("Gemini client created", "model", cfg.Gemini.Model)
-
-	// ElevenLabs TTS Service
-	if cfg.ElevenLabs.APIKey != "" {
-		c.TTSService = tts.NewElevenLabsClient(tts.ElevenLabsConfig{
-			APIKey:  cfg.ElevenLabs.APIKey,
-			VoiceID: cfg.ElevenLabs.VoiceID,
-			Model:   cfg.ElevenLabs.Model,
-		})
-		c.logger.Info("ElevenLabs client created",
-			"voice_id", cfg.ElevenLabs.VoiceID,
-			"model", cfg.ElevenLabs.Model,
-		)
-	} else {
-		c.logger.Warn("ElevenLabs API key not set, TTS disabled")
+	c.geminiClient.SetSRTGuard(ai.SRTGuardConfig{
+		MaxChars: cfg.Gemini.MaxSRTChars,
+	})
+	c.geminiClient.SetChunkModels(cfg.Gemini.ChunkModels)
+	c.geminiClient.SetChunkTemperatures(cfg.Gemini.ChunkTemperatures)
+	c.geminiClient.SetSeed(cfg.Gemini.Seed)
+	c.geminiClient.SetChunkTimeout(cfg.Gemini.ChunkTimeout)
+	if cfg.SEO.SeedMomentsFile != "" {
+		seedMoments, err := ai.LoadSeedMomentTemplates(cfg.SEO.SeedMomentsFile)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SEO_SEED_MOMENTS_FILE: %w", err)
+		}
+		c.geminiClient.SetSeedMoments(seedMoments)
 	}
+	c.geminiClient.SetDebugOutput(ai.DebugOutputConfig{
+		Disabled:  !cfg.Gemini.DebugDumps,
+		Dir:       cfg.Gemini.DebugDir,
+		Retention: time.Duration(cfg.Gemini.DebugRetentionHours) * time.Hour,
+	})
+	c.AIService = c.geminiClient
+	c.logger.Info("Gemini client created",
+		"model", cfg.Gemini.Model,
+		"max_srt_chars", cfg.Gemini.MaxSRTChars,
+		"chunk_models", cfg.Gemini.ChunkModels,
+		"chunk_temperatures", cfg.Gemini.ChunkTemperatures,
+		"deterministic", cfg.Gemini.Seed != nil,
+		"chunk_timeout", cfg.Gemini.ChunkTimeout,
+	)
 
-	// pgvector Embedding Service
-	c.EmbeddingService = embedding.NewPgVectorClient(c.DB)
-	c.logger.Info("pgvector client created")
+	// TTS Service (provider หลัก + fallback provider ตาม config)
+	var ttsPrimary string
+	c.TTSService, ttsPrimary = c.newTTSService(cfg)
+
+	// pgvector Embedding Service (provider ตาม EMBEDDING_PROVIDER)
+	c.EmbeddingService = c.newEmbeddingService(cfg)
+
+	// Pipeline Event Log (job_events/processing_costs อยู่ DB ของ API → ส่งผ่าน NATS ให้ API เขียน)
+	c.EventLog = eventlog.NewNATSEventLog(c.NATSConn)
+	c.logger.Info("Event log created")
 
 	// Article Publisher (api.subth.com)
-	c.ArticlePublisher = publisher.NewArticlePublisher(cfg.SubthAPI.URL, subthAuth)
+	c.ArticlePublisher = publisher.NewArticlePublisher(cfg.SubthAPI.URL, subthAuth, c.SubthBreaker)
 	c.logger.Info("Article publisher created")
 
 	// NATS Consumer
@@ -173,9 +223,11 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 		URL:             cfg.NATS.URL,
 		Stream:          cfg.NATS.Stream,
 		Subject:         cfg.NATS.Subject,
+		PrioritySubject: cfg.NATS.PrioritySubject,
 		ConsumerName:    cfg.NATS.Consumer,
 		Concurrency:     cfg.Worker.Concurrency,
 		ShutdownTimeout: cfg.NATS.ShutdownTimeout,
+		DeferDelay:      cfg.SEO.GalleryDeferDelay,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create consumer: %w", err)
@@ -207,8 +259,10 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 
 	// Image Copier (e2 → r2) - copy gallery images from suekk to subth
 	if c.SuekkStorage != nil && c.Storage != nil {
-		c.ImageCopier = imagecopier.NewImageCopier(c.SuekkStorage, c.Storage)
-		c.logger.Info("Image copier created (e2 → r2)")
+		imageCopier := imagecopier.NewImageCopier(c.SuekkStorage, c.Storage)
+		imageCopier.SetNaming(cfg.SEO.GalleryCopyNaming)
+		c.ImageCopier = imageCopier
+		c.logger.Info("Image copier created (e2 → r2)", "naming", cfg.SEO.GalleryCopyNaming)
 	} else {
 		c.logger.Warn("Image copier not created (missing source or destination storage)")
 	}
@@ -229,20 +283,199 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 		c.ImageCopier,
 		c.Messenger,
 		c.Storage,
+		c.EventLog,
 	)
+	c.SEOHandler.SetTTSFallback(use_cases.TTSFallbackConfig{
+		FallbackVoiceIDs: ttsFallbackVoices(cfg, ttsPrimary),
+		MaxRetries:       cfg.ElevenLabs.MaxRetries,
+		RetryBackoff:     cfg.ElevenLabs.RetryBackoff,
+	})
+	c.SEOHandler.SetMetaLimits(use_cases.MetaLimitsConfig{
+		MaxTitleChars:       cfg.SEO.MetaTitleMaxChars,
+		MaxDescriptionChars: cfg.SEO.MetaDescriptionMaxChars,
+	})
+	c.SEOHandler.SetPreviousWorks(use_cases.PreviousWorksConfig{
+		PerCast:     cfg.SEO.PreviousWorksPerCast,
+		Concurrency: cfg.SEO.PreviousWorksConcurrency,
+	})
+	c.SEOHandler.SetSafeMoments(models.SafeMomentSettings{
+		Disabled:         cfg.SEO.SafeMomentsDisabled,
+		ThresholdSeconds: cfg.SEO.SafeThresholdSeconds,
+		MinMoments:       cfg.SEO.MinKeyMoments,
+		MaxPublic:        cfg.SEO.MaxKeyMomentsPublic,
+		MaxInternal:      cfg.SEO.MaxKeyMomentsInternal,
+	})
+	c.SEOHandler.SetArticleOutput(use_cases.ArticleOutputConfig{
+		Mode: cfg.SEO.ArticleOutput,
+	})
+	galleryAudience, err := models.ParseGalleryAudience(cfg.SEO.GalleryPublicTiers, cfg.SEO.GalleryMemberTiers)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SEO_GALLERY_PUBLIC_TIERS/SEO_GALLERY_MEMBER_TIERS: %w", err)
+	}
+	c.SEOHandler.SetGalleryAudience(galleryAudience)
+	c.SEOHandler.SetGalleryRequirement(use_cases.GalleryRequirementConfig{
+		MinPublicImages: cfg.SEO.MinGalleryImages,
+		AutoGenerate:    cfg.SEO.AutoGenerateGallery,
+	})
+	// Job cancel - api.suekk.com ตั้ง flag ใน JOB_CANCEL (ไม่มี bucket = ยกเลิกไม่ได้ ทำงานต่อปกติ)
+	if jobCancel, err := jobcancel.NewKVWatcher(context.Background(), c.NATSConn); err != nil {
+		c.logger.Warn("Job cancel bucket not available", "error", err)
+	} else {
+		c.SEOHandler.SetJobCancel(jobCancel)
+	}
 	c.logger.Info("SEO handler created")
 
 	// Wire handler to consumer
 	c.Consumer.SetHandler(c.SEOHandler.ProcessJob)
 
+	// Schema server (optional)
+	if cfg.SchemaServer.Addr != "" {
+		c.SchemaServer, err = schemaserver.NewServer(cfg.SchemaServer.Addr)
+		if err != nil {
+			return nil, err
+		}
+		// editor preview ของการกรอง key moments (เรียก Gemini ได้ - ต้องมี token)
+		c.SchemaServer.EnablePreview(c.SEOHandler, cfg.SchemaServer.PreviewToken)
+		// related videos ให้ API server (article_embeddings อยู่ DB นี้เท่านั้น)
+		if pg, ok := c.EmbeddingService.(*embedding.PgVectorClient); ok {
+			c.SchemaServer.EnableRelated(pg, cfg.SchemaServer.RelatedToken)
+		}
+		// editor แก้ key moments ของ article ที่ publish แล้ว (validate ด้วยกฎเดียวกับ pipeline)
+		c.SchemaServer.EnableKeyMoments(c.SEOHandler, cfg.SchemaServer.EditorToken)
+	}
+
 	c.logger.Info("Container initialized successfully")
 	return c, nil
 }
 
+// newEmbeddingService สร้าง pgvector client + embedding provider ตาม config
+// ตรวจขนาด vector กับ column ตอน startup - ไม่ตรง = warn (embedding ไม่ใช่ critical path)
+func (c *Container) newEmbeddingService(cfg *config.Config) *embedding.PgVectorClient {
+	client := embedding.NewPgVectorClient(c.DB)
+	client.SetDimension(cfg.Embedding.Dimension)
+
+	apiKey := cfg.Embedding.APIKey
+	switch cfg.Embedding.Provider {
+	case "gemini":
+		if apiKey == "" {
+			apiKey = cfg.Gemini.APIKey
+		}
+		client.SetGenerator(embedding.NewGeminiGenerator(apiKey, cfg.Embedding.Model, cfg.Embedding.Dimension))
+	case "openai":
+		if apiKey == "" {
+			c.logger.Warn("Embedding API key not set, using placeholder embeddings", "provider", "openai")
+			break
+		}
+		client.SetGenerator(embedding.NewOpenAIGenerator(apiKey, cfg.Embedding.Model, cfg.Embedding.Dimension))
+	case "", "placeholder":
+	default:
+		c.logger.Warn("Unknown embedding provider, using placeholder embeddings", "provider", cfg.Embedding.Provider)
+	}
+
+	if err := client.VerifyColumnDimension(context.Background()); err != nil {
+		c.logger.Warn("Embedding dimension check failed", "error", err)
+	}
+
+	c.logger.Info("pgvector client created",
+		"provider", cfg.Embedding.Provider,
+		"model", cfg.Embedding.Model,
+		"dimension", cfg.Embedding.Dimension,
+	)
+	return client
+}
+
+// newTTSService สร้าง TTS ตาม TTS_PROVIDER (+ TTS_FALLBACK_PROVIDER)
+// provider ที่ไม่มี API key ถูกข้าม - ไม่มีเลย = nil (TTS disabled)
+// คืนชื่อ provider ที่ได้เป็นตัวหลักจริงด้วย (ใช้เลือก fallback voices)
+func (c *Container) newTTSService(cfg *config.Config) (ports.TTSPort, string) {
+	var providers []tts.Provider
+	for _, name := range []string{cfg.TTS.Provider, cfg.TTS.FallbackProvider} {
+		if name == "" {
+			continue
+		}
+		if len(providers) > 0 && providers[0].Name == name {
+			continue // fallback ซ้ำกับ provider หลัก
+		}
+
+		switch name {
+		case "elevenlabs":
+			if cfg.ElevenLabs.APIKey == "" {
+				c.logger.Warn("ElevenLabs API key not set, skipping TTS provider")
+				continue
+			}
+			providers = append(providers, tts.Provider{Name: name, TTS: tts.NewElevenLabsClient(tts.ElevenLabsConfig{
+				APIKey:   cfg.ElevenLabs.APIKey,
+				VoiceID:  cfg.ElevenLabs.VoiceID,
+				Model:    cfg.ElevenLabs.Model,
+				MaxChars: cfg.ElevenLabs.MaxChars,
+			})})
+			c.logger.Info("ElevenLabs client created",
+				"voice_id", cfg.ElevenLabs.VoiceID,
+				"model", cfg.ElevenLabs.Model,
+			)
+		case "google":
+			if cfg.GoogleTTS.APIKey == "" {
+				c.logger.Warn("Google TTS API key not set, skipping TTS provider")
+				continue
+			}
+			providers = append(providers, tts.Provider{Name: name, TTS: tts.NewGoogleTTSClient(tts.GoogleTTSConfig{
+				APIKey:       cfg.GoogleTTS.APIKey,
+				VoiceName:    cfg.GoogleTTS.VoiceName,
+				LanguageCode: cfg.GoogleTTS.LanguageCode,
+			})})
+			c.logger.Info("Google TTS client created",
+				"voice_name", cfg.GoogleTTS.VoiceName,
+				"language_code", cfg.GoogleTTS.LanguageCode,
+			)
+		default:
+			c.logger.Warn("Unknown TTS provider, skipping", "provider", name)
+		}
+	}
+
+	switch len(providers) {
+	case 0:
+		c.logger.Warn("No TTS provider configured, TTS disabled")
+		return nil, ""
+	case 1:
+		return providers[0].TTS, providers[0].Name
+	default:
+		c.logger.Info("TTS fallback provider enabled",
+			"primary", providers[0].Name,
+			"fallback", providers[1].Name,
+		)
+		return tts.NewProviderChain(providers...), providers[0].Name
+	}
+}
+
+// ttsFallbackVoices fallback voices ของ provider หลัก
+// voice ถูกส่งให้ provider หลักเท่านั้น - voice ID ของ ElevenLabs ใช้กับ Google ไม่ได้ (และกลับกัน)
+func ttsFallbackVoices(cfg *config.Config, provider string) []string {
+	switch provider {
+	case "elevenlabs":
+		return cfg.ElevenLabs.FallbackVoiceIDs
+	case "google":
+		return cfg.GoogleTTS.FallbackVoiceNames
+	default:
+		return nil
+	}
+}
+
 // Start เริ่ม services ทั้งหมด
 func (c *Container) Start(ctx context.Context) error {
 	c.logger.Info("Starting container services...")
 
+	// ลบ chunk debug file ที่เกิน retention (ทำงานจน ctx ถูก cancel)
+	go c.geminiClient.RunDebugCleanup(ctx)
+
+	// Schema endpoint ล้ม = warn (ไม่กระทบการประมวลผล job)
+	if c.SchemaServer != nil {
+		go func() {
+			if err := c.SchemaServer.Start(); err != nil {
+				c.logger.Warn("Schema server stopped", "error", err)
+			}
+		}()
+	}
+
 	// Start consumer (blocking)
 	if err := c.Consumer.Start(ctx); err != nil {
 		return fmt.Errorf("failed to start consumer: %w", err)
@@ -259,6 +492,22 @@ func (c *Container) Stop() {
 	c.Consumer.Stop()
 	c.logger.Info("Consumer stopped")
 
+	// Stop schema server
+	if c.SchemaServer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := c.SchemaServer.Shutdown(ctx); err != nil {
+			c.logger.Warn("Failed to shutdown schema server", "error", err)
+		}
+		cancel()
+	}
+
+	// Breaker stats (สรุปก่อนปิด)
+	for _, b := range []*circuitbreaker.Breaker{c.SuekkBreaker, c.SubthBreaker} {
+		if b != nil {
+			c.logger.Info("Circuit breaker stats", "stats", b.Stats())
+		}
+	}
+
 	// Close Gemini client
 	if c.geminiClient != nil {
 		c.geminiClient.Close()
@@ -4,7 +4,8 @@ import "time"
 
 // ArticleContent - ข้อมูล SEO Article (E-E-A-T Framework)
 type ArticleContent struct {
-	VideoID string `json:"videoId"`
+	SchemaVersion string `json:"schemaVersion"` // ArticleSchemaVersion ตอนสร้าง (ดู article_schema.go)
+	VideoID       string `json:"videoId"`
 
 	// === Core SEO ===
 	Title           string `json:"title"`           // H1 title (50-60 chars)
@@ -23,7 +24,8 @@ type ArticleContent struct {
 	EmbedURL         string `json:"embedUrl"`
 
 	// === Key Moments (hasPart) ===
-	KeyMoments []KeyMoment `json:"keyMoments"`
+	KeyMoments         []KeyMoment `json:"keyMoments"`                   // Public (Google Schema)
+	InternalKeyMoments []KeyMoment `json:"internalKeyMoments,omitempty"` // Member only (ชุดใหญ่กว่า)
 
 	// === Article Content ===
 	Summary        string   `json:"summary"`        // 500 words (AI)
@@ -107,6 +109,7 @@ type ArticleContent struct {
 	// === TTS ===
 	AudioSummaryURL string `json:"audioSummaryUrl,omitempty"` // ElevenLabs output
 	AudioDuration   int    `json:"audioDuration,omitempty"`   // seconds
+	AudioVoiceID    string `json:"audioVoiceId,omitempty"`    // voice ที่สร้างสำเร็จ (หลักหรือ fallback)
 
 	// === Gallery ===
 	GalleryImages       []GalleryImage `json:"galleryImages,omitempty"`       // Public (safe - admin approved)
@@ -160,6 +163,13 @@ type TagDesc struct {
 	URL         string `json:"url"`
 }
 
+// ขนาดของ gallery frame ที่ worker ดึงจาก HLS (pad เป็น 16:9) - ใช้เมื่ออ่านขนาดจริงของภาพไม่ได้
+const (
+	DefaultGalleryImageWidth  = 1280
+	DefaultGalleryImageHeight = 720
+)
+
+// GalleryImage ภาพใน gallery - Width/Height เป็นขนาดจริงที่อ่านได้ตอน copy (อ่านไม่ได้ = Default*)
 type GalleryImage struct {
 	URL    string `json:"url"`
 	Alt    string `json:"alt"` // AI generated from highlights
@@ -171,14 +181,30 @@ type GalleryImage struct {
 type GalleryTier string
 
 const (
-	GalleryTierSafe GalleryTier = "safe" // Admin approved - safe for public/SEO
-	GalleryTierNSFW GalleryTier = "nsfw" // Admin approved - members only
+	GalleryTierSuperSafe GalleryTier = "super_safe" // Auto-classified ปลอดภัยสุด (gallery แบบ three-tier)
+	GalleryTierSafe      GalleryTier = "safe"       // Admin approved - safe for public/SEO
+	GalleryTierNSFW      GalleryTier = "nsfw"       // Admin approved - members only
 )
 
 // TieredGalleryImages - ภาพแยกตาม tier (Manual Selection)
 type TieredGalleryImages struct {
-	Safe []string // Admin approved - safe for public/SEO
-	NSFW []string // Admin approved - members only
+	SuperSafe []string // Auto-classified ปลอดภัยสุด (ไม่มี = gallery แบบ manual selection)
+	Safe      []string // Admin approved - safe for public/SEO
+	NSFW      []string // Admin approved - members only
+}
+
+// Tier ภาพของ tier ตามชื่อ (tier ที่ไม่รู้จัก = nil)
+func (t *TieredGalleryImages) Tier(tier GalleryTier) []string {
+	switch tier {
+	case GalleryTierSuperSafe:
+		return t.SuperSafe
+	case GalleryTierSafe:
+		return t.Safe
+	case GalleryTierNSFW:
+		return t.NSFW
+	default:
+		return nil
+	}
 }
 
 type FAQItem struct {
@@ -263,6 +289,7 @@ type EmbeddingData struct {
 
 // SuekkVideoInfo - ข้อมูล video จาก api.suekk.com
 type SuekkVideoInfo struct {
+	ID               string `json:"id"` // video ID ฝั่ง suekk (ใช้สั่ง generate gallery)
 	Code             string `json:"code"`
 	Duration         int    `json:"duration"`         // seconds
 	ThumbnailURL     string `json:"thumbnailUrl"`
@@ -270,6 +297,7 @@ type SuekkVideoInfo struct {
 	GalleryCount     int    `json:"galleryCount"`
 	GallerySafeCount int    `json:"gallerySafeCount"` // จำนวนภาพ safe (pre-classified)
 	GalleryNsfwCount int    `json:"galleryNsfwCount"` // จำนวนภาพ nsfw (pre-classified)
+	CoverOverride    string `json:"galleryCoverOverride"` // editor เลือก cover เอง: ชื่อไฟล์ใน safe/ หรือ URL
 }
 
 // MakerMetadata - ข้อมูล maker จาก api.subth.com
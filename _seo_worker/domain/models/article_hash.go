@@ -0,0 +1,30 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ArticleContentHash hash ของเนื้อหา article (ไม่รวม CreatedAt/UpdatedAt ที่เปลี่ยนทุกครั้งที่ build)
+// article เดิมที่ build ซ้ำ = hash เดิม
+func ArticleContentHash(article *ArticleContent) (string, error) {
+	clone := *article
+	clone.CreatedAt = time.Time{}
+	clone.UpdatedAt = time.Time{}
+
+	data, err := json.Marshal(&clone)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal article for hash: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// PublishIdempotencyKey key สำหรับ publish: videoID + schema version + content hash
+// publish ซ้ำด้วย key เดิม (retry หลัง timeout) = API ไม่สร้าง/เขียนทับซ้ำ
+func PublishIdempotencyKey(article *ArticleContent, contentHash string) string {
+	return fmt.Sprintf("%s:%s:%s", article.VideoID, article.SchemaVersion, contentHash)
+}
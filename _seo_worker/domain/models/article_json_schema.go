@@ -0,0 +1,122 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// ArticleContent JSON Schema
+// สร้างจาก struct ด้วย reflection - เพิ่ม/ลบ field ใน ArticleContent แล้ว schema ตามเอง
+// field ที่ไม่มี omitempty = required, nested struct อยู่ใน $defs (ชื่อ Go type)
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// ArticleJSONSchemaDialect JSON Schema draft ที่ใช้
+const ArticleJSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// ArticleContentJSONSchema สร้าง JSON Schema ของ ArticleContent ตาม ArticleSchemaVersion ปัจจุบัน
+// schemaVersion ถูกล็อกเป็น const เพื่อให้ consumer ตรวจ payload ข้าม version ได้
+func ArticleContentJSONSchema() map[string]any {
+	defs := make(map[string]any)
+	root := structSchema(reflect.TypeOf(ArticleContent{}), defs)
+
+	if props, ok := root["properties"].(map[string]any); ok {
+		props["schemaVersion"] = map[string]any{
+			"type":  "string",
+			"const": ArticleSchemaVersion,
+		}
+	}
+
+	root["$schema"] = ArticleJSONSchemaDialect
+	root["$id"] = "https://subth.com/schemas/article-content/v" + ArticleSchemaVersion + ".json"
+	root["title"] = "ArticleContent"
+	root["version"] = ArticleSchemaVersion
+	root["$defs"] = defs
+	return root
+}
+
+// structSchema schema ของ struct (properties + required) - ไม่ใส่ตัวเองลง $defs
+func structSchema(t reflect.Type, defs map[string]any) map[string]any {
+	properties := make(map[string]any)
+	required := []string{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, omitEmpty, skip := jsonFieldName(field)
+		if skip {
+			continue
+		}
+
+		properties[name] = typeSchema(field.Type, defs)
+		if !omitEmpty {
+			required = append(required, name)
+		}
+	}
+
+	return map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
+
+// typeSchema schema ของ Go type หนึ่งตัว (struct อื่นนอกจาก time.Time = $ref ไป $defs)
+func typeSchema(t reflect.Type, defs map[string]any) map[string]any {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return map[string]any{"anyOf": []any{typeSchema(t.Elem(), defs), map[string]any{"type": "null"}}}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		// nil slice ถูก encode เป็น null
+		return map[string]any{"type": []string{"array", "null"}, "items": typeSchema(t.Elem(), defs)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": typeSchema(t.Elem(), defs)}
+	case reflect.Struct:
+		if t == timeType {
+			return map[string]any{"type": "string", "format": "date-time"}
+		}
+		if _, ok := defs[t.Name()]; !ok {
+			defs[t.Name()] = map[string]any{} // กัน recursion ก่อนสร้างเสร็จ
+			defs[t.Name()] = structSchema(t, defs)
+		}
+		return map[string]any{"$ref": "#/$defs/" + t.Name()}
+	default:
+		return map[string]any{}
+	}
+}
+
+// jsonFieldName ชื่อ field ตาม json tag + omitempty ("-" = ไม่ encode)
+func jsonFieldName(field reflect.StructField) (name string, omitEmpty bool, skip bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, true
+	}
+
+	parts := strings.Split(tag, ",")
+	name = parts[0]
+	if name == "" {
+		name = field.Name
+	}
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return name, omitEmpty, false
+}
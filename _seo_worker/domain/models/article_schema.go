@@ -0,0 +1,41 @@
+package models
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// ArticleContent Schema Versioning
+// ให้ api.subth.com และ re-ingestion tooling รู้ว่า JSON แต่ละชิ้นมี fields อะไร
+//
+// เมื่อเปลี่ยนโครงสร้าง ArticleContent (เพิ่ม/ลบ/เปลี่ยนความหมาย field):
+//  1. bump ArticleSchemaVersion
+//  2. เพิ่ม ArticleSchemaMigration ท้าย ArticleSchemaMigrations อธิบายสิ่งที่เปลี่ยน
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// ArticleSchemaVersion version ปัจจุบันของ ArticleContent
+const ArticleSchemaVersion = "2"
+
+// ArticleSchemaMigration บันทึกการเปลี่ยนแปลงของ schema แต่ละ version
+type ArticleSchemaMigration struct {
+	Version string `json:"version"`
+	Note    string `json:"note"`
+}
+
+// ArticleSchemaMigrations ประวัติ schema (เรียงจากเก่าไปใหม่)
+// ArticleContent ที่ไม่มี schemaVersion = สร้างก่อนมี versioning (ถือเป็น "0")
+var ArticleSchemaMigrations = []ArticleSchemaMigration{
+	{Version: "1", Note: "Initial versioned schema: adds schemaVersion; includes audioVoiceId and memberGalleryImages"},
+	{Version: "2", Note: "Adds internalKeyMoments (member-only, safe-filtered, larger than public keyMoments)"},
+}
+
+// ArticleSchemaMigrationsSince คืน migrations ที่ใหม่กว่า version ที่ระบุ
+// ใช้ตอน re-ingest article เก่าเพื่อดูว่าต้องเติม/แปลง field อะไรบ้าง
+func ArticleSchemaMigrationsSince(version string) []ArticleSchemaMigration {
+	if version == "" {
+		return ArticleSchemaMigrations
+	}
+	for i, m := range ArticleSchemaMigrations {
+		if m.Version == version {
+			return ArticleSchemaMigrations[i+1:]
+		}
+	}
+	// version ที่ไม่รู้จัก (ใหม่กว่า worker นี้) → ไม่มี migration ที่รู้
+	return nil
+}
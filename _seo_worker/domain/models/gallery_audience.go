@@ -0,0 +1,75 @@
+package models
+
+import "fmt"
+
+// GalleryAudience mapping tier → ผู้ชมของ gallery ในบทความ
+// Public = articles/{code}/gallery/public/ (SEO, ภาพแรกเป็น cover), Member = gallery/member/ (members only)
+// tier ที่ไม่อยู่ในทั้งสองรายการ = ไม่ใช้ในบทความ
+type GalleryAudience struct {
+	Public []GalleryTier
+	Member []GalleryTier
+}
+
+// DefaultGalleryAudience safe = public, nsfw = member (พฤติกรรมเดิม)
+func DefaultGalleryAudience() GalleryAudience {
+	return GalleryAudience{
+		Public: []GalleryTier{GalleryTierSafe},
+		Member: []GalleryTier{GalleryTierNSFW},
+	}
+}
+
+// ParseGalleryAudience สร้าง GalleryAudience จากชื่อ tier (เช่น จาก env)
+func ParseGalleryAudience(public, member []string) (GalleryAudience, error) {
+	audience := GalleryAudience{}
+	for _, name := range public {
+		audience.Public = append(audience.Public, GalleryTier(name))
+	}
+	for _, name := range member {
+		audience.Member = append(audience.Member, GalleryTier(name))
+	}
+	return audience, audience.Validate()
+}
+
+// Validate ตรวจชื่อ tier และห้าม tier เดียวกันอยู่ทั้ง public และ member
+func (a GalleryAudience) Validate() error {
+	if len(a.Public) == 0 {
+		return fmt.Errorf("gallery audience: at least one public tier is required")
+	}
+
+	seen := make(map[GalleryTier]string)
+	for audience, tiers := range map[string][]GalleryTier{"public": a.Public, "member": a.Member} {
+		for _, tier := range tiers {
+			switch tier {
+			case GalleryTierSuperSafe, GalleryTierSafe, GalleryTierNSFW:
+			default:
+				return fmt.Errorf("gallery audience: unknown tier %q", tier)
+			}
+			if prev, ok := seen[tier]; ok && prev != audience {
+				return fmt.Errorf("gallery audience: tier %q is both public and member", tier)
+			}
+			seen[tier] = audience
+		}
+	}
+	return nil
+}
+
+// Split แยกภาพเป็น public/member ตามลำดับ tier ที่ตั้งไว้ (tier ซ้ำในรายการเดียวกันใช้ครั้งเดียว)
+func (a GalleryAudience) Split(tiered *TieredGalleryImages) (public, member []string) {
+	if tiered == nil {
+		return nil, nil
+	}
+	return collectTiers(tiered, a.Public), collectTiers(tiered, a.Member)
+}
+
+func collectTiers(tiered *TieredGalleryImages, tiers []GalleryTier) []string {
+	var urls []string
+	used := make(map[GalleryTier]bool, len(tiers))
+	for _, tier := range tiers {
+		if used[tier] {
+			continue
+		}
+		used[tier] = true
+		urls = append(urls, tiered.Tier(tier)...)
+	}
+	return urls
+}
@@ -1,15 +1,33 @@
 package models
 
-import "time"
+import (
+	"errors"
+	"time"
+)
+
+// ErrGalleryNotReady gallery ของวิดีโอยังไม่พร้อม/ภาพ public ไม่พอ - consumer เลื่อน job ไปลองใหม่ภายหลัง
+var ErrGalleryNotReady = errors.New("gallery not ready")
+
+// ErrJobCancelled admin สั่งยกเลิก job (JOB_CANCEL KV) - consumer terminate ไม่ retry
+var ErrJobCancelled = errors.New("job cancelled by admin")
 
 // SEOArticleJob - Job สำหรับสร้าง SEO Article
 // ส่งมาจาก api.subth.com ผ่าน NATS JetStream
 type SEOArticleJob struct {
 	VideoID     string `json:"video_id"`
 	VideoCode   string `json:"video_code"`
-	Priority    int    `json:"priority"`     // 1=urgent, 2=normal, 3=backfill
+	Priority    int    `json:"priority"`     // 1=urgent (publish ไป seo.article.generate.priority เพื่อข้ามคิว), 2=normal, 3=backfill
 	GenerateTTS bool   `json:"generate_tts"` // ต้องการ TTS หรือไม่
 	CreatedAt   int64  `json:"created_at"`
+
+	// OutputLanguage ภาษาของบทความ (ISO 639-1 เช่น "th", "en", "ja") - ว่าง = ภาษาไทย
+	OutputLanguage string `json:"output_language,omitempty"`
+
+	// TraceID request ID ต้นทาง - ติดกับทุก log ของ job นี้ (trace ข้าม service)
+	TraceID string `json:"trace_id,omitempty"`
+
+	// Force ประมวลผลใหม่แม้วัตถุดิบ (SRT, metadata, gallery) ไม่เปลี่ยนจาก run ล่าสุด
+	Force bool `json:"force,omitempty"`
 }
 
 // NewSEOArticleJob สร้าง job ใหม่
@@ -33,21 +51,84 @@ type DLQJob struct {
 	Stage       string        `json:"stage"` // fetch, ai, tts, embedding, publish
 }
 
+// Progress job type / status - รูปแบบเดียวกับ progress ของ transcode, subtitle, gallery ฝั่ง api.suekk.com
+const (
+	ProgressJobTypeSEO = "seo"
+
+	ProgressStatusProcessing = "processing"
+	ProgressStatusCompleted  = "completed"
+	ProgressStatusFailed     = "failed"
+	ProgressStatusCancelled  = "cancelled"
+)
+
 // ProgressUpdate - ส่ง progress กลับไปที่ Admin UI
+// Status ร่วมกับทุก job type, Stage = ขั้นตอนละเอียดของ SEO (ports.Stage*)
 type ProgressUpdate struct {
-	VideoID   string `json:"video_id"`
-	Stage     string `json:"stage"`
-	Progress  int    `json:"progress"` // 0-100
-	Message   string `json:"message,omitempty"`
-	Error     string `json:"error,omitempty"`
-	Timestamp int64  `json:"timestamp"`
+	VideoID   string  `json:"video_id"`
+	VideoCode string  `json:"video_code,omitempty"`
+	JobType   string  `json:"job_type"` // "seo"
+	Status    string  `json:"status"`   // processing, completed, failed, cancelled
+	Stage     string  `json:"stage"`
+	Progress  float64 `json:"progress"` // 0-100
+	Message   string  `json:"message,omitempty"`
+	Error     string  `json:"error,omitempty"`
+	Timestamp int64   `json:"timestamp"`
 }
 
-func NewProgressUpdate(videoID, stage string, progress int) *ProgressUpdate {
+func NewProgressUpdate(videoID, videoCode, stage string, progress float64) *ProgressUpdate {
 	return &ProgressUpdate{
 		VideoID:   videoID,
+		VideoCode: videoCode,
+		JobType:   ProgressJobTypeSEO,
+		Status:    progressStatusForStage(stage),
 		Stage:     stage,
 		Progress:  progress,
 		Timestamp: time.Now().Unix(),
 	}
 }
+
+// progressStatusForStage stage สุดท้าย (completed/failed/cancelled) = status เดียวกัน นอกนั้น processing
+func progressStatusForStage(stage string) string {
+	switch stage {
+	case ProgressStatusCompleted, ProgressStatusFailed, ProgressStatusCancelled:
+		return stage
+	default:
+		return ProgressStatusProcessing
+	}
+}
+
+// JobEventSourceSEO - source ของ event ที่มาจาก SEO worker
+const JobEventSourceSEO = "seo"
+
+// JobEvent - event ของ pipeline stage สำหรับเก็บลง job_events (audit trail)
+type JobEvent struct {
+	VideoID   string
+	Source    string
+	Stage     string
+	Progress  float64
+	Message   string
+	CreatedAt time.Time
+}
+
+func NewJobEvent(videoID, stage string, progress float64, message string) *JobEvent {
+	return &JobEvent{
+		VideoID:   videoID,
+		Source:    JobEventSourceSEO,
+		Stage:     stage,
+		Progress:  progress,
+		Message:   message,
+		CreatedAt: time.Now(),
+	}
+}
+
+// ProcessingCost - ค่าใช้จ่ายของ SEO job หนึ่งครั้ง สำหรับเก็บลง processing_costs
+type ProcessingCost struct {
+	VideoID            string
+	Source             string
+	GeminiPromptTokens int64
+	GeminiOutputTokens int64
+	TTSCharacters      int
+	EmbeddingChars     int
+	JobDuration        time.Duration
+	CreatedAt          time.Time
+}
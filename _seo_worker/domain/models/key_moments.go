@@ -0,0 +1,64 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidKeyMoments key moments ที่ editor ส่งมาไม่ผ่าน validation (endpoint ตอบ 400)
+var ErrInvalidKeyMoments = errors.New("invalid key moments")
+
+// MinKeyMomentDuration ความยาวขั้นต่ำของ key moment (วินาที) - สั้นกว่านี้ขยาย EndOffset ให้
+const MinKeyMomentDuration = 30
+
+// ArticleKeyMoments key moments ของ article ที่ publish แล้ว (สำหรับแก้ไขภายหลัง)
+type ArticleKeyMoments struct {
+	VideoID            string      `json:"videoId"`
+	KeyMoments         []KeyMoment `json:"keyMoments"`                   // Public (Google Schema)
+	InternalKeyMoments []KeyMoment `json:"internalKeyMoments,omitempty"` // Member only
+}
+
+// NormalizeKeyMoment ปรับ key moment ให้พร้อมใช้ใน article (กฎเดียวกับ buildArticle)
+// 1. แปลง milliseconds เป็น seconds (AI บางครั้งคืน ms)
+// 2. ต้องเริ่มในช่วง safe ตาม settings (ไม่ผ่าน = false)
+// 3. สั้นกว่า MinKeyMomentDuration → ขยาย EndOffset (ไม่เกินความยาววิดีโอ)
+// 4. สร้าง URL /member/videos/{videoID}?t={start}
+func NormalizeKeyMoment(km KeyMoment, settings SafeMomentSettings, videoID string, videoDuration int) (KeyMoment, bool) {
+	if km.StartOffset > 10000 {
+		km.StartOffset = km.StartOffset / 1000
+	}
+	if km.EndOffset > 10000 {
+		km.EndOffset = km.EndOffset / 1000
+	}
+
+	if !settings.IsSafeOffset(km.StartOffset) {
+		return km, false
+	}
+
+	if km.EndOffset-km.StartOffset < MinKeyMomentDuration {
+		km.EndOffset = km.StartOffset + MinKeyMomentDuration
+		if videoDuration > 0 && km.EndOffset > videoDuration {
+			km.EndOffset = videoDuration
+		}
+	}
+
+	km.URL = fmt.Sprintf("/member/videos/%s?t=%d", videoID, km.StartOffset)
+	return km, true
+}
+
+// ValidateKeyMoment ตรวจ key moment ที่ editor ส่งมา (เข้มกว่า NormalizeKeyMoment - ไม่ผ่านให้ error ไม่ข้ามเงียบ)
+func ValidateKeyMoment(km KeyMoment, settings SafeMomentSettings, videoDuration int) error {
+	switch {
+	case km.Name == "":
+		return errors.New("name is required")
+	case km.StartOffset < 0:
+		return fmt.Errorf("startOffset %d must be >= 0", km.StartOffset)
+	case km.EndOffset != 0 && km.EndOffset < km.StartOffset:
+		return fmt.Errorf("endOffset %d must be >= startOffset %d", km.EndOffset, km.StartOffset)
+	case videoDuration > 0 && km.StartOffset >= videoDuration:
+		return fmt.Errorf("startOffset %d exceeds video duration %d", km.StartOffset, videoDuration)
+	case !settings.IsSafeOffset(km.StartOffset):
+		return fmt.Errorf("startOffset %d is outside the safe window (%ds)", km.StartOffset, settings.ThresholdSeconds)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package models
+
+import "strings"
+
+// DefaultOutputLanguage ภาษา default ของบทความ SEO (ISO 639-1)
+const DefaultOutputLanguage = "th"
+
+// NormalizeLanguage แปลง language tag เป็น ISO 639-1 ตัวเล็ก
+// "TH", "th-TH", "th_TH" → "th" / ว่าง → ภาษาไทย
+func NormalizeLanguage(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if i := strings.IndexAny(lang, "-_"); i > 0 {
+		lang = lang[:i]
+	}
+	if lang == "" {
+		return DefaultOutputLanguage
+	}
+	return lang
+}
+
+// IsThaiOutput ตรวจว่าบทความเป็นภาษาไทยหรือไม่ (ใช้ตัดสินการ inject "ซับไทย")
+func IsThaiOutput(lang string) bool {
+	return NormalizeLanguage(lang) == DefaultOutputLanguage
+}
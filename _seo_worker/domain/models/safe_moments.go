@@ -0,0 +1,96 @@
+package models
+
+import "fmt"
+
+// Safe Moments defaults (JAV) - ช่วง intro/story setup ที่ปลอดภัยสำหรับ Google
+const (
+	DefaultSafeThresholdSeconds  = 600 // 10 นาทีแรก
+	DefaultMinKeyMoments         = 3   // Public Schema ขั้นต่ำ
+	DefaultMaxKeyMomentsPublic   = 5   // Public (Google)
+	DefaultMaxKeyMomentsInternal = 20  // Internal (Members)
+)
+
+// SafeMomentSettings ค่าตั้งของการกรอง key moments ที่ใช้ร่วมกันทั้ง AI post-process และ buildArticle
+// zero value = ใช้ default ทั้งหมด (เปิดการกรอง)
+type SafeMomentSettings struct {
+	Disabled         bool `json:"disabled"`         // true = ไม่กรองตามเวลา (ยังกรอง keyword blacklist เสมอ)
+	ThresholdSeconds int  `json:"thresholdSeconds"` // เก็บเฉพาะ moments ที่เริ่มก่อนวินาทีนี้
+	MinMoments       int  `json:"minMoments"`       // ต่ำกว่านี้เติม seed moments
+	MaxPublic        int  `json:"maxPublic"`        // สูงสุดใน Public Schema
+	MaxInternal      int  `json:"maxInternal"`      // สูงสุดสำหรับ Members
+}
+
+// WithDefaults เติมค่า default ให้ field ที่ไม่ได้ตั้ง (<= 0)
+func (s SafeMomentSettings) WithDefaults() SafeMomentSettings {
+	if s.ThresholdSeconds <= 0 {
+		s.ThresholdSeconds = DefaultSafeThresholdSeconds
+	}
+	if s.MinMoments <= 0 {
+		s.MinMoments = DefaultMinKeyMoments
+	}
+	if s.MaxPublic <= 0 {
+		s.MaxPublic = DefaultMaxKeyMomentsPublic
+	}
+	if s.MaxInternal <= 0 {
+		s.MaxInternal = DefaultMaxKeyMomentsInternal
+	}
+	return s
+}
+
+// Validate ตรวจความสอดคล้อง (เรียกหลัง WithDefaults): min ≤ maxPublic ≤ maxInternal
+func (s SafeMomentSettings) Validate() error {
+	if s.MinMoments > s.MaxPublic {
+		return fmt.Errorf("safe moments: min (%d) > max public (%d)", s.MinMoments, s.MaxPublic)
+	}
+	if s.MaxPublic > s.MaxInternal {
+		return fmt.Errorf("safe moments: max public (%d) > max internal (%d)", s.MaxPublic, s.MaxInternal)
+	}
+	return nil
+}
+
+// ForDuration ปรับ threshold ไม่ให้เกินความยาววิดีโอ (duration <= 0 = ไม่รู้ความยาว ไม่ปรับ)
+func (s SafeMomentSettings) ForDuration(videoDuration int) SafeMomentSettings {
+	s = s.WithDefaults()
+	if videoDuration > 0 && s.ThresholdSeconds > videoDuration {
+		s.ThresholdSeconds = videoDuration
+	}
+	return s
+}
+
+// IsSafeOffset ตรวจว่า startOffset อยู่ในช่วง safe หรือไม่
+func (s SafeMomentSettings) IsSafeOffset(startOffset int) bool {
+	return s.Disabled || startOffset <= s.ThresholdSeconds
+}
+
+// เหตุผลที่ key moment ถูกตัดออก (SafeMomentsPreview.Dropped)
+const (
+	SafeMomentDropBlacklist = "blacklist"  // ชื่อมีคำต้องห้าม
+	SafeMomentDropTimeLimit = "time_limit" // เริ่มหลัง ThresholdSeconds
+	SafeMomentDropDedup     = "dedup"      // อยู่ใน 30 วินาทีเดียวกับ moment ที่เก็บไว้แล้ว
+	SafeMomentDropCap       = "cap"        // เกิน MaxInternal
+)
+
+// ที่มาของ key moments ใน SafeMomentsPreview
+const (
+	SafeMomentsSourceGenerated = "generated" // เรียก chunk 1 ใหม่จาก SRT
+	SafeMomentsSourceCached    = "cached"    // จาก AI output ที่เก็บไว้ตอน publish ล่าสุด
+)
+
+// DroppedKeyMoment key moment ที่ถูกตัด + เหตุผล
+type DroppedKeyMoment struct {
+	KeyMoment
+	Reason string `json:"reason"`           // SafeMomentDrop*
+	Detail string `json:"detail,omitempty"` // เช่น limit ที่เกิน
+}
+
+// SafeMomentsPreview ผลการกรอง key moments ทีละขั้น (ให้ editor ดูก่อน publish)
+type SafeMomentsPreview struct {
+	VideoCode string             `json:"videoCode"`
+	Source    string             `json:"source"` // SafeMomentsSource*
+	Settings  SafeMomentSettings `json:"settings"`
+	Input     []KeyMoment        `json:"input"`    // ก่อนกรอง
+	Dropped   []DroppedKeyMoment `json:"dropped"`  // ถูกตัด (ตามลำดับขั้น)
+	Seeds     []KeyMoment        `json:"seeds"`    // seed moments ที่เติมเพราะเหลือไม่ถึง MinMoments
+	Public    []KeyMoment        `json:"public"`   // Google Schema
+	Internal  []KeyMoment        `json:"internal"` // Members
+}
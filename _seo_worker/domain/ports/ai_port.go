@@ -16,6 +16,10 @@ type AIPort interface {
 	// ใช้ Atomic Chunking + Context Feeding + Entity-Consistency
 	// ~55 sec (vs ~90 sec sequential)
 	GenerateArticleContentV2(ctx context.Context, input *AIInput) (*AIOutput, error)
+
+	// PreviewSafeMoments กรอง key moments ด้วยกฎเดียวกับ pipeline แล้วคืนผลทีละขั้น
+	// moments == nil = สร้างใหม่ด้วย chunk 1 จาก input.SRTContent
+	PreviewSafeMoments(ctx context.Context, input *AIInput, moments []models.KeyMoment) (*models.SafeMomentsPreview, error)
 }
 
 // AIInput - ข้อมูลที่ส่งให้ AI
@@ -27,6 +31,8 @@ type AIInput struct {
 	PreviousWorks   []models.PreviousWork    // For context
 	GalleryCount    int                      // จำนวน gallery images (สำหรับสร้าง alt)
 	RelatedArticles []RelatedArticleForAI    // Related articles (สำหรับสร้าง contextual links)
+	OutputLanguage  string                   // ภาษาของบทความ (e.g. "th", "en") - ว่าง = ภาษาไทย
+	SafeMoments     models.SafeMomentSettings // ค่าตั้ง safe moments ของ job (zero value = default)
 }
 
 // RelatedArticleForAI - ข้อมูล related article สำหรับ AI สร้าง contextual links
@@ -52,7 +58,8 @@ type AIOutput struct {
 	QualityScore    int      `json:"qualityScore"`
 
 	// Key Moments with timestamps from SRT
-	KeyMoments []models.KeyMoment `json:"keyMoments"`
+	KeyMoments         []models.KeyMoment `json:"keyMoments"`                   // Public (Google Schema)
+	InternalKeyMoments []models.KeyMoment `json:"internalKeyMoments,omitempty"` // Members (ชุดใหญ่กว่า, safe-filtered แล้ว)
 
 	// Cast bios generated from previous works
 	CastBios []CastBio `json:"castBios"`
@@ -133,6 +140,17 @@ type AIOutput struct {
 	BestMoments   []string `json:"bestMoments,omitempty"`   // ช่วงเวลาดีที่สุด
 	AudienceMatch string   `json:"audienceMatch,omitempty"` // เหมาะกับใคร
 	ReplayValue   string   `json:"replayValue,omitempty"`   // ความคุ้มค่าดูซ้ำ
+
+	// Token usage รวมทุก chunk (ไม่ใช่ส่วนของบทความ - ใช้ log/cost tracking)
+	TokenUsage TokenUsage `json:"-"`
+}
+
+// TokenUsage จำนวน token ที่ Gemini ใช้ (จาก UsageMetadata ของ response)
+type TokenUsage struct {
+	PromptTokens    int64 `json:"promptTokens"`
+	CandidateTokens int64 `json:"candidateTokens"`
+	TotalTokens     int64 `json:"totalTokens"`
+	Calls           int   `json:"calls"` // จำนวนครั้งที่เรียก API (รวม retry)
 }
 
 type CastBio struct {
@@ -26,6 +26,9 @@ type ConsumerPort interface {
 	// IsPaused ตรวจสอบว่า paused อยู่หรือไม่
 	IsPaused() bool
 
+	// InFlight จำนวน job ที่กำลังทำอยู่ (ไม่เกิน WORKER_CONCURRENCY)
+	InFlight() int
+
 	// Pause หยุดรับ job ชั่วคราว
 	Pause()
 
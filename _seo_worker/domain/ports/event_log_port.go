@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+
+	"seo-worker/domain/models"
+)
+
+// JobEventPort - Interface สำหรับบันทึก pipeline events (job_events)
+// ใช้ดู timeline ย้อนหลังว่าแต่ละ stage ใช้เวลาเท่าไหร่ / fail ตรงไหน
+type JobEventPort interface {
+	// RecordEvent บันทึก event หนึ่งรายการ
+	RecordEvent(ctx context.Context, event *models.JobEvent) error
+
+	// RecordCost บันทึก processing cost ของ job (processing_costs)
+	RecordCost(ctx context.Context, cost *models.ProcessingCost) error
+}
@@ -23,6 +23,9 @@ type SuekkVideoFetcherPort interface {
 
 	// ListAllGalleryImages ดึงรายการ gallery images จากทุก tier (safe, nsfw)
 	ListAllGalleryImages(ctx context.Context, galleryPath string) (*models.TieredGalleryImages, error)
+
+	// RequestGalleryGeneration สั่งสร้าง gallery ของวิดีโอ (videoID ฝั่ง suekk)
+	RequestGalleryGeneration(ctx context.Context, videoID string) error
 }
 
 // ImageSelectorPort - Interface สำหรับเลือกภาพ cover และ gallery ที่เหมาะสม
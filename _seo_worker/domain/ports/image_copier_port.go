@@ -16,15 +16,25 @@ type ImageCopierPort interface {
 	// srcURL = URL จาก e2, returns URL ใหม่จาก r2
 	CopyImage(ctx context.Context, videoCode string, srcURL string, filename string) (string, error)
 
-	// CopyTieredGallery copy ภาพจากทุก tier ไป r2 แยก path
-	// - public/  = safe (admin approved - Google-safe)
-	// - member/  = nsfw (admin approved - members only)
-	CopyTieredGallery(ctx context.Context, videoCode string, tiered *models.TieredGalleryImages) (*CopiedGalleryResult, error)
+	// CopyTieredGallery copy ภาพจากทุก tier ไป r2 แยก path ตาม audience
+	// - public/  = audience.Public (default: safe - admin approved, Google-safe)
+	// - member/  = audience.Member (default: nsfw - members only)
+	CopyTieredGallery(ctx context.Context, videoCode string, tiered *models.TieredGalleryImages, audience models.GalleryAudience) (*CopiedGalleryResult, error)
 }
 
 // CopiedGalleryResult - ผลลัพธ์จาก CopyTieredGallery
+// PublicImages/MemberImages มีเฉพาะภาพที่ verify แล้วว่าอยู่ใน R2 จริง
 type CopiedGalleryResult struct {
-	PublicImages []models.GalleryImage // R2 URLs for safe (admin approved)
-	MemberImages []models.GalleryImage // R2 URLs for nsfw
+	PublicImages []models.GalleryImage // R2 URLs ของ tier ใน audience.Public
+	MemberImages []models.GalleryImage // R2 URLs ของ tier ใน audience.Member
 	CoverURL     string                // Best cover image URL
+	Failed       []FailedImageCopy     // ภาพที่ copy ไม่สำเร็จหลัง verify + repair
+}
+
+// FailedImageCopy - ภาพที่ copy ไป R2 ไม่สำเร็จ (ห้ามใส่ในบทความ)
+type FailedImageCopy struct {
+	Tier      string `json:"tier"` // public, member, cover
+	SourceURL string `json:"sourceUrl"`
+	DestURL   string `json:"destUrl"`
+	Error     string `json:"error"`
 }
@@ -0,0 +1,13 @@
+package ports
+
+import "context"
+
+// JobCancelPort - Interface สำหรับรับคำขอยกเลิก job จาก api.suekk.com (NATS KV JOB_CANCEL)
+type JobCancelPort interface {
+	// Watch คืน ctx ที่ถูก cancel ด้วย cause models.ErrJobCancelled เมื่อมีคำขอยกเลิก job ของวิดีโอนี้
+	// ต้องเรียก stop เมื่อ job จบ
+	Watch(ctx context.Context, videoID string) (context.Context, func())
+
+	// Clear ลบคำขอยกเลิกหลังหยุด job แล้ว
+	Clear(ctx context.Context, videoID string) error
+}
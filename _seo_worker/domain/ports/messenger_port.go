@@ -13,13 +13,13 @@ type MessengerPort interface {
 	SendProgress(ctx context.Context, update *models.ProgressUpdate) error
 
 	// SendCompleted ส่งแจ้งว่า job เสร็จแล้ว
-	SendCompleted(ctx context.Context, videoID string) error
+	SendCompleted(ctx context.Context, videoID, videoCode string) error
 
 	// SendFailed ส่งแจ้งว่า job failed
-	SendFailed(ctx context.Context, videoID string, err error) error
+	SendFailed(ctx context.Context, videoID, videoCode string, err error) error
 }
 
-// Progress stages
+// Progress stages (StageCompleted/StageFailed/StageCancelled ตรงกับ models.ProgressStatus*)
 const (
 	StageFetching   = "fetching_data"
 	StageDataFetched = "data_fetched"
@@ -30,4 +30,5 @@ const (
 	StagePublishing = "publishing"
 	StageCompleted  = "completed"
 	StageFailed     = "failed"
+	StageCancelled  = "cancelled"
 )
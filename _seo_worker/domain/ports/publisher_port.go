@@ -14,6 +14,15 @@ type ArticlePublisherPort interface {
 
 	// UpdateArticleStatus อัพเดทสถานะ (draft/published)
 	UpdateArticleStatus(ctx context.Context, videoID string, status string) error
+
+	// GetArticleKeyMoments ดึง key moments ของ article ที่ publish แล้ว
+	GetArticleKeyMoments(ctx context.Context, videoID string) (*models.ArticleKeyMoments, error)
+
+	// UpdateArticleKeyMoments แทนที่ key moments ของ article (ผ่าน validation มาแล้ว)
+	UpdateArticleKeyMoments(ctx context.Context, moments *models.ArticleKeyMoments) error
+
+	// GetArticleSlugOwner คืน video ID ของ article ที่ใช้ slug นี้อยู่ ("" = ยังไม่มีใครใช้)
+	GetArticleSlugOwner(ctx context.Context, slug string) (string, error)
 }
 
 // Article status constants
@@ -1,8 +1,19 @@
 package ports
 
-import "context"
+import (
+	"context"
+	"errors"
+)
 
-// TTSPort - Interface สำหรับ Text-to-Speech (ElevenLabs)
+// TTS errors ที่ retry ไม่ช่วย (provider wrap ด้วย %w)
+var (
+	// ErrTTSInvalidInput text ใช้ไม่ได้ (เช่น ว่าง) - ทุก voice ก็ล้มเหลวเหมือนกัน
+	ErrTTSInvalidInput = errors.New("tts: invalid input")
+	// ErrTTSRejected provider ตอบ 4xx (ยกเว้น 408/429) - retry voice เดิมไม่ช่วย แต่ voice อื่นอาจผ่าน
+	ErrTTSRejected = errors.New("tts: request rejected")
+)
+
+// TTSPort - Interface สำหรับ Text-to-Speech (ElevenLabs, Google Cloud TTS)
 type TTSPort interface {
 	// GenerateAudio สร้างไฟล์เสียงจาก text
 	GenerateAudio(ctx context.Context, text string, voiceID string) (*TTSResult, error)
@@ -13,6 +24,7 @@ type TTSResult struct {
 	AudioData []byte // MP3 data
 	Duration  int    // seconds
 	CharCount int    // characters used (for logging)
+	VoiceID   string // voice ที่ใช้สร้างจริง
 }
 
 // ExtractTTSScript สกัดใจความสำคัญจาก summary + highlights
@@ -54,7 +54,7 @@ func (c *GeminiClient) buildChunk1Schema() *genai.Schema {
 					Type: genai.TypeObject,
 					Properties: map[string]*genai.Schema{
 						"name":        {Type: genai.TypeString, Description: "ชื่อฉากสุภาพ ⚠️ ใช้คำสุภาพเสมอ เช่น 'ช่วงเวลาแห่งความใกล้ชิด' แทน 'ฉากเซ็กส์'"},
-						"startOffset": {Type: genai.TypeInteger, Description: "เวลาเริ่ม (วินาที) ⚠️ ดึงจาก timestamp ใน SRT โดยตรง กระจายทั่ววิดีโอ"},
+						"startOffset": {Type: genai.TypeInteger, Description: "เวลาเริ่ม (วินาที) ⚠️ ดึงจาก timestamp ใน SRT โดยตรง อยู่ในช่วงเวลาที่ prompt กำหนด"},
 						"endOffset":   {Type: genai.TypeInteger, Description: "เวลาจบ (วินาที) ต้อง > startOffset อย่างน้อย 60 วินาที"},
 					},
 					Required: []string{"name", "startOffset", "endOffset"},
@@ -101,7 +101,12 @@ func (c *GeminiClient) buildChunk1Prompt(input *ports.AIInput) string {
 		castNames[i] = cast.Name
 	}
 
-	return fmt.Sprintf(`# บทบาท (Persona)
+	// จำนวน/ช่วงเวลา keyMoments ตาม safe moment settings (duration ขั้นต่ำ 60 วินาที)
+	safeMoments := safeMomentsFor(input)
+	momentsWindow := keyMomentsWindowRule(safeMoments)
+	minMoments, maxMoments := keyMomentsPromptRange(safeMoments, input.VideoMetadata.Duration, 60)
+
+	return languageInstruction(input.OutputLanguage) + fmt.Sprintf(`# บทบาท (Persona)
 คุณคือ "นักเขียนรีวิวหนังผู้ใหญ่ระดับ Premium ที่เก่งที่สุดในประเทศไทย"
 - เชี่ยวชาญการสรุปเนื้อหาและจับ key moments จาก subtitle
 - เขียนภาษาไทยที่เป็นธรรมชาติ ไม่แข็งทื่อ ไม่เหมือนหุ่นยนต์
@@ -132,12 +137,12 @@ func (c *GeminiClient) buildChunk1Prompt(input *ports.AIInput) string {
    - ✅ บรรยายฉากสำคัญทุกฉาก พร้อมอารมณ์ความรู้สึก
    - ✅ เล่าเรื่องราวตั้งแต่ต้นจนจบอย่างละเอียด
    - ✅ ใส่ความรู้สึกของตัวละคร บรรยากาศ และบริบท
-2. **keyMoments timestamps** (กระจายทั่ววิดีโอ + ใช้ภาษาสุภาพ):
-   - ⚠️ **กระจาย 8-12 moments ทั่วทั้งวิดีโอ** (ไม่จำกัดแค่ 10 นาทีแรก)
+2. **keyMoments timestamps** (อยู่ในช่วงเวลาที่กำหนด + ใช้ภาษาสุภาพ):
+   - ⚠️ **%d-%d moments %s**
    - ⚠️ startOffset และ endOffset ต้องเป็น **วินาที** (ไม่ใช่ milliseconds)
    - ⚠️ แต่ละ moment ต้องมี duration อย่างน้อย **60 วินาที** (endOffset - startOffset >= 60)
    - ⚠️ endOffset ต้อง > startOffset เสมอ
-   - ⚠️ **ห้าม timestamps ซ้อนทับกัน!** กระจายอย่างสม่ำเสมอตลอดวิดีโอ
+   - ⚠️ **ห้าม timestamps ซ้อนทับกัน!**
 
    ### 📛 กฎการใช้ภาษาสุภาพ (สำคัญมาก!)
    - ⚠️ **ห้ามใช้คำหยาบ/โจ่งแจ้ง** - ใช้คำสุภาพแทนเสมอ:
@@ -150,7 +155,7 @@ func (c *GeminiClient) buildChunk1Prompt(input *ports.AIInput) string {
 
    ### 📍 วิธีดึง keyMoments จาก SRT (สำคัญ!)
    - **ดูจาก timestamp ใน SRT** เช่น "00:45:30 --> 00:45:35" = startOffset: 2730
-   - **กระจายทั่วทั้งวิดีโอ** - ต้น กลาง ปลาย อย่างสม่ำเสมอ
+   - **%s**
    - **ใช้ชื่อเฉพาะเจาะจง** เช่น "คุณหมอเริ่มการตรวจร่างกาย" ไม่ใช่ "บทสนทนาทั่วไป"
    - **ห้ามใช้ชื่อ generic** เช่น "บทนำและการแนะนำตัวละครหลัก" ← ไม่ดี
 3. **highlights ต้องมี 5-10 รายการ** แต่ละรายการบรรยายฉากพร้อมอารมณ์
@@ -164,7 +169,7 @@ func (c *GeminiClient) buildChunk1Prompt(input *ports.AIInput) string {
 4. **summary**: ⚠️ สรุปเนื้อหา **600-800 คำ** (2,000-3,000 ตัวอักษร) - เขียนยาวๆ 5-6 ย่อหน้า บรรยายทุกฉากสำคัญอย่างละเอียด
 5. **summaryShort**: 🎯 TTS Audio Script 80-150 คำ (~2 นาที) - เขียนแบบ teaser น่าติดตาม เล่าเรื่องให้น่าสนใจ สร้างความอยากรู้ ใส่อารมณ์ความตื่นเต้น ห้ามสปอยล์ตอนจบ ลงท้ายเชิญชวนให้ดู
 6. **highlights**: 5-10 ฉากสำคัญ บรรยายอารมณ์และความรู้สึก
-7. **keyMoments**: ดึง timestamp จาก SRT โดยตรง 8-12 moments กระจายทั่ววิดีโอ ใช้คำสุภาพ
+7. **keyMoments**: ดึง timestamp จาก SRT โดยตรง %d-%d moments %s ใช้คำสุภาพ
 8. **galleryAlts**: Alt text แบบ Hybrid สำหรับ %d รูป: "[รหัส] - [ชื่อนักแสดง] - [บริบทกว้างๆ]"
 9. **sceneLocations**: สถานที่ในเรื่อง เช่น ["ห้องตรวจ", "คลินิก"]
 10. **thumbnailAlt**: Alt text สำหรับ thumbnail
@@ -176,8 +181,8 @@ func (c *GeminiClient) buildChunk1Prompt(input *ports.AIInput) string {
 - ❌ เขียนแบบหุ่นยนต์ หรือ Wikipedia
 - ❌ keyMoments.endOffset < startOffset
 - ❌ keyMoments duration < 60 วินาที
-- ❌ **keyMoments น้อยกว่า 8 รายการ (ต้องมีอย่างน้อย 8 moments)**
-- ❌ **keyMoments กระจุกตัวอยู่ช่วงต้น (ต้องกระจายทั่วทั้งวิดีโอ)**
+- ❌ **keyMoments น้อยกว่า %d รายการ (ต้องมีอย่างน้อย %d moments)**
+- ❌ **keyMoments นอกช่วงเวลาที่กำหนด (จะถูกตัดทิ้ง)**
 - ❌ **ใช้คำหยาบ/โจ่งแจ้งใน keyMoments** (ใช้คำสุภาพเสมอ!)
 - ❌ **summary สั้นกว่า 600 คำ (ถ้าสั้นกว่านี้จะถูก REJECT ทันที!)**
 - ❌ **ห้ามผสมภาษาในชื่อนักแสดง!** เช่น "เซ็นมะ มami" หรือ "เซ็นมะ Mami" ← ผิด!
@@ -232,9 +237,13 @@ summary ต้องยาวประมาณนี้ (600+ คำ):
 		input.VideoMetadata.Duration,
 		strings.Join(castNames, ", "),
 		input.GalleryCount,
+		minMoments, maxMoments, momentsWindow,
+		momentsWindow,
 		input.GalleryCount,
 		input.VideoMetadata.RealCode,
+		minMoments, maxMoments, momentsWindow,
 		input.GalleryCount,
+		minMoments, minMoments,
 		strings.Join(castNames, ", "), // สำหรับ กฎชื่อนักแสดง
 	)
 }
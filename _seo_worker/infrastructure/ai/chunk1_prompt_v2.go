@@ -82,7 +82,7 @@ func (c *GeminiClient) buildChunk1PromptV2(input *ports.AIInput) string {
 	}
 	castNamesStr := strings.Join(castNames, ", ")
 
-	return fmt.Sprintf(`[PERSONA]
+	return languageInstruction(input.OutputLanguage) + fmt.Sprintf(`[PERSONA]
 คุณคือ "นักเขียน SEO มืออาชีพระดับ Premium"
 - เชี่ยวชาญการสรุปเนื้อหาและจับใจความสำคัญ
 - เขียนภาษาไทยที่เป็นธรรมชาติ ไม่แข็งทื่อ
@@ -150,17 +150,17 @@ func (c *GeminiClient) buildChunk1PromptV2(input *ports.AIInput) string {
 ## 🎯 เกณฑ์ให้คะแนน qualityScore (สำคัญมาก!)
 
 ### ระดับคะแนน:
-- **9-10 (Masterpiece)**: เนื้อเรื่องยอดเยี่ยม, การแสดงระดับสูงมาก, งานภาพสวย, อารมณ์ลึกซึ้ง (หายาก ~5%)
-- **7-8 (ดีมาก)**: เนื้อเรื่องน่าสนใจ, การแสดงดี, มีจุดเด่นชัดเจน (~30%)
-- **5-6 (ปานกลาง)**: เนื้อเรื่องธรรมดา, การแสดงพอใช้, ไม่มีอะไรโดดเด่น (~40%)
-- **3-4 (ต่ำกว่ามาตรฐาน)**: เนื้อเรื่องอ่อน, การแสดงไม่น่าประทับใจ (~20%)
-- **1-2 (แย่)**: ไม่แนะนำ (~5%)
+- **9-10 (Masterpiece)**: เนื้อเรื่องยอดเยี่ยม, การแสดงระดับสูงมาก, งานภาพสวย, อารมณ์ลึกซึ้ง (หายาก ~5%%)
+- **7-8 (ดีมาก)**: เนื้อเรื่องน่าสนใจ, การแสดงดี, มีจุดเด่นชัดเจน (~30%%)
+- **5-6 (ปานกลาง)**: เนื้อเรื่องธรรมดา, การแสดงพอใช้, ไม่มีอะไรโดดเด่น (~40%%)
+- **3-4 (ต่ำกว่ามาตรฐาน)**: เนื้อเรื่องอ่อน, การแสดงไม่น่าประทับใจ (~20%%)
+- **1-2 (แย่)**: ไม่แนะนำ (~5%%)
 
 ### ปัจจัยที่ต้องพิจารณา:
-1. **ความน่าสนใจของเนื้อเรื่อง (40%)**: Plot twist? มีความซับซ้อน? หรือเป็นแบบแผนทั่วไป?
-2. **คุณภาพการแสดง (30%)**: อารมณ์สมจริง? มี chemistry? หรือแข็งทื่อ?
-3. **งานภาพ/บรรยากาศ (20%)**: มุมกล้องน่าสนใจ? แสงสวย? หรือธรรมดา?
-4. **คุณภาพบทสนทนา (10%)**: บทพูดดี? หรือน่าเบื่อ?
+1. **ความน่าสนใจของเนื้อเรื่อง (40%%)**: Plot twist? มีความซับซ้อน? หรือเป็นแบบแผนทั่วไป?
+2. **คุณภาพการแสดง (30%%)**: อารมณ์สมจริง? มี chemistry? หรือแข็งทื่อ?
+3. **งานภาพ/บรรยากาศ (20%%)**: มุมกล้องน่าสนใจ? แสงสวย? หรือธรรมดา?
+4. **คุณภาพบทสนทนา (10%%)**: บทพูดดี? หรือน่าเบื่อ?
 
 ### ⚠️ ข้อห้าม:
 - ❌ ห้ามให้ 9-10 ทุกเรื่อง (ถ้าทุกเรื่องดี = ไม่มีเรื่องไหนดี)
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/google/generative-ai-go/genai"
+	"seo-worker/domain/models"
 	"seo-worker/domain/ports"
 )
 
@@ -16,7 +17,9 @@ import (
 // ============================================================================
 
 // buildChunk2Schema สร้าง JSON Schema สำหรับ Chunk 2
-func (c *GeminiClient) buildChunk2Schema() *genai.Schema {
+func (c *GeminiClient) buildChunk2Schema(safeMoments models.SafeMomentSettings) *genai.Schema {
+	quoteWindow := topQuoteWindowRule(safeMoments)
+
 	return &genai.Schema{
 		Type: genai.TypeObject,
 		Properties: map[string]*genai.Schema{
@@ -47,13 +50,13 @@ func (c *GeminiClient) buildChunk2Schema() *genai.Schema {
 					Type: genai.TypeObject,
 					Properties: map[string]*genai.Schema{
 						"text":      {Type: genai.TypeString, Description: "ประโยคภาษาไทย (ไม่ใช่ประโยคลามก)"},
-						"timestamp": {Type: genai.TypeInteger, Description: "เวลา (วินาที) ⚠️ ต้องอยู่ภายใน 600 วินาทีแรก"},
+						"timestamp": {Type: genai.TypeInteger, Description: "เวลา (วินาที) ⚠️ " + quoteWindow},
 						"emotion":   {Type: genai.TypeString, Description: "อารมณ์"},
 						"context":   {Type: genai.TypeString, Description: "บริบท"},
 					},
 					Required: []string{"text", "timestamp", "emotion", "context"},
 				},
-				Description: "3-5 ประโยคเด็ดจากซับ ⚠️ เลือกเฉพาะ 10 นาทีแรก (600 วินาที) เน้นบทสนทนาที่น่าสนใจ",
+				Description: "3-5 ประโยคเด็ดจากซับ ⚠️ " + quoteWindow + " เน้นบทสนทนาที่น่าสนใจ",
 			},
 			"expertAnalysis": {
 				Type:        genai.TypeString,
@@ -172,24 +175,13 @@ func (c *GeminiClient) buildChunk2Prompt(input *ports.AIInput, chunk1 *Chunk1Out
 	}
 
 	// สร้าง related articles string (for contextual links)
-	var relatedArticles strings.Builder
-	if len(input.RelatedArticles) == 0 {
-		relatedArticles.WriteString("⚠️ ไม่มี Related Articles - ให้ส่ง contextualLinks เป็น array ว่าง []\n")
-	} else {
-		for _, article := range input.RelatedArticles {
-			relatedArticles.WriteString(fmt.Sprintf("- Slug: %s, Code: %s, Title: %s, Casts: %s, Tags: %s\n",
-				article.Slug, article.RealCode, article.Title,
-				strings.Join(article.CastNames, ", "),
-				strings.Join(article.Tags, ", "),
-			))
-		}
-	}
+	relatedArticles := relatedArticlesPrompt(input.RelatedArticles)
 
 	// Serialize Chunk 1 context
 	highlightsJSON, _ := json.Marshal(chunk1.Highlights)
 	keyMomentsJSON, _ := json.Marshal(chunk1.KeyMoments)
 
-	return fmt.Sprintf(`# บทบาท (Persona)
+	return languageInstruction(input.OutputLanguage) + fmt.Sprintf(`# บทบาท (Persona)
 คุณคือ "นักวิเคราะห์หนังผู้ใหญ่ระดับ Premium ที่เก่งที่สุดในประเทศไทย"
 - เชี่ยวชาญการวิเคราะห์อารมณ์และความรู้สึกของตัวละคร
 - สามารถวิเคราะห์บทสนทนาและการแสดงได้อย่างละเอียด
@@ -267,7 +259,7 @@ func (c *GeminiClient) buildChunk2Prompt(input *ports.AIInput, chunk1 *Chunk1Out
 1. **dialogueAnalysis**: วิเคราะห์บทสนทนา สรรพนาม หางเสียง อารมณ์ (100-150 คำ)
 2. **characterInsight**: วิเคราะห์บุคลิกตัวละคร (100-150 คำ)
 3. **topQuotes**: 3-5 ประโยคเด็ดจากซับ
-    - ⚠️ **เลือกเฉพาะ timestamp ภายใน 600 วินาทีแรก (10 นาที)**
+    - ⚠️ **%[11]s**
     - เน้นบทสนทนาที่น่าสนใจ ไม่ใช่ประโยคลามก
     - พร้อม timestamp (วินาที), emotion, context
 4. **languageNotes**: หมายเหตุภาษา (50 คำ)
@@ -335,6 +327,7 @@ func (c *GeminiClient) buildChunk2Prompt(input *ports.AIInput, chunk1 *Chunk1Out
 		castsInfo.String(),
 		prevWorks.String(),
 		tagsInfo.String(),
-		relatedArticles.String(),
+		relatedArticles,
+		topQuoteWindowRule(safeMomentsFor(input)),
 	)
 }
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/google/generative-ai-go/genai"
+	"seo-worker/domain/models"
 	"seo-worker/domain/ports"
 )
 
@@ -36,7 +37,7 @@ func (c *GeminiClient) buildChunk2SchemaV2() *genai.Schema {
 					},
 					Required: []string{"name", "startOffset", "endOffset"},
 				},
-				Description: "3-5 key moments กระจายทั่วทั้งวิดีโอ ใช้ชื่อสุภาพ",
+				Description: "key moments ตามจำนวนและช่วงเวลาที่ prompt กำหนด ใช้ชื่อสุภาพ (Google เห็นเฉพาะชุดแรก, สมาชิกเห็นทั้งหมด)",
 			},
 			"sceneLocations": {
 				Type:        genai.TypeArray,
@@ -65,7 +66,12 @@ func (c *GeminiClient) buildChunk2PromptV2(input *ports.AIInput, coreCtx *CoreCo
 	// Serialize entities
 	entitiesJSON, _ := json.Marshal(coreCtx.Entities)
 
-	return fmt.Sprintf(`[PERSONA]
+	// จำนวน/ช่วงเวลา keyMoments ตาม safe moment settings (ตรงกับ processKeyMomentsSafe)
+	safeMoments := safeMomentsFor(input)
+	momentsWindow := keyMomentsWindowRule(safeMoments)
+	minMoments, maxMoments := keyMomentsPromptRange(safeMoments, input.VideoMetadata.Duration, models.MinKeyMomentDuration)
+
+	return languageInstruction(input.OutputLanguage) + fmt.Sprintf(`[PERSONA]
 คุณคือ "ผู้กำกับภาพยนตร์ / Scene Analyst"
 - เชี่ยวชาญการวิเคราะห์ฉากและ Timing
 - สังเกตรายละเอียดและบรรยากาศ
@@ -128,9 +134,9 @@ func (c *GeminiClient) buildChunk2PromptV2(input *ports.AIInput, coreCtx *CoreCo
 
 - แต่ละ highlight ต้องยาว 15-30 คำ
 
-### 2. keyMoments ต้องกระจายทั่ววิดีโอ
+### 2. keyMoments ต้องอยู่ในช่วงเวลาที่กำหนด
 - ⚠️ ดึง timestamp จาก SRT โดยตรง
-- ⚠️ กระจาย 3-5 moments ตลอดวิดีโอ
+- ⚠️ %d-%d moments %s (สมาชิกเห็นทั้งหมด)
 - ⚠️ ใช้ชื่อสุภาพ ห้ามใช้คำหยาบ
 
 ### 3. Entity-Consistency
@@ -142,7 +148,7 @@ func (c *GeminiClient) buildChunk2PromptV2(input *ports.AIInput, coreCtx *CoreCo
 ## Output Requirements
 
 1. **highlights**: 5-8 ฉากสำคัญ แต่ละจุด 15-30 คำ
-2. **keyMoments**: 3-5 timestamps พร้อมชื่อสุภาพ
+2. **keyMoments**: %d-%d timestamps พร้อมชื่อสุภาพ
 3. **sceneLocations**: 3-5 สถานที่ในเรื่อง
 4. **galleryAlts**: %d alt texts รูปแบบ "[%s] - [ชื่อนักแสดง] - [บริบท]"
 
@@ -165,6 +171,8 @@ func (c *GeminiClient) buildChunk2PromptV2(input *ports.AIInput, coreCtx *CoreCo
 		castNamesStr,
 		input.GalleryCount,
 		GlobalConstraintsV2+GlobalConstraintsForArrays, // Global Rules
+		minMoments, maxMoments, momentsWindow,
+		minMoments, maxMoments,
 		input.GalleryCount,
 		input.VideoMetadata.RealCode,
 	)
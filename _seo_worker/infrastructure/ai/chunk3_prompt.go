@@ -110,7 +110,7 @@ func (c *GeminiClient) buildChunk3Prompt(input *ports.AIInput, chunk1 *Chunk1Out
 	// Format duration to readable Thai format
 	durationStr := formatDurationThai(input.VideoMetadata.Duration)
 
-	return fmt.Sprintf(`# บทบาท (Persona)
+	return languageInstruction(input.OutputLanguage) + fmt.Sprintf(`# บทบาท (Persona)
 คุณคือ "ผู้เชี่ยวชาญด้าน SEO และ Technical Content สำหรับเว็บไซต์หนังผู้ใหญ่"
 - เชี่ยวชาญการเขียน FAQ ที่ตอบคำถามที่คนค้นหาจริง
 - สามารถวิเคราะห์คุณภาพเทคนิคของวิดีโอและซับไตเติ้ล
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/google/generative-ai-go/genai"
+	"seo-worker/domain/models"
 	"seo-worker/domain/ports"
 )
 
@@ -16,7 +17,9 @@ import (
 // ============================================================================
 
 // buildChunk3SchemaV2 สร้าง JSON Schema สำหรับ Chunk 3 V2
-func (c *GeminiClient) buildChunk3SchemaV2() *genai.Schema {
+func (c *GeminiClient) buildChunk3SchemaV2(safeMoments models.SafeMomentSettings) *genai.Schema {
+	quoteWindow := topQuoteWindowRule(safeMoments)
+
 	return &genai.Schema{
 		Type: genai.TypeObject,
 		Properties: map[string]*genai.Schema{
@@ -34,13 +37,13 @@ func (c *GeminiClient) buildChunk3SchemaV2() *genai.Schema {
 					Type: genai.TypeObject,
 					Properties: map[string]*genai.Schema{
 						"text":      {Type: genai.TypeString, Description: "ประโยคภาษาไทย (ไม่ใช่ประโยคลามก)"},
-						"timestamp": {Type: genai.TypeInteger, Description: "เวลา (วินาที) ⚠️ ต้องอยู่ภายใน 600 วินาทีแรก"},
+						"timestamp": {Type: genai.TypeInteger, Description: "เวลา (วินาที) ⚠️ " + quoteWindow},
 						"emotion":   {Type: genai.TypeString, Description: "อารมณ์"},
 						"context":   {Type: genai.TypeString, Description: "บริบท"},
 					},
 					Required: []string{"text", "timestamp", "emotion", "context"},
 				},
-				Description: "4-5 ประโยคเด็ดจากซับ ⚠️ เลือกเฉพาะ 10 นาทีแรก",
+				Description: "4-5 ประโยคเด็ดจากซับ ⚠️ " + quoteWindow,
 			},
 			"languageNotes": {
 				Type:        genai.TypeString,
@@ -78,7 +81,7 @@ func (c *GeminiClient) buildChunk3PromptV2(input *ports.AIInput, coreCtx *CoreCo
 	// Entities
 	entitiesJSON, _ := json.Marshal(coreCtx.Entities)
 
-	return fmt.Sprintf(`[PERSONA]
+	return languageInstruction(input.OutputLanguage) + fmt.Sprintf(`[PERSONA]
 คุณคือ "นักภาษาศาสตร์ และ นักวิจารณ์ภาพยนตร์มืออาชีพ"
 - เชี่ยวชาญการวิเคราะห์ภาษาและการสื่อสาร
 - สังเกตรูปแบบการพูด หางเสียง สรรพนาม
@@ -122,9 +125,9 @@ func (c *GeminiClient) buildChunk3PromptV2(input *ports.AIInput, coreCtx *CoreCo
 
 ## ⚠️ CRITICAL RULES
 
-### 1. topQuotes ต้องอยู่ใน 600 วินาทีแรก
+### 1. topQuotes ต้องอยู่ในช่วง safe window
 - ⚠️ ดึง timestamp จาก SRT
-- ⚠️ เลือกเฉพาะประโยคที่ timestamp ≤ 600
+- ⚠️ %[9]s
 - ⚠️ ไม่ใช่ประโยคลามก
 
 ### 2. ใช้ศัพท์ทางภาษาศาสตร์
@@ -151,7 +154,7 @@ func (c *GeminiClient) buildChunk3PromptV2(input *ports.AIInput, coreCtx *CoreCo
 
 3. **topQuotes**: 4-5 ประโยคเด็ด
    - text: ประโยคภาษาไทย
-   - timestamp: ≤ 600 วินาที
+   - timestamp: วินาที (%[9]s)
    - emotion: อารมณ์
    - context: บริบท
 
@@ -163,7 +166,7 @@ func (c *GeminiClient) buildChunk3PromptV2(input *ports.AIInput, coreCtx *CoreCo
 ---
 
 ## ⛔ ข้อห้าม (DON'T)
-- ❌ topQuotes timestamp > 600 วินาที (ถูก filter!)
+- ❌ topQuotes ที่ผิดกฎ "%[9]s" (ถูก filter!)
 - ❌ ประโยคลามกใน topQuotes
 - ❌ ผสมภาษาในชื่อนักแสดง
 - ❌ วิเคราะห์แบบผิวเผิน
@@ -176,5 +179,6 @@ func (c *GeminiClient) buildChunk3PromptV2(input *ports.AIInput, coreCtx *CoreCo
 		truncateSRT(input.SRTContent, 3000),
 		castsInfo.String(),
 		prevWorks.String(),
+		topQuoteWindowRule(safeMomentsFor(input)),
 	)
 }
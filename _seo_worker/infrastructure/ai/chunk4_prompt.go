@@ -144,7 +144,7 @@ func (c *GeminiClient) buildChunk4Prompt(input *ports.AIInput, chunk1 *Chunk1Out
 	// Format duration
 	durationStr := formatDurationThai(input.VideoMetadata.Duration)
 
-	return fmt.Sprintf(`# บทบาท (Persona)
+	return languageInstruction(input.OutputLanguage) + fmt.Sprintf(`# บทบาท (Persona)
 คุณคือ "นักวิจารณ์ภาพยนตร์ระดับพรีเมียม" ที่เชี่ยวชาญ:
 - การวิเคราะห์ Cinematography และ Visual Aesthetics
 - การวิเคราะห์พัฒนาการตัวละครและอารมณ์ (Character Arc)
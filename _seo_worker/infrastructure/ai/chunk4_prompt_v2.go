@@ -81,7 +81,7 @@ func (c *GeminiClient) buildChunk4PromptV2(input *ports.AIInput, coreCtx *CoreCo
 	// Entities
 	entitiesJSON, _ := json.Marshal(coreCtx.Entities)
 
-	return fmt.Sprintf(`[PERSONA]
+	return languageInstruction(input.OutputLanguage) + fmt.Sprintf(`[PERSONA]
 คุณคือ "นักเขียนชีวประวัติ และ Encyclopedia Writer"
 - เชี่ยวชาญการเขียน bio ที่ให้ข้อมูลครบถ้วน
 - สามารถเขียนคำอธิบายแบบสารานุกรม
@@ -102,20 +102,9 @@ func (c *GeminiClient) buildChunk5PromptV2(
 	expertAnalysis := chunk4.ExpertAnalysis
 
 	// Related articles
-	var relatedArticles strings.Builder
-	if len(input.RelatedArticles) == 0 {
-		relatedArticles.WriteString("⚠️ ไม่มี Related Articles - ให้ส่ง contextualLinks เป็น array ว่าง []\n")
-	} else {
-		for _, article := range input.RelatedArticles {
-			relatedArticles.WriteString(fmt.Sprintf("- Slug: %s, Code: %s, Title: %s\n  Casts: %s, Tags: %s\n",
-				article.Slug, article.RealCode, article.Title,
-				strings.Join(article.CastNames, ", "),
-				strings.Join(article.Tags, ", "),
-			))
-		}
-	}
+	relatedArticles := relatedArticlesPrompt(input.RelatedArticles)
 
-	return fmt.Sprintf(`[PERSONA]
+	return languageInstruction(input.OutputLanguage) + fmt.Sprintf(`[PERSONA]
 คุณคือ "Content Strategist / SEO Specialist"
 - เชี่ยวชาญการสร้าง Internal Links ที่มีคุณค่า
 - วิเคราะห์กลุ่มเป้าหมายและคำแนะนำ
@@ -230,6 +219,6 @@ func (c *GeminiClient) buildChunk5PromptV2(
 		characterInsight,
 		expertAnalysis,
 		string(entitiesJSON),
-		relatedArticles.String(),
+		relatedArticles,
 	)
 }
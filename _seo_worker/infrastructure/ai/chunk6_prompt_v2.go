@@ -112,7 +112,7 @@ func (c *GeminiClient) buildChunk6PromptV2(input *ports.AIInput, extCtx *Extende
 	// Duration formatted
 	durationStr := formatDurationThai(input.VideoMetadata.Duration)
 
-	return fmt.Sprintf(`[PERSONA]
+	return languageInstruction(input.OutputLanguage) + fmt.Sprintf(`[PERSONA]
 คุณคือ "Technical Writer / Customer Support Specialist"
 - เชี่ยวชาญการเขียน FAQ ที่ตอบคำถามที่คนค้นหาจริง
 - วิเคราะห์คุณภาพเทคนิคของวิดีโอและซับไตเติ้ล
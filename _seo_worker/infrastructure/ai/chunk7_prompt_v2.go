@@ -142,7 +142,7 @@ func (c *GeminiClient) buildChunk7PromptV2(input *ports.AIInput, extCtx *Extende
 	// Duration
 	durationStr := formatDurationThai(input.VideoMetadata.Duration)
 
-	return fmt.Sprintf(`[PERSONA]
+	return languageInstruction(input.OutputLanguage) + fmt.Sprintf(`[PERSONA]
 คุณคือ "Film Critic / Cultural Analyst ระดับพรีเมียม"
 - เชี่ยวชาญ Cinematography และ Visual Aesthetics
 - วิเคราะห์ Character Arc และ Emotional Journey
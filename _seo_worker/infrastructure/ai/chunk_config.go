@@ -0,0 +1,92 @@
+package ai
+
+import (
+	"time"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// ============================================================================
+// Per-chunk model / temperature
+// ============================================================================
+//
+// Temperature default ต่อ chunk (ไม่อยู่ใน map = defaultTemp 0.7):
+//   - chunk ข้อเท็จจริง (technical/FAQ, bios, timestamps) = ต่ำ → ตอบตรงกับ SRT/metadata
+//   - chunk เล่าเรื่อง (summary, review, deep analysis) = defaultTemp → สำนวนหลากหลาย
+//
+// override ได้ด้วย SetChunkTemperatures (GEMINI_CHUNK_TEMPERATURES)
+// ============================================================================
+
+var defaultChunkTemperatures = map[string]float32{
+	// V1
+	"chunk3": 0.3, // Technical + FAQ
+
+	// V2
+	"chunk2v2": 0.5, // Scene & Moments (timestamp ต้องตรง SRT)
+	"chunk4v2": 0.5, // Entity Bios
+	"chunk6v2": 0.3, // Technical & FAQ
+}
+
+// SetChunkModels ตั้ง model ต่อ chunk (key = ชื่อ chunk เช่น "chunk1v2", "chunk3")
+// chunk ที่ไม่อยู่ใน map ใช้ model หลักของ client
+func (c *GeminiClient) SetChunkModels(models map[string]string) {
+	c.chunkModels = models
+}
+
+// SetChunkTemperatures override temperature ต่อ chunk (ทับ defaultChunkTemperatures เฉพาะ key ที่ระบุ)
+func (c *GeminiClient) SetChunkTemperatures(temps map[string]float32) {
+	c.chunkTemps = temps
+}
+
+// SetSeed เปิด deterministic mode (nil = ปิด) ให้ reviewer รัน output ซ้ำได้
+// genai SDK ที่ใช้อยู่ยังไม่มี field seed จึงใช้ greedy decoding (temperature 0, topK 1) แทน
+// ผลลัพธ์จึงไม่ขึ้นกับค่า seed แต่ได้ output เดิมเมื่อ prompt เหมือนเดิม
+func (c *GeminiClient) SetSeed(seed *int32) {
+	c.seed = seed
+}
+
+// SetChunkTimeout timeout ต่อการเรียก Gemini 1 ครั้ง (<= 0 = default 3 นาที)
+// request ที่ค้างเกินนี้ fail แล้วเข้า retry/backoff ของ chunk แทนการค้างทั้ง job
+func (c *GeminiClient) SetChunkTimeout(timeout time.Duration) {
+	c.chunkTimeout = timeout
+}
+
+// chunkTimeoutOrDefault timeout ที่ใช้จริง
+func (c *GeminiClient) chunkTimeoutOrDefault() time.Duration {
+	if c.chunkTimeout > 0 {
+		return c.chunkTimeout
+	}
+	return defaultChunkTimeout
+}
+
+// modelFor ชื่อ model ที่ใช้กับ chunk
+func (c *GeminiClient) modelFor(chunk string) string {
+	if model := c.chunkModels[chunk]; model != "" {
+		return model
+	}
+	return c.model
+}
+
+// temperatureFor temperature ที่ใช้กับ chunk (config > default ต่อ chunk > defaultTemp)
+func (c *GeminiClient) temperatureFor(chunk string) float32 {
+	if temp, ok := c.chunkTemps[chunk]; ok {
+		return temp
+	}
+	if temp, ok := defaultChunkTemperatures[chunk]; ok {
+		return temp
+	}
+	return defaultTemp
+}
+
+// newChunkModel สร้าง GenerativeModel ของ chunk พร้อม config พื้นฐาน (ยังไม่ใส่ schema)
+func (c *GeminiClient) newChunkModel(chunk string) *genai.GenerativeModel {
+	model := c.client.GenerativeModel(c.modelFor(chunk))
+	c.configureModel(model)
+	model.Temperature = toPtr(c.temperatureFor(chunk))
+
+	if c.seed != nil {
+		model.Temperature = toPtr(float32(0))
+		model.TopK = toPtr(int32(1))
+	}
+	return model
+}
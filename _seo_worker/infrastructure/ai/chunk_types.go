@@ -22,8 +22,8 @@ import (
 //   - จำกัด 20-30 จุด, ครอบคลุมทั้งเรื่อง, ชื่อละเอียด
 //   - ใช้ AI เจนแบบละเอียดทุกจุดพีค
 //
-// ปัจจุบัน Chunk1Output.KeyMoments = Public Moments เท่านั้น
-// Internal Moments ต้อง implement แยกใน Member API
+// Chunk1Output.KeyMoments = Public Moments (cap MaxPublic)
+// Chunk1Output.InternalKeyMoments = Internal Moments (safe-filtered เหมือนกัน, cap MaxInternal)
 // ============================================================================
 
 type Chunk1Output struct {
@@ -38,7 +38,8 @@ type Chunk1Output struct {
 	Highlights   []string `json:"highlights"`   // 5-10 ฉากสำคัญ
 
 	// Key Moments (timestamps จาก SRT)
-	KeyMoments []models.KeyMoment `json:"keyMoments"`
+	KeyMoments         []models.KeyMoment `json:"keyMoments"`
+	InternalKeyMoments []models.KeyMoment `json:"-"` // สร้างจาก processKeyMomentsSafe (ไม่ได้มาจาก AI)
 
 	// Gallery & Scene
 	GalleryAlts    []string `json:"galleryAlts"`    // Alt text สำหรับรูป
@@ -224,6 +225,8 @@ func AggregateChunks(chunk1 *Chunk1Output, chunk2 *Chunk2Output, chunk3 *Chunk3O
 		ThumbnailAlt:    chunk1.ThumbnailAlt,
 		QualityScore:    chunk1.QualityScore,
 
+		InternalKeyMoments: chunk1.InternalKeyMoments,
+
 		// === From Chunk 2: E-E-A-T Analysis ===
 		DialogueAnalysis:      chunk2.DialogueAnalysis,
 		CharacterInsight:      chunk2.CharacterInsight,
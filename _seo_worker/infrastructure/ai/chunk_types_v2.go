@@ -59,9 +59,13 @@ type Chunk1OutputV2 struct {
 
 type Chunk2OutputV2 struct {
 	Highlights     []string           `json:"highlights"`     // 5-8 ฉากสำคัญ (แต่ละจุด 15-30 คำ)
-	KeyMoments     []models.KeyMoment `json:"keyMoments"`     // 3-5 Timestamps สำคัญ (Public)
+	KeyMoments     []models.KeyMoment `json:"keyMoments"`     // Timestamps สำคัญ (Public, cap MaxPublic)
 	SceneLocations []string           `json:"sceneLocations"` // 3-5 สถานที่ในเรื่อง
 	GalleryAlts    []string           `json:"galleryAlts"`    // Alt text สำหรับรูป
+
+	// Internal Key Moments (Members) - ไม่ได้มาจาก AI โดยตรง
+	// processKeyMomentsSafe แยกจาก keyMoments ชุดเดียวกัน (safe-filtered, cap MaxInternal)
+	InternalKeyMoments []models.KeyMoment `json:"-"`
 }
 
 // ============================================================================
@@ -367,6 +371,8 @@ func AggregateChunksV2(
 		SceneLocations: chunk2.SceneLocations,
 		GalleryAlts:    chunk2.GalleryAlts,
 
+		InternalKeyMoments: chunk2.InternalKeyMoments,
+
 		// === From Chunk 3: Expertise ===
 		DialogueAnalysis:      chunk3.DialogueAnalysis,
 		CharacterInsight:      chunk3.CharacterInsight,
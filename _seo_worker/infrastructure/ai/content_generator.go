@@ -0,0 +1,82 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// ============================================================================
+// ContentGenerator - seam ระหว่าง chunk pipeline กับ Gemini API
+// ============================================================================
+//
+// Production ใช้ Gemini API จริง ส่วน test ใช้ NewRecordedGenerator
+// เล่น response ที่บันทึกไว้ (ไม่เสีย API quota และได้ผลลัพธ์เหมือนเดิมทุกครั้ง)
+// ============================================================================
+
+// ContentGenerator สร้าง response ของ chunk หนึ่ง
+// chunk คือชื่อ chunk เช่น "chunk1", "chunk3v2" (ใช้เลือก recorded response)
+type ContentGenerator func(ctx context.Context, model *genai.GenerativeModel, chunk, prompt string) (*genai.GenerateContentResponse, error)
+
+// NewGeminiClientWithGenerator สร้าง GeminiClient ที่ใช้ generator แทน Gemini API
+// (ไม่มี genai.Client จริง - สำหรับ test / deterministic mode)
+func NewGeminiClientWithGenerator(model string, generator ContentGenerator) *GeminiClient {
+	return &GeminiClient{
+		model:     model,
+		generator: generator,
+		logger:    slog.Default().With("component", "gemini"),
+	}
+}
+
+// NewRecordedGenerator เล่น response จากไฟล์ {dir}/{chunk}.json
+// ไฟล์คือ JSON ที่ Gemini ตอบกลับมา (เหมือนที่ extractJSON ได้จาก candidate แรก)
+func NewRecordedGenerator(dir string) ContentGenerator {
+	return func(ctx context.Context, _ *genai.GenerativeModel, chunk, _ string) (*genai.GenerateContentResponse, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, chunk+".json"))
+		if err != nil {
+			return nil, fmt.Errorf("no recorded response for %s: %w", chunk, err)
+		}
+
+		return &genai.GenerateContentResponse{
+			Candidates: []*genai.Candidate{{
+				Content:      &genai.Content{Role: "model", Parts: []genai.Part{genai.Text(data)}},
+				FinishReason: genai.FinishReasonStop,
+			}},
+		}, nil
+	}
+}
+
+// generate เรียก generator ที่ inject ไว้ หรือ Gemini API จริงถ้าไม่มี
+// แต่ละครั้งมี timeout ของตัวเอง (SetChunkTimeout) - หมดเวลา = error ให้ retry ของ chunk จัดการ
+// response ที่ได้ถูกบันทึก token usage ของ chunk (ดู token_usage.go)
+func (c *GeminiClient) generate(ctx context.Context, model *genai.GenerativeModel, chunk, prompt string) (*genai.GenerateContentResponse, error) {
+	timeout := c.chunkTimeoutOrDefault()
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var resp *genai.GenerateContentResponse
+	var err error
+	if c.generator != nil {
+		resp, err = c.generator(callCtx, model, chunk, prompt)
+	} else {
+		resp, err = model.GenerateContent(callCtx, genai.Text(prompt))
+	}
+	if err != nil {
+		// หมดเวลาของ chunk เอง (ไม่ใช่ job ถูกยกเลิก) → บอกให้ชัดใน log ของ retry
+		if ctx.Err() == nil && errors.Is(callCtx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("%s timed out after %s: %w", chunk, timeout, err)
+		}
+		return nil, err
+	}
+	c.recordTokenUsage(ctx, chunk, resp)
+	return resp, nil
+}
@@ -0,0 +1,100 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	defaultDebugDir         = "output"
+	maxDebugCleanupInterval = time.Hour
+	debugFilePattern        = "*_debug_*.json"
+)
+
+// DebugOutputConfig ที่เก็บ raw JSON ของ chunk ที่ parse ไม่ผ่าน (chunkN_debug_<code>.json)
+type DebugOutputConfig struct {
+	Disabled  bool          // true = ไม่ dump (production)
+	Dir       string        // ว่าง = output/
+	Retention time.Duration // ลบไฟล์ที่เก่ากว่านี้ (0 = เก็บตลอด)
+}
+
+// SetDebugOutput ตั้งค่า debug dump + retention
+func (c *GeminiClient) SetDebugOutput(cfg DebugOutputConfig) {
+	if cfg.Dir == "" {
+		cfg.Dir = defaultDebugDir
+	}
+	c.debug = cfg
+}
+
+func (c *GeminiClient) debugDir() string {
+	if c.debug.Dir == "" {
+		return defaultDebugDir
+	}
+	return c.debug.Dir
+}
+
+// writeChunkDebug เก็บ response ของ chunk ที่ parse ไม่ผ่านไว้ตรวจสอบ (ล้มเหลว = warn เท่านั้น)
+func (c *GeminiClient) writeChunkDebug(chunk, videoCode, content string) {
+	if c.debug.Disabled {
+		return
+	}
+
+	dir := c.debugDir()
+	path := filepath.Join(dir, fmt.Sprintf("%s_debug_%s.json", chunk, videoCode))
+	if err := writeDebugFile(path, content); err != nil {
+		c.logger.Warn("Failed to write chunk debug file", "path", path, "error", err)
+	}
+}
+
+// RunDebugCleanup ลบ debug file ที่เกิน retention เป็นระยะจน ctx ถูก cancel (blocking)
+// Retention = 0 หรือปิด dump = return ทันที
+func (c *GeminiClient) RunDebugCleanup(ctx context.Context) {
+	retention := c.debug.Retention
+	if retention <= 0 || c.debug.Disabled {
+		return
+	}
+
+	interval := min(retention, maxDebugCleanupInterval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		removed, err := CleanupDebugFiles(c.debugDir(), retention, time.Now())
+		if err != nil {
+			c.logger.WarnContext(ctx, "Debug file cleanup failed", "dir", c.debugDir(), "error", err)
+		} else if removed > 0 {
+			c.logger.InfoContext(ctx, "Debug files cleaned up", "dir", c.debugDir(), "removed", removed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CleanupDebugFiles ลบ debug file (*_debug_*.json) ใน dir ที่แก้ไขล่าสุดก่อน now-maxAge
+// ไม่แตะไฟล์อื่นใน dir เดียวกัน (state_<code>.json ใช้ resume, <code>_article.json)
+func CleanupDebugFiles(dir string, maxAge time.Duration, now time.Time) (int, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, debugFilePattern))
+	if err != nil {
+		return 0, err
+	}
+
+	cutoff := now.Add(-maxAge)
+	removed := 0
+	for _, path := range matches {
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() || !info.ModTime().Before(cutoff) {
+			continue
+		}
+		if err := os.Remove(path); err == nil {
+			removed++
+		}
+	}
+	return removed, nil
+}
@@ -3,9 +3,11 @@ package ai
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
+	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
@@ -23,19 +25,17 @@ import (
 // ============================================================================
 
 const (
-	maxRetries       = 3
-	retryBaseDelay   = time.Second
-	maxOutputTokens  = 4096 // Per chunk (ไม่ใช่ 8192 เพราะแบ่งเป็น 3 chunks แล้ว)
-	defaultTemp      = 0.7  // สไตล์การเขียนคงที่ทุก chunk
-
-	// Safe Moments Strategy for JAV
-	safeKeyMomentsLimit = 600 // Hard limit: 10 นาทีแรกเท่านั้น (วินาที)
-	minKeyMoments       = 3   // จำนวน key moments ขั้นต่ำ (Public Schema)
-	maxKeyMomentsPublic = 5   // จำนวน key moments สูงสุดสำหรับ Public (Google)
-	maxKeyMomentsInternal = 20 // จำนวน key moments สูงสุดสำหรับ Internal (Members)
+	maxRetries          = 3
+	retryBaseDelay      = time.Second
+	defaultChunkTimeout = 3 * time.Minute // ต่อการเรียก 1 ครั้ง - chunk ปกติใช้ 20-60 วินาที
+	maxOutputTokens     = 4096            // Per chunk (ไม่ใช่ 8192 เพราะแบ่งเป็น 3 chunks แล้ว)
+	defaultTemp         = 0.7             // chunk ที่ไม่ได้ตั้ง temperature เอง (ดู chunk_config.go)
+
+	// Safe Moments Strategy for JAV - ค่าตั้งอยู่ที่ models.SafeMomentSettings (AIInput.SafeMoments)
 )
 
-// keywordBlacklist - คำต้องห้ามใน keyMoments name (explicit content)
+// keywordBlacklist - คำต้องห้ามใน keyMoments name (explicit content) - ภาษาไทย (default)
+// ภาษาอื่นดู sanitize_rules.go
 var keywordBlacklist = []string{
 	"เซ็กซ์", "เซ็ก", "sex", "ร่วมเพศ", "มีเพศสัมพันธ์",
 	"ออรัล", "oral", "อมควย", "อมนม",
@@ -46,7 +46,7 @@ var keywordBlacklist = []string{
 	"69", "threesome", "gangbang",
 }
 
-// seoKeywordBlacklist - คำต้องห้ามใน SEO keywords (สำหรับ Google)
+// seoKeywordBlacklist - คำต้องห้ามใน SEO keywords (สำหรับ Google) - ภาษาไทย (default)
 var seoKeywordBlacklist = []string{
 	"หนังโป๊", "โป๊", "porn", "xxx", "av",
 	"เย็ด", "เอากัน", "ร่วมรัก",
@@ -55,7 +55,7 @@ var seoKeywordBlacklist = []string{
 	"blowjob", "อมควย",
 }
 
-// explicitTermReplacements - คำที่ต้องแทนที่ด้วยคำสุภาพ
+// explicitTermReplacements - คำที่ต้องแทนที่ด้วยคำสุภาพ - ภาษาไทย (default)
 var explicitTermReplacements = map[string]string{
 	"หลั่งใน":          "ใกล้ชิดแบบพิเศษ",
 	"แตกใน":           "ใกล้ชิดแบบพิเศษ",
@@ -77,7 +77,7 @@ var explicitTermReplacements = map[string]string{
 // ============================================================================
 
 func writeDebugFile(path, content string) error {
-	_ = os.MkdirAll("output", 0755)
+	_ = os.MkdirAll(filepath.Dir(path), 0755)
 	return os.WriteFile(path, []byte(content), 0644)
 }
 
@@ -90,9 +90,23 @@ func toPtr[T any](v T) *T {
 // ============================================================================
 
 type GeminiClient struct {
-	client *genai.Client
-	model  string
-	logger *slog.Logger
+	client   *genai.Client
+	model    string
+	logger   *slog.Logger
+	srtGuard SRTGuardConfig // ตัด SRT ที่ยาวเกินก่อนส่ง (SetSRTGuard)
+
+	// model/temperature ต่อ chunk - ดู chunk_config.go
+	chunkModels map[string]string
+	chunkTemps  map[string]float32
+	seed        *int32 // deterministic mode (SetSeed)
+
+	chunkTimeout time.Duration       // timeout ต่อการเรียก Gemini 1 ครั้ง (SetChunkTimeout)
+	seedMoments  SeedMomentTemplates // seed key moments ต่อภาษา (SetSeedMoments, nil = default)
+
+	debug DebugOutputConfig // debug dump ของ chunk ที่ parse ไม่ผ่าน (SetDebugOutput)
+
+	// generator แทน Gemini API (nil = เรียก API จริง) - ดู content_generator.go
+	generator ContentGenerator
 }
 
 func NewGeminiClient(apiKey, model string) (*GeminiClient, error) {
@@ -110,6 +124,9 @@ func NewGeminiClient(apiKey, model string) (*GeminiClient, error) {
 }
 
 func (c *GeminiClient) Close() error {
+	if c.client == nil {
+		return nil // recorded/deterministic mode ไม่มี client จริง
+	}
 	return c.client.Close()
 }
 
@@ -118,6 +135,9 @@ func (c *GeminiClient) Close() error {
 // ============================================================================
 
 func (c *GeminiClient) GenerateArticleContent(ctx context.Context, input *ports.AIInput) (*ports.AIOutput, error) {
+	input = c.guardSRTInput(ctx, input)
+	ctx, usage := withTokenUsage(ctx)
+
 	videoCode := input.VideoMetadata.RealCode
 	if videoCode == "" {
 		videoCode = input.VideoMetadata.Code
@@ -178,31 +198,49 @@ func (c *GeminiClient) GenerateArticleContent(ctx context.Context, input *ports.
 
 	// ===== Chunk 3: Technical + FAQ (ใช้ context จาก Chunk 1) =====
 	c.logger.InfoContext(ctx, "[Chunk 3/4] Generating Technical + FAQ...")
-	chunk3, err := c.generateChunk3WithRetry(ctx, input, chunk1)
-	if err != nil {
+	chunk3, chunk3Err := c.generateChunk3WithRetry(ctx, input, chunk1)
+	if chunk3Err == nil {
+		c.logger.InfoContext(ctx, "[Chunk 3/4] Completed",
+			"faq_items", len(chunk3.FAQItems),
+			"keywords", len(chunk3.Keywords),
+		)
+
+		// Save state after Chunk 3
+		state.Chunk3 = chunk3
+		state.LastChunk = 3
+		state.UpdatedAt = time.Now()
+		c.saveState(state)
+	}
+
+	// ===== Chunk 4: Deep Analysis (ใช้ context จาก Chunk 1 + Chunk 2) =====
+	// ไม่พึ่ง Chunk 3 → generate ต่อแม้ Chunk 3 ล้มเหลว (resume จะ generate แค่ Chunk 3)
+	c.logger.InfoContext(ctx, "[Chunk 4/4] Generating Deep Analysis...")
+	chunk4, err := c.generateChunk4WithRetry(ctx, input, chunk1, chunk2)
+	if err == nil {
+		c.logger.InfoContext(ctx, "[Chunk 4/4] Completed",
+			"cinematography_len", len(chunk4.CinematographyAnalysis),
+			"character_journey_len", len(chunk4.CharacterJourney),
+			"thematic_explanation_len", len(chunk4.ThematicExplanation),
+		)
+		state.Chunk4 = chunk4
+		state.LastChunk = state.completedUpTo()
+		state.UpdatedAt = time.Now()
+		c.saveState(state)
+	}
+
+	if chunk3Err != nil {
+		if err != nil {
+			c.logger.WarnContext(ctx, "[Chunk 4/4] Failed after Chunk 3 failure", "error", err)
+		}
 		// Partial success: save state and return partial error
 		return nil, &PartialGenerationError{
 			Message:       "chunk3 failed after retries",
 			PartialPath:   fmt.Sprintf("output/state_%s.json", videoCode),
 			FailedChunk:   3,
 			CompletedUpTo: 2,
-			Cause:         err,
+			Cause:         chunk3Err,
 		}
 	}
-	c.logger.InfoContext(ctx, "[Chunk 3/4] Completed",
-		"faq_items", len(chunk3.FAQItems),
-		"keywords", len(chunk3.Keywords),
-	)
-
-	// Save state after Chunk 3
-	state.Chunk3 = chunk3
-	state.LastChunk = 3
-	state.UpdatedAt = time.Now()
-	c.saveState(state)
-
-	// ===== Chunk 4: Deep Analysis (ใช้ context จาก Chunk 1 + Chunk 2) =====
-	c.logger.InfoContext(ctx, "[Chunk 4/4] Generating Deep Analysis...")
-	chunk4, err := c.generateChunk4WithRetry(ctx, input, chunk1, chunk2)
 	if err != nil {
 		// Partial success: save state and return partial error
 		return nil, &PartialGenerationError{
@@ -213,20 +251,29 @@ func (c *GeminiClient) GenerateArticleContent(ctx context.Context, input *ports.
 			Cause:         err,
 		}
 	}
-	c.logger.InfoContext(ctx, "[Chunk 4/4] Completed",
-		"cinematography_len", len(chunk4.CinematographyAnalysis),
-		"character_journey_len", len(chunk4.CharacterJourney),
-		"thematic_explanation_len", len(chunk4.ThematicExplanation),
-	)
 
 	// ===== Aggregate =====
 	output := AggregateChunks(chunk1, chunk2, chunk3, chunk4)
+	output.TokenUsage = usage.total()
+	if err := ValidateAIOutput(output); err != nil {
+		// ไม่ลบ state file - แก้ schema/prompt แล้ว resume ได้ (ตัด chunk ที่ไม่ผ่านออก ให้ resume generate ใหม่)
+		c.logger.ErrorContext(ctx, "Aggregated output validation failed",
+			"video_code", videoCode,
+			"error", err,
+		)
+		c.dropInvalidChunk(state, err)
+		return nil, err
+	}
 
 	// Clean up state file on full success
 	os.Remove(fmt.Sprintf("output/state_%s.json", videoCode))
 
 	c.logger.InfoContext(ctx, "4-chunk generation completed successfully",
 		"video_code", videoCode,
+		"prompt_tokens", output.TokenUsage.PromptTokens,
+		"candidate_tokens", output.TokenUsage.CandidateTokens,
+		"total_tokens", output.TokenUsage.TotalTokens,
+		"gemini_calls", output.TokenUsage.Calls,
 	)
 
 	return output, nil
@@ -242,7 +289,7 @@ func (c *GeminiClient) generateChunk1WithRetry(ctx context.Context, input *ports
 		chunk, err := c.generateChunk1(ctx, input)
 		if err == nil {
 			// Validate
-			if valErr := c.validateChunk1(chunk); valErr != nil {
+			if valErr := c.validateChunk1(chunk, input.OutputLanguage, safeMomentsFor(input)); valErr != nil {
 				lastErr = valErr
 				c.logger.WarnContext(ctx, "[Chunk 1] Validation failed, retrying",
 					"attempt", i+1,
@@ -269,7 +316,7 @@ func (c *GeminiClient) generateChunk2WithRetry(ctx context.Context, input *ports
 		chunk, err := c.generateChunk2(ctx, input, chunk1)
 		if err == nil {
 			// Validate
-			if valErr := c.validateChunk2(chunk); valErr != nil {
+			if valErr := c.validateChunk2(chunk, input.OutputLanguage); valErr != nil {
 				lastErr = valErr
 				c.logger.WarnContext(ctx, "[Chunk 2] Validation failed, retrying",
 					"attempt", i+1,
@@ -313,7 +360,7 @@ func (c *GeminiClient) generateChunk4WithRetry(ctx context.Context, input *ports
 		chunk, err := c.generateChunk4(ctx, input, chunk1, chunk2)
 		if err == nil {
 			// Validate
-			if valErr := c.validateChunk4(chunk); valErr != nil {
+			if valErr := c.validateChunk4(chunk, input.OutputLanguage); valErr != nil {
 				lastErr = valErr
 				c.logger.WarnContext(ctx, "[Chunk 4] Validation failed, retrying",
 					"attempt", i+1,
@@ -339,14 +386,27 @@ func (c *GeminiClient) generateChunk4WithRetry(ctx context.Context, input *ports
 // ============================================================================
 
 func (c *GeminiClient) generateChunk1(ctx context.Context, input *ports.AIInput) (*Chunk1Output, error) {
-	model := c.client.GenerativeModel(c.model)
-	c.configureModel(model)
+	chunk, err := c.generateChunk1Raw(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	// Post-process: Safe Moments filtering for JAV content
+	chunk.KeyMoments, chunk.InternalKeyMoments = c.processKeyMomentsSafe(chunk.KeyMoments, safeMomentsFor(input), input.VideoMetadata.Duration, input.OutputLanguage)
+	chunk.GalleryAlts = c.filterGalleryAlts(chunk.GalleryAlts, input.OutputLanguage)
+
+	return chunk, nil
+}
+
+// generateChunk1Raw เรียก chunk 1 โดยยังไม่ post-process (key moments ตามที่ AI คืนมา)
+func (c *GeminiClient) generateChunk1Raw(ctx context.Context, input *ports.AIInput) (*Chunk1Output, error) {
+	model := c.newChunkModel("chunk1")
 	model.ResponseSchema = c.buildChunk1Schema()
 
 	prompt := c.buildChunk1Prompt(input)
 	prompt = sanitizeUTF8(prompt) // Fix invalid UTF-8
 
-	resp, err := model.GenerateContent(ctx, genai.Text(prompt))
+	resp, err := c.generate(ctx, model, "chunk1", prompt)
 	if err != nil {
 		return nil, fmt.Errorf("gemini generate failed: %w", err)
 	}
@@ -359,26 +419,22 @@ func (c *GeminiClient) generateChunk1(ctx context.Context, input *ports.AIInput)
 	var chunk Chunk1Output
 	if err := json.Unmarshal([]byte(jsonString), &chunk); err != nil {
 		// Save debug file
-		debugPath := fmt.Sprintf("output/chunk1_debug_%s.json", input.VideoMetadata.RealCode)
-		_ = writeDebugFile(debugPath, jsonString)
+		c.writeChunkDebug("chunk1", input.VideoMetadata.RealCode, jsonString)
 		return nil, fmt.Errorf("failed to parse chunk1: %w", err)
 	}
 
-	// Post-process: Safe Moments filtering for JAV content
-	chunk.KeyMoments = c.processKeyMomentsSafe(chunk.KeyMoments, input.VideoMetadata.Duration)
-
 	return &chunk, nil
 }
 
 func (c *GeminiClient) generateChunk2(ctx context.Context, input *ports.AIInput, chunk1 *Chunk1Output) (*Chunk2Output, error) {
-	model := c.client.GenerativeModel(c.model)
-	c.configureModel(model)
-	model.ResponseSchema = c.buildChunk2Schema()
+	model := c.newChunkModel("chunk2")
+	model.ResponseSchema = c.buildChunk2Schema(safeMomentsFor(input))
+	restrictContextualLinks(model.ResponseSchema, input.RelatedArticles)
 
 	prompt := c.buildChunk2Prompt(input, chunk1)
 	prompt = sanitizeUTF8(prompt) // Fix invalid UTF-8
 
-	resp, err := model.GenerateContent(ctx, genai.Text(prompt))
+	resp, err := c.generate(ctx, model, "chunk2", prompt)
 	if err != nil {
 		return nil, fmt.Errorf("gemini generate failed: %w", err)
 	}
@@ -390,29 +446,27 @@ func (c *GeminiClient) generateChunk2(ctx context.Context, input *ports.AIInput,
 
 	var chunk Chunk2Output
 	if err := json.Unmarshal([]byte(jsonString), &chunk); err != nil {
-		debugPath := fmt.Sprintf("output/chunk2_debug_%s.json", input.VideoMetadata.RealCode)
-		_ = writeDebugFile(debugPath, jsonString)
+		c.writeChunkDebug("chunk2", input.VideoMetadata.RealCode, jsonString)
 		return nil, fmt.Errorf("failed to parse chunk2: %w", err)
 	}
 
-	// Post-process: Filter topQuotes ที่ timestamp > 600 วินาที
-	chunk.TopQuotes = c.filterTopQuotesSafe(chunk.TopQuotes)
+	// Post-process: Filter topQuotes ที่ timestamp เกินช่วง safe
+	chunk.TopQuotes = c.filterTopQuotesSafe(chunk.TopQuotes, safeMomentsFor(input))
 
 	// Post-process: Sanitize tagDescriptions ให้สุภาพ
-	chunk.TagDescriptions = c.sanitizeTagDescriptions(chunk.TagDescriptions)
+	chunk.TagDescriptions = c.sanitizeTagDescriptions(chunk.TagDescriptions, input.OutputLanguage)
 
 	return &chunk, nil
 }
 
 func (c *GeminiClient) generateChunk3(ctx context.Context, input *ports.AIInput, chunk1 *Chunk1Output) (*Chunk3Output, error) {
-	model := c.client.GenerativeModel(c.model)
-	c.configureModel(model)
+	model := c.newChunkModel("chunk3")
 	model.ResponseSchema = c.buildChunk3Schema()
 
 	prompt := c.buildChunk3Prompt(input, chunk1)
 	prompt = sanitizeUTF8(prompt) // Fix invalid UTF-8
 
-	resp, err := model.GenerateContent(ctx, genai.Text(prompt))
+	resp, err := c.generate(ctx, model, "chunk3", prompt)
 	if err != nil {
 		return nil, fmt.Errorf("gemini generate failed: %w", err)
 	}
@@ -424,30 +478,28 @@ func (c *GeminiClient) generateChunk3(ctx context.Context, input *ports.AIInput,
 
 	var chunk Chunk3Output
 	if err := json.Unmarshal([]byte(jsonString), &chunk); err != nil {
-		debugPath := fmt.Sprintf("output/chunk3_debug_%s.json", input.VideoMetadata.RealCode)
-		_ = writeDebugFile(debugPath, jsonString)
+		c.writeChunkDebug("chunk3", input.VideoMetadata.RealCode, jsonString)
 		return nil, fmt.Errorf("failed to parse chunk3: %w", err)
 	}
 
 	// Post-process: Filter keywords ที่ไม่เหมาะสมสำหรับ Google
-	chunk.Keywords = c.filterSEOKeywords(chunk.Keywords)
-	chunk.LongTailKeywords = c.filterSEOKeywords(chunk.LongTailKeywords)
+	chunk.Keywords = c.filterSEOKeywords(chunk.Keywords, input.OutputLanguage)
+	chunk.LongTailKeywords = c.filterSEOKeywords(chunk.LongTailKeywords, input.OutputLanguage)
 
 	// Post-process: Sanitize faqItems ให้สุภาพ
-	chunk.FAQItems = c.sanitizeFAQItems(chunk.FAQItems)
+	chunk.FAQItems = c.sanitizeFAQItems(chunk.FAQItems, input.OutputLanguage)
 
 	return &chunk, nil
 }
 
 func (c *GeminiClient) generateChunk4(ctx context.Context, input *ports.AIInput, chunk1 *Chunk1Output, chunk2 *Chunk2Output) (*Chunk4Output, error) {
-	model := c.client.GenerativeModel(c.model)
-	c.configureModel(model)
+	model := c.newChunkModel("chunk4")
 	model.ResponseSchema = c.buildChunk4Schema()
 
 	prompt := c.buildChunk4Prompt(input, chunk1, chunk2)
 	prompt = sanitizeUTF8(prompt) // Fix invalid UTF-8
 
-	resp, err := model.GenerateContent(ctx, genai.Text(prompt))
+	resp, err := c.generate(ctx, model, "chunk4", prompt)
 	if err != nil {
 		return nil, fmt.Errorf("gemini generate failed: %w", err)
 	}
@@ -459,17 +511,16 @@ func (c *GeminiClient) generateChunk4(ctx context.Context, input *ports.AIInput,
 
 	var chunk Chunk4Output
 	if err := json.Unmarshal([]byte(jsonString), &chunk); err != nil {
-		debugPath := fmt.Sprintf("output/chunk4_debug_%s.json", input.VideoMetadata.RealCode)
-		_ = writeDebugFile(debugPath, jsonString)
+		c.writeChunkDebug("chunk4", input.VideoMetadata.RealCode, jsonString)
 		return nil, fmt.Errorf("failed to parse chunk4: %w", err)
 	}
 
 	// Post-process: Sanitize all text fields
-	chunk.CinematographyAnalysis = c.sanitizeText(chunk.CinematographyAnalysis)
-	chunk.CharacterJourney = c.sanitizeText(chunk.CharacterJourney)
-	chunk.ThematicExplanation = c.sanitizeText(chunk.ThematicExplanation)
-	chunk.ViewingTips = c.sanitizeText(chunk.ViewingTips)
-	chunk.AudienceMatch = c.sanitizeText(chunk.AudienceMatch)
+	chunk.CinematographyAnalysis = c.sanitizeText(chunk.CinematographyAnalysis, input.OutputLanguage)
+	chunk.CharacterJourney = c.sanitizeText(chunk.CharacterJourney, input.OutputLanguage)
+	chunk.ThematicExplanation = c.sanitizeText(chunk.ThematicExplanation, input.OutputLanguage)
+	chunk.ViewingTips = c.sanitizeText(chunk.ViewingTips, input.OutputLanguage)
+	chunk.AudienceMatch = c.sanitizeText(chunk.AudienceMatch, input.OutputLanguage)
 
 	return &chunk, nil
 }
@@ -543,45 +594,120 @@ func (c *GeminiClient) sanitizeJSONNumbers(jsonStr string) string {
 // Safe Moments Post-Processing (JAV-specific)
 // ============================================================================
 
+// safeMomentsFor คืน safe moment settings ของ job (ปรับ threshold ตามความยาววิดีโอแล้ว)
+func safeMomentsFor(input *ports.AIInput) models.SafeMomentSettings {
+	duration := 0
+	if input.VideoMetadata != nil {
+		duration = input.VideoMetadata.Duration
+	}
+	return input.SafeMoments.ForDuration(duration)
+}
+
+// keyMomentsWindowRule ช่วงเวลาที่ prompt ขอ keyMoments - ต้องตรงกับ processKeyMomentsSafe
+// ไม่งั้น AI กระจาย moments ทั้งเรื่องแล้วโดนตัดเหลือไม่กี่อัน
+func keyMomentsWindowRule(s models.SafeMomentSettings) string {
+	if s.Disabled {
+		return "กระจายทั่วทั้งวิดีโอ ต้น กลาง ปลาย อย่างสม่ำเสมอ"
+	}
+	return fmt.Sprintf("เฉพาะช่วง %d วินาทีแรก (startOffset <= %d) กระจายสม่ำเสมอในช่วงนี้ - moments หลังจากนี้จะถูกตัดทิ้ง", s.ThresholdSeconds, s.ThresholdSeconds)
+}
+
+// keyMomentsPromptRange จำนวน moments ที่ขอ: อย่างน้อย MaxPublic (เต็ม Google Schema)
+// ไม่เกิน MaxInternal และไม่เกินที่ช่วงเวลาจุได้โดยไม่ซ้อนทับ (minDuration วินาทีต่อ moment)
+func keyMomentsPromptRange(s models.SafeMomentSettings, videoDuration, minDuration int) (lo, hi int) {
+	lo, hi = s.MaxPublic, s.MaxInternal
+	window := s.ThresholdSeconds
+	if s.Disabled {
+		window = videoDuration
+	}
+	if window > 0 && minDuration > 0 && window/minDuration < hi {
+		hi = window / minDuration
+	}
+	if hi < s.MinMoments {
+		hi = s.MinMoments
+	}
+	if lo > hi {
+		lo = hi
+	}
+	return lo, hi
+}
+
 // processKeyMomentsSafe ประมวลผล keyMoments ให้ปลอดภัย
 // 1. กรอง explicit keywords
-// 2. จำกัดเวลาไม่เกิน 600 วินาที (10 นาทีแรก)
+// 2. จำกัดเวลาไม่เกิน settings.ThresholdSeconds (default 10 นาทีแรก)
 // 3. เรียงลำดับตาม startOffset
 // 4. ลบ timestamps ที่ซ้อนทับกัน
-func (c *GeminiClient) processKeyMomentsSafe(moments []models.KeyMoment, videoDuration int) []models.KeyMoment {
+// 5. แยกเป็น public (cap MaxPublic, Google Schema) และ internal (cap MaxInternal, Members)
+// public เป็น prefix ของ internal เสมอ - ทั้งสองชุดผ่านการกรองเดียวกัน
+func (c *GeminiClient) processKeyMomentsSafe(moments []models.KeyMoment, settings models.SafeMomentSettings, videoDuration int, lang string) (public, internal []models.KeyMoment) {
 	if len(moments) == 0 {
-		return moments
+		return moments, nil
 	}
 
 	c.logger.Info("[Safe Moments] Processing",
 		"input_count", len(moments),
 		"video_duration", videoDuration,
+		"threshold_seconds", settings.ThresholdSeconds,
+		"time_filter", !settings.Disabled,
+	)
+
+	trace := c.traceKeyMomentsSafe(moments, settings, videoDuration, lang)
+	for _, d := range trace.Dropped {
+		c.logger.Debug("[Safe Moments] Filtered out",
+			"name", d.Name,
+			"start_offset", d.StartOffset,
+			"reason", d.Reason,
+			"detail", d.Detail,
+		)
+	}
+	for _, seed := range trace.Seeds {
+		c.logger.Debug("[Safe Moments] Added seed moment",
+			"name", seed.Name,
+			"start", seed.StartOffset,
+		)
+	}
+
+	c.logger.Info("[Safe Moments] Completed",
+		"public_count", len(trace.Public),
+		"internal_count", len(trace.Internal),
 	)
 
+	return trace.Public, trace.Internal
+}
+
+// traceKeyMomentsSafe ขั้นตอนของ processKeyMomentsSafe พร้อมบันทึกว่า moment ไหนถูกตัดเพราะอะไร
+// ใช้ร่วมกับ PreviewSafeMoments - ผลตรงกับ pipeline จริงเสมอ
+func (c *GeminiClient) traceKeyMomentsSafe(moments []models.KeyMoment, settings models.SafeMomentSettings, videoDuration int, lang string) *models.SafeMomentsPreview {
+	trace := &models.SafeMomentsPreview{
+		Settings: settings,
+		Input:    append([]models.KeyMoment(nil), moments...),
+		Dropped:  []models.DroppedKeyMoment{},
+		Seeds:    []models.KeyMoment{},
+	}
+	drop := func(m models.KeyMoment, reason, detail string) {
+		trace.Dropped = append(trace.Dropped, models.DroppedKeyMoment{KeyMoment: m, Reason: reason, Detail: detail})
+	}
+	if len(moments) == 0 {
+		return trace // ไม่เติม seed ให้ชุดว่าง (เหมือน processKeyMomentsSafe)
+	}
+
 	// Step 1: Filter by keyword blacklist
 	filtered := make([]models.KeyMoment, 0, len(moments))
 	for _, m := range moments {
-		if !c.containsBlacklistedKeyword(m.Name) {
+		if !c.containsBlacklistedKeyword(m.Name, lang) {
 			filtered = append(filtered, m)
 		} else {
-			c.logger.Debug("[Safe Moments] Filtered out",
-				"name", m.Name,
-				"reason", "blacklisted keyword",
-			)
+			drop(m, models.SafeMomentDropBlacklist, "")
 		}
 	}
 
-	// Step 2: Filter by time limit (600 seconds)
+	// Step 2: Filter by time limit (settings.ThresholdSeconds)
 	safeFiltered := make([]models.KeyMoment, 0, len(filtered))
 	for _, m := range filtered {
-		if m.StartOffset <= safeKeyMomentsLimit {
+		if settings.IsSafeOffset(m.StartOffset) {
 			safeFiltered = append(safeFiltered, m)
 		} else {
-			c.logger.Debug("[Safe Moments] Filtered out",
-				"name", m.Name,
-				"start_offset", m.StartOffset,
-				"reason", "exceeds 600s limit",
-			)
+			drop(m, models.SafeMomentDropTimeLimit, fmt.Sprintf("exceeds %ds limit", settings.ThresholdSeconds))
 		}
 	}
 
@@ -599,53 +725,55 @@ func (c *GeminiClient) processKeyMomentsSafe(moments []models.KeyMoment, videoDu
 			seenBuckets[bucket] = true
 			deduped = append(deduped, m)
 		} else {
-			c.logger.Debug("[Safe Moments] Filtered out duplicate bucket",
-				"name", m.Name,
-				"bucket", bucket*30,
-			)
+			drop(m, models.SafeMomentDropDedup, fmt.Sprintf("same 30s bucket as %ds", bucket*30))
 		}
 	}
 
 	// Step 5: Ensure minimum coverage - add static seed moments if needed
-	if len(deduped) < minKeyMoments {
-		deduped = c.addSeedMoments(deduped, videoDuration)
+	if len(deduped) < settings.MinMoments {
+		withSeeds := c.addSeedMoments(deduped, settings, videoDuration, lang)
+		trace.Seeds = append(trace.Seeds, withSeeds[len(deduped):]...)
+		deduped = sortedKeyMoments(withSeeds)
 	}
 
-	// Step 6: Limit to maxKeyMomentsPublic (สำหรับ Google Schema)
-	// Note: Internal moments (สำหรับ Members) จะใช้ maxKeyMomentsInternal
-	if len(deduped) > maxKeyMomentsPublic {
-		deduped = deduped[:maxKeyMomentsPublic]
+	// Step 6: Internal (Members) ≤ settings.MaxInternal, Public (Google Schema) ≤ settings.MaxPublic
+	internal := deduped
+	if len(internal) > settings.MaxInternal {
+		for _, m := range internal[settings.MaxInternal:] {
+			drop(m, models.SafeMomentDropCap, fmt.Sprintf("exceeds max %d", settings.MaxInternal))
+		}
+		internal = internal[:settings.MaxInternal]
 	}
+	public := internal
+	if len(public) > settings.MaxPublic {
+		public = public[:settings.MaxPublic]
+	}
+	// แยก backing array กัน - แก้ชุดหนึ่ง (เช่น sanitize) ต้องไม่กระทบอีกชุด
+	trace.Public = append([]models.KeyMoment(nil), public...)
+	trace.Internal = internal
 
-	c.logger.Info("[Safe Moments] Completed",
-		"output_count", len(deduped),
-		"mode", "public", // สำหรับ Google Schema
-	)
+	return trace
+}
 
-	return deduped
+// sortedKeyMoments เรียงตาม startOffset (in place)
+func sortedKeyMoments(moments []models.KeyMoment) []models.KeyMoment {
+	sort.Slice(moments, func(i, j int) bool {
+		return moments[i].StartOffset < moments[j].StartOffset
+	})
+	return moments
 }
 
-// containsBlacklistedKeyword ตรวจสอบว่ามีคำต้องห้ามหรือไม่
-func (c *GeminiClient) containsBlacklistedKeyword(text string) bool {
-	textLower := strings.ToLower(text)
-	for _, keyword := range keywordBlacklist {
-		if strings.Contains(textLower, strings.ToLower(keyword)) {
-			return true
-		}
-	}
-	return false
+// containsBlacklistedKeyword ตรวจสอบว่ามีคำต้องห้ามหรือไม่ (ตามภาษาของบทความ)
+func (c *GeminiClient) containsBlacklistedKeyword(text, lang string) bool {
+	rules := rulesForLanguage(lang)
+	return containsBlacklistedTerm(text, rules.KeyMomentBlacklist, rules.KeyMomentStems)
 }
 
-// addSeedMoments เพิ่ม static seed moments เมื่อมี moments ไม่พอ
-// Static seeds: ใช้ชื่อสุภาพแบบวิชาการ/รีวิว ตาม E-E-A-T guidelines
-func (c *GeminiClient) addSeedMoments(existing []models.KeyMoment, videoDuration int) []models.KeyMoment {
-	seedMoments := []models.KeyMoment{
-		{Name: "บทนำและการแนะนำตัวละครหลัก", StartOffset: 0, EndOffset: 90},
-		{Name: "บทสนทนาเปิดเรื่องและการสร้างสถานการณ์", StartOffset: 120, EndOffset: 210},
-		{Name: "การพัฒนาความสัมพันธ์ระหว่างตัวละคร", StartOffset: 240, EndOffset: 330},
-		{Name: "จุดเปลี่ยนสำคัญของเนื้อเรื่อง", StartOffset: 360, EndOffset: 450},
-		{Name: "ไคลแมกซ์ของบทบาทและอารมณ์", StartOffset: 480, EndOffset: 570},
-	}
+// addSeedMoments เพิ่ม seed moments ของภาษา lang เมื่อมี moments ไม่พอ (ต่อท้าย existing)
+// Seeds: ใช้ชื่อสุภาพแบบวิชาการ/รีวิว ตาม E-E-A-T guidelines (ตั้งได้ผ่าน SetSeedMoments)
+// seed ต้องจบก่อนวิดีโอจบ และอยู่ในช่วง safe ตาม settings ด้วย (ไม่งั้น threshold ที่ตั้งสั้นลงจะไม่มีผล)
+func (c *GeminiClient) addSeedMoments(existing []models.KeyMoment, settings models.SafeMomentSettings, videoDuration int, lang string) []models.KeyMoment {
+	seedMoments := c.seedMomentsFor(lang)
 
 	// Collect existing start offsets to avoid overlap
 	existingStarts := make(map[int]bool)
@@ -657,25 +785,17 @@ func (c *GeminiClient) addSeedMoments(existing []models.KeyMoment, videoDuration
 	// Add seeds that don't overlap
 	result := append([]models.KeyMoment{}, existing...)
 	for _, seed := range seedMoments {
-		if len(result) >= minKeyMoments {
+		if len(result) >= settings.MinMoments {
 			break
 		}
 		bucket := seed.StartOffset / 60
-		if !existingStarts[bucket] && seed.EndOffset <= videoDuration {
+		if !existingStarts[bucket] && seed.EndOffset <= videoDuration && settings.IsSafeOffset(seed.StartOffset) {
 			result = append(result, seed)
 			existingStarts[bucket] = true
-			c.logger.Debug("[Safe Moments] Added seed moment",
-				"name", seed.Name,
-				"start", seed.StartOffset,
-			)
 		}
 	}
 
-	// Re-sort
-	sort.Slice(result, func(i, j int) bool {
-		return result[i].StartOffset < result[j].StartOffset
-	})
-
+	// seeds ต่อท้าย existing (ยังไม่เรียง) - caller เรียงเอง
 	return result
 }
 
@@ -683,21 +803,30 @@ func (c *GeminiClient) addSeedMoments(existing []models.KeyMoment, videoDuration
 // Additional Post-Processing Filters
 // ============================================================================
 
-// filterTopQuotesSafe กรอง topQuotes ที่ timestamp > 600 วินาที
-func (c *GeminiClient) filterTopQuotesSafe(quotes []ports.TopQuote) []ports.TopQuote {
+// topQuoteWindowRule กฎช่วงเวลาของ topQuotes ที่บอก Gemini ใน prompt/schema
+// ใช้ threshold เดียวกับ filterTopQuotesSafe (ไม่ hardcode 600 วินาที)
+func topQuoteWindowRule(settings models.SafeMomentSettings) string {
+	if settings.Disabled {
+		return "timestamp เลือกได้ทั้งวิดีโอ"
+	}
+	return fmt.Sprintf("timestamp ต้องไม่เกิน %d วินาที", settings.ThresholdSeconds)
+}
+
+// filterTopQuotesSafe กรอง topQuotes ที่ timestamp เกิน settings.ThresholdSeconds
+func (c *GeminiClient) filterTopQuotesSafe(quotes []ports.TopQuote, settings models.SafeMomentSettings) []ports.TopQuote {
 	if len(quotes) == 0 {
 		return quotes
 	}
 
 	filtered := make([]ports.TopQuote, 0, len(quotes))
 	for _, q := range quotes {
-		if q.Timestamp <= safeKeyMomentsLimit {
+		if settings.IsSafeOffset(q.Timestamp) {
 			filtered = append(filtered, q)
 		} else {
 			c.logger.Debug("[Safe Filter] Filtered out topQuote",
 				"text", q.Text[:min(50, len(q.Text))],
 				"timestamp", q.Timestamp,
-				"reason", "exceeds 600s limit",
+				"reason", fmt.Sprintf("exceeds %ds limit", settings.ThresholdSeconds),
 			)
 		}
 	}
@@ -710,15 +839,15 @@ func (c *GeminiClient) filterTopQuotesSafe(quotes []ports.TopQuote) []ports.TopQ
 	return filtered
 }
 
-// filterSEOKeywords กรองคำที่ไม่เหมาะสมสำหรับ Google
-func (c *GeminiClient) filterSEOKeywords(keywords []string) []string {
+// filterSEOKeywords กรองคำที่ไม่เหมาะสมสำหรับ Google (ตามภาษาของบทความ)
+func (c *GeminiClient) filterSEOKeywords(keywords []string, lang string) []string {
 	if len(keywords) == 0 {
 		return keywords
 	}
 
 	filtered := make([]string, 0, len(keywords))
 	for _, kw := range keywords {
-		if !c.containsSEOBlacklistedKeyword(kw) {
+		if !c.containsSEOBlacklistedKeyword(kw, lang) {
 			filtered = append(filtered, kw)
 		} else {
 			c.logger.Debug("[SEO Filter] Filtered out keyword",
@@ -736,23 +865,43 @@ func (c *GeminiClient) filterSEOKeywords(keywords []string) []string {
 	return filtered
 }
 
-// containsSEOBlacklistedKeyword ตรวจสอบว่ามีคำต้องห้ามสำหรับ SEO หรือไม่
-func (c *GeminiClient) containsSEOBlacklistedKeyword(text string) bool {
-	textLower := strings.ToLower(text)
-	for _, keyword := range seoKeywordBlacklist {
-		if strings.Contains(textLower, strings.ToLower(keyword)) {
-			return true
+// filterGalleryAlts ล้าง alt ที่มีคำต้องห้าม (SEO blacklist) ให้เป็นค่าว่าง
+// คงลำดับไว้ (alt[i] = ภาพที่ i) - buildArticle เติม fallback ให้ alt ที่ว่าง
+func (c *GeminiClient) filterGalleryAlts(alts []string, lang string) []string {
+	blanked := 0
+	for i, alt := range alts {
+		if c.containsSEOBlacklistedKeyword(alt, lang) {
+			c.logger.Debug("[SEO Filter] Blanked gallery alt",
+				"alt", alt,
+				"reason", "contains blacklisted word",
+			)
+			alts[i] = ""
+			blanked++
 		}
 	}
-	return false
+
+	if blanked > 0 {
+		c.logger.Info("[SEO Filter] Gallery alts filtered",
+			"input", len(alts),
+			"blanked", blanked,
+		)
+	}
+
+	return alts
 }
 
-// sanitizeText แทนที่คำไม่สุภาพด้วยคำสุภาพ
-func (c *GeminiClient) sanitizeText(text string) string {
+// containsSEOBlacklistedKeyword ตรวจสอบว่ามีคำต้องห้ามสำหรับ SEO หรือไม่
+func (c *GeminiClient) containsSEOBlacklistedKeyword(text, lang string) bool {
+	rules := rulesForLanguage(lang)
+	return containsBlacklistedTerm(text, rules.SEOKeywordBlacklist, rules.SEOKeywordStems)
+}
+
+// sanitizeText แทนที่คำไม่สุภาพด้วยคำสุภาพ (ตามภาษาของบทความ)
+func (c *GeminiClient) sanitizeText(text, lang string) string {
 	result := text
-	for explicit, polite := range explicitTermReplacements {
-		if strings.Contains(result, explicit) {
-			result = strings.ReplaceAll(result, explicit, polite)
+	for explicit, polite := range rulesForLanguage(lang).ExplicitReplacements {
+		var replaced bool
+		if result, replaced = replaceTermFold(result, explicit, polite); replaced {
 			c.logger.Debug("[Sanitize] Replaced explicit term",
 				"from", explicit,
 				"to", polite,
@@ -763,17 +912,17 @@ func (c *GeminiClient) sanitizeText(text string) string {
 }
 
 // sanitizeTagDescriptions แทนที่คำไม่สุภาพใน tagDescriptions
-func (c *GeminiClient) sanitizeTagDescriptions(tags []models.TagDesc) []models.TagDesc {
+func (c *GeminiClient) sanitizeTagDescriptions(tags []models.TagDesc, lang string) []models.TagDesc {
 	for i := range tags {
-		tags[i].Description = c.sanitizeText(tags[i].Description)
+		tags[i].Description = c.sanitizeText(tags[i].Description, lang)
 	}
 	return tags
 }
 
 // sanitizeFAQItems แทนที่คำไม่สุภาพใน faqItems
-func (c *GeminiClient) sanitizeFAQItems(items []models.FAQItem) []models.FAQItem {
+func (c *GeminiClient) sanitizeFAQItems(items []models.FAQItem, lang string) []models.FAQItem {
 	for i := range items {
-		items[i].Answer = c.sanitizeText(items[i].Answer)
+		items[i].Answer = c.sanitizeText(items[i].Answer, lang)
 	}
 	return items
 }
@@ -782,13 +931,13 @@ func (c *GeminiClient) sanitizeFAQItems(items []models.FAQItem) []models.FAQItem
 // Validation
 // ============================================================================
 
-func (c *GeminiClient) validateChunk1(chunk *Chunk1Output) error {
+func (c *GeminiClient) validateChunk1(chunk *Chunk1Output, lang string, settings models.SafeMomentSettings) error {
 	var errors []string
 
 	// ตรวจสอบความยาว summary (400 คำ ≈ 1,500 chars, tolerance 800)
 	summaryChars := len([]rune(chunk.Summary))
-	if summaryChars < 800 {
-		errors = append(errors, fmt.Sprintf("summary: %d chars (min 800)", summaryChars))
+	if minSummary := minCharsFor(800, lang); summaryChars < minSummary {
+		errors = append(errors, fmt.Sprintf("summary: %d chars (min %d)", summaryChars, minSummary))
 	}
 
 	// ตรวจสอบ highlights
@@ -799,8 +948,8 @@ func (c *GeminiClient) validateChunk1(chunk *Chunk1Output) error {
 	// ตรวจสอบ key moments (หลังจาก Safe Moments processing แล้ว)
 	// Note: อนุญาตให้ keyMoments ว่างได้ (Context Discovery rule - ถ้าวิดีโอไม่มี safe scenes)
 	// แต่ถ้ามีแล้วต้องมีอย่างน้อย 3 ตัว
-	if len(chunk.KeyMoments) > 0 && len(chunk.KeyMoments) < minKeyMoments {
-		errors = append(errors, fmt.Sprintf("keyMoments: %d items (min %d or 0)", len(chunk.KeyMoments), minKeyMoments))
+	if len(chunk.KeyMoments) > 0 && len(chunk.KeyMoments) < settings.MinMoments {
+		errors = append(errors, fmt.Sprintf("keyMoments: %d items (min %d or 0)", len(chunk.KeyMoments), settings.MinMoments))
 	}
 
 	// ตรวจสอบ timestamps - เบาลงเพราะ Safe Moments processing ทำแล้ว
@@ -814,9 +963,9 @@ func (c *GeminiClient) validateChunk1(chunk *Chunk1Output) error {
 			errors = append(errors, fmt.Sprintf("keyMoments[%d]: duration %ds < 30s", i, km.EndOffset-km.StartOffset))
 			break
 		}
-		// ตรวจสอบว่า startOffset ไม่เกิน 600 วินาที (Safe Moments limit)
-		if km.StartOffset > safeKeyMomentsLimit {
-			errors = append(errors, fmt.Sprintf("keyMoments[%d]: startOffset %d exceeds safe limit %d", i, km.StartOffset, safeKeyMomentsLimit))
+		// ตรวจสอบว่า startOffset ไม่เกินช่วง safe (Safe Moments limit)
+		if !settings.IsSafeOffset(km.StartOffset) {
+			errors = append(errors, fmt.Sprintf("keyMoments[%d]: startOffset %d exceeds safe limit %d", i, km.StartOffset, settings.ThresholdSeconds))
 			break
 		}
 	}
@@ -842,25 +991,25 @@ func (c *GeminiClient) validateChunk1(chunk *Chunk1Output) error {
 	return nil
 }
 
-func (c *GeminiClient) validateChunk2(chunk *Chunk2Output) error {
+func (c *GeminiClient) validateChunk2(chunk *Chunk2Output, lang string) error {
 	var errors []string
 
 	// ตรวจสอบความยาว detailedReview (600 คำ ≈ 2,000 chars, tolerance 1,000)
 	detailedChars := len([]rune(chunk.DetailedReview))
-	if detailedChars < 1000 {
-		errors = append(errors, fmt.Sprintf("detailedReview: %d chars (min 1000)", detailedChars))
+	if minDetailed := minCharsFor(1000, lang); detailedChars < minDetailed {
+		errors = append(errors, fmt.Sprintf("detailedReview: %d chars (min %d)", detailedChars, minDetailed))
 	}
 
 	// ตรวจสอบ expertAnalysis (100 คำ ≈ 300 chars, tolerance 100)
 	expertChars := len([]rune(chunk.ExpertAnalysis))
-	if expertChars < 100 {
-		errors = append(errors, fmt.Sprintf("expertAnalysis: %d chars (min 100)", expertChars))
+	if minExpert := minCharsFor(100, lang); expertChars < minExpert {
+		errors = append(errors, fmt.Sprintf("expertAnalysis: %d chars (min %d)", expertChars, minExpert))
 	}
 
 	// ตรวจสอบ dialogueAnalysis
 	dialogueChars := len([]rune(chunk.DialogueAnalysis))
-	if dialogueChars < 100 {
-		errors = append(errors, fmt.Sprintf("dialogueAnalysis: %d chars (min 100)", dialogueChars))
+	if minDialogue := minCharsFor(100, lang); dialogueChars < minDialogue {
+		errors = append(errors, fmt.Sprintf("dialogueAnalysis: %d chars (min %d)", dialogueChars, minDialogue))
 	}
 
 	// ตรวจสอบ topQuotes
@@ -887,31 +1036,31 @@ func (c *GeminiClient) validateChunk2(chunk *Chunk2Output) error {
 	return nil
 }
 
-func (c *GeminiClient) validateChunk4(chunk *Chunk4Output) error {
+func (c *GeminiClient) validateChunk4(chunk *Chunk4Output, lang string) error {
 	var errors []string
 
 	// ตรวจสอบ cinematographyAnalysis (300 คำ ≈ 900 chars, tolerance 500)
 	cinematographyChars := len([]rune(chunk.CinematographyAnalysis))
-	if cinematographyChars < 500 {
-		errors = append(errors, fmt.Sprintf("cinematographyAnalysis: %d chars (min 500)", cinematographyChars))
+	if minCinematography := minCharsFor(500, lang); cinematographyChars < minCinematography {
+		errors = append(errors, fmt.Sprintf("cinematographyAnalysis: %d chars (min %d)", cinematographyChars, minCinematography))
 	}
 
 	// ตรวจสอบ characterJourney (400 คำ ≈ 1,200 chars, tolerance 600)
 	characterChars := len([]rune(chunk.CharacterJourney))
-	if characterChars < 600 {
-		errors = append(errors, fmt.Sprintf("characterJourney: %d chars (min 600)", characterChars))
+	if minCharacter := minCharsFor(600, lang); characterChars < minCharacter {
+		errors = append(errors, fmt.Sprintf("characterJourney: %d chars (min %d)", characterChars, minCharacter))
 	}
 
 	// ตรวจสอบ thematicExplanation (300 คำ ≈ 900 chars, tolerance 400)
 	thematicChars := len([]rune(chunk.ThematicExplanation))
-	if thematicChars < 400 {
-		errors = append(errors, fmt.Sprintf("thematicExplanation: %d chars (min 400)", thematicChars))
+	if minThematic := minCharsFor(400, lang); thematicChars < minThematic {
+		errors = append(errors, fmt.Sprintf("thematicExplanation: %d chars (min %d)", thematicChars, minThematic))
 	}
 
 	// ตรวจสอบ viewingTips (200 คำ ≈ 600 chars, tolerance 300)
 	viewingChars := len([]rune(chunk.ViewingTips))
-	if viewingChars < 300 {
-		errors = append(errors, fmt.Sprintf("viewingTips: %d chars (min 300)", viewingChars))
+	if minViewing := minCharsFor(300, lang); viewingChars < minViewing {
+		errors = append(errors, fmt.Sprintf("viewingTips: %d chars (min %d)", viewingChars, minViewing))
 	}
 
 	// ตรวจสอบ emotionalArc
@@ -966,71 +1115,203 @@ func (c *GeminiClient) loadState(videoCode string) (*ChunkState, error) {
 	return &state, nil
 }
 
+// chunkDependencies chunk ที่ต้องใช้ output ของ chunk อื่นเป็น context
+// chunk ที่ถูก generate ใหม่ → chunk ที่พึ่งพามันต้อง generate ใหม่ด้วย (context เปลี่ยน)
+var chunkDependencies = map[int][]int{
+	2: {1},
+	3: {1},
+	4: {1, 2},
+}
+
+// has chunk n อยู่ใน state แล้วหรือยัง
+func (s *ChunkState) has(n int) bool {
+	switch n {
+	case 1:
+		return s.Chunk1 != nil
+	case 2:
+		return s.Chunk2 != nil
+	case 3:
+		return s.Chunk3 != nil
+	case 4:
+		return s.Chunk4 != nil
+	}
+	return false
+}
+
+// completedUpTo chunk สุดท้ายที่มีครบต่อเนื่องจาก chunk 1 (ค่าของ LastChunk)
+func (s *ChunkState) completedUpTo() int {
+	n := 0
+	for n < 4 && s.has(n+1) {
+		n++
+	}
+	return n
+}
+
+// outputFieldChunks chunk ที่เป็นเจ้าของ field ใน AIOutput (ใช้หา chunk ที่ทำให้ ValidateAIOutput ไม่ผ่าน)
+var outputFieldChunks = map[string]int{
+	"title":          1,
+	"summary":        1,
+	"galleryAlts":    1,
+	"detailedReview": 2,
+}
+
+// chunksToRegenerate chunk ที่ต้อง generate ตอน resume (2..lastChunk): ไม่มีใน state หรือ dependency ถูก generate ใหม่
+// chunk ที่บันทึกไว้แล้วและ dependency ไม่เปลี่ยน = ใช้ของเดิม
+func chunksToRegenerate(has func(int) bool, deps map[int][]int, lastChunk int) map[int]bool {
+	regen := make(map[int]bool)
+	for n := 2; n <= lastChunk; n++ {
+		if !has(n) {
+			regen[n] = true
+			continue
+		}
+		for _, dep := range deps[n] {
+			if regen[dep] {
+				regen[n] = true
+				break
+			}
+		}
+	}
+	return regen
+}
+
+// invalidChunks chunk ที่ทำให้ validation ไม่ผ่าน (IncompleteOutputError → ผ่าน fieldChunks, ValidationError → Chunk)
+func invalidChunks(err error, fieldChunks map[string]int) []int {
+	var verr *ValidationError
+	if errors.As(err, &verr) {
+		return []int{verr.Chunk}
+	}
+
+	var incomplete *IncompleteOutputError
+	if !errors.As(err, &incomplete) {
+		return nil
+	}
+	seen := make(map[int]bool)
+	var chunks []int
+	for _, field := range incomplete.Missing {
+		n, ok := fieldChunks[field]
+		if !ok || seen[n] {
+			continue
+		}
+		seen[n] = true
+		chunks = append(chunks, n)
+	}
+	return chunks
+}
+
+// dropInvalidChunk ตัด chunk ที่ validation ไม่ผ่านออกจาก state (resume จะ generate ใหม่แค่ chunk นั้น + ที่พึ่งพามัน)
+// chunk 1 ไม่ผ่าน = resume ไม่ได้ (ทุก chunk ใช้ chunk 1) → ลบ state ให้ job ถัดไป generate ใหม่ทั้งหมด
+func (c *GeminiClient) dropInvalidChunk(state *ChunkState, err error) {
+	chunks := invalidChunks(err, outputFieldChunks)
+	if len(chunks) == 0 {
+		return
+	}
+	for _, n := range chunks {
+		switch n {
+		case 1:
+			os.Remove(fmt.Sprintf("output/state_%s.json", state.VideoCode))
+			return
+		case 2:
+			state.Chunk2 = nil
+		case 3:
+			state.Chunk3 = nil
+		case 4:
+			state.Chunk4 = nil
+		}
+	}
+	state.LastChunk = state.completedUpTo()
+	state.UpdatedAt = time.Now()
+	c.saveState(state)
+}
+
 // ResumeFromState ทำต่อจาก state ที่บันทึกไว้
+// generate เฉพาะ chunk ที่ล้มเหลว (ไม่มีใน state) + chunk ที่พึ่งพามัน - chunk อื่นที่บันทึกไว้แล้วใช้ของเดิม
 func (c *GeminiClient) ResumeFromState(ctx context.Context, input *ports.AIInput, videoCode string) (*ports.AIOutput, error) {
+	input = c.guardSRTInput(ctx, input)
+	ctx, usage := withTokenUsage(ctx)
+
 	state, err := c.loadState(videoCode)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load state: %w", err)
 	}
+	if state.Chunk1 == nil {
+		return nil, fmt.Errorf("state has no chunk1 - cannot resume")
+	}
+
+	regen := chunksToRegenerate(state.has, chunkDependencies, 4)
+	var regenerated, reused []int
+	for n := 2; n <= 4; n++ {
+		if regen[n] {
+			regenerated = append(regenerated, n)
+		} else {
+			reused = append(reused, n)
+		}
+	}
 
 	c.logger.InfoContext(ctx, "Resuming from saved state",
 		"video_code", videoCode,
 		"last_chunk", state.LastChunk,
+		"regenerate_chunks", regenerated,
+		"reuse_chunks", reused,
 	)
 
-	var chunk2 *Chunk2Output
-	var chunk3 *Chunk3Output
-	var chunk4 *Chunk4Output
-
-	// Resume based on last completed chunk
-	switch state.LastChunk {
-	case 1:
-		// Need to generate chunk 2, 3, and 4
-		chunk2, err = c.generateChunk2WithRetry(ctx, input, state.Chunk1)
+	if regen[2] {
+		chunk2, err := c.generateChunk2WithRetry(ctx, input, state.Chunk1)
 		if err != nil {
 			return nil, err
 		}
 		state.Chunk2 = chunk2
-		state.LastChunk = 2
+		state.LastChunk = state.completedUpTo()
+		state.UpdatedAt = time.Now()
 		c.saveState(state)
-		fallthrough
+	}
 
-	case 2:
-		// Need to generate chunk 3 and 4
-		if state.Chunk2 != nil {
-			chunk2 = state.Chunk2
-		}
-		chunk3, err = c.generateChunk3WithRetry(ctx, input, state.Chunk1)
+	if regen[3] {
+		chunk3, err := c.generateChunk3WithRetry(ctx, input, state.Chunk1)
 		if err != nil {
 			return nil, err
 		}
 		state.Chunk3 = chunk3
-		state.LastChunk = 3
+		state.LastChunk = state.completedUpTo()
+		state.UpdatedAt = time.Now()
 		c.saveState(state)
-		fallthrough
+	}
 
-	case 3:
-		// Need to generate chunk 4
-		if state.Chunk3 != nil {
-			chunk3 = state.Chunk3
-		}
-		chunk4, err = c.generateChunk4WithRetry(ctx, input, state.Chunk1, state.Chunk2)
+	if regen[4] {
+		chunk4, err := c.generateChunk4WithRetry(ctx, input, state.Chunk1, state.Chunk2)
 		if err != nil {
 			return nil, err
 		}
+		state.Chunk4 = chunk4
+		state.LastChunk = state.completedUpTo()
+		state.UpdatedAt = time.Now()
+		c.saveState(state)
 	}
 
-	// Use saved chunks if available
-	if state.Chunk3 != nil && chunk3 == nil {
-		chunk3 = state.Chunk3
+	// Aggregate (chunk ที่ generate ใหม่ + chunk เดิมใน state)
+	output := AggregateChunks(state.Chunk1, state.Chunk2, state.Chunk3, state.Chunk4)
+	output.TokenUsage = usage.total() // เฉพาะ chunk ที่ generate ตอน resume
+	if err := ValidateAIOutput(output); err != nil {
+		// ไม่ลบ state file - แก้ schema/prompt แล้ว resume ได้
+		c.logger.ErrorContext(ctx, "Aggregated output validation failed",
+			"video_code", videoCode,
+			"error", err,
+		)
+		c.dropInvalidChunk(state, err)
+		return nil, err
 	}
 
-	// Aggregate
-	output := AggregateChunks(state.Chunk1, state.Chunk2, chunk3, chunk4)
-
 	// Clean up state file
 	os.Remove(fmt.Sprintf("output/state_%s.json", videoCode))
 
+	c.logger.InfoContext(ctx, "Resume completed",
+		"video_code", videoCode,
+		"regenerated_chunks", regenerated,
+		"prompt_tokens", output.TokenUsage.PromptTokens,
+		"candidate_tokens", output.TokenUsage.CandidateTokens,
+		"total_tokens", output.TokenUsage.TotalTokens,
+		"gemini_calls", output.TokenUsage.Calls,
+	)
+
 	return output, nil
 }
 
@@ -8,8 +8,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/google/generative-ai-go/genai"
-
 	"seo-worker/domain/ports"
 )
 
@@ -50,6 +48,9 @@ import (
 
 // GenerateArticleContentV2 รัน 7-chunk pipeline แบบ parallel
 func (c *GeminiClient) GenerateArticleContentV2(ctx context.Context, input *ports.AIInput) (*ports.AIOutput, error) {
+	input = c.guardSRTInput(ctx, input)
+	ctx, usage := withTokenUsage(ctx)
+
 	videoCode := input.VideoMetadata.RealCode
 	if videoCode == "" {
 		videoCode = input.VideoMetadata.Code
@@ -88,35 +89,32 @@ func (c *GeminiClient) GenerateArticleContentV2(ctx context.Context, input *port
 	c.saveStateV2(state)
 
 	// ===== Phase 2: Chunks 2, 3, 4 (Parallel) =====
+	// chunk ที่สำเร็จถูกเก็บใน state แม้ตัวอื่นล้มเหลว (resume จะ generate แค่ตัวที่ล้มเหลว)
 	c.logger.InfoContext(ctx, "[Phase 2] Generating Chunks 2,3,4 in parallel...")
-	chunk2, chunk3, chunk4, err := c.generateChunks234Parallel(ctx, input, coreCtx)
+	err = c.generateChunks234Parallel(ctx, input, state, phase2Chunks)
+	if state.Chunk2 != nil {
+		// Update CoreContext with scene locations from Chunk 2
+		coreCtx.Entities.Locations = state.Chunk2.SceneLocations
+	}
+	state.LastChunk = state.completedUpTo()
+	state.UpdatedAt = time.Now()
+	c.saveStateV2(state)
 	if err != nil {
 		return nil, &PartialGenerationErrorV2{
 			Message:       "phase 2 failed",
 			PartialPath:   fmt.Sprintf("output/state_%s.json", videoCode),
-			FailedChunk:   2, // Could be 2, 3, or 4
-			CompletedUpTo: 1,
+			FailedChunk:   state.firstMissing(),
+			CompletedUpTo: state.LastChunk,
 			Cause:         err,
 		}
 	}
+	chunk2, chunk3, chunk4 := state.Chunk2, state.Chunk3, state.Chunk4
 	c.logger.InfoContext(ctx, "[Phase 2] Chunks 2,3,4 completed",
 		"highlights", len(chunk2.Highlights),
 		"topQuotes", len(chunk3.TopQuotes),
 		"detailedReview_len", len(chunk4.DetailedReview),
 	)
 
-	// Update CoreContext with scene locations from Chunk 2
-	coreCtx.Entities.Locations = chunk2.SceneLocations
-
-	// Save state after Phase 2
-	state.Chunk2 = chunk2
-	state.Chunk3 = chunk3
-	state.Chunk4 = chunk4
-	state.CoreContext = coreCtx
-	state.LastChunk = 4
-	state.UpdatedAt = time.Now()
-	c.saveStateV2(state)
-
 	// ===== Phase 3: Chunk 5 (Sequential - needs 2,3,4) =====
 	c.logger.InfoContext(ctx, "[Phase 3] Generating Chunk 5: Recommendations...")
 	chunk5, err := c.generateChunk5V2WithRetry(ctx, input, coreCtx, chunk2, chunk3, chunk4)
@@ -146,16 +144,20 @@ func (c *GeminiClient) GenerateArticleContentV2(ctx context.Context, input *port
 
 	// ===== Phase 4: Chunks 6, 7 (Parallel) =====
 	c.logger.InfoContext(ctx, "[Phase 4] Generating Chunks 6,7 in parallel...")
-	chunk6, chunk7, err := c.generateChunks67Parallel(ctx, input, extCtx)
+	err = c.generateChunks67Parallel(ctx, input, state, phase4Chunks)
+	state.LastChunk = state.completedUpTo()
+	state.UpdatedAt = time.Now()
+	c.saveStateV2(state)
 	if err != nil {
 		return nil, &PartialGenerationErrorV2{
 			Message:       "phase 4 failed",
 			PartialPath:   fmt.Sprintf("output/state_%s.json", videoCode),
-			FailedChunk:   6, // Could be 6 or 7
-			CompletedUpTo: 5,
+			FailedChunk:   state.firstMissing(),
+			CompletedUpTo: state.LastChunk,
 			Cause:         err,
 		}
 	}
+	chunk6, chunk7 := state.Chunk6, state.Chunk7
 	c.logger.InfoContext(ctx, "[Phase 4] Chunks 6,7 completed",
 		"faqItems", len(chunk6.FAQItems),
 		"cinematography_len", len(chunk7.CinematographyAnalysis),
@@ -163,6 +165,16 @@ func (c *GeminiClient) GenerateArticleContentV2(ctx context.Context, input *port
 
 	// ===== Aggregate =====
 	output := AggregateChunksV2(chunk1, chunk2, chunk3, chunk4, chunk5, chunk6, chunk7)
+	output.TokenUsage = usage.total()
+	if err := ValidateAIOutput(output); err != nil {
+		// ไม่ลบ state file - แก้ schema/prompt แล้ว resume ได้ (ตัด chunk ที่ไม่ผ่านออก ให้ resume generate ใหม่)
+		c.logger.ErrorContext(ctx, "Aggregated output validation failed",
+			"video_code", videoCode,
+			"error", err,
+		)
+		c.dropInvalidChunkV2(state, err)
+		return nil, err
+	}
 
 	// Clean up state file on full success
 	os.Remove(fmt.Sprintf("output/state_%s.json", videoCode))
@@ -171,6 +183,10 @@ func (c *GeminiClient) GenerateArticleContentV2(ctx context.Context, input *port
 	c.logger.InfoContext(ctx, "7-chunk V2 generation completed successfully",
 		"video_code", videoCode,
 		"elapsed", elapsed.String(),
+		"prompt_tokens", output.TokenUsage.PromptTokens,
+		"candidate_tokens", output.TokenUsage.CandidateTokens,
+		"total_tokens", output.TokenUsage.TotalTokens,
+		"gemini_calls", output.TokenUsage.Calls,
 	)
 
 	return output, nil
@@ -180,92 +196,133 @@ func (c *GeminiClient) GenerateArticleContentV2(ctx context.Context, input *port
 // Phase 2: Parallel execution of Chunks 2, 3, 4
 // ============================================================================
 
+// phase2Chunks / phase4Chunks chunk ที่ generate พร้อมกันในแต่ละ phase
+var (
+	phase2Chunks = map[int]bool{2: true, 3: true, 4: true}
+	phase4Chunks = map[int]bool{6: true, 7: true}
+)
+
+// generateChunks234Parallel generate chunk 2,3,4 ที่อยู่ใน need พร้อมกัน แล้วเก็บตัวที่สำเร็จลง state
+// error = chunk แรกที่ล้มเหลว (ตัวที่สำเร็จยังอยู่ใน state)
 func (c *GeminiClient) generateChunks234Parallel(
 	ctx context.Context,
 	input *ports.AIInput,
-	coreCtx *CoreContext,
-) (*Chunk2OutputV2, *Chunk3OutputV2, *Chunk4OutputV2, error) {
+	state *ChunkStateV2,
+	need map[int]bool,
+) error {
 	var wg sync.WaitGroup
 	var chunk2 *Chunk2OutputV2
 	var chunk3 *Chunk3OutputV2
 	var chunk4 *Chunk4OutputV2
 	var err2, err3, err4 error
-
-	wg.Add(3)
+	coreCtx := state.CoreContext
 
 	// Chunk 2: Scene & Moments
-	go func() {
-		defer wg.Done()
-		chunk2, err2 = c.generateChunk2V2WithRetry(ctx, input, coreCtx)
-	}()
+	if need[2] {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			chunk2, err2 = c.generateChunk2V2WithRetry(ctx, input, coreCtx)
+		}()
+	}
 
 	// Chunk 3: Expertise
-	go func() {
-		defer wg.Done()
-		chunk3, err3 = c.generateChunk3V2WithRetry(ctx, input, coreCtx)
-	}()
+	if need[3] {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			chunk3, err3 = c.generateChunk3V2WithRetry(ctx, input, coreCtx)
+		}()
+	}
 
 	// Chunk 4: Authority
-	go func() {
-		defer wg.Done()
-		chunk4, err4 = c.generateChunk4V2WithRetry(ctx, input, coreCtx)
-	}()
+	if need[4] {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			chunk4, err4 = c.generateChunk4V2WithRetry(ctx, input, coreCtx)
+		}()
+	}
 
 	wg.Wait()
 
+	if chunk2 != nil {
+		state.Chunk2 = chunk2
+	}
+	if chunk3 != nil {
+		state.Chunk3 = chunk3
+	}
+	if chunk4 != nil {
+		state.Chunk4 = chunk4
+	}
+
 	// Check for errors
 	if err2 != nil {
-		return nil, nil, nil, fmt.Errorf("chunk2 failed: %w", err2)
+		return fmt.Errorf("chunk2 failed: %w", err2)
 	}
 	if err3 != nil {
-		return nil, nil, nil, fmt.Errorf("chunk3 failed: %w", err3)
+		return fmt.Errorf("chunk3 failed: %w", err3)
 	}
 	if err4 != nil {
-		return nil, nil, nil, fmt.Errorf("chunk4 failed: %w", err4)
+		return fmt.Errorf("chunk4 failed: %w", err4)
 	}
 
-	return chunk2, chunk3, chunk4, nil
+	return nil
 }
 
 // ============================================================================
 // Phase 4: Parallel execution of Chunks 6, 7
 // ============================================================================
 
+// generateChunks67Parallel generate chunk 6,7 ที่อยู่ใน need พร้อมกัน แล้วเก็บตัวที่สำเร็จลง state
 func (c *GeminiClient) generateChunks67Parallel(
 	ctx context.Context,
 	input *ports.AIInput,
-	extCtx *ExtendedContext,
-) (*Chunk6OutputV2, *Chunk7OutputV2, error) {
+	state *ChunkStateV2,
+	need map[int]bool,
+) error {
 	var wg sync.WaitGroup
 	var chunk6 *Chunk6OutputV2
 	var chunk7 *Chunk7OutputV2
 	var err6, err7 error
-
-	wg.Add(2)
+	extCtx := state.ExtendedContext
 
 	// Chunk 6: Technical & FAQ
-	go func() {
-		defer wg.Done()
-		chunk6, err6 = c.generateChunk6V2WithRetry(ctx, input, extCtx)
-	}()
+	if need[6] {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			chunk6, err6 = c.generateChunk6V2WithRetry(ctx, input, extCtx)
+		}()
+	}
 
 	// Chunk 7: Deep Analysis
-	go func() {
-		defer wg.Done()
-		chunk7, err7 = c.generateChunk7V2WithRetry(ctx, input, extCtx)
-	}()
+	if need[7] {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			chunk7, err7 = c.generateChunk7V2WithRetry(ctx, input, extCtx)
+		}()
+	}
 
 	wg.Wait()
 
+	if chunk6 != nil {
+		state.Chunk6 = chunk6
+	}
+	if chunk7 != nil {
+		state.Chunk7 = chunk7
+	}
+
 	// Check for errors
 	if err6 != nil {
-		return nil, nil, fmt.Errorf("chunk6 failed: %w", err6)
+		return fmt.Errorf("chunk6 failed: %w", err6)
 	}
 	if err7 != nil {
-		return nil, nil, fmt.Errorf("chunk7 failed: %w", err7)
+		return fmt.Errorf("chunk7 failed: %w", err7)
 	}
 
-	return chunk6, chunk7, nil
+	return nil
 }
 
 // ============================================================================
@@ -403,14 +460,13 @@ func (c *GeminiClient) generateChunk7V2WithRetry(ctx context.Context, input *por
 // ============================================================================
 
 func (c *GeminiClient) generateChunk1V2(ctx context.Context, input *ports.AIInput) (*Chunk1OutputV2, error) {
-	model := c.client.GenerativeModel(c.model)
-	c.configureModel(model)
+	model := c.newChunkModel("chunk1v2")
 	model.ResponseSchema = c.buildChunk1SchemaV2()
 
 	prompt := c.buildChunk1PromptV2(input)
 	prompt = sanitizeUTF8(prompt)
 
-	resp, err := model.GenerateContent(ctx, genai.Text(prompt))
+	resp, err := c.generate(ctx, model, "chunk1v2", prompt)
 	if err != nil {
 		return nil, fmt.Errorf("gemini generate failed: %w", err)
 	}
@@ -422,8 +478,7 @@ func (c *GeminiClient) generateChunk1V2(ctx context.Context, input *ports.AIInpu
 
 	var chunk Chunk1OutputV2
 	if err := json.Unmarshal([]byte(jsonString), &chunk); err != nil {
-		debugPath := fmt.Sprintf("output/chunk1v2_debug_%s.json", input.VideoMetadata.RealCode)
-		_ = writeDebugFile(debugPath, jsonString)
+		c.writeChunkDebug("chunk1v2", input.VideoMetadata.RealCode, jsonString)
 		return nil, fmt.Errorf("failed to parse chunk1v2: %w", err)
 	}
 
@@ -431,14 +486,13 @@ func (c *GeminiClient) generateChunk1V2(ctx context.Context, input *ports.AIInpu
 }
 
 func (c *GeminiClient) generateChunk2V2(ctx context.Context, input *ports.AIInput, coreCtx *CoreContext) (*Chunk2OutputV2, error) {
-	model := c.client.GenerativeModel(c.model)
-	c.configureModel(model)
+	model := c.newChunkModel("chunk2v2")
 	model.ResponseSchema = c.buildChunk2SchemaV2()
 
 	prompt := c.buildChunk2PromptV2(input, coreCtx)
 	prompt = sanitizeUTF8(prompt)
 
-	resp, err := model.GenerateContent(ctx, genai.Text(prompt))
+	resp, err := c.generate(ctx, model, "chunk2v2", prompt)
 	if err != nil {
 		return nil, fmt.Errorf("gemini generate failed: %w", err)
 	}
@@ -450,26 +504,25 @@ func (c *GeminiClient) generateChunk2V2(ctx context.Context, input *ports.AIInpu
 
 	var chunk Chunk2OutputV2
 	if err := json.Unmarshal([]byte(jsonString), &chunk); err != nil {
-		debugPath := fmt.Sprintf("output/chunk2v2_debug_%s.json", input.VideoMetadata.RealCode)
-		_ = writeDebugFile(debugPath, jsonString)
+		c.writeChunkDebug("chunk2v2", input.VideoMetadata.RealCode, jsonString)
 		return nil, fmt.Errorf("failed to parse chunk2v2: %w", err)
 	}
 
 	// Post-process: Safe Moments filtering
-	chunk.KeyMoments = c.processKeyMomentsSafe(chunk.KeyMoments, input.VideoMetadata.Duration)
+	chunk.KeyMoments, chunk.InternalKeyMoments = c.processKeyMomentsSafe(chunk.KeyMoments, safeMomentsFor(input), input.VideoMetadata.Duration, input.OutputLanguage)
+	chunk.GalleryAlts = c.filterGalleryAlts(chunk.GalleryAlts, input.OutputLanguage)
 
 	return &chunk, nil
 }
 
 func (c *GeminiClient) generateChunk3V2(ctx context.Context, input *ports.AIInput, coreCtx *CoreContext) (*Chunk3OutputV2, error) {
-	model := c.client.GenerativeModel(c.model)
-	c.configureModel(model)
-	model.ResponseSchema = c.buildChunk3SchemaV2()
+	model := c.newChunkModel("chunk3v2")
+	model.ResponseSchema = c.buildChunk3SchemaV2(safeMomentsFor(input))
 
 	prompt := c.buildChunk3PromptV2(input, coreCtx)
 	prompt = sanitizeUTF8(prompt)
 
-	resp, err := model.GenerateContent(ctx, genai.Text(prompt))
+	resp, err := c.generate(ctx, model, "chunk3v2", prompt)
 	if err != nil {
 		return nil, fmt.Errorf("gemini generate failed: %w", err)
 	}
@@ -481,26 +534,24 @@ func (c *GeminiClient) generateChunk3V2(ctx context.Context, input *ports.AIInpu
 
 	var chunk Chunk3OutputV2
 	if err := json.Unmarshal([]byte(jsonString), &chunk); err != nil {
-		debugPath := fmt.Sprintf("output/chunk3v2_debug_%s.json", input.VideoMetadata.RealCode)
-		_ = writeDebugFile(debugPath, jsonString)
+		c.writeChunkDebug("chunk3v2", input.VideoMetadata.RealCode, jsonString)
 		return nil, fmt.Errorf("failed to parse chunk3v2: %w", err)
 	}
 
-	// Post-process: Filter topQuotes ที่ timestamp > 600 วินาที
-	chunk.TopQuotes = c.filterTopQuotesSafe(chunk.TopQuotes)
+	// Post-process: Filter topQuotes ที่ timestamp เกินช่วง safe
+	chunk.TopQuotes = c.filterTopQuotesSafe(chunk.TopQuotes, safeMomentsFor(input))
 
 	return &chunk, nil
 }
 
 func (c *GeminiClient) generateChunk4V2(ctx context.Context, input *ports.AIInput, coreCtx *CoreContext) (*Chunk4OutputV2, error) {
-	model := c.client.GenerativeModel(c.model)
-	c.configureModel(model)
+	model := c.newChunkModel("chunk4v2")
 	model.ResponseSchema = c.buildChunk4SchemaV2()
 
 	prompt := c.buildChunk4PromptV2(input, coreCtx)
 	prompt = sanitizeUTF8(prompt)
 
-	resp, err := model.GenerateContent(ctx, genai.Text(prompt))
+	resp, err := c.generate(ctx, model, "chunk4v2", prompt)
 	if err != nil {
 		return nil, fmt.Errorf("gemini generate failed: %w", err)
 	}
@@ -512,13 +563,12 @@ func (c *GeminiClient) generateChunk4V2(ctx context.Context, input *ports.AIInpu
 
 	var chunk Chunk4OutputV2
 	if err := json.Unmarshal([]byte(jsonString), &chunk); err != nil {
-		debugPath := fmt.Sprintf("output/chunk4v2_debug_%s.json", input.VideoMetadata.RealCode)
-		_ = writeDebugFile(debugPath, jsonString)
+		c.writeChunkDebug("chunk4v2", input.VideoMetadata.RealCode, jsonString)
 		return nil, fmt.Errorf("failed to parse chunk4v2: %w", err)
 	}
 
 	// Post-process: Sanitize tagDescriptions
-	chunk.TagDescriptions = c.sanitizeTagDescriptions(chunk.TagDescriptions)
+	chunk.TagDescriptions = c.sanitizeTagDescriptions(chunk.TagDescriptions, input.OutputLanguage)
 
 	return &chunk, nil
 }
@@ -531,14 +581,14 @@ func (c *GeminiClient) generateChunk5V2(
 	chunk3 *Chunk3OutputV2,
 	chunk4 *Chunk4OutputV2,
 ) (*Chunk5OutputV2, error) {
-	model := c.client.GenerativeModel(c.model)
-	c.configureModel(model)
+	model := c.newChunkModel("chunk5v2")
 	model.ResponseSchema = c.buildChunk5SchemaV2()
+	restrictContextualLinks(model.ResponseSchema, input.RelatedArticles)
 
 	prompt := c.buildChunk5PromptV2(input, coreCtx, chunk2, chunk3, chunk4)
 	prompt = sanitizeUTF8(prompt)
 
-	resp, err := model.GenerateContent(ctx, genai.Text(prompt))
+	resp, err := c.generate(ctx, model, "chunk5v2", prompt)
 	if err != nil {
 		return nil, fmt.Errorf("gemini generate failed: %w", err)
 	}
@@ -550,8 +600,7 @@ func (c *GeminiClient) generateChunk5V2(
 
 	var chunk Chunk5OutputV2
 	if err := json.Unmarshal([]byte(jsonString), &chunk); err != nil {
-		debugPath := fmt.Sprintf("output/chunk5v2_debug_%s.json", input.VideoMetadata.RealCode)
-		_ = writeDebugFile(debugPath, jsonString)
+		c.writeChunkDebug("chunk5v2", input.VideoMetadata.RealCode, jsonString)
 		return nil, fmt.Errorf("failed to parse chunk5v2: %w", err)
 	}
 
@@ -559,14 +608,13 @@ func (c *GeminiClient) generateChunk5V2(
 }
 
 func (c *GeminiClient) generateChunk6V2(ctx context.Context, input *ports.AIInput, extCtx *ExtendedContext) (*Chunk6OutputV2, error) {
-	model := c.client.GenerativeModel(c.model)
-	c.configureModel(model)
+	model := c.newChunkModel("chunk6v2")
 	model.ResponseSchema = c.buildChunk6SchemaV2()
 
 	prompt := c.buildChunk6PromptV2(input, extCtx)
 	prompt = sanitizeUTF8(prompt)
 
-	resp, err := model.GenerateContent(ctx, genai.Text(prompt))
+	resp, err := c.generate(ctx, model, "chunk6v2", prompt)
 	if err != nil {
 		return nil, fmt.Errorf("gemini generate failed: %w", err)
 	}
@@ -578,28 +626,26 @@ func (c *GeminiClient) generateChunk6V2(ctx context.Context, input *ports.AIInpu
 
 	var chunk Chunk6OutputV2
 	if err := json.Unmarshal([]byte(jsonString), &chunk); err != nil {
-		debugPath := fmt.Sprintf("output/chunk6v2_debug_%s.json", input.VideoMetadata.RealCode)
-		_ = writeDebugFile(debugPath, jsonString)
+		c.writeChunkDebug("chunk6v2", input.VideoMetadata.RealCode, jsonString)
 		return nil, fmt.Errorf("failed to parse chunk6v2: %w", err)
 	}
 
 	// Post-process: Filter keywords และ FAQ
-	chunk.Keywords = c.filterSEOKeywords(chunk.Keywords)
-	chunk.LongTailKeywords = c.filterSEOKeywords(chunk.LongTailKeywords)
-	chunk.FAQItems = c.sanitizeFAQItems(chunk.FAQItems)
+	chunk.Keywords = c.filterSEOKeywords(chunk.Keywords, input.OutputLanguage)
+	chunk.LongTailKeywords = c.filterSEOKeywords(chunk.LongTailKeywords, input.OutputLanguage)
+	chunk.FAQItems = c.sanitizeFAQItems(chunk.FAQItems, input.OutputLanguage)
 
 	return &chunk, nil
 }
 
 func (c *GeminiClient) generateChunk7V2(ctx context.Context, input *ports.AIInput, extCtx *ExtendedContext) (*Chunk7OutputV2, error) {
-	model := c.client.GenerativeModel(c.model)
-	c.configureModel(model)
+	model := c.newChunkModel("chunk7v2")
 	model.ResponseSchema = c.buildChunk7SchemaV2()
 
 	prompt := c.buildChunk7PromptV2(input, extCtx)
 	prompt = sanitizeUTF8(prompt)
 
-	resp, err := model.GenerateContent(ctx, genai.Text(prompt))
+	resp, err := c.generate(ctx, model, "chunk7v2", prompt)
 	if err != nil {
 		return nil, fmt.Errorf("gemini generate failed: %w", err)
 	}
@@ -611,17 +657,16 @@ func (c *GeminiClient) generateChunk7V2(ctx context.Context, input *ports.AIInpu
 
 	var chunk Chunk7OutputV2
 	if err := json.Unmarshal([]byte(jsonString), &chunk); err != nil {
-		debugPath := fmt.Sprintf("output/chunk7v2_debug_%s.json", input.VideoMetadata.RealCode)
-		_ = writeDebugFile(debugPath, jsonString)
+		c.writeChunkDebug("chunk7v2", input.VideoMetadata.RealCode, jsonString)
 		return nil, fmt.Errorf("failed to parse chunk7v2: %w", err)
 	}
 
 	// Post-process: Sanitize all text fields
-	chunk.CinematographyAnalysis = c.sanitizeText(chunk.CinematographyAnalysis)
-	chunk.CharacterJourney = c.sanitizeText(chunk.CharacterJourney)
-	chunk.ThematicExplanation = c.sanitizeText(chunk.ThematicExplanation)
-	chunk.ViewingTips = c.sanitizeText(chunk.ViewingTips)
-	chunk.AudienceMatch = c.sanitizeText(chunk.AudienceMatch)
+	chunk.CinematographyAnalysis = c.sanitizeText(chunk.CinematographyAnalysis, input.OutputLanguage)
+	chunk.CharacterJourney = c.sanitizeText(chunk.CharacterJourney, input.OutputLanguage)
+	chunk.ThematicExplanation = c.sanitizeText(chunk.ThematicExplanation, input.OutputLanguage)
+	chunk.ViewingTips = c.sanitizeText(chunk.ViewingTips, input.OutputLanguage)
+	chunk.AudienceMatch = c.sanitizeText(chunk.AudienceMatch, input.OutputLanguage)
 
 	return &chunk, nil
 }
@@ -652,93 +697,185 @@ func (c *GeminiClient) loadStateV2(videoCode string) (*ChunkStateV2, error) {
 	return &state, nil
 }
 
+// chunkDependenciesV2 chunk ที่ต้องใช้ output ของ chunk อื่นเป็น context (V2)
+// 2,3,4 ใช้ CoreContext (chunk 1), 5 ใช้ 2,3,4, 6/7 ใช้ ExtendedContext (chunk 2 + 4)
+var chunkDependenciesV2 = map[int][]int{
+	2: {1},
+	3: {1},
+	4: {1},
+	5: {2, 3, 4},
+	6: {2, 4},
+	7: {2, 4},
+}
+
+// outputFieldChunksV2 chunk ที่เป็นเจ้าของ field ใน AIOutput (V2)
+var outputFieldChunksV2 = map[string]int{
+	"title":          1,
+	"summary":        1,
+	"galleryAlts":    2,
+	"detailedReview": 4,
+}
+
+// has chunk n อยู่ใน state แล้วหรือยัง
+func (s *ChunkStateV2) has(n int) bool {
+	switch n {
+	case 1:
+		return s.Chunk1 != nil
+	case 2:
+		return s.Chunk2 != nil
+	case 3:
+		return s.Chunk3 != nil
+	case 4:
+		return s.Chunk4 != nil
+	case 5:
+		return s.Chunk5 != nil
+	case 6:
+		return s.Chunk6 != nil
+	case 7:
+		return s.Chunk7 != nil
+	}
+	return false
+}
+
+// completedUpTo chunk สุดท้ายที่มีครบต่อเนื่องจาก chunk 1 (ค่าของ LastChunk)
+func (s *ChunkStateV2) completedUpTo() int {
+	n := 0
+	for n < 7 && s.has(n+1) {
+		n++
+	}
+	return n
+}
+
+// firstMissing chunk แรกที่ยังไม่มีใน state (0 = ครบ)
+func (s *ChunkStateV2) firstMissing() int {
+	if n := s.completedUpTo(); n < 7 {
+		return n + 1
+	}
+	return 0
+}
+
+// dropInvalidChunkV2 ตัด chunk ที่ validation ไม่ผ่านออกจาก state (resume จะ generate ใหม่แค่ chunk นั้น + ที่พึ่งพามัน)
+// chunk 1 ไม่ผ่าน = resume ไม่ได้ (CoreContext มาจาก chunk 1) → ลบ state ให้ job ถัดไป generate ใหม่ทั้งหมด
+func (c *GeminiClient) dropInvalidChunkV2(state *ChunkStateV2, err error) {
+	chunks := invalidChunks(err, outputFieldChunksV2)
+	if len(chunks) == 0 {
+		return
+	}
+	for _, n := range chunks {
+		switch n {
+		case 1:
+			os.Remove(fmt.Sprintf("output/state_%s.json", state.VideoCode))
+			return
+		case 2:
+			state.Chunk2 = nil
+		case 3:
+			state.Chunk3 = nil
+		case 4:
+			state.Chunk4 = nil
+		case 5:
+			state.Chunk5 = nil
+		case 6:
+			state.Chunk6 = nil
+		case 7:
+			state.Chunk7 = nil
+		}
+	}
+	state.LastChunk = state.completedUpTo()
+	state.UpdatedAt = time.Now()
+	c.saveStateV2(state)
+}
+
 // ResumeFromStateV2 ทำต่อจาก state ที่บันทึกไว้
+// generate เฉพาะ chunk ที่ล้มเหลว (ไม่มีใน state) + chunk ที่พึ่งพามัน - chunk อื่นที่บันทึกไว้แล้วใช้ของเดิม
 func (c *GeminiClient) ResumeFromStateV2(ctx context.Context, input *ports.AIInput, videoCode string) (*ports.AIOutput, error) {
+	input = c.guardSRTInput(ctx, input)
+	ctx, usage := withTokenUsage(ctx)
+
 	state, err := c.loadStateV2(videoCode)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load state: %w", err)
 	}
+	if state.Chunk1 == nil || state.CoreContext == nil {
+		return nil, fmt.Errorf("state has no chunk1 - cannot resume")
+	}
+
+	regen := chunksToRegenerate(state.has, chunkDependenciesV2, 7)
+	var regenerated, reused []int
+	for n := 2; n <= 7; n++ {
+		if regen[n] {
+			regenerated = append(regenerated, n)
+		} else {
+			reused = append(reused, n)
+		}
+	}
 
 	c.logger.InfoContext(ctx, "Resuming V2 from saved state",
 		"video_code", videoCode,
 		"last_chunk", state.LastChunk,
+		"regenerate_chunks", regenerated,
+		"reuse_chunks", reused,
 	)
 
-	// Resume based on last completed chunk
-	var chunk2 *Chunk2OutputV2
-	var chunk3 *Chunk3OutputV2
-	var chunk4 *Chunk4OutputV2
-	var chunk5 *Chunk5OutputV2
-	var chunk6 *Chunk6OutputV2
-	var chunk7 *Chunk7OutputV2
-
-	switch state.LastChunk {
-	case 1:
-		// Need Phase 2 + Phase 3 + Phase 4
-		chunk2, chunk3, chunk4, err = c.generateChunks234Parallel(ctx, input, state.CoreContext)
+	// Phase 2: chunk 2,3,4 ที่ต้อง generate ใหม่ (parallel)
+	if regen[2] || regen[3] || regen[4] {
+		err := c.generateChunks234Parallel(ctx, input, state, regen)
+		state.LastChunk = state.completedUpTo()
+		state.UpdatedAt = time.Now()
+		c.saveStateV2(state)
 		if err != nil {
 			return nil, err
 		}
-		state.Chunk2 = chunk2
-		state.Chunk3 = chunk3
-		state.Chunk4 = chunk4
-		state.LastChunk = 4
-		c.saveStateV2(state)
-		fallthrough
-
-	case 4:
-		// Need Phase 3 + Phase 4
-		if state.Chunk2 != nil {
-			chunk2 = state.Chunk2
-		}
-		if state.Chunk3 != nil {
-			chunk3 = state.Chunk3
-		}
-		if state.Chunk4 != nil {
-			chunk4 = state.Chunk4
-		}
+	}
+	state.CoreContext.Entities.Locations = state.Chunk2.SceneLocations
 
-		chunk5, err = c.generateChunk5V2WithRetry(ctx, input, state.CoreContext, chunk2, chunk3, chunk4)
+	// Phase 3: chunk 5
+	if regen[5] {
+		chunk5, err := c.generateChunk5V2WithRetry(ctx, input, state.CoreContext, state.Chunk2, state.Chunk3, state.Chunk4)
 		if err != nil {
 			return nil, err
 		}
 		state.Chunk5 = chunk5
-		state.LastChunk = 5
+		state.LastChunk = state.completedUpTo()
+		state.UpdatedAt = time.Now()
 		c.saveStateV2(state)
-		fallthrough
-
-	case 5:
-		// Need Phase 4
-		if state.Chunk5 != nil {
-			chunk5 = state.Chunk5
-		}
-		extCtx := BuildExtendedContext(state.CoreContext, state.Chunk2, state.Chunk4)
+	}
 
-		chunk6, chunk7, err = c.generateChunks67Parallel(ctx, input, extCtx)
+	// Phase 4: chunk 6,7 ที่ต้อง generate ใหม่ (parallel)
+	state.ExtendedContext = BuildExtendedContext(state.CoreContext, state.Chunk2, state.Chunk4)
+	if regen[6] || regen[7] {
+		err := c.generateChunks67Parallel(ctx, input, state, regen)
+		state.LastChunk = state.completedUpTo()
+		state.UpdatedAt = time.Now()
+		c.saveStateV2(state)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	// Use saved chunks if available
-	if state.Chunk2 != nil && chunk2 == nil {
-		chunk2 = state.Chunk2
-	}
-	if state.Chunk3 != nil && chunk3 == nil {
-		chunk3 = state.Chunk3
-	}
-	if state.Chunk4 != nil && chunk4 == nil {
-		chunk4 = state.Chunk4
-	}
-	if state.Chunk5 != nil && chunk5 == nil {
-		chunk5 = state.Chunk5
+	// Aggregate (chunk ที่ generate ใหม่ + chunk เดิมใน state)
+	output := AggregateChunksV2(state.Chunk1, state.Chunk2, state.Chunk3, state.Chunk4, state.Chunk5, state.Chunk6, state.Chunk7)
+	output.TokenUsage = usage.total() // เฉพาะ chunk ที่ generate ตอน resume
+	if err := ValidateAIOutput(output); err != nil {
+		// ไม่ลบ state file - แก้ schema/prompt แล้ว resume ได้
+		c.logger.ErrorContext(ctx, "Aggregated output validation failed",
+			"video_code", videoCode,
+			"error", err,
+		)
+		c.dropInvalidChunkV2(state, err)
+		return nil, err
 	}
 
-	// Aggregate
-	output := AggregateChunksV2(state.Chunk1, chunk2, chunk3, chunk4, chunk5, chunk6, chunk7)
-
 	// Clean up state file
 	os.Remove(fmt.Sprintf("output/state_%s.json", videoCode))
 
+	c.logger.InfoContext(ctx, "Resume V2 completed",
+		"video_code", videoCode,
+		"regenerated_chunks", regenerated,
+		"prompt_tokens", output.TokenUsage.PromptTokens,
+		"candidate_tokens", output.TokenUsage.CandidateTokens,
+		"total_tokens", output.TokenUsage.TotalTokens,
+		"gemini_calls", output.TokenUsage.Calls,
+	)
+
 	return output, nil
 }
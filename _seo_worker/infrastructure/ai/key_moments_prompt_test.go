@@ -0,0 +1,35 @@
+package ai
+
+import (
+	"testing"
+
+	"seo-worker/domain/models"
+)
+
+func TestKeyMomentsPromptRange(t *testing.T) {
+	defaults := models.SafeMomentSettings{}.WithDefaults()
+
+	tests := []struct {
+		name          string
+		settings      models.SafeMomentSettings
+		videoDuration int
+		minDuration   int
+		wantLo        int
+		wantHi        int
+	}{
+		{"default window fits 10 one-minute moments", defaults, 7200, 60, 5, 10},
+		{"default window with 30s moments capped by max internal", defaults, 7200, 30, 5, 20},
+		{"short window never below min moments", defaults.ForDuration(120), 120, 60, 3, 3},
+		{"disabled uses whole video", models.SafeMomentSettings{Disabled: true}.WithDefaults(), 7200, 60, 5, 20},
+		{"disabled unknown duration", models.SafeMomentSettings{Disabled: true}.WithDefaults(), 0, 60, 5, 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lo, hi := keyMomentsPromptRange(tt.settings, tt.videoDuration, tt.minDuration)
+			if lo != tt.wantLo || hi != tt.wantHi {
+				t.Errorf("keyMomentsPromptRange() = %d-%d, want %d-%d", lo, hi, tt.wantLo, tt.wantHi)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package ai
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+
+	"seo-worker/domain/ports"
+)
+
+// ============================================================================
+// Related Articles - allowlist ของ contextualLinks
+// AI แต่ slug ที่ไม่มีจริงถูกกรองทิ้งทีหลังอยู่แล้ว (filterValidContextualLinks) แต่เปลือง token
+// จึงบอก allowlist ชัดๆ ใน prompt + ล็อก linkedSlug เป็น enum ใน response schema
+// ============================================================================
+
+// relatedArticlesPrompt รายการ Related Articles + allowlist ของ linkedSlug
+func relatedArticlesPrompt(articles []ports.RelatedArticleForAI) string {
+	if len(articles) == 0 {
+		return "⚠️ ไม่มี Related Articles - ให้ส่ง contextualLinks เป็น array ว่าง []\n"
+	}
+
+	var sb strings.Builder
+	slugs := make([]string, len(articles))
+	for i, article := range articles {
+		sb.WriteString(fmt.Sprintf("- Slug: %s, Code: %s, Title: %s\n  Casts: %s, Tags: %s\n",
+			article.Slug, article.RealCode, article.Title,
+			strings.Join(article.CastNames, ", "),
+			strings.Join(article.Tags, ", "),
+		))
+		slugs[i] = fmt.Sprintf("%q", article.Slug)
+	}
+
+	sb.WriteString(fmt.Sprintf("\n✅ linkedSlug ที่ใช้ได้ (ALLOWLIST - copy ตรงตัว): %s\n", strings.Join(slugs, ", ")))
+	sb.WriteString("⚠️ slug อื่นนอกจากนี้จะถูกตัดทิ้งทั้งหมด - linkedTitle ต้องขึ้นต้นด้วย Code ของ slug ที่เลือก\n")
+	return sb.String()
+}
+
+// restrictContextualLinks ล็อก contextualLinks.linkedSlug ใน schema ให้เลือกได้เฉพาะ slug จริง
+// ไม่มี Related Articles = ตัด field ออกจาก schema (ไม่ต้อง generate เลย)
+func restrictContextualLinks(schema *genai.Schema, articles []ports.RelatedArticleForAI) {
+	links, ok := schema.Properties["contextualLinks"]
+	if !ok {
+		return
+	}
+	if len(articles) == 0 {
+		delete(schema.Properties, "contextualLinks")
+		return
+	}
+	if links.Items == nil || links.Items.Properties["linkedSlug"] == nil {
+		return
+	}
+
+	slugs := make([]string, len(articles))
+	for i, article := range articles {
+		slugs[i] = article.Slug
+	}
+	linkedSlug := *links.Items.Properties["linkedSlug"]
+	linkedSlug.Format = "enum"
+	linkedSlug.Enum = slugs
+	links.Items.Properties["linkedSlug"] = &linkedSlug
+}
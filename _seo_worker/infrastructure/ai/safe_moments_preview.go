@@ -0,0 +1,28 @@
+package ai
+
+import (
+	"context"
+
+	"seo-worker/domain/models"
+	"seo-worker/domain/ports"
+)
+
+// PreviewSafeMoments รันการกรอง safe moments แล้วคืนผลทีละขั้น (ไม่ publish อะไร)
+// moments == nil = เรียก chunk 1 ใหม่จาก input.SRTContent
+// ไม่ nil = ใช้ชุดนั้นเป็น input (เช่น key moments จาก AI output ที่เก็บไว้)
+func (c *GeminiClient) PreviewSafeMoments(ctx context.Context, input *ports.AIInput, moments []models.KeyMoment) (*models.SafeMomentsPreview, error) {
+	source := models.SafeMomentsSourceCached
+	if moments == nil {
+		input = c.guardSRTInput(ctx, input)
+		chunk, err := c.generateChunk1Raw(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		moments = chunk.KeyMoments
+		source = models.SafeMomentsSourceGenerated
+	}
+
+	preview := c.traceKeyMomentsSafe(moments, safeMomentsFor(input), input.VideoMetadata.Duration, input.OutputLanguage)
+	preview.Source = source
+	return preview, nil
+}
@@ -0,0 +1,238 @@
+package ai
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"seo-worker/domain/models"
+)
+
+// ============================================================================
+// Language-specific Sanitization Rules
+// ============================================================================
+
+// defaultLanguage ภาษา default ของบทความ (rule set ภาษาไทย)
+const defaultLanguage = models.DefaultOutputLanguage
+
+// sanitizeRules ชุดกฎ content-policy ของแต่ละภาษา
+type sanitizeRules struct {
+	PromptName           string            // ชื่อภาษาที่ใส่ใน prompt (e.g. "English")
+	MinCharsScale        float64           // ตัวคูณ min char count เทียบกับภาษาไทย (แต่ละ script ใช้ตัวอักษรต่อคำไม่เท่ากัน)
+	KeyMomentBlacklist   []string          // คำต้องห้ามใน keyMoments name (ทั้งคำ)
+	KeyMomentStems       []string          // รากคำต้องห้ามใน keyMoments name ("sex" → "sexy", "sexual")
+	SEOKeywordBlacklist  []string          // คำต้องห้ามใน SEO keywords (ทั้งคำ)
+	SEOKeywordStems      []string          // รากคำต้องห้ามใน SEO keywords ("porn" → "porno")
+	ExplicitReplacements map[string]string // คำที่ต้องแทนที่ด้วยคำสุภาพ
+}
+
+// sanitizeRulesByLanguage rule sets แยกตามภาษา (key = ISO 639-1)
+var sanitizeRulesByLanguage = map[string]*sanitizeRules{
+	"th": {
+		PromptName:           "Thai",
+		MinCharsScale:        1.0,
+		KeyMomentBlacklist:   keywordBlacklist,
+		KeyMomentStems:       []string{"sex", "orgasm"},
+		SEOKeywordBlacklist:  seoKeywordBlacklist,
+		SEOKeywordStems:      []string{"porn"},
+		ExplicitReplacements: explicitTermReplacements,
+	},
+	"en": {
+		PromptName:    "English",
+		MinCharsScale: 1.5, // ภาษาอังกฤษใช้ตัวอักษรต่อคำมากกว่า + มีช่องว่างระหว่างคำ
+		KeyMomentBlacklist: []string{
+			"intercourse", "oral",
+			"pussy", "dick", "cock", "tits", "nipple",
+			"cum", "doggy", "cowgirl", "missionary", "69",
+		},
+		KeyMomentStems: []string{
+			"sex", "fuck", "orgasm", "blowjob", "handjob",
+			"creampie", "threesome", "gangbang",
+		},
+		SEOKeywordBlacklist: []string{
+			"xxx", "av", "nsfw",
+			"sex video", "adult video",
+		},
+		SEOKeywordStems: []string{
+			"porn", "fuck", "creampie", "blowjob", "hentai",
+		},
+		ExplicitReplacements: map[string]string{
+			"creampie":        "intimate finale",
+			"sex scene":       "romantic scene",
+			"intercourse":     "intimacy",
+			"genitals":        "private parts",
+			"internal finish": "intimate finale",
+		},
+	},
+	"ja": {
+		PromptName:    "Japanese",
+		MinCharsScale: 0.5, // คันจิ 1 ตัวสื่อความหมายได้มากกว่า
+		KeyMomentBlacklist: []string{
+			"セックス", "エッチ", "フェラ", "中出し", "挿入",
+			"おっぱい", "乳首", "イク", "絶頂",
+			"バック", "騎乗位", "正常位", "3P", "乱交",
+			"cum",
+		},
+		KeyMomentStems: []string{"sex", "orgasm"},
+		SEOKeywordBlacklist: []string{
+			"エロ", "アダルト", "無修正", "ポルノ",
+			"中出し", "フェラ", "xxx", "av",
+		},
+		SEOKeywordStems: []string{"porn"},
+		ExplicitReplacements: map[string]string{
+			"中出し":      "特別なクライマックス",
+			"セックス":     "愛のシーン",
+			"性行為":      "親密なシーン",
+			"性器":       "デリケートな部分",
+			"creampie": "特別なクライマックス",
+		},
+	},
+}
+
+// rulesForLanguage เลือก rule set ตามภาษา
+// รองรับ "th", "TH", "th-TH" - ภาษาที่ไม่รู้จักหรือว่าง → ภาษาไทย
+func rulesForLanguage(lang string) *sanitizeRules {
+	if rules, ok := sanitizeRulesByLanguage[models.NormalizeLanguage(lang)]; ok {
+		return rules
+	}
+	return sanitizeRulesByLanguage[defaultLanguage]
+}
+
+// containsBlacklistedTerm ตรวจว่า text มีคำต้องห้าม (ไม่สนตัวพิมพ์)
+// terms ต้อง match ทั้งคำ ส่วน stems match ที่ต้นคำแล้วต่อท้ายอะไรก็ได้ ("sex" → "sexy" แต่ไม่ match "Sussex")
+func containsBlacklistedTerm(text string, terms, stems []string) bool {
+	for _, term := range terms {
+		if containsTerm(text, term) {
+			return true
+		}
+	}
+	for _, stem := range stems {
+		if containsStem(text, stem) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsTerm หา term ใน text (ไม่สนตัวพิมพ์) โดยขอบของคำต้องไม่ติดกับตัวอักษร script เดียวกัน
+// ละติน/ตัวเลข และคาตาคานะ มีขอบคำ ("av" ไม่ match "available", "イク" ไม่ match "マイク")
+// ไทย/คันจิ/ฮิรางานะ ไม่มีช่องว่างระหว่างคำ จึงยัง match แบบ substring
+func containsTerm(text, term string) bool {
+	return matchTerm(text, term, true)
+}
+
+// containsStem เหมือน containsTerm แต่ไม่ตรวจขอบท้ายคำ (รากคำ + คำต่อท้าย เช่น "sexy", "porno")
+func containsStem(text, stem string) bool {
+	return matchTerm(text, stem, false)
+}
+
+func matchTerm(text, term string, wholeWord bool) bool {
+	if term == "" {
+		return false
+	}
+	text = strings.ToLower(text)
+	term = strings.ToLower(term)
+
+	first, _ := utf8.DecodeRuneInString(term)
+	last, _ := utf8.DecodeLastRuneInString(term)
+
+	for offset := 0; offset < len(text); {
+		i := strings.Index(text[offset:], term)
+		if i < 0 {
+			return false
+		}
+		start := offset + i
+		end := start + len(term)
+
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if !sameWordScript(before, first) && (!wholeWord || !sameWordScript(after, last)) {
+			return true
+		}
+
+		_, size := utf8.DecodeRuneInString(text[start:])
+		offset = start + size
+	}
+	return false
+}
+
+// replaceTermFold แทนที่ term ใน text แบบไม่สนตัวพิมพ์
+// ถ้าคำที่เจอขึ้นต้นด้วยตัวพิมพ์ใหญ่ ("Creampie") คำแทนที่ก็ขึ้นต้นด้วยตัวพิมพ์ใหญ่ ("Intimate finale")
+func replaceTermFold(text, term, replacement string) (string, bool) {
+	if term == "" {
+		return text, false
+	}
+	re := regexp.MustCompile("(?i)" + regexp.QuoteMeta(term))
+	replaced := false
+	result := re.ReplaceAllStringFunc(text, func(match string) string {
+		replaced = true
+		first, _ := utf8.DecodeRuneInString(match)
+		if unicode.IsUpper(first) {
+			return capitalizeFirst(replacement)
+		}
+		return replacement
+	})
+	return result, replaced
+}
+
+func capitalizeFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// sameWordScript r อยู่ในคำเดียวกับ edge หรือไม่ (เฉพาะ script ที่เว้นวรรคระหว่างคำ)
+func sameWordScript(r, edge rune) bool {
+	if r == utf8.RuneError {
+		return false // ต้น/ท้าย text
+	}
+	class := wordScript(edge)
+	return class != 0 && wordScript(r) == class
+}
+
+func wordScript(r rune) int {
+	switch {
+	case unicode.IsDigit(r) || unicode.Is(unicode.Latin, r):
+		return 1
+	case unicode.Is(unicode.Katakana, r) || r == 'ー':
+		return 2
+	default:
+		return 0
+	}
+}
+
+// minCharsFor ปรับ min char count (กำหนดไว้สำหรับภาษาไทย) ตาม script ของภาษา output
+func minCharsFor(thaiMin int, lang string) int {
+	scale := rulesForLanguage(lang).MinCharsScale
+	if scale <= 0 {
+		return thaiMin
+	}
+	return int(math.Round(float64(thaiMin) * scale))
+}
+
+// languageInstruction คำสั่งภาษา output ที่ใส่หน้า prompt ทุก chunk
+// ภาษาไทย (default) → ว่าง เพราะ prompt เขียนสำหรับภาษาไทยอยู่แล้ว
+func languageInstruction(lang string) string {
+	code := models.NormalizeLanguage(lang)
+	if code == defaultLanguage {
+		return ""
+	}
+
+	name := code
+	if rules, ok := sanitizeRulesByLanguage[code]; ok && rules.PromptName != "" {
+		name = rules.PromptName
+	}
+
+	return fmt.Sprintf(`[OUTPUT LANGUAGE]
+⚠️ เขียนเนื้อหาทุก field เป็นภาษา %[1]s (%[2]s) เท่านั้น - คำสั่งด้านล่างเป็นภาษาไทย แต่ output ต้องเป็นภาษา %[1]s
+- ชื่อนักแสดง, รหัสเรื่อง, ชื่อค่าย ใช้ตามต้นฉบับ
+- ห้ามใส่คำว่า "ซับไทย" ใน metaTitle / keywords / FAQ - ใช้คำที่เหมาะกับผู้อ่านภาษา %[1]s แทน
+- ตัวอย่างภาษาไทยในคำสั่งเป็นแค่แนวทาง ให้แปลเป็นภาษา %[1]s
+
+`, name, code)
+}
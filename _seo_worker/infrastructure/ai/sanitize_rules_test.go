@@ -0,0 +1,118 @@
+package ai
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+)
+
+func TestContainsBlacklistedKeyword(t *testing.T) {
+	c := &GeminiClient{}
+
+	tests := []struct {
+		name string
+		lang string
+		text string
+		want bool
+	}{
+		// คำต้องห้ามทั้งคำ
+		{"en whole word", "en", "The oral scene", true},
+		{"en case insensitive", "en", "COWGIRL position", true},
+		{"en punctuation boundary", "en", "cum!", true},
+		{"en number", "en", "69 position", true},
+		{"ja katakana", "ja", "バックで責める", true},
+		{"ja katakana at end", "ja", "最後にイク", true},
+		{"ja kanji substring", "ja", "騎乗位のシーン", true},
+		{"th substring", "th", "ฉากเซ็กส์ในห้องนอน", true},
+		{"th latin in thai text", "th", "ท่าdoggyในห้อง", true},
+
+		// รากคำ - คำต่อท้ายต้องถูกบล็อกด้วย
+		{"en stem sexy", "en", "A sexy evening", true},
+		{"en stem sexual", "en", "Sexual tension", true},
+		{"en stem upper case", "en", "ORGASMIC finale", true},
+		{"ja stem sexy", "ja", "sexyな夜", true},
+		{"th stem sexy", "th", "ชุด sexy", true},
+
+		// คำที่มีคำต้องห้ามเป็นส่วนหนึ่ง - ต้องไม่ถูกบล็อก
+		{"en oral in moral", "en", "A moral dilemma", false},
+		{"en oral in choral", "en", "Choral performance", false},
+		{"en cum in document", "en", "Reading the document", false},
+		{"en cum in cucumber", "en", "Cucumber salad", false},
+		{"en cock in cockpit", "en", "Inside the cockpit", false},
+		{"en cock in peacock", "en", "Peacock feathers", false},
+		{"en sex in sussex", "en", "Trip to Sussex", false},
+		{"en 69 in year", "en", "Released in 1969", false},
+		{"ja イク in マイク", "ja", "マイクを持つ", false},
+		{"ja イク in バイク", "ja", "バイクで出かける", false},
+		{"ja バック in バックステージ", "ja", "バックステージの会話", false},
+		{"ja cum in document", "ja", "documentary", false},
+		{"th oral in latin word", "th", "ฉาก moral ของเรื่อง", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.containsBlacklistedKeyword(tt.text, tt.lang); got != tt.want {
+				t.Errorf("containsBlacklistedKeyword(%q, %q) = %v, want %v", tt.text, tt.lang, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainsSEOBlacklistedKeyword(t *testing.T) {
+	c := &GeminiClient{}
+
+	tests := []struct {
+		name string
+		lang string
+		text string
+		want bool
+	}{
+		{"en av", "en", "JAV av online", true},
+		{"en phrase", "en", "free sex video", true},
+		{"en stem porno", "en", "Porno clips", true},
+		{"en stem hentai", "en", "HentaiHaven", true},
+		{"ja av", "ja", "AV女優", true},
+		{"th porn", "th", "หนังโป๊ญี่ปุ่น", true},
+
+		{"en av in available", "en", "Available now", false},
+		{"en av in javanese", "en", "Javanese culture", false},
+		{"en av in aviation", "en", "aviation drama", false},
+		{"en xxx in longer token", "en", "xxxl shirt", false},
+		{"ja av in travel", "ja", "travel guide", false},
+		{"th av in latin word", "th", "รีวิว avatar", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.containsSEOBlacklistedKeyword(tt.text, tt.lang); got != tt.want {
+				t.Errorf("containsSEOBlacklistedKeyword(%q, %q) = %v, want %v", tt.text, tt.lang, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeTextCaseInsensitive(t *testing.T) {
+	c := &GeminiClient{logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	tests := []struct {
+		name string
+		lang string
+		text string
+		want string
+	}{
+		{"en lower", "en", "the creampie ending", "the intimate finale ending"},
+		{"en capitalized", "en", "Creampie ending", "Intimate finale ending"},
+		{"en upper", "en", "a CREAMPIE ending", "a Intimate finale ending"},
+		{"en phrase mixed case", "en", "The Sex Scene was long", "The Romantic scene was long"},
+		{"en untouched", "en", "A romantic dinner", "A romantic dinner"},
+		{"th latin term", "th", "ฉาก CreamPie", "ฉาก ฉากจบแบบพิเศษ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.sanitizeText(tt.text, tt.lang); got != tt.want {
+				t.Errorf("sanitizeText(%q, %q) = %q, want %q", tt.text, tt.lang, got, tt.want)
+			}
+		})
+	}
+}
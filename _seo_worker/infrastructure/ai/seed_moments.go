@@ -0,0 +1,91 @@
+package ai
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"seo-worker/domain/models"
+)
+
+// ============================================================================
+// Seed Moments - key moments สำรองเมื่อกรองแล้วเหลือไม่ถึง MinMoments
+// แยกตามภาษาของบทความ (key = ISO 639-1) - ภาษาที่ไม่มี template = ไม่เติม seed
+// ============================================================================
+
+// SeedMomentTemplates seed moments ต่อภาษา
+type SeedMomentTemplates map[string][]models.KeyMoment
+
+//go:embed seed_moments.json
+var defaultSeedMomentsJSON []byte
+
+// defaultSeedMoments parse ครั้งเดียว (ใช้เมื่อไม่ได้ SetSeedMoments)
+var defaultSeedMoments = DefaultSeedMomentTemplates()
+
+// DefaultSeedMomentTemplates templates ที่มากับ binary (th, en, ja)
+func DefaultSeedMomentTemplates() SeedMomentTemplates {
+	templates, err := ParseSeedMomentTemplates(defaultSeedMomentsJSON)
+	if err != nil {
+		panic(fmt.Sprintf("invalid embedded seed_moments.json: %v", err))
+	}
+	return templates
+}
+
+// LoadSeedMomentTemplates อ่าน templates จากไฟล์ JSON ({"th": [{"name", "startOffset", "endOffset"}], ...})
+func LoadSeedMomentTemplates(path string) (SeedMomentTemplates, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read seed moments: %w", err)
+	}
+	return ParseSeedMomentTemplates(data)
+}
+
+// ParseSeedMomentTemplates parse + validate templates (key ภาษาถูก normalize)
+func ParseSeedMomentTemplates(data []byte) (SeedMomentTemplates, error) {
+	var raw map[string][]models.KeyMoment
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse seed moments: %w", err)
+	}
+
+	templates := make(SeedMomentTemplates, len(raw))
+	for lang, seeds := range raw {
+		key := models.NormalizeLanguage(lang)
+		if err := validateSeedMoments(seeds); err != nil {
+			return nil, fmt.Errorf("seed moments %q: %w", lang, err)
+		}
+		templates[key] = seeds
+	}
+	return templates, nil
+}
+
+// validateSeedMoments ทุก seed ต้องมีชื่อและ 0 <= start < end
+func validateSeedMoments(seeds []models.KeyMoment) error {
+	var errs []error
+	for i, seed := range seeds {
+		switch {
+		case seed.Name == "":
+			errs = append(errs, fmt.Errorf("[%d] name is required", i))
+		case seed.StartOffset < 0:
+			errs = append(errs, fmt.Errorf("[%d] startOffset %d must be >= 0", i, seed.StartOffset))
+		case seed.EndOffset <= seed.StartOffset:
+			errs = append(errs, fmt.Errorf("[%d] endOffset %d must be > startOffset %d", i, seed.EndOffset, seed.StartOffset))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SetSeedMoments ตั้ง seed templates (nil = ใช้ DefaultSeedMomentTemplates)
+func (c *GeminiClient) SetSeedMoments(templates SeedMomentTemplates) {
+	c.seedMoments = templates
+}
+
+// seedMomentsFor templates ของภาษา lang (ไม่มี = nil, ไม่ fallback ไปภาษาอื่น)
+func (c *GeminiClient) seedMomentsFor(lang string) []models.KeyMoment {
+	templates := c.seedMoments
+	if templates == nil {
+		templates = defaultSeedMoments
+	}
+	return templates[models.NormalizeLanguage(lang)]
+}
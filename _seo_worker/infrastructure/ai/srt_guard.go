@@ -0,0 +1,111 @@
+package ai
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"seo-worker/domain/ports"
+)
+
+// ============================================================================
+// SRT Input Guard - กัน SRT ยาวเกิน input limit ของ Gemini
+// ============================================================================
+//
+// SRT ยาวเกิน MaxChars → ตัดเหลือเฉพาะช่วง safe window (input.SafeMoments.ThresholdSeconds
+// ของ job - ช่วงเดียวกับที่ใช้กรอง key moments) แล้วถ้ายังเกินอีกก็ตัดที่ขอบ cue ให้อยู่ใน MaxChars
+// ============================================================================
+
+const defaultMaxSRTChars = 120000 // ~30-40k tokens สำหรับภาษาไทย/ญี่ปุ่น
+
+// srtCueStartRegex จับเวลาเริ่มของ cue: "00:12:34,567 --> ..."
+var srtCueStartRegex = regexp.MustCompile(`(\d{1,2}):(\d{2}):(\d{2})[,.]\d{1,3}\s*-->`)
+
+// SRTGuardConfig ตั้งค่าการตัด SRT ก่อนส่ง Gemini (zero value = ใช้ค่า default)
+type SRTGuardConfig struct {
+	MaxChars int // ความยาว SRT สูงสุดที่ส่งให้ Gemini (นับเป็นตัวอักษร ไม่ใช่ byte)
+}
+
+// SetSRTGuard ตั้งค่า threshold การตัด SRT
+func (c *GeminiClient) SetSRTGuard(cfg SRTGuardConfig) {
+	if cfg.MaxChars <= 0 {
+		cfg.MaxChars = defaultMaxSRTChars
+	}
+	c.srtGuard = cfg
+}
+
+// guardSRTInput คืน input ที่ SRT ไม่เกิน MaxChars (ไม่แก้ input ของ caller)
+func (c *GeminiClient) guardSRTInput(ctx context.Context, input *ports.AIInput) *ports.AIInput {
+	maxChars := c.srtGuard.MaxChars
+	if maxChars <= 0 {
+		maxChars = defaultMaxSRTChars
+	}
+	originalChars := utf8.RuneCountInString(input.SRTContent)
+	if originalChars <= maxChars {
+		return input
+	}
+
+	// moment ที่เริ่มหลัง threshold ถูกกรองทิ้งอยู่แล้ว → ไม่ต้องส่ง cue ช่วงนั้น
+	// ปิด safe moments = ไม่ตัดตามเวลา (ตัดตาม MaxChars อย่างเดียว)
+	windowSec := 0
+	if settings := input.SafeMoments.WithDefaults(); !settings.Disabled {
+		windowSec = settings.ThresholdSeconds
+	}
+
+	reduced := limitSRT(input.SRTContent, windowSec, maxChars)
+
+	c.logger.WarnContext(ctx, "SRT too long for Gemini, truncated to safe window",
+		"original_chars", originalChars,
+		"reduced_chars", utf8.RuneCountInString(reduced),
+		"max_chars", maxChars,
+		"window_seconds", windowSec,
+	)
+
+	guarded := *input
+	guarded.SRTContent = reduced
+	return &guarded
+}
+
+// limitSRT เก็บ cue ที่เริ่มก่อน windowSec (<= 0 = ไม่จำกัดเวลา) และรวมกันไม่เกิน maxChars
+// ตัดที่ขอบ cue เสมอ (ไม่ตัดกลางประโยค) - cue ที่ parse เวลาไม่ได้ถือว่าอยู่ใน window
+// นับเป็น rune เพราะไทย/ญี่ปุ่น 1 ตัวอักษร = 3 byte (นับ byte จะตัดเหลือแค่ ~1/3 ของ limit)
+func limitSRT(srt string, windowSec, maxChars int) string {
+	blocks := strings.Split(strings.ReplaceAll(srt, "\r\n", "\n"), "\n\n")
+
+	var b strings.Builder
+	chars := 0
+	for _, block := range blocks {
+		block = strings.TrimSpace(block)
+		if block == "" {
+			continue
+		}
+
+		if start, ok := srtCueStart(block); ok && windowSec > 0 && start >= windowSec {
+			break // SRT เรียงตามเวลา → cue ถัดไปก็อยู่นอก window
+		}
+
+		blockChars := utf8.RuneCountInString(block)
+		if chars+blockChars+2 > maxChars {
+			break
+		}
+		chars += blockChars + 2
+		b.WriteString(block)
+		b.WriteString("\n\n")
+	}
+
+	return strings.TrimSpace(b.String())
+}
+
+// srtCueStart คืนเวลาเริ่มของ cue (วินาที)
+func srtCueStart(block string) (int, bool) {
+	m := srtCueStartRegex.FindStringSubmatch(block)
+	if m == nil {
+		return 0, false
+	}
+	h, _ := strconv.Atoi(m[1])
+	minutes, _ := strconv.Atoi(m[2])
+	sec, _ := strconv.Atoi(m[3])
+	return h*3600 + minutes*60 + sec, true
+}
@@ -0,0 +1,50 @@
+package ai
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestLimitSRTCountsRunes(t *testing.T) {
+	// cue ละ 3 บรรทัด: เลข, เวลา, ข้อความไทย 12 ตัวอักษร (36 byte)
+	srt := "1\n00:00:01,000 --> 00:00:02,000\nสวัสดีครับผม\n\n" +
+		"2\n00:00:03,000 --> 00:00:04,000\nสวัสดีครับผม\n\n" +
+		"3\n00:00:05,000 --> 00:00:06,000\nสวัสดีครับผม"
+
+	block := "1\n00:00:01,000 --> 00:00:02,000\nสวัสดีครับผม"
+	blockChars := utf8.RuneCountInString(block)
+	if blockChars >= len(block) {
+		t.Fatalf("test block should contain multi-byte runes")
+	}
+
+	tests := []struct {
+		name      string
+		maxChars  int
+		wantCues  int
+		windowSec int
+	}{
+		{"fits two cues by runes", 2*(blockChars+2) + 1, 2, 0},
+		{"fits all cues", 10 * blockChars, 3, 0},
+		{"too small for one cue", blockChars, 0, 0},
+		{"window cuts before limit", 10 * blockChars, 2, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := limitSRT(srt, tt.windowSec, tt.maxChars)
+			if n := utf8.RuneCountInString(got); n > tt.maxChars {
+				t.Errorf("limitSRT() = %d chars, want <= %d", n, tt.maxChars)
+			}
+			cues := 0
+			for _, b := range strings.Split(got, "\n\n") {
+				if _, ok := srtCueStart(b); ok {
+					cues++
+				}
+			}
+			if cues != tt.wantCues {
+				t.Errorf("limitSRT() kept %d cues, want %d", cues, tt.wantCues)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// chdirTemp ย้าย working dir ไป temp dir (saveState เขียนลง output/ แบบ relative)
+func chdirTemp(t *testing.T) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+func sortedKeys(m map[int]bool) []int {
+	keys := []int{}
+	for k, v := range m {
+		if v {
+			keys = append(keys, k)
+		}
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+func hasChunks(chunks ...int) func(int) bool {
+	set := make(map[int]bool)
+	for _, n := range chunks {
+		set[n] = true
+	}
+	return func(n int) bool { return set[n] }
+}
+
+func TestChunksToRegenerate(t *testing.T) {
+	tests := []struct {
+		name      string
+		has       []int
+		deps      map[int][]int
+		lastChunk int
+		want      []int
+	}{
+		{"v1 complete", []int{1, 2, 3, 4}, chunkDependencies, 4, []int{}},
+		{"v1 only chunk1", []int{1}, chunkDependencies, 4, []int{2, 3, 4}},
+		{"v1 chunk3 failed", []int{1, 2, 4}, chunkDependencies, 4, []int{3}},
+		{"v1 chunk2 missing forces chunk4", []int{1, 3, 4}, chunkDependencies, 4, []int{2, 4}},
+		{"v1 chunk4 failed", []int{1, 2, 3}, chunkDependencies, 4, []int{4}},
+
+		{"v2 complete", []int{1, 2, 3, 4, 5, 6, 7}, chunkDependenciesV2, 7, []int{}},
+		{"v2 only chunk1", []int{1}, chunkDependenciesV2, 7, []int{2, 3, 4, 5, 6, 7}},
+		{"v2 chunk3 failed", []int{1, 2, 4, 5, 6, 7}, chunkDependenciesV2, 7, []int{3, 5}},
+		{"v2 chunk2 missing", []int{1, 3, 4, 5, 6, 7}, chunkDependenciesV2, 7, []int{2, 5, 6, 7}},
+		{"v2 chunk4 missing", []int{1, 2, 3, 5, 6, 7}, chunkDependenciesV2, 7, []int{4, 5, 6, 7}},
+		{"v2 chunk5 failed", []int{1, 2, 3, 4}, chunkDependenciesV2, 7, []int{5, 6, 7}},
+		{"v2 chunk7 failed", []int{1, 2, 3, 4, 5, 6}, chunkDependenciesV2, 7, []int{7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sortedKeys(chunksToRegenerate(hasChunks(tt.has...), tt.deps, tt.lastChunk))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("chunksToRegenerate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInvalidChunks(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		fieldChunks map[string]int
+		want        []int
+	}{
+		{"nil", nil, outputFieldChunks, nil},
+		{"other error", fmt.Errorf("boom"), outputFieldChunks, nil},
+		{"validation error", &ValidationError{Chunk: 3, Field: "faqItems"}, outputFieldChunks, []int{3}},
+		{"wrapped validation error", fmt.Errorf("wrap: %w", &ValidationError{Chunk: 4}), outputFieldChunks, []int{4}},
+		{"v1 detailedReview", &IncompleteOutputError{Missing: []string{"detailedReview"}}, outputFieldChunks, []int{2}},
+		{"v1 chunk1 fields deduped", &IncompleteOutputError{Missing: []string{"title", "summary", "galleryAlts"}}, outputFieldChunks, []int{1}},
+		{"v2 galleryAlts + detailedReview", &IncompleteOutputError{Missing: []string{"galleryAlts", "detailedReview"}}, outputFieldChunksV2, []int{2, 4}},
+		{"unknown field", &IncompleteOutputError{Missing: []string{"output"}}, outputFieldChunksV2, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := invalidChunks(tt.err, tt.fieldChunks)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("invalidChunks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDropInvalidChunk(t *testing.T) {
+	chdirTemp(t)
+	c := &GeminiClient{}
+
+	full := func() *ChunkState {
+		return &ChunkState{
+			VideoCode: "ABC-123",
+			Chunk1:    &Chunk1Output{},
+			Chunk2:    &Chunk2Output{},
+			Chunk3:    &Chunk3Output{},
+			Chunk4:    &Chunk4Output{},
+			LastChunk: 4,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		err      error
+		wantHas  []int
+		wantLast int
+	}{
+		{"unrelated error keeps state", fmt.Errorf("boom"), []int{1, 2, 3, 4}, 4},
+		{"detailedReview drops chunk2", &IncompleteOutputError{Missing: []string{"detailedReview"}}, []int{1, 3, 4}, 1},
+		{"validation error drops chunk4", &ValidationError{Chunk: 4}, []int{1, 2, 3}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := full()
+			c.dropInvalidChunk(state, tt.err)
+
+			var got []int
+			for n := 1; n <= 4; n++ {
+				if state.has(n) {
+					got = append(got, n)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.wantHas) {
+				t.Errorf("chunks in state = %v, want %v", got, tt.wantHas)
+			}
+			if state.LastChunk != tt.wantLast {
+				t.Errorf("LastChunk = %d, want %d", state.LastChunk, tt.wantLast)
+			}
+		})
+	}
+}
+
+func TestDropInvalidChunkV2(t *testing.T) {
+	chdirTemp(t)
+	c := &GeminiClient{}
+	statePath := "output/state_ABC-123.json"
+
+	full := func() *ChunkStateV2 {
+		return &ChunkStateV2{
+			VideoCode: "ABC-123",
+			Chunk1:    &Chunk1OutputV2{},
+			Chunk2:    &Chunk2OutputV2{},
+			Chunk3:    &Chunk3OutputV2{},
+			Chunk4:    &Chunk4OutputV2{},
+			Chunk5:    &Chunk5OutputV2{},
+			Chunk6:    &Chunk6OutputV2{},
+			Chunk7:    &Chunk7OutputV2{},
+			LastChunk: 7,
+		}
+	}
+
+	t.Run("detailedReview drops chunk4 and saves state", func(t *testing.T) {
+		state := full()
+		c.dropInvalidChunkV2(state, &IncompleteOutputError{Missing: []string{"detailedReview"}})
+
+		if state.Chunk4 != nil {
+			t.Fatal("chunk4 should be dropped")
+		}
+		if state.LastChunk != 3 {
+			t.Errorf("LastChunk = %d, want 3", state.LastChunk)
+		}
+
+		data, err := os.ReadFile(statePath)
+		if err != nil {
+			t.Fatalf("state not saved: %v", err)
+		}
+		var saved ChunkStateV2
+		if err := json.Unmarshal(data, &saved); err != nil {
+			t.Fatal(err)
+		}
+		if saved.Chunk4 != nil || saved.Chunk7 == nil {
+			t.Error("saved state should drop only chunk4")
+		}
+		if got := sortedKeys(chunksToRegenerate(saved.has, chunkDependenciesV2, 7)); !reflect.DeepEqual(got, []int{4, 5, 6, 7}) {
+			t.Errorf("resume would regenerate %v, want [4 5 6 7]", got)
+		}
+	})
+
+	t.Run("chunk1 field removes state file", func(t *testing.T) {
+		state := full()
+		c.saveStateV2(state)
+		c.dropInvalidChunkV2(state, &IncompleteOutputError{Missing: []string{"title"}})
+
+		if _, err := os.Stat(statePath); !os.IsNotExist(err) {
+			t.Errorf("state file should be removed, stat err = %v", err)
+		}
+	})
+}
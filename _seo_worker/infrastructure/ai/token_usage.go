@@ -0,0 +1,75 @@
+package ai
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/generative-ai-go/genai"
+
+	"seo-worker/domain/ports"
+)
+
+// tokenUsageKey context key ของ accumulator ต่อบทความ
+type tokenUsageKey struct{}
+
+// tokenUsageAccumulator รวม token usage ของทุก chunk ในบทความเดียว
+// chunk 2-4 และ 6-7 รัน parallel จึงต้องมี mutex
+type tokenUsageAccumulator struct {
+	mu    sync.Mutex
+	usage ports.TokenUsage
+}
+
+func (a *tokenUsageAccumulator) add(u ports.TokenUsage) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.usage.PromptTokens += u.PromptTokens
+	a.usage.CandidateTokens += u.CandidateTokens
+	a.usage.TotalTokens += u.TotalTokens
+	a.usage.Calls += u.Calls
+}
+
+func (a *tokenUsageAccumulator) total() ports.TokenUsage {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.usage
+}
+
+// withTokenUsage แนบ accumulator ใหม่ไปกับ ctx (เรียกที่ต้น pipeline ของแต่ละบทความ)
+func withTokenUsage(ctx context.Context) (context.Context, *tokenUsageAccumulator) {
+	acc := &tokenUsageAccumulator{}
+	return context.WithValue(ctx, tokenUsageKey{}, acc), acc
+}
+
+// usageFromResponse อ่าน UsageMetadata จาก response (ไม่มี = ค่า 0 แต่นับเป็น 1 call)
+func usageFromResponse(resp *genai.GenerateContentResponse) ports.TokenUsage {
+	usage := ports.TokenUsage{Calls: 1}
+	if resp == nil || resp.UsageMetadata == nil {
+		return usage
+	}
+	usage.PromptTokens = int64(resp.UsageMetadata.PromptTokenCount)
+	usage.CandidateTokens = int64(resp.UsageMetadata.CandidatesTokenCount)
+	usage.TotalTokens = int64(resp.UsageMetadata.TotalTokenCount)
+	return usage
+}
+
+// recordTokenUsage log usage ของ chunk และบวกเข้า accumulator ใน ctx (ถ้ามี)
+// finish_reason ช่วยดูว่า chunk ที่ token สูงถูกตัด (MAX_TOKENS) หรือไม่
+func (c *GeminiClient) recordTokenUsage(ctx context.Context, chunk string, resp *genai.GenerateContentResponse) {
+	usage := usageFromResponse(resp)
+
+	finishReason := ""
+	if resp != nil && len(resp.Candidates) > 0 {
+		finishReason = resp.Candidates[0].FinishReason.String()
+	}
+	c.logger.InfoContext(ctx, "Gemini token usage",
+		"chunk", chunk,
+		"prompt_tokens", usage.PromptTokens,
+		"candidate_tokens", usage.CandidateTokens,
+		"total_tokens", usage.TotalTokens,
+		"finish_reason", finishReason,
+	)
+
+	if acc, ok := ctx.Value(tokenUsageKey{}).(*tokenUsageAccumulator); ok {
+		acc.add(usage)
+	}
+}
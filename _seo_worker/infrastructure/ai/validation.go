@@ -7,6 +7,7 @@ import (
 	"unicode"
 
 	"seo-worker/domain/models"
+	"seo-worker/domain/ports"
 )
 
 // ============================================================================
@@ -333,16 +334,16 @@ func validateNameSpam(text string, casts []models.CastMetadata, maxPerHundredWor
 // ============================================================================
 
 // ValidateChunk1V2 validates Chunk 1 output
-func ValidateChunk1V2(chunk *Chunk1OutputV2) *ValidationResult {
+func ValidateChunk1V2(chunk *Chunk1OutputV2, lang string) *ValidationResult {
 	result := &ValidationResult{}
 
 	// summary length
 	summaryRunes := len([]rune(chunk.Summary))
-	if summaryRunes < 800 {
+	if minSummary := minCharsFor(800, lang); summaryRunes < minSummary {
 		result.Errors = append(result.Errors, ValidationError{
 			Chunk:   1,
 			Field:   "summary",
-			Message: fmt.Sprintf("summary สั้นเกินไป (%d ตัวอักษร, min 800)", summaryRunes),
+			Message: fmt.Sprintf("summary สั้นเกินไป (%d ตัวอักษร, min %d)", summaryRunes, minSummary),
 		})
 	}
 
@@ -416,16 +417,16 @@ func ValidateChunk2V2(chunk *Chunk2OutputV2, casts []models.CastMetadata) *Valid
 }
 
 // ValidateChunk4V2 validates Chunk 4 output
-func ValidateChunk4V2(chunk *Chunk4OutputV2, casts []models.CastMetadata) *ValidationResult {
+func ValidateChunk4V2(chunk *Chunk4OutputV2, casts []models.CastMetadata, lang string) *ValidationResult {
 	result := &ValidationResult{}
 
 	// detailedReview length
 	reviewRunes := len([]rune(chunk.DetailedReview))
-	if reviewRunes < 1000 {
+	if minReview := minCharsFor(1000, lang); reviewRunes < minReview {
 		result.Errors = append(result.Errors, ValidationError{
 			Chunk:   4,
 			Field:   "detailedReview",
-			Message: fmt.Sprintf("detailedReview สั้นเกินไป (%d ตัวอักษร, min 1000)", reviewRunes),
+			Message: fmt.Sprintf("detailedReview สั้นเกินไป (%d ตัวอักษร, min %d)", reviewRunes, minReview),
 		})
 	}
 
@@ -442,11 +443,11 @@ func ValidateChunk4V2(chunk *Chunk4OutputV2, casts []models.CastMetadata) *Valid
 	}
 
 	// expertAnalysis
-	if len([]rune(chunk.ExpertAnalysis)) < 100 {
+	if minExpert := minCharsFor(100, lang); len([]rune(chunk.ExpertAnalysis)) < minExpert {
 		result.Errors = append(result.Errors, ValidationError{
 			Chunk:   4,
 			Field:   "expertAnalysis",
-			Message: "expertAnalysis สั้นเกินไป (min 100 ตัวอักษร)",
+			Message: fmt.Sprintf("expertAnalysis สั้นเกินไป (min %d ตัวอักษร)", minExpert),
 		})
 	}
 
@@ -489,16 +490,16 @@ func ValidateChunk6V2(chunk *Chunk6OutputV2, casts []models.CastMetadata) *Valid
 }
 
 // ValidateChunk7V2 validates Chunk 7 output
-func ValidateChunk7V2(chunk *Chunk7OutputV2, casts []models.CastMetadata) *ValidationResult {
+func ValidateChunk7V2(chunk *Chunk7OutputV2, casts []models.CastMetadata, lang string) *ValidationResult {
 	result := &ValidationResult{}
 
 	// cinematographyAnalysis length
 	cinemaRunes := len([]rune(chunk.CinematographyAnalysis))
-	if cinemaRunes < 500 {
+	if minCinema := minCharsFor(500, lang); cinemaRunes < minCinema {
 		result.Errors = append(result.Errors, ValidationError{
 			Chunk:   7,
 			Field:   "cinematographyAnalysis",
-			Message: fmt.Sprintf("cinematographyAnalysis สั้นเกินไป (%d ตัวอักษร, min 500)", cinemaRunes),
+			Message: fmt.Sprintf("cinematographyAnalysis สั้นเกินไป (%d ตัวอักษร, min %d)", cinemaRunes, minCinema),
 		})
 	}
 
@@ -516,11 +517,11 @@ func ValidateChunk7V2(chunk *Chunk7OutputV2, casts []models.CastMetadata) *Valid
 
 	// characterJourney length
 	journeyRunes := len([]rune(chunk.CharacterJourney))
-	if journeyRunes < 600 {
+	if minJourney := minCharsFor(600, lang); journeyRunes < minJourney {
 		result.Errors = append(result.Errors, ValidationError{
 			Chunk:   7,
 			Field:   "characterJourney",
-			Message: fmt.Sprintf("characterJourney สั้นเกินไป (%d ตัวอักษร, min 600)", journeyRunes),
+			Message: fmt.Sprintf("characterJourney สั้นเกินไป (%d ตัวอักษร, min %d)", journeyRunes, minJourney),
 		})
 	}
 
@@ -551,3 +552,51 @@ func ValidateChunk7V2(chunk *Chunk7OutputV2, casts []models.CastMetadata) *Valid
 
 	return result
 }
+
+// ============================================================================
+// Aggregated Output Validation
+// ============================================================================
+
+// IncompleteOutputError AIOutput หลัง aggregate ขาด field ที่จำเป็นต่อการสร้างบทความ
+type IncompleteOutputError struct {
+	Missing []string // ชื่อ field (json) ที่ว่าง
+}
+
+func (e *IncompleteOutputError) Error() string {
+	return fmt.Sprintf("incomplete AI output: empty required fields: %s", strings.Join(e.Missing, ", "))
+}
+
+// ValidateAIOutput ตรวจ field สำคัญหลัง aggregate (title, summary, detailedReview, galleryAlts อย่างน้อย 1)
+// กันกรณี chunk "สำเร็จ" แต่ field หายเพราะ schema drift → job fail ก่อน build/publish บทความไม่ครบ
+func ValidateAIOutput(output *ports.AIOutput) error {
+	if output == nil {
+		return &IncompleteOutputError{Missing: []string{"output"}}
+	}
+
+	var missing []string
+	if strings.TrimSpace(output.Title) == "" {
+		missing = append(missing, "title")
+	}
+	if strings.TrimSpace(output.Summary) == "" {
+		missing = append(missing, "summary")
+	}
+	if strings.TrimSpace(output.DetailedReview) == "" {
+		missing = append(missing, "detailedReview")
+	}
+
+	hasAlt := false
+	for _, alt := range output.GalleryAlts {
+		if strings.TrimSpace(alt) != "" {
+			hasAlt = true
+			break
+		}
+	}
+	if !hasAlt {
+		missing = append(missing, "galleryAlts")
+	}
+
+	if len(missing) > 0 {
+		return &IncompleteOutputError{Missing: missing}
+	}
+	return nil
+}
@@ -10,6 +10,8 @@ import (
 	"net/http"
 	"sync"
 	"time"
+
+	"seo-worker/infrastructure/circuitbreaker"
 )
 
 // AuthClient จัดการ authentication สำหรับ API
@@ -40,15 +42,14 @@ type loginResponse struct {
 	Error string `json:"error,omitempty"`
 }
 
-func NewAuthClient(apiURL, email, password string) *AuthClient {
+// breaker = circuit breaker ของ API ปลายทาง (nil = ไม่ใช้)
+func NewAuthClient(apiURL, email, password string, breaker *circuitbreaker.Breaker) *AuthClient {
 	return &AuthClient{
-		apiURL:   apiURL,
-		email:    email,
-		password: password,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		logger: slog.Default().With("component", "auth_client"),
+		apiURL:     apiURL,
+		email:      email,
+		password:   password,
+		httpClient: circuitbreaker.NewHTTPClient(30*time.Second, breaker),
+		logger:     slog.Default().With("component", "auth_client"),
 	}
 }
 
@@ -108,6 +109,9 @@ func (c *AuthClient) login(ctx context.Context) (string, error) {
 		}
 
 		lastErr = err
+		if circuitbreaker.IsOpen(err) {
+			break // downstream ล่ม ไม่ต้อง retry
+		}
 		c.logger.WarnContext(ctx, "Login request failed, retrying",
 			"attempt", i+1,
 			"error", err,
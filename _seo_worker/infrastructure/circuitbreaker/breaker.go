@@ -0,0 +1,205 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// ErrOpen คืนเมื่อ breaker เปิดอยู่ (downstream ล่ม) - request ถูกตัดทันทีไม่รอ timeout
+var ErrOpen = errors.New("circuit breaker is open")
+
+// State สถานะของ breaker
+type State int
+
+const (
+	StateClosed   State = iota // ปกติ - ปล่อยทุก request
+	StateOpen                  // downstream ล่ม - ตัดทุก request จนครบ OpenTimeout
+	StateHalfOpen              // ทดลองปล่อย request จำนวนจำกัดเพื่อเช็คว่า downstream กลับมาหรือยัง
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+const (
+	defaultFailureThreshold    = 5
+	defaultOpenTimeout         = 30 * time.Second
+	defaultHalfOpenMaxRequests = 1
+)
+
+// Config ค่าตั้งของ breaker (ค่า <= 0 = ใช้ default)
+type Config struct {
+	Name                string        // ชื่อ downstream (ใช้ใน log) เช่น "suekk_api"
+	FailureThreshold    int           // fail ติดกันกี่ครั้งถึงเปิด breaker (default 5)
+	OpenTimeout         time.Duration // เปิดค้างไว้นานเท่าไหร่ก่อนลอง half-open (default 30s)
+	HalfOpenMaxRequests int           // จำนวน request ทดลองพร้อมกันตอน half-open (default 1)
+}
+
+// Stats snapshot สำหรับ log/metrics
+type Stats struct {
+	Name                string    `json:"name"`
+	State               string    `json:"state"`
+	ConsecutiveFailures int       `json:"consecutiveFailures"`
+	TotalSuccesses      int64     `json:"totalSuccesses"`
+	TotalFailures       int64     `json:"totalFailures"`
+	TotalRejected       int64     `json:"totalRejected"` // request ที่ถูกตัดเพราะ breaker เปิด
+	OpenedAt            time.Time `json:"openedAt,omitempty"`
+}
+
+// Breaker circuit breaker แบบ closed → open → half-open
+// ใช้ร่วมกันได้หลาย client ที่เรียก downstream เดียวกัน (thread-safe)
+type Breaker struct {
+	cfg    Config
+	logger *slog.Logger
+	now    func() time.Time
+
+	mu               sync.Mutex
+	state            State
+	failures         int
+	openedAt         time.Time
+	halfOpenInFlight int
+	successes        int64
+	totalFailures    int64
+	rejected         int64
+}
+
+func New(cfg Config) *Breaker {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = defaultFailureThreshold
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = defaultOpenTimeout
+	}
+	if cfg.HalfOpenMaxRequests <= 0 {
+		cfg.HalfOpenMaxRequests = defaultHalfOpenMaxRequests
+	}
+	return &Breaker{
+		cfg:    cfg,
+		logger: slog.Default().With("component", "circuit_breaker", "breaker", cfg.Name),
+		now:    time.Now,
+	}
+}
+
+// Name ชื่อ downstream
+func (b *Breaker) Name() string {
+	return b.cfg.Name
+}
+
+// Allow ตรวจว่าปล่อย request ได้หรือไม่ - ถ้าได้ ต้องเรียก Done ตามหลังเสมอ
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if b.now().Sub(b.openedAt) < b.cfg.OpenTimeout {
+			b.rejected++
+			return ErrOpen
+		}
+		b.setState(StateHalfOpen)
+		fallthrough
+	case StateHalfOpen:
+		if b.halfOpenInFlight >= b.cfg.HalfOpenMaxRequests {
+			b.rejected++
+			return ErrOpen
+		}
+		b.halfOpenInFlight++
+	}
+	return nil
+}
+
+// Done บันทึกผลของ request ที่ผ่าน Allow
+// counted=false = ผลที่ไม่ได้บอกสุขภาพของ downstream (เช่น caller ยกเลิก context)
+func (b *Breaker) Done(success, counted bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wasHalfOpen := b.state == StateHalfOpen
+	if wasHalfOpen && b.halfOpenInFlight > 0 {
+		b.halfOpenInFlight--
+	}
+	if !counted {
+		return
+	}
+
+	if success {
+		b.successes++
+		b.failures = 0
+		if wasHalfOpen {
+			b.setState(StateClosed)
+		}
+		return
+	}
+
+	b.totalFailures++
+	b.failures++
+	if wasHalfOpen || (b.state == StateClosed && b.failures >= b.cfg.FailureThreshold) {
+		b.openedAt = b.now()
+		b.setState(StateOpen)
+	}
+}
+
+// State สถานะปัจจุบัน
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// Stats snapshot ของ counters
+func (b *Breaker) Stats() Stats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stats := Stats{
+		Name:                b.cfg.Name,
+		State:               b.state.String(),
+		ConsecutiveFailures: b.failures,
+		TotalSuccesses:      b.successes,
+		TotalFailures:       b.totalFailures,
+		TotalRejected:       b.rejected,
+	}
+	if b.state != StateClosed {
+		stats.OpenedAt = b.openedAt
+	}
+	return stats
+}
+
+// setState เปลี่ยนสถานะ + log (ต้องถือ mu อยู่)
+func (b *Breaker) setState(next State) {
+	if b.state == next {
+		return
+	}
+	prev := b.state
+	b.state = next
+	if next == StateClosed {
+		b.halfOpenInFlight = 0
+	}
+
+	attrs := []any{
+		"from", prev.String(),
+		"to", next.String(),
+		"consecutive_failures", b.failures,
+		"total_failures", b.totalFailures,
+		"total_rejected", b.rejected,
+	}
+	switch next {
+	case StateOpen:
+		b.logger.Warn("Circuit breaker opened", append(attrs, "open_timeout", b.cfg.OpenTimeout)...)
+	case StateHalfOpen:
+		b.logger.Info("Circuit breaker half-open, probing downstream", attrs...)
+	case StateClosed:
+		b.logger.Info("Circuit breaker closed, downstream recovered", attrs...)
+	}
+}
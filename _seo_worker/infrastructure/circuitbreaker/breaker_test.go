@@ -0,0 +1,183 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newTestBreaker breaker ที่คุมเวลาได้ (advance เลื่อนนาฬิกา)
+func newTestBreaker(threshold int, openTimeout time.Duration) (*Breaker, func(time.Duration)) {
+	b := New(Config{Name: "test", FailureThreshold: threshold, OpenTimeout: openTimeout})
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	b.now = func() time.Time { return now }
+	return b, func(d time.Duration) { now = now.Add(d) }
+}
+
+func fail(t *testing.T, b *Breaker) {
+	t.Helper()
+	if err := b.Allow(); err != nil {
+		t.Fatalf("Allow: %v", err)
+	}
+	b.Done(false, true)
+}
+
+func TestBreaker_OpensAfterThreshold(t *testing.T) {
+	b, _ := newTestBreaker(3, time.Minute)
+
+	fail(t, b)
+	fail(t, b)
+	if b.State() != StateClosed {
+		t.Fatalf("state = %s, want closed before threshold", b.State())
+	}
+
+	fail(t, b)
+	if b.State() != StateOpen {
+		t.Fatalf("state = %s, want open after threshold", b.State())
+	}
+	if err := b.Allow(); !errors.Is(err, ErrOpen) {
+		t.Fatalf("Allow while open = %v, want ErrOpen", err)
+	}
+	if got := b.Stats().TotalRejected; got != 1 {
+		t.Errorf("rejected = %d, want 1", got)
+	}
+}
+
+func TestBreaker_SuccessResetsFailures(t *testing.T) {
+	b, _ := newTestBreaker(2, time.Minute)
+
+	fail(t, b)
+	if err := b.Allow(); err != nil {
+		t.Fatal(err)
+	}
+	b.Done(true, true)
+	fail(t, b)
+
+	if b.State() != StateClosed {
+		t.Fatalf("state = %s, want closed (failures not consecutive)", b.State())
+	}
+}
+
+func TestBreaker_HalfOpenClosesOnSuccess(t *testing.T) {
+	b, advance := newTestBreaker(1, time.Minute)
+	fail(t, b)
+
+	advance(59 * time.Second)
+	if err := b.Allow(); !errors.Is(err, ErrOpen) {
+		t.Fatalf("Allow before open timeout = %v, want ErrOpen", err)
+	}
+
+	advance(time.Second)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("probe Allow = %v, want nil", err)
+	}
+	if b.State() != StateHalfOpen {
+		t.Fatalf("state = %s, want half-open", b.State())
+	}
+
+	// half-open ปล่อยได้แค่ HalfOpenMaxRequests (default 1) พร้อมกัน
+	if err := b.Allow(); !errors.Is(err, ErrOpen) {
+		t.Fatalf("second probe = %v, want ErrOpen", err)
+	}
+
+	b.Done(true, true)
+	if b.State() != StateClosed {
+		t.Fatalf("state = %s, want closed after successful probe", b.State())
+	}
+	if err := b.Allow(); err != nil {
+		t.Fatalf("Allow after close = %v", err)
+	}
+	b.Done(true, true)
+}
+
+func TestBreaker_HalfOpenReopensOnFailure(t *testing.T) {
+	b, advance := newTestBreaker(1, time.Minute)
+	fail(t, b)
+
+	advance(time.Minute)
+	fail(t, b) // probe ล้มเหลว
+	if b.State() != StateOpen {
+		t.Fatalf("state = %s, want open after failed probe", b.State())
+	}
+
+	// เริ่มนับ open timeout ใหม่จากตอน probe ล้มเหลว
+	advance(30 * time.Second)
+	if err := b.Allow(); !errors.Is(err, ErrOpen) {
+		t.Fatalf("Allow = %v, want ErrOpen", err)
+	}
+	advance(30 * time.Second)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("Allow after new timeout = %v", err)
+	}
+}
+
+func TestBreaker_UncountedDoneReleasesProbe(t *testing.T) {
+	b, advance := newTestBreaker(1, time.Minute)
+	fail(t, b)
+	advance(time.Minute)
+
+	if err := b.Allow(); err != nil {
+		t.Fatal(err)
+	}
+	b.Done(false, false) // caller ยกเลิกเอง - ไม่นับผล
+
+	if b.State() != StateHalfOpen {
+		t.Fatalf("state = %s, want still half-open", b.State())
+	}
+	if err := b.Allow(); err != nil {
+		t.Fatalf("probe slot not released: %v", err)
+	}
+}
+
+func TestTransport_CountsServerErrorsOnly(t *testing.T) {
+	status := http.StatusInternalServerError
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	b, _ := newTestBreaker(2, time.Minute)
+	client := NewHTTPClient(5*time.Second, b)
+
+	get := func() error {
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		return nil
+	}
+
+	// 4xx = downstream ยังตอบได้ → ไม่นับเป็น failure
+	status = http.StatusNotFound
+	for i := 0; i < 3; i++ {
+		if err := get(); err != nil {
+			t.Fatalf("4xx request: %v", err)
+		}
+	}
+	if b.State() != StateClosed {
+		t.Fatalf("state = %s after 4xx, want closed", b.State())
+	}
+
+	status = http.StatusBadGateway
+	_ = get()
+	_ = get()
+	if b.State() != StateOpen {
+		t.Fatalf("state = %s after 5xx, want open", b.State())
+	}
+	if err := get(); !IsOpen(err) {
+		t.Fatalf("request while open = %v, want ErrOpen", err)
+	}
+}
+
+func TestNewHTTPClient_NilBreaker(t *testing.T) {
+	client := NewHTTPClient(time.Second, nil)
+	if client.Transport != nil {
+		t.Errorf("transport = %T, want default", client.Transport)
+	}
+	if client.Timeout != time.Second {
+		t.Errorf("timeout = %v, want 1s", client.Timeout)
+	}
+}
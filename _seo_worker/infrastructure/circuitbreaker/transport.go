@@ -0,0 +1,53 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Transport http.RoundTripper ที่ผ่าน breaker ก่อนส่ง request
+// network error / 5xx = fail, อื่นๆ (รวม 4xx) = success เพราะ downstream ยังตอบได้
+type Transport struct {
+	Breaker *Breaker
+	Base    http.RoundTripper // nil = http.DefaultTransport
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.Breaker.Allow(); err != nil {
+		return nil, fmt.Errorf("%s: %w", t.Breaker.Name(), err)
+	}
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		// caller ยกเลิกเอง ไม่ได้แปลว่า downstream มีปัญหา
+		canceled := errors.Is(err, context.Canceled) || errors.Is(req.Context().Err(), context.Canceled)
+		t.Breaker.Done(false, !canceled)
+		return nil, err
+	}
+
+	t.Breaker.Done(resp.StatusCode < http.StatusInternalServerError, true)
+	return resp, nil
+}
+
+// NewHTTPClient สร้าง http.Client ที่ส่ง request ผ่าน breaker (b == nil = client ปกติ)
+// client หลายตัวที่เรียก API เดียวกันควรใช้ breaker ตัวเดียวกัน
+func NewHTTPClient(timeout time.Duration, b *Breaker) *http.Client {
+	client := &http.Client{Timeout: timeout}
+	if b != nil {
+		client.Transport = &Transport{Breaker: b}
+	}
+	return client
+}
+
+// IsOpen ตรวจว่า error มาจาก breaker ที่เปิดอยู่หรือไม่ (ใช้ตัดสินว่าไม่ต้อง retry)
+func IsOpen(err error) bool {
+	return errors.Is(err, ErrOpen)
+}
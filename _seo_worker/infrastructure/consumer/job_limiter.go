@@ -0,0 +1,47 @@
+package consumer
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// jobLimiter semaphore จำกัดจำนวน job ที่ทำพร้อมกัน (= WORKER_CONCURRENCY)
+// กัน Gemini rate limit และ memory เมื่อมี job เข้ามาพร้อมกันเยอะ
+type jobLimiter struct {
+	slots    chan struct{}
+	inFlight atomic.Int64
+}
+
+func newJobLimiter(limit int) *jobLimiter {
+	if limit <= 0 {
+		limit = 1
+	}
+	return &jobLimiter{slots: make(chan struct{}, limit)}
+}
+
+// Acquire รอจนมี slot ว่าง - ctx ถูก cancel ระหว่างรอ = false (ไม่ได้ slot)
+func (l *jobLimiter) Acquire(ctx context.Context) bool {
+	select {
+	case l.slots <- struct{}{}:
+		l.inFlight.Add(1)
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Release คืน slot (เรียกหลัง Acquire สำเร็จเท่านั้น)
+func (l *jobLimiter) Release() {
+	l.inFlight.Add(-1)
+	<-l.slots
+}
+
+// InFlight จำนวน job ที่กำลังทำอยู่
+func (l *jobLimiter) InFlight() int {
+	return int(l.inFlight.Load())
+}
+
+// Limit จำนวน job สูงสุดที่ทำพร้อมกันได้
+func (l *jobLimiter) Limit() int {
+	return cap(l.slots)
+}
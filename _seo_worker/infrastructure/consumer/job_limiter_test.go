@@ -0,0 +1,81 @@
+package consumer
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestJobLimiterRespectsConcurrency(t *testing.T) {
+	const limit = 3
+	const jobs = 20
+
+	limiter := newJobLimiter(limit)
+	ctx := context.Background()
+
+	var current, peak atomic.Int64
+	var wg sync.WaitGroup
+	for i := 0; i < jobs; i++ {
+		if !limiter.Acquire(ctx) {
+			t.Fatal("Acquire failed with live context")
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer limiter.Release()
+
+			n := current.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			if got := limiter.InFlight(); got > limit {
+				t.Errorf("InFlight() = %d, want <= %d", got, limit)
+			}
+			time.Sleep(5 * time.Millisecond)
+			current.Add(-1)
+		}()
+	}
+	wg.Wait()
+
+	if p := peak.Load(); p > limit {
+		t.Errorf("peak concurrency = %d, want <= %d", p, limit)
+	}
+	if p := peak.Load(); p < 2 {
+		t.Errorf("peak concurrency = %d, jobs did not run in parallel", p)
+	}
+	if got := limiter.InFlight(); got != 0 {
+		t.Errorf("InFlight() after all jobs = %d, want 0", got)
+	}
+}
+
+func TestJobLimiterAcquireCancelled(t *testing.T) {
+	limiter := newJobLimiter(1)
+	if !limiter.Acquire(context.Background()) {
+		t.Fatal("first Acquire should succeed")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if limiter.Acquire(ctx) {
+		t.Fatal("Acquire should fail when no slot is free and ctx is done")
+	}
+	if got := limiter.InFlight(); got != 1 {
+		t.Errorf("InFlight() = %d, want 1", got)
+	}
+
+	limiter.Release()
+	if !limiter.Acquire(context.Background()) {
+		t.Error("Acquire should succeed after Release")
+	}
+}
+
+func TestNewJobLimiterDefaultsToOne(t *testing.T) {
+	if got := newJobLimiter(0).Limit(); got != 1 {
+		t.Errorf("Limit() = %d, want 1", got)
+	}
+}
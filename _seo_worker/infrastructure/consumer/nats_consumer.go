@@ -3,6 +3,7 @@ package consumer
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"sync"
@@ -14,6 +15,7 @@ import (
 
 	"seo-worker/domain/models"
 	"seo-worker/domain/ports"
+	"seo-worker/infrastructure/logging"
 )
 
 type NATSConsumer struct {
@@ -27,6 +29,7 @@ type NATSConsumer struct {
 	running atomic.Bool
 	paused  atomic.Bool
 	wg      sync.WaitGroup
+	limiter *jobLimiter
 
 	// Config
 	config NATSConsumerConfig
@@ -36,9 +39,11 @@ type NATSConsumerConfig struct {
 	URL             string
 	Stream          string
 	Subject         string
+	PrioritySubject string // job urgent (ดึงก่อนคิวปกติเสมอ) - "" = ไม่มีคิว priority
 	ConsumerName    string
 	Concurrency     int
 	ShutdownTimeout time.Duration
+	DeferDelay      time.Duration // หน่วงก่อนส่ง job ที่ gallery ยังไม่พร้อมกลับมาใหม่ (0 = NAK ทันที)
 }
 
 func NewNATSConsumer(cfg NATSConsumerConfig) (*NATSConsumer, error) {
@@ -57,10 +62,11 @@ func NewNATSConsumer(cfg NATSConsumerConfig) (*NATSConsumer, error) {
 	}
 
 	return &NATSConsumer{
-		nc:     nc,
-		js:     js,
-		config: cfg,
-		logger: slog.Default().With("component", "nats_consumer"),
+		nc:      nc,
+		js:      js,
+		config:  cfg,
+		limiter: newJobLimiter(cfg.Concurrency),
+		logger:  slog.Default().With("component", "nats_consumer"),
 	}, nil
 }
 
@@ -73,10 +79,15 @@ func (c *NATSConsumer) Start(ctx context.Context) error {
 		return fmt.Errorf("handler not set")
 	}
 
+	subjects := []string{c.config.Subject}
+	if c.config.PrioritySubject != "" {
+		subjects = append(subjects, c.config.PrioritySubject)
+	}
+
 	// Create or get stream
 	stream, err := c.js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
 		Name:      c.config.Stream,
-		Subjects:  []string{c.config.Subject},
+		Subjects:  subjects,
 		Retention: jetstream.WorkQueuePolicy,
 		MaxAge:    7 * 24 * time.Hour, // 7 days
 	})
@@ -85,14 +96,7 @@ func (c *NATSConsumer) Start(ctx context.Context) error {
 	}
 
 	// Create or get consumer
-	consumer, err := stream.CreateOrUpdateConsumer(ctx, jetstream.ConsumerConfig{
-		Name:          c.config.ConsumerName,
-		Durable:       c.config.ConsumerName,
-		AckPolicy:     jetstream.AckExplicitPolicy,
-		MaxDeliver:    3, // Retry 3 times then DLQ
-		AckWait:       5 * time.Minute,
-		FilterSubject: c.config.Subject,
-	})
+	consumer, err := c.createConsumer(ctx, stream, c.config.ConsumerName, c.config.Subject)
 	if err != nil {
 		return fmt.Errorf("failed to create consumer: %w", err)
 	}
@@ -102,9 +106,24 @@ func (c *NATSConsumer) Start(ctx context.Context) error {
 	c.logger.Info("Consumer started",
 		"stream", c.config.Stream,
 		"consumer", c.config.ConsumerName,
-		"concurrency", c.config.Concurrency,
+		"concurrency", c.limiter.Limit(),
+		"priority_subject", c.config.PrioritySubject,
 	)
 
+	// มีคิว priority → ดึงเองทีละ message (priority ก่อนเสมอ)
+	if c.config.PrioritySubject != "" {
+		priority, err := c.createConsumer(ctx, stream, c.config.ConsumerName+"-priority", c.config.PrioritySubject)
+		if err != nil {
+			return fmt.Errorf("failed to create priority consumer: %w", err)
+		}
+		c.consumeWithPriority(ctx, priority, consumer)
+
+		c.logger.Info("Context cancelled, stopping consumer")
+		c.running.Store(false)
+		c.wg.Wait()
+		return nil
+	}
+
 	// Start consuming with Consume API
 	consumeCtx, err := consumer.Consume(func(msg jetstream.Msg) {
 		if c.paused.Load() {
@@ -113,12 +132,19 @@ func (c *NATSConsumer) Start(ctx context.Context) error {
 			return
 		}
 
+		// รอ slot ว่าง (block callback = ไม่ดึง message เพิ่มจนกว่า job จะเสร็จ)
+		if !c.limiter.Acquire(ctx) {
+			msg.Nak()
+			return
+		}
+
 		c.wg.Add(1)
 		go func() {
 			defer c.wg.Done()
+			defer c.limiter.Release()
 			c.processMessage(ctx, msg)
 		}()
-	})
+	}, jetstream.PullMaxMessages(c.limiter.Limit()))
 	if err != nil {
 		return fmt.Errorf("failed to start consuming: %w", err)
 	}
@@ -132,6 +158,77 @@ func (c *NATSConsumer) Start(ctx context.Context) error {
 	return nil
 }
 
+// createConsumer สร้าง/อัพเดท durable consumer ของ subject
+func (c *NATSConsumer) createConsumer(ctx context.Context, stream jetstream.Stream, name, subject string) (jetstream.Consumer, error) {
+	return stream.CreateOrUpdateConsumer(ctx, jetstream.ConsumerConfig{
+		Name:          name,
+		Durable:       name,
+		AckPolicy:     jetstream.AckExplicitPolicy,
+		MaxDeliver:    3, // Retry 3 times then DLQ
+		AckWait:       5 * time.Minute,
+		FilterSubject: subject,
+		// server ส่ง message ที่ยังไม่ ack ได้ไม่เกิน concurrency
+		// (message ที่รอ slot นานเกิน AckWait จะถูกส่งซ้ำ)
+		MaxAckPending: c.limiter.Limit(),
+	})
+}
+
+// consumeWithPriority รอ slot ว่างแล้วค่อยดึง message ถัดไป (ไม่ดึงล่วงหน้า = job urgent ไม่ต้องรอหลัง buffer)
+func (c *NATSConsumer) consumeWithPriority(ctx context.Context, priority, normal jetstream.Consumer) {
+	for ctx.Err() == nil {
+		if c.paused.Load() {
+			select {
+			case <-ctx.Done():
+			case <-time.After(priorityPollWait):
+			}
+			continue
+		}
+
+		if !c.limiter.Acquire(ctx) {
+			return
+		}
+
+		msg := c.nextMessage(priority, normal)
+		if msg == nil {
+			c.limiter.Release()
+			continue
+		}
+
+		c.wg.Add(1)
+		go func() {
+			defer c.wg.Done()
+			defer c.limiter.Release()
+			c.processMessage(ctx, msg)
+		}()
+	}
+}
+
+// priorityPollWait เวลารอคิวปกติต่อรอบ - job urgent ที่เข้ามาระหว่างรอจะถูกดึงในรอบถัดไป
+const priorityPollWait = 2 * time.Second
+
+// nextMessage คิว priority ก่อน (ไม่รอ) แล้วค่อยคิวปกติ (รอไม่เกิน priorityPollWait) - ไม่มีงาน = nil
+func (c *NATSConsumer) nextMessage(priority, normal jetstream.Consumer) jetstream.Msg {
+	if msg := c.fetchOne(priority.FetchNoWait(1)); msg != nil {
+		return msg
+	}
+	return c.fetchOne(normal.Fetch(1, jetstream.FetchMaxWait(priorityPollWait)))
+}
+
+// fetchOne message แรกของ batch (fetch ทีละ 1)
+func (c *NATSConsumer) fetchOne(batch jetstream.MessageBatch, err error) jetstream.Msg {
+	if err != nil {
+		c.logger.Warn("Failed to fetch message", "error", err)
+		return nil
+	}
+	for msg := range batch.Messages() {
+		return msg
+	}
+	if err := batch.Error(); err != nil && !errors.Is(err, nats.ErrTimeout) {
+		c.logger.Warn("Failed to fetch message", "error", err)
+	}
+	return nil
+}
+
 func (c *NATSConsumer) processMessage(ctx context.Context, msg jetstream.Msg) {
 	var job models.SEOArticleJob
 	if err := json.Unmarshal(msg.Data(), &job); err != nil {
@@ -140,17 +237,31 @@ func (c *NATSConsumer) processMessage(ctx context.Context, msg jetstream.Msg) {
 		return
 	}
 
-	c.logger.Info("Processing job",
+	// ติด trace ID ของ job กับ context → ทุก log ใน pipeline มี trace_id
+	ctx = logging.ContextWithTraceID(ctx, job.TraceID)
+
+	c.logger.InfoContext(ctx, "Processing job",
 		"video_id", job.VideoID,
 		"video_code", job.VideoCode,
+		"in_flight", c.limiter.InFlight(),
 	)
 
 	// Process job
 	if err := c.handler(ctx, &job); err != nil {
-		c.logger.Error("Job failed",
+		c.logger.ErrorContext(ctx, "Job failed",
 			"video_id", job.VideoID,
 			"error", err,
 		)
+		// admin ยกเลิก job - ไม่ต้อง retry
+		if errors.Is(err, models.ErrJobCancelled) {
+			msg.Term()
+			return
+		}
+		// Gallery ยังไม่พร้อม - เลื่อนไปลองใหม่ภายหลัง (รอ gallery generate/admin คัดภาพ)
+		if errors.Is(err, models.ErrGalleryNotReady) && c.config.DeferDelay > 0 {
+			msg.NakWithDelay(c.config.DeferDelay)
+			return
+		}
 		// NAK to retry (or send to DLQ after max retries)
 		msg.Nak()
 		return
@@ -158,7 +269,7 @@ func (c *NATSConsumer) processMessage(ctx context.Context, msg jetstream.Msg) {
 
 	// Success
 	msg.Ack()
-	c.logger.Info("Job completed",
+	c.logger.InfoContext(ctx, "Job completed",
 		"video_id", job.VideoID,
 	)
 }
@@ -176,6 +287,11 @@ func (c *NATSConsumer) IsRunning() bool {
 	return c.running.Load()
 }
 
+// InFlight จำนวน job ที่กำลังทำอยู่ (ไม่เกิน concurrency)
+func (c *NATSConsumer) InFlight() int {
+	return c.limiter.InFlight()
+}
+
 func (c *NATSConsumer) IsPaused() bool {
 	return c.paused.Load()
 }
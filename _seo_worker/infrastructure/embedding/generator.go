@@ -0,0 +1,168 @@
+package embedding
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const generatorTimeout = 30 * time.Second
+
+// Generator สร้าง embedding vector จาก text (provider ภายนอก)
+type Generator interface {
+	Embed(ctx context.Context, text string) ([]float32, error)
+	Name() string // provider/model สำหรับ log
+}
+
+// ============================================================================
+// Placeholder - zero vector (พฤติกรรมเดิมก่อนมี provider จริง)
+// ============================================================================
+
+type placeholderGenerator struct {
+	dimension int
+}
+
+func (g placeholderGenerator) Embed(ctx context.Context, text string) ([]float32, error) {
+	return make([]float32, g.dimension), nil
+}
+
+func (g placeholderGenerator) Name() string { return "placeholder" }
+
+// ============================================================================
+// Gemini - models/{model}:embedContent
+// ============================================================================
+
+// GeminiGenerator ใช้ Gemini embedding API (เช่น text-embedding-004)
+type GeminiGenerator struct {
+	apiKey     string
+	model      string
+	dimension  int // outputDimensionality (0 = default ของ model)
+	httpClient *http.Client
+}
+
+func NewGeminiGenerator(apiKey, model string, dimension int) *GeminiGenerator {
+	if model == "" {
+		model = "text-embedding-004"
+	}
+	return &GeminiGenerator{
+		apiKey:     apiKey,
+		model:      model,
+		dimension:  dimension,
+		httpClient: &http.Client{Timeout: generatorTimeout},
+	}
+}
+
+func (g *GeminiGenerator) Name() string { return "gemini/" + g.model }
+
+func (g *GeminiGenerator) Embed(ctx context.Context, text string) ([]float32, error) {
+	reqBody := map[string]any{
+		"content": map[string]any{
+			"parts": []map[string]string{{"text": text}},
+		},
+	}
+	if g.dimension > 0 {
+		reqBody["outputDimensionality"] = g.dimension
+	}
+
+	// ส่ง key ทาง header (ไม่ให้ key ไปโผล่ใน URL/error log)
+	endpoint := fmt.Sprintf("https://generativelanguage.googleapis.com/v1beta/models/%s:embedContent",
+		g.model)
+	headers := map[string]string{"x-goog-api-key": g.apiKey}
+
+	var resp struct {
+		Embedding struct {
+			Values []float32 `json:"values"`
+		} `json:"embedding"`
+	}
+	if err := postJSON(ctx, g.httpClient, endpoint, headers, reqBody, &resp); err != nil {
+		return nil, fmt.Errorf("gemini embedding: %w", err)
+	}
+	return resp.Embedding.Values, nil
+}
+
+// ============================================================================
+// OpenAI - /v1/embeddings
+// ============================================================================
+
+// OpenAIGenerator ใช้ OpenAI embedding API (เช่น text-embedding-3-small)
+type OpenAIGenerator struct {
+	apiKey     string
+	model      string
+	dimension  int // dimensions (0 = default ของ model, รองรับเฉพาะ text-embedding-3-*)
+	httpClient *http.Client
+}
+
+func NewOpenAIGenerator(apiKey, model string, dimension int) *OpenAIGenerator {
+	if model == "" {
+		model = "text-embedding-3-small"
+	}
+	return &OpenAIGenerator{
+		apiKey:     apiKey,
+		model:      model,
+		dimension:  dimension,
+		httpClient: &http.Client{Timeout: generatorTimeout},
+	}
+}
+
+func (g *OpenAIGenerator) Name() string { return "openai/" + g.model }
+
+func (g *OpenAIGenerator) Embed(ctx context.Context, text string) ([]float32, error) {
+	reqBody := map[string]any{
+		"model": g.model,
+		"input": text,
+	}
+	if g.dimension > 0 {
+		reqBody["dimensions"] = g.dimension
+	}
+
+	var resp struct {
+		Data []struct {
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+	}
+	headers := map[string]string{"Authorization": "Bearer " + g.apiKey}
+	if err := postJSON(ctx, g.httpClient, "https://api.openai.com/v1/embeddings", headers, reqBody, &resp); err != nil {
+		return nil, fmt.Errorf("openai embedding: %w", err)
+	}
+	if len(resp.Data) == 0 {
+		return nil, fmt.Errorf("openai embedding: empty response")
+	}
+	return resp.Data[0].Embedding, nil
+}
+
+// postJSON ส่ง JSON request แล้ว decode response (non-200 = error พร้อม body)
+func postJSON(ctx context.Context, client *http.Client, endpoint string, headers map[string]string, body, out any) error {
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(jsonBody))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("API error: %d - %s", resp.StatusCode, string(respBody))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
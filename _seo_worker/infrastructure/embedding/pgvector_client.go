@@ -3,6 +3,7 @@ package embedding
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log/slog"
 
@@ -13,20 +14,47 @@ import (
 	"seo-worker/domain/ports"
 )
 
+// DefaultDimension ขนาด vector ของ column article_embeddings.embedding (vector(1536))
+const DefaultDimension = 1536
+
+// ErrDimensionMismatch vector ไม่ตรงกับขนาด column (model/column ไม่ตรงกัน)
+var ErrDimensionMismatch = errors.New("embedding dimension mismatch")
+
 type PgVectorClient struct {
-	db     *sql.DB
-	logger *slog.Logger
+	db        *sql.DB
+	generator Generator
+	dimension int // ขนาดที่ column รับได้ - ตรวจก่อน store
+	logger    *slog.Logger
 }
 
 func NewPgVectorClient(db *sql.DB) *PgVectorClient {
 	return &PgVectorClient{
-		db:     db,
-		logger: slog.Default().With("component", "pgvector"),
+		db:        db,
+		generator: placeholderGenerator{dimension: DefaultDimension},
+		dimension: DefaultDimension,
+		logger:    slog.Default().With("component", "pgvector"),
 	}
 }
 
-// GenerateEmbedding สร้าง embedding vector จาก text
-// TODO: ใช้ Gemini text-embedding-004 หรือ OpenAI ada-002
+// SetGenerator ตั้ง embedding provider (ไม่ตั้ง = placeholder zero vector)
+func (c *PgVectorClient) SetGenerator(g Generator) {
+	c.generator = g
+}
+
+// SetDimension ตั้งขนาด vector ที่ column รับได้ (<= 0 = DefaultDimension)
+// placeholder ใช้ขนาดนี้ด้วย
+func (c *PgVectorClient) SetDimension(dim int) {
+	if dim <= 0 {
+		dim = DefaultDimension
+	}
+	c.dimension = dim
+	if _, ok := c.generator.(placeholderGenerator); ok {
+		c.generator = placeholderGenerator{dimension: dim}
+	}
+}
+
+// GenerateEmbedding สร้าง embedding vector จาก text ผ่าน provider ที่ตั้งไว้
+// ตรวจขนาด vector ทันที - model ไม่ตรงกับ column = error ชัดเจน (ไม่ต้องรอ insert fail)
 func (c *PgVectorClient) GenerateEmbedding(ctx context.Context, text string) ([]float32, error) {
 	// Skip if DB is nil (testing mode)
 	if c.db == nil {
@@ -34,15 +62,54 @@ func (c *PgVectorClient) GenerateEmbedding(ctx context.Context, text string) ([]
 		return nil, nil
 	}
 
-	// Placeholder: ต้อง implement เรียก embedding API
-	// ตอนนี้ return dummy vector
-	c.logger.WarnContext(ctx, "Using placeholder embedding - implement real embedding API")
+	if _, ok := c.generator.(placeholderGenerator); ok {
+		c.logger.WarnContext(ctx, "Using placeholder embedding - set EMBEDDING_PROVIDER for real vectors")
+	}
+
+	vector, err := c.generator.Embed(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkDimension(vector); err != nil {
+		return nil, err
+	}
 
-	// Return 1536-dim zero vector as placeholder
-	vector := make([]float32, 1536)
 	return vector, nil
 }
 
+// checkDimension ตรวจว่า vector ตรงกับขนาด column
+func (c *PgVectorClient) checkDimension(vector []float32) error {
+	if len(vector) != c.dimension {
+		return fmt.Errorf("%w: %s returned %d, column expects %d",
+			ErrDimensionMismatch, c.generator.Name(), len(vector), c.dimension)
+	}
+	return nil
+}
+
+// VerifyColumnDimension ตรวจว่าขนาดที่ตั้งไว้ตรงกับ column ใน DB (เรียกตอน startup)
+// pgvector เก็บขนาดไว้ใน atttypmod ของ column
+func (c *PgVectorClient) VerifyColumnDimension(ctx context.Context) error {
+	if c.db == nil {
+		return nil
+	}
+
+	var columnDim int
+	err := c.db.QueryRowContext(ctx, `
+		SELECT atttypmod FROM pg_attribute
+		WHERE attrelid = 'article_embeddings'::regclass AND attname = 'embedding'
+	`).Scan(&columnDim)
+	if err != nil {
+		return fmt.Errorf("failed to read embedding column dimension: %w", err)
+	}
+
+	// atttypmod = -1 คือ column ไม่ได้ระบุขนาด (vector) - รับได้ทุกขนาด
+	if columnDim > 0 && columnDim != c.dimension {
+		return fmt.Errorf("%w: configured %d, article_embeddings.embedding is vector(%d)",
+			ErrDimensionMismatch, c.dimension, columnDim)
+	}
+	return nil
+}
+
 // StoreEmbedding บันทึก embedding ลง pgvector
 // ใช้ ON CONFLICT สำหรับ idempotency
 func (c *PgVectorClient) StoreEmbedding(ctx context.Context, data *models.EmbeddingData) error {
@@ -52,6 +119,10 @@ func (c *PgVectorClient) StoreEmbedding(ctx context.Context, data *models.Embedd
 		return nil
 	}
 
+	if err := c.checkDimension(data.Vector); err != nil {
+		return err
+	}
+
 	query := `
 		INSERT INTO article_embeddings (video_id, embedding, cast_ids, maker_id, tag_ids, created_at)
 		VALUES ($1, $2, $3, $4, $5, $6)
@@ -151,5 +222,90 @@ func (c *PgVectorClient) FindSimilar(ctx context.Context, query *ports.Similarit
 	return results, rows.Err()
 }
 
+// RelatedMatch video ที่ embedding ใกล้กัน (ID อย่างเดียว - metadata อยู่ฝั่ง API DB)
+type RelatedMatch struct {
+	VideoID    string  `json:"videoId"`
+	Similarity float64 `json:"similarity"`
+}
+
+// RelatedFilter filter ของ FindRelatedByVideo (ค่าว่าง = ไม่ filter)
+type RelatedFilter struct {
+	CastID        string
+	MakerID       string
+	TagID         string
+	MinSimilarity float64
+}
+
+// FindRelatedByVideo ใช้ embedding ของ video ต้นทางเป็น query vector - ไม่ join videos
+// (article_embeddings อยู่ DB ของ SEO ส่วน videos อยู่ DB ของ API)
+// hasEmbedding = false เมื่อ video ต้นทางยังไม่มี embedding
+func (c *PgVectorClient) FindRelatedByVideo(ctx context.Context, videoID string, limit int, filter RelatedFilter) (matches []RelatedMatch, hasEmbedding bool, err error) {
+	if c.db == nil {
+		c.logger.WarnContext(ctx, "Skipping related search - DB is nil (testing mode)")
+		return nil, false, nil
+	}
+
+	var exists bool
+	if err := c.db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM article_embeddings WHERE video_id = $1)`, videoID,
+	).Scan(&exists); err != nil {
+		return nil, false, fmt.Errorf("failed to check embedding: %w", err)
+	}
+	if !exists {
+		return []RelatedMatch{}, false, nil
+	}
+
+	sqlQuery := `
+		SELECT e.video_id, 1 - (e.embedding <=> src.embedding) AS similarity
+		FROM article_embeddings e
+		CROSS JOIN (SELECT embedding FROM article_embeddings WHERE video_id = $1) src
+		WHERE e.video_id <> $1
+	`
+	args := []any{videoID}
+	argIdx := 2
+
+	if filter.CastID != "" {
+		sqlQuery += fmt.Sprintf(" AND $%d = ANY(e.cast_ids)", argIdx)
+		args = append(args, filter.CastID)
+		argIdx++
+	}
+	if filter.MakerID != "" {
+		sqlQuery += fmt.Sprintf(" AND e.maker_id = $%d", argIdx)
+		args = append(args, filter.MakerID)
+		argIdx++
+	}
+	if filter.TagID != "" {
+		sqlQuery += fmt.Sprintf(" AND $%d = ANY(e.tag_ids)", argIdx)
+		args = append(args, filter.TagID)
+		argIdx++
+	}
+	if filter.MinSimilarity > 0 {
+		sqlQuery += fmt.Sprintf(" AND 1 - (e.embedding <=> src.embedding) >= $%d", argIdx)
+		args = append(args, filter.MinSimilarity)
+		argIdx++
+	}
+
+	sqlQuery += " ORDER BY e.embedding <=> src.embedding"
+	sqlQuery += fmt.Sprintf(" LIMIT $%d", argIdx)
+	args = append(args, limit)
+
+	rows, err := c.db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to query related videos: %w", err)
+	}
+	defer rows.Close()
+
+	matches = []RelatedMatch{}
+	for rows.Next() {
+		var m RelatedMatch
+		if err := rows.Scan(&m.VideoID, &m.Similarity); err != nil {
+			return nil, true, fmt.Errorf("failed to scan row: %w", err)
+		}
+		matches = append(matches, m)
+	}
+
+	return matches, true, rows.Err()
+}
+
 // Verify interface implementation
 var _ ports.EmbeddingPort = (*PgVectorClient)(nil)
@@ -0,0 +1,139 @@
+package eventlog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"seo-worker/domain/models"
+	"seo-worker/domain/ports"
+)
+
+// subjects ที่ API server subscribe แล้วเขียนลง job_events / processing_costs ของ suekk DB
+// (ทั้งสองตารางอยู่ DB ของ API - worker นี้ต่อ DB ของ subth)
+const (
+	SubjectJobEvent = "events.job"
+	SubjectJobCost  = "events.cost"
+)
+
+// jobEventMessage payload ต้องตรงกับ JobEventMessage ฝั่ง API
+type jobEventMessage struct {
+	VideoID   string    `json:"video_id"`
+	Source    string    `json:"source"`
+	Stage     string    `json:"stage"`
+	Progress  float64   `json:"progress"`
+	Message   string    `json:"message,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// jobCostMessage payload ต้องตรงกับ JobCostMessage ฝั่ง API
+type jobCostMessage struct {
+	VideoID            string    `json:"video_id"`
+	Source             string    `json:"source"`
+	GeminiPromptTokens int64     `json:"gemini_prompt_tokens"`
+	GeminiOutputTokens int64     `json:"gemini_output_tokens"`
+	TTSCharacters      int       `json:"tts_characters"`
+	EmbeddingChars     int       `json:"embedding_chars"`
+	JobDurationMs      int64     `json:"job_duration_ms"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+// NATSEventLog ส่ง pipeline events และ cost ให้ API ผ่าน NATS (publish ไม่ block รอ DB)
+// event stage เดิมซ้ำของ video เดียวกันจะถูกข้าม - เก็บเฉพาะตอนเปลี่ยน stage
+type NATSEventLog struct {
+	nc *nats.Conn
+
+	mu        sync.Mutex
+	lastStage map[string]string // video_id → stage ล่าสุดที่ส่งไป
+
+	logger *slog.Logger
+}
+
+func NewNATSEventLog(nc *nats.Conn) *NATSEventLog {
+	return &NATSEventLog{
+		nc:        nc,
+		lastStage: make(map[string]string),
+		logger:    slog.Default().With("component", "event_log"),
+	}
+}
+
+// RecordEvent publish event ไป events.job.{source}.{video_id}
+func (l *NATSEventLog) RecordEvent(ctx context.Context, event *models.JobEvent) error {
+	if l.nc == nil || event == nil {
+		return nil
+	}
+	if !l.shouldRecord(event) {
+		return nil
+	}
+
+	data, err := json.Marshal(jobEventMessage{
+		VideoID:   event.VideoID,
+		Source:    event.Source,
+		Stage:     event.Stage,
+		Progress:  event.Progress,
+		Message:   event.Message,
+		CreatedAt: event.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal job event: %w", err)
+	}
+
+	subject := fmt.Sprintf("%s.%s.%s", SubjectJobEvent, event.Source, event.VideoID)
+	if err := l.nc.Publish(subject, data); err != nil {
+		return fmt.Errorf("failed to publish job event: %w", err)
+	}
+	return nil
+}
+
+// shouldRecord throttle ตาม stage - stage จบ job (completed/failed/cancelled) ส่งเสมอและล้างสถานะ
+func (l *NATSEventLog) shouldRecord(event *models.JobEvent) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	switch event.Stage {
+	case ports.StageCompleted, ports.StageFailed, ports.StageCancelled:
+		delete(l.lastStage, event.VideoID)
+		return true
+	}
+
+	if l.lastStage[event.VideoID] == event.Stage {
+		return false
+	}
+	l.lastStage[event.VideoID] = event.Stage
+	return true
+}
+
+// RecordCost publish cost ของ job ไป events.cost.{source}.{video_id}
+func (l *NATSEventLog) RecordCost(ctx context.Context, cost *models.ProcessingCost) error {
+	if l.nc == nil || cost == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(jobCostMessage{
+		VideoID:            cost.VideoID,
+		Source:             cost.Source,
+		GeminiPromptTokens: cost.GeminiPromptTokens,
+		GeminiOutputTokens: cost.GeminiOutputTokens,
+		TTSCharacters:      cost.TTSCharacters,
+		EmbeddingChars:     cost.EmbeddingChars,
+		JobDurationMs:      cost.JobDuration.Milliseconds(),
+		CreatedAt:          cost.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal processing cost: %w", err)
+	}
+
+	subject := fmt.Sprintf("%s.%s.%s", SubjectJobCost, cost.Source, cost.VideoID)
+	if err := l.nc.Publish(subject, data); err != nil {
+		return fmt.Errorf("failed to publish processing cost: %w", err)
+	}
+	return nil
+}
+
+// Verify interface implementation
+var _ ports.JobEventPort = (*NATSEventLog)(nil)
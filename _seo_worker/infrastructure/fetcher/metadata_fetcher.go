@@ -14,6 +14,7 @@ import (
 	"seo-worker/domain/models"
 	"seo-worker/domain/ports"
 	"seo-worker/infrastructure/auth"
+	"seo-worker/infrastructure/circuitbreaker"
 )
 
 // videoCodeRegex - สกัด video code จริงจาก title (เช่น DLDSS-471, ABP-123, SSIS-001)
@@ -26,14 +27,12 @@ type MetadataFetcher struct {
 	logger     *slog.Logger
 }
 
-func NewMetadataFetcher(apiURL string, authClient *auth.AuthClient) *MetadataFetcher {
+func NewMetadataFetcher(apiURL string, authClient *auth.AuthClient, breaker *circuitbreaker.Breaker) *MetadataFetcher {
 	return &MetadataFetcher{
 		apiURL:     apiURL,
 		authClient: authClient,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		logger: slog.Default().With("component", "metadata_fetcher"),
+		httpClient: circuitbreaker.NewHTTPClient(30*time.Second, breaker),
+		logger:     slog.Default().With("component", "metadata_fetcher"),
 	}
 }
 
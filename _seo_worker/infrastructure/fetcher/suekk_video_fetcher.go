@@ -7,12 +7,16 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
+	"path"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"seo-worker/domain/models"
 	"seo-worker/domain/ports"
 	"seo-worker/infrastructure/auth"
+	"seo-worker/infrastructure/circuitbreaker"
 )
 
 // Presigned URL expiry สำหรับ gallery images (1 ชั่วโมง)
@@ -27,21 +31,20 @@ type SuekkVideoFetcher struct {
 	logger     *slog.Logger
 }
 
-func NewSuekkVideoFetcher(apiURL string, authClient *auth.AuthClient, storage ports.StoragePort) *SuekkVideoFetcher {
+func NewSuekkVideoFetcher(apiURL string, authClient *auth.AuthClient, storage ports.StoragePort, breaker *circuitbreaker.Breaker) *SuekkVideoFetcher {
 	return &SuekkVideoFetcher{
 		apiURL:     apiURL,
 		authClient: authClient,
 		storage:    storage,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		logger: slog.Default().With("component", "suekk_video_fetcher"),
+		httpClient: circuitbreaker.NewHTTPClient(30*time.Second, breaker),
+		logger:     slog.Default().With("component", "suekk_video_fetcher"),
 	}
 }
 
 type suekkVideoResponse struct {
 	Success bool `json:"success"`
 	Data    struct {
+		ID               string `json:"id"`
 		Code             string `json:"code"`
 		Duration         int    `json:"duration"`
 		ThumbnailURL     string `json:"thumbnailUrl"`
@@ -49,6 +52,7 @@ type suekkVideoResponse struct {
 		GalleryCount     int    `json:"galleryCount"`
 		GallerySafeCount int    `json:"gallerySafeCount"` // จำนวนภาพ safe (pre-classified)
 		GalleryNsfwCount int    `json:"galleryNsfwCount"` // จำนวนภาพ nsfw (pre-classified)
+		CoverOverride    string `json:"galleryCoverOverride"` // editor cover override
 	} `json:"data"`
 	Error string `json:"error,omitempty"`
 }
@@ -105,9 +109,11 @@ func (f *SuekkVideoFetcher) FetchVideoInfo(ctx context.Context, videoCode string
 		"gallery_safe_count", result.Data.GallerySafeCount,
 		"gallery_nsfw_count", result.Data.GalleryNsfwCount,
 		"thumbnail_url", result.Data.ThumbnailURL,
+		"cover_override", result.Data.CoverOverride,
 	)
 
 	return &models.SuekkVideoInfo{
+		ID:               result.Data.ID,
 		Code:             result.Data.Code,
 		Duration:         result.Data.Duration,
 		ThumbnailURL:     result.Data.ThumbnailURL,
@@ -115,9 +121,48 @@ func (f *SuekkVideoFetcher) FetchVideoInfo(ctx context.Context, videoCode string
 		GalleryCount:     result.Data.GalleryCount,
 		GallerySafeCount: result.Data.GallerySafeCount,
 		GalleryNsfwCount: result.Data.GalleryNsfwCount,
+		CoverOverride:    result.Data.CoverOverride,
 	}, nil
 }
 
+// RequestGalleryGeneration สั่ง api.suekk.com สร้าง gallery จาก HLS (ใช้ได้เฉพาะวิดีโอที่ยังไม่มี gallery)
+func (f *SuekkVideoFetcher) RequestGalleryGeneration(ctx context.Context, videoID string) error {
+	url := fmt.Sprintf("%s/api/v1/videos/%s/generate-gallery", f.apiURL, videoID)
+
+	token, err := f.authClient.GetToken(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get auth token: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Handle 401 - retry with new token
+	if resp.StatusCode == http.StatusUnauthorized {
+		f.authClient.InvalidateToken()
+		return f.RequestGalleryGeneration(ctx, videoID)
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("API error: %d - %s", resp.StatusCode, string(body))
+	}
+
+	f.logger.InfoContext(ctx, "Gallery generation requested", "video_id", videoID)
+	return nil
+}
+
 // ListGalleryImages ดึงรายการ gallery images จาก storage (ใช้ presigned URLs)
 // Two-Tier System: safe (admin approved) → fallback to main gallery
 // safe = Admin เลือกแล้วว่า safe สำหรับ SEO
@@ -200,8 +245,43 @@ func isImageFile(filename string) bool {
 	return false
 }
 
-// ListAllGalleryImages ดึงรายการ gallery images จากทุก tier (safe, nsfw)
+// sortByFrameNumber เรียงไฟล์ตามเลขท้ายชื่อ (001.jpg, ss_002.jpg, 1000.jpg) - ไม่มีเลข = ท้ายสุดตามชื่อ
+func sortByFrameNumber(files []string) {
+	sort.SliceStable(files, func(i, j int) bool {
+		ni, oki := frameNumber(files[i])
+		nj, okj := frameNumber(files[j])
+		if oki != okj {
+			return oki
+		}
+		if ni != nj {
+			return ni < nj
+		}
+		return files[i] < files[j]
+	})
+}
+
+// frameNumber เลขเฟรมจากชื่อไฟล์ (ตัวเลขชุดสุดท้ายก่อนนามสกุล)
+func frameNumber(file string) (int, bool) {
+	name := path.Base(file)
+	name = strings.TrimSuffix(name, path.Ext(name))
+	end := len(name)
+	start := end
+	for start > 0 && name[start-1] >= '0' && name[start-1] <= '9' {
+		start--
+	}
+	if start == end {
+		return 0, false
+	}
+	n, err := strconv.Atoi(name[start:end])
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// ListAllGalleryImages ดึงรายการ gallery images จากทุก tier (super_safe, safe, nsfw)
 // Two-Tier System: safe (admin approved for SEO), nsfw (members only)
+// super_safe มีเฉพาะ gallery แบบ three-tier (ไม่มี = ว่าง) - tier ไหนเป็น public/member ขึ้นกับ GalleryAudience
 // Return presigned URLs แยกตาม tier
 func (f *SuekkVideoFetcher) ListAllGalleryImages(ctx context.Context, galleryPath string) (*models.TieredGalleryImages, error) {
 	if galleryPath == "" {
@@ -212,8 +292,9 @@ func (f *SuekkVideoFetcher) ListAllGalleryImages(ctx context.Context, galleryPat
 	galleryPath = strings.TrimSuffix(galleryPath, "/")
 
 	result := &models.TieredGalleryImages{
-		Safe: []string{},
-		NSFW: []string{},
+		SuperSafe: []string{},
+		Safe:      []string{},
+		NSFW:      []string{},
 	}
 
 	// Fetch from each tier
@@ -222,6 +303,7 @@ func (f *SuekkVideoFetcher) ListAllGalleryImages(ctx context.Context, galleryPat
 		target *[]string
 		name   string
 	}{
+		{galleryPath + "/super_safe", &result.SuperSafe, "super_safe"},
 		{galleryPath + "/safe", &result.Safe, "safe"},
 		{galleryPath + "/nsfw", &result.NSFW, "nsfw"},
 	}
@@ -237,6 +319,8 @@ func (f *SuekkVideoFetcher) ListAllGalleryImages(ctx context.Context, galleryPat
 			continue
 		}
 
+		// เรียงตามเลขเฟรม (ไม่สน prefix ss_/sf_/ns_ และเลขเกิน 3 หลัก) - ลำดับ = ลำดับเวลาในวิดีโอ
+		sortByFrameNumber(files)
 		for _, file := range files {
 			if isImageFile(file) {
 				url, err := f.storage.GetPresignedDownloadURL(file, galleryURLExpiry)
@@ -254,9 +338,10 @@ func (f *SuekkVideoFetcher) ListAllGalleryImages(ctx context.Context, galleryPat
 	}
 
 	f.logger.InfoContext(ctx, "All gallery images listed",
+		"super_safe", len(result.SuperSafe),
 		"safe", len(result.Safe),
 		"nsfw", len(result.NSFW),
-		"total", len(result.Safe)+len(result.NSFW),
+		"total", len(result.SuperSafe)+len(result.Safe)+len(result.NSFW),
 	)
 
 	return result, nil
@@ -1,6 +1,7 @@
 package imagecopier
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -21,6 +22,7 @@ type ImageCopier struct {
 	sourceStorage ports.StoragePort // e2 (suekk)
 	destStorage   ports.StoragePort // r2 (subth)
 	httpClient    *http.Client
+	naming        string // ชื่อไฟล์ปลายทางของ tiered gallery (SetNaming)
 	logger        *slog.Logger
 }
 
@@ -229,14 +231,29 @@ func extractFilename(rawURL string, fallbackIdx int) string {
 	return filename
 }
 
-// CopyTieredGallery copy ภาพจากทุก tier ไป r2 แยก path
-// Two-Tier System (Admin Manual Selection):
+// maxRepairAttempts จำนวนครั้งที่ลอง copy ซ้ำสำหรับภาพที่ verify ไม่ผ่าน
+const maxRepairAttempts = 2
+
+// tieredCopyTask ภาพ 1 ภาพที่ต้อง copy (เก็บ source/dest ไว้ใช้ตอน verify + repair)
+type tieredCopyTask struct {
+	tier     string // public, member, cover
+	srcURL   string
+	destPath string
+	size     imageSize // ขนาดจริงของภาพ (0 = อ่านไม่ได้)
+	err      error
+}
+
+// CopyTieredGallery copy ภาพจากทุก tier ไป r2 แยก path ตาม audience
+// Two-Tier System (Admin Manual Selection) - default audience:
 // - articles/{code}/gallery/public/  = safe (admin approved - SEO safe)
 // - articles/{code}/gallery/member/  = nsfw (admin approved - members only)
-func (c *ImageCopier) CopyTieredGallery(ctx context.Context, videoCode string, tiered *models.TieredGalleryImages) (*ports.CopiedGalleryResult, error) {
+// tier ที่ไม่อยู่ใน audience ไม่ถูก copy
+// หลัง copy จะ verify ทุกภาพใน r2 และ copy ซ้ำภาพที่หาย - ภาพที่ยังไม่สำเร็จจะถูกตัดออกและคืนใน Failed
+func (c *ImageCopier) CopyTieredGallery(ctx context.Context, videoCode string, tiered *models.TieredGalleryImages, audience models.GalleryAudience) (*ports.CopiedGalleryResult, error) {
 	if tiered == nil {
 		return nil, nil
 	}
+	publicURLs, memberURLs := audience.Split(tiered)
 
 	result := &ports.CopiedGalleryResult{
 		PublicImages: []models.GalleryImage{},
@@ -245,71 +262,140 @@ func (c *ImageCopier) CopyTieredGallery(ctx context.Context, videoCode string, t
 
 	c.logger.InfoContext(ctx, "Starting tiered gallery copy",
 		"video_code", videoCode,
-		"safe", len(tiered.Safe),
-		"nsfw", len(tiered.NSFW),
+		"public_tiers", audience.Public,
+		"member_tiers", audience.Member,
+		"public", len(publicURLs),
+		"member", len(memberURLs),
 	)
 
-	// Copy safe → public/ (admin approved for SEO)
-	for i, srcURL := range tiered.Safe {
-		filename := fmt.Sprintf("%03d.jpg", i+1)
-		destPath := fmt.Sprintf("articles/%s/gallery/public/%s", videoCode, filename)
-
-		newURL, err := c.copyToPath(ctx, srcURL, destPath)
-		if err != nil {
-			c.logger.WarnContext(ctx, "Failed to copy safe image", "error", err)
-			continue
+	// public tiers → public/ (SEO), ภาพแรกเป็น cover
+	var tasks []*tieredCopyTask
+	for i, srcURL := range publicURLs {
+		tasks = append(tasks, &tieredCopyTask{
+			tier:     "public",
+			srcURL:   srcURL,
+			destPath: fmt.Sprintf("articles/%s/gallery/public/%s", videoCode, c.tieredDestName(srcURL, i+1)),
+		})
+		if i == 0 {
+			tasks = append(tasks, &tieredCopyTask{
+				tier:     "cover",
+				srcURL:   srcURL,
+				destPath: fmt.Sprintf("articles/%s/gallery/cover.jpg", videoCode),
+			})
 		}
-
-		result.PublicImages = append(result.PublicImages, models.GalleryImage{
-			URL:    newURL,
-			Width:  1280,
-			Height: 720,
+	}
+	// member tiers → member/ (members only)
+	// global: เลขต่อจาก public เพื่อไม่ให้ชื่อชนเมื่อรวมสอง tier
+	memberOffset := 0
+	if c.naming == NamingGlobal {
+		memberOffset = len(publicURLs)
+	}
+	for i, srcURL := range memberURLs {
+		tasks = append(tasks, &tieredCopyTask{
+			tier:     "member",
+			srcURL:   srcURL,
+			destPath: fmt.Sprintf("articles/%s/gallery/member/%s", videoCode, c.tieredDestName(srcURL, memberOffset+i+1)),
 		})
+	}
 
-		// ใช้ภาพแรกเป็น cover
-		if i == 0 {
-			coverPath := fmt.Sprintf("articles/%s/gallery/cover.jpg", videoCode)
-			coverURL, err := c.copyToPath(ctx, srcURL, coverPath)
-			if err == nil {
-				result.CoverURL = coverURL
-			}
+	for _, task := range tasks {
+		size, err := c.copyToPath(ctx, task.srcURL, task.destPath)
+		if err != nil {
+			c.logger.WarnContext(ctx, "Failed to copy "+task.tier+" image", "error", err)
+			task.err = err
 		}
+		task.size = size
 	}
 
-	// Copy nsfw → member/ (members only)
-	for i, srcURL := range tiered.NSFW {
-		filename := fmt.Sprintf("%03d.jpg", i+1)
-		destPath := fmt.Sprintf("articles/%s/gallery/member/%s", videoCode, filename)
+	c.verifyAndRepair(ctx, videoCode, tasks)
 
-		newURL, err := c.copyToPath(ctx, srcURL, destPath)
-		if err != nil {
-			c.logger.WarnContext(ctx, "Failed to copy nsfw image", "error", err)
+	for _, task := range tasks {
+		if task.err != nil {
+			result.Failed = append(result.Failed, ports.FailedImageCopy{
+				Tier:      task.tier,
+				SourceURL: task.srcURL,
+				DestURL:   c.destStorage.GetPublicURL(task.destPath),
+				Error:     task.err.Error(),
+			})
 			continue
 		}
 
-		result.MemberImages = append(result.MemberImages, models.GalleryImage{
-			URL:    newURL,
-			Width:  1280,
-			Height: 720,
-		})
+		newURL := c.destStorage.GetPublicURL(task.destPath)
+		switch task.tier {
+		case "public":
+			result.PublicImages = append(result.PublicImages, task.size.galleryImage(newURL))
+		case "member":
+			result.MemberImages = append(result.MemberImages, task.size.galleryImage(newURL))
+		case "cover":
+			result.CoverURL = newURL
+		}
 	}
 
 	c.logger.InfoContext(ctx, "Tiered gallery copy completed",
 		"video_code", videoCode,
 		"public_count", len(result.PublicImages),
 		"member_count", len(result.MemberImages),
+		"failed_count", len(result.Failed),
 		"has_cover", result.CoverURL != "",
 	)
 
 	return result, nil
 }
 
-// copyToPath copy ภาพไปยัง path ที่กำหนด
-func (c *ImageCopier) copyToPath(ctx context.Context, srcURL string, destPath string) (string, error) {
-	// Check if already exists
+// verifyAndRepair ตรวจว่าทุกภาพอยู่ใน r2 จริง (HEAD object) แล้ว copy ซ้ำภาพที่หาย
+// task.err != nil หลังจบ = ภาพนั้นใช้ไม่ได้
+func (c *ImageCopier) verifyAndRepair(ctx context.Context, videoCode string, tasks []*tieredCopyTask) {
+	for _, task := range tasks {
+		if task.err == nil {
+			if exists, _ := c.destStorage.Exists(ctx, task.destPath); exists {
+				continue
+			}
+			task.err = fmt.Errorf("missing in destination after copy: %s", task.destPath)
+		}
+
+		for attempt := 1; attempt <= maxRepairAttempts && task.err != nil; attempt++ {
+			if ctx.Err() != nil {
+				return
+			}
+
+			c.logger.WarnContext(ctx, "Repairing gallery image",
+				"video_code", videoCode,
+				"tier", task.tier,
+				"dest", task.destPath,
+				"attempt", attempt,
+				"previous_error", task.err,
+			)
+
+			size, err := c.copyToPath(ctx, task.srcURL, task.destPath)
+			if err != nil {
+				task.err = err
+				continue
+			}
+			task.size = size
+			if exists, _ := c.destStorage.Exists(ctx, task.destPath); !exists {
+				task.err = fmt.Errorf("missing in destination after repair: %s", task.destPath)
+				continue
+			}
+			task.err = nil
+		}
+
+		if task.err != nil {
+			c.logger.WarnContext(ctx, "Gallery image unrecoverable, dropping from article",
+				"video_code", videoCode,
+				"tier", task.tier,
+				"src", task.srcURL,
+				"error", task.err,
+			)
+		}
+	}
+}
+
+// copyToPath copy ภาพไปยัง path ที่กำหนด คืนขนาดจริงของภาพ (อ่านไม่ได้ = imageSize ว่าง)
+func (c *ImageCopier) copyToPath(ctx context.Context, srcURL string, destPath string) (imageSize, error) {
+	// Check if already exists (อ่านขนาดจาก header ของไฟล์ปลายทาง)
 	exists, _ := c.destStorage.Exists(ctx, destPath)
 	if exists {
-		return c.destStorage.GetPublicURL(destPath), nil
+		return c.probeStoredSize(destPath), nil
 	}
 
 	// Download
@@ -323,7 +409,7 @@ func (c *ImageCopier) copyToPath(ctx context.Context, srcURL string, destPath st
 	}
 
 	if err != nil {
-		return "", fmt.Errorf("failed to download: %w", err)
+		return imageSize{}, fmt.Errorf("failed to download: %w", err)
 	}
 
 	// Detect content type
@@ -334,10 +420,10 @@ func (c *ImageCopier) copyToPath(ctx context.Context, srcURL string, destPath st
 
 	// Upload
 	if err := c.destStorage.Upload(ctx, destPath, data, contentType); err != nil {
-		return "", fmt.Errorf("failed to upload: %w", err)
+		return imageSize{}, fmt.Errorf("failed to upload: %w", err)
 	}
 
-	return c.destStorage.GetPublicURL(destPath), nil
+	return probeSize(bytes.NewReader(data)), nil
 }
 
 // Verify interface implementation
@@ -0,0 +1,44 @@
+package imagecopier
+
+import (
+	"image"
+	_ "image/jpeg" // gallery frames
+	_ "image/png"
+	"io"
+
+	"seo-worker/domain/models"
+)
+
+// imageSize ขนาดจริงของภาพ (อ่านจาก header - ไม่ decode ทั้งภาพ)
+type imageSize struct {
+	Width  int
+	Height int
+}
+
+// probeSize อ่านขนาดภาพจาก header (format ที่ไม่รู้จัก/เสีย = imageSize ว่าง)
+func probeSize(r io.Reader) imageSize {
+	cfg, _, err := image.DecodeConfig(r)
+	if err != nil {
+		return imageSize{}
+	}
+	return imageSize{Width: cfg.Width, Height: cfg.Height}
+}
+
+// probeStoredSize อ่านขนาดของภาพที่อยู่ใน r2 แล้ว (ภาพที่ copy ไว้ตั้งแต่ run ก่อน)
+func (c *ImageCopier) probeStoredSize(destPath string) imageSize {
+	reader, _, err := c.destStorage.GetFileContent(destPath)
+	if err != nil {
+		c.logger.Debug("Failed to read stored image for size", "path", destPath, "error", err)
+		return imageSize{}
+	}
+	defer reader.Close()
+	return probeSize(reader)
+}
+
+// galleryImage GalleryImage ของ url - อ่านขนาดไม่ได้ = ขนาด frame มาตรฐาน
+func (s imageSize) galleryImage(url string) models.GalleryImage {
+	if s.Width <= 0 || s.Height <= 0 {
+		return models.GalleryImage{URL: url, Width: models.DefaultGalleryImageWidth, Height: models.DefaultGalleryImageHeight}
+	}
+	return models.GalleryImage{URL: url, Width: s.Width, Height: s.Height}
+}
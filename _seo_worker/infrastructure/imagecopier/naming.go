@@ -0,0 +1,48 @@
+package imagecopier
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// Naming modes ของ tiered gallery ปลายทาง (articles/{code}/gallery/public|member/)
+const (
+	NamingPerTier = "per_tier" // 001.jpg เริ่มใหม่ทุก tier (default - ตรงกับพฤติกรรมเดิม)
+	NamingGlobal  = "global"   // public 001..N แล้ว member ต่อ N+1.. (ไม่ชนเมื่อรวม tier)
+	NamingSource  = "source"   // ใช้ชื่อไฟล์ต้นทาง (ss_001.jpg, ns_014.jpg จาก suekk worker)
+)
+
+// SetNaming ตั้งรูปแบบชื่อไฟล์ปลายทาง ("" / ไม่รู้จัก = per_tier)
+func (c *ImageCopier) SetNaming(naming string) {
+	switch naming {
+	case NamingGlobal, NamingSource:
+		c.naming = naming
+	default:
+		c.naming = NamingPerTier
+	}
+}
+
+// tieredDestName ชื่อไฟล์ปลายทางของภาพลำดับที่ seq (1-based)
+func (c *ImageCopier) tieredDestName(srcURL string, seq int) string {
+	if c.naming == NamingSource {
+		if name := sourceFilename(srcURL); name != "" {
+			return name
+		}
+	}
+	return fmt.Sprintf("%03d.jpg", seq)
+}
+
+// sourceFilename ชื่อไฟล์จาก URL ต้นทาง (ตัด query string ของ presigned URL)
+func sourceFilename(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	name := path.Base(parsed.Path)
+	if name == "." || name == "/" || !strings.Contains(name, ".") {
+		return ""
+	}
+	return name
+}
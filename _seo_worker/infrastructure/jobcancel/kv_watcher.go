@@ -0,0 +1,84 @@
+package jobcancel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"seo-worker/domain/models"
+	"seo-worker/domain/ports"
+)
+
+// Bucket KV bucket คำขอยกเลิก job (api.suekk.com เป็นคนสร้าง)
+const Bucket = "JOB_CANCEL"
+
+// keyPrefix job type ของ SEO ใน key "<job type>.<video id>"
+const keyPrefix = models.ProgressJobTypeSEO + "."
+
+// KVWatcher - Implementation ของ JobCancelPort บน NATS KV
+type KVWatcher struct {
+	kv     jetstream.KeyValue
+	logger *slog.Logger
+}
+
+// NewKVWatcher เชื่อม bucket JOB_CANCEL - ไม่มี bucket = error (ยกเลิก job ไม่ได้)
+func NewKVWatcher(ctx context.Context, nc *nats.Conn) (*KVWatcher, error) {
+	js, err := jetstream.New(nc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JetStream context: %w", err)
+	}
+
+	kv, err := js.KeyValue(ctx, Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s bucket: %w", Bucket, err)
+	}
+
+	return &KVWatcher{
+		kv:     kv,
+		logger: slog.Default().With("component", "job_cancel"),
+	}, nil
+}
+
+// Watch คืน ctx ที่ถูก cancel ด้วย cause models.ErrJobCancelled เมื่อ key ของวิดีโอถูกตั้ง
+// watch ไม่ได้ = job ทำต่อตามปกติ (แค่ยกเลิกไม่ได้)
+func (w *KVWatcher) Watch(ctx context.Context, videoID string) (context.Context, func()) {
+	jobCtx, cancel := context.WithCancelCause(ctx)
+
+	watcher, err := w.kv.Watch(jobCtx, keyPrefix+videoID)
+	if err != nil {
+		w.logger.WarnContext(ctx, "Failed to watch job cancel key", "video_id", videoID, "error", err)
+		return jobCtx, func() { cancel(nil) }
+	}
+
+	go func() {
+		for entry := range watcher.Updates() {
+			// nil = ส่งค่าปัจจุบันครบแล้ว, purge/delete = ล้าง flag
+			if entry == nil || entry.Operation() != jetstream.KeyValuePut {
+				continue
+			}
+			w.logger.InfoContext(ctx, "Job cancel requested", "video_id", videoID)
+			cancel(models.ErrJobCancelled)
+			return
+		}
+	}()
+
+	return jobCtx, func() {
+		watcher.Stop()
+		cancel(nil)
+	}
+}
+
+// Clear ลบคำขอยกเลิกหลังหยุด job แล้ว
+func (w *KVWatcher) Clear(ctx context.Context, videoID string) error {
+	if err := w.kv.Purge(ctx, keyPrefix+videoID); err != nil && !errors.Is(err, jetstream.ErrKeyNotFound) {
+		return fmt.Errorf("failed to clear job cancel: %w", err)
+	}
+	return nil
+}
+
+// Verify interface implementation
+var _ ports.JobCancelPort = (*KVWatcher)(nil)
@@ -0,0 +1,55 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+)
+
+type contextKey string
+
+// traceIDKey context key ของ trace ID (มาจาก job payload "trace_id")
+const traceIDKey contextKey = "trace_id"
+
+// ContextWithTraceID ใส่ trace ID ลง context - log ที่ใช้ *Context(ctx, ...) จะมี trace_id อัตโนมัติ
+func ContextWithTraceID(ctx context.Context, traceID string) context.Context {
+	if traceID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, traceIDKey, traceID)
+}
+
+// TraceIDFromContext ดึง trace ID จาก context ("" ถ้าไม่มี)
+func TraceIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if traceID, ok := ctx.Value(traceIDKey).(string); ok {
+		return traceID
+	}
+	return ""
+}
+
+// TraceHandler slog.Handler ที่เติม trace_id จาก context ให้ทุก record
+type TraceHandler struct {
+	slog.Handler
+}
+
+// NewTraceHandler ครอบ handler เดิม (JSON/Text) ให้ใส่ trace_id
+func NewTraceHandler(h slog.Handler) *TraceHandler {
+	return &TraceHandler{Handler: h}
+}
+
+func (h *TraceHandler) Handle(ctx context.Context, r slog.Record) error {
+	if traceID := TraceIDFromContext(ctx); traceID != "" {
+		r.AddAttrs(slog.String("trace_id", traceID))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *TraceHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &TraceHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *TraceHandler) WithGroup(name string) slog.Handler {
+	return &TraceHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
-	"time"
 
 	"github.com/nats-io/nats.go"
 
@@ -26,17 +25,21 @@ func NewNATSPublisher(nc *nats.Conn) *NATSPublisher {
 }
 
 // SendProgress ส่ง progress update ไปที่ NATS
-// Subject: seo.progress.{video_id}
+// Subject: seo.progress.{video_id} (subth) + progress.seo.{video_id} (suekk progress broadcaster)
 func (p *NATSPublisher) SendProgress(ctx context.Context, update *models.ProgressUpdate) error {
-	subject := fmt.Sprintf("seo.progress.%s", update.VideoID)
-
 	data, err := json.Marshal(update)
 	if err != nil {
 		return fmt.Errorf("failed to marshal progress update: %w", err)
 	}
 
-	if err := p.nc.Publish(subject, data); err != nil {
-		return fmt.Errorf("failed to publish progress: %w", err)
+	subjects := []string{
+		fmt.Sprintf("seo.progress.%s", update.VideoID),
+		fmt.Sprintf("progress.seo.%s", update.VideoID),
+	}
+	for _, subject := range subjects {
+		if err := p.nc.Publish(subject, data); err != nil {
+			return fmt.Errorf("failed to publish progress: %w", err)
+		}
 	}
 
 	p.logger.DebugContext(ctx, "Progress sent",
@@ -49,26 +52,16 @@ func (p *NATSPublisher) SendProgress(ctx context.Context, update *models.Progres
 }
 
 // SendCompleted ส่งแจ้งว่า job เสร็จแล้ว
-func (p *NATSPublisher) SendCompleted(ctx context.Context, videoID string) error {
-	update := &models.ProgressUpdate{
-		VideoID:   videoID,
-		Stage:     ports.StageCompleted,
-		Progress:  100,
-		Message:   "Article generated successfully",
-		Timestamp: time.Now().Unix(),
-	}
+func (p *NATSPublisher) SendCompleted(ctx context.Context, videoID, videoCode string) error {
+	update := models.NewProgressUpdate(videoID, videoCode, ports.StageCompleted, 100)
+	update.Message = "Article generated successfully"
 	return p.SendProgress(ctx, update)
 }
 
 // SendFailed ส่งแจ้งว่า job failed
-func (p *NATSPublisher) SendFailed(ctx context.Context, videoID string, err error) error {
-	update := &models.ProgressUpdate{
-		VideoID:   videoID,
-		Stage:     ports.StageFailed,
-		Progress:  0,
-		Error:     err.Error(),
-		Timestamp: time.Now().Unix(),
-	}
+func (p *NATSPublisher) SendFailed(ctx context.Context, videoID, videoCode string, err error) error {
+	update := models.NewProgressUpdate(videoID, videoCode, ports.StageFailed, 0)
+	update.Error = err.Error()
 	return p.SendProgress(ctx, update)
 }
 
@@ -28,12 +28,12 @@ func (m *NoopMessenger) SendProgress(ctx context.Context, update *models.Progres
 	return nil
 }
 
-func (m *NoopMessenger) SendCompleted(ctx context.Context, videoID string) error {
+func (m *NoopMessenger) SendCompleted(ctx context.Context, videoID, videoCode string) error {
 	m.logger.InfoContext(ctx, "Completed (noop)", "video_id", videoID)
 	return nil
 }
 
-func (m *NoopMessenger) SendFailed(ctx context.Context, videoID string, err error) error {
+func (m *NoopMessenger) SendFailed(ctx context.Context, videoID, videoCode string, err error) error {
 	m.logger.WarnContext(ctx, "Failed (noop)", "video_id", videoID, "error", err)
 	return nil
 }
@@ -8,28 +8,37 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
+	"sync"
 	"time"
 
 	"seo-worker/domain/models"
 	"seo-worker/domain/ports"
 	"seo-worker/infrastructure/auth"
+	"seo-worker/infrastructure/circuitbreaker"
 )
 
+// maxPublishedHashes จำนวน video ที่จำ hash ล่าสุดไว้ (เกินแล้วล้างทั้งหมด - แค่ทำให้ skip ไม่ได้ ไม่กระทบความถูกต้อง)
+const maxPublishedHashes = 10000
+
 type ArticlePublisher struct {
 	apiURL     string
 	authClient *auth.AuthClient
 	httpClient *http.Client
 	logger     *slog.Logger
+
+	// hash ของ article ที่ publish สำเร็จล่าสุดต่อ video (in-memory)
+	// ProcessJob ซ้ำ (NATS redelivery) ด้วยเนื้อหาเดิม → ข้าม publish
+	mu              sync.Mutex
+	publishedHashes map[string]string
 }
 
-func NewArticlePublisher(apiURL string, authClient *auth.AuthClient) *ArticlePublisher {
+func NewArticlePublisher(apiURL string, authClient *auth.AuthClient, breaker *circuitbreaker.Breaker) *ArticlePublisher {
 	return &ArticlePublisher{
-		apiURL:     apiURL,
-		authClient: authClient,
-		httpClient: &http.Client{
-			Timeout: 120 * time.Second, // Increased for large payloads
-		},
-		logger: slog.Default().With("component", "article_publisher"),
+		apiURL:          apiURL,
+		authClient:      authClient,
+		httpClient:      circuitbreaker.NewHTTPClient(120*time.Second, breaker), // Increased for large payloads
+		logger:          slog.Default().With("component", "article_publisher"),
+		publishedHashes: make(map[string]string),
 	}
 }
 
@@ -40,8 +49,28 @@ type apiResponse struct {
 
 // PublishArticle ส่ง article ไปบันทึกที่ api.subth.com
 // ใช้ ingest endpoint สำหรับ worker
+// retry-safe: ส่ง Idempotency-Key (videoID + schema version + content hash)
+// - 409 Conflict (key ซ้ำ) = publish ไปแล้ว → ถือว่าสำเร็จ
+// - เนื้อหาเดิมกับที่ publish สำเร็จล่าสุด → ข้าม ไม่ยิง API
 func (p *ArticlePublisher) PublishArticle(ctx context.Context, article *models.ArticleContent) error {
+	contentHash, err := models.ArticleContentHash(article)
+	if err != nil {
+		return err
+	}
+	if p.lastPublishedHash(article.VideoID) == contentHash {
+		p.logger.InfoContext(ctx, "Article unchanged since last publish, skipping",
+			"video_id", article.VideoID,
+			"content_hash", contentHash,
+		)
+		return nil
+	}
+
+	return p.publish(ctx, article, contentHash)
+}
+
+func (p *ArticlePublisher) publish(ctx context.Context, article *models.ArticleContent, contentHash string) error {
 	url := fmt.Sprintf("%s/api/v1/articles/ingest", p.apiURL)
+	idempotencyKey := models.PublishIdempotencyKey(article, contentHash)
 
 	// Get token from auth client
 	token, err := p.authClient.GetToken(ctx)
@@ -61,9 +90,12 @@ func (p *ArticlePublisher) PublishArticle(ctx context.Context, article *models.A
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Idempotency-Key", idempotencyKey)
 
 	p.logger.InfoContext(ctx, "Publishing article",
 		"video_id", article.VideoID,
+		"schema_version", article.SchemaVersion,
+		"idempotency_key", idempotencyKey,
 		"url", url,
 	)
 
@@ -76,7 +108,17 @@ func (p *ArticlePublisher) PublishArticle(ctx context.Context, article *models.A
 	// Handle 401 - invalidate token and retry once
 	if resp.StatusCode == http.StatusUnauthorized {
 		p.authClient.InvalidateToken()
-		return p.PublishArticle(ctx, article) // Retry with new token
+		return p.publish(ctx, article, contentHash) // Retry with new token
+	}
+
+	// 409 = idempotency key ซ้ำ → request ก่อนหน้า (ที่ timeout ฝั่งเรา) สำเร็จไปแล้ว
+	if resp.StatusCode == http.StatusConflict {
+		p.logger.InfoContext(ctx, "Article already published (duplicate idempotency key)",
+			"video_id", article.VideoID,
+			"idempotency_key", idempotencyKey,
+		)
+		p.rememberPublished(article.VideoID, contentHash)
+		return nil
 	}
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
@@ -93,6 +135,8 @@ func (p *ArticlePublisher) PublishArticle(ctx context.Context, article *models.A
 		return fmt.Errorf("API error: %s", apiResp.Error)
 	}
 
+	p.rememberPublished(article.VideoID, contentHash)
+
 	p.logger.InfoContext(ctx, "Article published",
 		"video_id", article.VideoID,
 	)
@@ -100,6 +144,21 @@ func (p *ArticlePublisher) PublishArticle(ctx context.Context, article *models.A
 	return nil
 }
 
+func (p *ArticlePublisher) lastPublishedHash(videoID string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.publishedHashes[videoID]
+}
+
+func (p *ArticlePublisher) rememberPublished(videoID, contentHash string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.publishedHashes) >= maxPublishedHashes {
+		p.publishedHashes = make(map[string]string)
+	}
+	p.publishedHashes[videoID] = contentHash
+}
+
 // UpdateArticleStatus อัพเดทสถานะ article
 func (p *ArticlePublisher) UpdateArticleStatus(ctx context.Context, videoID string, status string) error {
 	url := fmt.Sprintf("%s/api/v1/articles/%s/status", p.apiURL, videoID)
@@ -146,5 +205,157 @@ func (p *ArticlePublisher) UpdateArticleStatus(ctx context.Context, videoID stri
 	return nil
 }
 
+// GetArticleKeyMoments ดึง key moments ของ article (public + internal)
+func (p *ArticlePublisher) GetArticleKeyMoments(ctx context.Context, videoID string) (*models.ArticleKeyMoments, error) {
+	url := fmt.Sprintf("%s/api/v1/articles/%s/key-moments", p.apiURL, videoID)
+
+	token, err := p.authClient.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get auth token: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("key moments request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		p.authClient.InvalidateToken()
+		return p.GetArticleKeyMoments(ctx, videoID)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("key moments API error: %d - %s", resp.StatusCode, string(body))
+	}
+
+	var apiResp struct {
+		Success bool                     `json:"success"`
+		Data    models.ArticleKeyMoments `json:"data"`
+		Error   string                   `json:"error,omitempty"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if !apiResp.Success {
+		return nil, fmt.Errorf("API error: %s", apiResp.Error)
+	}
+
+	if apiResp.Data.VideoID == "" {
+		apiResp.Data.VideoID = videoID
+	}
+	return &apiResp.Data, nil
+}
+
+// UpdateArticleKeyMoments แทนที่ key moments ของ article ทั้งชุด
+func (p *ArticlePublisher) UpdateArticleKeyMoments(ctx context.Context, moments *models.ArticleKeyMoments) error {
+	url := fmt.Sprintf("%s/api/v1/articles/%s/key-moments", p.apiURL, moments.VideoID)
+
+	token, err := p.authClient.GetToken(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get auth token: %w", err)
+	}
+
+	jsonBody, err := json.Marshal(moments)
+	if err != nil {
+		return fmt.Errorf("failed to marshal key moments: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, bytes.NewReader(jsonBody))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("key moments update request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		p.authClient.InvalidateToken()
+		return p.UpdateArticleKeyMoments(ctx, moments)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("key moments update API error: %d - %s", resp.StatusCode, string(body))
+	}
+
+	// เนื้อหาฝั่ง API ไม่ตรงกับ hash ที่จำไว้แล้ว → publish รอบหน้าต้องส่งจริง
+	p.mu.Lock()
+	delete(p.publishedHashes, moments.VideoID)
+	p.mu.Unlock()
+
+	p.logger.InfoContext(ctx, "Article key moments updated",
+		"video_id", moments.VideoID,
+		"public_count", len(moments.KeyMoments),
+		"internal_count", len(moments.InternalKeyMoments),
+	)
+
+	return nil
+}
+
+// GetArticleSlugOwner ดึง video ID ของ article ที่ใช้ slug นี้ (404 = slug ว่าง)
+func (p *ArticlePublisher) GetArticleSlugOwner(ctx context.Context, slug string) (string, error) {
+	url := fmt.Sprintf("%s/api/v1/articles/slug/%s", p.apiURL, slug)
+
+	token, err := p.authClient.GetToken(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get auth token: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("slug lookup request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		p.authClient.InvalidateToken()
+		return p.GetArticleSlugOwner(ctx, slug)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("slug lookup API error: %d - %s", resp.StatusCode, string(body))
+	}
+
+	var apiResp struct {
+		Success bool `json:"success"`
+		Data    struct {
+			VideoID string `json:"videoId"`
+		} `json:"data"`
+		Error string `json:"error,omitempty"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+	if !apiResp.Success {
+		return "", fmt.Errorf("API error: %s", apiResp.Error)
+	}
+
+	return apiResp.Data.VideoID, nil
+}
+
 // Verify interface implementation
 var _ ports.ArticlePublisherPort = (*ArticlePublisher)(nil)
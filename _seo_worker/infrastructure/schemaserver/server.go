@@ -0,0 +1,283 @@
+package schemaserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"seo-worker/domain/models"
+	"seo-worker/infrastructure/embedding"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Schema Server - HTTP endpoint ให้ subth.com / partner ดึง JSON Schema ของ ArticleContent
+// GET /schemas/article-content       → schema version ปัจจุบัน
+// GET /schemas/article-content/versions → ArticleSchemaMigrations
+// GET /preview/safe-moments/{code}       → ผลการกรอง key moments (EnablePreview, ต้องมี token)
+// GET /related/{videoId}                 → video IDs ที่ embedding ใกล้กัน (EnableRelated, ต้องมี token)
+// GET/PUT /articles/{code}/key-moments   → ดู/แก้ key moments ของ article ที่ publish แล้ว (EnableKeyMoments, ต้องมี token)
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// Server HTTP server สำหรับ schema (ไม่มี auth - schema ไม่ใช่ข้อมูลลับ)
+type Server struct {
+	httpServer *http.Server
+	mux        *http.ServeMux
+	schema     []byte
+	logger     *slog.Logger
+
+	previewer    SafeMomentsPreviewer
+	previewToken string
+
+	related      RelatedFinder
+	relatedToken string
+
+	keyMoments  KeyMomentsEditor
+	editorToken string
+}
+
+// SafeMomentsPreviewer use case ของ preview endpoint (SEOHandler)
+type SafeMomentsPreviewer interface {
+	PreviewSafeMoments(ctx context.Context, videoCode, lang string, cached bool) (*models.SafeMomentsPreview, error)
+}
+
+// RelatedFinder vector search ของ related endpoint (PgVectorClient)
+type RelatedFinder interface {
+	FindRelatedByVideo(ctx context.Context, videoID string, limit int, filter embedding.RelatedFilter) ([]embedding.RelatedMatch, bool, error)
+}
+
+// KeyMomentsEditor use case ของ key moments endpoint (SEOHandler)
+type KeyMomentsEditor interface {
+	GetKeyMoments(ctx context.Context, videoCode string) (*models.ArticleKeyMoments, error)
+	UpdateKeyMoments(ctx context.Context, videoCode string, edit *models.ArticleKeyMoments) (*models.ArticleKeyMoments, error)
+}
+
+// keyMomentsMaxBody ขนาด body สูงสุดของ PUT key moments
+const keyMomentsMaxBody = 1 << 20
+
+// relatedMaxLimit เพดาน limit ของ /related (API ก็ clamp ไว้ที่ 50 เหมือนกัน)
+const relatedMaxLimit = 50
+
+// NewServer สร้าง server ที่ addr (เช่น ":8090") - schema ถูกสร้างครั้งเดียวตอนเริ่ม
+func NewServer(addr string) (*Server, error) {
+	schema, err := json.MarshalIndent(models.ArticleContentJSONSchema(), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to build article schema: %w", err)
+	}
+
+	s := &Server{
+		schema: schema,
+		logger: slog.Default().With("component", "schema_server"),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /schemas/article-content", s.handleSchema)
+	mux.HandleFunc("GET /schemas/article-content/versions", s.handleVersions)
+
+	s.mux = mux
+	s.httpServer = &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	return s, nil
+}
+
+// EnablePreview เปิด GET /preview/safe-moments/{code}?cached=true&lang=th (เรียกก่อน Start)
+// ต่างจาก schema ตรงที่เรียก Gemini ได้ → ต้องส่ง "Authorization: Bearer <token>" (token ว่าง = ไม่เปิด)
+func (s *Server) EnablePreview(previewer SafeMomentsPreviewer, token string) {
+	if previewer == nil || token == "" {
+		return
+	}
+	s.previewer = previewer
+	s.previewToken = token
+	s.mux.HandleFunc("GET /preview/safe-moments/{code}", s.handleSafeMomentsPreview)
+}
+
+// EnableRelated เปิด GET /related/{videoId}?limit=&castId=&makerId=&tagId=&minSimilarity= (เรียกก่อน Start)
+// ให้ API server ใช้ทำ related videos - article_embeddings อยู่ DB ของ SEO เท่านั้น (token ว่าง = ไม่เปิด)
+func (s *Server) EnableRelated(finder RelatedFinder, token string) {
+	if finder == nil || token == "" {
+		return
+	}
+	s.related = finder
+	s.relatedToken = token
+	s.mux.HandleFunc("GET /related/{videoId}", s.handleRelated)
+}
+
+// EnableKeyMoments เปิด GET/PUT /articles/{code}/key-moments (เรียกก่อน Start)
+// PUT แทนที่ key moments ทั้งชุด ผ่าน safe window + duration rules เดียวกับ buildArticle (token ว่าง = ไม่เปิด)
+func (s *Server) EnableKeyMoments(editor KeyMomentsEditor, token string) {
+	if editor == nil || token == "" {
+		return
+	}
+	s.keyMoments = editor
+	s.editorToken = token
+	s.mux.HandleFunc("GET /articles/{code}/key-moments", s.handleGetKeyMoments)
+	s.mux.HandleFunc("PUT /articles/{code}/key-moments", s.handleUpdateKeyMoments)
+}
+
+// Start รับ request จน Shutdown (blocking)
+func (s *Server) Start() error {
+	s.logger.Info("Schema server listening", "addr", s.httpServer.Addr)
+	if err := s.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("schema server failed: %w", err)
+	}
+	return nil
+}
+
+// Shutdown ปิด server (รอ request ที่ค้างอยู่)
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.httpServer.Shutdown(ctx)
+}
+
+func (s *Server) handleSchema(w http.ResponseWriter, r *http.Request) {
+	etag := `"article-content-v` + models.ArticleSchemaVersion + `"`
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/schema+json")
+	w.Header().Set("ETag", etag)
+	w.Header().Set("X-Schema-Version", models.ArticleSchemaVersion)
+	_, _ = w.Write(s.schema)
+}
+
+func (s *Server) handleVersions(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"current":    models.ArticleSchemaVersion,
+		"migrations": models.ArticleSchemaMigrations,
+	})
+}
+
+func (s *Server) handleSafeMomentsPreview(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get("Authorization")
+	if subtle.ConstantTimeCompare([]byte(token), []byte("Bearer "+s.previewToken)) != 1 {
+		writeJSONError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	code := r.PathValue("code")
+	cached, _ := strconv.ParseBool(r.URL.Query().Get("cached"))
+	lang := r.URL.Query().Get("lang")
+
+	preview, err := s.previewer.PreviewSafeMoments(r.Context(), code, lang, cached)
+	if err != nil {
+		s.logger.WarnContext(r.Context(), "Safe moments preview failed", "video_code", code, "cached", cached, "error", err)
+		writeJSONError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(preview)
+}
+
+func (s *Server) handleRelated(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get("Authorization")
+	if subtle.ConstantTimeCompare([]byte(token), []byte("Bearer "+s.relatedToken)) != 1 {
+		writeJSONError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	videoID := r.PathValue("videoId")
+	q := r.URL.Query()
+
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	if limit <= 0 || limit > relatedMaxLimit {
+		limit = relatedMaxLimit
+	}
+	filter := embedding.RelatedFilter{
+		CastID:  q.Get("castId"),
+		MakerID: q.Get("makerId"),
+		TagID:   q.Get("tagId"),
+	}
+	if v := q.Get("minSimilarity"); v != "" {
+		minSim, err := strconv.ParseFloat(v, 64)
+		if err != nil || minSim < 0 || minSim > 1 {
+			writeJSONError(w, http.StatusBadRequest, "minSimilarity must be between 0 and 1")
+			return
+		}
+		filter.MinSimilarity = minSim
+	}
+
+	matches, hasEmbedding, err := s.related.FindRelatedByVideo(r.Context(), videoID, limit, filter)
+	if err != nil {
+		s.logger.ErrorContext(r.Context(), "Related search failed", "video_id", videoID, "error", err)
+		writeJSONError(w, http.StatusInternalServerError, "related search failed")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"hasEmbedding": hasEmbedding,
+		"matches":      matches,
+	})
+}
+
+func (s *Server) handleGetKeyMoments(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get("Authorization")
+	if subtle.ConstantTimeCompare([]byte(token), []byte("Bearer "+s.editorToken)) != 1 {
+		writeJSONError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	code := r.PathValue("code")
+	moments, err := s.keyMoments.GetKeyMoments(r.Context(), code)
+	if err != nil {
+		s.logger.ErrorContext(r.Context(), "Get key moments failed", "video_code", code, "error", err)
+		writeJSONError(w, http.StatusBadGateway, "failed to get key moments")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(moments)
+}
+
+func (s *Server) handleUpdateKeyMoments(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get("Authorization")
+	if subtle.ConstantTimeCompare([]byte(token), []byte("Bearer "+s.editorToken)) != 1 {
+		writeJSONError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	code := r.PathValue("code")
+	var edit models.ArticleKeyMoments
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, keyMomentsMaxBody)).Decode(&edit); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	updated, err := s.keyMoments.UpdateKeyMoments(r.Context(), code, &edit)
+	if errors.Is(err, models.ErrInvalidKeyMoments) {
+		// ข้อความ validation บอก editor ว่าต้องแก้ moment ไหน
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		s.logger.ErrorContext(r.Context(), "Update key moments failed", "video_code", code, "error", err)
+		writeJSONError(w, http.StatusBadGateway, "failed to update key moments")
+		return
+	}
+
+	s.logger.InfoContext(r.Context(), "Key moments updated via editor endpoint",
+		"video_code", code,
+		"public_count", len(updated.KeyMoments),
+		"internal_count", len(updated.InternalKeyMoments),
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(updated)
+}
+
+func writeJSONError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
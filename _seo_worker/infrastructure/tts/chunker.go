@@ -0,0 +1,81 @@
+package tts
+
+import (
+	"strings"
+)
+
+// sentenceEnds เครื่องหมายจบประโยค (ภาษาไทยมักใช้ช่องว่างแทน จึงใช้เป็นจุดตัดสำรอง)
+var sentenceEnds = []rune{'.', '!', '?', '。', '！', '？'}
+
+// splitTTSScript แบ่ง script เป็น chunks ไม่เกิน maxChars (นับเป็น rune)
+// ตัดที่ท้ายประโยคก่อน ถ้าประโยคยาวเกินจะตัดที่ช่องว่าง และตัดตรงๆ เป็นทางเลือกสุดท้าย
+func splitTTSScript(text string, maxChars int) []string {
+	text = strings.TrimSpace(text)
+	runes := []rune(text)
+	if maxChars <= 0 || len(runes) <= maxChars {
+		return []string{text}
+	}
+
+	var chunks []string
+	for len(runes) > maxChars {
+		cut := lastBoundary(runes[:maxChars], isSentenceEnd)
+		if cut <= 0 {
+			cut = lastBoundary(runes[:maxChars], isSpace)
+		}
+		if cut <= 0 {
+			cut = maxChars
+		}
+
+		if chunk := strings.TrimSpace(string(runes[:cut])); chunk != "" {
+			chunks = append(chunks, chunk)
+		}
+		runes = []rune(strings.TrimSpace(string(runes[cut:])))
+	}
+	if len(runes) > 0 {
+		chunks = append(chunks, string(runes))
+	}
+
+	return chunks
+}
+
+// lastBoundary หา index หลังตัวอักษรสุดท้ายที่ตรง match (0 = ไม่เจอ)
+func lastBoundary(runes []rune, match func(rune) bool) int {
+	for i := len(runes) - 1; i > 0; i-- {
+		if match(runes[i]) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func isSentenceEnd(r rune) bool {
+	for _, end := range sentenceEnds {
+		if r == end {
+			return true
+		}
+	}
+	return false
+}
+
+func isSpace(r rune) bool {
+	return r == ' ' || r == '\n' || r == '\t'
+}
+
+// stripID3v2 ตัด ID3v2 tag หน้าไฟล์ MP3 (ใช้ตอนต่อ chunk ที่ 2 เป็นต้นไป)
+func stripID3v2(data []byte) []byte {
+	if len(data) < 10 || string(data[:3]) != "ID3" {
+		return data
+	}
+
+	// ขนาด tag เป็น syncsafe integer 4 bytes (7 bits ต่อ byte) + header 10 bytes
+	size := int(data[6]&0x7f)<<21 | int(data[7]&0x7f)<<14 | int(data[8]&0x7f)<<7 | int(data[9]&0x7f)
+	size += 10
+	if data[5]&0x10 != 0 {
+		size += 10 // footer
+	}
+	if size > len(data) {
+		return data
+	}
+
+	return data[size:]
+}
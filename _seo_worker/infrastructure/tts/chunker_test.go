@@ -0,0 +1,116 @@
+package tts
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSplitTTSScript(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		maxChars int
+		want     []string
+	}{
+		{
+			name:     "short text single chunk",
+			text:     "  สวัสดีครับ  ",
+			maxChars: 100,
+			want:     []string{"สวัสดีครับ"},
+		},
+		{
+			name:     "no limit",
+			text:     "ประโยคแรก ประโยคที่สอง",
+			maxChars: 0,
+			want:     []string{"ประโยคแรก ประโยคที่สอง"},
+		},
+		{
+			name:     "thai sentences split on space",
+			text:     "วันนี้อากาศดี เราไปเที่ยวทะเลกัน แล้วกลับบ้านตอนเย็น",
+			maxChars: 20,
+			want:     []string{"วันนี้อากาศดี", "เราไปเที่ยวทะเลกัน", "แล้วกลับบ้านตอนเย็น"},
+		},
+		{
+			name:     "sentence end preferred over space",
+			text:     "เรื่องนี้สนุกมาก! ตัวละครมีมิติ และเนื้อเรื่องดี",
+			maxChars: 30,
+			want:     []string{"เรื่องนี้สนุกมาก!", "ตัวละครมีมิติ และเนื้อเรื่องดี"},
+		},
+		{
+			name:     "japanese full stop",
+			text:     "今日は晴れです。明日は雨です。",
+			maxChars: 10,
+			want:     []string{"今日は晴れです。", "明日は雨です。"},
+		},
+		{
+			name:     "over-long sentence without spaces is hard cut",
+			text:     "กขคงจฉชซฌญ",
+			maxChars: 4,
+			want:     []string{"กขคง", "จฉชซ", "ฌญ"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitTTSScript(tt.text, tt.maxChars)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitTTSScript() = %q, want %q", got, tt.want)
+			}
+			if tt.maxChars <= 0 {
+				return
+			}
+			for _, chunk := range got {
+				if n := utf8.RuneCountInString(chunk); n > tt.maxChars {
+					t.Errorf("chunk %q has %d runes, want <= %d", chunk, n, tt.maxChars)
+				}
+			}
+		})
+	}
+}
+
+func TestSplitTTSScriptKeepsAllText(t *testing.T) {
+	text := strings.Repeat("นักแสดงเล่นได้ดีมาก ", 50)
+	chunks := splitTTSScript(text, 64)
+
+	if len(chunks) < 2 {
+		t.Fatalf("splitTTSScript() = %d chunks, want several", len(chunks))
+	}
+	if got, want := strings.Join(chunks, " "), strings.TrimSpace(text); got != want {
+		t.Errorf("joined chunks differ from input:\n got %q\nwant %q", got, want)
+	}
+}
+
+func TestStripID3v2(t *testing.T) {
+	audio := []byte{0xFF, 0xFB, 0x90, 0x64}
+
+	// header 10 bytes: "ID3", version, flags, syncsafe size
+	id3 := func(flags byte, size []byte, body int) []byte {
+		b := append([]byte("ID3\x04\x00"), flags)
+		b = append(b, size...)
+		return append(b, make([]byte, body)...)
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		want []byte
+	}{
+		{"no tag", audio, audio},
+		{"too short", []byte("ID3"), []byte("ID3")},
+		{"tag removed", append(id3(0, []byte{0, 0, 0, 5}, 5), audio...), audio},
+		{"syncsafe size", append(id3(0, []byte{0, 0, 1, 0}, 128), audio...), audio},
+		{"footer removed", append(id3(0x10, []byte{0, 0, 0, 3}, 13), audio...), audio},
+		{"size beyond data kept", id3(0, []byte{0, 0, 0, 50}, 5), id3(0, []byte{0, 0, 0, 50}, 5)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stripID3v2(tt.data); !bytes.Equal(got, tt.want) {
+				t.Errorf("stripID3v2() = %x, want %x", got, tt.want)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
+	"strings"
 	"time"
 
 	"seo-worker/domain/ports"
@@ -16,20 +17,23 @@ import (
 const (
 	elevenLabsAPIURL = "https://api.elevenlabs.io/v1"
 	defaultTimeout   = 60 * time.Second
+	defaultMaxChars  = 2500 // eleven_v3 รับได้ ~3000 ตัวอักษรต่อ request (เผื่อไว้)
 )
 
 type ElevenLabsClient struct {
 	apiKey     string
 	voiceID    string
 	model      string
+	maxChars   int // ความยาวสูงสุดต่อ request (เกินนี้จะแบ่ง chunk)
 	httpClient *http.Client
 	logger     *slog.Logger
 }
 
 type ElevenLabsConfig struct {
-	APIKey  string
-	VoiceID string
-	Model   string
+	APIKey   string
+	VoiceID  string
+	Model    string
+	MaxChars int // 0 = defaultMaxChars
 }
 
 func NewElevenLabsClient(cfg ElevenLabsConfig) *ElevenLabsClient {
@@ -41,11 +45,16 @@ func NewElevenLabsClient(cfg ElevenLabsConfig) *ElevenLabsClient {
 	if voiceID == "" {
 		voiceID = "q0IMILNRPxOgtBTS4taI"
 	}
+	maxChars := cfg.MaxChars
+	if maxChars <= 0 {
+		maxChars = defaultMaxChars
+	}
 
 	return &ElevenLabsClient{
-		apiKey:  cfg.APIKey,
-		voiceID: voiceID,
-		model:   model,
+		apiKey:   cfg.APIKey,
+		voiceID:  voiceID,
+		model:    model,
+		maxChars: maxChars,
 		httpClient: &http.Client{
 			Timeout: defaultTimeout,
 		},
@@ -56,6 +65,8 @@ func NewElevenLabsClient(cfg ElevenLabsConfig) *ElevenLabsClient {
 type ttsRequest struct {
 	Text          string        `json:"text"`
 	ModelID       string        `json:"model_id"`
+	PreviousText  string        `json:"previous_text,omitempty"` // context สำหรับ chunk ต่อเนื่อง
+	NextText      string        `json:"next_text,omitempty"`
 	VoiceSettings voiceSettings `json:"voice_settings"`
 }
 
@@ -72,11 +83,79 @@ func (c *ElevenLabsClient) GenerateAudio(ctx context.Context, text string, voice
 		voiceID = c.voiceID
 	}
 
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("%w: empty text", ports.ErrTTSInvalidInput)
+	}
+	charCount := len([]rune(text))
+
+	// แบ่ง script ตามประโยคให้ไม่เกิน limit ของ provider
+	chunks := splitTTSScript(text, c.maxChars)
+
+	c.logger.InfoContext(ctx, "Generating TTS audio",
+		"voice_id", voiceID,
+		"char_count", charCount,
+		"chunks", len(chunks),
+	)
+
+	var audioData []byte
+	for i, chunk := range chunks {
+		// previous_text/next_text ช่วยให้น้ำเสียงและจังหวะต่อเนื่องระหว่าง chunks
+		var prevText, nextText string
+		if i > 0 {
+			prevText = chunks[i-1]
+		}
+		if i < len(chunks)-1 {
+			nextText = chunks[i+1]
+		}
+
+		data, err := c.synthesize(ctx, chunk, voiceID, prevText, nextText)
+		if err != nil {
+			if len(chunks) > 1 {
+				return nil, fmt.Errorf("chunk %d/%d: %w", i+1, len(chunks), err)
+			}
+			return nil, err
+		}
+
+		// MP3 ต่อกันได้ตรงๆ ระดับ frame - ตัด ID3 tag ของ chunk ถัดไปออก
+		if i > 0 {
+			data = stripID3v2(data)
+		}
+		audioData = append(audioData, data...)
+	}
+
+	// Calculate duration from MP3 file size (ElevenLabs uses ~128kbps MP3)
+	// Duration = (file_size_bytes * 8) / bitrate_bps
+	audioSize := len(audioData)
+	duration := (audioSize * 8) / 128000 // 128 kbps
+	if duration < 1 {
+		duration = 1 // minimum 1 second
+	}
+
+	c.logger.InfoContext(ctx, "TTS audio generated",
+		"voice_id", voiceID,
+		"char_count", charCount,
+		"chunks", len(chunks),
+		"audio_size", audioSize,
+		"duration_sec", duration,
+	)
+
+	return &ports.TTSResult{
+		AudioData: audioData,
+		Duration:  duration,
+		CharCount: charCount,
+		VoiceID:   voiceID,
+	}, nil
+}
+
+// synthesize เรียก ElevenLabs API สำหรับ text 1 chunk
+func (c *ElevenLabsClient) synthesize(ctx context.Context, text, voiceID, prevText, nextText string) ([]byte, error) {
 	url := fmt.Sprintf("%s/text-to-speech/%s", elevenLabsAPIURL, voiceID)
 
 	reqBody := ttsRequest{
-		Text:    text,
-		ModelID: c.model,
+		Text:         text,
+		ModelID:      c.model,
+		PreviousText: prevText,
+		NextText:     nextText,
 		VoiceSettings: voiceSettings{
 			Stability:       0.5,
 			SimilarityBoost: 0.75,
@@ -99,11 +178,6 @@ func (c *ElevenLabsClient) GenerateAudio(ctx context.Context, text string, voice
 	req.Header.Set("xi-api-key", c.apiKey)
 	req.Header.Set("Accept", "audio/mpeg")
 
-	c.logger.InfoContext(ctx, "Generating TTS audio",
-		"voice_id", voiceID,
-		"char_count", len([]rune(text)),
-	)
-
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("TTS request failed: %w", err)
@@ -112,7 +186,7 @@ func (c *ElevenLabsClient) GenerateAudio(ctx context.Context, text string, voice
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("TTS API error: %d - %s", resp.StatusCode, string(body))
+		return nil, apiStatusError("TTS", resp.StatusCode, body)
 	}
 
 	audioData, err := io.ReadAll(resp.Body)
@@ -120,28 +194,7 @@ func (c *ElevenLabsClient) GenerateAudio(ctx context.Context, text string, voice
 		return nil, fmt.Errorf("failed to read audio data: %w", err)
 	}
 
-	charCount := len([]rune(text))
-
-	// Calculate duration from MP3 file size (ElevenLabs uses ~128kbps MP3)
-	// Duration = (file_size_bytes * 8) / bitrate_bps
-	audioSize := len(audioData)
-	duration := (audioSize * 8) / 128000 // 128 kbps
-	if duration < 1 {
-		duration = 1 // minimum 1 second
-	}
-
-	c.logger.InfoContext(ctx, "TTS audio generated",
-		"voice_id", voiceID,
-		"char_count", charCount,
-		"audio_size", audioSize,
-		"duration_sec", duration,
-	)
-
-	return &ports.TTSResult{
-		AudioData: audioData,
-		Duration:  duration,
-		CharCount: charCount,
-	}, nil
+	return audioData, nil
 }
 
 // Verify interface implementation
@@ -0,0 +1,18 @@
+package tts
+
+import (
+	"fmt"
+	"net/http"
+
+	"seo-worker/domain/ports"
+)
+
+// apiStatusError สร้าง error จาก HTTP status ที่ไม่ใช่ 200
+// 4xx (ยกเว้น 408 timeout / 429 rate limit) = ErrTTSRejected → caller ไม่ต้อง retry voice เดิม
+func apiStatusError(provider string, status int, body []byte) error {
+	err := fmt.Errorf("%s API error: %d - %s", provider, status, string(body))
+	if status >= 400 && status < 500 && status != http.StatusRequestTimeout && status != http.StatusTooManyRequests {
+		return fmt.Errorf("%w: %w", ports.ErrTTSRejected, err)
+	}
+	return err
+}
@@ -0,0 +1,187 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"seo-worker/domain/ports"
+)
+
+const (
+	googleTTSAPIURL = "https://texttospeech.googleapis.com/v1/text:synthesize"
+	// Google จำกัด input 5000 bytes ต่อ request - ภาษาไทย 1 ตัวอักษร = 3 bytes (UTF-8)
+	googleDefaultMaxChars = 1500
+	googleMP3Bitrate      = 32000 // Google MP3 output = 32 kbps
+)
+
+// GoogleTTSClient implements TTSPort ด้วย Google Cloud Text-to-Speech (REST + API key)
+// ใช้เป็น fallback เมื่อ ElevenLabs quota หมด หรือภาษาที่ ElevenLabs อ่านไม่ดี
+type GoogleTTSClient struct {
+	apiKey       string
+	voiceName    string // e.g. "th-TH-Neural2-C"
+	languageCode string // e.g. "th-TH"
+	maxChars     int
+	httpClient   *http.Client
+	logger       *slog.Logger
+}
+
+type GoogleTTSConfig struct {
+	APIKey       string
+	VoiceName    string // ว่าง = ให้ Google เลือกตาม languageCode
+	LanguageCode string // default th-TH
+	MaxChars     int    // 0 = googleDefaultMaxChars
+}
+
+func NewGoogleTTSClient(cfg GoogleTTSConfig) *GoogleTTSClient {
+	languageCode := cfg.LanguageCode
+	if languageCode == "" {
+		languageCode = "th-TH"
+	}
+	maxChars := cfg.MaxChars
+	if maxChars <= 0 {
+		maxChars = googleDefaultMaxChars
+	}
+
+	return &GoogleTTSClient{
+		apiKey:       cfg.APIKey,
+		voiceName:    cfg.VoiceName,
+		languageCode: languageCode,
+		maxChars:     maxChars,
+		httpClient: &http.Client{
+			Timeout: defaultTimeout,
+		},
+		logger: slog.Default().With("component", "google_tts"),
+	}
+}
+
+type googleSynthesizeRequest struct {
+	Input       googleInput       `json:"input"`
+	Voice       googleVoice       `json:"voice"`
+	AudioConfig googleAudioConfig `json:"audioConfig"`
+}
+
+type googleInput struct {
+	Text string `json:"text"`
+}
+
+type googleVoice struct {
+	LanguageCode string `json:"languageCode"`
+	Name         string `json:"name,omitempty"`
+}
+
+type googleAudioConfig struct {
+	AudioEncoding string `json:"audioEncoding"`
+}
+
+type googleSynthesizeResponse struct {
+	AudioContent string `json:"audioContent"` // base64 MP3
+}
+
+// GenerateAudio voiceID = ชื่อ voice ของ Google (ว่าง = ใช้ค่าจาก config)
+func (c *GoogleTTSClient) GenerateAudio(ctx context.Context, text string, voiceID string) (*ports.TTSResult, error) {
+	if voiceID == "" {
+		voiceID = c.voiceName
+	}
+
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("%w: empty text", ports.ErrTTSInvalidInput)
+	}
+	charCount := len([]rune(text))
+	chunks := splitTTSScript(text, c.maxChars)
+
+	c.logger.InfoContext(ctx, "Generating TTS audio",
+		"voice", voiceID,
+		"language_code", c.languageCode,
+		"char_count", charCount,
+		"chunks", len(chunks),
+	)
+
+	var audioData []byte
+	for i, chunk := range chunks {
+		data, err := c.synthesize(ctx, chunk, voiceID)
+		if err != nil {
+			if len(chunks) > 1 {
+				return nil, fmt.Errorf("chunk %d/%d: %w", i+1, len(chunks), err)
+			}
+			return nil, err
+		}
+		if i > 0 {
+			data = stripID3v2(data)
+		}
+		audioData = append(audioData, data...)
+	}
+
+	duration := (len(audioData) * 8) / googleMP3Bitrate
+	if duration < 1 {
+		duration = 1
+	}
+
+	c.logger.InfoContext(ctx, "TTS audio generated",
+		"voice", voiceID,
+		"char_count", charCount,
+		"audio_size", len(audioData),
+		"duration_sec", duration,
+	)
+
+	return &ports.TTSResult{
+		AudioData: audioData,
+		Duration:  duration,
+		CharCount: charCount,
+		VoiceID:   voiceID,
+	}, nil
+}
+
+// synthesize เรียก Google TTS API สำหรับ text 1 chunk
+func (c *GoogleTTSClient) synthesize(ctx context.Context, text, voiceName string) ([]byte, error) {
+	reqBody := googleSynthesizeRequest{
+		Input:       googleInput{Text: text},
+		Voice:       googleVoice{LanguageCode: c.languageCode, Name: voiceName},
+		AudioConfig: googleAudioConfig{AudioEncoding: "MP3"},
+	}
+
+	jsonBody, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", googleTTSAPIURL, bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	// ส่ง key ทาง header (ไม่ให้ key ไปโผล่ใน URL/error log)
+	req.Header.Set("X-Goog-Api-Key", c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("TTS request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, apiStatusError("Google TTS", resp.StatusCode, body)
+	}
+
+	var result googleSynthesizeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	audioData, err := base64.StdEncoding.DecodeString(result.AudioContent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode audio content: %w", err)
+	}
+
+	return audioData, nil
+}
+
+// Verify interface implementation
+var _ ports.TTSPort = (*GoogleTTSClient)(nil)
@@ -0,0 +1,63 @@
+package tts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"seo-worker/domain/ports"
+)
+
+// Provider ผู้ให้บริการ TTS ที่ตั้งชื่อไว้ (ใช้ใน log)
+type Provider struct {
+	Name string
+	TTS  ports.TTSPort
+}
+
+// ProviderChain implements TTSPort ลอง provider ตามลำดับ - ตัวแรกล้มเหลว (เช่น quota หมด) ใช้ตัวถัดไป
+// voiceID ส่งให้เฉพาะ provider แรก (voice ID ของแต่ละ provider ใช้ข้ามกันไม่ได้)
+type ProviderChain struct {
+	providers []Provider
+	logger    *slog.Logger
+}
+
+func NewProviderChain(providers ...Provider) *ProviderChain {
+	return &ProviderChain{
+		providers: providers,
+		logger:    slog.Default().With("component", "tts_chain"),
+	}
+}
+
+func (c *ProviderChain) GenerateAudio(ctx context.Context, text string, voiceID string) (*ports.TTSResult, error) {
+	var errs []error
+	for i, p := range c.providers {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		voice := voiceID
+		if i > 0 {
+			voice = "" // default voice ของ provider สำรอง
+			c.logger.WarnContext(ctx, "TTS provider failed, trying next provider",
+				"failed", c.providers[i-1].Name,
+				"next", p.Name,
+				"error", errs[len(errs)-1],
+			)
+		}
+
+		result, err := p.TTS.GenerateAudio(ctx, text, voice)
+		if err == nil {
+			return result, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", p.Name, err))
+	}
+
+	if len(errs) == 0 {
+		return nil, errors.New("no TTS provider configured")
+	}
+	return nil, errors.Join(errs...)
+}
+
+// Verify interface implementation
+var _ ports.TTSPort = (*ProviderChain)(nil)
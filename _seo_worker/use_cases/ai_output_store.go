@@ -0,0 +1,108 @@
+package use_cases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"seo-worker/domain/models"
+	"seo-worker/domain/ports"
+)
+
+// StoredAIOutput AIOutput ดิบ (ก่อน sanitize) ของ run ล่าสุดที่ publish สำเร็จ
+// เก็บค่าที่ไม่ได้มาจาก AI แต่ buildArticle ต้องใช้ไว้ด้วย - build ใหม่ได้โดยไม่เรียก Gemini/TTS
+// SchemaVersion = ArticleSchemaVersion ตอนบันทึก (AIOutput เปลี่ยนโครงสร้างตาม ArticleContent)
+// ไม่มี schemaVersion = บันทึกก่อนมี versioning
+type StoredAIOutput struct {
+	SchemaVersion  string          `json:"schemaVersion"`
+	SavedAt        time.Time       `json:"savedAt"`
+	OutputLanguage string          `json:"outputLanguage"`
+	AudioURL       string          `json:"audioUrl,omitempty"`
+	AudioDuration  int             `json:"audioDuration,omitempty"`
+	AudioVoiceID   string          `json:"audioVoiceId,omitempty"`
+	AIOutput       json.RawMessage `json:"aiOutput"`
+}
+
+// aiOutputStoragePath path ของ AIOutput ดิบบน storage
+func aiOutputStoragePath(videoCode string) string {
+	return fmt.Sprintf("ai-output/%s.json", videoCode)
+}
+
+// snapshotAIOutput สำเนา JSON ของ AIOutput - ต้องเรียกก่อน sanitizeAIOutput (แก้ struct in-place)
+func snapshotAIOutput(aiOutput *ports.AIOutput) json.RawMessage {
+	data, err := json.Marshal(aiOutput)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// saveAIOutput บันทึก AIOutput ดิบหลัง publish สำเร็จ (ล้มเหลว = warn, แค่ re-sanitize ไม่ได้)
+func (h *SEOHandler) saveAIOutput(ctx context.Context, videoCode string, stored *StoredAIOutput) {
+	if h.storage == nil || len(stored.AIOutput) == 0 {
+		return
+	}
+	stored.SchemaVersion = models.ArticleSchemaVersion
+	stored.SavedAt = time.Now()
+
+	data, err := json.Marshal(stored)
+	if err != nil {
+		h.logger.WarnContext(ctx, "Failed to marshal AI output", "video_code", videoCode, "error", err)
+		return
+	}
+
+	path := aiOutputStoragePath(videoCode)
+	if err := h.storage.Upload(ctx, path, data, "application/json"); err != nil {
+		h.logger.WarnContext(ctx, "Failed to save AI output", "path", path, "error", err)
+	}
+}
+
+// LoadAIOutput อ่าน AIOutput ดิบที่เก็บไว้ของวิดีโอ (ใช้กับ rebuild, re-sanitize, embedding ใหม่)
+func (h *SEOHandler) LoadAIOutput(ctx context.Context, videoCode string) (*StoredAIOutput, *ports.AIOutput, error) {
+	if h.storage == nil {
+		return nil, nil, fmt.Errorf("storage not configured")
+	}
+
+	path := aiOutputStoragePath(videoCode)
+	exists, err := h.storage.Exists(ctx, path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("check AI output: %w", err)
+	}
+	if !exists {
+		return nil, nil, fmt.Errorf("no stored AI output for %s", videoCode)
+	}
+
+	reader, _, err := h.storage.GetFileContent(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read AI output: %w", err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read AI output: %w", err)
+	}
+
+	var stored StoredAIOutput
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, nil, fmt.Errorf("parse AI output: %w", err)
+	}
+	var aiOutput ports.AIOutput
+	if err := json.Unmarshal(stored.AIOutput, &aiOutput); err != nil {
+		return nil, nil, fmt.Errorf("parse AI output: %w", err)
+	}
+
+	// version เก่ายังใช้ได้ - field ที่เพิ่มทีหลังเป็น zero value (buildArticle จัดการ fallback เอง)
+	if stored.SchemaVersion != models.ArticleSchemaVersion {
+		migrations := models.ArticleSchemaMigrationsSince(stored.SchemaVersion)
+		h.logger.InfoContext(ctx, "Stored AI output has older schema version",
+			"video_code", videoCode,
+			"stored_version", stored.SchemaVersion,
+			"current_version", models.ArticleSchemaVersion,
+			"migrations", len(migrations),
+		)
+	}
+	return &stored, &aiOutput, nil
+}
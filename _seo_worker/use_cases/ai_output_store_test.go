@@ -0,0 +1,88 @@
+package use_cases
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"seo-worker/domain/models"
+	"seo-worker/domain/ports"
+)
+
+// readableStorage recordingStorage ที่อ่านไฟล์ที่ upload แล้วกลับมาได้
+type readableStorage struct {
+	recordingStorage
+}
+
+func (s *readableStorage) Exists(ctx context.Context, path string) (bool, error) {
+	_, ok := s.uploads[path]
+	return ok, nil
+}
+func (s *readableStorage) GetFileContent(path string) (io.ReadCloser, int64, error) {
+	data := s.uploads[path]
+	return io.NopCloser(bytes.NewReader(data)), int64(len(data)), nil
+}
+
+func TestAIOutputStoreRoundTrip(t *testing.T) {
+	storage := &readableStorage{recordingStorage{uploads: map[string][]byte{}}}
+	h := &SEOHandler{storage: storage, logger: slog.Default()}
+	ctx := context.Background()
+
+	aiOutput := &ports.AIOutput{Title: "เซ็นมะ Mami", Highlights: []string{"a", "b"}}
+	raw := snapshotAIOutput(aiOutput)
+
+	// sanitize แก้ struct in-place - snapshot ต้องไม่เปลี่ยนตาม
+	aiOutput.Title = "Mami"
+
+	h.saveAIOutput(ctx, "ABC-123", &StoredAIOutput{
+		OutputLanguage: "th",
+		AudioURL:       "audio/articles/ABC-123/summary.mp3",
+		AudioDuration:  42,
+		AIOutput:       raw,
+	})
+	if _, ok := storage.uploads["ai-output/ABC-123.json"]; !ok {
+		t.Fatalf("AI output not uploaded, got paths %v", storage.uploads)
+	}
+
+	stored, loaded, err := h.LoadAIOutput(ctx, "ABC-123")
+	if err != nil {
+		t.Fatalf("LoadAIOutput: %v", err)
+	}
+	if loaded.Title != "เซ็นมะ Mami" {
+		t.Errorf("loaded title = %q, want pre-sanitize title", loaded.Title)
+	}
+	if len(loaded.Highlights) != 2 {
+		t.Errorf("loaded highlights = %v", loaded.Highlights)
+	}
+	if stored.OutputLanguage != "th" || stored.AudioDuration != 42 || stored.AudioURL == "" {
+		t.Errorf("stored metadata = %+v", stored)
+	}
+	if stored.SchemaVersion != models.ArticleSchemaVersion {
+		t.Errorf("schema version = %q, want %q", stored.SchemaVersion, models.ArticleSchemaVersion)
+	}
+	if stored.SavedAt.IsZero() {
+		t.Error("savedAt not set")
+	}
+
+	if _, _, err := h.LoadAIOutput(ctx, "MISSING-1"); err == nil {
+		t.Error("expected error for video without stored AI output")
+	}
+}
+
+func TestLoadAIOutputLegacyRecord(t *testing.T) {
+	storage := &readableStorage{recordingStorage{uploads: map[string][]byte{}}}
+	h := &SEOHandler{storage: storage, logger: slog.Default()}
+
+	// บันทึกก่อนมี schemaVersion
+	storage.uploads["ai-output/OLD-001.json"] = []byte(`{"outputLanguage":"th","aiOutput":{"title":"เก่า"}}`)
+
+	stored, loaded, err := h.LoadAIOutput(context.Background(), "OLD-001")
+	if err != nil {
+		t.Fatalf("LoadAIOutput: %v", err)
+	}
+	if stored.SchemaVersion != "" || loaded.Title != "เก่า" {
+		t.Errorf("legacy record = %+v, title %q", stored, loaded.Title)
+	}
+}
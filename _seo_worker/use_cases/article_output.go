@@ -0,0 +1,80 @@
+package use_cases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"seo-worker/domain/models"
+)
+
+// Article JSON output modes
+const (
+	ArticleOutputLocal   = "local"   // output/<code>_article.json (หายเมื่อ worker restart)
+	ArticleOutputStorage = "storage" // articles/<code>.json ใน object storage
+	ArticleOutputBoth    = "both"
+)
+
+// ArticleOutputConfig ที่เก็บ article JSON สำหรับ review
+type ArticleOutputConfig struct {
+	Mode string // local | storage | both (ว่าง/ไม่รู้จัก = local)
+}
+
+// SetArticleOutput ตั้งค่าที่เก็บ article JSON (ไม่ตั้ง = local อย่างเดียว)
+func (h *SEOHandler) SetArticleOutput(cfg ArticleOutputConfig) {
+	h.articleOutput = cfg
+}
+
+func (c ArticleOutputConfig) local() bool {
+	mode := strings.ToLower(c.Mode)
+	return mode != ArticleOutputStorage
+}
+
+func (c ArticleOutputConfig) remote() bool {
+	mode := strings.ToLower(c.Mode)
+	return mode == ArticleOutputStorage || mode == ArticleOutputBoth
+}
+
+// articleStoragePath path ของ article JSON ใน storage
+func articleStoragePath(videoCode string) string {
+	return fmt.Sprintf("articles/%s.json", videoCode)
+}
+
+// storeArticleJSON บันทึก article JSON ตาม mode ที่ตั้งไว้
+// ล้มเหลว = warn เท่านั้น (ไม่ block การ publish)
+func (h *SEOHandler) storeArticleJSON(ctx context.Context, article *models.ArticleContent, videoCode string) {
+	jsonData, err := json.MarshalIndent(article, "", "  ")
+	if err != nil {
+		h.logger.WarnContext(ctx, "Failed to marshal article JSON", "error", err)
+		return
+	}
+
+	if h.articleOutput.local() {
+		outputPath := fmt.Sprintf("output/%s_article.json", videoCode)
+		if err := h.saveArticleJSON(jsonData, outputPath); err != nil {
+			h.logger.WarnContext(ctx, "Failed to save article JSON", "error", err)
+		} else {
+			h.logger.InfoContext(ctx, "Article saved to JSON for review",
+				"path", outputPath,
+				"video_code", videoCode,
+			)
+		}
+	}
+
+	if h.articleOutput.remote() {
+		if h.storage == nil {
+			h.logger.WarnContext(ctx, "Storage not configured, article JSON not uploaded", "video_code", videoCode)
+			return
+		}
+		storagePath := articleStoragePath(videoCode)
+		if err := h.storage.Upload(ctx, storagePath, jsonData, "application/json"); err != nil {
+			h.logger.WarnContext(ctx, "Failed to upload article JSON", "path", storagePath, "error", err)
+		} else {
+			h.logger.InfoContext(ctx, "Article JSON uploaded to storage",
+				"path", storagePath,
+				"video_code", videoCode,
+			)
+		}
+	}
+}
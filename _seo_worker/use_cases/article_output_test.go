@@ -0,0 +1,71 @@
+package use_cases
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"seo-worker/domain/models"
+)
+
+type recordingStorage struct {
+	uploads map[string][]byte
+}
+
+func (s *recordingStorage) Upload(ctx context.Context, path string, data []byte, contentType string) error {
+	s.uploads[path] = data
+	return nil
+}
+func (s *recordingStorage) UploadReader(ctx context.Context, path string, reader io.Reader, contentType string) error {
+	return nil
+}
+func (s *recordingStorage) GetFileContent(path string) (io.ReadCloser, int64, error) {
+	return nil, 0, nil
+}
+func (s *recordingStorage) GetPublicURL(path string) string                       { return path }
+func (s *recordingStorage) Delete(ctx context.Context, path string) error         { return nil }
+func (s *recordingStorage) Exists(ctx context.Context, path string) (bool, error) { return false, nil }
+func (s *recordingStorage) ListFiles(prefix string) ([]string, error)             { return nil, nil }
+func (s *recordingStorage) GetPresignedDownloadURL(path string, expiry time.Duration) (string, error) {
+	return path, nil
+}
+
+func TestArticleOutputModes(t *testing.T) {
+	tests := []struct {
+		mode          string
+		local, remote bool
+	}{
+		{"", true, false},
+		{"local", true, false},
+		{"storage", false, true},
+		{"both", true, true},
+		{"BOTH", true, true},
+		{"unknown", true, false},
+	}
+
+	for _, tt := range tests {
+		cfg := ArticleOutputConfig{Mode: tt.mode}
+		if cfg.local() != tt.local || cfg.remote() != tt.remote {
+			t.Errorf("mode %q: local=%v remote=%v, want local=%v remote=%v",
+				tt.mode, cfg.local(), cfg.remote(), tt.local, tt.remote)
+		}
+	}
+}
+
+func TestStoreArticleJSONUploadsToStorage(t *testing.T) {
+	storage := &recordingStorage{uploads: map[string][]byte{}}
+	h := &SEOHandler{storage: storage, logger: slog.Default()}
+	h.SetArticleOutput(ArticleOutputConfig{Mode: ArticleOutputStorage})
+
+	h.storeArticleJSON(context.Background(), &models.ArticleContent{Title: "Test"}, "ABC-123")
+
+	data, ok := storage.uploads["articles/ABC-123.json"]
+	if !ok {
+		t.Fatalf("article JSON not uploaded, got paths %v", storage.uploads)
+	}
+	if len(data) == 0 {
+		t.Error("uploaded article JSON is empty")
+	}
+}
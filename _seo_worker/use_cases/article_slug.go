@@ -0,0 +1,74 @@
+package use_cases
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"seo-worker/domain/models"
+)
+
+// maxSlugAttempts จำนวน suffix ที่ลองก่อนยอมใช้ slug ตัวสุดท้าย (-2 ... -20)
+const maxSlugAttempts = 20
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// normalizeSlug แปลงเป็น slug ที่ใช้ใน URL ได้
+// lowercase → อักขระที่ไม่ใช่ a-z0-9 เป็น "-" (ติดกันหลายตัว = ตัวเดียว) → ตัด "-" หัวท้าย
+// เช่น "DLDSS 471 (Uncensored)" → "dldss-471-uncensored"
+func normalizeSlug(s string) string {
+	s = nonSlugChars.ReplaceAllString(strings.ToLower(s), "-")
+	return strings.Trim(s, "-")
+}
+
+// baseArticleSlug slug ตั้งต้นจาก RealCode (movie code เช่น DLDSS-471)
+// ไม่มี RealCode หรือ normalize แล้วว่าง = fallback เป็น internal code
+func baseArticleSlug(job *models.SEOArticleJob, metadata *models.VideoMetadata) string {
+	if slug := normalizeSlug(metadata.RealCode); slug != "" {
+		return slug
+	}
+	if slug := normalizeSlug(job.VideoCode); slug != "" {
+		return slug
+	}
+	return job.VideoCode
+}
+
+// resolveArticleSlug เช็คว่า slug ซ้ำกับ article ของวิดีโออื่นหรือไม่ ซ้ำ = ต่อท้าย -2, -3, ...
+// slug ที่เป็นของวิดีโอนี้เอง (publish ซ้ำ) ใช้ต่อได้
+// เช็คไม่ได้ = ใช้ slug ที่กำลังลองอยู่ (ไม่ให้ publish ล้มเพราะ lookup)
+func (h *SEOHandler) resolveArticleSlug(ctx context.Context, videoID, base string) string {
+	candidate := base
+	for attempt := 1; attempt <= maxSlugAttempts; attempt++ {
+		if attempt > 1 {
+			candidate = fmt.Sprintf("%s-%d", base, attempt)
+		}
+
+		owner, err := h.articlePublisher.GetArticleSlugOwner(ctx, candidate)
+		if err != nil {
+			h.logger.WarnContext(ctx, "Failed to check article slug, using as-is",
+				"video_id", videoID,
+				"slug", candidate,
+				"error", err,
+			)
+			return candidate
+		}
+		if owner == "" || owner == videoID {
+			if candidate != base {
+				h.logger.InfoContext(ctx, "Article slug taken, using suffix",
+					"video_id", videoID,
+					"base_slug", base,
+					"slug", candidate,
+				)
+			}
+			return candidate
+		}
+	}
+
+	h.logger.WarnContext(ctx, "No free article slug found",
+		"video_id", videoID,
+		"base_slug", base,
+		"slug", candidate,
+	)
+	return candidate
+}
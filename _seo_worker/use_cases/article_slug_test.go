@@ -0,0 +1,103 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"seo-worker/domain/models"
+	"seo-worker/domain/ports"
+)
+
+// stubSlugPublisher คืน owner ของ slug จาก map
+type stubSlugPublisher struct {
+	ports.ArticlePublisherPort
+	owners  map[string]string
+	err     error
+	lookups []string
+}
+
+func (p *stubSlugPublisher) GetArticleSlugOwner(ctx context.Context, slug string) (string, error) {
+	p.lookups = append(p.lookups, slug)
+	if p.err != nil {
+		return "", p.err
+	}
+	return p.owners[slug], nil
+}
+
+func TestNormalizeSlug(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"DLDSS-471", "dldss-471"},
+		{"DLDSS 471", "dldss-471"},
+		{"  SSIS_001 (Uncensored) ", "ssis-001-uncensored"},
+		{"abc--123", "abc-123"},
+		{"--abc--", "abc"},
+		{"ไทย", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := normalizeSlug(tt.in); got != tt.want {
+			t.Errorf("normalizeSlug(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestBaseArticleSlug(t *testing.T) {
+	job := &models.SEOArticleJob{VideoCode: "3993bp6j"}
+
+	if got := baseArticleSlug(job, &models.VideoMetadata{RealCode: "DASS 541"}); got != "dass-541" {
+		t.Errorf("with RealCode = %q, want dass-541", got)
+	}
+	if got := baseArticleSlug(job, &models.VideoMetadata{RealCode: "!!!"}); got != "3993bp6j" {
+		t.Errorf("invalid RealCode = %q, want fallback 3993bp6j", got)
+	}
+}
+
+func TestResolveArticleSlug(t *testing.T) {
+	t.Run("Free slug", func(t *testing.T) {
+		pub := &stubSlugPublisher{}
+		h := &SEOHandler{articlePublisher: pub, logger: slog.Default()}
+
+		if got := h.resolveArticleSlug(context.Background(), "v1", "dass-541"); got != "dass-541" {
+			t.Errorf("slug = %q, want dass-541", got)
+		}
+	})
+
+	t.Run("Own slug on republish", func(t *testing.T) {
+		pub := &stubSlugPublisher{owners: map[string]string{"dass-541": "v1"}}
+		h := &SEOHandler{articlePublisher: pub, logger: slog.Default()}
+
+		if got := h.resolveArticleSlug(context.Background(), "v1", "dass-541"); got != "dass-541" {
+			t.Errorf("slug = %q, want dass-541", got)
+		}
+	})
+
+	t.Run("Collision appends suffix", func(t *testing.T) {
+		pub := &stubSlugPublisher{owners: map[string]string{
+			"dass-541":   "other",
+			"dass-541-2": "another",
+		}}
+		h := &SEOHandler{articlePublisher: pub, logger: slog.Default()}
+
+		if got := h.resolveArticleSlug(context.Background(), "v1", "dass-541"); got != "dass-541-3" {
+			t.Errorf("slug = %q, want dass-541-3", got)
+		}
+		if len(pub.lookups) != 3 {
+			t.Errorf("lookups = %v, want 3", pub.lookups)
+		}
+	})
+
+	t.Run("Lookup error keeps slug", func(t *testing.T) {
+		pub := &stubSlugPublisher{err: errors.New("api down")}
+		h := &SEOHandler{articlePublisher: pub, logger: slog.Default()}
+
+		if got := h.resolveArticleSlug(context.Background(), "v1", "dass-541"); got != "dass-541" {
+			t.Errorf("slug = %q, want dass-541", got)
+		}
+	})
+}
@@ -0,0 +1,89 @@
+package use_cases
+
+import (
+	"strings"
+
+	"seo-worker/domain/models"
+	"seo-worker/domain/ports"
+)
+
+// contextualLinkHallucinationWarnRate สัดส่วน link ที่ slug ไม่อยู่ใน allowlist ที่ควรกลับไปปรับ prompt (log warn)
+const contextualLinkHallucinationWarnRate = 0.3
+
+// filterValidContextualLinks กรอง contextual links ที่ valid
+// - slug ต้องมีอยู่จริง (ป้องกัน AI แต่ง slug ขึ้นมาเอง) - เทียบแบบไม่สนตัวพิมพ์ แล้วใช้ slug จริงแทน
+// - ห้าม link ไปหาตัวเอง (self-reference)
+// - เพิ่ม ThumbnailUrl จาก validArticles
+// log hallucination rate (slug ที่ไม่อยู่ใน allowlist / links ทั้งหมด) ไว้วัดผลของ prompt
+func (h *SEOHandler) filterValidContextualLinks(
+	links []models.ContextualLink,
+	validArticles []ports.RelatedArticleForAI,
+	currentSlug string,
+) []models.ContextualLink {
+	if len(links) == 0 {
+		return nil
+	}
+
+	// สร้าง map ของ valid slugs -> article data (รวม ThumbnailUrl)
+	validArticleMap := make(map[string]ports.RelatedArticleForAI)
+	for _, article := range validArticles {
+		validArticleMap[normalizeLinkSlug(article.Slug)] = article
+	}
+
+	// กรองเฉพาะ links ที่:
+	// 1. slug อยู่ใน valid slugs
+	// 2. ไม่ใช่ตัวเอง (self-reference)
+	filtered := make([]models.ContextualLink, 0, len(links))
+	invalid, selfReference := 0, 0
+	for _, link := range links {
+		slug := normalizeLinkSlug(link.LinkedSlug)
+
+		// ห้าม link ไปหาตัวเอง
+		if slug == normalizeLinkSlug(currentSlug) {
+			selfReference++
+			h.logger.Warn("Filtered out self-referencing contextual link",
+				"slug", link.LinkedSlug,
+				"reason", "self-reference",
+			)
+			continue
+		}
+
+		if article, ok := validArticleMap[slug]; ok {
+			// ใช้ slug จริง + เพิ่ม ThumbnailUrl และ QualityScore จาก validArticles
+			link.LinkedSlug = article.Slug
+			link.ThumbnailUrl = article.ThumbnailUrl
+			link.QualityScore = article.QualityScore
+			filtered = append(filtered, link)
+		} else {
+			invalid++
+			h.logger.Warn("Filtered out invalid contextual link",
+				"slug", link.LinkedSlug,
+				"reason", "slug not in valid articles",
+			)
+		}
+	}
+
+	hallucinationRate := float64(invalid) / float64(len(links))
+	h.logger.Info("Filtered contextual links",
+		"original", len(links),
+		"valid", len(filtered),
+		"invalid", invalid,
+		"self_reference", selfReference,
+		"allowlist", len(validArticles),
+		"hallucination_rate", hallucinationRate,
+	)
+	if hallucinationRate >= contextualLinkHallucinationWarnRate {
+		h.logger.Warn("High contextual link hallucination rate - review related articles prompt",
+			"invalid", invalid,
+			"original", len(links),
+			"hallucination_rate", hallucinationRate,
+		)
+	}
+
+	return filtered
+}
+
+// normalizeLinkSlug slug สำหรับเทียบ (AI มักตอบเป็นตัวใหญ่ตาม Code)
+func normalizeLinkSlug(slug string) string {
+	return strings.ToLower(strings.TrimSpace(slug))
+}
@@ -0,0 +1,39 @@
+package use_cases
+
+import (
+	"log/slog"
+	"testing"
+
+	"seo-worker/domain/models"
+	"seo-worker/domain/ports"
+)
+
+func TestFilterValidContextualLinks(t *testing.T) {
+	h := &SEOHandler{logger: slog.Default()}
+	related := []ports.RelatedArticleForAI{
+		{Slug: "dldss-470", ThumbnailUrl: "https://cdn.example/dldss-470.jpg", QualityScore: 8},
+		{Slug: "abc-123"},
+	}
+	links := []models.ContextualLink{
+		{Text: "ลองดู", LinkedSlug: "DLDSS-470"}, // ตัวพิมพ์ใหญ่ตาม Code = ยังนับว่า valid
+		{Text: "แต่งขึ้นเอง", LinkedSlug: "xyz-999"},
+		{Text: "ตัวเอง", LinkedSlug: "self-001"},
+		{Text: "อีกเรื่อง", LinkedSlug: "abc-123"},
+	}
+
+	got := h.filterValidContextualLinks(links, related, "self-001")
+
+	if len(got) != 2 {
+		t.Fatalf("links = %+v, want 2 valid", got)
+	}
+	if got[0].LinkedSlug != "dldss-470" || got[0].ThumbnailUrl == "" || got[0].QualityScore != 8 {
+		t.Errorf("links[0] = %+v, want canonical slug with thumbnail and score", got[0])
+	}
+	if got[1].LinkedSlug != "abc-123" {
+		t.Errorf("links[1].linkedSlug = %q, want abc-123", got[1].LinkedSlug)
+	}
+
+	if got := h.filterValidContextualLinks(links, nil, "self-001"); len(got) != 0 {
+		t.Errorf("links without related articles = %+v, want none", got)
+	}
+}
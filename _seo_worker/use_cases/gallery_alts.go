@@ -0,0 +1,40 @@
+package use_cases
+
+import (
+	"fmt"
+	"strings"
+
+	"seo-worker/domain/models"
+)
+
+// assignGalleryAlts ใส่ alt ให้ gallery images ทุกภาพ - ไม่ว่างและไม่ซ้ำกัน
+// - ใช้ AI alt ตามลำดับ (alt ที่มีคำต้องห้ามถูกล้างเป็นค่าว่างตั้งแต่ AI post-process)
+// - alt ว่าง / AI generate ไม่พอ = "ฉากจาก <code>"
+// - alt ซ้ำ (ไม่สนตัวพิมพ์/ช่องว่าง) = ต่อท้ายด้วย "(ภาพที่ N)"
+func assignGalleryAlts(images []models.GalleryImage, alts []string, realCode string) {
+	seen := make(map[string]bool, len(images))
+	for i := range images {
+		alt := ""
+		if i < len(alts) {
+			alt = strings.TrimSpace(alts[i])
+		}
+		if alt == "" {
+			alt = fmt.Sprintf("ฉากจาก %s", realCode)
+		}
+
+		if seen[altKey(alt)] {
+			base := alt
+			for n := i + 1; seen[altKey(alt)]; n++ {
+				alt = fmt.Sprintf("%s (ภาพที่ %d)", base, n)
+			}
+		}
+
+		seen[altKey(alt)] = true
+		images[i].Alt = alt
+	}
+}
+
+// altKey key สำหรับเทียบ alt ซ้ำ
+func altKey(alt string) string {
+	return strings.ToLower(strings.Join(strings.Fields(alt), " "))
+}
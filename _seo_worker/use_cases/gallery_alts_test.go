@@ -0,0 +1,41 @@
+package use_cases
+
+import (
+	"testing"
+
+	"seo-worker/domain/models"
+)
+
+func TestAssignGalleryAlts(t *testing.T) {
+	images := make([]models.GalleryImage, 6)
+	alts := []string{
+		"นางเอกยืนริมหน้าต่าง",
+		"",
+		"นางเอกยืนริมหน้าต่าง",
+		"  นางเอกยืนริมหน้าต่าง ",
+	}
+
+	assignGalleryAlts(images, alts, "ABC-123")
+
+	expected := []string{
+		"นางเอกยืนริมหน้าต่าง",
+		"ฉากจาก ABC-123",
+		"นางเอกยืนริมหน้าต่าง (ภาพที่ 3)",
+		"นางเอกยืนริมหน้าต่าง (ภาพที่ 4)",
+		"ฉากจาก ABC-123 (ภาพที่ 5)",
+		"ฉากจาก ABC-123 (ภาพที่ 6)",
+	}
+	seen := map[string]bool{}
+	for i, img := range images {
+		if img.Alt != expected[i] {
+			t.Errorf("images[%d].Alt = %q, want %q", i, img.Alt, expected[i])
+		}
+		if img.Alt == "" {
+			t.Errorf("images[%d].Alt is empty", i)
+		}
+		if seen[altKey(img.Alt)] {
+			t.Errorf("images[%d].Alt %q is duplicated", i, img.Alt)
+		}
+		seen[altKey(img.Alt)] = true
+	}
+}
@@ -0,0 +1,21 @@
+package use_cases
+
+import "seo-worker/domain/models"
+
+// SetGalleryAudience ตั้ง tier ที่เป็น public/member ของ gallery ในบทความ
+// ไม่ตั้ง/ไม่ถูกต้อง = safe เป็น public, nsfw เป็น member
+func (h *SEOHandler) SetGalleryAudience(audience models.GalleryAudience) {
+	if err := audience.Validate(); err != nil {
+		h.logger.Warn("Invalid gallery audience, using default", "error", err)
+		audience = models.DefaultGalleryAudience()
+	}
+	h.galleryAudience = audience
+}
+
+// audience mapping ที่ใช้กับ job (ยังไม่ได้ตั้ง = default)
+func (h *SEOHandler) audience() models.GalleryAudience {
+	if len(h.galleryAudience.Public) == 0 {
+		return models.DefaultGalleryAudience()
+	}
+	return h.galleryAudience
+}
@@ -0,0 +1,61 @@
+package use_cases
+
+import (
+	"log/slog"
+	"reflect"
+	"testing"
+
+	"seo-worker/domain/models"
+)
+
+func TestGalleryAudienceSplit(t *testing.T) {
+	tiered := &models.TieredGalleryImages{
+		SuperSafe: []string{"ss1"},
+		Safe:      []string{"sf1", "sf2"},
+		NSFW:      []string{"ns1"},
+	}
+
+	tests := []struct {
+		name       string
+		public     []string
+		member     []string
+		wantPublic []string
+		wantMember []string
+		wantErr    bool
+	}{
+		{"Default", []string{"safe"}, []string{"nsfw"}, []string{"sf1", "sf2"}, []string{"ns1"}, false},
+		{"Super safe only public", []string{"super_safe"}, []string{"safe", "nsfw"}, []string{"ss1"}, []string{"sf1", "sf2", "ns1"}, false},
+		{"Nsfw unused", []string{"super_safe", "safe"}, nil, []string{"ss1", "sf1", "sf2"}, nil, false},
+		{"Unknown tier", []string{"safe"}, []string{"explicit"}, nil, nil, true},
+		{"Tier in both", []string{"safe"}, []string{"safe", "nsfw"}, nil, nil, true},
+		{"No public tier", nil, []string{"nsfw"}, nil, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			audience, err := models.ParseGalleryAudience(tt.public, tt.member)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			public, member := audience.Split(tiered)
+			if !reflect.DeepEqual(public, tt.wantPublic) || !reflect.DeepEqual(member, tt.wantMember) {
+				t.Errorf("Split = %v / %v, want %v / %v", public, member, tt.wantPublic, tt.wantMember)
+			}
+		})
+	}
+}
+
+func TestSetGalleryAudienceInvalidUsesDefault(t *testing.T) {
+	h := &SEOHandler{logger: slog.Default()}
+	if got := h.audience(); !reflect.DeepEqual(got, models.DefaultGalleryAudience()) {
+		t.Errorf("unset audience = %+v, want default", got)
+	}
+
+	h.SetGalleryAudience(models.GalleryAudience{Public: []models.GalleryTier{"explicit"}})
+	if got := h.audience(); !reflect.DeepEqual(got, models.DefaultGalleryAudience()) {
+		t.Errorf("invalid audience = %+v, want default", got)
+	}
+}
@@ -0,0 +1,63 @@
+package use_cases
+
+import (
+	"context"
+	"fmt"
+
+	"seo-worker/domain/models"
+)
+
+// GalleryRequirementConfig เงื่อนไข gallery ก่อนเริ่มสร้างบทความ
+type GalleryRequirementConfig struct {
+	MinPublicImages int  // ภาพ safe (public) ขั้นต่ำ - <= 0 = ไม่ตรวจ
+	AutoGenerate    bool // ยังไม่มี gallery เลย = สั่ง suekk สร้าง gallery ให้ก่อน defer job
+}
+
+// SetGalleryRequirement ตั้งค่าจำนวนภาพ gallery ขั้นต่ำ (ไม่ตั้ง = ไม่ตรวจ)
+func (h *SEOHandler) SetGalleryRequirement(cfg GalleryRequirementConfig) {
+	h.galleryRequirement = cfg
+}
+
+// checkGalleryRequirement ตรวจว่า gallery พร้อมสำหรับ SEO หรือไม่
+// info = nil คือดึงข้อมูลจาก suekk ไม่ได้ (ตรวจไม่ได้ = ยังไม่พร้อม)
+// คืน error ที่ wrap models.ErrGalleryNotReady พร้อมเหตุผล
+func (h *SEOHandler) checkGalleryRequirement(ctx context.Context, info *models.SuekkVideoInfo) error {
+	minImages := h.galleryRequirement.MinPublicImages
+	if minImages <= 0 {
+		return nil
+	}
+	if info == nil {
+		return fmt.Errorf("%w: cannot verify gallery (Suekk video info unavailable)", models.ErrGalleryNotReady)
+	}
+	if info.GallerySafeCount >= minImages {
+		return nil
+	}
+
+	// ยังไม่มี gallery เลย → สั่งสร้าง (มี gallery แล้วแต่ safe ไม่พอ = รอ admin คัดภาพ สร้างใหม่ไม่ช่วย)
+	if info.GalleryCount == 0 {
+		if h.galleryRequirement.AutoGenerate && info.ID != "" {
+			if err := h.suekkVideoFetcher.RequestGalleryGeneration(ctx, info.ID); err != nil {
+				h.logger.WarnContext(ctx, "Failed to request gallery generation",
+					"video_code", info.Code,
+					"error", err,
+				)
+			} else {
+				return fmt.Errorf("%w: video has no gallery, generation requested (need %d public images)",
+					models.ErrGalleryNotReady, minImages)
+			}
+		}
+		return fmt.Errorf("%w: video has no gallery (need %d public images)", models.ErrGalleryNotReady, minImages)
+	}
+
+	return fmt.Errorf("%w: %d public images, need %d (%d awaiting review)",
+		models.ErrGalleryNotReady, info.GallerySafeCount, minImages,
+		max(info.GalleryCount-info.GallerySafeCount-info.GalleryNsfwCount, 0))
+}
+
+// suekkVideoInfoOrNil nil เมื่อดึง video info ไม่สำเร็จ
+func suekkVideoInfoOrNil(info *models.SuekkVideoInfo, err error) *models.SuekkVideoInfo {
+	if err != nil {
+		return nil
+	}
+	return info
+}
@@ -0,0 +1,59 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"seo-worker/domain/models"
+	"seo-worker/domain/ports"
+)
+
+// galleryRequestFetcher บันทึก video ID ที่ถูกสั่ง generate gallery
+type galleryRequestFetcher struct {
+	ports.SuekkVideoFetcherPort
+	requested []string
+}
+
+func (f *galleryRequestFetcher) RequestGalleryGeneration(ctx context.Context, videoID string) error {
+	f.requested = append(f.requested, videoID)
+	return nil
+}
+
+func TestCheckGalleryRequirement(t *testing.T) {
+	tests := []struct {
+		name         string
+		cfg          GalleryRequirementConfig
+		info         *models.SuekkVideoInfo
+		wantErr      bool
+		wantRequests int
+	}{
+		{"Disabled", GalleryRequirementConfig{}, nil, false, 0},
+		{"Enough public images", GalleryRequirementConfig{MinPublicImages: 3}, &models.SuekkVideoInfo{GalleryCount: 10, GallerySafeCount: 3}, false, 0},
+		{"Too few public images", GalleryRequirementConfig{MinPublicImages: 3}, &models.SuekkVideoInfo{GalleryCount: 10, GallerySafeCount: 2}, true, 0},
+		{"Info unavailable", GalleryRequirementConfig{MinPublicImages: 3}, nil, true, 0},
+		{"No gallery without auto-generate", GalleryRequirementConfig{MinPublicImages: 3}, &models.SuekkVideoInfo{ID: "v1"}, true, 0},
+		{"No gallery with auto-generate", GalleryRequirementConfig{MinPublicImages: 3, AutoGenerate: true}, &models.SuekkVideoInfo{ID: "v1"}, true, 1},
+		{"Pending review is not regenerated", GalleryRequirementConfig{MinPublicImages: 3, AutoGenerate: true}, &models.SuekkVideoInfo{ID: "v1", GalleryCount: 20}, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetcher := &galleryRequestFetcher{}
+			h := &SEOHandler{suekkVideoFetcher: fetcher, logger: slog.Default()}
+			h.SetGalleryRequirement(tt.cfg)
+
+			err := h.checkGalleryRequirement(context.Background(), tt.info)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, models.ErrGalleryNotReady) {
+				t.Errorf("err = %v, want ErrGalleryNotReady", err)
+			}
+			if len(fetcher.requested) != tt.wantRequests {
+				t.Errorf("gallery generation requested %d times, want %d", len(fetcher.requested), tt.wantRequests)
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package use_cases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"seo-worker/domain/models"
+	"seo-worker/domain/ports"
+	"seo-worker/infrastructure/ai"
+)
+
+// recordedDir คือ fixtures ของ Gemini response (1 ไฟล์ต่อ chunk)
+const recordedDir = "testdata/gemini"
+
+// runRecordedPipeline รัน 7-chunk V2 pipeline กับ recorded responses
+// chdir ไป temp dir เพราะ pipeline เขียน state file ลง output/
+func runRecordedPipeline(t *testing.T) *ports.AIOutput {
+	t.Helper()
+
+	dir, err := filepath.Abs(recordedDir)
+	if err != nil {
+		t.Fatalf("resolve fixtures: %v", err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	client := ai.NewGeminiClientWithGenerator("recorded", ai.NewRecordedGenerator(dir))
+	defer client.Close()
+
+	input := &ports.AIInput{
+		SRTContent: "1\n00:00:30,000 --> 00:00:33,000\nวันนี้ขอบคุณมากนะคะ\n",
+		VideoMetadata: &models.VideoMetadata{
+			Code:     "testcode",
+			RealCode: "TEST-001",
+			Duration: 3600,
+		},
+		Casts:          []models.CastMetadata{{ID: "cast-1", Name: "Zemba Mami", Slug: "zemba-mami"}},
+		OutputLanguage: "th",
+	}
+
+	output, err := client.GenerateArticleContentV2(context.Background(), input)
+	if err != nil {
+		t.Fatalf("GenerateArticleContentV2 failed: %v", err)
+	}
+	return output
+}
+
+func TestRecordedPipelineAggregate(t *testing.T) {
+	output := runRecordedPipeline(t)
+
+	checks := []struct {
+		field string
+		got   string
+		want  string
+	}{
+		{"title (chunk1)", output.Title, "TEST-001 เรื่องราวความสัมพันธ์ในที่ทำงานที่ซับซ้อน"},
+		{"dialogueAnalysis (chunk3)", output.DialogueAnalysis, "บทสนทนาใช้ภาษาสุภาพแบบที่ทำงาน"},
+		{"expertAnalysis (chunk4)", output.ExpertAnalysis, "บทวิเคราะห์จากมุมมองผู้เชี่ยวชาญ"},
+		{"plotAnalysis (chunk5)", output.PlotAnalysis, "โครงเรื่องเดินหน้าอย่างช้า ๆ"},
+		{"translationMethod (chunk6)", output.TranslationMethod, "แปลโดยทีมงานพร้อมตรวจทาน"},
+		{"cinematographyAnalysis (chunk7)", output.CinematographyAnalysis, "งานภาพใช้แสงธรรมชาติเป็นหลัก"},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("%s = %q, want %q", c.field, c.got, c.want)
+		}
+	}
+
+	if len(output.Highlights) != 3 {
+		t.Errorf("highlights = %d, want 3", len(output.Highlights))
+	}
+	if len(output.FAQItems) != 2 {
+		t.Errorf("faqItems = %d, want 2", len(output.FAQItems))
+	}
+	if len(output.EmotionalArc) != 2 {
+		t.Errorf("emotionalArc = %d, want 2", len(output.EmotionalArc))
+	}
+}
+
+func TestRecordedPipelineSafeMoments(t *testing.T) {
+	output := runRecordedPipeline(t)
+
+	// fixture: 30s, 45s (bucket ซ้ำ), 150s (blacklist), 300s, 900s (เกิน 600s)
+	// เหลือ 30s, 300s แล้วเติม seed 120s ให้ครบ minimum 3
+	wantStarts := []int{30, 120, 300}
+	if len(output.KeyMoments) != len(wantStarts) {
+		t.Fatalf("keyMoments = %+v, want starts %v", output.KeyMoments, wantStarts)
+	}
+	for i, m := range output.KeyMoments {
+		if m.StartOffset != wantStarts[i] {
+			t.Errorf("keyMoments[%d].startOffset = %d, want %d", i, m.StartOffset, wantStarts[i])
+		}
+		if strings.Contains(strings.ToLower(m.Name), "sex") {
+			t.Errorf("blacklisted key moment not filtered: %q", m.Name)
+		}
+	}
+
+	if len(output.TopQuotes) != 1 || output.TopQuotes[0].Timestamp != 60 {
+		t.Errorf("topQuotes = %+v, want only timestamp 60", output.TopQuotes)
+	}
+}
+
+func TestRecordedPipelineSanitize(t *testing.T) {
+	output := runRecordedPipeline(t)
+
+	h := &SEOHandler{logger: slog.Default()}
+	h.sanitizeAIOutput(output, []models.CastMetadata{{ID: "cast-1", Name: "Zemba Mami", Slug: "zemba-mami"}}, "th")
+
+	if strings.Contains(output.DetailedReview, "-->") {
+		t.Errorf("SRT artifact not stripped: %q", output.DetailedReview)
+	}
+	if strings.Contains(output.DetailedReview, "[PARA]") || !strings.Contains(output.DetailedReview, "\n\n") {
+		t.Errorf("paragraph markers not converted: %q", output.DetailedReview)
+	}
+}
+
+func TestRecordedChunkValidation(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join(recordedDir, "chunk1v2.json"))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+
+	var chunk1 ai.Chunk1OutputV2
+	if err := json.Unmarshal(data, &chunk1); err != nil {
+		t.Fatalf("parse fixture: %v", err)
+	}
+
+	// fixture มี summary สั้นกว่า 800 ตัวอักษร → ต้องได้ error ที่ field summary
+	result := ai.ValidateChunk1V2(&chunk1, "th")
+	found := false
+	for _, e := range result.Errors {
+		if e.Field == "summary" {
+			found = true
+		}
+		if e.Field == "title" || e.Field == "mainTheme" || e.Field == "mainTone" {
+			t.Errorf("unexpected validation error: %+v", e)
+		}
+	}
+	if !found {
+		t.Errorf("expected summary length error, got %+v", result.Errors)
+	}
+}
+
+func TestValidateAIOutput(t *testing.T) {
+	if err := ai.ValidateAIOutput(runRecordedPipeline(t)); err != nil {
+		t.Errorf("recorded output should be complete, got %v", err)
+	}
+
+	// schema drift: chunk สำเร็จแต่ field หาย / alt ถูกกรองจนว่างหมด
+	output := &ports.AIOutput{
+		Title:       "TEST-001 เรื่องราว",
+		Summary:     "  ",
+		GalleryAlts: []string{"", " "},
+	}
+	err := ai.ValidateAIOutput(output)
+	var incomplete *ai.IncompleteOutputError
+	if !errors.As(err, &incomplete) {
+		t.Fatalf("expected IncompleteOutputError, got %v", err)
+	}
+	want := []string{"summary", "detailedReview", "galleryAlts"}
+	if strings.Join(incomplete.Missing, ",") != strings.Join(want, ",") {
+		t.Errorf("missing = %v, want %v", incomplete.Missing, want)
+	}
+}
+
+func TestPreviewSafeMomentsReasons(t *testing.T) {
+	client := ai.NewGeminiClientWithGenerator("recorded", ai.NewRecordedGenerator(recordedDir))
+	defer client.Close()
+
+	input := &ports.AIInput{
+		VideoMetadata:  &models.VideoMetadata{Code: "testcode", Duration: 3600},
+		OutputLanguage: "th",
+	}
+	cached := []models.KeyMoment{
+		{Name: "เปิดเรื่อง", StartOffset: 30, EndOffset: 60},
+		{Name: "ต่อเนื่อง", StartOffset: 45, EndOffset: 75},
+		{Name: "sex scene", StartOffset: 150, EndOffset: 180},
+		{Name: "พูดคุย", StartOffset: 300, EndOffset: 330},
+		{Name: "ช่วงท้าย", StartOffset: 900, EndOffset: 930},
+	}
+
+	preview, err := client.PreviewSafeMoments(context.Background(), input, cached)
+	if err != nil {
+		t.Fatalf("PreviewSafeMoments failed: %v", err)
+	}
+
+	if preview.Source != models.SafeMomentsSourceCached {
+		t.Errorf("source = %q, want cached", preview.Source)
+	}
+	if len(preview.Input) != len(cached) {
+		t.Errorf("input = %d, want %d", len(preview.Input), len(cached))
+	}
+
+	wantDropped := map[int]string{
+		150: models.SafeMomentDropBlacklist,
+		900: models.SafeMomentDropTimeLimit,
+		45:  models.SafeMomentDropDedup,
+	}
+	if len(preview.Dropped) != len(wantDropped) {
+		t.Fatalf("dropped = %+v, want %v", preview.Dropped, wantDropped)
+	}
+	for _, d := range preview.Dropped {
+		if wantDropped[d.StartOffset] != d.Reason {
+			t.Errorf("dropped %ds reason = %q, want %q", d.StartOffset, d.Reason, wantDropped[d.StartOffset])
+		}
+	}
+
+	if len(preview.Seeds) != 1 || preview.Seeds[0].StartOffset != 120 {
+		t.Errorf("seeds = %+v, want one seed at 120s", preview.Seeds)
+	}
+
+	wantPublic := []int{30, 120, 300}
+	if len(preview.Public) != len(wantPublic) {
+		t.Fatalf("public = %+v, want starts %v", preview.Public, wantPublic)
+	}
+	for i, m := range preview.Public {
+		if m.StartOffset != wantPublic[i] {
+			t.Errorf("public[%d].startOffset = %d, want %d", i, m.StartOffset, wantPublic[i])
+		}
+	}
+}
+
+func TestPreviewSafeMomentsSeedTemplates(t *testing.T) {
+	client := ai.NewGeminiClientWithGenerator("recorded", ai.NewRecordedGenerator(recordedDir))
+	defer client.Close()
+
+	templates, err := ai.ParseSeedMomentTemplates([]byte(`{
+		"EN": [
+			{"name": "Opening", "startOffset": 0, "endOffset": 90},
+			{"name": "Too long", "startOffset": 200, "endOffset": 400}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("ParseSeedMomentTemplates failed: %v", err)
+	}
+	client.SetSeedMoments(templates)
+
+	cached := []models.KeyMoment{{Name: "Talk", StartOffset: 120, EndOffset: 150}}
+	tests := []struct {
+		lang      string
+		wantSeeds []string
+	}{
+		{"en", []string{"Opening"}}, // "Too long" จบหลังวิดีโอ (300s)
+		{"th", nil},                 // ไม่มี template ของ th แล้ว
+	}
+	for _, tt := range tests {
+		input := &ports.AIInput{
+			VideoMetadata:  &models.VideoMetadata{Code: "testcode", Duration: 300},
+			OutputLanguage: tt.lang,
+		}
+		preview, err := client.PreviewSafeMoments(context.Background(), input, cached)
+		if err != nil {
+			t.Fatalf("[%s] PreviewSafeMoments failed: %v", tt.lang, err)
+		}
+
+		var got []string
+		for _, seed := range preview.Seeds {
+			got = append(got, seed.Name)
+		}
+		if strings.Join(got, ",") != strings.Join(tt.wantSeeds, ",") {
+			t.Errorf("[%s] seeds = %v, want %v", tt.lang, got, tt.wantSeeds)
+		}
+	}
+
+	if _, err := ai.ParseSeedMomentTemplates([]byte(`{"th": [{"name": "", "startOffset": 10, "endOffset": 5}]}`)); err == nil {
+		t.Error("ParseSeedMomentTemplates accepted invalid seed")
+	}
+}
@@ -0,0 +1,96 @@
+package use_cases
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"seo-worker/domain/models"
+)
+
+// inputHashStoragePath path ของ input hash ของ run ล่าสุดที่ publish สำเร็จ (เก็บคู่กับ article JSON)
+func inputHashStoragePath(videoCode string) string {
+	return fmt.Sprintf("articles/%s.input-hash", videoCode)
+}
+
+// computeInputHash hash ของวัตถุดิบทั้งหมดที่มีผลต่อบทความ (SRT, metadata, gallery, cover, ภาษา, TTS)
+// gallery ใช้ชื่อไฟล์เรียงแล้ว - URL เป็น presigned (query เปลี่ยนทุกครั้ง) และลำดับจาก storage ไม่แน่นอน
+func computeInputHash(job *models.SEOArticleJob, srtContent string, metadata *models.VideoMetadata, tiered *models.TieredGalleryImages, coverOverride string) string {
+	h := sha256.New()
+
+	writeField := func(name, value string) {
+		fmt.Fprintf(h, "%s:%d:%s\n", name, len(value), value)
+	}
+
+	writeField("srt", srtContent)
+
+	metaJSON, _ := json.Marshal(metadata)
+	writeField("metadata", string(metaJSON))
+
+	if tiered != nil {
+		// super_safe เพิ่มทีหลัง - เขียนเฉพาะเมื่อมี เพื่อไม่ให้ hash ของ gallery แบบ two-tier เปลี่ยน
+		if len(tiered.SuperSafe) > 0 {
+			writeField("gallery_super_safe", sortedGalleryFilenames(tiered.SuperSafe))
+		}
+		writeField("gallery_safe", sortedGalleryFilenames(tiered.Safe))
+		writeField("gallery_nsfw", sortedGalleryFilenames(tiered.NSFW))
+	}
+	writeField("cover_override", coverOverride)
+	writeField("language", models.NormalizeLanguage(job.OutputLanguage))
+	writeField("tts", fmt.Sprintf("%t", job.GenerateTTS))
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func sortedGalleryFilenames(urls []string) string {
+	names := make([]string, 0, len(urls))
+	for _, u := range urls {
+		names = append(names, galleryFilename(u))
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+// loadInputHash อ่าน hash ของ run ล่าสุด (ไม่มี/อ่านไม่ได้ = "" → ประมวลผลตามปกติ)
+func (h *SEOHandler) loadInputHash(ctx context.Context, videoCode string) string {
+	if h.storage == nil {
+		return ""
+	}
+
+	path := inputHashStoragePath(videoCode)
+	exists, err := h.storage.Exists(ctx, path)
+	if err != nil || !exists {
+		return ""
+	}
+
+	reader, _, err := h.storage.GetFileContent(path)
+	if err != nil {
+		h.logger.WarnContext(ctx, "Failed to read input hash", "path", path, "error", err)
+		return ""
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		h.logger.WarnContext(ctx, "Failed to read input hash", "path", path, "error", err)
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// saveInputHash บันทึก hash หลัง publish สำเร็จ (ล้มเหลว = warn, run ถัดไปจะประมวลผลใหม่)
+func (h *SEOHandler) saveInputHash(ctx context.Context, videoCode, hash string) {
+	if h.storage == nil {
+		return
+	}
+
+	path := inputHashStoragePath(videoCode)
+	if err := h.storage.Upload(ctx, path, []byte(hash), "text/plain"); err != nil {
+		h.logger.WarnContext(ctx, "Failed to save input hash", "path", path, "error", err)
+	}
+}
@@ -0,0 +1,43 @@
+package use_cases
+
+import (
+	"testing"
+
+	"seo-worker/domain/models"
+)
+
+func TestComputeInputHash(t *testing.T) {
+	job := &models.SEOArticleJob{VideoCode: "ABC-123"}
+	metadata := &models.VideoMetadata{ID: "v1", Title: "Test", Duration: 3600}
+	tiered := &models.TieredGalleryImages{
+		Safe: []string{"https://cdn/g/safe/001.jpg?sig=a", "https://cdn/g/safe/002.jpg?sig=a"},
+		NSFW: []string{"https://cdn/g/nsfw/003.jpg?sig=a"},
+	}
+	base := computeInputHash(job, "srt", metadata, tiered, "")
+
+	if got := computeInputHash(job, "srt", metadata, tiered, ""); got != base {
+		t.Errorf("hash not deterministic: %s != %s", got, base)
+	}
+
+	// presigned query และลำดับภาพไม่มีผล
+	reordered := &models.TieredGalleryImages{
+		Safe: []string{"https://cdn/g/safe/002.jpg?sig=b", "https://cdn/g/safe/001.jpg?sig=b"},
+		NSFW: []string{"https://cdn/g/nsfw/003.jpg?sig=b"},
+	}
+	if got := computeInputHash(job, "srt", metadata, reordered, ""); got != base {
+		t.Error("hash changed for reordered gallery with different signatures")
+	}
+
+	changed := map[string]string{
+		"srt":      computeInputHash(job, "srt changed", metadata, tiered, ""),
+		"metadata": computeInputHash(job, "srt", &models.VideoMetadata{ID: "v1", Title: "Other", Duration: 3600}, tiered, ""),
+		"gallery":  computeInputHash(job, "srt", metadata, &models.TieredGalleryImages{Safe: tiered.Safe}, ""),
+		"cover":    computeInputHash(job, "srt", metadata, tiered, "001.jpg"),
+		"language": computeInputHash(&models.SEOArticleJob{VideoCode: "ABC-123", OutputLanguage: "en"}, "srt", metadata, tiered, ""),
+	}
+	for name, got := range changed {
+		if got == base {
+			t.Errorf("hash unchanged after %s changed", name)
+		}
+	}
+}
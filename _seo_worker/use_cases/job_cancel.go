@@ -0,0 +1,52 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"seo-worker/domain/models"
+	"seo-worker/domain/ports"
+)
+
+// SetJobCancel เปิดรับคำขอยกเลิก job จาก api.suekk.com (ไม่ตั้ง = ยกเลิกไม่ได้)
+func (h *SEOHandler) SetJobCancel(jobCancel ports.JobCancelPort) {
+	h.jobCancel = jobCancel
+}
+
+// watchJobCancel ผูกคำขอยกเลิกของ job เข้ากับ ctx (Gemini/TTS ที่ใช้ ctx นี้หยุดตามทันที)
+func (h *SEOHandler) watchJobCancel(ctx context.Context, job *models.SEOArticleJob) (context.Context, func()) {
+	if h.jobCancel == nil {
+		return ctx, func() {}
+	}
+	return h.jobCancel.Watch(ctx, job.VideoID)
+}
+
+// checkCancelled เช็คที่ stage boundary - admin ยกเลิกแล้ว = ส่ง status cancelled แล้วคืน error
+// ที่ wrap models.ErrJobCancelled (consumer terminate ไม่ retry)
+func (h *SEOHandler) checkCancelled(ctx context.Context, job *models.SEOArticleJob, stage string) error {
+	if !errors.Is(context.Cause(ctx), models.ErrJobCancelled) {
+		return nil
+	}
+
+	h.logger.WarnContext(ctx, "SEO job cancelled",
+		"video_id", job.VideoID,
+		"video_code", job.VideoCode,
+		"stage", stage,
+	)
+
+	// ctx ถูก cancel แล้ว → ใช้ WithoutCancel ส่ง status/ล้าง flag (trace ID ยังติดอยู่)
+	bg := context.WithoutCancel(ctx)
+	update := models.NewProgressUpdate(job.VideoID, job.VideoCode, ports.StageCancelled, 0)
+	update.Message = fmt.Sprintf("cancelled by admin during %s", stage)
+	if err := h.messenger.SendProgress(bg, update); err != nil {
+		h.logger.WarnContext(ctx, "Failed to send cancelled status", "error", err)
+	}
+	h.recordEvent(bg, models.NewJobEvent(job.VideoID, ports.StageCancelled, 0, update.Message))
+
+	if err := h.jobCancel.Clear(bg, job.VideoID); err != nil {
+		h.logger.WarnContext(ctx, "Failed to clear job cancel request", "video_id", job.VideoID, "error", err)
+	}
+
+	return fmt.Errorf("%w at %s", models.ErrJobCancelled, stage)
+}
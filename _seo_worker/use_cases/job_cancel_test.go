@@ -0,0 +1,93 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"seo-worker/domain/models"
+	"seo-worker/domain/ports"
+)
+
+// stubJobCancel ยกเลิก ctx ทันทีเมื่อ cancelled = true
+type stubJobCancel struct {
+	cancelled bool
+	cleared   []string
+}
+
+func (s *stubJobCancel) Watch(ctx context.Context, videoID string) (context.Context, func()) {
+	jobCtx, cancel := context.WithCancelCause(ctx)
+	if s.cancelled {
+		cancel(models.ErrJobCancelled)
+	}
+	return jobCtx, func() { cancel(nil) }
+}
+
+func (s *stubJobCancel) Clear(ctx context.Context, videoID string) error {
+	s.cleared = append(s.cleared, videoID)
+	return nil
+}
+
+// recordingMessenger เก็บ progress update ที่ส่ง
+type recordingMessenger struct {
+	ports.MessengerPort
+	updates []*models.ProgressUpdate
+}
+
+func (m *recordingMessenger) SendProgress(ctx context.Context, update *models.ProgressUpdate) error {
+	m.updates = append(m.updates, update)
+	return nil
+}
+
+func TestCheckCancelled(t *testing.T) {
+	job := &models.SEOArticleJob{VideoID: "v1", VideoCode: "ABC-123"}
+
+	t.Run("Not cancelled", func(t *testing.T) {
+		jobCancel := &stubJobCancel{}
+		messenger := &recordingMessenger{}
+		h := &SEOHandler{messenger: messenger, logger: slog.Default()}
+		h.SetJobCancel(jobCancel)
+
+		ctx, stop := h.watchJobCancel(context.Background(), job)
+		defer stop()
+
+		if err := h.checkCancelled(ctx, job, ports.StageDataFetched); err != nil {
+			t.Fatalf("err = %v, want nil", err)
+		}
+		if len(messenger.updates) != 0 || len(jobCancel.cleared) != 0 {
+			t.Errorf("unexpected side effects: updates %d, cleared %v", len(messenger.updates), jobCancel.cleared)
+		}
+	})
+
+	t.Run("Worker shutdown is not a cancel", func(t *testing.T) {
+		h := &SEOHandler{messenger: &recordingMessenger{}, logger: slog.Default()}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if err := h.checkCancelled(ctx, job, ports.StageDataFetched); err != nil {
+			t.Fatalf("err = %v, want nil", err)
+		}
+	})
+
+	t.Run("Cancelled by admin", func(t *testing.T) {
+		jobCancel := &stubJobCancel{cancelled: true}
+		messenger := &recordingMessenger{}
+		h := &SEOHandler{messenger: messenger, logger: slog.Default()}
+		h.SetJobCancel(jobCancel)
+
+		ctx, stop := h.watchJobCancel(context.Background(), job)
+		defer stop()
+
+		err := h.checkCancelled(ctx, job, ports.StageAIComplete)
+		if !errors.Is(err, models.ErrJobCancelled) {
+			t.Fatalf("err = %v, want ErrJobCancelled", err)
+		}
+		if len(messenger.updates) != 1 || messenger.updates[0].Status != models.ProgressStatusCancelled {
+			t.Errorf("updates = %+v, want one cancelled update", messenger.updates)
+		}
+		if len(jobCancel.cleared) != 1 || jobCancel.cleared[0] != "v1" {
+			t.Errorf("cleared = %v, want [v1]", jobCancel.cleared)
+		}
+	})
+}
@@ -0,0 +1,94 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"seo-worker/domain/models"
+)
+
+// GetKeyMoments ดึง key moments ของ article ที่ publish แล้ว (สำหรับ editor)
+func (h *SEOHandler) GetKeyMoments(ctx context.Context, videoCode string) (*models.ArticleKeyMoments, error) {
+	metadata, err := h.metadataFetcher.FetchVideoMetadataByCode(ctx, videoCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch video metadata: %w", err)
+	}
+	return h.articlePublisher.GetArticleKeyMoments(ctx, metadata.ID)
+}
+
+// UpdateKeyMoments แก้ key moments ของ article ที่ publish แล้ว
+// ใช้ safe window + duration rules เดียวกับ buildArticle แต่ input ที่ไม่ผ่านจะ error (ไม่ข้ามเงียบ)
+// ไม่ส่ง InternalKeyMoments = คง internal เดิมของ article ([] = ล้าง internal)
+func (h *SEOHandler) UpdateKeyMoments(ctx context.Context, videoCode string, edit *models.ArticleKeyMoments) (*models.ArticleKeyMoments, error) {
+	metadata, err := h.metadataFetcher.FetchVideoMetadataByCode(ctx, videoCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch video metadata: %w", err)
+	}
+
+	settings := h.safeMomentsForJob(metadata.Duration)
+	if err := validateKeyMomentsEdit(edit, settings, metadata.Duration); err != nil {
+		return nil, err
+	}
+
+	updated := &models.ArticleKeyMoments{
+		VideoID:    metadata.ID,
+		KeyMoments: safeKeyMomentsForArticle(edit.KeyMoments, settings, metadata),
+	}
+
+	// ไม่ส่ง internalKeyMoments มา = แก้แค่ public → คงชุด internal เดิมของ article ไว้
+	if edit.InternalKeyMoments == nil {
+		current, err := h.articlePublisher.GetArticleKeyMoments(ctx, metadata.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch current key moments: %w", err)
+		}
+		updated.InternalKeyMoments = current.InternalKeyMoments
+	} else {
+		updated.InternalKeyMoments = safeKeyMomentsForArticle(edit.InternalKeyMoments, settings, metadata)
+	}
+
+	if err := h.articlePublisher.UpdateArticleKeyMoments(ctx, updated); err != nil {
+		return nil, fmt.Errorf("failed to update key moments: %w", err)
+	}
+
+	h.logger.InfoContext(ctx, "Key moments updated by editor",
+		"video_id", metadata.ID,
+		"video_code", videoCode,
+		"public_count", len(updated.KeyMoments),
+		"internal_count", len(updated.InternalKeyMoments),
+	)
+
+	return updated, nil
+}
+
+// validateKeyMomentsEdit ตรวจทุก moment + จำนวนไม่เกิน MaxPublic / MaxInternal
+// รวม error ทุกจุดให้ editor แก้ได้ในรอบเดียว
+func validateKeyMomentsEdit(edit *models.ArticleKeyMoments, settings models.SafeMomentSettings, videoDuration int) error {
+	if edit == nil || len(edit.KeyMoments) == 0 {
+		return fmt.Errorf("%w: keyMoments is required", models.ErrInvalidKeyMoments)
+	}
+
+	var errs []error
+	if len(edit.KeyMoments) > settings.MaxPublic {
+		errs = append(errs, fmt.Errorf("keyMoments: %d exceeds max %d", len(edit.KeyMoments), settings.MaxPublic))
+	}
+	if len(edit.InternalKeyMoments) > settings.MaxInternal {
+		errs = append(errs, fmt.Errorf("internalKeyMoments: %d exceeds max %d", len(edit.InternalKeyMoments), settings.MaxInternal))
+	}
+
+	for i, km := range edit.KeyMoments {
+		if err := models.ValidateKeyMoment(km, settings, videoDuration); err != nil {
+			errs = append(errs, fmt.Errorf("keyMoments[%d]: %w", i, err))
+		}
+	}
+	for i, km := range edit.InternalKeyMoments {
+		if err := models.ValidateKeyMoment(km, settings, videoDuration); err != nil {
+			errs = append(errs, fmt.Errorf("internalKeyMoments[%d]: %w", i, err))
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %w", models.ErrInvalidKeyMoments, errors.Join(errs...))
+}
@@ -0,0 +1,78 @@
+package use_cases
+
+import (
+	"errors"
+	"testing"
+
+	"seo-worker/domain/models"
+)
+
+func TestValidateKeyMomentsEdit(t *testing.T) {
+	settings := models.SafeMomentSettings{}.ForDuration(3600)
+
+	tests := []struct {
+		name    string
+		edit    *models.ArticleKeyMoments
+		wantErr bool
+	}{
+		{"Valid public only", &models.ArticleKeyMoments{
+			KeyMoments: []models.KeyMoment{{Name: "บทนำ", StartOffset: 0, EndOffset: 60}},
+		}, false},
+		{"Empty edit", &models.ArticleKeyMoments{}, true},
+		{"Missing name", &models.ArticleKeyMoments{
+			KeyMoments: []models.KeyMoment{{StartOffset: 30, EndOffset: 60}},
+		}, true},
+		{"End before start", &models.ArticleKeyMoments{
+			KeyMoments: []models.KeyMoment{{Name: "ฉาก", StartOffset: 120, EndOffset: 60}},
+		}, true},
+		{"Outside safe window", &models.ArticleKeyMoments{
+			KeyMoments: []models.KeyMoment{{Name: "ฉาก", StartOffset: 900, EndOffset: 960}},
+		}, true},
+		{"Too many public", &models.ArticleKeyMoments{
+			KeyMoments: make6Moments(),
+		}, true},
+		{"Invalid internal", &models.ArticleKeyMoments{
+			KeyMoments:         []models.KeyMoment{{Name: "บทนำ", StartOffset: 0, EndOffset: 60}},
+			InternalKeyMoments: []models.KeyMoment{{Name: "ฉาก", StartOffset: -5}},
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateKeyMomentsEdit(tt.edit, settings, 3600)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("wantErr=%v, got %v", tt.wantErr, err)
+			}
+			if err != nil && !errors.Is(err, models.ErrInvalidKeyMoments) {
+				t.Errorf("error should wrap ErrInvalidKeyMoments: %v", err)
+			}
+		})
+	}
+}
+
+func TestSafeKeyMomentsForArticleNormalizes(t *testing.T) {
+	settings := models.SafeMomentSettings{}.ForDuration(100)
+	metadata := &models.VideoMetadata{ID: "vid-1", Duration: 100}
+
+	got := safeKeyMomentsForArticle([]models.KeyMoment{
+		{Name: "สั้น", StartOffset: 80, EndOffset: 85},
+	}, settings, metadata)
+
+	if len(got) != 1 {
+		t.Fatalf("expected 1 moment, got %d", len(got))
+	}
+	if got[0].EndOffset != 100 {
+		t.Errorf("EndOffset should be clamped to duration: got %d", got[0].EndOffset)
+	}
+	if got[0].URL != "/member/videos/vid-1?t=80" {
+		t.Errorf("unexpected URL: %s", got[0].URL)
+	}
+}
+
+func make6Moments() []models.KeyMoment {
+	moments := make([]models.KeyMoment, 6)
+	for i := range moments {
+		moments[i] = models.KeyMoment{Name: "ฉาก", StartOffset: i * 60, EndOffset: i*60 + 30}
+	}
+	return moments
+}
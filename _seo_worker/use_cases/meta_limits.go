@@ -0,0 +1,104 @@
+package use_cases
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	defaultMetaTitleMaxChars       = 60  // Google แสดง title ราว 50-60 ตัวอักษร
+	defaultMetaDescriptionMaxChars = 160 // Google แสดง description ราว 150-160 ตัวอักษร
+)
+
+// MetaLimitsConfig ความยาวสูงสุดของ metaTitle / metaDescription (นับเป็น rune)
+type MetaLimitsConfig struct {
+	MaxTitleChars       int // <= 0 = default 60
+	MaxDescriptionChars int // <= 0 = default 160
+}
+
+// SetMetaLimits ตั้งค่าความยาวสูงสุดของ meta fields (ไม่ตั้ง = 60/160)
+func (h *SEOHandler) SetMetaLimits(cfg MetaLimitsConfig) {
+	h.metaLimits = cfg
+}
+
+func (c MetaLimitsConfig) withDefaults() MetaLimitsConfig {
+	if c.MaxTitleChars <= 0 {
+		c.MaxTitleChars = defaultMetaTitleMaxChars
+	}
+	if c.MaxDescriptionChars <= 0 {
+		c.MaxDescriptionChars = defaultMetaDescriptionMaxChars
+	}
+	return c
+}
+
+// enforceMetaLimit ตัด meta field ให้ไม่เกิน maxChars พร้อม log เมื่อมีการตัด
+// requiredKeyword (ถ้ามี) ต้องยังอยู่หลังตัด - ถ้าหลุดไปจะตัดให้สั้นลงแล้วต่อท้ายด้วย keyword
+func (h *SEOHandler) enforceMetaLimit(field, text string, maxChars int, requiredKeyword string) string {
+	if len([]rune(text)) <= maxChars {
+		return text
+	}
+
+	result := truncateAtWordBoundary(text, maxChars)
+	if requiredKeyword != "" && strings.Contains(text, requiredKeyword) && !strings.Contains(result, requiredKeyword) {
+		suffix := " " + requiredKeyword
+		result = truncateAtWordBoundary(text, maxChars-len([]rune(suffix))) + suffix
+	}
+
+	h.logger.Info("Meta field truncated",
+		"field", field,
+		"from_chars", len([]rune(text)),
+		"to_chars", len([]rune(result)),
+		"max_chars", maxChars,
+	)
+
+	return result
+}
+
+// truncateAtWordBoundary ตัดข้อความให้ไม่เกิน maxChars rune โดยไม่ตัดกลางคำ
+//   - มีช่องว่างในครึ่งหลัง → ตัดที่ช่องว่างสุดท้าย (ภาษาอังกฤษ / ไทยที่เว้นวรรค)
+//   - ไม่มี (ไทยเขียนติดกัน) → ตัดที่ maxChars แต่ไม่แยกสระ/วรรณยุกต์ออกจากพยัญชนะ
+func truncateAtWordBoundary(text string, maxChars int) string {
+	runes := []rune(text)
+	if maxChars <= 0 {
+		return ""
+	}
+	if len(runes) <= maxChars {
+		return text
+	}
+
+	cut := maxChars
+	if idx := lastSpaceIndex(runes[:cut+1]); idx >= maxChars/2 {
+		cut = idx
+	} else {
+		// สระบน/ล่าง + วรรณยุกต์ (combining marks) ต้องอยู่กับพยัญชนะตัวหน้า
+		for cut > 0 && isThaiDependent(runes[cut]) {
+			cut--
+		}
+		// สระหน้า (เ แ โ ใ ไ) ต้องอยู่กับพยัญชนะตัวถัดไป
+		for cut > 0 && isThaiLeadingVowel(runes[cut-1]) {
+			cut--
+		}
+	}
+
+	return strings.TrimRightFunc(string(runes[:cut]), func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune(",:;-|–—/", r)
+	})
+}
+
+func lastSpaceIndex(runes []rune) int {
+	for i := len(runes) - 1; i >= 0; i-- {
+		if unicode.IsSpace(runes[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// isThaiDependent สระ/วรรณยุกต์ที่ต้องตามหลังพยัญชนะ (รวม ะ า ำ ที่ไม่ใช่ combining mark)
+func isThaiDependent(r rune) bool {
+	return unicode.Is(unicode.Mn, r) || r == 'ะ' || r == 'า' || r == 'ำ' || r == 'ๆ'
+}
+
+func isThaiLeadingVowel(r rune) bool {
+	return r >= 'เ' && r <= 'ไ'
+}
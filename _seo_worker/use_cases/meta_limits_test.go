@@ -0,0 +1,59 @@
+package use_cases
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+
+	"seo-worker/domain/ports"
+)
+
+func TestTruncateAtWordBoundary(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		max      int
+		expected string
+	}{
+		{"Short text unchanged", "สั้นมาก", 60, "สั้นมาก"},
+		{"English cuts at space", "An office drama about two coworkers", 20, "An office drama"},
+		{"Trailing punctuation trimmed", "[TEST-001] Office Drama: the story", 25, "[TEST-001] Office Drama"},
+		{"Thai spaced cuts at space", "เรื่องราวในที่ทำงาน ความสัมพันธ์ที่ซับซ้อน", 25, "เรื่องราวในที่ทำงาน"},
+		{"Thai keeps vowel with consonant", "ความสัมพันธ์", 5, "ความ"},
+		{"Thai keeps tone mark with consonant", "ที่ทำงาน", 4, "ที่"},
+		{"Thai drops dangling leading vowel", "ทำงานเป็นทีม", 6, "ทำงาน"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := truncateAtWordBoundary(tt.input, tt.max)
+			if result != tt.expected {
+				t.Errorf("\nInput:    %q (max %d)\nExpected: %q\nGot:      %q", tt.input, tt.max, tt.expected, result)
+			}
+			if len([]rune(result)) > tt.max {
+				t.Errorf("result exceeds max: %d > %d", len([]rune(result)), tt.max)
+			}
+		})
+	}
+}
+
+func TestSanitizeEnforcesMetaLimits(t *testing.T) {
+	h := &SEOHandler{logger: slog.Default()}
+	h.SetMetaLimits(MetaLimitsConfig{MaxTitleChars: 30, MaxDescriptionChars: 40})
+
+	output := &ports.AIOutput{
+		MetaTitle:       "[TEST-001] เรื่องราวในที่ทำงาน ความสัมพันธ์ที่ซับซ้อนและการตัดสินใจ",
+		MetaDescription: "รีวิว TEST-001 เรื่องราวความสัมพันธ์ระหว่างเพื่อนร่วมงาน ที่ค่อย ๆ พัฒนาไปสู่จุดเปลี่ยน",
+	}
+	h.sanitizeAIOutput(output, nil, "th")
+
+	if n := len([]rune(output.MetaTitle)); n > 30 {
+		t.Errorf("metaTitle too long (%d): %q", n, output.MetaTitle)
+	}
+	if !strings.Contains(output.MetaTitle, "ซับไทย") {
+		t.Errorf("metaTitle lost required keyword: %q", output.MetaTitle)
+	}
+	if n := len([]rune(output.MetaDescription)); n > 40 {
+		t.Errorf("metaDescription too long (%d): %q", n, output.MetaDescription)
+	}
+}
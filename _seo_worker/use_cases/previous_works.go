@@ -0,0 +1,80 @@
+package use_cases
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+
+	"seo-worker/domain/models"
+)
+
+const (
+	defaultPreviousWorksPerCast     = 5
+	defaultPreviousWorksConcurrency = 3
+)
+
+// PreviousWorksConfig จำนวนผลงานก่อนหน้าต่อ cast + จำนวน request พร้อมกัน
+type PreviousWorksConfig struct {
+	PerCast     int // ผลงานสูงสุดต่อ cast (<= 0 = default 5)
+	Concurrency int // ดึงพร้อมกันกี่ cast (<= 0 = default 3)
+}
+
+// SetPreviousWorks ตั้งค่าการดึง previous works (ไม่ตั้ง = 5 ต่อ cast, พร้อมกัน 3 cast)
+func (h *SEOHandler) SetPreviousWorks(cfg PreviousWorksConfig) {
+	h.previousWorks = cfg
+}
+
+func (c PreviousWorksConfig) withDefaults() PreviousWorksConfig {
+	if c.PerCast <= 0 {
+		c.PerCast = defaultPreviousWorksPerCast
+	}
+	if c.Concurrency <= 0 {
+		c.Concurrency = defaultPreviousWorksConcurrency
+	}
+	return c
+}
+
+// fetchPreviousWorks ดึงผลงานก่อนหน้าของทุก cast พร้อมกัน (จำกัด concurrency)
+// ผลลัพธ์เรียงตามลำดับ cast เดิม และตัดงานซ้ำ (เรื่องเดียวมีหลาย cast)
+// ดึงไม่ได้ = ข้าม cast นั้น (non-critical เหมือนเดิม)
+func (h *SEOHandler) fetchPreviousWorks(ctx context.Context, casts []models.CastMetadata) []models.PreviousWork {
+	cfg := h.previousWorks.withDefaults()
+
+	results := make([][]models.PreviousWork, len(casts))
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(cfg.Concurrency)
+
+	for i, cast := range casts {
+		g.Go(func() error {
+			works, err := h.metadataFetcher.FetchPreviousWorks(gctx, cast.Slug, cfg.PerCast)
+			if err != nil {
+				h.logger.WarnContext(ctx, "Failed to fetch previous works (non-critical)",
+					"cast_slug", cast.Slug,
+					"error", err,
+				)
+				return nil
+			}
+			results[i] = works
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	var previousWorks []models.PreviousWork
+	seen := make(map[string]bool)
+	for _, works := range results {
+		for _, work := range works {
+			key := work.VideoID
+			if key == "" {
+				key = work.Slug
+			}
+			if key != "" && seen[key] {
+				continue
+			}
+			seen[key] = true
+			previousWorks = append(previousWorks, work)
+		}
+	}
+
+	return previousWorks
+}
@@ -0,0 +1,126 @@
+package use_cases
+
+import (
+	"context"
+	"fmt"
+
+	"seo-worker/domain/models"
+	"seo-worker/domain/ports"
+)
+
+// RebuildArticle สร้าง article ใหม่จาก AI output ที่ editor แก้แล้ว (ไม่เรียก Gemini)
+// ดึง metadata/gallery ใหม่ → sanitize → buildArticle → publish
+// caller ต้อง validate edited ก่อน (ai.ValidateAIOutput) - use_cases ไม่ผูกกับ infrastructure
+// TTS สร้างใหม่จาก SummaryShort เมื่อ job.GenerateTTS และมี TTS service (ไม่งั้นใช้เสียงเดิมที่เก็บไว้)
+// embedding ไม่สร้างใหม่
+func (h *SEOHandler) RebuildArticle(ctx context.Context, job *models.SEOArticleJob, edited *ports.AIOutput) (*models.ArticleContent, error) {
+	h.logger.InfoContext(ctx, "Rebuilding article from edited AI output",
+		"video_code", job.VideoCode,
+		"generate_tts", job.GenerateTTS,
+	)
+
+	previous, _, err := h.LoadAIOutput(ctx, job.VideoCode)
+	if err != nil {
+		previous = nil // ไม่มี run ก่อนหน้า - ไม่มีเสียงเดิมให้ใช้
+	}
+	return h.rebuildArticle(ctx, job, edited, previous, "rebuilt from edited AI output")
+}
+
+// ResanitizeArticle รัน sanitize + buildArticle ใหม่บน AIOutput ดิบที่เก็บไว้ แล้ว publish
+// ใช้หลังแก้กฎ sanitize (ชื่อนักแสดง, สรรพนาม ฯลฯ) ให้มีผลย้อนหลังโดยไม่เรียก Gemini/TTS
+func (h *SEOHandler) ResanitizeArticle(ctx context.Context, videoCode string) (*models.ArticleContent, error) {
+	stored, aiOutput, err := h.LoadAIOutput(ctx, videoCode)
+	if err != nil {
+		return nil, err
+	}
+
+	h.logger.InfoContext(ctx, "Re-sanitizing stored AI output", "video_code", videoCode)
+
+	job := &models.SEOArticleJob{
+		VideoCode:      videoCode,
+		OutputLanguage: stored.OutputLanguage,
+	}
+	return h.rebuildArticle(ctx, job, aiOutput, stored, "re-sanitized stored AI output")
+}
+
+// rebuildArticle ขั้นตอนร่วมของ RebuildArticle/ResanitizeArticle
+// previous = AIOutput ที่เก็บไว้ของ run ก่อน (nil = ไม่มี) ใช้เอาเสียงเดิมเมื่อไม่ได้สร้าง TTS ใหม่
+func (h *SEOHandler) rebuildArticle(ctx context.Context, job *models.SEOArticleJob, aiOutput *ports.AIOutput, previous *StoredAIOutput, message string) (*models.ArticleContent, error) {
+	suekkVideoInfo, err := h.suekkVideoFetcher.FetchVideoInfo(ctx, job.VideoCode)
+	if err != nil {
+		h.logger.WarnContext(ctx, "Failed to fetch Suekk video info (non-critical)",
+			"video_code", job.VideoCode,
+			"error", err,
+		)
+		suekkVideoInfo = &models.SuekkVideoInfo{Code: job.VideoCode}
+	}
+
+	metadata, err := h.metadataFetcher.FetchVideoMetadataByCode(ctx, job.VideoCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch metadata: %w", err)
+	}
+	if suekkVideoInfo.Duration > 0 {
+		metadata.Duration = suekkVideoInfo.Duration
+	}
+	if job.VideoID == "" {
+		job.VideoID = metadata.ID
+	}
+
+	casts := metadata.Casts
+	tags := metadata.Tags
+	previousWorks := h.fetchPreviousWorks(ctx, casts)
+	gallery := h.fetchGallery(ctx, job.VideoCode, suekkVideoInfo)
+
+	relatedArticles := h.buildRelatedArticlesForAI(previousWorks, casts, tags)
+	safeMoments := h.safeMomentsForJob(metadata.Duration)
+
+	outputLanguage := models.NormalizeLanguage(job.OutputLanguage)
+	rawAIOutput := snapshotAIOutput(aiOutput)
+	h.sanitizeAIOutput(aiOutput, casts, outputLanguage)
+
+	var audioURL, audioVoiceID string
+	var audioDuration int
+	if previous != nil {
+		audioURL, audioDuration, audioVoiceID = previous.AudioURL, previous.AudioDuration, previous.AudioVoiceID
+	}
+	if job.GenerateTTS && h.ttsService != nil && h.storage != nil && aiOutput.SummaryShort != "" {
+		ttsResult, err := h.generateAudioWithFallback(ctx, aiOutput.SummaryShort)
+		if err != nil {
+			h.logger.WarnContext(ctx, "TTS failed (non-critical)", "video_code", job.VideoCode, "error", err)
+		} else {
+			audioPath := fmt.Sprintf("audio/articles/%s/summary.mp3", job.VideoCode)
+			if err := h.storage.Upload(ctx, audioPath, ttsResult.AudioData, "audio/mpeg"); err != nil {
+				h.logger.WarnContext(ctx, "TTS upload failed", "video_code", job.VideoCode, "error", err)
+			} else {
+				audioURL = h.storage.GetPublicURL(audioPath)
+				audioDuration = ttsResult.Duration
+				audioVoiceID = ttsResult.VoiceID
+			}
+		}
+	}
+
+	article := h.buildArticle(job, metadata, aiOutput, casts, metadata.Maker, tags, previousWorks,
+		gallery.publicImages, gallery.memberImages, gallery.coverURL,
+		audioURL, audioDuration, audioVoiceID, relatedArticles, safeMoments)
+	article.Slug = h.resolveArticleSlug(ctx, metadata.ID, article.Slug)
+
+	h.storeArticleJSON(ctx, article, job.VideoCode)
+
+	if err := h.articlePublisher.PublishArticle(ctx, article); err != nil {
+		return nil, fmt.Errorf("publish failed: %w", err)
+	}
+	h.recordEvent(ctx, models.NewJobEvent(job.VideoID, ports.StageCompleted, 100, message))
+	h.saveAIOutput(ctx, job.VideoCode, &StoredAIOutput{
+		OutputLanguage: outputLanguage,
+		AudioURL:       audioURL,
+		AudioDuration:  audioDuration,
+		AudioVoiceID:   audioVoiceID,
+		AIOutput:       rawAIOutput,
+	})
+
+	h.logger.InfoContext(ctx, "Article rebuilt and published",
+		"video_id", job.VideoID,
+		"video_code", job.VideoCode,
+	)
+	return article, nil
+}
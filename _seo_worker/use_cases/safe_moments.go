@@ -0,0 +1,97 @@
+package use_cases
+
+import (
+	"context"
+	"fmt"
+
+	"seo-worker/domain/models"
+	"seo-worker/domain/ports"
+)
+
+// SetSafeMoments ตั้งค่าการกรอง key moments (ไม่ตั้ง = 10 นาทีแรก, 3-5 public, 20 internal)
+// ค่าไม่สอดคล้อง (min > max) → log แล้วใช้ default แทน
+func (h *SEOHandler) SetSafeMoments(settings models.SafeMomentSettings) {
+	settings = settings.WithDefaults()
+	if err := settings.Validate(); err != nil {
+		h.logger.Warn("Invalid safe moment settings, using defaults", "error", err)
+		settings = models.SafeMomentSettings{Disabled: settings.Disabled}.WithDefaults()
+	}
+	h.safeMoments = settings
+}
+
+// safeMomentsForJob คืน settings ของ job นี้ (threshold ไม่เกินความยาววิดีโอ)
+// คำนวณครั้งเดียวต่อ job แล้วส่งให้ทั้ง AI post-process และ buildArticle
+func (h *SEOHandler) safeMomentsForJob(videoDuration int) models.SafeMomentSettings {
+	settings := h.safeMoments.ForDuration(videoDuration)
+	if settings.ThresholdSeconds != h.safeMoments.WithDefaults().ThresholdSeconds {
+		h.logger.Info("Safe moment threshold clamped to video duration",
+			"threshold_seconds", settings.ThresholdSeconds,
+			"video_duration", videoDuration,
+		)
+	}
+	return settings
+}
+
+// PreviewSafeMoments แสดงว่า key moments ไหนผ่าน/ถูกตัดจากการกรอง safe moments (ไม่ publish)
+// cached = ใช้ key moments จาก AI output ที่เก็บไว้ (ไม่เสีย Gemini call)
+// ไม่ cached = ดึง SRT แล้วเรียก chunk 1 ใหม่เป็นภาษา lang (ว่าง = ภาษาไทย)
+func (h *SEOHandler) PreviewSafeMoments(ctx context.Context, videoCode, lang string, cached bool) (*models.SafeMomentsPreview, error) {
+	metadata, err := h.metadataFetcher.FetchVideoMetadataByCode(ctx, videoCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch video metadata: %w", err)
+	}
+
+	// duration จาก suekk แม่นยำกว่า (เหมือน ProcessJob)
+	if h.suekkVideoFetcher != nil {
+		if info, err := h.suekkVideoFetcher.FetchVideoInfo(ctx, videoCode); err == nil && info.Duration > 0 {
+			metadata.Duration = info.Duration
+		}
+	}
+
+	input := &ports.AIInput{
+		VideoMetadata: metadata,
+		Casts:         metadata.Casts,
+		Tags:          metadata.Tags,
+		SafeMoments:   h.safeMomentsForJob(metadata.Duration),
+	}
+
+	var moments []models.KeyMoment
+	if cached {
+		stored, aiOutput, err := h.LoadAIOutput(ctx, videoCode)
+		if err != nil {
+			return nil, err
+		}
+		input.OutputLanguage = models.NormalizeLanguage(stored.OutputLanguage)
+
+		// internal เป็นชุดใหญ่สุดที่เก็บไว้ (public เป็น prefix ของ internal)
+		moments = aiOutput.InternalKeyMoments
+		if len(moments) == 0 {
+			moments = aiOutput.KeyMoments
+		}
+		if moments == nil {
+			moments = []models.KeyMoment{}
+		}
+	} else {
+		srtContent, err := h.srtFetcher.FetchSRT(ctx, videoCode)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch SRT: %w", err)
+		}
+		input.SRTContent = srtContent
+		input.OutputLanguage = models.NormalizeLanguage(lang)
+	}
+
+	preview, err := h.aiService.PreviewSafeMoments(ctx, input, moments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to preview safe moments: %w", err)
+	}
+	preview.VideoCode = videoCode
+
+	h.logger.InfoContext(ctx, "Safe moments previewed",
+		"video_code", videoCode,
+		"source", preview.Source,
+		"input_count", len(preview.Input),
+		"dropped_count", len(preview.Dropped),
+		"public_count", len(preview.Public),
+	)
+	return preview, nil
+}
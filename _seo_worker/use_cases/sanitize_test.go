@@ -1,9 +1,11 @@
 package use_cases
 
 import (
+	"log/slog"
 	"testing"
 
 	"seo-worker/domain/models"
+	"seo-worker/domain/ports"
 )
 
 func TestSanitizeCastNames(t *testing.T) {
@@ -71,3 +73,55 @@ func TestMixedNameRegex(t *testing.T) {
 		})
 	}
 }
+
+func TestStripSRTArtifacts(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expected      string
+		expectedCount int
+	}{
+		{"No artifacts", "เธอยิ้มให้เขาตอน 10:30 น.", "เธอยิ้มให้เขาตอน 10:30 น.", 0},
+		{"Inline range", "เธอพูดว่า 00:01:02,500 --> 00:01:05,000 ฉันรักเธอ", "เธอพูดว่า ฉันรักเธอ", 1},
+		{"Cue number + range", "ฉากเปิด 12 00:01:02,500 --> 00:01:05,000 สวยมาก", "ฉากเปิด สวยมาก", 1},
+		{"Standalone timestamp", "ช่วง 01:15:20,000 คือไฮไลท์", "ช่วง คือไฮไลท์", 1},
+		{"Cue block", "ย่อหน้าแรก\n15\n00:10:00,000 --> 00:10:02,000\nย่อหน้าสอง", "ย่อหน้าแรก\n\nย่อหน้าสอง", 1},
+		{"Orphan cue line", "ย่อหน้าแรก\n16\nย่อหน้าสอง 00:10:03,000", "ย่อหน้าแรก\nย่อหน้าสอง", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, count := stripSRTArtifacts(tt.input)
+			if result != tt.expected || count != tt.expectedCount {
+				t.Errorf("\nInput:    %q\nExpected: %q (%d)\nGot:      %q (%d)",
+					tt.input, tt.expected, tt.expectedCount, result, count)
+			}
+		})
+	}
+}
+
+func TestSanitizeMetaTitleByLanguage(t *testing.T) {
+	h := &SEOHandler{logger: slog.Default()}
+
+	tests := []struct {
+		name     string
+		lang     string
+		input    string
+		expected string
+	}{
+		{"Thai injects keyword", "th", "[TEST-001] เรื่องราวในที่ทำงาน", "[TEST-001] ซับไทย เรื่องราวในที่ทำงาน"},
+		{"Empty defaults to Thai", "", "[TEST-001] เรื่องราวในที่ทำงาน", "[TEST-001] ซับไทย เรื่องราวในที่ทำงาน"},
+		{"English skips keyword", "en", "[TEST-001] An Office Drama", "[TEST-001] An Office Drama"},
+		{"Japanese skips keyword", "ja-JP", "[TEST-001] オフィスドラマ", "[TEST-001] オフィスドラマ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := &ports.AIOutput{MetaTitle: tt.input}
+			h.sanitizeAIOutput(output, nil, tt.lang)
+			if output.MetaTitle != tt.expected {
+				t.Errorf("\nLang:     %q\nExpected: %q\nGot:      %q", tt.lang, tt.expected, output.MetaTitle)
+			}
+		})
+	}
+}
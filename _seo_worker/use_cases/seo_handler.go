@@ -2,10 +2,12 @@ package use_cases
 
 import (
 	"context"
-	"encoding/json"
+	"crypto/sha1"
 	"fmt"
 	"log/slog"
+	"net/url"
 	"os"
+	"path"
 	"regexp"
 	"strings"
 	"sync"
@@ -17,17 +19,26 @@ import (
 )
 
 type SEOHandler struct {
-	srtFetcher        ports.SRTFetcherPort
-	suekkVideoFetcher ports.SuekkVideoFetcherPort
-	metadataFetcher   ports.MetadataFetcherPort
-	imageSelector     ports.ImageSelectorPort
-	aiService         ports.AIPort
-	ttsService        ports.TTSPort
-	embeddingService  ports.EmbeddingPort
-	articlePublisher  ports.ArticlePublisherPort
-	imageCopier       ports.ImageCopierPort
-	messenger         ports.MessengerPort
-	storage           ports.StoragePort
+	srtFetcher         ports.SRTFetcherPort
+	suekkVideoFetcher  ports.SuekkVideoFetcherPort
+	metadataFetcher    ports.MetadataFetcherPort
+	imageSelector      ports.ImageSelectorPort
+	aiService          ports.AIPort
+	ttsService         ports.TTSPort
+	embeddingService   ports.EmbeddingPort
+	articlePublisher   ports.ArticlePublisherPort
+	imageCopier        ports.ImageCopierPort
+	messenger          ports.MessengerPort
+	storage            ports.StoragePort
+	eventLog           ports.JobEventPort        // บันทึก pipeline events (optional)
+	ttsFallback        TTSFallbackConfig         // retry + fallback voices (SetTTSFallback)
+	metaLimits         MetaLimitsConfig          // ความยาวสูงสุด metaTitle/metaDescription (SetMetaLimits)
+	previousWorks      PreviousWorksConfig       // จำนวน/concurrency ของ previous works (SetPreviousWorks)
+	safeMoments        models.SafeMomentSettings // threshold/จำนวน key moments (SetSafeMoments)
+	articleOutput      ArticleOutputConfig       // ที่เก็บ article JSON: local/storage (SetArticleOutput)
+	galleryRequirement GalleryRequirementConfig  // จำนวนภาพ public ขั้นต่ำก่อนสร้างบทความ (SetGalleryRequirement)
+	galleryAudience    models.GalleryAudience    // tier → public/member (SetGalleryAudience)
+	jobCancel          ports.JobCancelPort       // คำขอยกเลิก job จาก api.suekk.com (SetJobCancel)
 
 	logger *slog.Logger
 }
@@ -44,6 +55,7 @@ func NewSEOHandler(
 	imageCopier ports.ImageCopierPort,
 	messenger ports.MessengerPort,
 	storage ports.StoragePort,
+	eventLog ports.JobEventPort,
 ) *SEOHandler {
 	return &SEOHandler{
 		srtFetcher:        srtFetcher,
@@ -57,6 +69,7 @@ func NewSEOHandler(
 		imageCopier:       imageCopier,
 		messenger:         messenger,
 		storage:           storage,
+		eventLog:          eventLog,
 		logger:            slog.Default().With("component", "seo_handler"),
 	}
 }
@@ -68,21 +81,39 @@ func (h *SEOHandler) ProcessJob(ctx context.Context, job *models.SEOArticleJob)
 		"video_id", job.VideoID,
 		"video_code", job.VideoCode,
 		"generate_tts", job.GenerateTTS,
+		"force", job.Force,
+		"output_language", models.NormalizeLanguage(job.OutputLanguage),
 	)
 
+	// admin ยกเลิก job = หยุดที่ stage boundary ถัดไป (checkCancelled)
+	ctx, stopWatch := h.watchJobCancel(ctx, job)
+	defer stopWatch()
+
 	// === Stage 1: Fetch Raw Materials ===
-	h.sendProgress(ctx, job.VideoID, ports.StageFetching, 10)
+	h.sendProgress(ctx, job, ports.StageFetching, 10)
 
 	// 1.1 Fetch SRT content (pre-validated at Admin UI)
 	srtContent, err := h.srtFetcher.FetchSRT(ctx, job.VideoCode)
 	if err != nil {
-		h.messenger.SendFailed(ctx, job.VideoID, err)
+		h.sendFailed(ctx, job, err)
 		return fmt.Errorf("failed to fetch SRT: %w", err)
 	}
 
 	// 1.2 Fetch video info from api.suekk.com (duration, gallery)
 	h.logger.InfoContext(ctx, "[DEBUG] Fetching Suekk video info...", "video_code", job.VideoCode)
 	suekkVideoInfo, err := h.suekkVideoFetcher.FetchVideoInfo(ctx, job.VideoCode)
+
+	// 1.2.1 Gallery ต้องพร้อมก่อน (ภาพ public ไม่พอ = defer job, ไม่เสีย Gemini/TTS กับบทความที่ไม่มีภาพ)
+	if guardErr := h.checkGalleryRequirement(ctx, suekkVideoInfoOrNil(suekkVideoInfo, err)); guardErr != nil {
+		h.logger.WarnContext(ctx, "Gallery requirement not met, deferring SEO job",
+			"video_id", job.VideoID,
+			"video_code", job.VideoCode,
+			"reason", guardErr,
+		)
+		h.sendFailed(ctx, job, guardErr)
+		return guardErr
+	}
+
 	if err != nil {
 		h.logger.WarnContext(ctx, "[DEBUG] Failed to fetch Suekk video info (non-critical)",
 			"video_code", job.VideoCode,
@@ -104,7 +135,7 @@ func (h *SEOHandler) ProcessJob(ctx context.Context, job *models.SEOArticleJob)
 	// 1.3 Fetch metadata by video code from api.subth.com
 	metadata, err := h.metadataFetcher.FetchVideoMetadataByCode(ctx, job.VideoCode)
 	if err != nil {
-		h.messenger.SendFailed(ctx, job.VideoID, err)
+		h.sendFailed(ctx, job, err)
 		return fmt.Errorf("failed to fetch metadata: %w", err)
 	}
 
@@ -119,11 +150,7 @@ func (h *SEOHandler) ProcessJob(ctx context.Context, job *models.SEOArticleJob)
 	tags := metadata.Tags
 
 	// 1.5 Fetch previous works for each cast (จาก articles ที่ publish แล้ว)
-	var previousWorks []models.PreviousWork
-	for _, cast := range casts {
-		works, _ := h.metadataFetcher.FetchPreviousWorks(ctx, cast.Slug, 5)
-		previousWorks = append(previousWorks, works...)
-	}
+	previousWorks := h.fetchPreviousWorks(ctx, casts)
 
 	h.logger.InfoContext(ctx, "Metadata loaded from video response",
 		"casts_count", len(casts),
@@ -131,78 +158,38 @@ func (h *SEOHandler) ProcessJob(ctx context.Context, job *models.SEOArticleJob)
 		"has_maker", makerInfo != nil,
 	)
 
-	// 1.7 Fetch ALL gallery images from Suekk storage (Three-Tier)
-	var galleryImages []models.GalleryImage
-	var memberGalleryImages []models.GalleryImage
-	var coverURL string
-
-	h.logger.InfoContext(ctx, "[DEBUG] Gallery fetch start (Two-Tier)",
-		"gallery_path", suekkVideoInfo.GalleryPath,
-		"gallery_count", suekkVideoInfo.GalleryCount,
-		"gallery_safe_count", suekkVideoInfo.GallerySafeCount,
-		"gallery_nsfw_count", suekkVideoInfo.GalleryNsfwCount,
-	)
-
-	if suekkVideoInfo.GalleryPath != "" {
-		// ดึงภาพจากทุก tier (safe, nsfw) - Two-Tier System
-		tieredImages, err := h.suekkVideoFetcher.ListAllGalleryImages(ctx, suekkVideoInfo.GalleryPath)
-		if err != nil {
-			h.logger.WarnContext(ctx, "Failed to list tiered gallery images",
-				"gallery_path", suekkVideoInfo.GalleryPath,
-				"error", err,
-			)
-		} else if tieredImages != nil {
-			h.logger.InfoContext(ctx, "Tiered gallery images fetched",
-				"safe", len(tieredImages.Safe),
-				"nsfw", len(tieredImages.NSFW),
-			)
-
-			// Copy ทุก tier ไป R2 แยก path (public/ และ member/)
-			if h.imageCopier != nil {
-				copyResult, err := h.imageCopier.CopyTieredGallery(ctx, job.VideoCode, tieredImages)
-				if err != nil {
-					h.logger.WarnContext(ctx, "Tiered gallery copy failed",
-						"error", err,
-					)
-				} else if copyResult != nil {
-					galleryImages = copyResult.PublicImages
-					memberGalleryImages = copyResult.MemberImages
-					coverURL = copyResult.CoverURL
+	// 1.7-1.8 Gallery (copy ไป R2 + cover override)
+	gallery := h.fetchGallery(ctx, job.VideoCode, suekkVideoInfo)
+	galleryImages, memberGalleryImages := gallery.publicImages, gallery.memberImages
+	coverURL, tieredImages := gallery.coverURL, gallery.tiered
 
-					h.logger.InfoContext(ctx, "Gallery copied to R2",
-						"public_count", len(galleryImages),
-						"member_count", len(memberGalleryImages),
-						"cover_url", coverURL,
-					)
-				}
-			} else {
-				// Fallback: ใช้ safe/nsfw URLs ตรงๆ (ไม่ copy)
-				for _, url := range tieredImages.Safe {
-					galleryImages = append(galleryImages, models.GalleryImage{URL: url, Width: 1280, Height: 720})
-				}
-				for _, url := range tieredImages.NSFW {
-					memberGalleryImages = append(memberGalleryImages, models.GalleryImage{URL: url, Width: 1280, Height: 720})
-				}
-			}
-		}
-	} else {
-		h.logger.WarnContext(ctx, "[DEBUG] No gallery path available")
+	// 1.9 Input hash - วัตถุดิบไม่เปลี่ยนจาก run ล่าสุดที่ publish สำเร็จ = ข้าม (redelivery/re-run ซ้ำ) ยกเว้น force
+	inputHash := computeInputHash(job, srtContent, metadata, tieredImages, suekkVideoInfo.CoverOverride)
+	if !job.Force && inputHash == h.loadInputHash(ctx, job.VideoCode) {
+		h.logger.InfoContext(ctx, "Inputs unchanged since last publish, skipping SEO job",
+			"video_id", job.VideoID,
+			"video_code", job.VideoCode,
+			"input_hash", inputHash,
+		)
+		h.sendCompleted(ctx, job)
+		return nil
 	}
 
-	h.logger.InfoContext(ctx, "[DEBUG] Gallery images final",
-		"public_count", len(galleryImages),
-		"member_count", len(memberGalleryImages),
-		"has_cover", coverURL != "",
-	)
+	h.sendProgress(ctx, job, ports.StageDataFetched, 25)
 
-	h.sendProgress(ctx, job.VideoID, ports.StageDataFetched, 25)
+	if err := h.checkCancelled(ctx, job, ports.StageDataFetched); err != nil {
+		return err
+	}
 
 	// === Stage 2: AI Processing (Gemini with JSON Mode) ===
-	h.sendProgress(ctx, job.VideoID, ports.StageAI, 30)
+	h.sendProgress(ctx, job, ports.StageAI, 30)
 
 	// Build related articles for contextual linking (from previous works)
 	relatedArticles := h.buildRelatedArticlesForAI(previousWorks, casts, tags)
 
+	// Safe moment settings ของ job นี้ - ใช้ชุดเดียวกันทั้ง AI post-process และ buildArticle
+	safeMoments := h.safeMomentsForJob(metadata.Duration)
+
 	aiInput := &ports.AIInput{
 		SRTContent:      srtContent,
 		VideoMetadata:   metadata,
@@ -211,27 +198,41 @@ func (h *SEOHandler) ProcessJob(ctx context.Context, job *models.SEOArticleJob)
 		PreviousWorks:   previousWorks,
 		GalleryCount:    len(galleryImages),
 		RelatedArticles: relatedArticles,
+		OutputLanguage:  models.NormalizeLanguage(job.OutputLanguage),
+		SafeMoments:     safeMoments,
 	}
 
 	// ใช้ V2: 7-chunk pipeline (Atomic Chunking + Context Feeding)
 	aiOutput, err := h.aiService.GenerateArticleContentV2(ctx, aiInput)
 	if err != nil {
-		h.messenger.SendFailed(ctx, job.VideoID, err)
+		if cancelErr := h.checkCancelled(ctx, job, ports.StageAI); cancelErr != nil {
+			return cancelErr
+		}
+		h.sendFailed(ctx, job, err)
 		return fmt.Errorf("AI generation failed: %w", err)
 	}
 
+	// เก็บสำเนาก่อน sanitize - re-sanitize ด้วยกฎใหม่ได้โดยไม่เรียก Gemini (ดู ResanitizeArticle)
+	rawAIOutput := snapshotAIOutput(aiOutput)
+
 	// Sanitize AI output: แก้ไขชื่อนักแสดงที่ผสมภาษา
-	h.sanitizeAIOutput(aiOutput, casts)
+	h.sanitizeAIOutput(aiOutput, casts, aiInput.OutputLanguage)
+
+	h.sendProgress(ctx, job, ports.StageAIComplete, 60)
 
-	h.sendProgress(ctx, job.VideoID, ports.StageAIComplete, 60)
+	if err := h.checkCancelled(ctx, job, ports.StageAIComplete); err != nil {
+		return err
+	}
 
 	// === Stage 3: TTS & Embedding (Parallel) ===
-	h.sendProgress(ctx, job.VideoID, ports.StageTTSEmbed, 65)
+	h.sendProgress(ctx, job, ports.StageTTSEmbed, 65)
 
 	var wg sync.WaitGroup
 	var embedErr error
 	var audioURL string
 	var audioDuration int
+	var audioVoiceID string
+	var ttsChars, embeddingChars int // สำหรับ processing cost
 
 	// 3.1 TTS Generation (Optional)
 	if job.GenerateTTS && h.ttsService != nil {
@@ -246,8 +247,8 @@ func (h *SEOHandler) ProcessJob(ctx context.Context, job *models.SEOArticleJob)
 				return
 			}
 
-			// Use empty string to use default voice from config
-			ttsResult, err := h.ttsService.GenerateAudio(ctx, ttsScript, "")
+			// Default voice จาก config → retry → fallback voices
+			ttsResult, err := h.generateAudioWithFallback(ctx, ttsScript)
 			if err != nil {
 				h.logger.WarnContext(ctx, "TTS failed (non-critical)",
 					"video_id", job.VideoID,
@@ -255,6 +256,7 @@ func (h *SEOHandler) ProcessJob(ctx context.Context, job *models.SEOArticleJob)
 				)
 				return
 			}
+			ttsChars = ttsResult.CharCount
 
 			// Upload to storage
 			audioPath := fmt.Sprintf("audio/articles/%s/summary.mp3", job.VideoCode)
@@ -268,6 +270,7 @@ func (h *SEOHandler) ProcessJob(ctx context.Context, job *models.SEOArticleJob)
 
 			audioURL = h.storage.GetPublicURL(audioPath)
 			audioDuration = ttsResult.Duration
+			audioVoiceID = ttsResult.VoiceID
 		}()
 	}
 
@@ -282,6 +285,7 @@ func (h *SEOHandler) ProcessJob(ctx context.Context, job *models.SEOArticleJob)
 			embeddingText += " " + h
 		}
 
+		embeddingChars = len([]rune(embeddingText))
 		vector, err := h.embeddingService.GenerateEmbedding(ctx, embeddingText)
 		if err != nil {
 			embedErr = err
@@ -315,60 +319,170 @@ func (h *SEOHandler) ProcessJob(ctx context.Context, job *models.SEOArticleJob)
 		)
 	}
 
-	h.sendProgress(ctx, job.VideoID, ports.StageTTSEmbedComplete, 90)
+	h.sendProgress(ctx, job, ports.StageTTSEmbedComplete, 90)
+
+	// เช็คก่อน publish - หลัง publish แล้วยกเลิกไม่ได้
+	if err := h.checkCancelled(ctx, job, ports.StageTTSEmbedComplete); err != nil {
+		return err
+	}
 
 	// === Stage 4: Build Article ===
 	// (Images already copied to R2 in Stage 1.7)
-	h.sendProgress(ctx, job.VideoID, ports.StagePublishing, 95)
+	h.sendProgress(ctx, job, ports.StagePublishing, 95)
 
-	article := h.buildArticle(job, metadata, aiOutput, casts, makerInfo, tags, previousWorks, galleryImages, memberGalleryImages, coverURL, audioURL, audioDuration, relatedArticles)
+	article := h.buildArticle(job, metadata, aiOutput, casts, makerInfo, tags, previousWorks, galleryImages, memberGalleryImages, coverURL, audioURL, audioDuration, audioVoiceID, relatedArticles, safeMoments)
+	article.Slug = h.resolveArticleSlug(ctx, metadata.ID, article.Slug)
 
-	// Save JSON for debug/review (always)
-	outputPath := fmt.Sprintf("output/%s_article.json", job.VideoCode)
-	if err := h.saveArticleJSON(article, outputPath); err != nil {
-		h.logger.WarnContext(ctx, "Failed to save article JSON", "error", err)
-	} else {
-		h.logger.InfoContext(ctx, "Article saved to JSON for review",
-			"path", outputPath,
-			"video_code", job.VideoCode,
-		)
-	}
+	// Save JSON for debug/review (local และ/หรือ storage ตาม SetArticleOutput)
+	h.storeArticleJSON(ctx, article, job.VideoCode)
 
 	// Publish article to api.subth.com
 	if err := h.articlePublisher.PublishArticle(ctx, article); err != nil {
-		h.messenger.SendFailed(ctx, job.VideoID, err)
+		h.sendFailed(ctx, job, err)
 		return fmt.Errorf("publish failed: %w", err)
 	}
 
 	h.logger.InfoContext(ctx, "Article published successfully",
 		"video_id", job.VideoID,
 		"video_code", job.VideoCode,
+		"slug", article.Slug,
 	)
 
+	h.saveInputHash(ctx, job.VideoCode, inputHash)
+	h.saveAIOutput(ctx, job.VideoCode, &StoredAIOutput{
+		OutputLanguage: aiInput.OutputLanguage,
+		AudioURL:       audioURL,
+		AudioDuration:  audioDuration,
+		AudioVoiceID:   audioVoiceID,
+		AIOutput:       rawAIOutput,
+	})
+
 	// === Done ===
-	h.messenger.SendCompleted(ctx, job.VideoID)
+	h.sendCompleted(ctx, job)
+	h.recordCost(ctx, &models.ProcessingCost{
+		VideoID:            job.VideoID,
+		Source:             models.JobEventSourceSEO,
+		GeminiPromptTokens: aiOutput.TokenUsage.PromptTokens,
+		GeminiOutputTokens: aiOutput.TokenUsage.CandidateTokens,
+		TTSCharacters:      ttsChars,
+		EmbeddingChars:     embeddingChars,
+		JobDuration:        time.Since(startTime),
+		CreatedAt:          time.Now(),
+	})
 
 	h.logger.InfoContext(ctx, "SEO job completed",
 		"video_id", job.VideoID,
 		"video_code", job.VideoCode,
 		"duration", time.Since(startTime),
+		"prompt_tokens", aiOutput.TokenUsage.PromptTokens,
+		"candidate_tokens", aiOutput.TokenUsage.CandidateTokens,
+		"total_tokens", aiOutput.TokenUsage.TotalTokens,
 	)
 
 	return nil
 }
 
-// saveArticleJSON saves article content to JSON file for review
-func (h *SEOHandler) saveArticleJSON(article *models.ArticleContent, path string) error {
+// jobGallery ภาพ gallery ของ job หลัง copy ไป R2 และ apply cover override แล้ว
+type jobGallery struct {
+	publicImages []models.GalleryImage
+	memberImages []models.GalleryImage
+	coverURL     string
+	tiered       *models.TieredGalleryImages
+}
+
+// fetchGallery ดึงภาพทุก tier จาก Suekk storage, copy ไป R2 และ apply cover override (ล้มเหลว = gallery ว่าง, ไม่ error)
+func (h *SEOHandler) fetchGallery(ctx context.Context, videoCode string, info *models.SuekkVideoInfo) *jobGallery {
+	// 1.7 Fetch ALL gallery images from Suekk storage (Three-Tier)
+	var galleryImages []models.GalleryImage
+	var memberGalleryImages []models.GalleryImage
+	var coverURL string
+	var tieredImages *models.TieredGalleryImages
+	var err error
+
+	h.logger.InfoContext(ctx, "[DEBUG] Gallery fetch start (Two-Tier)",
+		"gallery_path", info.GalleryPath,
+		"gallery_count", info.GalleryCount,
+		"gallery_safe_count", info.GallerySafeCount,
+		"gallery_nsfw_count", info.GalleryNsfwCount,
+	)
+
+	if info.GalleryPath != "" {
+		// ดึงภาพจากทุก tier (safe, nsfw) - Two-Tier System
+		tieredImages, err = h.suekkVideoFetcher.ListAllGalleryImages(ctx, info.GalleryPath)
+		if err != nil {
+			h.logger.WarnContext(ctx, "Failed to list tiered gallery images",
+				"gallery_path", info.GalleryPath,
+				"error", err,
+			)
+		} else if tieredImages != nil {
+			h.logger.InfoContext(ctx, "Tiered gallery images fetched",
+				"super_safe", len(tieredImages.SuperSafe),
+				"safe", len(tieredImages.Safe),
+				"nsfw", len(tieredImages.NSFW),
+			)
+
+			// Copy ไป R2 แยก path (public/ และ member/) ตาม tier → audience
+			if h.imageCopier != nil {
+				copyResult, err := h.imageCopier.CopyTieredGallery(ctx, videoCode, tieredImages, h.audience())
+				if err != nil {
+					h.logger.WarnContext(ctx, "Tiered gallery copy failed",
+						"error", err,
+					)
+				} else if copyResult != nil {
+					galleryImages = copyResult.PublicImages
+					memberGalleryImages = copyResult.MemberImages
+					coverURL = copyResult.CoverURL
+
+					h.logger.InfoContext(ctx, "Gallery copied to R2",
+						"public_count", len(galleryImages),
+						"member_count", len(memberGalleryImages),
+						"failed_count", len(copyResult.Failed),
+						"cover_url", coverURL,
+					)
+				}
+			} else {
+				// Fallback: ใช้ URLs ต้นทางตรงๆ (ไม่ copy = ไม่ได้อ่านภาพ → ใช้ขนาด frame มาตรฐาน)
+				publicURLs, memberURLs := h.audience().Split(tieredImages)
+				for _, url := range publicURLs {
+					galleryImages = append(galleryImages, models.GalleryImage{URL: url, Width: models.DefaultGalleryImageWidth, Height: models.DefaultGalleryImageHeight})
+				}
+				for _, url := range memberURLs {
+					memberGalleryImages = append(memberGalleryImages, models.GalleryImage{URL: url, Width: models.DefaultGalleryImageWidth, Height: models.DefaultGalleryImageHeight})
+				}
+			}
+		}
+	} else {
+		h.logger.WarnContext(ctx, "[DEBUG] No gallery path available")
+	}
+
+	// 1.8 Cover override (editor เลือกเอง) - มีผลเหนือ cover ที่เลือกอัตโนมัติ
+	if info.CoverOverride != "" {
+		if overrideURL := h.applyCoverOverride(ctx, videoCode, info.CoverOverride, tieredImages); overrideURL != "" {
+			coverURL = overrideURL
+		}
+	}
+
+	h.logger.InfoContext(ctx, "[DEBUG] Gallery images final",
+		"public_count", len(galleryImages),
+		"member_count", len(memberGalleryImages),
+		"has_cover", coverURL != "",
+	)
+
+	return &jobGallery{
+		publicImages: galleryImages,
+		memberImages: memberGalleryImages,
+		coverURL:     coverURL,
+		tiered:       tieredImages,
+	}
+}
+
+// saveArticleJSON saves article JSON to local file for review
+func (h *SEOHandler) saveArticleJSON(jsonData []byte, path string) error {
 	// Create output directory if not exists
 	if err := os.MkdirAll("output", 0755); err != nil {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	jsonData, err := json.MarshalIndent(article, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal article: %w", err)
-	}
-
 	if err := os.WriteFile(path, jsonData, 0644); err != nil {
 		return fmt.Errorf("failed to write file: %w", err)
 	}
@@ -376,11 +490,109 @@ func (h *SEOHandler) saveArticleJSON(article *models.ArticleContent, path string
 	return nil
 }
 
-func (h *SEOHandler) sendProgress(ctx context.Context, videoID, stage string, progress int) {
-	update := models.NewProgressUpdate(videoID, stage, progress)
+// applyCoverOverride หา source ของ cover override แล้ว copy ไป R2
+// override = ชื่อไฟล์ใน public tier (default: safe/) หรือ external URL
+// คืนค่า "" ถ้าใช้ไม่ได้ (fallback เป็น cover อัตโนมัติ)
+func (h *SEOHandler) applyCoverOverride(ctx context.Context, videoCode, override string, tiered *models.TieredGalleryImages) string {
+	var srcURL, destName string
+
+	if strings.HasPrefix(override, "http://") || strings.HasPrefix(override, "https://") {
+		srcURL = override
+		sum := sha1.Sum([]byte(override))
+		destName = fmt.Sprintf("cover-%x.jpg", sum[:6])
+	} else {
+		// ต้องยังอยู่ใน public tier (admin อาจย้ายภาพออกไปหลังตั้ง override)
+		if tiered != nil {
+			publicURLs, _ := h.audience().Split(tiered)
+			for _, u := range publicURLs {
+				if galleryFilename(u) == override {
+					srcURL = u
+					break
+				}
+			}
+		}
+		destName = "cover-" + override
+	}
+
+	if srcURL == "" {
+		h.logger.WarnContext(ctx, "Cover override not found in public gallery, using auto cover",
+			"video_code", videoCode,
+			"override", override,
+		)
+		return ""
+	}
+
+	if h.imageCopier == nil {
+		return srcURL
+	}
+
+	newURL, err := h.imageCopier.CopyImage(ctx, videoCode, srcURL, destName)
+	if err != nil {
+		h.logger.WarnContext(ctx, "Failed to copy cover override, using auto cover",
+			"video_code", videoCode,
+			"override", override,
+			"error", err,
+		)
+		return ""
+	}
+
+	h.logger.InfoContext(ctx, "Cover override applied",
+		"video_code", videoCode,
+		"override", override,
+		"cover_url", newURL,
+	)
+	return newURL
+}
+
+// galleryFilename ดึงชื่อไฟล์จาก URL (ตัด query string ของ presigned URL)
+func galleryFilename(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return path.Base(parsed.Path)
+}
+
+func (h *SEOHandler) sendProgress(ctx context.Context, job *models.SEOArticleJob, stage string, progress float64) {
+	update := models.NewProgressUpdate(job.VideoID, job.VideoCode, stage, progress)
 	if err := h.messenger.SendProgress(ctx, update); err != nil {
 		h.logger.WarnContext(ctx, "Failed to send progress", "error", err)
 	}
+	h.recordEvent(ctx, models.NewJobEvent(job.VideoID, stage, progress, ""))
+}
+
+func (h *SEOHandler) sendCompleted(ctx context.Context, job *models.SEOArticleJob) {
+	if err := h.messenger.SendCompleted(ctx, job.VideoID, job.VideoCode); err != nil {
+		h.logger.WarnContext(ctx, "Failed to send completed", "error", err)
+	}
+	h.recordEvent(ctx, models.NewJobEvent(job.VideoID, ports.StageCompleted, 100, ""))
+}
+
+func (h *SEOHandler) sendFailed(ctx context.Context, job *models.SEOArticleJob, jobErr error) {
+	if err := h.messenger.SendFailed(ctx, job.VideoID, job.VideoCode, jobErr); err != nil {
+		h.logger.WarnContext(ctx, "Failed to send failed status", "error", err)
+	}
+	h.recordEvent(ctx, models.NewJobEvent(job.VideoID, ports.StageFailed, 0, jobErr.Error()))
+}
+
+// recordEvent บันทึก event ลง job_events (non-critical)
+func (h *SEOHandler) recordEvent(ctx context.Context, event *models.JobEvent) {
+	if h.eventLog == nil {
+		return
+	}
+	if err := h.eventLog.RecordEvent(ctx, event); err != nil {
+		h.logger.WarnContext(ctx, "Failed to record job event", "stage", event.Stage, "error", err)
+	}
+}
+
+// recordCost บันทึก processing cost ของ job (non-critical)
+func (h *SEOHandler) recordCost(ctx context.Context, cost *models.ProcessingCost) {
+	if h.eventLog == nil {
+		return
+	}
+	if err := h.eventLog.RecordCost(ctx, cost); err != nil {
+		h.logger.WarnContext(ctx, "Failed to record processing cost", "video_id", cost.VideoID, "error", err)
+	}
 }
 
 func (h *SEOHandler) buildArticle(
@@ -396,7 +608,9 @@ func (h *SEOHandler) buildArticle(
 	coverURL string,
 	audioURL string,
 	audioDuration int,
+	audioVoiceID string,
 	relatedArticles []ports.RelatedArticleForAI,
+	safeMoments models.SafeMomentSettings,
 ) *models.ArticleContent {
 	now := time.Now()
 
@@ -421,56 +635,28 @@ func (h *SEOHandler) buildArticle(
 	}
 
 	// Add alt texts to gallery images
-	// ใช้ AI-generated alt ที่อธิบายฉากจาก script (ดูดีกว่า format แห้งๆ)
-	for i := range galleryImages {
-		if i < len(aiOutput.GalleryAlts) {
-			galleryImages[i].Alt = aiOutput.GalleryAlts[i]
-		} else {
-			// Fallback สำหรับภาพที่เกินจำนวน alt ที่ AI generate
-			galleryImages[i].Alt = fmt.Sprintf("ฉากจาก %s", metadata.RealCode)
-		}
-	}
+	// ใช้ AI-generated alt ที่อธิบายฉากจาก script (ดูดีกว่า format แห้งๆ) - ทุกภาพต้องมี alt ไม่ซ้ำกัน
+	assignGalleryAlts(galleryImages, aiOutput.GalleryAlts, metadata.RealCode)
 
 	// Filter & validate key moments
-	// Option B: เก็บเฉพาะ moments ในช่วง 10 นาทีแรก (600 วินาที) เพื่อหลีกเลี่ยง explicit content
-	const safeThresholdSeconds = 600 // 10 นาที - ช่วง intro/story setup
-
+	// Option B: เก็บเฉพาะ moments ในช่วง safe (default 10 นาทีแรก) เพื่อหลีกเลี่ยง explicit content
+	// ใช้ settings ชุดเดียวกับ processKeyMomentsSafe (AIInput.SafeMoments)
+	// Public = Google Schema, Internal = Members (ชุดใหญ่กว่า กรองแบบเดียวกัน)
 	originalCount := len(aiOutput.KeyMoments)
-	var safeKeyMoments []models.KeyMoment
-	for _, km := range aiOutput.KeyMoments {
-		// แปลง milliseconds เป็น seconds ถ้าจำเป็น
-		if km.StartOffset > 10000 {
-			km.StartOffset = km.StartOffset / 1000
-		}
-		if km.EndOffset > 10000 {
-			km.EndOffset = km.EndOffset / 1000
-		}
-
-		// กรองเฉพาะ moments ที่อยู่ในช่วง safe (10 นาทีแรก)
-		if km.StartOffset > safeThresholdSeconds {
-			continue // ข้าม moments หลังช่วง safe
-		}
-
-		// Validate minimum duration: ต้องยาวอย่างน้อย 30 วินาที
-		duration := km.EndOffset - km.StartOffset
-		if duration < 30 {
-			km.EndOffset = km.StartOffset + 30
-			if metadata.Duration > 0 && km.EndOffset > metadata.Duration {
-				km.EndOffset = metadata.Duration
-			}
-		}
-
-		km.URL = fmt.Sprintf("/member/videos/%s?t=%d", metadata.ID, km.StartOffset)
-		safeKeyMoments = append(safeKeyMoments, km)
+	internalSource := aiOutput.InternalKeyMoments
+	if len(internalSource) == 0 {
+		// AI output เก่า (ก่อนแยก internal) - ใช้ public เป็น internal ไปก่อน
+		internalSource = aiOutput.KeyMoments
 	}
-
-	// ใช้เฉพาะ safe moments
-	aiOutput.KeyMoments = safeKeyMoments
+	aiOutput.KeyMoments = safeKeyMomentsForArticle(aiOutput.KeyMoments, safeMoments, metadata)
+	aiOutput.InternalKeyMoments = safeKeyMomentsForArticle(internalSource, safeMoments, metadata)
 
 	h.logger.Info("Key moments filtered for safety",
 		"original_count", originalCount,
-		"safe_count", len(safeKeyMoments),
-		"threshold_seconds", safeThresholdSeconds,
+		"safe_count", len(aiOutput.KeyMoments),
+		"internal_count", len(aiOutput.InternalKeyMoments),
+		"threshold_seconds", safeMoments.ThresholdSeconds,
+		"time_filter", !safeMoments.Disabled,
 	)
 
 	// Build MakerInfo
@@ -528,13 +714,12 @@ func (h *SEOHandler) buildArticle(
 
 	// ใช้ RealCode (movie code เช่น DLDSS-471) เป็น slug สำหรับ SEO
 	// Fallback เป็น internal code ถ้าไม่มี RealCode
-	slug := strings.ToLower(metadata.RealCode)
-	if slug == "" {
-		slug = job.VideoCode
-	}
+	// caller เช็ค slug ซ้ำด้วย resolveArticleSlug ก่อน publish
+	slug := baseArticleSlug(job, metadata)
 
 	return &models.ArticleContent{
 		// === Core ===
+		SchemaVersion:    models.ArticleSchemaVersion,
 		VideoID:          metadata.ID,
 		Title:            aiOutput.Title,
 		MetaTitle:        aiOutput.MetaTitle,
@@ -553,6 +738,9 @@ func (h *SEOHandler) buildArticle(
 		Highlights:       aiOutput.Highlights,
 		DetailedReview:   aiOutput.DetailedReview,
 
+		// === Member Only ===
+		InternalKeyMoments: aiOutput.InternalKeyMoments,
+
 		// === Cast & Relations ===
 		CastProfiles:    castProfiles,
 		MakerInfo:       makerInfo,
@@ -618,6 +806,7 @@ func (h *SEOHandler) buildArticle(
 		// === TTS ===
 		AudioSummaryURL: audioURL,
 		AudioDuration:   audioDuration,
+		AudioVoiceID:    audioVoiceID,
 
 		// === Gallery & FAQ ===
 		GalleryImages:       galleryImages,       // Public (safe - admin approved) - R2
@@ -666,60 +855,6 @@ func formatDuration(seconds int) string {
 	return result
 }
 
-// filterValidContextualLinks กรอง contextual links ที่ valid
-// - slug ต้องมีอยู่จริง (ป้องกัน AI แต่ง slug ขึ้นมาเอง)
-// - ห้าม link ไปหาตัวเอง (self-reference)
-// - เพิ่ม ThumbnailUrl จาก validArticles
-func (h *SEOHandler) filterValidContextualLinks(
-	links []models.ContextualLink,
-	validArticles []ports.RelatedArticleForAI,
-	currentSlug string,
-) []models.ContextualLink {
-	if len(links) == 0 || len(validArticles) == 0 {
-		return nil
-	}
-
-	// สร้าง map ของ valid slugs -> article data (รวม ThumbnailUrl)
-	validArticleMap := make(map[string]ports.RelatedArticleForAI)
-	for _, article := range validArticles {
-		validArticleMap[article.Slug] = article
-	}
-
-	// กรองเฉพาะ links ที่:
-	// 1. slug อยู่ใน valid slugs
-	// 2. ไม่ใช่ตัวเอง (self-reference)
-	filtered := make([]models.ContextualLink, 0, len(links))
-	for _, link := range links {
-		// ห้าม link ไปหาตัวเอง
-		if link.LinkedSlug == currentSlug {
-			h.logger.Warn("Filtered out self-referencing contextual link",
-				"slug", link.LinkedSlug,
-				"reason", "self-reference",
-			)
-			continue
-		}
-
-		if article, ok := validArticleMap[link.LinkedSlug]; ok {
-			// เพิ่ม ThumbnailUrl และ QualityScore จาก validArticles
-			link.ThumbnailUrl = article.ThumbnailUrl
-			link.QualityScore = article.QualityScore
-			filtered = append(filtered, link)
-		} else {
-			h.logger.Warn("Filtered out invalid contextual link",
-				"slug", link.LinkedSlug,
-				"reason", "slug not in valid articles",
-			)
-		}
-	}
-
-	h.logger.Info("Filtered contextual links",
-		"original", len(links),
-		"valid", len(filtered),
-	)
-
-	return filtered
-}
-
 // buildRelatedArticlesForAI สร้าง RelatedArticles สำหรับ AI ใช้สร้าง contextual links
 // ใช้ข้อมูลจาก previousWorks (ผลงานก่อนหน้าของ cast เดียวกัน)
 func (h *SEOHandler) buildRelatedArticlesForAI(
@@ -1036,6 +1171,18 @@ func filterEmptyHighlights(highlights []string, casts []models.CastMetadata) []s
 	return filtered
 }
 
+// safeKeyMomentsForArticle กรอง moments ตามช่วง safe + normalize offsets + สร้าง URL
+// คืน slice ใหม่เสมอ (ไม่แก้ input)
+func safeKeyMomentsForArticle(moments []models.KeyMoment, settings models.SafeMomentSettings, metadata *models.VideoMetadata) []models.KeyMoment {
+	var safe []models.KeyMoment
+	for _, km := range moments {
+		if normalized, ok := models.NormalizeKeyMoment(km, settings, metadata.ID, metadata.Duration); ok {
+			safe = append(safe, normalized)
+		}
+	}
+	return safe
+}
+
 // filterEmptyKeyMoments กรอง KeyMoments ที่ชื่อเป็นแค่ชื่อนักแสดง (เฉพาะชื่อเต็ม)
 func filterEmptyKeyMoments(moments []models.KeyMoment, casts []models.CastMetadata) []models.KeyMoment {
 	if len(moments) == 0 {
@@ -1172,6 +1319,38 @@ func convertParagraphMarkers(text string) string {
 	return result
 }
 
+// SRT artifacts ที่ AI คัดลอกมาจาก subtitle โดยตรง
+var (
+	// "12 00:01:02,500 --> 00:01:05,000" หรือ timestamp เดี่ยว (มี milliseconds)
+	srtTimestampRegex = regexp.MustCompile(`(?:\b\d{1,5}\s+)?\d{1,2}:\d{2}:\d{2}[,.]\d{3}(?:\s*-->\s*\d{1,2}:\d{2}:\d{2}[,.]\d{3})?`)
+	// บรรทัดที่มีแต่ตัวเลข (cue number) - ลบเฉพาะเมื่อพบ timestamp ในข้อความ
+	srtCueLineRegex = regexp.MustCompile(`(?m)^[ \t]*\d{1,5}[ \t]*$\n?`)
+	// ช่องว่างซ้ำหลังลบ (ไม่รวม newline เพื่อรักษาย่อหน้า)
+	srtSpaceRegex = regexp.MustCompile(`[ \t]{2,}`)
+)
+
+// stripSRTArtifacts ลบ SRT timestamps และ cue numbers ที่หลุดมาใน prose
+// คืนข้อความที่สะอาดและจำนวนที่ลบ (สำหรับ logging)
+func stripSRTArtifacts(text string) (string, int) {
+	matches := srtTimestampRegex.FindAllStringIndex(text, -1)
+	if len(matches) == 0 {
+		return text, 0
+	}
+
+	count := len(matches)
+	result := srtTimestampRegex.ReplaceAllString(text, "")
+
+	count += len(srtCueLineRegex.FindAllStringIndex(result, -1))
+	result = srtCueLineRegex.ReplaceAllString(result, "")
+
+	result = srtSpaceRegex.ReplaceAllString(result, " ")
+	for strings.Contains(result, "\n\n\n") {
+		result = strings.ReplaceAll(result, "\n\n\n", "\n\n")
+	}
+
+	return strings.TrimSpace(result), count
+}
+
 // extractEnglishPart ดึงเฉพาะส่วนที่เป็นภาษาอังกฤษออกมา
 func extractEnglishPart(s string) string {
 	var result strings.Builder
@@ -1299,17 +1478,23 @@ func sanitizeTextWithCastNames(text string, castNameMap map[string]string) (stri
 }
 
 // sanitizeAIOutput ทำความสะอาด output จาก AI โดย:
+// 0. ลบ SRT timestamps / cue numbers ที่หลุดมา
 // 1. แทนที่ชื่อนักแสดงที่ผสมภาษา (mixed-language)
 // 2. ลบชื่อที่ซ้ำติดกัน (repeated names)
 // 3. แทนชื่อที่ใช้บ่อยเกินไปด้วยสรรพนาม (pronoun substitution)
-func (h *SEOHandler) sanitizeAIOutput(aiOutput *ports.AIOutput, casts []models.CastMetadata) {
+func (h *SEOHandler) sanitizeAIOutput(aiOutput *ports.AIOutput, casts []models.CastMetadata, lang string) {
 	castNameMap := buildCastNameMap(casts)
+	isThai := models.IsThaiOutput(lang)
 
 	// Helper function to sanitize with all steps
 	totalReplacements := 0
+	srtArtifacts := 0
 	sanitize := func(text string) string {
+		// Step 0: ลบ SRT timestamps ที่ AI คัดลอกมา (e.g., "00:01:02,500 --> 00:01:05,000")
+		result, srtCount := stripSRTArtifacts(text)
+		srtArtifacts += srtCount
+
 		// Step 1: แก้ mixed-language names (e.g., "เมกามิ Jun" → "Megami Jun")
-		result := text
 		if len(castNameMap) > 0 {
 			var count int
 			result, count = sanitizeTextWithCastNames(result, castNameMap)
@@ -1325,8 +1510,10 @@ func (h *SEOHandler) sanitizeAIOutput(aiOutput *ports.AIOutput, casts []models.C
 	// Helper for long text fields - includes pronoun substitution + paragraph markers conversion
 	sanitizeLongText := func(text string) string {
 		result := sanitize(text)
-		// Step 3: แทนชื่อที่ใช้บ่อยด้วยสรรพนาม (เธอ, first name)
-		result = replaceExcessiveNamesWithPronouns(result, casts)
+		// Step 3: แทนชื่อที่ใช้บ่อยด้วยสรรพนาม (เธอ, first name) - สรรพนามภาษาไทย ใช้เฉพาะบทความไทย
+		if isThai {
+			result = replaceExcessiveNamesWithPronouns(result, casts)
+		}
 		// Step 4: แปลง [PARA] markers เป็น \n\n (AI ใช้ [PARA] เพื่อหลีกเลี่ยง JSON encoding issues)
 		result = convertParagraphMarkers(result)
 		return result
@@ -1338,8 +1525,8 @@ func (h *SEOHandler) sanitizeAIOutput(aiOutput *ports.AIOutput, casts []models.C
 	aiOutput.Title = sanitize(aiOutput.Title)
 	aiOutput.MetaTitle = sanitize(aiOutput.MetaTitle)
 
-	// Ensure metaTitle มี "ซับไทย" (SEO keyword สำคัญ)
-	if !strings.Contains(aiOutput.MetaTitle, "ซับไทย") {
+	// Ensure metaTitle มี "ซับไทย" (SEO keyword สำคัญ) - เฉพาะบทความภาษาไทย
+	if isThai && !strings.Contains(aiOutput.MetaTitle, "ซับไทย") {
 		// เพิ่ม "ซับไทย" หลัง ] แรก หรือต่อท้าย
 		if idx := strings.Index(aiOutput.MetaTitle, "]"); idx != -1 {
 			aiOutput.MetaTitle = aiOutput.MetaTitle[:idx+1] + " ซับไทย" + aiOutput.MetaTitle[idx+1:]
@@ -1349,6 +1536,15 @@ func (h *SEOHandler) sanitizeAIOutput(aiOutput *ports.AIOutput, casts []models.C
 	}
 
 	aiOutput.MetaDescription = sanitize(aiOutput.MetaDescription)
+
+	// ตัด meta fields ให้อยู่ในความยาวที่ Google แสดงผล (ไม่ให้ Google ตัดเองกลางประโยค)
+	limits := h.metaLimits.withDefaults()
+	requiredKeyword := ""
+	if isThai {
+		requiredKeyword = "ซับไทย"
+	}
+	aiOutput.MetaTitle = h.enforceMetaLimit("metaTitle", aiOutput.MetaTitle, limits.MaxTitleChars, requiredKeyword)
+	aiOutput.MetaDescription = h.enforceMetaLimit("metaDescription", aiOutput.MetaDescription, limits.MaxDescriptionChars, "")
 	aiOutput.ThumbnailAlt = sanitize(aiOutput.ThumbnailAlt)
 
 	// Sanitize long text fields (with pronoun substitution for natural reading)
@@ -1404,9 +1600,13 @@ func (h *SEOHandler) sanitizeAIOutput(aiOutput *ports.AIOutput, casts []models.C
 	for i := range aiOutput.KeyMoments {
 		aiOutput.KeyMoments[i].Name = sanitize(aiOutput.KeyMoments[i].Name)
 	}
+	for i := range aiOutput.InternalKeyMoments {
+		aiOutput.InternalKeyMoments[i].Name = sanitize(aiOutput.InternalKeyMoments[i].Name)
+	}
 
 	// Filter out KeyMoments that are just actor names
 	aiOutput.KeyMoments = filterEmptyKeyMoments(aiOutput.KeyMoments, casts)
+	aiOutput.InternalKeyMoments = filterEmptyKeyMoments(aiOutput.InternalKeyMoments, casts)
 
 	for i := range aiOutput.CastBios {
 		aiOutput.CastBios[i].Bio = sanitize(aiOutput.CastBios[i].Bio)
@@ -1434,6 +1634,12 @@ func (h *SEOHandler) sanitizeAIOutput(aiOutput *ports.AIOutput, casts []models.C
 		)
 	}
 
+	if srtArtifacts > 0 {
+		h.logger.Warn("Stripped leaked SRT timestamps from AI output",
+			"occurrences", srtArtifacts,
+		)
+	}
+
 	if totalReplacements > 0 {
 		h.logger.Info("AI output sanitized for mixed-language cast names",
 			"total_replacements", totalReplacements,
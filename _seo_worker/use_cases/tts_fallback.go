@@ -0,0 +1,85 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"seo-worker/domain/ports"
+)
+
+// TTSFallbackConfig retry + fallback voices สำหรับ TTS
+type TTSFallbackConfig struct {
+	FallbackVoiceIDs []string      // ลองตามลำดับหลัง voice หลักล้มเหลวครบทุก retry
+	MaxRetries       int           // จำนวน retry ของ voice หลัก (ไม่รวมครั้งแรก)
+	RetryBackoff     time.Duration // backoff เริ่มต้น (x2 ทุกครั้ง)
+}
+
+// SetTTSFallback ตั้งค่า retry/fallback voices (ไม่ตั้ง = ลองครั้งเดียวเหมือนเดิม)
+func (h *SEOHandler) SetTTSFallback(cfg TTSFallbackConfig) {
+	h.ttsFallback = cfg
+}
+
+// generateAudioWithFallback สร้างเสียงด้วย voice หลัก (retry + backoff)
+// ถ้ายังล้มเหลว ลอง fallback voices ทีละตัวก่อนยอมแพ้
+// error ที่ retry ไม่ช่วย: ErrTTSRejected = ข้าม retry ไปลอง fallback voices, ErrTTSInvalidInput = หยุดทันที
+func (h *SEOHandler) generateAudioWithFallback(ctx context.Context, script string) (*ports.TTSResult, error) {
+	var lastErr error
+	backoff := h.ttsFallback.RetryBackoff
+	retries := 0
+
+	// 1. Voice หลัก ("" = default voice จาก config ของ client)
+	for attempt := 0; attempt <= h.ttsFallback.MaxRetries; attempt++ {
+		if attempt > 0 {
+			if errors.Is(lastErr, ports.ErrTTSRejected) {
+				break
+			}
+			retries++
+			h.logger.WarnContext(ctx, "Retrying TTS",
+				"attempt", attempt,
+				"backoff", backoff,
+				"error", lastErr,
+			)
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		result, err := h.ttsService.GenerateAudio(ctx, script, "")
+		if err == nil {
+			return result, nil
+		}
+		if errors.Is(err, ports.ErrTTSInvalidInput) {
+			return nil, err
+		}
+		lastErr = err
+	}
+
+	// 2. Fallback voices
+	for _, voiceID := range h.ttsFallback.FallbackVoiceIDs {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		h.logger.WarnContext(ctx, "Primary TTS voice failed, trying fallback voice",
+			"voice_id", voiceID,
+			"error", lastErr,
+		)
+
+		result, err := h.ttsService.GenerateAudio(ctx, script, voiceID)
+		if err == nil {
+			return result, nil
+		}
+		if errors.Is(err, ports.ErrTTSInvalidInput) {
+			return nil, err
+		}
+		lastErr = err
+	}
+
+	return nil, fmt.Errorf("TTS failed after %d retries and %d fallback voices: %w",
+		retries, len(h.ttsFallback.FallbackVoiceIDs), lastErr)
+}
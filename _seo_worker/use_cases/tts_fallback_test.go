@@ -0,0 +1,85 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"seo-worker/domain/ports"
+)
+
+// scriptedTTS คืน error ตามลำดับที่กำหนด (หมดแล้ว = สำเร็จ) และจำ voice ที่ถูกเรียก
+type scriptedTTS struct {
+	errs   []error
+	voices []string
+}
+
+func (s *scriptedTTS) GenerateAudio(ctx context.Context, text string, voiceID string) (*ports.TTSResult, error) {
+	s.voices = append(s.voices, voiceID)
+	if len(s.errs) > 0 {
+		err := s.errs[0]
+		s.errs = s.errs[1:]
+		return nil, err
+	}
+	return &ports.TTSResult{VoiceID: voiceID}, nil
+}
+
+func TestGenerateAudioWithFallback(t *testing.T) {
+	transient := errors.New("TTS API error: 503 - unavailable")
+	rejected := fmt.Errorf("%w: TTS API error: 404 - voice not found", ports.ErrTTSRejected)
+	invalid := fmt.Errorf("%w: empty text", ports.ErrTTSInvalidInput)
+
+	tests := []struct {
+		name       string
+		errs       []error
+		wantVoices []string
+		wantErr    error
+	}{
+		{
+			name:       "transient error retried on primary voice",
+			errs:       []error{transient, transient},
+			wantVoices: []string{"", "", ""},
+		},
+		{
+			name:       "rejected skips retries and tries fallback voice",
+			errs:       []error{rejected},
+			wantVoices: []string{"", "fallback-1"},
+		},
+		{
+			name:       "invalid input stops immediately",
+			errs:       []error{invalid},
+			wantVoices: []string{""},
+			wantErr:    ports.ErrTTSInvalidInput,
+		},
+		{
+			name:       "all voices fail",
+			errs:       []error{transient, transient, transient, rejected, rejected},
+			wantVoices: []string{"", "", "", "fallback-1", "fallback-2"},
+			wantErr:    ports.ErrTTSRejected,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tts := &scriptedTTS{errs: tt.errs}
+			h := &SEOHandler{ttsService: tts, logger: slog.Default()}
+			h.SetTTSFallback(TTSFallbackConfig{
+				FallbackVoiceIDs: []string{"fallback-1", "fallback-2"},
+				MaxRetries:       2,
+			})
+
+			_, err := h.generateAudioWithFallback(context.Background(), "script")
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if fmt.Sprint(tts.voices) != fmt.Sprint(tt.wantVoices) {
+				t.Errorf("voices = %q, want %q", tts.voices, tt.wantVoices)
+			}
+		})
+	}
+}
@@ -36,6 +36,7 @@ export const VIDEO_ROUTES = {
   REGENERATE_GALLERY: (id: string) => `/api/v1/videos/${id}/regenerate-gallery`,
   // Dead Letter Queue (DLQ) Management
   DLQ_LIST: '/api/v1/videos/dlq',
+  DLQ_DETAIL: (id: string) => `/api/v1/videos/dlq/${id}`,
   DLQ_RETRY: (id: string) => `/api/v1/videos/dlq/${id}/retry`,
   DLQ_DELETE: (id: string) => `/api/v1/videos/dlq/${id}`,
 }
@@ -56,6 +56,8 @@ const SETTING_LABELS: Record<string, string> = {
   'on_transcode_complete': 'แจ้งเตือนเมื่อแปลงไฟล์สำเร็จ',
   'on_transcode_fail': 'แจ้งเตือนเมื่อแปลงไฟล์ล้มเหลว',
   'on_worker_offline': 'แจ้งเตือนเมื่อ Worker ออฟไลน์',
+  'on_subtitle_ready': 'แจ้งเตือนเมื่อถอดซับไตเติ้ลเสร็จ',
+  'on_translation_ready': 'แจ้งเตือนเมื่อแปลซับไตเติ้ลเสร็จ',
 }
 
 function getSettingLabel(key: string): string {
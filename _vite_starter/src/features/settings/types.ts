@@ -56,6 +56,7 @@ export type SettingsByCategory = Record<string, SettingResponse[]>
 export const SETTING_CATEGORIES: CategoryInfo[] = [
   { name: 'general', label: 'ทั่วไป', description: 'ชื่อเว็บไซต์ คำอธิบาย และขนาดไฟล์สูงสุด' },
   { name: 'transcoding', label: 'แปลงวิดีโอ', description: 'กำหนดความละเอียดของวิดีโอที่ต้องการแปลง' },
+  { name: 'subtitle', label: 'ซับไตเติ้ล', description: 'Retry อัตโนมัติสำหรับซับไตเติ้ลที่ค้างในคิว' },
   { name: 'alert', label: 'แจ้งเตือน', description: 'Discord, Telegram และเงื่อนไขการแจ้งเตือน' },
 ]
 
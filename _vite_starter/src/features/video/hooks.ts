@@ -16,6 +16,7 @@ export const videoKeys = {
   workers: () => [...videoKeys.all, 'workers'] as const,
   dlq: () => [...videoKeys.all, 'dlq'] as const,
   dlqList: (params?: { page?: number; limit?: number }) => [...videoKeys.dlq(), 'list', params] as const,
+  dlqDetail: (id: string) => [...videoKeys.dlq(), 'detail', id] as const,
 }
 
 // ดึงรายการวิดีโอ
@@ -171,6 +172,15 @@ export function useDLQVideos(params?: { page?: number; limit?: number }) {
   })
 }
 
+// ดึงรายละเอียด video ใน DLQ พร้อม job payload
+export function useDLQDetail(videoId: string) {
+  return useQuery({
+    queryKey: videoKeys.dlqDetail(videoId),
+    queryFn: () => videoService.getDLQDetail(videoId),
+    enabled: !!videoId,
+  })
+}
+
 // Retry video จาก DLQ
 export function useRetryDLQ() {
   const queryClient = useQueryClient()
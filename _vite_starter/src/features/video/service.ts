@@ -10,6 +10,7 @@ import type {
   TranscodingStats,
   WorkersResponse,
   DLQVideo,
+  DLQJobDetail,
   UploadLimits,
   GalleryUrlsResponse,
   GalleryImagesResponse,
@@ -104,6 +105,11 @@ export const videoService = {
     return apiClient.getPaginated<DLQVideo>(VIDEO_ROUTES.DLQ_LIST, { params })
   },
 
+  // ดึงรายละเอียด video ใน DLQ พร้อม job payload
+  async getDLQDetail(videoId: string): Promise<DLQJobDetail> {
+    return apiClient.get<DLQJobDetail>(VIDEO_ROUTES.DLQ_DETAIL(videoId))
+  },
+
   // Retry video จาก DLQ
   async retryDLQ(videoId: string): Promise<{ message: string; video_id: string; code: string }> {
     return apiClient.post(VIDEO_ROUTES.DLQ_RETRY(videoId))
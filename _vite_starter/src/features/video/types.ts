@@ -215,6 +215,23 @@ export interface DLQVideo {
   userId: string
 }
 
+// Job ที่เข้า DLQ หนึ่งครั้ง (payload ถูก redact secrets แล้ว)
+export interface DLQEntry {
+  id: string
+  source: string
+  error: string
+  attempts: number
+  workerId: string
+  stage: string
+  payload: Record<string, unknown>
+  failedAt: string
+}
+
+export interface DLQJobDetail extends DLQVideo {
+  status: string
+  entries: DLQEntry[]
+}
+
 // Upload Limits (from /api/v1/config/upload-limits)
 export interface UploadLimits {
   max_file_size: number      // bytes
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"strconv"
 	"time"
 
 	_ "github.com/lib/pq"
@@ -18,6 +19,8 @@ import (
 	"suekk-worker/infrastructure/cleanup"
 	"suekk-worker/infrastructure/consumer"
 	"suekk-worker/infrastructure/gallery"
+	"suekk-worker/infrastructure/gallerylock"
+	"suekk-worker/infrastructure/jobcancel"
 	"suekk-worker/infrastructure/messenger"
 	"suekk-worker/infrastructure/monitor"
 	"suekk-worker/infrastructure/repository"
@@ -250,10 +253,74 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 	)
 	c.logger.Info("audio service created")
 
+	// Gallery config (GALLERY_* env) - ใช้ร่วมกันทั้ง GalleryService และ GalleryHandler
+	// TEST_MODE: Set GALLERY_TEST_MODE=true to skip upload & DB update
+	testMode := os.Getenv("GALLERY_TEST_MODE") == "true"
+	if testMode {
+		c.logger.Warn("========================================")
+		c.logger.Warn("GALLERY TEST MODE ENABLED")
+		c.logger.Warn("Upload and DB update will be SKIPPED")
+		c.logger.Warn("Files will be kept locally for inspection")
+		c.logger.Warn("========================================")
+	}
+
+	phaseDefaults := use_cases.DefaultGalleryPhaseConfig()
+	galleryConfig := use_cases.GalleryHandlerConfig{
+		TempDir:  cfg.TempPath,
+		APIURL:   cfg.AutoSubtitle.APIURL, // Reuse API URL from auto subtitle config
+		TestMode: testMode,
+		// phase windows (นาที) + frames ต่อนาที - ไม่ตั้ง = default (safe 0-10, nsfw 10-30, 10 fpm)
+		Phases: use_cases.GalleryPhaseConfig{
+			SafeWindow: use_cases.GalleryPhaseWindow{
+				StartMinute: envInt("GALLERY_SAFE_START_MINUTE", phaseDefaults.SafeWindow.StartMinute),
+				EndMinute:   envInt("GALLERY_SAFE_END_MINUTE", phaseDefaults.SafeWindow.EndMinute),
+			},
+			NsfwWindow: use_cases.GalleryPhaseWindow{
+				StartMinute: envInt("GALLERY_NSFW_START_MINUTE", phaseDefaults.NsfwWindow.StartMinute),
+				EndMinute:   envInt("GALLERY_NSFW_END_MINUTE", phaseDefaults.NsfwWindow.EndMinute),
+			},
+			FramesPerMinute: envInt("GALLERY_FRAMES_PER_MINUTE", phaseDefaults.FramesPerMinute),
+		},
+		Classifier: use_cases.GalleryClassifierConfig{
+			PythonPath: os.Getenv("GALLERY_CLASSIFIER_PYTHON"),
+			ScriptPath: os.Getenv("GALLERY_CLASSIFIER_SCRIPT"),
+			Device:     os.Getenv("GALLERY_CLASSIFIER_DEVICE"), // cpu, cuda, mps ("" = auto)
+			// ภาพต่อการเรียก Python (0 = 100, -1 = ไม่แบ่ง) + จำนวน batch พร้อมกัน (GPU workers เพิ่มได้)
+			BatchSize:   envInt("GALLERY_CLASSIFIER_BATCH_SIZE", 0),
+			Concurrency: envInt("GALLERY_CLASSIFIER_CONCURRENCY", 0),
+		},
+		// classifier ล้มเหลว → ส่ง frames ให้ admin review แทน gallery ว่าง
+		ReviewOnClassifyFailure: os.Getenv("GALLERY_REVIEW_ON_CLASSIFY_FAILURE") == "true",
+		// ข้ามช่วงต้น/ท้ายวิดีโอ (0 = 0.05) - เพิ่มได้สำหรับ content ที่ intro/credits ยาว
+		FrameSkip: use_cases.GalleryFrameSkip{
+			StartFraction: envFloat("GALLERY_SKIP_START_FRACTION", 0),
+			EndFraction:   envFloat("GALLERY_SKIP_END_FRACTION", 0),
+		},
+		// timestamp เกินท้าย playlist ไม่เกินค่านี้ยังใช้ segment สุดท้าย (0 = 500ms)
+		SegmentEndTolerance: time.Duration(envInt("GALLERY_SEGMENT_END_TOLERANCE_MS", 0)) * time.Millisecond,
+		TierLimits: use_cases.GalleryTierLimits{
+			MinSuperSafeImages: envInt("GALLERY_MIN_SUPER_SAFE_IMAGES", 0),
+			MinSafeImages:      envInt("GALLERY_MIN_SAFE_IMAGES", 0),
+			MaxSafeImages:      envInt("GALLERY_MAX_SAFE_IMAGES", 0),
+			MaxNsfwImages:      envInt("GALLERY_MAX_NSFW_IMAGES", 0),
+		},
+		// ชื่อไฟล์ใน tier dirs: sequential (001.jpg) | tier_prefix (ss_001.jpg, sf_..., ns_...)
+		Naming: os.Getenv("GALLERY_NAMING"),
+		// ตำแหน่ง thumbnail อัตโนมัติ เป็นสัดส่วนของความยาววิดีโอ (0 = 0.25)
+		ThumbnailFraction: envFloat("THUMBNAIL_FRACTION", 0),
+		// poster AVIF เพิ่มจาก WebP (ffmpeg ต้อง build พร้อม libaom)
+		ThumbnailAVIF: os.Getenv("THUMBNAIL_AVIF") == "true",
+		// สร้าง thumbnail ต่อท้าย gallery job (ทับ thumbnails/<code>.jpg เดิม)
+		ThumbnailWithGallery: os.Getenv("GALLERY_GENERATE_THUMBNAIL") == "true",
+
+		// timeout ของ ffmpeg ต่อ frame (retry 1 ครั้งด้วย URL ใหม่ก่อนข้าม)
+		FrameCaptureTimeout: time.Duration(envInt("GALLERY_FRAME_CAPTURE_TIMEOUT_SEC", 0)) * time.Second,
+	}
+
 	// Gallery Service (shared between TranscodeHandler and GalleryHandler)
-	c.GalleryService = gallery.NewService(gallery.DefaultConfig(), c.logger)
+	c.GalleryService = gallery.NewService(use_cases.GalleryServiceConfig(galleryConfig), c.logger)
 	c.GalleryUploader = gallery.NewUploader(c.Storage, c.logger)
-	c.logger.Info("gallery service created (shared)")
+	c.logger.Info("gallery service created")
 
 	// ─────────────────────────────────────────────────────────────────────────────
 	// 4. Use Cases Layer
@@ -309,16 +376,6 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 	c.Consumer.SetHandler(c.TranscodeHandler.ProcessJob)
 
 	// Gallery Handler (uses S3 presigned URLs for HLS access)
-	// TEST_MODE: Set GALLERY_TEST_MODE=true to skip upload & DB update
-	testMode := os.Getenv("GALLERY_TEST_MODE") == "true"
-	if testMode {
-		c.logger.Warn("========================================")
-		c.logger.Warn("GALLERY TEST MODE ENABLED")
-		c.logger.Warn("Upload and DB update will be SKIPPED")
-		c.logger.Warn("Files will be kept locally for inspection")
-		c.logger.Warn("========================================")
-	}
-
 	c.GalleryHandler = use_cases.NewGalleryHandler(
 		c.Storage,
 		c.Messenger,
@@ -326,14 +383,43 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 		c.AuthClient,
 		c.GalleryService,
 		c.GalleryUploader,
-		use_cases.GalleryHandlerConfig{
-			TempDir:  cfg.TempPath,
-			APIURL:   cfg.AutoSubtitle.APIURL, // Reuse API URL from auto subtitle config
-			TestMode: testMode,
-		},
+		galleryConfig,
 	)
 	c.logger.Info("gallery handler created", "test_mode", testMode)
 
+	// shared gallery service ดึง frame ผ่าน HLS segment mapping ของ GalleryHandler
+	// (TranscodeHandler ใช้ service ตัวเดียวกัน จึงได้ logic เดียวกัน)
+	c.GalleryService.SetFrameSource(c.GalleryHandler)
+
+	// Job cancel - API ตั้ง flag ใน JOB_CANCEL, gallery job หยุดที่ stage ถัดไป
+	if jobCancel, err := jobcancel.NewKVWatcher(context.Background(), c.NATSConn); err != nil {
+		c.logger.Warn("job cancel bucket not available - gallery jobs cannot be cancelled", "error", err)
+	} else {
+		c.GalleryHandler.SetJobCancel(jobCancel)
+	}
+
+	// Gallery lock - job ของวิดีโอเดียวกันทำทีละตัว (API จองไว้ตอน publish, worker รับต่อตอนเริ่ม job)
+	if galleryLock, err := gallerylock.NewKVLock(context.Background(), c.NATSConn); err != nil {
+		c.logger.Warn("gallery lock bucket not available - gallery jobs of the same video may overlap", "error", err)
+	} else {
+		c.GalleryHandler.SetGalleryLock(galleryLock)
+	}
+
+	// Classifier health check - จับ Python deps/model ที่หายไปตั้งแต่ตอน boot
+	// GALLERY_CLASSIFIER_REQUIRED=true → fail startup ถ้า classifier ไม่พร้อม
+	// ไม่งั้นรันใน background แค่ log (load model ใช้เวลา ไม่ block startup)
+	if os.Getenv("GALLERY_CLASSIFIER_REQUIRED") == "true" {
+		if err := c.GalleryHandler.CheckClassifier(context.Background()); err != nil {
+			return nil, fmt.Errorf("classifier health check failed: %w", err)
+		}
+	} else {
+		go func() {
+			if err := c.GalleryHandler.CheckClassifier(context.Background()); err != nil {
+				c.logger.Warn("classifier not ready - gallery classification will fail until fixed", "error", err)
+			}
+		}()
+	}
+
 	// Gallery Consumer
 	c.galleryConsumer, err = consumer.NewGalleryConsumer(consumer.GalleryConsumerConfig{
 		URL: cfg.NATS.URL,
@@ -463,3 +549,21 @@ func (c *Container) GetStatus() map[string]interface{} {
 		"disk_usage":       c.DiskMonitor.GetUsagePercent(),
 	}
 }
+
+// envInt อ่าน int จาก env (ไม่ตั้ง/ผิดรูปแบบ = defaultValue)
+func envInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// envFloat อ่าน float จาก env (ไม่ตั้ง/ผิดรูปแบบ = defaultValue)
+func envFloat(key string, defaultValue float64) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
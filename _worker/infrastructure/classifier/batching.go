@@ -0,0 +1,215 @@
+package classifier
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Batched Classification - วิดีโอยาวได้ frames เยอะจนเรียก Python ครั้งเดียวเกิน Timeout
+// แบ่งภาพเป็น batch (hard link เข้า temp dir) → classify แต่ละ batch → รวมผล
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// imageExtensions นามสกุลที่ classify_batch.py อ่าน (ตรงกับ IMAGE_EXTENSIONS)
+var imageExtensions = map[string]bool{".jpg": true, ".jpeg": true, ".png": true, ".webp": true}
+
+// listImageFiles ชื่อไฟล์ภาพใน dir (ไม่รวม subdir) เรียงตามชื่อ - inputPath เป็นไฟล์ = ไม่แบ่ง
+func listImageFiles(inputPath string) ([]string, error) {
+	info, err := os.Stat(inputPath)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(inputPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var images []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if imageExtensions[strings.ToLower(filepath.Ext(entry.Name()))] {
+			images = append(images, entry.Name())
+		}
+	}
+	sort.Strings(images)
+	return images, nil
+}
+
+// classifyInBatches classify ทีละ BatchSize ภาพ (พร้อมกันไม่เกิน Concurrency batch) แล้วรวมผล
+// batch ไหนล้มเหลว = ยกเลิก batch ที่เหลือแล้วคืน error แรก (ผลบางส่วนไม่ถูกใช้)
+func (c *NSFWClassifier) classifyInBatches(ctx context.Context, inputPath string, images []string) (*BatchResult, error) {
+	batchSize := c.config.BatchSize
+	concurrency := c.config.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var batches [][]string
+	for start := 0; start < len(images); start += batchSize {
+		end := start + batchSize
+		if end > len(images) {
+			end = len(images)
+		}
+		batches = append(batches, images[start:end])
+	}
+
+	c.logger.Info("classifying in batches",
+		"input_path", inputPath,
+		"images", len(images),
+		"batch_size", batchSize,
+		"batches", len(batches),
+		"concurrency", concurrency,
+	)
+
+	// temp dir อยู่ข้าง inputPath (filesystem เดียวกัน → hard link ได้)
+	workDir, err := os.MkdirTemp(filepath.Dir(inputPath), ".classify-batches-")
+	if err != nil {
+		return nil, fmt.Errorf("create batch dir: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	startTime := time.Now()
+	batchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]*BatchResult, len(batches))
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	sem := make(chan struct{}, concurrency)
+
+	for i, batch := range batches {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, batch []string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if batchCtx.Err() != nil {
+				return
+			}
+
+			result, err := c.classifyBatchFiles(batchCtx, inputPath, filepath.Join(workDir, fmt.Sprintf("%03d", i+1)), batch)
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = fmt.Errorf("batch %d/%d: %w", i+1, len(batches), err)
+					cancel()
+				}
+				mu.Unlock()
+				return
+			}
+			results[i] = result
+		}(i, batch)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("classification cancelled: %w", ctx.Err())
+	}
+
+	merged := mergeBatchResults(results)
+	merged.OutputPath = inputPath
+	merged.Stats.ProcessingTime = time.Since(startTime).Seconds()
+
+	c.logger.Info("batched classification complete",
+		"input_path", inputPath,
+		"batches", len(batches),
+		"unique", merged.Stats.TotalImages,
+		"super_safe", merged.Stats.SuperSafeCount,
+		"safe", merged.Stats.SafeCount,
+		"nsfw", merged.Stats.NsfwCount,
+		"errors", merged.Stats.ErrorCount,
+		"time_sec", merged.Stats.ProcessingTime,
+	)
+
+	return merged, nil
+}
+
+// classifyBatchFiles ลิงก์ไฟล์ของ batch เข้า batchDir แล้ว classify (ชื่อไฟล์คงเดิม → ผลใช้กับ inputPath ได้ตรง)
+func (c *NSFWClassifier) classifyBatchFiles(ctx context.Context, inputPath, batchDir string, files []string) (*BatchResult, error) {
+	if err := os.MkdirAll(batchDir, 0755); err != nil {
+		return nil, fmt.Errorf("create batch dir: %w", err)
+	}
+	for _, name := range files {
+		if err := linkOrCopy(filepath.Join(inputPath, name), filepath.Join(batchDir, name)); err != nil {
+			return nil, fmt.Errorf("prepare %s: %w", name, err)
+		}
+	}
+	return c.classifyPath(ctx, batchDir)
+}
+
+// linkOrCopy hard link ถ้าได้ (ไม่เปลืองพื้นที่) ไม่งั้น copy
+func linkOrCopy(src, dst string) error {
+	if err := os.Link(src, dst); err == nil {
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// mergeBatchResults รวม results + stats ของทุก batch (ค่าเฉลี่ยถ่วงน้ำหนักด้วยจำนวนภาพ)
+func mergeBatchResults(batches []*BatchResult) *BatchResult {
+	merged := &BatchResult{Results: make(map[string]ClassificationResult)}
+	var nsfwScoreSum, faceScoreSum float64
+
+	for _, b := range batches {
+		if b == nil {
+			continue
+		}
+		for name, r := range b.Results {
+			merged.Results[name] = r
+		}
+
+		s := b.Stats
+		merged.Stats.TotalImages += s.TotalImages
+		merged.Stats.OriginalImages += s.OriginalImages
+		merged.Stats.DuplicatesRemoved += s.DuplicatesRemoved
+		merged.Stats.SuperSafeCount += s.SuperSafeCount
+		merged.Stats.SafeCount += s.SafeCount
+		merged.Stats.NsfwCount += s.NsfwCount
+		merged.Stats.ErrorCount += s.ErrorCount
+		merged.Stats.MosaicCount += s.MosaicCount
+		merged.Stats.POVCount += s.POVCount
+		nsfwScoreSum += s.AvgNsfwScore * float64(s.TotalImages)
+		faceScoreSum += s.AvgFaceScore * float64(s.TotalImages)
+	}
+
+	if merged.Stats.TotalImages > 0 {
+		merged.Stats.AvgNsfwScore = nsfwScoreSum / float64(merged.Stats.TotalImages)
+		merged.Stats.AvgFaceScore = faceScoreSum / float64(merged.Stats.TotalImages)
+	}
+	return merged
+}
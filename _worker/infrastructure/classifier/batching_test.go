@@ -0,0 +1,162 @@
+package classifier
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// fakeClassifyScript แทน classify_batch.py: ทุกภาพใน --input เป็น safe
+// ถ้า batch มี fail.jpg = exit 1 (จำลอง Python crash)
+const fakeClassifyScript = `
+input=""
+while [ $# -gt 0 ]; do
+	if [ "$1" = "--input" ]; then input="$2"; shift; fi
+	shift
+done
+if [ -e "$input/fail.jpg" ]; then
+	echo "boom" >&2
+	exit 1
+fi
+first=1
+printf '{"results":{'
+n=0
+for f in "$input"/*; do
+	name=$(basename "$f")
+	if [ $first -eq 0 ]; then printf ','; fi
+	first=0
+	printf '"%s":{"filename":"%s","is_safe":true,"nsfw_score":0.2,"face_score":0.5,"classification":"safe"}' "$name" "$name"
+	n=$((n+1))
+done
+printf '},"stats":{"total_images":%d,"original_images":%d,"safe_count":%d,"avg_nsfw_score":0.2,"avg_face_score":0.5}}' $n $n $n
+`
+
+func newFakeClassifier(t *testing.T, batchSize, concurrency int) *NSFWClassifier {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake classifier needs /bin/sh")
+	}
+
+	script := filepath.Join(t.TempDir(), "classify.sh")
+	if err := os.WriteFile(script, []byte(fakeClassifyScript), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := DefaultConfig()
+	cfg.PythonPath = "/bin/sh"
+	cfg.ScriptPath = script
+	cfg.Timeout = 30
+	cfg.BatchSize = batchSize
+	cfg.Concurrency = concurrency
+	return NewNSFWClassifier(cfg, nil)
+}
+
+func writeImages(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("img"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestClassifyBatch_MergesBatches(t *testing.T) {
+	for _, concurrency := range []int{1, 3} {
+		t.Run(fmt.Sprintf("concurrency=%d", concurrency), func(t *testing.T) {
+			c := newFakeClassifier(t, 2, concurrency)
+			dir := filepath.Join(t.TempDir(), "all")
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			writeImages(t, dir, "001.jpg", "002.jpg", "003.jpg", "004.jpg", "005.jpg")
+
+			result, err := c.ClassifyBatch(context.Background(), dir)
+			if err != nil {
+				t.Fatalf("ClassifyBatch: %v", err)
+			}
+
+			if len(result.Results) != 5 {
+				t.Fatalf("results = %d, want 5", len(result.Results))
+			}
+			for _, name := range []string{"001.jpg", "002.jpg", "003.jpg", "004.jpg", "005.jpg"} {
+				if _, ok := result.Results[name]; !ok {
+					t.Errorf("missing result for %s", name)
+				}
+			}
+			if result.Stats.TotalImages != 5 || result.Stats.SafeCount != 5 {
+				t.Errorf("stats = %+v, want 5 total / 5 safe", result.Stats)
+			}
+			if result.OutputPath != dir {
+				t.Errorf("output path = %q, want %q", result.OutputPath, dir)
+			}
+
+			// batch dirs ชั่วคราวต้องถูกลบ
+			leftovers, _ := filepath.Glob(filepath.Join(filepath.Dir(dir), ".classify-batches-*"))
+			if len(leftovers) != 0 {
+				t.Errorf("batch dirs not cleaned up: %v", leftovers)
+			}
+		})
+	}
+}
+
+func TestClassifyBatch_PartialFailure(t *testing.T) {
+	c := newFakeClassifier(t, 2, 2)
+	dir := filepath.Join(t.TempDir(), "all")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	// เรียงตามชื่อ: [001, 002] [003, fail] → batch 2 ล้มเหลว
+	writeImages(t, dir, "001.jpg", "002.jpg", "003.jpg", "fail.jpg")
+
+	result, err := c.ClassifyBatch(context.Background(), dir)
+	if err == nil {
+		t.Fatalf("expected error, got result %+v", result)
+	}
+	if result != nil {
+		t.Errorf("partial result returned on failure: %+v", result)
+	}
+
+	// ไฟล์ต้นฉบับต้องอยู่ครบ (batch ใช้ hard link/copy)
+	for _, name := range []string{"001.jpg", "002.jpg", "003.jpg", "fail.jpg"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("source image %s missing: %v", name, err)
+		}
+	}
+}
+
+func TestMergeBatchResults(t *testing.T) {
+	merged := mergeBatchResults([]*BatchResult{
+		{
+			Results: map[string]ClassificationResult{"001.jpg": {Filename: "001.jpg"}},
+			Stats:   ClassificationStats{TotalImages: 1, SuperSafeCount: 1, AvgNsfwScore: 0.1, AvgFaceScore: 0.9},
+		},
+		nil, // batch ที่ไม่ได้รัน
+		{
+			Results: map[string]ClassificationResult{"002.jpg": {Filename: "002.jpg"}, "003.jpg": {Filename: "003.jpg"}},
+			Stats:   ClassificationStats{TotalImages: 3, NsfwCount: 2, ErrorCount: 1, DuplicatesRemoved: 1, AvgNsfwScore: 0.5, AvgFaceScore: 0.1},
+		},
+	})
+
+	if len(merged.Results) != 3 {
+		t.Errorf("results = %d, want 3", len(merged.Results))
+	}
+	s := merged.Stats
+	if s.TotalImages != 4 || s.SuperSafeCount != 1 || s.NsfwCount != 2 || s.ErrorCount != 1 || s.DuplicatesRemoved != 1 {
+		t.Errorf("unexpected counts: %+v", s)
+	}
+	// ค่าเฉลี่ยถ่วงน้ำหนักด้วยจำนวนภาพ: (0.1*1 + 0.5*3) / 4 = 0.4
+	if diff := s.AvgNsfwScore - 0.4; diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("avg nsfw = %v, want 0.4", s.AvgNsfwScore)
+	}
+	// (0.9*1 + 0.1*3) / 4 = 0.3
+	if diff := s.AvgFaceScore - 0.3; diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("avg face = %v, want 0.3", s.AvgFaceScore)
+	}
+
+	if empty := mergeBatchResults(nil); empty.Stats.AvgNsfwScore != 0 || len(empty.Results) != 0 {
+		t.Errorf("empty merge = %+v", empty)
+	}
+}
@@ -7,6 +7,7 @@ Outputs JSON result to stdout or file
 Usage:
     python classify_batch.py --input /path/to/images --output result.json
     python classify_batch.py --input /path/to/images  # Output to stdout
+    python classify_batch.py --selftest               # Health check (load models + probe image)
 """
 import os
 import sys
@@ -55,6 +56,9 @@ NSFW_LABELS = [
 # Supported image extensions
 IMAGE_EXTENSIONS = {'.jpg', '.jpeg', '.png', '.webp'}
 
+# Device สำหรับ Falconsai (None = auto: cuda ถ้ามี ไม่งั้น cpu)
+CLASSIFIER_DEVICE = None
+
 # Deduplication settings
 PHASH_THRESHOLD = 8  # Hamming distance threshold (0=identical, lower=more strict)
 
@@ -142,6 +146,21 @@ def deduplicate_images(image_files: List[str], threshold: int = PHASH_THRESHOLD,
 # Dual Model NSFW Classifier (Falconsai + NudeNet)
 # ═══════════════════════════════════════════════════════════════════════════════
 
+def resolve_device(requested: Optional[str], torch_module) -> Any:
+    """แปลง --device เป็นค่าที่ transformers pipeline รับ (cuda ไม่มี → fallback cpu)"""
+    if requested == "cpu":
+        return -1
+    if requested == "mps":
+        if getattr(torch_module.backends, "mps", None) and torch_module.backends.mps.is_available():
+            return "mps"
+        print("[WARN] MPS requested but not available, using CPU", file=sys.stderr)
+        return -1
+    if requested == "cuda" and not torch_module.cuda.is_available():
+        print("[WARN] CUDA requested but not available, using CPU", file=sys.stderr)
+        return -1
+    return 0 if torch_module.cuda.is_available() else -1
+
+
 class NSFWClassifier:
     """
     NSFW classifier using dual models:
@@ -167,7 +186,7 @@ class NSFWClassifier:
             from transformers import pipeline
             import torch
 
-            device = 0 if torch.cuda.is_available() else -1
+            device = resolve_device(CLASSIFIER_DEVICE, torch)
             self.falconsai_model = pipeline(
                 "image-classification",
                 model="Falconsai/nsfw_image_detection",
@@ -891,13 +910,53 @@ def classify_batch(input_path: str, verbose: bool = False, skip_mosaic: bool = F
     }
 
 
+# ═══════════════════════════════════════════════════════════════════════════════
+# Self Test (Health Check)
+# ═══════════════════════════════════════════════════════════════════════════════
+
+def run_selftest() -> Dict[str, Any]:
+    """
+    Load models แล้ว classify probe image 1 ภาพ
+    ใช้ตอน worker start เพื่อจับ Python deps/model ที่หายไปก่อนเจอ job จริง
+    """
+    import tempfile
+
+    start_time = time.time()
+
+    classifier = NSFWClassifier()
+    classifier.skip_mosaic = True
+    classifier.skip_pov = True
+    classifier.load()
+
+    models = {
+        "falconsai": classifier.falconsai_model is not None,
+        "nudenet": classifier.nudenet_detector is not None,
+        "face_cascade": classifier.face_cascade is not None and not classifier.face_cascade.empty(),
+    }
+
+    # Probe image: ภาพสีเทาเล็กๆ (ไม่ต้องมีไฟล์จริงใน worker)
+    with tempfile.TemporaryDirectory() as tmp_dir:
+        probe_path = os.path.join(tmp_dir, "probe.jpg")
+        Image.new("RGB", (64, 64), (128, 128, 128)).save(probe_path)
+        probe = classifier.classify(probe_path)
+
+    probe_error = probe.get("error", "")
+    return {
+        "ok": models["falconsai"] and models["nudenet"] and not probe_error,
+        "device": CLASSIFIER_DEVICE or "auto",
+        "models": models,
+        "probe_error": probe_error,
+        "time_sec": round(time.time() - start_time, 2),
+    }
+
+
 # ═══════════════════════════════════════════════════════════════════════════════
 # Main
 # ═══════════════════════════════════════════════════════════════════════════════
 
 def main():
     parser = argparse.ArgumentParser(description="NSFW Batch Classifier (Falconsai + NudeNet)")
-    parser.add_argument("--input", "-i", required=True, help="Input folder or image file")
+    parser.add_argument("--input", "-i", help="Input folder or image file (required unless --selftest)")
     parser.add_argument("--output", "-o", help="Output JSON file (default: stdout)")
     parser.add_argument("--threshold", "-t", type=float, default=0.3, help="NSFW threshold (default: 0.3)")
     parser.add_argument("--super-safe-threshold", type=float, default=0.15, help="Super safe threshold (default: 0.15)")
@@ -907,9 +966,26 @@ def main():
     parser.add_argument("--skip-pov", action="store_true", help="Skip slow POV detection")
     parser.add_argument("--skip-dedup", action="store_true", help="Skip image deduplication")
     parser.add_argument("--dedup-threshold", type=int, default=8, help="Dedup hamming distance threshold (default: 8, lower=stricter)")
+    parser.add_argument("--selftest", action="store_true", help="Load models, classify a probe image, print health JSON")
+    parser.add_argument("--device", choices=["cpu", "cuda", "mps"], help="Inference device (default: auto)")
 
     args = parser.parse_args()
 
+    global CLASSIFIER_DEVICE
+    CLASSIFIER_DEVICE = args.device
+
+    # Health check mode
+    if args.selftest:
+        try:
+            health = run_selftest()
+        except Exception as e:
+            health = {"ok": False, "error": str(e)}
+        print(json.dumps(health))
+        sys.exit(0 if health.get("ok") else 1)
+
+    if not args.input:
+        parser.error("--input is required")
+
     # Update thresholds if specified
     global NSFW_THRESHOLD, SUPER_SAFE_THRESHOLD, MIN_FACE_SCORE
     NSFW_THRESHOLD = args.threshold
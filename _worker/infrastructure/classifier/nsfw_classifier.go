@@ -1,8 +1,10 @@
 package classifier
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os/exec"
@@ -16,6 +18,17 @@ import (
 // Uses subprocess to call classify_batch.py for batch processing
 // ═══════════════════════════════════════════════════════════════════════════════
 
+// ErrClassificationTimeout classify_batch.py ทำงานเกิน Timeout (process ถูก kill แล้ว)
+var ErrClassificationTimeout = errors.New("classification timeout")
+
+const (
+	// waitDelay เวลารอ pipe ปิดหลัง kill process (กัน child process ของ torch ถือ stdout ค้าง)
+	waitDelay = 10 * time.Second
+
+	// maxStderrLogBytes จำกัดขนาด stderr ที่ log (verbose mode พิมพ์ทุกภาพ)
+	maxStderrLogBytes = 4096
+)
+
 // NSFWClassifier wraps Python NudeNet classifier
 type NSFWClassifier struct {
 	config ClassifierConfig
@@ -41,7 +54,22 @@ func NewNSFWClassifierWithDefaults(logger *slog.Logger) *NSFWClassifier {
 
 // ClassifyBatch classifies all images in a folder
 // Returns BatchResult with classification results for each image
+// BatchSize > 0 และภาพเกิน BatchSize = แบ่งเรียก Python ทีละ batch (ดู classifyInBatches)
 func (c *NSFWClassifier) ClassifyBatch(ctx context.Context, inputPath string) (*BatchResult, error) {
+	if c.config.BatchSize > 0 {
+		images, err := listImageFiles(inputPath)
+		if err != nil {
+			return nil, fmt.Errorf("list images: %w", err)
+		}
+		if len(images) > c.config.BatchSize {
+			return c.classifyInBatches(ctx, inputPath, images)
+		}
+	}
+	return c.classifyPath(ctx, inputPath)
+}
+
+// classifyPath เรียก classify_batch.py ครั้งเดียวกับทุกภาพใน inputPath (Timeout ต่อการเรียก)
+func (c *NSFWClassifier) classifyPath(ctx context.Context, inputPath string) (*BatchResult, error) {
 	c.logger.Info("starting batch classification",
 		"input_path", inputPath,
 		"timeout", c.config.Timeout,
@@ -49,12 +77,17 @@ func (c *NSFWClassifier) ClassifyBatch(ctx context.Context, inputPath string) (*
 		"super_safe_threshold", c.config.SuperSafeThreshold,
 		"nsfw_threshold", c.config.NsfwThreshold,
 		"min_face_score", c.config.MinFaceScore,
+		"device", c.config.Device,
 	)
 
 	startTime := time.Now()
 
-	// Create context with timeout
-	ctxWithTimeout, cancel := context.WithTimeout(ctx, time.Duration(c.config.Timeout)*time.Second)
+	// Create context with timeout (hard timeout - ไม่ตั้ง = ใช้ default)
+	timeoutSec := c.config.Timeout
+	if timeoutSec <= 0 {
+		timeoutSec = DefaultConfig().Timeout
+	}
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, time.Duration(timeoutSec)*time.Second)
 	defer cancel()
 
 	// Build command with all thresholds
@@ -66,6 +99,11 @@ func (c *NSFWClassifier) ClassifyBatch(ctx context.Context, inputPath string) (*
 		"--min-face-score", fmt.Sprintf("%.2f", c.config.MinFaceScore),
 	}
 
+	// Device (cpu/cuda/mps) - ไม่ตั้ง = ให้ script auto-detect
+	if c.config.Device != "" {
+		args = append(args, "--device", c.config.Device)
+	}
+
 	// Add verbose flag for detailed per-image logging
 	if c.config.Verbose {
 		args = append(args, "--verbose")
@@ -88,29 +126,38 @@ func (c *NSFWClassifier) ClassifyBatch(ctx context.Context, inputPath string) (*
 	}
 
 	cmd := exec.CommandContext(ctxWithTimeout, c.config.PythonPath, args...)
+	// หลัง kill แล้วถ้า pipe ยังไม่ปิดภายใน waitDelay ให้ตัดทิ้ง (Output ไม่ค้างตลอดไป)
+	cmd.WaitDelay = waitDelay
 
-	// Run command and capture both stdout and stderr
+	// Capture stderr แยก เพื่อ log ได้ทั้งกรณี timeout และ crash
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	// Run command and capture stdout (JSON result)
 	output, err := cmd.Output()
 	if err != nil {
 		// Check if it was a timeout
-		if ctxWithTimeout.Err() == context.DeadlineExceeded {
+		if ctxWithTimeout.Err() == context.DeadlineExceeded && ctx.Err() == nil {
 			c.logger.Error("classification timeout",
 				"input_path", inputPath,
-				"timeout_sec", c.config.Timeout,
+				"timeout_sec", timeoutSec,
+				"stderr", tailString(stderr.String(), maxStderrLogBytes),
 			)
-			return nil, fmt.Errorf("classification timeout after %d seconds", c.config.Timeout)
+			return nil, fmt.Errorf("%w after %d seconds", ErrClassificationTimeout, timeoutSec)
 		}
 
-		// Get stderr for error details
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			c.logger.Error("classification failed",
-				"input_path", inputPath,
-				"stderr", string(exitErr.Stderr),
-				"error", err,
-			)
-			return nil, fmt.Errorf("classification failed: %s", string(exitErr.Stderr))
-		}
+		c.logger.Error("classification failed",
+			"input_path", inputPath,
+			"stderr", tailString(stderr.String(), maxStderrLogBytes),
+			"error", err,
+		)
 
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("classification cancelled: %w", ctx.Err())
+		}
+		if _, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("classification failed: %s", tailString(stderr.String(), maxStderrLogBytes))
+		}
 		return nil, fmt.Errorf("classification error: %w", err)
 	}
 
@@ -162,6 +209,84 @@ func (c *NSFWClassifier) ClassifyBatch(ctx context.Context, inputPath string) (*
 	return &result, nil
 }
 
+// HealthCheck รัน classify_batch.py --selftest (load models + classify probe image)
+// ใช้ตอน worker start เพื่อจับ Python deps/model files ที่หายไปก่อนเจอ job จริง
+func (c *NSFWClassifier) HealthCheck(ctx context.Context) (*HealthStatus, error) {
+	timeoutSec := c.config.Timeout
+	if timeoutSec <= 0 {
+		timeoutSec = DefaultConfig().Timeout
+	}
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, time.Duration(timeoutSec)*time.Second)
+	defer cancel()
+
+	startTime := time.Now()
+
+	args := []string{c.config.ScriptPath, "--selftest"}
+	if c.config.Device != "" {
+		args = append(args, "--device", c.config.Device)
+	}
+
+	cmd := exec.CommandContext(ctxWithTimeout, c.config.PythonPath, args...)
+	cmd.WaitDelay = waitDelay
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	// --selftest exit 1 เมื่อไม่ ok แต่ยังพิมพ์ JSON ออกมา → parse ก่อนดู error
+	output, runErr := cmd.Output()
+
+	var status HealthStatus
+	if err := json.Unmarshal(output, &status); err != nil {
+		c.logger.Error("classifier health check failed",
+			"python_path", c.config.PythonPath,
+			"script_path", c.config.ScriptPath,
+			"stderr", tailString(stderr.String(), maxStderrLogBytes),
+			"error", runErr,
+		)
+		if runErr != nil {
+			return nil, fmt.Errorf("classifier selftest: %w", runErr)
+		}
+		return nil, fmt.Errorf("failed to parse selftest result: %w", err)
+	}
+
+	if !status.OK {
+		c.logger.Error("classifier not ready",
+			"models", status.Models,
+			"probe_error", status.ProbeError,
+			"error", status.Error,
+			"stderr", tailString(stderr.String(), maxStderrLogBytes),
+		)
+		return &status, fmt.Errorf("classifier not ready: models=%v probe_error=%q error=%q",
+			status.Models, status.ProbeError, status.Error)
+	}
+
+	c.logger.Info("classifier ready",
+		"device", status.Device,
+		"models", status.Models,
+		"selftest_sec", status.TimeSec,
+		"total_sec", time.Since(startTime).Seconds(),
+	)
+	return &status, nil
+}
+
+// ValidateDevice ตรวจสอบค่า device ("" = auto)
+func ValidateDevice(device string) error {
+	switch device {
+	case "", DeviceCPU, DeviceCUDA, DeviceMPS:
+		return nil
+	default:
+		return fmt.Errorf("invalid classifier device %q (must be %s, %s or %s)", device, DeviceCPU, DeviceCUDA, DeviceMPS)
+	}
+}
+
+// tailString ตัดเหลือ maxBytes ตัวท้าย (error ของ Python อยู่ท้าย stderr)
+func tailString(s string, maxBytes int) string {
+	if len(s) <= maxBytes {
+		return s
+	}
+	return "..." + s[len(s)-maxBytes:]
+}
+
 // SeparateResults separates classification results into three tiers + error
 // Three-Tier: SuperSafe (< 0.15 + face) | Safe (0.15-0.3) | NSFW (>= 0.3)
 func (c *NSFWClassifier) SeparateResults(results map[string]ClassificationResult) *SeparatedImages {
@@ -63,6 +63,16 @@ type ClassificationStats struct {
 	ProcessingTime    float64 `json:"processing_time_sec"`
 }
 
+// HealthStatus ผลจาก classify_batch.py --selftest
+type HealthStatus struct {
+	OK         bool            `json:"ok"`
+	Device     string          `json:"device"` // device ที่ใช้จริง (auto ถ้าไม่ได้ระบุ)
+	Models     map[string]bool `json:"models"` // falconsai, nudenet, face_cascade → loaded?
+	ProbeError string          `json:"probe_error,omitempty"`
+	Error      string          `json:"error,omitempty"`
+	TimeSec    float64         `json:"time_sec"`
+}
+
 // ClassifierConfig configuration สำหรับ classifier
 type ClassifierConfig struct {
 	PythonPath    string  // Path to python executable (default: "python")
@@ -72,6 +82,7 @@ type ClassifierConfig struct {
 	MaxNsfwImages int     // Max NSFW images to keep (default: 10)
 	MaxSafeImages int     // Max Safe images to keep (default: 10)
 	MinSafeImages int     // Minimum safe images required (default: 12)
+	Device        string  // cpu, cuda, mps ("" = auto: cuda ถ้ามี ไม่งั้น cpu)
 
 	// Three-Tier config
 	SuperSafeThreshold float64 // Score below this + face = super safe (default: 0.15)
@@ -88,8 +99,20 @@ type ClassifierConfig struct {
 	// Deduplication options
 	SkipDedup      bool // If true, skip image deduplication
 	DedupThreshold int  // Hamming distance threshold for dedup (0=identical, 8=default)
+
+	// Batching - แบ่งภาพเป็นชุดละ BatchSize ต่อการเรียก Python 1 ครั้ง (Timeout นับต่อ batch)
+	// dedup ทำภายใน batch เท่านั้น
+	BatchSize   int // ภาพต่อ batch (<= 0 = ส่งทั้ง folder ครั้งเดียว)
+	Concurrency int // จำนวน batch ที่รันพร้อมกัน (<= 1 = ทีละ batch)
 }
 
+// Supported devices สำหรับ --device
+const (
+	DeviceCPU  = "cpu"
+	DeviceCUDA = "cuda"
+	DeviceMPS  = "mps" // Apple Silicon
+)
+
 // DefaultConfig returns default classifier configuration
 func DefaultConfig() ClassifierConfig {
 	return ClassifierConfig{
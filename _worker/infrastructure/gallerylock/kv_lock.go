@@ -0,0 +1,94 @@
+package gallerylock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"suekk-worker/ports"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// KVLock - Implementation ของ GalleryLockPort
+// API ตั้ง key "<video code>" = "queued" ตอน publish gallery job (ปฏิเสธ request ซ้อนด้วย 409)
+// worker เปลี่ยนเป็น "processing" ตอนเริ่ม job แล้วลบเมื่อจบ
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// Bucket KV bucket lock ของ gallery (API เป็นคนสร้าง - TTL กัน lock ค้างเมื่อ worker ตาย)
+const Bucket = "GALLERY_LOCK"
+
+// Lock states (ต้องตรงกับ API)
+const (
+	stateQueued     = "queued"
+	stateProcessing = "processing"
+)
+
+// KVLock implementation ของ ports.GalleryLockPort
+type KVLock struct {
+	kv     jetstream.KeyValue
+	logger *slog.Logger
+}
+
+// NewKVLock เชื่อม bucket GALLERY_LOCK - ไม่มี bucket (API รุ่นเก่า) = error
+func NewKVLock(ctx context.Context, nc *nats.Conn) (*KVLock, error) {
+	js, err := jetstream.New(nc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JetStream context: %w", err)
+	}
+
+	kv, err := js.KeyValue(ctx, Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s bucket: %w", Bucket, err)
+	}
+
+	return &KVLock{
+		kv:     kv,
+		logger: slog.Default().With("component", "gallery-lock"),
+	}, nil
+}
+
+// Acquire จอง lock ของวิดีโอ
+// - ไม่มี key (backfill / API รุ่นเก่า) = สร้างใหม่
+// - "queued" (API จองไว้ให้ job นี้) = รับต่อด้วย revision เดิม (ชนกับ worker อื่น = แพ้)
+// - "processing" = job อื่นกำลังทำ → ErrGalleryLocked
+func (l *KVLock) Acquire(ctx context.Context, videoCode string) (func(), error) {
+	entry, err := l.kv.Get(ctx, videoCode)
+	switch {
+	case errors.Is(err, jetstream.ErrKeyNotFound):
+		if _, err := l.kv.Create(ctx, videoCode, []byte(stateProcessing)); err != nil {
+			if errors.Is(err, jetstream.ErrKeyExists) {
+				return nil, ports.ErrGalleryLocked
+			}
+			return nil, fmt.Errorf("failed to create gallery lock: %w", err)
+		}
+	case err != nil:
+		return nil, fmt.Errorf("failed to read gallery lock: %w", err)
+	case string(entry.Value()) == stateQueued:
+		if _, err := l.kv.Update(ctx, videoCode, []byte(stateProcessing), entry.Revision()); err != nil {
+			// revision เปลี่ยน = worker อื่นรับไปก่อน
+			return nil, ports.ErrGalleryLocked
+		}
+	default:
+		return nil, ports.ErrGalleryLocked
+	}
+
+	l.logger.Info("gallery lock acquired", "video_code", videoCode)
+	return func() { l.release(videoCode) }, nil
+}
+
+// release ลบ lock (ใช้ ctx ใหม่ - ctx ของ job อาจถูก cancel ไปแล้ว)
+func (l *KVLock) release(videoCode string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := l.kv.Delete(ctx, videoCode); err != nil && !errors.Is(err, jetstream.ErrKeyNotFound) {
+		l.logger.Warn("failed to release gallery lock", "video_code", videoCode, "error", err)
+		return
+	}
+	l.logger.Info("gallery lock released", "video_code", videoCode)
+}
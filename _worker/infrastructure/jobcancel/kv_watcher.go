@@ -0,0 +1,84 @@
+package jobcancel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"suekk-worker/ports"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// KVWatcher - Implementation ของ JobCancelPort
+// API ตั้ง key "<job type>.<video id>" ใน JOB_CANCEL เมื่อ admin สั่งยกเลิก
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// Bucket KV bucket คำขอยกเลิก job (API เป็นคนสร้าง)
+const Bucket = "JOB_CANCEL"
+
+// KVWatcher implementation ของ ports.JobCancelPort
+type KVWatcher struct {
+	kv     jetstream.KeyValue
+	logger *slog.Logger
+}
+
+// NewKVWatcher เชื่อม bucket JOB_CANCEL - ไม่มี bucket (API รุ่นเก่า) = error
+func NewKVWatcher(ctx context.Context, nc *nats.Conn) (*KVWatcher, error) {
+	js, err := jetstream.New(nc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JetStream context: %w", err)
+	}
+
+	kv, err := js.KeyValue(ctx, Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s bucket: %w", Bucket, err)
+	}
+
+	return &KVWatcher{
+		kv:     kv,
+		logger: slog.Default().With("component", "job-cancel"),
+	}, nil
+}
+
+// Watch คืน ctx ที่ถูก cancel ด้วย cause ports.ErrJobCancelled เมื่อ key ของ job ถูกตั้ง
+// key ที่ตั้งไว้ก่อนเริ่ม job ก็นับ (job ถูกยกเลิกตั้งแต่ยังอยู่ในคิว)
+// watch ไม่ได้ = job ทำต่อตามปกติ (แค่ยกเลิกไม่ได้)
+func (w *KVWatcher) Watch(ctx context.Context, jobType, videoID string) (context.Context, func()) {
+	jobCtx, cancel := context.WithCancelCause(ctx)
+
+	key := jobType + "." + videoID
+	watcher, err := w.kv.Watch(jobCtx, key)
+	if err != nil {
+		w.logger.Warn("failed to watch job cancel key", "key", key, "error", err)
+		return jobCtx, func() { cancel(nil) }
+	}
+
+	go func() {
+		for entry := range watcher.Updates() {
+			// nil = ส่งค่าปัจจุบันครบแล้ว, purge/delete = API ล้าง flag
+			if entry == nil || entry.Operation() != jetstream.KeyValuePut {
+				continue
+			}
+			w.logger.Info("job cancel requested", "job_type", jobType, "video_id", videoID)
+			cancel(ports.ErrJobCancelled)
+			return
+		}
+	}()
+
+	return jobCtx, func() {
+		watcher.Stop()
+		cancel(nil)
+	}
+}
+
+// Clear ลบคำขอยกเลิกหลังหยุด job แล้ว (กัน job ใหม่ของวิดีโอเดิมถูกยกเลิกตาม)
+func (w *KVWatcher) Clear(ctx context.Context, jobType, videoID string) error {
+	if err := w.kv.Purge(ctx, jobType+"."+videoID); err != nil && !errors.Is(err, jetstream.ErrKeyNotFound) {
+		return fmt.Errorf("failed to clear job cancel: %w", err)
+	}
+	return nil
+}
@@ -269,6 +269,21 @@ func (p *PostgresClient) UpdateGalleryProcessingStarted(ctx context.Context, vid
 	return nil
 }
 
+// UpdateGalleryCancelled ตั้ง gallery_status = 'cancelled' หลัง admin ยกเลิก job
+func (p *PostgresClient) UpdateGalleryCancelled(ctx context.Context, videoID string) error {
+	if p.db == nil {
+		return nil
+	}
+
+	query := `UPDATE videos SET gallery_status = 'cancelled', updated_at = NOW() WHERE id = $1`
+	if _, err := p.db.ExecContext(ctx, query, videoID); err != nil {
+		return fmt.Errorf("failed to update gallery cancelled: %w", err)
+	}
+
+	p.logger.Info("gallery cancelled", "video_id", videoID, "gallery_status", "cancelled")
+	return nil
+}
+
 // UpdateGalleryClassified อัพเดท gallery info พร้อม super_safe/safe/nsfw counts (Three-Tier)
 // gallery_count = super_safe + safe (public-accessible images for backward compatibility)
 // gallery_status = "pending_review" เพื่อให้ Admin ตรวจสอบก่อน publish
@@ -357,10 +372,148 @@ func (p *PostgresClient) UpdateGalleryManualSelection(ctx context.Context, video
 	return nil
 }
 
+// GetThumbnailSource ดึงข้อมูลวิดีโอที่ใช้สร้าง thumbnail
+func (p *PostgresClient) GetThumbnailSource(ctx context.Context, videoID string) (*ports.ThumbnailSource, error) {
+	if p.db == nil {
+		return nil, fmt.Errorf("database not configured")
+	}
+
+	var source ports.ThumbnailSource
+	var hlsPath, quality sql.NullString
+	var duration sql.NullInt64
+	query := `SELECT code, hls_path, quality, duration FROM videos WHERE id = $1`
+	err := p.db.QueryRowContext(ctx, query, videoID).Scan(&source.Code, &hlsPath, &quality, &duration)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("video not found: %s", videoID)
+		}
+		return nil, fmt.Errorf("failed to get thumbnail source: %w", err)
+	}
+
+	source.HLSPath = hlsPath.String
+	source.Quality = quality.String
+	source.Duration = int(duration.Int64)
+	return &source, nil
+}
+
+// UpdateThumbnail อัพเดท thumbnail_url และ thumbnail_variants ของวิดีโอ
+// variants ถูกแทนที่ทั้งชุด (format ที่ไม่ได้สร้างรอบนี้ไม่ค้างชี้ไฟล์เก่า)
+func (p *PostgresClient) UpdateThumbnail(ctx context.Context, videoID, thumbnailURL string, variants map[string]string) error {
+	if p.db == nil {
+		return nil
+	}
+
+	variantsJSON := "{}"
+	if len(variants) > 0 {
+		if jsonBytes, err := json.Marshal(variants); err == nil {
+			variantsJSON = string(jsonBytes)
+		}
+	}
+
+	query := `UPDATE videos SET thumbnail_url = $1, thumbnail_variants = $2, updated_at = NOW() WHERE id = $3`
+	result, err := p.db.ExecContext(ctx, query, thumbnailURL, variantsJSON, videoID)
+	if err != nil {
+		return fmt.Errorf("failed to update thumbnail: %w", err)
+	}
+
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		p.logger.Warn("no video updated for thumbnail", "video_id", videoID)
+	}
+
+	return nil
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Additional Methods
 // ─────────────────────────────────────────────────────────────────────────────
 
+// RecordJobEvent บันทึก pipeline event ลง job_events
+// ตาราง job_events ถูกสร้างโดย API (AutoMigrate)
+func (p *PostgresClient) RecordJobEvent(ctx context.Context, event *ports.JobEvent) error {
+	if p.db == nil || event == nil {
+		return nil
+	}
+
+	query := `INSERT INTO job_events (video_id, source, stage, progress, message, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())`
+
+	if _, err := p.db.ExecContext(ctx, query, event.VideoID, event.Source, event.Stage, event.Progress, event.Message); err != nil {
+		return fmt.Errorf("failed to record job event: %w", err)
+	}
+
+	return nil
+}
+
+// RecordProcessingCost บันทึก compute ที่ใช้ของ job ลง processing_costs
+// ตาราง processing_costs ถูกสร้างโดย API (AutoMigrate)
+func (p *PostgresClient) RecordProcessingCost(ctx context.Context, cost *ports.ProcessingCost) error {
+	if p.db == nil || cost == nil {
+		return nil
+	}
+
+	query := `INSERT INTO processing_costs (video_id, source, frame_count, classifier_runtime_ms, job_duration_ms, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())`
+
+	if _, err := p.db.ExecContext(ctx, query,
+		cost.VideoID,
+		cost.Source,
+		cost.FrameCount,
+		cost.ClassifierRuntime.Milliseconds(),
+		cost.JobDuration.Milliseconds(),
+	); err != nil {
+		return fmt.Errorf("failed to record processing cost: %w", err)
+	}
+
+	return nil
+}
+
+// ReplaceGalleryScores ลบ scores เดิมของวิดีโอแล้วบันทึกชุดใหม่ (transaction เดียว)
+// สร้าง gallery ใหม่ = stats สะท้อนเฉพาะผล classify ล่าสุด
+func (p *PostgresClient) ReplaceGalleryScores(ctx context.Context, videoID string, scores []ports.GalleryImageScore) error {
+	if p.db == nil {
+		return nil
+	}
+
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin gallery scores transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM gallery_image_scores WHERE video_id = $1`, videoID); err != nil {
+		return fmt.Errorf("failed to delete gallery scores: %w", err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `INSERT INTO gallery_image_scores
+		(video_id, filename, tier, nsfw_score, face_score, aesthetic_score, mosaic_detected, pov_detected, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare gallery scores insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, score := range scores {
+		if _, err := stmt.ExecContext(ctx,
+			videoID,
+			score.Filename,
+			score.Tier,
+			score.NsfwScore,
+			score.FaceScore,
+			score.AestheticScore,
+			score.MosaicDetected,
+			score.POVDetected,
+		); err != nil {
+			return fmt.Errorf("failed to insert gallery score: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit gallery scores: %w", err)
+	}
+	return nil
+}
+
 // GetDB returns underlying database connection
 // ใช้สำหรับ backward compatibility
 func (p *PostgresClient) GetDB() *sql.DB {
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrGalleryLocked มี gallery job ของวิดีโอนี้กำลังทำอยู่ (job ซ้ำถูก ack ทิ้ง - job ที่ทำอยู่สร้าง gallery ให้แล้ว)
+var ErrGalleryLocked = errors.New("gallery generation already in progress")
+
+// GalleryLockPort lock การสร้าง gallery ต่อวิดีโอ (NATS KV GALLERY_LOCK, key = video code)
+// กัน job สองตัวของวิดีโอเดียวกัน upload สลับกันจน gallery ปนกัน
+type GalleryLockPort interface {
+	// Acquire จอง lock ตอนเริ่ม job - ถูกจองโดย job อื่นที่กำลังทำอยู่ = ErrGalleryLocked
+	// ต้องเรียก release เมื่อ job จบ
+	Acquire(ctx context.Context, videoCode string) (release func(), err error)
+}
@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+	"errors"
+)
+
+// Job types ที่ใช้เป็น prefix ของ key ใน JOB_CANCEL (ต้องตรงกับ API)
+const (
+	JobTypeTranscode = "transcode"
+	JobTypeGallery   = "gallery"
+)
+
+// ErrJobCancelled job ถูก admin ยกเลิก (context cause จาก JobCancelPort.Watch)
+var ErrJobCancelled = errors.New("job cancelled by admin")
+
+// JobCancelPort รับคำขอยกเลิก job ที่ API ตั้งไว้ใน NATS KV (JOB_CANCEL)
+type JobCancelPort interface {
+	// Watch คืน ctx ที่ถูก cancel ด้วย cause ErrJobCancelled เมื่อมีคำขอยกเลิก job นี้
+	// ต้องเรียก stop เมื่อ job จบ
+	Watch(ctx context.Context, jobType, videoID string) (context.Context, func())
+
+	// Clear ลบคำขอยกเลิกหลัง worker หยุด job แล้ว
+	Clear(ctx context.Context, jobType, videoID string) error
+}
@@ -1,6 +1,9 @@
 package ports
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // ═══════════════════════════════════════════════════════════════════════════════
 // VideoRepository - Interface สำหรับ Video Status Management (PostgreSQL)
@@ -16,6 +19,43 @@ type VideoCompletedInfo struct {
 	Quality      string            // highest quality ที่มี (e.g. "1080p")
 }
 
+// JobEvent event ของ pipeline stage สำหรับเก็บลง job_events (audit trail)
+type JobEvent struct {
+	VideoID  string  // UUID ของวิดีโอ
+	Source   string  // "transcode", "gallery"
+	Stage    string  // "processing", "completed", "failed"
+	Progress float64 // 0-100
+	Message  string
+}
+
+// ProcessingCost compute ที่ใช้ของ job หนึ่งครั้ง สำหรับเก็บลง processing_costs
+type ProcessingCost struct {
+	VideoID           string        // UUID ของวิดีโอ
+	Source            string        // "gallery"
+	FrameCount        int           // จำนวน frames ที่ดึงจาก HLS
+	ClassifierRuntime time.Duration // เวลารวมที่ใช้ classify (0 = ไม่ได้วัด)
+	JobDuration       time.Duration // เวลาทั้ง job
+}
+
+// GalleryImageScore ผล classify ของภาพหนึ่งภาพ สำหรับเก็บลง gallery_image_scores
+type GalleryImageScore struct {
+	Filename       string
+	Tier           string // "super_safe", "safe", "nsfw", "error"
+	NsfwScore      float64
+	FaceScore      float64
+	AestheticScore float64
+	MosaicDetected bool
+	POVDetected    bool
+}
+
+// ThumbnailSource ข้อมูลวิดีโอที่ใช้สร้าง thumbnail
+type ThumbnailSource struct {
+	Code     string // video code (ใช้ตั้งชื่อไฟล์ thumbnails/<code>.jpg)
+	HLSPath  string // path ของ master playlist
+	Quality  string // highest quality ที่มี (e.g. "1080p")
+	Duration int    // ความยาววิดีโอ (วินาที)
+}
+
 type VideoRepository interface {
 	// GetStatus ดึง status ปัจจุบันของวิดีโอ
 	GetStatus(ctx context.Context, videoID string) (string, error)
@@ -26,6 +66,9 @@ type VideoRepository interface {
 	// UpdateGalleryProcessingStarted อัพเดทว่าเริ่ม gallery processing แล้ว
 	UpdateGalleryProcessingStarted(ctx context.Context, videoID string) error
 
+	// UpdateGalleryCancelled ตั้ง gallery_status = "cancelled" (admin ยกเลิก job)
+	UpdateGalleryCancelled(ctx context.Context, videoID string) error
+
 	// UpdateCompleted อัพเดทเมื่อ transcode สำเร็จ
 	UpdateCompleted(ctx context.Context, videoID string, info *VideoCompletedInfo) error
 
@@ -41,4 +84,19 @@ type VideoRepository interface {
 	// UpdateGalleryManualSelection อัพเดท gallery info สำหรับ Manual Selection Flow
 	// ตั้ง gallery_status = "pending_review" และ gallery_source_count
 	UpdateGalleryManualSelection(ctx context.Context, videoID, galleryPath string, sourceCount int) error
+
+	// GetThumbnailSource ดึง code/hls_path/quality/duration สำหรับสร้าง thumbnail
+	GetThumbnailSource(ctx context.Context, videoID string) (*ThumbnailSource, error)
+
+	// UpdateThumbnail อัพเดท thumbnail_url และ thumbnail_variants (format → path, e.g. {"webp": ...})
+	UpdateThumbnail(ctx context.Context, videoID, thumbnailURL string, variants map[string]string) error
+
+	// RecordJobEvent บันทึก pipeline event ลง job_events (ใช้ดู timeline ผ่าน API)
+	RecordJobEvent(ctx context.Context, event *JobEvent) error
+
+	// RecordProcessingCost บันทึก compute ที่ใช้ของ job ลง processing_costs
+	RecordProcessingCost(ctx context.Context, cost *ProcessingCost) error
+
+	// ReplaceGalleryScores แทนที่ผล classify รายภาพของวิดีโอใน gallery_image_scores (ใช้ดู classification stats)
+	ReplaceGalleryScores(ctx context.Context, videoID string, scores []GalleryImageScore) error
 }
@@ -0,0 +1,49 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"suekk-worker/domain/models"
+	"suekk-worker/ports"
+)
+
+// SetJobCancel เปิดรับคำขอยกเลิก job จาก API (ไม่ตั้ง = ยกเลิกได้แค่ตอน worker shutdown)
+func (h *GalleryHandler) SetJobCancel(jobCancel ports.JobCancelPort) {
+	h.jobCancel = jobCancel
+}
+
+// watchJobCancel ผูกคำขอยกเลิกของ job เข้ากับ ctx - abortIfCancelled เช็คที่ทุก stage
+func (h *GalleryHandler) watchJobCancel(ctx context.Context, job *models.GalleryJob) (context.Context, func()) {
+	if h.jobCancel == nil {
+		return ctx, func() {}
+	}
+	return h.jobCancel.Watch(ctx, ports.JobTypeGallery, job.VideoID)
+}
+
+// isJobCancelled ctx ถูก cancel เพราะ admin สั่งยกเลิก (ไม่ใช่ worker shutdown)
+func isJobCancelled(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), ports.ErrJobCancelled)
+}
+
+// markGalleryCancelled ตั้ง gallery_status = cancelled แล้วล้างคำขอยกเลิก
+func (h *GalleryHandler) markGalleryCancelled(ctx context.Context, job *models.GalleryJob, stage string) {
+	msg := fmt.Sprintf("ยกเลิกโดย admin ระหว่าง %s", stage)
+
+	if h.repository != nil {
+		if err := h.repository.UpdateGalleryCancelled(ctx, job.VideoID); err != nil {
+			h.logger.Warn("failed to update gallery cancelled", "video_id", job.VideoID, "error", err)
+		}
+	}
+	if h.messenger != nil {
+		h.messenger.PublishGalleryFailed(ctx, job.VideoID, job.VideoCode, msg)
+	}
+	h.recordJobEvent(ctx, job, "cancelled", 0, msg)
+
+	if h.jobCancel != nil {
+		if err := h.jobCancel.Clear(ctx, ports.JobTypeGallery, job.VideoID); err != nil {
+			h.logger.Warn("failed to clear job cancel request", "video_id", job.VideoID, "error", err)
+		}
+	}
+}
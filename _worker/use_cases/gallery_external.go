@@ -0,0 +1,217 @@
+package use_cases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"suekk-worker/domain/models"
+	"suekk-worker/infrastructure/classifier"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// External Gallery - ภาพจากค่าย (official stills) ที่ admin upload ไว้ใน gallery/<code>/external/
+// classify + แยก tier + update DB เหมือน Three-Tier flow แต่ข้ามการดึง frame จาก HLS
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// externalGalleryResponse response ของ GET /internal/videos/:id/gallery/external
+type externalGalleryResponse struct {
+	Data struct {
+		Images []string `json:"images"`
+	} `json:"data"`
+}
+
+// fetchExternalGalleryImages ดึง storage keys ของภาพจากค่ายจาก API
+// เรียก API ไม่ได้ = ถือว่าไม่มี (ทำ gallery จาก HLS ตามปกติ)
+func (h *GalleryHandler) fetchExternalGalleryImages(ctx context.Context, videoID string) []string {
+	if h.config.APIURL == "" || h.authClient == nil || !h.authClient.IsConfigured() {
+		return nil
+	}
+
+	url := fmt.Sprintf("%s/api/v1/internal/videos/%s/gallery/external", h.config.APIURL, videoID)
+	resp, err := h.authClient.DoRequestWithAuth(ctx, "GET", url, nil)
+	if err != nil {
+		h.logger.Warn("failed to check external gallery images", "video_id", videoID, "error", err)
+		return nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		h.logger.Warn("failed to check external gallery images", "video_id", videoID, "status_code", resp.StatusCode)
+		return nil
+	}
+
+	var result externalGalleryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		h.logger.Warn("failed to decode external gallery images", "video_id", videoID, "error", err)
+		return nil
+	}
+	return result.Data.Images
+}
+
+// clearExternalGalleryImages ลบภาพจากค่ายหลัง upload เข้า tier แล้ว (job ถัดไปจะไม่หยิบมา classify ซ้ำ)
+func (h *GalleryHandler) clearExternalGalleryImages(ctx context.Context, videoID string) {
+	if h.config.APIURL == "" || h.authClient == nil || !h.authClient.IsConfigured() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/api/v1/internal/videos/%s/gallery/external", h.config.APIURL, videoID)
+	resp, err := h.authClient.DoRequestWithAuth(ctx, "DELETE", url, nil)
+	if err != nil {
+		h.logger.Warn("failed to clear external gallery images", "video_id", videoID, "error", err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		h.logger.Warn("failed to clear external gallery images", "video_id", videoID, "status_code", resp.StatusCode)
+	}
+}
+
+// processExternalGallery classify ภาพจากค่ายแล้ว upload เข้า super_safe/safe/nsfw
+func (h *GalleryHandler) processExternalGallery(ctx context.Context, job *models.GalleryJob, images []string, startedAt time.Time) error {
+	h.logger.Info("processing external gallery images",
+		"video_id", job.VideoID,
+		"video_code", job.VideoCode,
+		"image_count", len(images),
+	)
+
+	h.publishProgress(ctx, job, 0, "เริ่มจัดการภาพ Gallery จากค่าย...")
+
+	baseDir := filepath.Join(h.config.TempDir, "gallery", job.VideoCode)
+	allDir := filepath.Join(baseDir, "all")
+	superSafeDir := filepath.Join(baseDir, "super_safe")
+	safeDir := filepath.Join(baseDir, "safe")
+	nsfwDir := filepath.Join(baseDir, "nsfw")
+
+	for _, dir := range []string{allDir, superSafeDir, safeDir, nsfwDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			h.publishFailed(ctx, job, err.Error())
+			return fmt.Errorf("create dir %s: %w", dir, err)
+		}
+	}
+	defer os.RemoveAll(baseDir)
+
+	h.publishProgress(ctx, job, 10, "กำลังดาวน์โหลดภาพจากค่าย...")
+
+	downloaded := 0
+	for _, key := range images {
+		localPath := filepath.Join(allDir, path.Base(key))
+		if err := h.storage.Download(ctx, key, localPath, nil); err != nil {
+			if cerr := h.abortIfCancelled(ctx, job, baseDir, "external download"); cerr != nil {
+				return cerr
+			}
+			h.logger.Warn("failed to download external gallery image", "key", key, "error", err)
+			continue
+		}
+		downloaded++
+	}
+
+	if downloaded == 0 {
+		h.publishFailed(ctx, job, "no external gallery images downloaded")
+		return fmt.Errorf("no external gallery images downloaded")
+	}
+
+	if err := h.abortIfCancelled(ctx, job, baseDir, "external download"); err != nil {
+		return err
+	}
+
+	h.publishProgress(ctx, job, 40, "กำลังจัดกลุ่มภาพ (NSFW Classification)...")
+
+	classifierConfig := h.threeTierClassifierConfig()
+	nsfwClassifier := classifier.NewNSFWClassifier(classifierConfig, h.logger)
+
+	classifyStart := time.Now()
+	result, err := nsfwClassifier.ClassifyBatch(ctx, allDir)
+	classifierRuntime := time.Since(classifyStart)
+	if err != nil {
+		if cerr := h.abortIfCancelled(ctx, job, baseDir, "external classify"); cerr != nil {
+			return cerr
+		}
+		if h.config.ReviewOnClassifyFailure {
+			if ferr := h.fallbackToManualReview(ctx, job, baseDir, err); ferr != nil {
+				return ferr
+			}
+			h.clearExternalGalleryImages(ctx, job.VideoID)
+			return nil
+		}
+		h.publishFailed(ctx, job, err.Error())
+		return fmt.Errorf("classify external gallery: %w", err)
+	}
+
+	scores := appendGalleryScores(nil, result.Results)
+
+	separated := nsfwClassifier.SeparateResults(result.Results)
+	h.moveClassifiedFilesThreeTier(allDir, superSafeDir, safeDir, nsfwDir, separated)
+
+	// ภาพจากค่ายเก็บทุก tier (ไม่มีแยก phase) - จำกัดจำนวนตาม quality เหมือน Three-Tier flow
+	h.trimByQuality(nsfwClassifier, separated.Nsfw, classifierConfig.MaxNsfwImages, nsfwDir, "nsfw")
+	h.trimByQuality(nsfwClassifier, separated.Safe, classifierConfig.MaxSafeImages, safeDir, "safe")
+
+	if err := h.abortIfCancelled(ctx, job, baseDir, "external classify"); err != nil {
+		return err
+	}
+
+	h.publishProgress(ctx, job, 85, "กำลังอัพโหลดภาพ...")
+
+	superSafeUploaded, err := h.uploadGalleryImages(ctx, superSafeDir, job.OutputPath+"/super_safe", job.VideoCode)
+	if err != nil {
+		h.logger.Warn("failed to upload super_safe images", "error", err)
+	}
+
+	safeUploaded, err := h.uploadGalleryImages(ctx, safeDir, job.OutputPath+"/safe", job.VideoCode)
+	if err != nil {
+		h.logger.Warn("failed to upload safe images", "error", err)
+	}
+
+	nsfwUploaded, err := h.uploadGalleryImages(ctx, nsfwDir, job.OutputPath+"/nsfw", job.VideoCode)
+	if err != nil {
+		h.logger.Warn("failed to upload nsfw images", "error", err)
+	}
+
+	if err := h.abortIfCancelled(ctx, job, baseDir, "upload"); err != nil {
+		return err
+	}
+
+	h.publishProgress(ctx, job, 95, "กำลังบันทึกข้อมูล...")
+
+	if err := h.updateVideoGalleryClassifiedThreeTier(ctx, job.VideoID, job.OutputPath, superSafeUploaded, safeUploaded, nsfwUploaded); err != nil {
+		h.logger.Warn("failed to update classified gallery in DB",
+			"video_id", job.VideoID,
+			"error", err,
+		)
+	}
+
+	h.clearExternalGalleryImages(ctx, job.VideoID)
+
+	h.publishCompleted(ctx, job)
+	h.recordProcessingCost(ctx, job, downloaded, classifierRuntime, time.Since(startedAt))
+	h.recordGalleryScores(ctx, job, scores)
+
+	h.logger.Info("external gallery job completed",
+		"video_id", job.VideoID,
+		"video_code", job.VideoCode,
+		"images", downloaded,
+		"super_safe_images", superSafeUploaded,
+		"safe_images", safeUploaded,
+		"nsfw_images", nsfwUploaded,
+		"classifier_runtime", classifierRuntime,
+	)
+
+	return nil
+}
+
+// trimByQuality เก็บภาพที่ quality ดีที่สุด max ภาพ ลบที่เหลือออกจาก tier dir
+func (h *GalleryHandler) trimByQuality(nsfwClassifier *classifier.NSFWClassifier, results []classifier.ClassificationResult, max int, dir, tier string) {
+	if len(results) <= max {
+		return
+	}
+	nsfwClassifier.SortByQuality(results)
+	for _, r := range results[max:] {
+		os.Remove(filepath.Join(dir, galleryTierFilename(h.config.Naming, tier, r.Filename)))
+	}
+}
@@ -0,0 +1,110 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// defaultFrameCaptureTimeout ค่า default ของ FrameCaptureTimeout
+const defaultFrameCaptureTimeout = 30 * time.Second
+
+// frameCaptureRetries จำนวนครั้งที่ลองใหม่ต่อ frame (presigned URL ใหม่ทุกครั้ง)
+const frameCaptureRetries = 1
+
+// errFrameCaptureTimeout ffmpeg ใช้เวลาเกิน FrameCaptureTimeout
+var errFrameCaptureTimeout = errors.New("frame capture timed out")
+
+// errPresignSegment สร้าง presigned URL ของ segment ไม่สำเร็จ
+var errPresignSegment = errors.New("presign segment")
+
+// transientCaptureErrors ข้อความจาก ffmpeg ที่มักเกิดจากเครือข่าย/URL หมดอายุชั่วคราว
+var transientCaptureErrors = []string{
+	"403 Forbidden",
+	"Server returned 5",
+	"Connection reset",
+	"Connection refused",
+	"Connection timed out",
+	"I/O error",
+}
+
+// frameCaptureStats ตัวนับสะสมของการ capture frame (ทุก job ของ handler นี้)
+type frameCaptureStats struct {
+	retried   atomic.Int64
+	recovered atomic.Int64
+	failed    atomic.Int64
+}
+
+// FrameCaptureStats snapshot ของตัวนับ capture frame
+type FrameCaptureStats struct {
+	Retried   int64 // จำนวนครั้งที่ลองใหม่
+	Recovered int64 // frame ที่สำเร็จหลัง retry
+	Failed    int64 // frame ที่ข้ามไป (retry แล้วยังไม่ได้ หรือ error ที่ retry ไม่ช่วย)
+}
+
+// FrameCaptureStats คืนจำนวน retry/กู้คืน/ล้มเหลวสะสมของการ capture frame
+func (h *GalleryHandler) FrameCaptureStats() FrameCaptureStats {
+	return FrameCaptureStats{
+		Retried:   h.captureStats.retried.Load(),
+		Recovered: h.captureStats.recovered.Load(),
+		Failed:    h.captureStats.failed.Load(),
+	}
+}
+
+// captureSegmentFrame presign segment แล้ว capture frame
+// timeout/เครือข่ายสะดุด = ลองใหม่ด้วย presigned URL ใหม่ก่อนยอมข้าม frame
+func (h *GalleryHandler) captureSegmentFrame(ctx context.Context, hlsPath string, segment *hlsSegment, outputPath string, seekTime float64) error {
+	var lastErr error
+	for attempt := 0; attempt <= frameCaptureRetries; attempt++ {
+		if attempt > 0 {
+			if ctx.Err() != nil || !isRetryableCaptureError(lastErr) {
+				break
+			}
+			h.captureStats.retried.Add(1)
+			h.logger.Warn("retrying frame capture",
+				"segment", segment.filename,
+				"attempt", attempt+1,
+				"error", lastErr,
+			)
+		}
+
+		segmentURL, initURL, err := h.presignSegmentURLs(ctx, hlsPath, segment)
+		if err != nil {
+			lastErr = fmt.Errorf("%w: %v", errPresignSegment, err)
+			continue
+		}
+
+		if err := h.captureFrameFromSegment(ctx, segmentURL, initURL, outputPath, seekTime); err != nil {
+			lastErr = err
+			continue
+		}
+
+		if attempt > 0 {
+			h.captureStats.recovered.Add(1)
+		}
+		return nil
+	}
+
+	h.captureStats.failed.Add(1)
+	return lastErr
+}
+
+// isRetryableCaptureError error ที่ลองใหม่แล้วมีโอกาสสำเร็จ (timeout, presign, network)
+func isRetryableCaptureError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, errFrameCaptureTimeout) || errors.Is(err, errPresignSegment) {
+		return true
+	}
+	msg := err.Error()
+	for _, marker := range transientCaptureErrors {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
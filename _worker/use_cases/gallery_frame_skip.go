@@ -0,0 +1,50 @@
+package use_cases
+
+import "fmt"
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Gallery Frame Skip - สัดส่วนของวิดีโอที่ไม่ดึงภาพ (intro/โลโก้ตอนต้น, credits ตอนท้าย)
+// Default: ข้าม 5% แรกและ 5% สุดท้าย
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// GalleryFrameSkip สัดส่วนที่ข้าม (0-1 ของความยาววิดีโอ)
+type GalleryFrameSkip struct {
+	StartFraction float64 // ข้ามช่วงต้น (default 0.05)
+	EndFraction   float64 // ข้ามช่วงท้าย นับจากท้ายวิดีโอ (default 0.05)
+}
+
+// DefaultGalleryFrameSkip ค่า default (ตรงกับพฤติกรรมเดิม)
+func DefaultGalleryFrameSkip() GalleryFrameSkip {
+	return GalleryFrameSkip{StartFraction: 0.05, EndFraction: 0.05}
+}
+
+// withDefaults เติมค่า default ให้ field ที่ไม่ได้ตั้ง (zero value)
+func (s GalleryFrameSkip) withDefaults() GalleryFrameSkip {
+	def := DefaultGalleryFrameSkip()
+	if s.StartFraction == 0 {
+		s.StartFraction = def.StartFraction
+	}
+	if s.EndFraction == 0 {
+		s.EndFraction = def.EndFraction
+	}
+	return s
+}
+
+// Validate ตรวจสอบว่าช่วงที่เหลือไม่ว่าง (จุดเริ่มต้องมาก่อนจุดจบ)
+func (s GalleryFrameSkip) Validate() error {
+	if s.StartFraction < 0 || s.StartFraction >= 1 {
+		return fmt.Errorf("start skip fraction must be in [0, 1), got %g", s.StartFraction)
+	}
+	if s.EndFraction < 0 || s.EndFraction >= 1 {
+		return fmt.Errorf("end skip fraction must be in [0, 1), got %g", s.EndFraction)
+	}
+	if s.StartFraction >= 1-s.EndFraction {
+		return fmt.Errorf("start (%g) must be before end (%g)", s.StartFraction, 1-s.EndFraction)
+	}
+	return nil
+}
+
+// window ช่วงเวลา (วินาที) ที่ใช้ดึงภาพ
+func (s GalleryFrameSkip) window(durationSec int) (float64, float64) {
+	return float64(durationSec) * s.StartFraction, float64(durationSec) * (1 - s.EndFraction)
+}
@@ -0,0 +1,42 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"suekk-worker/infrastructure/gallery"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Gallery Frame Source - การ map timestamp → HLS segment ของ GalleryHandler
+// ลงทะเบียนกับ gallery.Service ที่ใช้ร่วมกัน (SetFrameSource) เพื่อให้ gallery
+// ตอน transcode และ gallery job ดึง frame ด้วย logic ชุดเดียวกัน
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// errTimestampOutsidePlaylist timestamp อยู่นอก playlist (เกิน SegmentEndTolerance) → ข้าม frame
+var errTimestampOutsidePlaylist = errors.New("timestamp outside playlist")
+
+// OpenFrameSource implements gallery.FrameSource
+// parse playlist ครั้งเดียวต่อวิดีโอ แล้วคืน capture func ที่ map timestamp เป็น segment
+// ตาม EXTINF จริง (รองรับ discontinuity และ SegmentEndTolerance ช่วงท้าย playlist)
+func (h *GalleryHandler) OpenFrameSource(ctx context.Context, hlsPath string) (gallery.FrameCaptureFunc, error) {
+	segments, err := h.parseHLSPlaylist(ctx, hlsPath)
+	if err != nil {
+		return nil, fmt.Errorf("parse playlist: %w", err)
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("no segments found in playlist")
+	}
+	h.logger.Info("frame source opened", "hls_path", hlsPath, "segments", len(segments), "segment_type", hlsPlaylistType(segments))
+
+	return func(ctx context.Context, timestamp float64, outputPath string) error {
+		segment := h.findSegmentForTimestamp(segments, timestamp)
+		if segment == nil {
+			return fmt.Errorf("%w: %.1fs", errTimestampOutsidePlaylist, timestamp)
+		}
+
+		// presign segment + init segment (fMP4) ทุกครั้งที่ลอง, retry ด้วย URL ใหม่ถ้า timeout
+		return h.captureSegmentFrame(ctx, hlsPath, segment, outputPath, timestamp-segment.startTime)
+	}, nil
+}
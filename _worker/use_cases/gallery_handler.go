@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -30,8 +31,61 @@ type GalleryHandlerConfig struct {
 	TempDir  string // Directory สำหรับเก็บ temp files
 	APIURL   string // API URL สำหรับ update video
 	TestMode bool   // TEST_MODE: skip upload & DB update, keep files locally
+
+	// Phases ช่วงเวลาของ Two-Phase Extraction (zero value = ใช้ค่า default)
+	Phases GalleryPhaseConfig
+
+	// TierLimits จำนวนภาพขั้นต่ำ/สูงสุดต่อ tier (zero value = ใช้ค่า default)
+	TierLimits GalleryTierLimits
+
+	// Classifier python/script/device สำหรับ classify_batch.py (zero value = ใช้ค่า default)
+	Classifier GalleryClassifierConfig
+
+	// ReviewOnClassifyFailure ถ้า classifier timeout/crash ให้ส่งทุก frame ไป source/
+	// รอ admin เลือกเอง (pending_review) แทนที่จะได้ gallery ว่าง
+	ReviewOnClassifyFailure bool
+
+	// FrameSkip สัดส่วนต้น/ท้ายวิดีโอที่ไม่ดึงภาพ (zero value = ข้าม 5% ทั้งสองฝั่ง)
+	FrameSkip GalleryFrameSkip
+
+	// SegmentEndTolerance timestamp ที่เกินท้าย playlist ไม่เกินค่านี้ยังใช้ segment สุดท้าย
+	// (เผื่อ EXTINF ปัดเศษ) - เกินกว่านี้ข้าม frame (zero value = defaultSegmentEndTolerance)
+	SegmentEndTolerance time.Duration
+
+	// Naming ชื่อไฟล์ใน tier dirs: sequential (default) | tier_prefix (ss_/sf_/ns_)
+	Naming string
+
+	// ThumbnailFraction ตำแหน่ง thumbnail อัตโนมัติ (สัดส่วนของความยาว, zero value = 0.25)
+	ThumbnailFraction float64
+
+	// ThumbnailAVIF สร้าง AVIF เพิ่มจาก WebP (encode ช้ากว่ามาก ปิดไว้เป็น default)
+	ThumbnailAVIF bool
+
+	// ThumbnailWithGallery สร้าง thumbnail (GenerateThumbnail) ต่อท้าย gallery job ที่สำเร็จ
+	ThumbnailWithGallery bool
+
+	// FrameCaptureTimeout เวลาสูงสุดของ ffmpeg ต่อ frame (zero value = defaultFrameCaptureTimeout)
+	FrameCaptureTimeout time.Duration
+}
+
+// defaultSegmentEndTolerance ค่า default ของ SegmentEndTolerance
+const defaultSegmentEndTolerance = 500 * time.Millisecond
+
+// GalleryClassifierConfig ตั้งค่า Python classifier (GPU workers ใช้ cuda ได้เร็วกว่า)
+type GalleryClassifierConfig struct {
+	PythonPath string // Python executable (default: "python")
+	ScriptPath string // Path to classify_batch.py
+	Device     string // cpu, cuda, mps ("" = auto)
+
+	// BatchSize ภาพต่อการเรียก Python 1 ครั้ง - กันวิดีโอยาว classify เกิน timeout (0 = defaultClassifierBatchSize, < 0 = ไม่แบ่ง)
+	BatchSize int
+	// Concurrency จำนวน batch ที่ classify พร้อมกัน (0 = ทีละ batch)
+	Concurrency int
 }
 
+// defaultClassifierBatchSize ค่า default ของ GalleryClassifierConfig.BatchSize
+const defaultClassifierBatchSize = 100
+
 // GalleryAuthClientPort interface สำหรับ auth client
 type GalleryAuthClientPort interface {
 	DoRequestWithAuth(ctx context.Context, method, url string, body []byte) (*http.Response, error)
@@ -47,10 +101,14 @@ type GalleryHandler struct {
 	galleryService  *gallery.Service
 	galleryUploader *gallery.Uploader
 	config          GalleryHandlerConfig
+	captureStats    *frameCaptureStats    // จำนวน retry/กู้คืน/ล้มเหลวของการ capture frame (ใช้ร่วมกันทุก job)
+	jobCancel       ports.JobCancelPort   // nil = ไม่รับคำขอยกเลิกจาก API
+	galleryLock     ports.GalleryLockPort // nil = ไม่ lock ต่อวิดีโอ
 	logger          *slog.Logger
 }
 
 // NewGalleryHandler สร้าง GalleryHandler instance
+// galleryService ควรสร้างจาก GalleryServiceConfig(config) - ตัวเดียวกับที่ TranscodeHandler ใช้
 func NewGalleryHandler(
 	storage ports.StoragePort,
 	messenger ports.MessengerPort,
@@ -60,6 +118,8 @@ func NewGalleryHandler(
 	galleryUploader *gallery.Uploader,
 	config GalleryHandlerConfig,
 ) *GalleryHandler {
+	logger := slog.Default().With("component", "gallery-handler")
+
 	return &GalleryHandler{
 		storage:         storage,
 		messenger:       messenger,
@@ -67,13 +127,91 @@ func NewGalleryHandler(
 		authClient:      authClient,
 		galleryService:  galleryService,
 		galleryUploader: galleryUploader,
-		config:          config,
-		logger:          slog.Default().With("component", "gallery-handler"),
+		config:          config.withDefaults(logger),
+		captureStats:    &frameCaptureStats{},
+		logger:          logger,
+	}
+}
+
+// forJob คืน handler ของ job นี้ - logger ติด trace_id จาก API ให้ทุก log ของ job
+// (สำเนาตื้น: storage, service, lock, counters ใช้ตัวเดียวกับ handler หลัก)
+func (h *GalleryHandler) forJob(job *models.GalleryJob) *GalleryHandler {
+	if job.TraceID == "" {
+		return h
+	}
+	jh := *h
+	jh.logger = h.logger.With("trace_id", job.TraceID)
+	return &jh
+}
+
+// withDefaults เติม default + validate ทุก field (ค่าที่ไม่ถูกต้องใช้ default พร้อม log warning)
+// ใช้ทั้ง NewGalleryHandler และ GalleryServiceConfig ให้สองฝั่งเห็นค่าชุดเดียวกัน
+func (config GalleryHandlerConfig) withDefaults(logger *slog.Logger) GalleryHandlerConfig {
+	// เติม default + validate phase config (ถ้าไม่ถูกต้องใช้ default ทั้งหมด)
+	config.Phases = config.Phases.withDefaults()
+	if err := config.Phases.Validate(); err != nil {
+		logger.Warn("invalid gallery phase config, using defaults", "error", err)
+		config.Phases = DefaultGalleryPhaseConfig()
+	}
+
+	def := classifier.DefaultConfig()
+	if config.Classifier.PythonPath == "" {
+		config.Classifier.PythonPath = def.PythonPath
+	}
+	if config.Classifier.ScriptPath == "" {
+		config.Classifier.ScriptPath = def.ScriptPath
+	}
+	if err := classifier.ValidateDevice(config.Classifier.Device); err != nil {
+		logger.Warn("invalid classifier device, using auto", "error", err)
+		config.Classifier.Device = ""
+	}
+	if config.Classifier.BatchSize == 0 {
+		config.Classifier.BatchSize = defaultClassifierBatchSize
+	}
+	if config.Classifier.Concurrency < 1 {
+		config.Classifier.Concurrency = 1
+	}
+
+	config.FrameSkip = config.FrameSkip.withDefaults()
+	if err := config.FrameSkip.Validate(); err != nil {
+		logger.Warn("invalid gallery frame skip, using defaults", "error", err)
+		config.FrameSkip = DefaultGalleryFrameSkip()
+	}
+
+	if config.SegmentEndTolerance <= 0 {
+		config.SegmentEndTolerance = defaultSegmentEndTolerance
+	}
+
+	config.TierLimits = config.TierLimits.withDefaults()
+	if err := config.TierLimits.Validate(); err != nil {
+		logger.Warn("invalid gallery tier limits, using defaults", "error", err)
+		config.TierLimits = DefaultGalleryTierLimits()
+	}
+
+	if err := ValidateGalleryNaming(config.Naming); err != nil {
+		logger.Warn("invalid gallery naming, using sequential", "error", err)
+		config.Naming = GalleryNamingSequential
+	}
+
+	if config.FrameCaptureTimeout <= 0 {
+		config.FrameCaptureTimeout = defaultFrameCaptureTimeout
 	}
+
+	if config.ThumbnailFraction == 0 {
+		config.ThumbnailFraction = defaultThumbnailFraction
+	}
+	if err := validateThumbnailFraction(config.ThumbnailFraction); err != nil {
+		logger.Warn("invalid thumbnail fraction, using default", "error", err)
+		config.ThumbnailFraction = defaultThumbnailFraction
+	}
+
+	return config
 }
 
 // ProcessJob handles the gallery job from NATS JetStream
 func (h *GalleryHandler) ProcessJob(ctx context.Context, job *models.GalleryJob) error {
+	h = h.forJob(job)
+
 	h.logger.Info("processing gallery job",
 		"video_id", job.VideoID,
 		"video_code", job.VideoCode,
@@ -82,6 +220,16 @@ func (h *GalleryHandler) ProcessJob(ctx context.Context, job *models.GalleryJob)
 		"image_count", job.ImageCount,
 	)
 
+	release, err := h.acquireGalleryLock(ctx, job)
+	if errors.Is(err, ports.ErrGalleryLocked) {
+		return nil // ack - job อื่นกำลังสร้าง gallery ของวิดีโอนี้อยู่
+	}
+	if err != nil {
+		h.logger.Warn("gallery lock not acquired - job will be retried", "video_id", job.VideoID, "video_code", job.VideoCode, "error", err)
+		return err
+	}
+	defer release()
+
 	// Publish initial progress
 	h.publishProgress(ctx, job, 0, "เริ่มสร้าง Gallery...")
 
@@ -106,15 +254,25 @@ func (h *GalleryHandler) ProcessJob(ctx context.Context, job *models.GalleryJob)
 	}
 
 	if err := h.extractFramesFromHLS(ctx, job, outputDir, progressCallback); err != nil {
+		if cerr := h.abortIfCancelled(ctx, job, outputDir, "extract"); cerr != nil {
+			return cerr
+		}
 		h.publishFailed(ctx, job, err.Error())
 		return fmt.Errorf("extract frames: %w", err)
 	}
 
+	if err := h.abortIfCancelled(ctx, job, outputDir, "before upload"); err != nil {
+		return err
+	}
+
 	h.publishProgress(ctx, job, 85, "กำลังอัพโหลดภาพ...")
 
 	// 4. Upload images to S3
 	uploadedCount, err := h.uploadGalleryImages(ctx, outputDir, job.OutputPath, job.VideoCode)
 	if err != nil {
+		if cerr := h.abortIfCancelled(ctx, job, outputDir, "upload"); cerr != nil {
+			return cerr
+		}
 		h.publishFailed(ctx, job, err.Error())
 		return fmt.Errorf("upload gallery: %w", err)
 	}
@@ -150,17 +308,43 @@ func (h *GalleryHandler) ProcessJob(ctx context.Context, job *models.GalleryJob)
 // ═══════════════════════════════════════════════════════════════════════════════
 // ProcessJobWithClassification - Gallery Generation with NSFW Classification
 // Uses shared GalleryService for consistent logic with TranscodeHandler
+// (phases, tier limits, classifier มาจาก GalleryServiceConfig - GALLERY_* settings เดียวกัน)
 // ═══════════════════════════════════════════════════════════════════════════════
 
 // ProcessJobWithClassification handles gallery job with classification or manual selection
 // Uses shared GalleryService เพื่อให้ logic เหมือนกับ TranscodeHandler
+// admin ยกเลิก job = หยุดที่ stage ถัดไปแล้วคืน nil (ack - ไม่ให้ NATS redeliver มาทำใหม่)
 func (h *GalleryHandler) ProcessJobWithClassification(ctx context.Context, job *models.GalleryJob) error {
+	h = h.forJob(job)
+
+	release, err := h.acquireGalleryLock(ctx, job)
+	if errors.Is(err, ports.ErrGalleryLocked) {
+		return nil // ack - job อื่นกำลังสร้าง gallery ของวิดีโอนี้อยู่
+	}
+	if err != nil {
+		h.logger.Warn("gallery lock not acquired - job will be retried", "video_id", job.VideoID, "video_code", job.VideoCode, "error", err)
+		return err
+	}
+	defer release()
+
+	ctx, stopWatch := h.watchJobCancel(ctx, job)
+	defer stopWatch()
+
+	err = h.processJobWithClassification(ctx, job)
+	if errors.Is(err, ports.ErrJobCancelled) {
+		return nil
+	}
+	return err
+}
+
+func (h *GalleryHandler) processJobWithClassification(ctx context.Context, job *models.GalleryJob) error {
 	h.logger.Info("processing gallery job (shared service)",
 		"video_id", job.VideoID,
 		"video_code", job.VideoCode,
 		"quality", job.VideoQuality,
 		"duration", job.Duration,
 	)
+	startedAt := time.Now()
 
 	// Update gallery_status to 'processing'
 	if h.repository != nil {
@@ -169,6 +353,11 @@ func (h *GalleryHandler) ProcessJobWithClassification(ctx context.Context, job *
 		}
 	}
 
+	// ภาพจากค่ายที่ admin upload ไว้ = classify ภาพชุดนั้นแทนการดึง frame จาก HLS
+	if images := h.fetchExternalGalleryImages(ctx, job.VideoID); len(images) > 0 {
+		return h.processExternalGallery(ctx, job, images, startedAt)
+	}
+
 	// Publish initial progress
 	h.publishProgress(ctx, job, 0, "เริ่มสร้าง Gallery...")
 
@@ -192,6 +381,10 @@ func (h *GalleryHandler) ProcessJobWithClassification(ctx context.Context, job *
 		h.storage, // StoragePort for presigned URLs
 	)
 	if err != nil {
+		// createDirectories ของ shared service สร้าง {outputDir}/{videoCode}
+		if cerr := h.abortIfCancelled(ctx, job, filepath.Join(outputDir, job.VideoCode), "generate"); cerr != nil {
+			return cerr
+		}
 		h.publishFailed(ctx, job, err.Error())
 		return fmt.Errorf("generate gallery: %w", err)
 	}
@@ -224,15 +417,33 @@ func (h *GalleryHandler) ProcessJobWithClassification(ctx context.Context, job *
 		return nil
 	}
 
+	if err := h.abortIfCancelled(ctx, job, result.BaseDir, "before upload"); err != nil {
+		return err
+	}
+
 	h.publishProgress(ctx, job, 85, "กำลังอัพโหลดภาพ...")
 
 	// Manual Selection Flow: Upload to source/ only
+	// Legacy: Three-tier classification flow
 	if result.IsManualSelection {
-		return h.handleManualSelectionUpload(ctx, job, result)
+		err = h.handleManualSelectionUpload(ctx, job, result)
+	} else {
+		err = h.handleThreeTierUpload(ctx, job, result)
+	}
+	if err != nil {
+		return err
 	}
 
-	// Legacy: Three-tier classification flow
-	return h.handleThreeTierUpload(ctx, job, result)
+	// shared service ไม่ได้แยกเวลา classify ออกมา - บันทึกแค่ frames + เวลาทั้ง job
+	h.recordProcessingCost(ctx, job, result.TotalFrames, 0, time.Since(startedAt))
+
+	// thumbnail ใช้ playlist ชุดเดียวกัน - ล้มเหลวไม่ทำให้ gallery job fail
+	if h.config.ThumbnailWithGallery {
+		if _, err := h.GenerateThumbnail(ctx, job.VideoID, 0); err != nil {
+			h.logger.Warn("failed to generate thumbnail after gallery", "video_id", job.VideoID, "error", err)
+		}
+	}
+	return nil
 }
 
 // handleManualSelectionUpload uploads source/ และ update DB สำหรับ Manual Selection Flow
@@ -243,11 +454,23 @@ func (h *GalleryHandler) handleManualSelectionUpload(ctx context.Context, job *m
 		h.logger.Warn("failed to upload gallery", "error", err)
 	}
 
+	// upload ถูกตัดกลางทาง = ไม่ update DB ด้วย counts ที่ไม่ครบ
+	if err := h.abortIfCancelled(ctx, job, result.BaseDir, "upload"); err != nil {
+		return err
+	}
+
 	h.logger.Info("manual selection gallery uploaded",
 		"video_code", job.VideoCode,
 		"source_uploaded", uploadResult.SuperSafeUploaded, // source count stored in SuperSafeUploaded
 	)
 
+	// ไม่มีภาพใน source/ = admin ไม่มีอะไรให้เลือก → fail แทนการตั้ง pending_review
+	if uploadResult.SuperSafeUploaded == 0 {
+		h.galleryService.Cleanup(result)
+		h.publishFailed(ctx, job, "no frames uploaded for manual selection")
+		return fmt.Errorf("manual selection: no frames uploaded")
+	}
+
 	h.publishProgress(ctx, job, 95, "กำลังบันทึกข้อมูล...")
 
 	// Update database with manual selection flow fields
@@ -282,6 +505,11 @@ func (h *GalleryHandler) handleThreeTierUpload(ctx context.Context, job *models.
 		h.logger.Warn("failed to upload gallery", "error", err)
 	}
 
+	// upload ถูกตัดกลางทาง = ไม่ update DB ด้วย counts ที่ไม่ครบ
+	if err := h.abortIfCancelled(ctx, job, result.BaseDir, "upload"); err != nil {
+		return err
+	}
+
 	h.logger.Info("three-tier gallery uploaded",
 		"video_code", job.VideoCode,
 		"super_safe_uploaded", uploadResult.SuperSafeUploaded,
@@ -335,12 +563,15 @@ func (h *GalleryHandler) handleThreeTierUpload(ctx context.Context, job *models.
 // ProcessJobWithClassificationLegacy handles gallery job with inline classification logic
 // DEPRECATED: Use ProcessJobWithClassification instead
 func (h *GalleryHandler) ProcessJobWithClassificationLegacy(ctx context.Context, job *models.GalleryJob) error {
+	h = h.forJob(job)
+
 	h.logger.Info("processing gallery job with classification (legacy)",
 		"video_id", job.VideoID,
 		"video_code", job.VideoCode,
 		"quality", job.VideoQuality,
 		"duration", job.Duration,
 	)
+	startedAt := time.Now()
 
 	// Publish initial progress
 	h.publishProgress(ctx, job, 0, "เริ่มสร้าง Gallery + NSFW Classification...")
@@ -358,13 +589,19 @@ func (h *GalleryHandler) ProcessJobWithClassificationLegacy(ctx context.Context,
 			return fmt.Errorf("create dir %s: %w", dir, err)
 		}
 	}
-	// defer os.RemoveAll(baseDir) // Cleanup after done - DISABLED for debugging
+	// TEST_MODE เก็บไฟล์ไว้ตรวจเอง
+	if !h.config.TestMode {
+		defer os.RemoveAll(baseDir)
+	}
 
 	h.publishProgress(ctx, job, 5, "กำลังวิเคราะห์ HLS playlist...")
 
 	// 2. Parse HLS playlist
 	segments, err := h.parseHLSPlaylist(ctx, job.HLSPath)
 	if err != nil {
+		if cerr := h.abortIfCancelled(ctx, job, baseDir, "parse playlist"); cerr != nil {
+			return cerr
+		}
 		h.publishFailed(ctx, job, err.Error())
 		return fmt.Errorf("parse playlist: %w", err)
 	}
@@ -375,50 +612,45 @@ func (h *GalleryHandler) ProcessJobWithClassificationLegacy(ctx context.Context,
 	}
 
 	// 3. Initialize classifier (Three-Tier config)
-	// Verbose mode เปิดตลอดเพื่อ debug ปัญหา super_safe images
-	classifierConfig := classifier.ClassifierConfig{
-		PythonPath:         "python",
-		ScriptPath:         "infrastructure/classifier/classify_batch.py",
-		NsfwThreshold:      0.3,
-		SuperSafeThreshold: 0.15,
-		MinFaceScore:       0.1,
-		Timeout:            300, // 5 minutes for POV + Mosaic detection
-		MaxNsfwImages:      20,  // จำกัด NSFW 20 ภาพ
-		MaxSafeImages:      10,  // จำกัด Safe 10 ภาพ
-		MinSafeImages:      12,
-		MinSuperSafeImages: 10,
-		Verbose:            true, // Enable detailed per-image logging
-		SkipMosaic:         true, // Skip slow mosaic detection (temporarily)
-		SkipPOV:            true, // Skip slow POV detection (temporarily)
-	}
+	// phase windows มาจาก GalleryHandlerConfig.Phases, จำนวนภาพจาก TierLimits
+	phases := h.config.Phases
+	classifierConfig := h.threeTierClassifierConfig()
 	nsfwClassifier := classifier.NewNSFWClassifier(classifierConfig, h.logger)
 
-	// 4. Two-Phase Extraction:
+	// 4. Two-Phase Extraction (default):
 	// Phase 1 (นาทีที่ 1-10): หา super_safe + safe
-	// Phase 2 (นาทีที่ 11-20): หา nsfw
+	// Phase 2 (นาทีที่ 11-30): หา nsfw
 	var allSuperSafeResults []classifier.ClassificationResult
 	var allSafeResults []classifier.ClassificationResult
 	var allNsfwResults []classifier.ClassificationResult
+	var classifyErr error // error ล่าสุดจาก ClassifyBatch (ใช้ตัดสินใจ fallback)
+	var classifierRuntime time.Duration
+	var allScores []ports.GalleryImageScore // ผล classify ทุก frame (ก่อนทิ้ง/limit) สำหรับ classification stats
 	totalFrames := 0
 
-	videoDurationMin := job.Duration / 60 // Duration in minutes
-	framesPerMinute := 10
+	framesPerMinute := phases.FramesPerMinute
+
+	// ปรับ windows ให้อยู่ในความยาววิดีโอ
+	windows := phases.resolveForDuration(job.Duration)
+	for _, adj := range windows.Adjustments {
+		h.logger.Warn("gallery phase window adjusted", "video_code", job.VideoCode, "adjustment", adj)
+	}
 
 	h.logger.Info("starting two-phase extraction",
-		"video_duration_min", videoDurationMin,
+		"video_duration_sec", job.Duration,
 		"frames_per_minute", framesPerMinute,
+		"safe_window", fmt.Sprintf("%d-%d", windows.Safe.StartMinute, windows.Safe.EndMinute),
+		"nsfw_window", fmt.Sprintf("%d-%d", windows.Nsfw.StartMinute, windows.Nsfw.EndMinute),
+		"nsfw_enabled", windows.NsfwEnabled,
 	)
 
 	timestampTracker := make(map[int]bool)
 
 	// ═══════════════════════════════════════════════════════════════
-	// Phase 1: นาทีที่ 1-10 → หา super_safe + safe
+	// Phase 1: SafeWindow (default นาทีที่ 1-10) → หา super_safe + safe
 	// ═══════════════════════════════════════════════════════════════
-	phase1Start := 0
-	phase1End := 10
-	if phase1End > videoDurationMin {
-		phase1End = videoDurationMin
-	}
+	phase1Start := windows.Safe.StartMinute
+	phase1End := windows.Safe.EndMinute
 
 	h.publishProgress(ctx, job, 20, fmt.Sprintf("Phase 1: นาทีที่ %d-%d (หา super_safe)...", phase1Start+1, phase1End))
 	h.logger.Info("phase 1: extracting super_safe candidates",
@@ -432,12 +664,19 @@ func (h *GalleryHandler) ProcessJobWithClassificationLegacy(ctx context.Context,
 		timestampTracker, totalFrames,
 	)
 
+	if err := h.abortIfCancelled(ctx, job, baseDir, "phase 1 extract"); err != nil {
+		return err
+	}
+
 	if frameCount1 > 0 {
 		totalFrames += frameCount1
 
+		classifyStart := time.Now()
 		result1, err := nsfwClassifier.ClassifyBatch(ctx, allFramesDir)
+		classifierRuntime += time.Since(classifyStart)
 		if err != nil {
 			h.logger.Warn("phase 1 classification failed", "error", err)
+			classifyErr = err
 		} else {
 			h.logger.Info("phase 1 classification complete",
 				"total_images", result1.Stats.TotalImages,
@@ -446,6 +685,8 @@ func (h *GalleryHandler) ProcessJobWithClassificationLegacy(ctx context.Context,
 				"nsfw", result1.Stats.NsfwCount,
 			)
 
+			allScores = appendGalleryScores(allScores, result1.Results)
+
 			separated1 := nsfwClassifier.SeparateResults(result1.Results)
 			h.moveClassifiedFilesThreeTier(allFramesDir, superSafeDir, safeDir, nsfwDir, separated1)
 
@@ -466,20 +707,19 @@ func (h *GalleryHandler) ProcessJobWithClassificationLegacy(ctx context.Context,
 	}
 
 	// ═══════════════════════════════════════════════════════════════
-	// Phase 2: นาทีที่ 11-30 → หา nsfw (20 นาที = 200 frames, เลือก 20 ภาพ)
+	// Phase 2: NsfwWindow (default นาทีที่ 11-30) → หา nsfw
 	// ═══════════════════════════════════════════════════════════════
-	phase2Start := 10
-	phase2End := 30
-	if phase2Start >= videoDurationMin {
+	phase2Start := windows.Nsfw.StartMinute
+	phase2End := windows.Nsfw.EndMinute
+	if err := h.abortIfCancelled(ctx, job, baseDir, "phase 1 classify"); err != nil {
+		return err
+	}
+	if !windows.NsfwEnabled {
 		h.logger.Warn("video too short for phase 2, skipping nsfw extraction",
-			"video_duration_min", videoDurationMin,
+			"video_duration_sec", job.Duration,
 			"phase2_start_min", phase2Start,
 		)
 	} else {
-		if phase2End > videoDurationMin {
-			phase2End = videoDurationMin
-		}
-
 		h.publishProgress(ctx, job, 50, fmt.Sprintf("Phase 2: นาทีที่ %d-%d (หา nsfw)...", phase2Start+1, phase2End))
 		h.logger.Info("phase 2: extracting nsfw candidates",
 			"start_minute", phase2Start+1,
@@ -492,12 +732,19 @@ func (h *GalleryHandler) ProcessJobWithClassificationLegacy(ctx context.Context,
 			timestampTracker, totalFrames,
 		)
 
+		if err := h.abortIfCancelled(ctx, job, baseDir, "phase 2 extract"); err != nil {
+			return err
+		}
+
 		if frameCount2 > 0 {
 			totalFrames += frameCount2
 
+			classifyStart := time.Now()
 			result2, err := nsfwClassifier.ClassifyBatch(ctx, allFramesDir)
+			classifierRuntime += time.Since(classifyStart)
 			if err != nil {
 				h.logger.Warn("phase 2 classification failed", "error", err)
+				classifyErr = err
 			} else {
 				h.logger.Info("phase 2 classification complete",
 					"total_images", result2.Stats.TotalImages,
@@ -506,6 +753,8 @@ func (h *GalleryHandler) ProcessJobWithClassificationLegacy(ctx context.Context,
 					"nsfw", result2.Stats.NsfwCount,
 				)
 
+				allScores = appendGalleryScores(allScores, result2.Results)
+
 				separated2 := nsfwClassifier.SeparateResults(result2.Results)
 				h.moveClassifiedFilesThreeTier(allFramesDir, superSafeDir, safeDir, nsfwDir, separated2)
 
@@ -528,7 +777,33 @@ func (h *GalleryHandler) ProcessJobWithClassificationLegacy(ctx context.Context,
 		}
 	}
 
-	// 5. Limit NSFW and Safe images to top 10 by quality
+	if err := h.abortIfCancelled(ctx, job, baseDir, "phase 2 classify"); err != nil {
+		return err
+	}
+
+	// วิดีโอสั้นเกิน/ไม่มี segment ตรงกับ windows = ไม่มี gallery (เหมือน shared service ที่ข้ามวิดีโอสั้น)
+	if totalFrames == 0 {
+		h.logger.Info("gallery skipped (no frames extracted)",
+			"video_id", job.VideoID,
+			"video_code", job.VideoCode,
+			"duration", job.Duration,
+		)
+		h.publishCompleted(ctx, job)
+		return nil
+	}
+
+	// Classifier ล้มเหลว → ส่งทุก frame ให้ admin review แทน (ไม่ปล่อย gallery ว่าง)
+	if classifyErr != nil && h.config.ReviewOnClassifyFailure {
+		return h.fallbackToManualReview(ctx, job, baseDir, classifyErr)
+	}
+
+	// ไม่มี fallback และไม่มี frame ไหน classify ได้เลย = job ล้มเหลว (ไม่ตั้ง gallery ว่างเป็น ready)
+	if classifyErr != nil && len(allScores) == 0 {
+		h.publishFailed(ctx, job, classifyErr.Error())
+		return fmt.Errorf("classify frames: %w", classifyErr)
+	}
+
+	// 5. Limit NSFW and Safe images by quality (MaxNsfwImages / MaxSafeImages)
 	nsfwClassifier.SortByQuality(allNsfwResults)
 	if len(allNsfwResults) > classifierConfig.MaxNsfwImages {
 		// Delete excess NSFW files
@@ -538,7 +813,7 @@ func (h *GalleryHandler) ProcessJobWithClassificationLegacy(ctx context.Context,
 		allNsfwResults = allNsfwResults[:classifierConfig.MaxNsfwImages]
 	}
 
-	// Limit Safe images to top 10 by quality
+	// Limit Safe images by quality
 	nsfwClassifier.SortByQuality(allSafeResults)
 	if len(allSafeResults) > classifierConfig.MaxSafeImages {
 		// Delete excess Safe files
@@ -548,6 +823,24 @@ func (h *GalleryHandler) ProcessJobWithClassificationLegacy(ctx context.Context,
 		allSafeResults = allSafeResults[:classifierConfig.MaxSafeImages]
 	}
 
+	// TEST_MODE: Skip upload and DB update, keep files locally
+	if h.config.TestMode {
+		h.logger.Info("TEST MODE - skipping upload & DB update",
+			"video_code", job.VideoCode,
+			"base_dir", baseDir,
+			"total_frames", totalFrames,
+			"super_safe_count", len(allSuperSafeResults),
+			"safe_count", len(allSafeResults),
+			"nsfw_count", len(allNsfwResults),
+		)
+		h.publishCompleted(ctx, job)
+		return nil
+	}
+
+	if err := h.abortIfCancelled(ctx, job, baseDir, "before upload"); err != nil {
+		return err
+	}
+
 	h.publishProgress(ctx, job, 85, "กำลังอัพโหลดภาพ...")
 
 	// 6. Upload super_safe, safe, and nsfw folders (Three-Tier)
@@ -566,6 +859,11 @@ func (h *GalleryHandler) ProcessJobWithClassificationLegacy(ctx context.Context,
 		h.logger.Warn("failed to upload nsfw images", "error", err)
 	}
 
+	// upload ถูกตัดกลางทาง = ไม่ update DB ด้วย counts ที่ไม่ครบ
+	if err := h.abortIfCancelled(ctx, job, baseDir, "upload"); err != nil {
+		return err
+	}
+
 	h.logger.Info("three-tier gallery uploaded",
 		"video_code", job.VideoCode,
 		"super_safe_uploaded", superSafeUploaded,
@@ -609,6 +907,15 @@ func (h *GalleryHandler) ProcessJobWithClassificationLegacy(ctx context.Context,
 
 	// Publish completed
 	h.publishCompleted(ctx, job)
+	h.recordProcessingCost(ctx, job, totalFrames, classifierRuntime, time.Since(startedAt))
+	h.recordGalleryScores(ctx, job, allScores)
+
+	// thumbnail ใช้ playlist ชุดเดียวกัน - ล้มเหลวไม่ทำให้ gallery job fail
+	if h.config.ThumbnailWithGallery {
+		if _, err := h.GenerateThumbnail(ctx, job.VideoID, 0); err != nil {
+			h.logger.Warn("failed to generate thumbnail after gallery", "video_id", job.VideoID, "error", err)
+		}
+	}
 
 	h.logger.Info("classified gallery job completed (three-tier)",
 		"video_id", job.VideoID,
@@ -616,6 +923,82 @@ func (h *GalleryHandler) ProcessJobWithClassificationLegacy(ctx context.Context,
 		"super_safe_images", superSafeUploaded,
 		"safe_images", safeUploaded,
 		"nsfw_images", nsfwUploaded,
+		"classifier_runtime", classifierRuntime,
+	)
+
+	return nil
+}
+
+// threeTierClassifierConfig classifier config ของ Three-Tier flow (limits จาก TierLimits)
+// Verbose mode เปิดตลอดเพื่อ debug ปัญหา super_safe images
+func (h *GalleryHandler) threeTierClassifierConfig() classifier.ClassifierConfig {
+	limits := h.config.TierLimits
+	return classifier.ClassifierConfig{
+		PythonPath:         h.config.Classifier.PythonPath,
+		ScriptPath:         h.config.Classifier.ScriptPath,
+		Device:             h.config.Classifier.Device,
+		NsfwThreshold:      0.3,
+		SuperSafeThreshold: 0.15,
+		MinFaceScore:       0.1,
+		Timeout:            300, // 5 minutes for POV + Mosaic detection
+		MaxNsfwImages:      limits.MaxNsfwImages,
+		MaxSafeImages:      limits.MaxSafeImages,
+		MinSafeImages:      limits.MinSafeImages,
+		MinSuperSafeImages: limits.MinSuperSafeImages,
+		Verbose:            true,                          // Enable detailed per-image logging
+		SkipMosaic:         true,                          // Skip slow mosaic detection (temporarily)
+		SkipPOV:            true,                          // Skip slow POV detection (temporarily)
+		BatchSize:          h.config.Classifier.BatchSize, // < 0 = ไม่แบ่ง batch
+		Concurrency:        h.config.Classifier.Concurrency,
+	}
+}
+
+// fallbackToManualReview upload ทุก frame ที่ดึงได้ไป source/ แล้วตั้ง pending_review
+// ใช้เมื่อ classifier timeout/crash - frames ที่ classify ไม่ได้ถือว่ายังไม่ผ่านการตรวจ
+// จึงต้องให้ admin เลือกเองผ่าน Manual Selection Flow
+func (h *GalleryHandler) fallbackToManualReview(ctx context.Context, job *models.GalleryJob, baseDir string, classifyErr error) error {
+	h.logger.Warn("classifier failed, falling back to manual review",
+		"video_code", job.VideoCode,
+		"error", classifyErr,
+	)
+
+	h.publishProgress(ctx, job, 85, "Classifier ล้มเหลว - อัพโหลดภาพให้ admin เลือก...")
+
+	// frames ที่ classify แล้วบางส่วนถูกย้ายไป tier dirs แล้ว → รวมทุก dir (ชื่อไฟล์ไม่ซ้ำกันเพราะใช้ filenameOffset)
+	sourceCount := 0
+	for _, dir := range []string{"all", "super_safe", "safe", "nsfw"} {
+		uploaded, err := h.uploadGalleryImages(ctx, filepath.Join(baseDir, dir), job.OutputPath+"/source", job.VideoCode)
+		if err != nil {
+			h.logger.Warn("failed to upload frames for manual review", "dir", dir, "error", err)
+		}
+		sourceCount += uploaded
+	}
+
+	if err := h.abortIfCancelled(ctx, job, baseDir, "fallback upload"); err != nil {
+		return err
+	}
+
+	// ไม่มีภาพให้ admin เลือก = ไม่ตั้ง pending_review (gallery ว่าง) → fail ให้ retry ได้
+	if sourceCount == 0 {
+		h.publishFailed(ctx, job, fmt.Sprintf("classifier failed and no frames uploaded for review: %v", classifyErr))
+		return fmt.Errorf("manual review fallback: no frames uploaded: %w", classifyErr)
+	}
+
+	h.publishProgress(ctx, job, 95, "กำลังบันทึกข้อมูล...")
+
+	if err := h.updateVideoGalleryManualSelection(ctx, job.VideoID, job.OutputPath, sourceCount); err != nil {
+		h.logger.Warn("failed to update gallery in DB",
+			"video_id", job.VideoID,
+			"error", err,
+		)
+	}
+
+	h.publishCompleted(ctx, job)
+
+	h.logger.Info("gallery job completed via manual review fallback",
+		"video_id", job.VideoID,
+		"video_code", job.VideoCode,
+		"source_count", sourceCount,
 	)
 
 	return nil
@@ -674,20 +1057,11 @@ func (h *GalleryHandler) extractRoundFramesFromHLS(
 			continue
 		}
 
-		// Get presigned URL
-		segmentPath := filepath.Dir(job.HLSPath) + "/" + segment.filename
-		segmentPath = strings.ReplaceAll(segmentPath, "\\", "/")
-
-		presignedURL, err := h.storage.GetPresignedURL(ctx, segmentPath, 5*time.Minute)
-		if err != nil {
-			continue
-		}
-
-		// Capture frame
+		// Capture frame (presign segment + init segment ถ้าเป็น fMP4, retry ด้วย URL ใหม่ถ้า timeout)
 		frameNum := filenameOffset + extracted + 1
 		outputPath := filepath.Join(outputDir, fmt.Sprintf("%03d.jpg", frameNum))
 
-		if err := h.captureFrameFromSegment(ctx, presignedURL, outputPath, timestamp-segment.startTime); err != nil {
+		if err := h.captureSegmentFrame(ctx, job.HLSPath, segment, outputPath, timestamp-segment.startTime); err != nil {
 			continue
 		}
 
@@ -705,7 +1079,7 @@ func (h *GalleryHandler) moveClassifiedFilesThreeTier(srcDir, superSafeDir, safe
 	// Move super_safe files (< 0.15 + face) - สำหรับ Public SEO
 	for _, img := range separated.SuperSafe {
 		src := filepath.Join(srcDir, img.Filename)
-		dst := filepath.Join(superSafeDir, img.Filename)
+		dst := filepath.Join(superSafeDir, galleryTierFilename(h.config.Naming, "super_safe", img.Filename))
 		if err := os.Rename(src, dst); err != nil {
 			h.logger.Warn("failed to move super_safe image", "file", img.Filename, "error", err)
 		}
@@ -714,7 +1088,7 @@ func (h *GalleryHandler) moveClassifiedFilesThreeTier(srcDir, superSafeDir, safe
 	// Move safe files (0.15-0.3) - Lazy load
 	for _, img := range separated.Safe {
 		src := filepath.Join(srcDir, img.Filename)
-		dst := filepath.Join(safeDir, img.Filename)
+		dst := filepath.Join(safeDir, galleryTierFilename(h.config.Naming, "safe", img.Filename))
 		if err := os.Rename(src, dst); err != nil {
 			h.logger.Warn("failed to move safe image", "file", img.Filename, "error", err)
 		}
@@ -723,7 +1097,7 @@ func (h *GalleryHandler) moveClassifiedFilesThreeTier(srcDir, superSafeDir, safe
 	// Move nsfw files (>= 0.3) - Member only
 	for _, img := range separated.Nsfw {
 		src := filepath.Join(srcDir, img.Filename)
-		dst := filepath.Join(nsfwDir, img.Filename)
+		dst := filepath.Join(nsfwDir, galleryTierFilename(h.config.Naming, "nsfw", img.Filename))
 		if err := os.Rename(src, dst); err != nil {
 			h.logger.Warn("failed to move nsfw image", "file", img.Filename, "error", err)
 		}
@@ -732,7 +1106,7 @@ func (h *GalleryHandler) moveClassifiedFilesThreeTier(srcDir, superSafeDir, safe
 	// Move error files to nsfw (safety first)
 	for _, img := range separated.Error {
 		src := filepath.Join(srcDir, img.Filename)
-		dst := filepath.Join(nsfwDir, img.Filename)
+		dst := filepath.Join(nsfwDir, galleryTierFilename(h.config.Naming, "nsfw", img.Filename))
 		if err := os.Rename(src, dst); err != nil {
 			h.logger.Warn("failed to move error image", "file", img.Filename, "error", err)
 		}
@@ -754,6 +1128,10 @@ func (h *GalleryHandler) extractTimeBasedFrames(
 	extracted := 0
 	secondsPerFrame := 60 / framesPerMinute // 6 seconds per frame for 10 frames/minute
 
+	// ข้ามช่วงต้น/ท้ายตาม FrameSkip (intro, โลโก้, credits) - ใช้กับ phase windows ด้วย
+	skipStart, skipEnd := h.config.FrameSkip.window(job.Duration)
+	skipped := 0
+
 	for minute := startMinute; minute < endMinute; minute++ {
 		for frameInMinute := 0; frameInMinute < framesPerMinute; frameInMinute++ {
 			select {
@@ -771,8 +1149,9 @@ func (h *GalleryHandler) extractTimeBasedFrames(
 				continue
 			}
 
-			// Check if timestamp is within video duration
-			if sec >= job.Duration {
+			// Check if timestamp is within the usable part of the video
+			if timestamp < skipStart || timestamp >= skipEnd {
+				skipped++
 				continue
 			}
 
@@ -782,20 +1161,11 @@ func (h *GalleryHandler) extractTimeBasedFrames(
 				continue
 			}
 
-			// Get presigned URL
-			segmentPath := filepath.Dir(job.HLSPath) + "/" + segment.filename
-			segmentPath = strings.ReplaceAll(segmentPath, "\\", "/")
-
-			presignedURL, err := h.storage.GetPresignedURL(ctx, segmentPath, 5*time.Minute)
-			if err != nil {
-				continue
-			}
-
-			// Capture frame
+			// Capture frame (presign segment + init segment ถ้าเป็น fMP4, retry ด้วย URL ใหม่ถ้า timeout)
 			frameNum := filenameOffset + extracted + 1
 			outputPath := filepath.Join(outputDir, fmt.Sprintf("%03d.jpg", frameNum))
 
-			if err := h.captureFrameFromSegment(ctx, presignedURL, outputPath, timestamp-segment.startTime); err != nil {
+			if err := h.captureSegmentFrame(ctx, job.HLSPath, segment, outputPath, timestamp-segment.startTime); err != nil {
 				continue
 			}
 
@@ -810,6 +1180,7 @@ func (h *GalleryHandler) extractTimeBasedFrames(
 		"start_minute", startMinute+1,
 		"end_minute", endMinute,
 		"frames_extracted", extracted,
+		"frames_skipped_intro_outro", skipped,
 	)
 
 	return extracted
@@ -950,6 +1321,8 @@ func (h *GalleryHandler) updateVideoGalleryClassifiedThreeTier(ctx context.Conte
 		"gallery_safe_count":       safeCount,        // borderline (0.15-0.3)
 		"gallery_nsfw_count":       nsfwCount,        // nsfw (>= 0.3)
 	}
+	// บันทึก limits ที่ใช้ได้ counts ชุดนี้ (เทียบย้อนหลังเมื่อเปลี่ยน config)
+	payload["gallery_limits"] = h.config.TierLimits.toPayload()
 
 	data, err := json.Marshal(payload)
 	if err != nil {
@@ -991,6 +1364,7 @@ func (h *GalleryHandler) publishProgress(ctx context.Context, job *models.Galler
 	if h.messenger != nil {
 		h.messenger.PublishGalleryProgress(ctx, job.VideoID, job.VideoCode, progress, message)
 	}
+	h.recordJobEvent(ctx, job, "processing", progress, message)
 }
 
 // publishCompleted ส่ง completion status
@@ -998,6 +1372,48 @@ func (h *GalleryHandler) publishCompleted(ctx context.Context, job *models.Galle
 	if h.messenger != nil {
 		h.messenger.PublishGalleryCompleted(ctx, job.VideoID, job.VideoCode)
 	}
+	h.recordJobEvent(ctx, job, "completed", 100, "")
+}
+
+// CheckClassifier รัน classifier selftest ด้วย python/script/device เดียวกับที่ใช้ตอน classify จริง
+func (h *GalleryHandler) CheckClassifier(ctx context.Context) error {
+	cfg := classifier.DefaultConfig()
+	cfg.PythonPath = h.config.Classifier.PythonPath
+	cfg.ScriptPath = h.config.Classifier.ScriptPath
+	cfg.Device = h.config.Classifier.Device
+
+	_, err := classifier.NewNSFWClassifier(cfg, h.logger).HealthCheck(ctx)
+	return err
+}
+
+// abortIfCancelled หยุด job ถ้า ctx ถูก cancel (worker shutdown / job ถูกยกเลิก)
+// ลบ temp dir ทิ้งเพื่อไม่ให้ไฟล์ที่ทำไม่เสร็จค้างอยู่ (TEST_MODE เก็บไว้ debug)
+func (h *GalleryHandler) abortIfCancelled(ctx context.Context, job *models.GalleryJob, tempDir, stage string) error {
+	if ctx.Err() == nil {
+		return nil
+	}
+
+	h.logger.Warn("gallery job cancelled",
+		"video_code", job.VideoCode,
+		"stage", stage,
+		"error", context.Cause(ctx),
+	)
+
+	if tempDir != "" && !h.config.TestMode {
+		if err := os.RemoveAll(tempDir); err != nil {
+			h.logger.Warn("failed to cleanup cancelled gallery temp dir", "dir", tempDir, "error", err)
+		}
+	}
+
+	// admin ยกเลิก → status cancelled (ไม่ใช่ failed)
+	if isJobCancelled(ctx) {
+		h.markGalleryCancelled(context.Background(), job, stage)
+		return fmt.Errorf("gallery cancelled at %s: %w", stage, ports.ErrJobCancelled)
+	}
+
+	// ctx ถูก cancel แล้ว → ใช้ Background เพื่อให้ส่ง failed status ได้
+	h.publishFailed(context.Background(), job, fmt.Sprintf("ยกเลิกระหว่าง %s", stage))
+	return fmt.Errorf("gallery cancelled at %s: %w", stage, ctx.Err())
 }
 
 // publishFailed ส่ง failure status
@@ -1005,13 +1421,94 @@ func (h *GalleryHandler) publishFailed(ctx context.Context, job *models.GalleryJ
 	if h.messenger != nil {
 		h.messenger.PublishGalleryFailed(ctx, job.VideoID, job.VideoCode, errMsg)
 	}
+	h.recordJobEvent(ctx, job, "failed", 0, errMsg)
+}
+
+// recordJobEvent บันทึก event ลง job_events (ไม่ critical - log warning ถ้า fail)
+func (h *GalleryHandler) recordJobEvent(ctx context.Context, job *models.GalleryJob, stage string, progress float64, message string) {
+	if h.repository == nil {
+		return
+	}
+	event := &ports.JobEvent{
+		VideoID:  job.VideoID,
+		Source:   "gallery",
+		Stage:    stage,
+		Progress: progress,
+		Message:  message,
+	}
+	if err := h.repository.RecordJobEvent(ctx, event); err != nil {
+		h.logger.Warn("failed to record job event", "video_id", job.VideoID, "stage", stage, "error", err)
+	}
+}
+
+// recordProcessingCost บันทึก compute ที่ใช้ลง processing_costs (ไม่ critical - log warning ถ้า fail)
+func (h *GalleryHandler) recordProcessingCost(ctx context.Context, job *models.GalleryJob, frameCount int, classifierRuntime, jobDuration time.Duration) {
+	if h.repository == nil || h.config.TestMode {
+		return
+	}
+	cost := &ports.ProcessingCost{
+		VideoID:           job.VideoID,
+		Source:            "gallery",
+		FrameCount:        frameCount,
+		ClassifierRuntime: classifierRuntime,
+		JobDuration:       jobDuration,
+	}
+	if err := h.repository.RecordProcessingCost(ctx, cost); err != nil {
+		h.logger.Warn("failed to record processing cost", "video_id", job.VideoID, "error", err)
+	}
+}
+
+// recordGalleryScores บันทึกผล classify รายภาพลง gallery_image_scores (ไม่ critical - log warning ถ้า fail)
+func (h *GalleryHandler) recordGalleryScores(ctx context.Context, job *models.GalleryJob, scores []ports.GalleryImageScore) {
+	if h.repository == nil || h.config.TestMode || len(scores) == 0 {
+		return
+	}
+	if err := h.repository.ReplaceGalleryScores(ctx, job.VideoID, scores); err != nil {
+		h.logger.Warn("failed to record gallery scores", "video_id", job.VideoID, "count", len(scores), "error", err)
+	}
+}
+
+// appendGalleryScores แปลงผล classify ทั้ง batch (รวมภาพที่จะถูกทิ้ง) เป็น scores สำหรับ stats
+func appendGalleryScores(scores []ports.GalleryImageScore, results map[string]classifier.ClassificationResult) []ports.GalleryImageScore {
+	for _, r := range results {
+		// tier เดียวกับ SeparateResults
+		tier := "nsfw"
+		switch {
+		case r.Error != "":
+			tier = "error"
+		case r.IsSuperSafe:
+			tier = "super_safe"
+		case r.IsSafe:
+			tier = "safe"
+		}
+		scores = append(scores, ports.GalleryImageScore{
+			Filename:       r.Filename,
+			Tier:           tier,
+			NsfwScore:      r.NsfwScore,
+			FaceScore:      r.FaceScore,
+			AestheticScore: r.AestheticScore,
+			MosaicDetected: r.MosaicDetected,
+			POVDetected:    r.POVDetected,
+		})
+	}
+	return scores
 }
 
 // hlsSegment represents an HLS segment with timing info
 type hlsSegment struct {
-	filename  string
-	duration  float64
-	startTime float64 // cumulative start time
+	filename      string
+	duration      float64
+	startTime     float64 // cumulative start time (playback timeline)
+	discontinuity bool    // segment แรกหลัง #EXT-X-DISCONTINUITY
+	initSegment   string  // URI จาก #EXT-X-MAP ที่มีผลกับ segment นี้ (fMP4/CMAF), "" = MPEG-TS
+}
+
+// hlsPlaylistType ประเภท segment ของ playlist (สำหรับ log)
+func hlsPlaylistType(segments []hlsSegment) string {
+	if len(segments) > 0 && segments[0].initSegment != "" {
+		return "fmp4"
+	}
+	return "mpegts"
 }
 
 // GalleryProgressCallback callback สำหรับ report progress
@@ -1039,13 +1536,13 @@ func (h *GalleryHandler) extractFramesFromHLS(ctx context.Context, job *models.G
 
 	h.logger.Info("parsed HLS playlist",
 		"segments", len(segments),
+		"type", hlsPlaylistType(segments),
 		"total_duration", segments[len(segments)-1].startTime+segments[len(segments)-1].duration,
 	)
 
 	// Calculate frame interval
-	// ข้าม 5% แรกและ 5% หลัง
-	startTime := float64(duration) * 0.05
-	endTime := float64(duration) * 0.95
+	// ข้ามช่วงต้น/ท้ายตาม FrameSkip (intro, โลโก้, credits)
+	startTime, endTime := h.config.FrameSkip.window(duration)
 	usableDuration := endTime - startTime
 	interval := usableDuration / float64(imageCount)
 
@@ -1077,26 +1574,14 @@ func (h *GalleryHandler) extractFramesFromHLS(ctx context.Context, job *models.G
 			continue
 		}
 
-		// Get presigned URL for this segment
-		segmentPath := filepath.Dir(hlsPath) + "/" + segment.filename
-		segmentPath = strings.ReplaceAll(segmentPath, "\\", "/")
-
-		presignedURL, err := h.storage.GetPresignedURL(ctx, segmentPath, 5*time.Minute)
-		if err != nil {
-			h.logger.Warn("failed to get presigned URL",
-				"segment", segmentPath,
-				"error", err,
-			)
-			continue
-		}
-
 		// Calculate seek time within segment
 		seekInSegment := timestamp - segment.startTime
 		if seekInSegment < 0 {
 			seekInSegment = 0
 		}
 
-		if err := h.captureFrameFromSegment(ctx, presignedURL, outputPath, seekInSegment); err != nil {
+		// presign segment (+ init segment ถ้าเป็น fMP4) แล้ว capture - retry ด้วย URL ใหม่ถ้า timeout
+		if err := h.captureSegmentFrame(ctx, hlsPath, segment, outputPath, seekInSegment); err != nil {
 			h.logger.Warn("failed to capture frame",
 				"frame", i+1,
 				"timestamp", timestamp,
@@ -1139,11 +1624,28 @@ func (h *GalleryHandler) parseHLSPlaylist(ctx context.Context, hlsPath string) (
 	var segments []hlsSegment
 	var currentDuration float64
 	var cumulativeTime float64
+	var discontinuity bool
+	var initSegment string
 
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
 
+		// Discontinuity: timestamp ภายใน media เริ่มใหม่ แต่ playback timeline ยังต่อเนื่อง
+		// (timestamp ของ gallery คิดจาก playback timeline) → cumulativeTime เดินต่อ
+		// แต่ล้าง EXTINF ที่ค้างอยู่ก่อน marker ไม่ให้ติดไปกับ segment ถัดไป
+		if line == "#EXT-X-DISCONTINUITY" {
+			discontinuity = true
+			currentDuration = 0
+			continue
+		}
+
+		// fMP4/CMAF: #EXT-X-MAP:URI="init.mp4" - มีผลกับทุก segment ถัดไปจนกว่าจะเจอ MAP ใหม่
+		if strings.HasPrefix(line, "#EXT-X-MAP:") {
+			initSegment = parseHLSAttribute(strings.TrimPrefix(line, "#EXT-X-MAP:"), "URI")
+			continue
+		}
+
 		// Parse EXTINF duration
 		if strings.HasPrefix(line, "#EXTINF:") {
 			// Format: #EXTINF:2.000000,
@@ -1158,12 +1660,15 @@ func (h *GalleryHandler) parseHLSPlaylist(ctx context.Context, hlsPath string) (
 		} else if !strings.HasPrefix(line, "#") && line != "" {
 			// This is a segment filename
 			segments = append(segments, hlsSegment{
-				filename:  line,
-				duration:  currentDuration,
-				startTime: cumulativeTime,
+				filename:      line,
+				duration:      currentDuration,
+				startTime:     cumulativeTime,
+				discontinuity: discontinuity,
+				initSegment:   initSegment,
 			})
 			cumulativeTime += currentDuration
 			currentDuration = 0
+			discontinuity = false
 		}
 	}
 
@@ -1174,7 +1679,66 @@ func (h *GalleryHandler) parseHLSPlaylist(ctx context.Context, hlsPath string) (
 	return segments, nil
 }
 
+// parseHLSAttribute ดึงค่า attribute จาก attribute list ของ HLS tag (เช่น URI="init.mp4",BYTERANGE="720@0")
+func parseHLSAttribute(attrs, name string) string {
+	for len(attrs) > 0 {
+		eq := strings.IndexByte(attrs, '=')
+		if eq < 0 {
+			return ""
+		}
+		key := strings.TrimSpace(attrs[:eq])
+		rest := attrs[eq+1:]
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				return ""
+			}
+			value = rest[1 : end+1]
+			rest = rest[end+2:]
+		} else if comma := strings.IndexByte(rest, ','); comma >= 0 {
+			value = rest[:comma]
+			rest = rest[comma:]
+		} else {
+			value = rest
+			rest = ""
+		}
+
+		if key == name {
+			return value
+		}
+		attrs = strings.TrimPrefix(rest, ",")
+	}
+	return ""
+}
+
+// presignSegmentURLs สร้าง presigned URL ของ segment (และ init segment ถ้าเป็น fMP4)
+// path ของ segment/init เป็น relative กับ playlist
+func (h *GalleryHandler) presignSegmentURLs(ctx context.Context, hlsPath string, segment *hlsSegment) (string, string, error) {
+	baseDir := filepath.Dir(hlsPath)
+
+	segmentPath := strings.ReplaceAll(baseDir+"/"+segment.filename, "\\", "/")
+	segmentURL, err := h.storage.GetPresignedURL(ctx, segmentPath, 5*time.Minute)
+	if err != nil {
+		return "", "", err
+	}
+
+	if segment.initSegment == "" {
+		return segmentURL, "", nil
+	}
+
+	initPath := strings.ReplaceAll(baseDir+"/"+segment.initSegment, "\\", "/")
+	initURL, err := h.storage.GetPresignedURL(ctx, initPath, 5*time.Minute)
+	if err != nil {
+		return "", "", fmt.Errorf("init segment: %w", err)
+	}
+	return segmentURL, initURL, nil
+}
+
 // findSegmentForTimestamp finds the segment that contains the given timestamp
+// timestamp เกินความยาว playlist (เกิน SegmentEndTolerance) = nil → caller ข้าม frame
+// (เดิมคืน segment สุดท้ายเสมอ ทำให้ได้ภาพซ้ำ/ภาพเสียช่วงท้ายวิดีโอ)
 func (h *GalleryHandler) findSegmentForTimestamp(segments []hlsSegment, timestamp float64) *hlsSegment {
 	for i := range segments {
 		seg := &segments[i]
@@ -1183,44 +1747,58 @@ func (h *GalleryHandler) findSegmentForTimestamp(segments []hlsSegment, timestam
 		}
 	}
 
-	// If timestamp is beyond all segments, return the last one
-	if len(segments) > 0 && timestamp >= segments[len(segments)-1].startTime {
-		return &segments[len(segments)-1]
+	// เกินท้าย playlist เล็กน้อย (EXTINF ปัดเศษ) → ยังใช้ segment สุดท้ายได้
+	if len(segments) > 0 {
+		last := &segments[len(segments)-1]
+		end := last.startTime + last.duration
+		if timestamp >= end && timestamp < end+h.config.SegmentEndTolerance.Seconds() {
+			return last
+		}
 	}
 
 	return nil
 }
 
 // captureFrameFromSegment captures a frame from a single segment using presigned URL
-func (h *GalleryHandler) captureFrameFromSegment(ctx context.Context, segmentURL, outputPath string, seekTime float64) error {
+// initURL (fMP4/CMAF) ถูกต่อหน้า segment ด้วย concat protocol - media segment ของ fMP4
+// ไม่มี moov box ถอดรหัสเองไม่ได้, "" = MPEG-TS segment ที่ decode ได้ด้วยตัวเอง
+func (h *GalleryHandler) captureFrameFromSegment(ctx context.Context, segmentURL, initURL, outputPath string, seekTime float64) error {
 	// Always extract first frame (no seeking) - segment selection already gives us the right time
 	// Seeking within HLS segments is unreliable due to timestamp discontinuities
 	_ = seekTime // unused, we always use first frame
 
+	input := segmentURL
+	if initURL != "" {
+		input = "concat:" + initURL + "|" + segmentURL
+	}
+
 	// FFmpeg command: extract first frame from segment
 	args := []string{
-		"-i", segmentURL,
+		"-i", input,
 		"-frames:v", "1",
 		"-vf", "scale=1280:720:force_original_aspect_ratio=decrease,pad=1280:720:(ow-iw)/2:(oh-ih)/2",
 		"-q:v", "2", // High quality JPEG
-		"-y",        // Overwrite
+		"-y", // Overwrite
 		outputPath,
 	}
 
-	cmdCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	cmdCtx, cancel := context.WithTimeout(ctx, h.config.FrameCaptureTimeout)
 	defer cancel()
 
 	cmd := exec.CommandContext(cmdCtx, "ffmpeg", args...)
 	output, err := cmd.CombinedOutput()
 
 	if err != nil {
+		// timeout ของ ffmpeg เอง (ไม่ใช่ job ถูกยกเลิก) → caller retry ได้
+		if ctx.Err() == nil && cmdCtx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("%w after %s", errFrameCaptureTimeout, h.config.FrameCaptureTimeout)
+		}
 		return fmt.Errorf("ffmpeg: %w, output: %s", err, string(output))
 	}
 
 	return nil
 }
 
-
 // uploadGalleryImages uploads all images in directory to S3
 func (h *GalleryHandler) uploadGalleryImages(ctx context.Context, localDir, remotePrefix, videoCode string) (int, error) {
 	uploadedCount := 0
@@ -1231,6 +1809,11 @@ func (h *GalleryHandler) uploadGalleryImages(ctx context.Context, localDir, remo
 			return err
 		}
 
+		// หยุดทันทีถ้า job ถูกยกเลิก (ไม่ต้อง upload ที่เหลือ)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+
 		if info.IsDir() {
 			return nil
 		}
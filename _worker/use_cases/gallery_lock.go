@@ -0,0 +1,30 @@
+package use_cases
+
+import (
+	"context"
+	"errors"
+
+	"suekk-worker/domain/models"
+	"suekk-worker/ports"
+)
+
+// SetGalleryLock เปิดใช้ lock ต่อวิดีโอ (ไม่ตั้ง = job ของวิดีโอเดียวกันทำซ้อนกันได้)
+func (h *GalleryHandler) SetGalleryLock(lock ports.GalleryLockPort) {
+	h.galleryLock = lock
+}
+
+// acquireGalleryLock จอง lock ของวิดีโอก่อนเริ่มงาน - ports.ErrGalleryLocked = มี job อื่นทำอยู่
+// job ซ้ำนี้ถูก ack ทิ้ง (NAK ทันทีจะวน redeliver จนหมด MaxDeliver ระหว่างที่ job แรกยังทำไม่เสร็จ)
+func (h *GalleryHandler) acquireGalleryLock(ctx context.Context, job *models.GalleryJob) (func(), error) {
+	if h.galleryLock == nil {
+		return func() {}, nil
+	}
+	release, err := h.galleryLock.Acquire(ctx, job.VideoCode)
+	if errors.Is(err, ports.ErrGalleryLocked) {
+		h.logger.Warn("gallery already being generated - dropping duplicate job",
+			"video_id", job.VideoID,
+			"video_code", job.VideoCode,
+		)
+	}
+	return release, err
+}
@@ -0,0 +1,40 @@
+package use_cases
+
+import "fmt"
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Gallery Naming - ชื่อไฟล์ภาพใน tier dirs (super_safe/safe/nsfw)
+// sequential (default): เลขเฟรมต่อเนื่องทั้ง job (filenameOffset ข้าม round) → 001.jpg, 002.jpg, ...
+// tier_prefix: เติม prefix ตาม tier บนเลขเดียวกัน → ss_001.jpg, sf_014.jpg, ns_007.jpg
+// ทั้งสองแบบไม่ซ้ำกันข้าม tier - รวม list ทุก tier (SEO worker, admin review) ได้โดยไม่ชน
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// Gallery naming modes
+const (
+	GalleryNamingSequential = "sequential"
+	GalleryNamingTierPrefix = "tier_prefix"
+)
+
+// galleryTierPrefixes prefix ของแต่ละ tier (ใช้เมื่อ naming = tier_prefix)
+var galleryTierPrefixes = map[string]string{
+	"super_safe": "ss_",
+	"safe":       "sf_",
+	"nsfw":       "ns_",
+}
+
+// ValidateGalleryNaming ตรวจ mode ("" = sequential)
+func ValidateGalleryNaming(naming string) error {
+	switch naming {
+	case "", GalleryNamingSequential, GalleryNamingTierPrefix:
+		return nil
+	}
+	return fmt.Errorf("unknown gallery naming %q (want %s or %s)", naming, GalleryNamingSequential, GalleryNamingTierPrefix)
+}
+
+// galleryTierFilename ชื่อไฟล์ปลายทางเมื่อย้ายเฟรมเข้า tier dir
+func galleryTierFilename(naming, tier, filename string) string {
+	if naming != GalleryNamingTierPrefix {
+		return filename
+	}
+	return galleryTierPrefixes[tier] + filename
+}
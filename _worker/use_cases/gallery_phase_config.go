@@ -0,0 +1,108 @@
+package use_cases
+
+import "fmt"
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Gallery Phase Config - ช่วงเวลาที่ใช้ดึงภาพแต่ละ tier (Two-Phase Extraction)
+// Default: Phase 1 นาทีที่ 1-10 (super_safe/safe), Phase 2 นาทีที่ 11-30 (nsfw)
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// GalleryPhaseWindow ช่วงนาทีของ phase [StartMinute, EndMinute)
+type GalleryPhaseWindow struct {
+	StartMinute int
+	EndMinute   int
+}
+
+// GalleryPhaseConfig ตั้งค่า phase windows และ frames-per-minute
+// (จำนวนภาพต่อ tier อยู่ใน GalleryTierLimits)
+type GalleryPhaseConfig struct {
+	SafeWindow      GalleryPhaseWindow // Phase 1: หา super_safe + safe
+	NsfwWindow      GalleryPhaseWindow // Phase 2: หา nsfw
+	FramesPerMinute int                // จำนวน frames ต่อนาที (1-60)
+}
+
+// DefaultGalleryPhaseConfig ค่า default (ตรงกับโครงสร้างทั่วไป: intro แล้วค่อยเข้าเนื้อหา)
+func DefaultGalleryPhaseConfig() GalleryPhaseConfig {
+	return GalleryPhaseConfig{
+		SafeWindow:      GalleryPhaseWindow{StartMinute: 0, EndMinute: 10},
+		NsfwWindow:      GalleryPhaseWindow{StartMinute: 10, EndMinute: 30},
+		FramesPerMinute: 10,
+	}
+}
+
+// withDefaults เติมค่า default ให้ field ที่ไม่ได้ตั้ง (zero value)
+func (c GalleryPhaseConfig) withDefaults() GalleryPhaseConfig {
+	def := DefaultGalleryPhaseConfig()
+	if c.SafeWindow == (GalleryPhaseWindow{}) {
+		c.SafeWindow = def.SafeWindow
+	}
+	if c.NsfwWindow == (GalleryPhaseWindow{}) {
+		c.NsfwWindow = def.NsfwWindow
+	}
+	if c.FramesPerMinute == 0 {
+		c.FramesPerMinute = def.FramesPerMinute
+	}
+	return c
+}
+
+// Validate ตรวจสอบค่า config (ไม่ขึ้นกับความยาววิดีโอ)
+func (c GalleryPhaseConfig) Validate() error {
+	windows := []struct {
+		name   string
+		window GalleryPhaseWindow
+	}{
+		{"safe", c.SafeWindow},
+		{"nsfw", c.NsfwWindow},
+	}
+	for _, w := range windows {
+		if w.window.StartMinute < 0 {
+			return fmt.Errorf("%s window start must be >= 0, got %d", w.name, w.window.StartMinute)
+		}
+		if w.window.EndMinute <= w.window.StartMinute {
+			return fmt.Errorf("%s window end (%d) must be greater than start (%d)", w.name, w.window.EndMinute, w.window.StartMinute)
+		}
+	}
+	if c.FramesPerMinute < 1 || c.FramesPerMinute > 60 {
+		return fmt.Errorf("frames per minute must be between 1 and 60, got %d", c.FramesPerMinute)
+	}
+	return nil
+}
+
+// resolvedPhaseWindows phase windows หลังปรับตามความยาววิดีโอ
+type resolvedPhaseWindows struct {
+	Safe        GalleryPhaseWindow
+	Nsfw        GalleryPhaseWindow
+	NsfwEnabled bool     // false ถ้าวิดีโอสั้นกว่าจุดเริ่ม phase 2
+	Adjustments []string // รายการที่ถูกปรับ (สำหรับ log)
+}
+
+// resolveForDuration ปรับ windows ให้อยู่ในความยาววิดีโอ (วินาที)
+// - ตัด EndMinute ไม่ให้เกินความยาววิดีโอ
+// - ถ้าวิดีโอสั้นกว่าจุดเริ่ม safe window → ใช้ทั้งวิดีโอแทน (ต้องมีภาพ public เสมอ)
+// - ถ้าวิดีโอสั้นกว่าจุดเริ่ม nsfw window → ข้าม phase 2
+func (c GalleryPhaseConfig) resolveForDuration(durationSec int) resolvedPhaseWindows {
+	durationMin := (durationSec + 59) / 60 // ปัดขึ้น เพื่อรวมนาทีสุดท้ายที่ไม่เต็ม
+	r := resolvedPhaseWindows{
+		Safe:        c.SafeWindow,
+		Nsfw:        c.NsfwWindow,
+		NsfwEnabled: true,
+	}
+
+	if r.Safe.StartMinute >= durationMin {
+		r.Adjustments = append(r.Adjustments, fmt.Sprintf("safe window starts at minute %d beyond video length %d, using whole video", r.Safe.StartMinute, durationMin))
+		r.Safe = GalleryPhaseWindow{StartMinute: 0, EndMinute: durationMin}
+	} else if r.Safe.EndMinute > durationMin {
+		r.Adjustments = append(r.Adjustments, fmt.Sprintf("safe window end clamped from %d to %d", r.Safe.EndMinute, durationMin))
+		r.Safe.EndMinute = durationMin
+	}
+
+	if r.Nsfw.StartMinute >= durationMin {
+		r.Adjustments = append(r.Adjustments, fmt.Sprintf("nsfw window starts at minute %d beyond video length %d, skipping", r.Nsfw.StartMinute, durationMin))
+		r.NsfwEnabled = false
+	} else if r.Nsfw.EndMinute > durationMin {
+		r.Adjustments = append(r.Adjustments, fmt.Sprintf("nsfw window end clamped from %d to %d", r.Nsfw.EndMinute, durationMin))
+		r.Nsfw.EndMinute = durationMin
+	}
+
+	return r
+}
@@ -0,0 +1,54 @@
+package use_cases
+
+import (
+	"log/slog"
+
+	"suekk-worker/infrastructure/gallery"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Gallery Service Config - แปลง GalleryHandlerConfig เป็น gallery.Config
+// gallery.Service ตัวเดียวใช้ร่วมกันทั้ง GalleryHandler และ TranscodeHandler
+// ดังนั้นค่าจาก GALLERY_* ต้องส่งเข้า service ที่นี่ ไม่ใช่ทำ pipeline แยก
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// GalleryServiceConfig สร้าง gallery.Config จาก GalleryHandlerConfig
+// field ที่ handler ไม่ได้ตั้ง (threshold, timeout ของ classifier) ใช้ค่าจาก gallery.DefaultConfig()
+func GalleryServiceConfig(config GalleryHandlerConfig) gallery.Config {
+	config = config.withDefaults(slog.Default().With("component", "gallery-service-config"))
+
+	cfg := gallery.DefaultConfig()
+
+	// Phase windows
+	phases := config.Phases
+	cfg.SafeStartMinute = phases.SafeWindow.StartMinute
+	cfg.SafeEndMinute = phases.SafeWindow.EndMinute
+	cfg.NsfwStartMinute = phases.NsfwWindow.StartMinute
+	cfg.NsfwEndMinute = phases.NsfwWindow.EndMinute
+	cfg.FramesPerMinute = phases.FramesPerMinute
+
+	// ข้ามช่วง intro/outro (สัดส่วนของความยาววิดีโอ)
+	cfg.SkipStartFraction = config.FrameSkip.StartFraction
+	cfg.SkipEndFraction = config.FrameSkip.EndFraction
+
+	// Classifier runtime (python/script path + device)
+	cfg.Classifier.PythonPath = config.Classifier.PythonPath
+	cfg.Classifier.ScriptPath = config.Classifier.ScriptPath
+	cfg.Classifier.Device = config.Classifier.Device
+
+	// Tier limits
+	limits := config.TierLimits
+	cfg.Classifier.MinSuperSafeImages = limits.MinSuperSafeImages
+	cfg.Classifier.MinSafeImages = limits.MinSafeImages
+	cfg.Classifier.MaxSafeImages = limits.MaxSafeImages
+	cfg.Classifier.MaxNsfwImages = limits.MaxNsfwImages
+
+	// classifier ล้มเหลว → ส่ง frames ทั้งหมดเข้า Manual Selection แทน gallery ว่าง
+	cfg.ReviewOnClassifyFailure = config.ReviewOnClassifyFailure
+
+	// ClassifyBatch batching (< 0 = ไม่แบ่ง batch)
+	cfg.Classifier.BatchSize = config.Classifier.BatchSize
+	cfg.Classifier.Concurrency = config.Classifier.Concurrency
+
+	return cfg
+}
@@ -0,0 +1,169 @@
+package use_cases
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Thumbnail - ดึง 1 frame จาก HLS เป็น thumbnail ของวิดีโอ (แยกจาก gallery)
+// ใช้ segment logic เดียวกับ gallery: parse playlist → หา segment → presign → ffmpeg
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// defaultThumbnailFraction ตำแหน่ง default ของ thumbnail (สัดส่วนของความยาววิดีโอ)
+const defaultThumbnailFraction = 0.25
+
+// thumbnail variant formats (ตรงกับ key ใน videos.thumbnail_variants ของ API)
+const (
+	thumbnailFormatWebP = "webp"
+	thumbnailFormatAVIF = "avif"
+)
+
+// thumbnailCacheControl ไม่ cache นาน - thumbnail สร้างใหม่ทับ path เดิมได้
+const thumbnailCacheControl = "public, max-age=3600"
+
+// validateThumbnailFraction ตรวจสอบว่าสัดส่วนอยู่ในช่วง (0, 1)
+func validateThumbnailFraction(f float64) error {
+	if f <= 0 || f >= 1 {
+		return fmt.Errorf("thumbnail fraction must be in (0, 1), got %g", f)
+	}
+	return nil
+}
+
+// thumbnailStoragePath path ของ thumbnail บน storage
+func thumbnailStoragePath(videoCode string) string {
+	return fmt.Sprintf("thumbnails/%s.jpg", videoCode)
+}
+
+// GenerateThumbnail ดึง frame ที่ timestampSeconds → upload thumbnails/<code>.jpg (+ webp/avif) → อัพเดท video
+// timestampSeconds <= 0 = เลือกอัตโนมัติที่ duration × ThumbnailFraction
+// ได้ frame แรกของ segment ที่ครอบ timestamp (เหมือน gallery - seek ใน segment ไม่แม่น)
+// คืน storage path ของ thumbnail
+func (h *GalleryHandler) GenerateThumbnail(ctx context.Context, videoID string, timestampSeconds float64) (string, error) {
+	if h.repository == nil {
+		return "", fmt.Errorf("generate thumbnail: video repository not configured")
+	}
+
+	source, err := h.repository.GetThumbnailSource(ctx, videoID)
+	if err != nil {
+		return "", fmt.Errorf("get video: %w", err)
+	}
+
+	hlsPath := source.HLSPath
+	if source.Quality != "" {
+		// master playlist ไม่มี segment - ใช้ variant ของ quality สูงสุด (path เดียวกับ gallery job)
+		hlsPath = fmt.Sprintf("hls/%s/%s/playlist.m3u8", source.Code, source.Quality)
+	}
+	if hlsPath == "" {
+		return "", fmt.Errorf("video %s has no HLS content", source.Code)
+	}
+
+	timestamp := timestampSeconds
+	if timestamp <= 0 {
+		timestamp = float64(source.Duration) * h.config.ThumbnailFraction
+	}
+
+	segments, err := h.parseHLSPlaylist(ctx, hlsPath)
+	if err != nil {
+		return "", fmt.Errorf("parse playlist: %w", err)
+	}
+	if len(segments) == 0 {
+		return "", fmt.Errorf("no segments found in playlist")
+	}
+
+	segment := h.findSegmentForTimestamp(segments, timestamp)
+	if segment == nil {
+		return "", fmt.Errorf("timestamp %.1fs is beyond end of video", timestamp)
+	}
+
+	localPath := filepath.Join(h.config.TempDir, fmt.Sprintf("thumbnail_%s.jpg", source.Code))
+	defer os.Remove(localPath)
+
+	if err := h.captureSegmentFrame(ctx, hlsPath, segment, localPath, timestamp-segment.startTime); err != nil {
+		return "", fmt.Errorf("capture frame: %w", err)
+	}
+
+	remotePath := thumbnailStoragePath(source.Code)
+	if h.config.TestMode {
+		h.logger.Info("TEST_MODE: skipping thumbnail upload", "local_path", localPath, "timestamp", timestamp)
+		return remotePath, nil
+	}
+
+	if err := h.storage.UploadWithOptions(ctx, remotePath, localPath, "image/jpeg", thumbnailCacheControl); err != nil {
+		return "", fmt.Errorf("upload thumbnail: %w", err)
+	}
+
+	variants := h.uploadThumbnailVariants(ctx, localPath, source.Code)
+
+	if err := h.repository.UpdateThumbnail(ctx, videoID, remotePath, variants); err != nil {
+		return "", fmt.Errorf("update video: %w", err)
+	}
+
+	h.logger.Info("thumbnail generated",
+		"video_id", videoID,
+		"video_code", source.Code,
+		"timestamp", timestamp,
+		"path", remotePath,
+		"variants", len(variants),
+	)
+	return remotePath, nil
+}
+
+// uploadThumbnailVariants แปลง JPEG เป็น WebP (และ AVIF ถ้าเปิด ThumbnailAVIF) แล้ว upload คู่กัน
+// คืน format → storage path ของ variant ที่สำเร็จ - ล้มเหลว = warn, ยังมี JPEG ให้ใช้
+func (h *GalleryHandler) uploadThumbnailVariants(ctx context.Context, jpegPath, videoCode string) map[string]string {
+	formats := []string{thumbnailFormatWebP}
+	if h.config.ThumbnailAVIF {
+		formats = append(formats, thumbnailFormatAVIF)
+	}
+
+	variants := make(map[string]string, len(formats))
+	for _, format := range formats {
+		localPath := strings.TrimSuffix(jpegPath, ".jpg") + "." + format
+		if err := convertThumbnail(ctx, jpegPath, localPath, format); err != nil {
+			h.logger.Warn("failed to convert thumbnail", "format", format, "error", err)
+			continue
+		}
+
+		remotePath := fmt.Sprintf("thumbnails/%s.%s", videoCode, format)
+		err := h.storage.UploadWithOptions(ctx, remotePath, localPath, "image/"+format, thumbnailCacheControl)
+		os.Remove(localPath)
+		if err != nil {
+			h.logger.Warn("failed to upload thumbnail variant", "path", remotePath, "error", err)
+			continue
+		}
+		variants[format] = remotePath
+	}
+	return variants
+}
+
+// convertThumbnail แปลง JPEG เป็น webp/avif ด้วย ffmpeg
+func convertThumbnail(ctx context.Context, inputPath, outputPath, format string) error {
+	var codecArgs []string
+	switch format {
+	case thumbnailFormatWebP:
+		codecArgs = []string{"-c:v", "libwebp", "-quality", "80"}
+	case thumbnailFormatAVIF:
+		// still-picture = ไฟล์ AVIF ภาพเดียว (ไม่ใช่ AV1 video stream)
+		codecArgs = []string{"-c:v", "libaom-av1", "-still-picture", "1", "-crf", "32", "-b:v", "0"}
+	default:
+		return fmt.Errorf("unsupported thumbnail format: %s", format)
+	}
+
+	args := append([]string{"-i", inputPath}, codecArgs...)
+	args = append(args, "-y", outputPath)
+
+	cmdCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	output, err := exec.CommandContext(cmdCtx, "ffmpeg", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("ffmpeg: %w, output: %s", err, string(output))
+	}
+	return nil
+}
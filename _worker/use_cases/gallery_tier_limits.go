@@ -0,0 +1,71 @@
+package use_cases
+
+import "fmt"
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Gallery Tier Limits - จำนวนภาพขั้นต่ำ/สูงสุดของแต่ละ tier
+// ส่งไปบันทึกพร้อม counts ใน DB เพื่อให้รู้ว่า counts มาจาก limits ชุดไหน
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// GalleryTierLimits จำนวนภาพต่อ tier (zero value = ใช้ค่า default)
+type GalleryTierLimits struct {
+	MinSuperSafeImages int // super_safe ขั้นต่ำ (Public SEO)
+	MinSafeImages      int // super_safe + safe รวมกันขั้นต่ำ
+	MaxSafeImages      int // safe สูงสุดที่เก็บ
+	MaxNsfwImages      int // nsfw สูงสุดที่เก็บ
+}
+
+// DefaultGalleryTierLimits ค่า default - ตรงกับค่าคงที่ใน infrastructure/transcoder/gallery_classified.go
+// (MinSuperSafeImages, MinSafeImages, MaxSafeImages, MaxNsfwImages) ที่ TranscodeHandler ใช้
+func DefaultGalleryTierLimits() GalleryTierLimits {
+	return GalleryTierLimits{
+		MinSuperSafeImages: 10,
+		MinSafeImages:      12,
+		MaxSafeImages:      10,
+		MaxNsfwImages:      20,
+	}
+}
+
+// withDefaults เติมค่า default ให้ field ที่ไม่ได้ตั้ง (zero value)
+func (l GalleryTierLimits) withDefaults() GalleryTierLimits {
+	def := DefaultGalleryTierLimits()
+	if l.MinSuperSafeImages == 0 {
+		l.MinSuperSafeImages = def.MinSuperSafeImages
+	}
+	if l.MinSafeImages == 0 {
+		l.MinSafeImages = def.MinSafeImages
+	}
+	if l.MaxSafeImages == 0 {
+		l.MaxSafeImages = def.MaxSafeImages
+	}
+	if l.MaxNsfwImages == 0 {
+		l.MaxNsfwImages = def.MaxNsfwImages
+	}
+	return l
+}
+
+// Validate ตรวจสอบว่า min ≤ max
+// MinSafeImages นับ super_safe + safe รวมกัน จึงต้องอยู่ระหว่าง
+// MinSuperSafeImages และ MinSuperSafeImages + MaxSafeImages (ไม่งั้นจะไม่มีทางถึงเป้า)
+func (l GalleryTierLimits) Validate() error {
+	if l.MinSuperSafeImages < 0 || l.MinSafeImages < 0 || l.MaxSafeImages < 0 || l.MaxNsfwImages < 0 {
+		return fmt.Errorf("image limits must be >= 0")
+	}
+	if l.MinSafeImages < l.MinSuperSafeImages {
+		return fmt.Errorf("min safe images (%d) must be >= min super_safe images (%d)", l.MinSafeImages, l.MinSuperSafeImages)
+	}
+	if l.MinSafeImages > l.MinSuperSafeImages+l.MaxSafeImages {
+		return fmt.Errorf("min safe images (%d) exceeds min super_safe (%d) + max safe (%d)", l.MinSafeImages, l.MinSuperSafeImages, l.MaxSafeImages)
+	}
+	return nil
+}
+
+// toPayload แปลงเป็น map สำหรับส่งไป API (gallery_limits)
+func (l GalleryTierLimits) toPayload() map[string]int {
+	return map[string]int{
+		"min_super_safe_images": l.MinSuperSafeImages,
+		"min_safe_images":       l.MinSafeImages,
+		"max_safe_images":       l.MaxSafeImages,
+		"max_nsfw_images":       l.MaxNsfwImages,
+	}
+}